@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// adoptOptions holds the flags of the adopt command.
+type adoptOptions struct {
+	list    bool
+	release bool
+	dryRun  bool
+}
+
+// NewAdoptCmd creates the adopt command.
+func NewAdoptCmd(deps *Deps) *cobra.Command {
+	opts := &adoptOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "adopt [chatmate names...]",
+		Short: "Bring existing prompt files under ChatMate management",
+		Long: `Adopt prompt files that are already in the prompts directory but were not
+installed by ChatMate, such as chatmodes you wrote yourself or copied from a
+colleague.
+
+📥 Adopting a file:
+• Validates it like a chatmate (safe filename, size limit, YAML frontmatter)
+• Records it as user-managed, with a checksum of its current content
+• Stops it from being reported as orphaned by 'chatmate validate'
+
+The files themselves are not changed. Without names, every prompt file that
+ChatMate neither ships nor already manages is adopted.`,
+		Example: `  # Preview which files would be adopted
+  chatmate adopt --dry-run
+
+  # Adopt all foreign prompt files
+  chatmate adopt
+
+  # Adopt specific files
+  chatmate adopt "My Agent" "Team Review"
+
+  # Show adopted files and whether they changed since
+  chatmate adopt --list
+
+  # Stop managing a file (the file is kept)
+  chatmate adopt --release "My Agent"`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.list && opts.release {
+				return fmt.Errorf("cannot use --list and --release together")
+			}
+			if opts.release && len(args) == 0 {
+				return fmt.Errorf("--release requires chatmate names")
+			}
+
+			adopter := app.Manager.Adopter()
+
+			switch {
+			case opts.list:
+				return printAdopted(adopter)
+			case opts.release:
+				_, err := adopter.Release(args)
+				return err
+			case opts.dryRun:
+				candidates, err := adopter.Candidates()
+				if err != nil {
+					return err
+				}
+				if len(candidates) == 0 {
+					output.Println("No prompt files to adopt")
+					return nil
+				}
+				output.Printf("Prompt files that would be adopted (%d):\n", len(candidates))
+				for _, filename := range candidates {
+					output.Printf("  📥 %s\n", filename)
+				}
+				return nil
+			}
+
+			adopted, err := adopter.Adopt(args)
+			if adopted > 0 {
+				output.Printf("\n✅ Adopted %d prompt file(s)\n", adopted)
+			}
+			return err
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&opts.list, "list", "l", false, "list adopted chatmates and whether they changed since")
+	cmd.Flags().BoolVar(&opts.release, "release", false, "stop managing the named chatmates without removing them")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "show which files would be adopted")
+
+	return cmd
+}
+
+// printAdopted lists the adopted files and whether they changed since.
+func printAdopted(adopter *manager.AdopterService) error {
+	adopted, err := adopter.Adopted()
+	if err != nil {
+		return err
+	}
+	if len(adopted) == 0 {
+		output.Println("No adopted chatmates")
+		return nil
+	}
+
+	output.Printf("Adopted chatmates (%d):\n", len(adopted))
+	for _, file := range adopted {
+		symbol := output.SymbolSuccess
+		if file.Status != manager.AdoptionUnchanged {
+			symbol = output.SymbolWarning
+		}
+		output.Printf("  %s %s (%s, adopted %s)\n", symbol, file.Filename, file.Status, output.FormatDate(file.RecordedAt))
+	}
+	return nil
+}
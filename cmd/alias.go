@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+// NewAliasCmd creates the alias command.
+func NewAliasCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Show and define shortcuts for chatmate commands",
+		Long: `Show the built-in command shortcuts and manage your own.
+
+⚡ Built-in Shortcuts:
+• chatmate ls: list
+• chatmate rm: uninstall
+• chatmate i, chatmate install: hire
+• chatmate up: update
+
+🔧 Your Aliases:
+'chatmate alias set <name> <command...>' defines a shortcut for a whole
+command line, stored in the configuration file (see 'chatmate config').
+Running 'chatmate <name> [args...]' runs the command with the extra
+arguments appended. An alias must be the first argument, and cannot
+replace a built-in command or shortcut or refer to another alias.`,
+		Example: `  # Show every shortcut
+  chatmate alias
+
+  # 'chatmate fix' installs the Solve Issue chatmate
+  chatmate alias set fix hire "Solve Issue"
+
+  # 'chatmate mine' lists installed chatmates with their provenance
+  chatmate alias set mine list --installed --long
+
+  # Remove an alias
+  chatmate alias unset fix`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			return listAliases(cmd, app)
+		}),
+	}
+
+	cmd.AddCommand(
+		newAliasListCmd(deps),
+		newAliasSetCmd(deps),
+		newAliasUnsetCmd(deps),
+	)
+
+	return cmd
+}
+
+// newAliasListCmd creates the alias list command.
+func newAliasListCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show the built-in shortcuts and your aliases",
+		Example: `  # Aliases for scripts
+  chatmate alias list --output json | jq -r '.aliases[].name'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			return listAliases(cmd, app)
+		}),
+	}
+}
+
+// newAliasSetCmd creates the alias set command.
+func newAliasSetCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <name> <command...>",
+		Short: "Define an alias for a command line",
+		Long: `Define an alias for a command line, replacing an alias of the same name.
+Arguments with spaces are kept together, so quote them as you would when
+running the command.`,
+		Example: `  # 'chatmate fix' installs the Solve Issue chatmate
+  chatmate alias set fix hire "Solve Issue"
+
+  # Flags after the name belong to the aliased command
+  chatmate alias set fresh sync --yes`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			name := args[0]
+			if command, ok := builtinCommand(cmd.Root(), name); ok {
+				return fmt.Errorf("%s is already the built-in command %q; choose another name", name, command)
+			}
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+			if err := file.SetAlias(name, args[1:]); err != nil {
+				return err
+			}
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ 'chatmate %s' now runs 'chatmate %s'\n", name, file.Aliases[name])
+			return nil
+		}),
+	}
+	// Everything after the name belongs to the aliased command
+	cmd.Flags().SetInterspersed(false)
+	return cmd
+}
+
+// newAliasUnsetCmd creates the alias unset command.
+func newAliasUnsetCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <name>",
+		Short: "Remove an alias",
+		Example: `  # Remove the fix alias
+  chatmate alias unset fix`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAliases,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+			if err := file.UnsetAlias(args[0]); err != nil {
+				return err
+			}
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ Removed alias %s from %s\n", args[0], path)
+			return nil
+		}),
+	}
+}
+
+// listAliases prints the built-in shortcuts and the user's aliases.
+func listAliases(cmd *cobra.Command, app *App) error {
+	file, path, err := readSettingsFile()
+	if err != nil {
+		return err
+	}
+	builtin := builtinAliases(cmd.Root())
+	aliases := file.ListAliases()
+
+	if app.Structured() {
+		return app.Write(map[string]any{"path": path, "builtin": builtin, "aliases": aliases}, "aliases")
+	}
+	output.Println("Built-in Shortcuts:")
+	for _, alias := range builtin {
+		output.Printf("  %-10s %s\n", alias.Name, alias.Command)
+	}
+	output.Println()
+	output.Printf("Your Aliases (%s):\n", path)
+	if len(aliases) == 0 {
+		output.Println("  (none; define one with 'chatmate alias set <name> <command...>')")
+	}
+	for _, alias := range aliases {
+		output.Printf("  %-10s %s\n", alias.Name, alias.Command)
+	}
+	return nil
+}
+
+// builtinAliases returns the aliases of the commands of root, sorted by name.
+func builtinAliases(root *cobra.Command) []settings.Alias {
+	var aliases []settings.Alias
+	for _, command := range root.Commands() {
+		for _, alias := range command.Aliases {
+			aliases = append(aliases, settings.Alias{Name: alias, Command: command.Name()})
+		}
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases
+}
+
+// builtinCommand returns the command of root that name runs, if it is the
+// name or a built-in alias of one.
+func builtinCommand(root *cobra.Command, name string) (string, bool) {
+	if name == "help" || strings.HasPrefix(name, "__") {
+		return name, true
+	}
+	for _, command := range root.Commands() {
+		if command.Name() == name || command.HasAlias(name) {
+			return command.Name(), true
+		}
+	}
+	return "", false
+}
+
+// expandAlias replaces a user-defined alias at the start of args with the
+// command line it stands for. Built-in commands always win over aliases, and
+// aliases are not expanded recursively.
+//
+// The configuration file is only read when the first argument is not a
+// built-in command, so everyday commands don't pay for it; a file that
+// cannot be read leaves args as they are, and the command reports it.
+//
+// Parameters:
+//   - root: The root command
+//   - args: The command-line arguments, without the program name
+//
+// Returns:
+//   - []string: The arguments to run
+func expandAlias(root *cobra.Command, args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args
+	}
+	if _, ok := builtinCommand(root, args[0]); ok {
+		return args
+	}
+	path, err := settings.Path()
+	if err != nil {
+		return args
+	}
+	file, _ := settings.Load(path)
+	if file == nil {
+		return args
+	}
+	expansion, err := file.ExpandAlias(args[0])
+	if err != nil {
+		output.Warnf("Ignoring %v in %s", err, path)
+		return args
+	}
+	if expansion == nil {
+		return args
+	}
+	return append(expansion, args[1:]...)
+}
+
+// completeAliases completes the name argument of alias unset.
+func completeAliases(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	file, _, err := readSettingsFile()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, alias := range file.ListAliases() {
+		names = append(names, alias.Name+"\t"+alias.Command)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+)
+
+// TestBuiltinAliases tests the built-in shortcuts of common commands
+func TestBuiltinAliases(t *testing.T) {
+	t.Parallel()
+
+	root := NewRootCmd(DefaultDeps())
+	for alias, name := range map[string]string{"ls": "list", "rm": "uninstall", "i": "hire", "install": "hire", "up": "update"} {
+		cmd, _, err := root.Find([]string{alias})
+		if err != nil || cmd.Name() != name {
+			t.Errorf("Expected %s to run %s, got %v, %v", alias, name, cmd.Name(), err)
+		}
+	}
+}
+
+// TestExpandAlias tests running user-defined aliases
+func TestExpandAlias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(settings.Env, path)
+	content := "aliases:\n  fix: hire \"Solve Issue\"\n  list: status\n  broken: hire \"Solve\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	root := NewRootCmd(DefaultDeps())
+	for _, tt := range []struct {
+		args, want []string
+	}{
+		{[]string{"fix", "--force"}, []string{"hire", "Solve Issue", "--force"}},
+		{[]string{"list"}, []string{"list"}},
+		{[]string{"--verbose", "fix"}, []string{"--verbose", "fix"}},
+		{[]string{"unknown"}, []string{"unknown"}},
+		{[]string{"broken"}, []string{"broken"}},
+		{nil, nil},
+	} {
+		if got := expandAlias(root, tt.args); !slices.Equal(got, tt.want) {
+			t.Errorf("expandAlias(%q) = %q; expected %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+// TestAliasSet tests defining and removing aliases with the alias command
+func TestAliasSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(settings.Env, path)
+	output.SetWriters(&bytes.Buffer{}, &bytes.Buffer{})
+	defer output.SetWriters(nil, nil)
+
+	run := func(args ...string) error {
+		root := NewRootCmd(DefaultDeps())
+		root.SetArgs(args)
+		return root.Execute()
+	}
+
+	if err := run("alias", "set", "mine", "list", "--installed", "--long"); err != nil {
+		t.Fatalf("alias set failed: %v", err)
+	}
+	if content, err := os.ReadFile(path); err != nil || !strings.Contains(string(content), "mine: list --installed --long") {
+		t.Errorf("Expected the alias in the config file, got %q, %v", content, err)
+	}
+	for _, name := range []string{"ls", "status"} {
+		if err := run("alias", "set", name, "list"); err == nil || !strings.Contains(err.Error(), "built-in") {
+			t.Errorf("Expected alias set %s to be rejected, got %v", name, err)
+		}
+	}
+	if err := run("alias", "unset", "mine"); err != nil {
+		t.Fatalf("alias unset failed: %v", err)
+	}
+	if err := run("alias", "unset", "mine"); err == nil {
+		t.Error("Expected removing a missing alias to fail")
+	}
+}
@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the global options shared by every command.
+type Config struct {
+	// Verbose enables diagnostic output (--verbose)
+	Verbose bool
+	// Quiet suppresses informational output (--quiet)
+	Quiet bool
+	// AssumeYes answers every confirmation prompt with yes (--yes)
+	AssumeYes bool
+	// Timeout bounds the whole command (--timeout); zero means no limit
+	Timeout time.Duration
+	// ReadOnly forbids every file write (--read-only), see files.SetReadOnly
+	ReadOnly bool
+	// DryRun reports the file changes of the command instead of making them
+	// (--dry-run), see manager.ChatMateManager.DryRun
+	DryRun bool
+	// Output is the format informational commands print (--output): text,
+	// json, or yaml
+	Output string
+	// Editor is the VS Code build or fork chatmates are installed for
+	// (--editor); empty to use manager.EditorEnv or detect it
+	Editor string
+	// PromptsDir is the prompts directory chatmates are managed in instead
+	// of the editor's (promptsDir setting); empty for the editor's
+	PromptsDir string
+	// ASCII selects ASCII markers instead of emoji (ascii setting); nil to
+	// detect what the terminal supports
+	ASCII *bool
+	// Locale is the locale of the dates and numbers in reports (locale
+	// setting); empty for the system locale. output.LocaleEnv overrides it
+	Locale string
+	// AutoBackup archives the prompts directory before destructive
+	// operations (autoBackup setting); nil for the manager's default
+	AutoBackup *bool
+	// BackupKeep is the number of archives of the prompts directory kept
+	// (backupKeep setting); nil for manager.DefaultBackupKeep
+	BackupKeep *int
+	// ScanLimit is the number of prompts directory entries scanned for
+	// chatmates (scanLimit setting); nil for manager.DefaultScanLimit
+	ScanLimit *int
+	// Concurrency is the number of chatmates installed at the same time
+	// (concurrency setting); nil for manager.DefaultConcurrency
+	Concurrency *int
+	// PreInstallHook and PostInstallHook are shell commands run around
+	// installations (preInstallHook and postInstallHook settings)
+	PreInstallHook  string
+	PostInstallHook string
+	// HookTimeout is how long a hook may run (hookTimeout setting); zero
+	// for manager.DefaultHookTimeout
+	HookTimeout time.Duration
+	// FileMode and DirMode are the permissions of the files and directories
+	// ChatMate creates (fileMode and dirMode settings); zero for
+	// manager.DefaultFileMode and manager.DefaultDirMode
+	FileMode fs.FileMode
+	DirMode  fs.FileMode
+	// ChatmateHooks runs the hooks chatmates declare (chatmateHooks
+	// setting); nil for the manager's default
+	ChatmateHooks *bool
+	// Sources are the configured sources of chatmates (sources setting,
+	// see 'chatmate source')
+	Sources []string
+
+	// settingsErr is why the configuration file could not be read; its
+	// settings are ignored then
+	settingsErr error
+}
+
+// Formats accepted by the global --output option.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+)
+
+// outputFormats are the formats accepted by the global --output option.
+var outputFormats = []string{OutputText, OutputJSON, OutputYAML}
+
+// dryRunCommands lists the commands that support the global --dry-run
+// option: all their writes go through the manager's filesystem, so they can
+// be recorded instead of made. Commands with a --dry-run option of their
+// own (e.g., sync and update) show their plan instead. Commands are looked
+// up by name, so "uninstall" covers self uninstall as well.
+var dryRunCommands = map[string]bool{
+	"hire":      true,
+	"import":    true,
+	"uninstall": true,
+	"validate":  true,
+}
+
+// readConfig reads the global options from the persistent flags of cmd.
+//
+// Options not given on the command line default to the settings of the
+// configuration file (see settings.Path). Environment variables that choose
+// the same thing, e.g. manager.EditorEnv or manager.PromptsDirEnv, take
+// precedence over the file. An
+// invalid file is ignored, so 'chatmate config' can still repair it.
+//
+// Parameters:
+//   - cmd: The command being executed; persistent flags of its parents are included
+//
+// Returns:
+//   - Config: The global options
+//   - error: Error if an option is invalid or the options contradict each other
+func readConfig(cmd *cobra.Command) (Config, error) {
+	var config Config
+	file, err := loadSettings()
+	if err != nil {
+		config.settingsErr = err
+		file = &settings.Settings{}
+	}
+
+	config.Verbose, _ = cmd.Flags().GetBool("verbose")
+	config.Quiet, _ = cmd.Flags().GetBool("quiet")
+	config.AssumeYes, _ = cmd.Flags().GetBool("yes")
+	if file.AssumeYes != nil && !cmd.Flags().Changed("yes") {
+		config.AssumeYes = *file.AssumeYes
+	}
+	config.Timeout, _ = cmd.Flags().GetDuration("timeout")
+	config.ReadOnly, _ = cmd.Flags().GetBool("read-only")
+	config.DryRun = globalDryRun(cmd)
+	config.Editor, _ = cmd.Flags().GetString("editor")
+	if !cmd.Flags().Changed("editor") && os.Getenv(manager.EditorEnv) == "" && os.Getenv(manager.PromptsDirEnv) == "" {
+		config.Editor = file.Editor
+		config.PromptsDir = file.ExpandedPromptsDir()
+	}
+	if os.Getenv(output.ASCIIEnv) == "" {
+		config.ASCII = file.ASCII
+	}
+	config.Locale = file.Locale
+	config.AutoBackup = file.AutoBackup
+	config.BackupKeep = file.BackupKeep
+	config.ScanLimit = file.ScanLimit
+	config.Concurrency = file.Concurrency
+	config.PreInstallHook = file.PreInstallHook
+	config.PostInstallHook = file.PostInstallHook
+	config.HookTimeout, _ = file.ParseHookTimeout()
+	config.FileMode, config.DirMode, _ = file.ParseModes()
+	config.ChatmateHooks = file.ChatmateHooks
+	config.Sources = file.Sources
+
+	// Commands with an --output option of their own (e.g., apply) hide the
+	// global one
+	config.Output = OutputText
+	if flag := cmd.Flags().Lookup("output"); flag != nil && cmd.LocalNonPersistentFlags().Lookup("output") == nil {
+		config.Output = flag.Value.String()
+		if !flag.Changed && file.Output != "" {
+			config.Output = file.Output
+		}
+	}
+	if !slices.Contains(outputFormats, config.Output) {
+		return Config{}, fmt.Errorf("unknown output format %q (available: %s)", config.Output, strings.Join(outputFormats, ", "))
+	}
+	// --json is kept as a shorthand by the commands that had it first
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		if config.Output == OutputYAML && cmd.Flags().Changed("output") {
+			return Config{}, fmt.Errorf("cannot use --json and --output yaml together")
+		}
+		config.Output = OutputJSON
+	}
+
+	if config.Verbose && config.Quiet {
+		return Config{}, fmt.Errorf("cannot use --quiet and --verbose together")
+	}
+	if config.Timeout < 0 {
+		return Config{}, fmt.Errorf("--timeout must not be negative")
+	}
+	if config.DryRun && config.ReadOnly {
+		return Config{}, fmt.Errorf("cannot use --dry-run and --read-only together")
+	}
+	return config, nil
+}
+
+// globalDryRun reports whether the global --dry-run option is set for cmd.
+// Commands with a --dry-run option of their own hide the global one.
+func globalDryRun(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("dry-run")
+	if flag == nil || cmd.LocalNonPersistentFlags().Lookup("dry-run") != nil {
+		return false
+	}
+	return flag.Value.String() == "true"
+}
+
+// loadSettings reads the configuration file.
+func loadSettings() (*settings.Settings, error) {
+	path, err := settings.Path()
+	if err != nil {
+		return nil, err
+	}
+	return settings.Load(path)
+}
+
+// App is the application container a command runs with.
+//
+// It is built once per execution by Deps.Run, so setup that every command
+// needs (reading the global options, creating the manager) and cross-cutting
+// features live in one place instead of being repeated in each RunE.
+// Informational and diagnostic messages go through the output package, which
+// Config has already been applied to.
+type App struct {
+	// Config holds the global options
+	Config Config
+	// Manager is the initialized ChatMate manager; its file operations are
+	// bound to Context
+	Manager *manager.ChatMateManager
+	// Stdout receives machine-readable output such as JSON reports
+	Stdout io.Writer
+	// Context is done when the command is interrupted (Ctrl-C) or exceeds
+	// --timeout; manager operations stop before their next chatmate
+	Context context.Context
+
+	cancel context.CancelFunc
+	// dryRun records the file changes of a dry run; nil otherwise
+	dryRun *files.DryRunFS
+}
+
+// Structured reports whether a structured document (JSON or YAML) was
+// requested instead of text.
+func (app *App) Structured() bool {
+	return app.Config.Output == OutputJSON || app.Config.Output == OutputYAML
+}
+
+// Write writes v to the app's standard output in the format selected with
+// --output: WriteYAML for yaml, WriteJSON otherwise.
+//
+// Example:
+//
+//	if app.Structured() {
+//		return app.Write(report, "validation report")
+//	}
+//
+// Parameters:
+//   - v: The value to encode; its JSON field names are used in every format
+//   - what: What v is, used in error messages (e.g., "validation report")
+//
+// Returns:
+//   - error: Error if v cannot be encoded or written
+func (app *App) Write(v any, what string) error {
+	if app.Config.Output == OutputYAML {
+		return app.WriteYAML(v, what)
+	}
+	return app.WriteJSON(v, what)
+}
+
+// JSONSchemaVersion is the version of the JSON documents ChatMate prints,
+// written as their first field, "schemaVersion".
+//
+// Fields may be added to a document without changing the version; it is
+// incremented when a field is removed, renamed, or changes its meaning, so
+// scripts can detect documents they do not understand.
+const JSONSchemaVersion = 1
+
+// WriteJSON writes v to the app's standard output as an indented JSON
+// document.
+//
+// A JSON object gets JSONSchemaVersion as its first field, "schemaVersion".
+// Fields keep the order of their struct declaration and map keys are sorted,
+// so the same data always produces the same output.
+//
+// Parameters:
+//   - v: The value to encode
+//   - what: What v is, used in error messages (e.g., "validation report")
+//
+// Returns:
+//   - error: Error if v cannot be encoded or written
+func (app *App) WriteJSON(v any, what string) error {
+	data, err := versionedJSON(v, what)
+	if err != nil {
+		return err
+	}
+	return app.writeIndented(data, what)
+}
+
+// WriteYAML writes v to the app's standard output as a YAML document.
+//
+// The document is the one WriteJSON writes, converted to YAML: it has the
+// same schemaVersion, field names, and field order.
+//
+// Parameters:
+//   - v: The value to encode
+//   - what: What v is, used in error messages (e.g., "validation report")
+//
+// Returns:
+//   - error: Error if v cannot be encoded or written
+func (app *App) WriteYAML(v any, what string) error {
+	data, err := versionedJSON(v, what)
+	if err != nil {
+		return err
+	}
+
+	// JSON is YAML, so decoding it into a node keeps the field order
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	if err := blockStyle(&document); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	if _, err := app.Stdout.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+	return nil
+}
+
+// versionedJSON encodes v as JSON with JSONSchemaVersion as the first field
+// of an object.
+func versionedJSON(v any, what string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	if len(data) > 1 && data[0] == '{' {
+		versioned := fmt.Appendf(nil, `{"schemaVersion":%d`, JSONSchemaVersion)
+		if data[1] != '}' {
+			versioned = append(versioned, ',')
+		}
+		data = append(versioned, data[1:]...)
+	}
+	return data, nil
+}
+
+// blockStyle replaces the JSON styles (flow collections, double-quoted
+// strings) of a decoded document, so it is written in the usual YAML block
+// style. Strings are quoted the way the YAML encoder quotes them, including
+// words such as "yes" that older YAML parsers read as booleans.
+func blockStyle(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		return node.Encode(node.Value)
+	}
+	node.Style = 0
+	for _, child := range node.Content {
+		if err := blockStyle(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteStandardJSON writes v to the app's standard output as indented JSON
+// without a schema version, for documents in a format defined elsewhere
+// (e.g., CycloneDX) that must not be extended.
+//
+// Parameters:
+//   - v: The value to encode
+//   - what: What v is, used in error messages (e.g., "CycloneDX inventory")
+//
+// Returns:
+//   - error: Error if v cannot be encoded or written
+func (app *App) WriteStandardJSON(v any, what string) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	return app.writeIndented(data, what)
+}
+
+// writeIndented writes encoded JSON to standard output, indented and
+// followed by a newline.
+func (app *App) writeIndented(data []byte, what string) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	buf.WriteByte('\n')
+	if _, err := app.Stdout.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", what, err)
+	}
+	return nil
+}
+
+// RunFunc is the body of a command that runs with an initialized App.
+type RunFunc func(cmd *cobra.Command, args []string, app *App) error
+
+// Run wraps a command body with the setup shared by all commands and returns
+// it as a cobra RunE function.
+//
+// Example:
+//
+//	RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+//		listing, err := app.Manager.Lister().Listing(true, false)
+//		if err != nil {
+//			return err
+//		}
+//		view.AvailableListing(listing)
+//		return nil
+//	}),
+//
+// Parameters:
+//   - run: The command body
+//
+// Returns:
+//   - func(*cobra.Command, []string) error: The RunE function for the command
+func (deps *Deps) Run(run RunFunc) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		start := time.Now()
+		app, err := deps.newApp(cmd)
+		if err != nil {
+			return err
+		}
+		defer app.cancel()
+
+		err = run(cmd, args, app)
+		if app.dryRun != nil && !app.Structured() {
+			view.DryRun(app.dryRun.Changes())
+		}
+		output.Tracef("⏱️  %s finished in %s\n", cmd.CommandPath(), time.Since(start).Round(time.Millisecond))
+
+		if err != nil && errors.Is(app.Context.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%s did not finish within --timeout %s: %w", cmd.CommandPath(), app.Config.Timeout, err)
+		}
+		return err
+	}
+}
+
+// newApp builds the App for an execution of cmd.
+func (deps *Deps) newApp(cmd *cobra.Command) (*App, error) {
+	config, err := readConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.DryRun && !dryRunCommands[cmd.Name()] {
+		return nil, fmt.Errorf("%s does not support --dry-run", cmd.CommandPath())
+	}
+	// A dry run leaves the state alone, even a corrupt file
+	if !config.DryRun {
+		recoverState()
+	}
+
+	chatMateManager, err := deps.NewManager()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ChatMate manager: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cancel := context.CancelFunc(func() {})
+	if config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+	}
+	if config.Editor != "" {
+		if err := chatMateManager.UseEditor(config.Editor); err != nil {
+			cancel()
+			return nil, fmt.Errorf("invalid --editor: %w", err)
+		}
+	}
+	if config.PromptsDir != "" {
+		if err := chatMateManager.UsePromptsDir(config.PromptsDir); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if config.AutoBackup != nil {
+		chatMateManager.AutoBackup = *config.AutoBackup
+	}
+	if config.BackupKeep != nil {
+		chatMateManager.BackupKeep = *config.BackupKeep
+	}
+	if config.ScanLimit != nil {
+		chatMateManager.ScanLimit = *config.ScanLimit
+	}
+	if config.Concurrency != nil {
+		chatMateManager.Concurrency = *config.Concurrency
+	}
+	chatMateManager.PreInstallHook = config.PreInstallHook
+	chatMateManager.PostInstallHook = config.PostInstallHook
+	if config.HookTimeout > 0 {
+		chatMateManager.HookTimeout = config.HookTimeout
+	}
+	chatMateManager.FileMode = config.FileMode
+	chatMateManager.DirMode = config.DirMode
+	if config.ChatmateHooks != nil {
+		chatMateManager.ChatmateHooks = *config.ChatmateHooks
+	}
+	if len(config.Sources) > 0 {
+		if err := chatMateManager.UseSources(config.Sources); err != nil {
+			output.Warnf("Ignoring invalid sources: %v", err)
+		}
+	}
+	var dryRun *files.DryRunFS
+	if config.DryRun {
+		dryRun = chatMateManager.DryRun()
+	} else {
+		enforceRetention(chatMateManager)
+	}
+	chatMateManager.FS.Context = ctx
+	chatMateManager.Version = version
+	chatMateManager.Progress = view.InstallResult
+
+	return &App{
+		Config:  config,
+		Manager: chatMateManager,
+		Stdout:  output.Stdout(),
+		Context: ctx,
+		cancel:  cancel,
+		dryRun:  dryRun,
+	}, nil
+}
+
+// enforceRetention removes the history entries, backups, and cached files
+// beyond the retention limits (see state.Retention), so the data directories
+// do not grow without bound. Failures never stop the command.
+func enforceRetention(chatMateManager *manager.ChatMateManager) {
+	pruned, err := chatMateManager.EnforceRetention()
+	if err != nil {
+		output.Debugf("Could not enforce the retention limits: %v\n", err)
+	}
+	if pruned != nil && !pruned.Empty() {
+		output.Debugf("Removed %d files and %d history entries beyond the retention limits, freeing %s\n",
+			len(pruned.Files), pruned.HistoryEntries, output.FormatSize(pruned.Bytes))
+	}
+}
+
+// recoverState starts in safe mode when a state file is corrupt: the file is
+// moved aside (see state.Recover) and the command continues with defaults,
+// so a bad edit or an interrupted write never makes ChatMate unusable.
+// Warnings tell the user where the content went and how to restore it.
+func recoverState() {
+	corrupt, err := state.Recover()
+	for _, file := range corrupt {
+		output.Warnf("Safe mode: %s could not be read (%v) and was moved to %s; continuing with defaults. "+
+			"Inspect or fix that file and move it back to %s to restore it.",
+			filepath.Base(file.Path), file.Err, file.Backup, file.Path)
+	}
+	if err != nil {
+		output.Debugf("Could not check state files: %v\n", err)
+	}
+}
@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+// TestDepsRun tests that the middleware builds the App before running the command body
+func TestDepsRun(t *testing.T) {
+	t.Parallel()
+
+	chatMateManager := &manager.ChatMateManager{PromptsDir: t.TempDir()}
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		return chatMateManager, nil
+	}}
+
+	var got *App
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("verbose", false, "")
+	cmd.Flags().Bool("quiet", false, "")
+	cmd.Flags().Bool("yes", false, "")
+	cmd.RunE = deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+		got = app
+		return nil
+	})
+
+	setFlags(t, cmd, "--verbose", "--yes")
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got == nil || got.Manager != chatMateManager || got.Stdout == nil {
+		t.Fatalf("Command should receive the initialized app, got %+v", got)
+	}
+	if !got.Config.Verbose || got.Config.Quiet || !got.Config.AssumeYes {
+		t.Errorf("Config should reflect the global flags, got %+v", got.Config)
+	}
+
+	// Contradicting options fail before the body runs
+	got = nil
+	setFlags(t, cmd, "--quiet")
+	if err := cmd.RunE(cmd, nil); err == nil || got != nil {
+		t.Error("Expected --quiet with --verbose to fail before running the command")
+	}
+}
+
+// TestDepsRunManagerError tests that manager failures are wrapped in one place
+func TestDepsRunManagerError(t *testing.T) {
+	t.Parallel()
+
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		return nil, errors.New("boom")
+	}}
+	run := deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+		t.Error("Command body should not run without a manager")
+		return nil
+	})
+
+	err := run(&cobra.Command{Use: "test"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to initialize ChatMate manager: boom") {
+		t.Errorf("Expected wrapped manager error, got %v", err)
+	}
+}
+
+// TestAppWriteJSON tests writing machine-readable output
+func TestAppWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	app := &App{Stdout: &buf}
+
+	if err := app.WriteJSON(map[string]int{"installed": 3}, "report"); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if buf.String() != "{\n  \"schemaVersion\": 1,\n  \"installed\": 3\n}\n" {
+		t.Errorf("Unexpected JSON output: %q", buf.String())
+	}
+
+	// Empty objects are versioned too; other values and standard formats are not
+	buf.Reset()
+	if err := app.WriteJSON(struct{}{}, "report"); err != nil || buf.String() != "{\n  \"schemaVersion\": 1\n}\n" {
+		t.Errorf("Unexpected JSON output for an empty object: %q, %v", buf.String(), err)
+	}
+	buf.Reset()
+	if err := app.WriteJSON([]int{1}, "report"); err != nil || buf.String() != "[\n  1\n]\n" {
+		t.Errorf("Unexpected JSON output for an array: %q, %v", buf.String(), err)
+	}
+	buf.Reset()
+	if err := app.WriteStandardJSON(map[string]int{"installed": 3}, "report"); err != nil || buf.String() != "{\n  \"installed\": 3\n}\n" {
+		t.Errorf("Unexpected standard JSON output: %q, %v", buf.String(), err)
+	}
+
+	if err := app.WriteJSON(make(chan int), "report"); err == nil || !strings.Contains(err.Error(), "failed to encode report") {
+		t.Errorf("Expected encode error, got %v", err)
+	}
+}
+
+// TestAppWriteYAML tests that YAML output mirrors the JSON document
+func TestAppWriteYAML(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	app := &App{Stdout: &buf, Config: Config{Output: OutputYAML}}
+
+	report := struct {
+		Version   string   `json:"version"`
+		Installed int      `json:"installed"`
+		Names     []string `json:"names"`
+		Missing   []string `json:"missing"`
+	}{"1.0", 2, []string{"Solve Issue", "yes"}, []string{}}
+	if err := app.Write(report, "report"); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	want := "schemaVersion: 1\nversion: \"1.0\"\ninstalled: 2\nnames:\n  - Solve Issue\n  - \"yes\"\nmissing: []\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected YAML output:\n%s", buf.String())
+	}
+}
+
+// TestReadConfigOutput tests selecting the output format
+func TestReadConfigOutput(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{[]string{"list"}, OutputText, false},
+		{[]string{"list", "--output", "yaml"}, OutputYAML, false},
+		{[]string{"list", "--json"}, OutputJSON, false},
+		{[]string{"list", "--json", "--output", "yaml"}, "", true},
+		{[]string{"config", "--output", "xml"}, "", true},
+		// apply has an --output option of its own
+		{[]string{"apply", "--output", "slack"}, OutputText, false},
+	} {
+		cmd, args, err := NewRootCmd(DefaultDeps()).Find(tt.args)
+		if err != nil {
+			t.Fatalf("Command %v not found: %v", tt.args, err)
+		}
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("Failed to parse %v: %v", tt.args, err)
+		}
+
+		config, err := readConfig(cmd)
+		if (err != nil) != tt.wantErr || config.Output != tt.want {
+			t.Errorf("%v: expected output %q (error: %t), got %q, %v", tt.args, tt.want, tt.wantErr, config.Output, err)
+		}
+	}
+}
+
+// TestReadConfigSettings tests that the configuration file provides defaults
+// for the options not given on the command line
+func TestReadConfigSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(settings.Env, path)
+	t.Setenv(manager.EditorEnv, "")
+	t.Setenv(manager.PromptsDirEnv, "")
+	t.Setenv(output.ASCIIEnv, "")
+	content := "promptsDir: /prompts\neditor: cursor\noutput: yaml\nascii: true\nassumeYes: true\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	readArgs := func(args ...string) (Config, error) {
+		t.Helper()
+		cmd, flags, err := NewRootCmd(DefaultDeps()).Find(args)
+		if err != nil {
+			t.Fatalf("Command %v not found: %v", args, err)
+		}
+		if err := cmd.ParseFlags(flags); err != nil {
+			t.Fatalf("Failed to parse %v: %v", args, err)
+		}
+		return readConfig(cmd)
+	}
+
+	config, err := readArgs("list")
+	if err != nil {
+		t.Fatalf("readConfig failed: %v", err)
+	}
+	if config.Output != OutputYAML || !config.AssumeYes || config.Editor != "cursor" ||
+		config.PromptsDir != "/prompts" || config.ASCII == nil || !*config.ASCII {
+		t.Errorf("Expected the settings of the file, got %+v", config)
+	}
+
+	config, err = readArgs("list", "--output", "text", "--yes=false", "--editor", "insiders")
+	if err != nil {
+		t.Fatalf("readConfig failed: %v", err)
+	}
+	if config.Output != OutputText || config.AssumeYes || config.Editor != "insiders" || config.PromptsDir != "" {
+		t.Errorf("Expected flags to take precedence over the file, got %+v", config)
+	}
+	if config, err := readArgs("list", "--json"); err != nil || config.Output != OutputJSON {
+		t.Errorf("Expected --json to take precedence over the file, got %q, %v", config.Output, err)
+	}
+
+	t.Setenv(manager.EditorEnv, "vscodium")
+	t.Setenv(output.ASCIIEnv, "0")
+	if config, _ := readArgs("list"); config.Editor != "" || config.PromptsDir != "" || config.ASCII != nil {
+		t.Errorf("Expected environment variables to take precedence over the file, got %+v", config)
+	}
+	t.Setenv(manager.EditorEnv, "")
+	t.Setenv(manager.PromptsDirEnv, "/ci/prompts")
+	if config, _ := readArgs("list"); config.Editor != "" || config.PromptsDir != "" {
+		t.Errorf("Expected %s to take precedence over the file, got %+v", manager.PromptsDirEnv, config)
+	}
+
+	if err := os.WriteFile(path, []byte("editor: [\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	config, err = readArgs("list")
+	if err != nil || config.settingsErr == nil || config.Output != OutputText {
+		t.Errorf("Expected an invalid file to be ignored, got %+v, %v", config, err)
+	}
+}
+
+// TestDepsRunTimeout tests that --timeout bounds the file operations of a command
+func TestDepsRunTimeout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		return &manager.ChatMateManager{PromptsDir: dir}, nil
+	}}
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Duration("timeout", 0, "")
+	cmd.RunE = deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+		<-app.Context.Done()
+		_, err := app.Manager.FS.Stat(app.Manager.PromptsDir)
+		return err
+	})
+
+	setFlags(t, cmd, "--timeout", "10ms")
+	err := cmd.RunE(cmd, nil)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "did not finish within --timeout 10ms") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+
+	setFlags(t, cmd, "--timeout", "-1s")
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "must not be negative") {
+		t.Errorf("Expected error for a negative timeout, got %v", err)
+	}
+}
+
+// TestDepsRunDryRun tests that --dry-run records the file changes of the
+// commands that support it and is refused by the others
+func TestDepsRunDryRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "Testing.chatmode.md")
+	if err := os.WriteFile(existing, []byte("installed"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		return &manager.ChatMateManager{PromptsDir: dir}, nil
+	}}
+
+	newCmd := func(name string) *cobra.Command {
+		root := &cobra.Command{Use: "chatmate"}
+		root.PersistentFlags().Bool("dry-run", false, "")
+		root.PersistentFlags().Bool("read-only", false, "")
+		cmd := &cobra.Command{Use: name}
+		root.AddCommand(cmd)
+		cmd.RunE = deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if err := app.Manager.FS.WriteFile(filepath.Join(dir, "New.chatmode.md"), []byte("new"), 0644); err != nil {
+				return err
+			}
+			return app.Manager.FS.Remove(existing)
+		})
+		return cmd
+	}
+
+	cmd := newCmd("uninstall")
+	setFlags(t, cmd, "--dry-run")
+	if err := cmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Errorf("A dry run should not remove files: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "New.chatmode.md")); !os.IsNotExist(err) {
+		t.Errorf("A dry run should not create files, got %v", err)
+	}
+
+	cmd = newCmd("status")
+	setFlags(t, cmd, "--dry-run")
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "does not support --dry-run") {
+		t.Errorf("Expected status to refuse --dry-run, got %v", err)
+	}
+
+	cmd = newCmd("hire")
+	setFlags(t, cmd, "--dry-run", "--read-only")
+	if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "--dry-run and --read-only") {
+		t.Errorf("Expected --dry-run with --read-only to fail, got %v", err)
+	}
+}
+
+// TestExitCode tests the exit codes and hints for the kinds of errors
+func TestExitCode(t *testing.T) {
+	var stderr bytes.Buffer
+	output.SetWriters(&bytes.Buffer{}, &stderr)
+	defer output.SetWriters(nil, nil)
+
+	for _, tc := range []struct {
+		err    error
+		code   int
+		remedy string
+	}{
+		{nil, 0, ""},
+		{errors.New("boom"), ExitFailure, ""},
+		{fmt.Errorf("uninstall: %w", manager.ErrChatmateNotFound), ExitNotFound, "chatmate list"},
+		{fmt.Errorf("%w: 1 of 3 checks failed", manager.ErrValidationFailed), ExitValidationFailed, "chatmate validate"},
+		{&fs.PathError{Op: "open", Path: "prompts", Err: fs.ErrPermission}, ExitPermissionDenied, "permissions"},
+		{fmt.Errorf("wrapped: %w", manager.ErrPromptsDirMissing), ExitPromptsDirMissing, "promptsDir"},
+	} {
+		if code := ExitCode(tc.err); code != tc.code {
+			t.Errorf("ExitCode(%v) = %d, expected %d", tc.err, code, tc.code)
+		}
+		stderr.Reset()
+		if tc.err != nil {
+			printRemedy(tc.err)
+		}
+		if tc.remedy == "" && stderr.Len() != 0 || !strings.Contains(stderr.String(), tc.remedy) {
+			t.Errorf("Unexpected hint for %v: %q", tc.err, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/notify"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+// applyOptions holds the flags of the apply command.
+type applyOptions struct {
+	output string
+}
+
+// applyOutputs are the formats accepted by apply --output and sync --output,
+// which replace the global --output option.
+var applyOutputs = []string{OutputText, OutputJSON, OutputYAML, notify.FormatSlack, notify.FormatTeams}
+
+// NewApplyCmd creates the apply command.
+func NewApplyCmd(deps *Deps) *cobra.Command {
+	opts := &applyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Install the chatmates required by the team policy",
+		Long: `Make this machine meet the team policy by installing the required chatmates
+that are missing.
+
+The team policy is a YAML file that lists the chatmates every team member
+must have installed:
+
+  required:
+    - Review PR
+    - Testing
+
+It is read from policy.yaml in the ChatMate config directory (see
+'chatmate config'), or from the file named by the ` + policy.Env + ` environment
+variable, so a team lead can distribute it with the team's dotfiles or from
+a shared drive.
+
+The policy can also pin chatmates to a range of versions, which 'chatmate
+update' and 'chatmate sync' respect (see 'chatmate outdated'):
+
+  pins:
+    Review PR: ^1.2
+
+Required chatmates that are already installed are left alone. Use
+'chatmate status --check' to check the policy without installing anything.
+
+If the policy sets a webhook URL, a JSON report of the outcome (hostname,
+installed chatmates, failures) is POSTed to it after every apply, so platform
+teams can observe a rollout centrally.
+
+Use --output slack or --output teams to print only a compact Markdown summary
+of the outcome, ready to be posted to a chat channel to announce the update,
+and --output json or yaml to print only the report the webhook receives.`,
+		Example: `  # Install the missing required chatmates
+  chatmate apply
+
+  # Apply a policy from the team repository without prompting
+  CHATMATE_POLICY=./team/chatmate-policy.yaml chatmate apply --yes
+
+  # Announce the outcome in a Slack channel
+  chatmate apply --yes --output slack | slack-post '#dev-tools'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if !slices.Contains(applyOutputs, opts.output) {
+				return fmt.Errorf("unknown output format %q (available: %s)", opts.output, strings.Join(applyOutputs, ", "))
+			}
+			summary := opts.output != OutputText
+			if summary {
+				// Standard output only carries the summary
+				output.SetLevel(output.LevelQuiet)
+			}
+
+			if err := checkEditor(app.Manager, false); err != nil {
+				return err
+			}
+			if err := migrateLegacyNames(app); err != nil {
+				return err
+			}
+			compliance, err := app.Manager.Installer().ApplyPolicy(app.Context)
+			if compliance == nil {
+				return err
+			}
+
+			report := applyReport(app, compliance, err)
+			notifyWebhook(app, report)
+			if summary {
+				if writeErr := writeSummary(app, report, opts.output); writeErr != nil && err == nil {
+					return writeErr
+				}
+			}
+			return err
+		}),
+	}
+
+	cmd.Flags().StringVar(&opts.output, "output", "text",
+		"output format: text, json or yaml for a summary document, or slack or teams for a chat-ready Markdown summary")
+
+	return cmd
+}
+
+// applyReport describes the outcome of an apply for the team policy webhook
+// and chat summaries.
+func applyReport(app *App, compliance *manager.Compliance, applyErr error) *notify.Report {
+	report := newReport(app, "apply", applyErr)
+	for _, filename := range compliance.Applied {
+		report.Changes = append(report.Changes, notify.Change{Chatmate: manager.DisplayName(filename), Action: "installed"})
+	}
+	for _, failure := range compliance.Failed {
+		report.Failures = append(report.Failures, notify.Failure{Chatmate: manager.DisplayName(failure.Filename), Error: failure.Err.Error()})
+	}
+	for _, name := range compliance.Unknown {
+		report.Failures = append(report.Failures, notify.Failure{Chatmate: name, Error: "not available to install"})
+	}
+	return report
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/internal/authoring"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewAuthoringCmd creates the authoring command with its subcommands.
+func NewAuthoringCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authoring",
+		Short: "Set up your editor for writing chatmates",
+		Long: `Make hand-authoring chatmates faster and safer in VS Code.
+
+Use the subcommands to install editor support for writing your own
+.chatmode.md files.`,
+		Example: `  # Install snippets and frontmatter validation
+  chatmate authoring setup`,
+	}
+
+	cmd.AddCommand(newAuthoringSetupCmd(deps))
+
+	return cmd
+}
+
+// newAuthoringSetupCmd creates the authoring setup command.
+func newAuthoringSetupCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Install chatmate snippets and frontmatter validation into VS Code",
+		Long: `Install editor support for writing chatmates into your VS Code user settings.
+
+✍️  What Gets Installed:
+• Snippets (snippets/` + authoring.SnippetsFilename + `): type "chatmate" in an empty
+  .chatmode.md file for the frontmatter and outline of a new chatmate, or
+  "example" for an entry of the examples: list
+• The chatmode frontmatter JSON Schema (chatmate/` + authoring.SchemaFilename + `)
+• A "yaml.schemas" association of the schema with ` + authoring.ChatmodePattern + ` files,
+  used by the YAML extension (redhat.vscode-yaml) to complete and validate
+  the frontmatter as you type
+
+Running setup again updates the snippets and schema to the current version.
+An existing "yaml.schemas" setting is not merged; the entry to add by hand is
+printed instead.`,
+		Example: `  # Install authoring support
+  chatmate authoring setup`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			settingsPath := app.Manager.SettingsPath()
+			if settingsPath == "" {
+				return fmt.Errorf("authoring setup installs into VS Code, which is not used in headless mode; run it on the machine where you write chatmates")
+			}
+
+			result, err := authoring.Setup(settingsPath)
+			if err != nil {
+				return err
+			}
+
+			output.Printf("✍️  Snippets: %s\n", result.SnippetsPath)
+			output.Printf("📐 Schema:   %s\n", result.SchemaPath)
+			if result.SettingsErr != nil {
+				output.Warnf("Could not associate the schema with %s files: %v", authoring.ChatmodePattern, result.SettingsErr)
+			} else {
+				output.Printf("⚙️  Settings: %s\n", result.SettingsPath)
+			}
+
+			if len(result.Changed) == 0 {
+				output.Println("\n✅ Authoring support is already up to date")
+				return nil
+			}
+			output.Printf("\n✅ Authoring support installed (%d file(s) updated)\n", len(result.Changed))
+			output.Println("   Open a .chatmode.md file and type \"chatmate\" to start a new chatmate.")
+			output.Println("   Frontmatter validation needs the YAML extension (redhat.vscode-yaml).")
+			return nil
+		}),
+	}
+
+	return cmd
+}
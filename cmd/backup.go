@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// backupOptions holds the flags of the backup command.
+type backupOptions struct {
+	list bool
+}
+
+// NewBackupCmd creates the backup command.
+func NewBackupCmd(deps *Deps) *cobra.Command {
+	opts := &backupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Archive the prompts directory",
+		Long: `Archive every file in the prompts directory into a timestamped tar.gz in
+ChatMate's state directory, so it can be brought back with 'chatmate restore'.
+
+ChatMate also makes a backup on its own before destructive operations:
+'uninstall --all', 'hire --force' and 'update --force' when they replace
+installed chatmates, and 'restore' itself. Disable this with
+'chatmate config set autoBackup false'.
+
+Only the newest backups are kept: 10 unless the backupKeep setting chooses
+another number ('chatmate config set backupKeep 20'; 0 keeps all of them).
+Use --list to see the backups.`,
+		Example: `  # Back up the prompts directory
+  chatmate backup
+
+  # List the backups, newest first
+  chatmate backup --list
+
+  # Path of the newest backup, for scripts
+  chatmate backup --list --output json | jq -r '.[0].path'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.list {
+				backups, err := app.Manager.Backups()
+				if err != nil {
+					return err
+				}
+				if app.Structured() {
+					return app.Write(backups, "backups")
+				}
+				view.Backups(backups)
+				return nil
+			}
+
+			report, err := app.Manager.CreateBackup("")
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(report, "backup")
+			}
+			view.BackupCreated(report)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.list, "list", false, "list the backups instead of making one")
+
+	return cmd
+}
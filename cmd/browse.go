@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+// browseOptions holds the flags of the browse command.
+type browseOptions struct {
+	refresh bool
+}
+
+// NewBrowseCmd creates the browse command.
+func NewBrowseCmd(deps *Deps) *cobra.Command {
+	opts := &browseOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "browse [search term]",
+		Short: "Browse chatmates, including community chatmates from the registry",
+		Long: `Browse every chatmate you can install: the chatmates shipped with ChatMate
+and the community chatmates published in the chatmate registry.
+
+🌐 The Registry:
+• A JSON index of community chatmates, served over HTTPS
+• Defaults to the ChatMate repository on GitHub; set ` + registry.Env + `
+  to use another registry, such as a company-internal mirror
+• The index is cached for an hour and used offline when the registry
+  cannot be reached; use --refresh to fetch it now
+
+Registry chatmates are marked [registry]. Install them with
+'chatmate hire --from-registry <name>'; every download is verified against
+the checksum listed in the registry.`,
+		Example: `  # Browse all chatmates
+  chatmate browse
+
+  # Find chatmates about Rust
+  chatmate browse rust
+
+  # Fetch the latest registry index
+  chatmate browse --refresh
+
+  # Install a community chatmate
+  chatmate hire --from-registry "Rust Reviewer"`,
+		Args: cobra.ArbitraryArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			entries, err := app.Manager.Lister().Browse(app.Context, strings.Join(args, " "), opts.refresh)
+			if err != nil {
+				return err
+			}
+			registryURL := ""
+			if app.Manager.Registry != nil {
+				registryURL = app.Manager.Registry.URL
+			}
+			view.Catalog(entries, registryURL)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.refresh, "refresh", false,
+		"Fetch the registry index even if the cached copy is recent")
+
+	return cmd
+}
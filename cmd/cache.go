@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewCacheCmd creates the cache command with its subcommands.
+func NewCacheCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect the disk space used by ChatMate's history, backups, versions, and cache",
+		Long: `Inspect the files ChatMate keeps in its state and cache directories.
+
+The operation history, backups of corrupt state files, previous versions of
+replaced chatmates, and cached results are pruned automatically whenever
+ChatMate runs, so they never grow without bound: operations, backups, and
+versions older than the maximum age are removed, only the newest backups of
+each state file are kept, and while the directories are larger than the
+maximum size, cached files, then the oldest versions, and then the oldest
+backups are removed. Set other limits with 'retention:' in the team policy.`,
+		Example: `  # Show the disk usage and the retention limits
+  chatmate cache info`,
+	}
+
+	cmd.AddCommand(newCacheInfoCmd(deps))
+
+	return cmd
+}
+
+// newCacheInfoCmd creates the cache info command.
+func newCacheInfoCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show the disk space used by ChatMate and the retention limits",
+		Long: `Report the disk space used by the operation history, the backups of corrupt
+state files, the previous versions of replaced chatmates, the other state
+files, and the cache, together with the retention limits ChatMate enforces
+on them.
+
+📏 Default limits (override them with 'retention:' in the team policy):
+• maxSize 50 MiB for the state and cache directories together
+• maxAge 90 days for operations in the history, backups, and versions
+• maxVersions 3 backups of each state file`,
+		Example: `  # Show the disk usage
+  chatmate cache info
+
+  # Machine-readable output
+  chatmate cache info --output json`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			info, err := app.Manager.CacheInfo()
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(info, "cache info")
+			}
+
+			output.Println("=== ChatMate Data ===")
+			output.Printf("State Directory: %s\n", info.StateDir)
+			output.Printf("Cache Directory: %s\n", info.CacheDir)
+			output.Println()
+			for _, area := range info.Areas {
+				detail := output.FormatNumber(area.Files) + " file(s)"
+				if area.Name == "history" {
+					detail = output.FormatNumber(area.Entries) + " operation(s)"
+				}
+				output.Printf("  %-8s %10s  %s\n", area.Name, output.FormatSize(area.Bytes), detail)
+			}
+			output.Printf("  %-8s %10s\n", "total", output.FormatSize(info.Total))
+			output.Println()
+
+			maxSize := "none"
+			if info.Limits.MaxSize > 0 {
+				maxSize = output.FormatSize(info.Limits.MaxSize)
+			}
+			output.Printf("Retention (%s): max size %s, max age %s, max versions %d\n",
+				info.Limits.Source, maxSize, info.Limits.MaxAge, info.Limits.MaxVersions)
+			if info.OverQuota {
+				output.Warnf("ChatMate uses more than %s, but only history, backups, versions, and cached files are removed automatically", maxSize)
+			}
+			return nil
+		}),
+	}
+
+	return cmd
+}
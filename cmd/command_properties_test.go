@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"os"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -9,6 +8,8 @@ import (
 
 // TestTutorialCommand tests tutorial command functionality
 func TestTutorialCommand(t *testing.T) {
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Find tutorial command
 	var tutorialCmd *cobra.Command
 	commands := rootCmd.Commands()
@@ -30,10 +31,7 @@ func TestTutorialCommand(t *testing.T) {
 
 	// Test execution if RunE exists
 	if tutorialCmd.RunE != nil {
-		// Capture output
-		old := os.Stdout
-		os.Stdout = os.NewFile(0, os.DevNull)
-		defer func() { os.Stdout = old }()
+		discardStdout(t)
 
 		err := tutorialCmd.RunE(tutorialCmd, []string{})
 		if err != nil {
@@ -44,6 +42,10 @@ func TestTutorialCommand(t *testing.T) {
 
 // TestVersionCommand tests version command functionality
 func TestVersionCommand(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Version command should be automatically generated by Cobra
 	commands := rootCmd.Commands()
 	found := false
@@ -61,6 +63,10 @@ func TestVersionCommand(t *testing.T) {
 
 // TestCompletionCommand tests completion command functionality
 func TestCompletionCommand(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Find completion command
 	var completionCmd *cobra.Command
 	commands := rootCmd.Commands()
@@ -97,6 +103,10 @@ func TestCompletionCommand(t *testing.T) {
 
 // TestGlobalFlags tests global flag functionality
 func TestGlobalFlags(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Test verbose flag
 	verboseFlag := rootCmd.PersistentFlags().Lookup("verbose")
 	if verboseFlag == nil {
@@ -115,6 +125,15 @@ func TestGlobalFlags(t *testing.T) {
 
 // TestCommandHelp tests help functionality for all commands
 func TestCommandHelp(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+	hireCmd := newTestCommand(t, "hire")
+	listCmd := newTestCommand(t, "list")
+	statusCmd := newTestCommand(t, "status")
+	configCmd := newTestCommand(t, "config")
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	commands := []*cobra.Command{rootCmd, hireCmd, listCmd, statusCmd, configCmd, uninstallCmd}
 
 	for _, cmd := range commands {
@@ -144,13 +163,21 @@ func TestCommandHelp(t *testing.T) {
 
 // TestCommandFlags tests that all commands have proper flag definitions
 func TestCommandFlags(t *testing.T) {
+	t.Parallel()
+
+	hireCmd := newTestCommand(t, "hire")
+	listCmd := newTestCommand(t, "list")
+	statusCmd := newTestCommand(t, "status")
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	testCases := []struct {
 		cmd           *cobra.Command
 		name          string
 		expectedFlags []string
 	}{
 		{hireCmd, "hire", []string{"force", "specific"}},
-		{listCmd, "list", []string{"available", "installed"}},
+		{listCmd, "list", []string{"available", "installed", "no-cache"}},
+		{statusCmd, "status", []string{"no-cache"}},
 		{uninstallCmd, "uninstall", []string{"all"}},
 	}
 
@@ -1,14 +1,25 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
 	"github.com/spf13/cobra"
 )
 
 // TestUninstallCommandExists tests that the uninstall command is properly defined
 func TestUninstallCommandExists(t *testing.T) {
+	t.Parallel()
+
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	if uninstallCmd == nil {
 		t.Fatal("uninstall command is not defined")
 	}
@@ -24,6 +35,10 @@ func TestUninstallCommandExists(t *testing.T) {
 
 // TestUninstallCommandFlags tests that required flags are defined
 func TestUninstallCommandFlags(t *testing.T) {
+	t.Parallel()
+
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	// Test that all flag exists
 	allFlag := uninstallCmd.Flags().Lookup("all")
 	if allFlag == nil {
@@ -53,13 +68,10 @@ func TestUninstallCommandExecution(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Capture output to prevent test noise
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
-			// Reset flags
-			uninstallAll = false
+			// Fresh command with default flags
+			uninstallCmd := newTestCommand(t, "uninstall")
 
 			// Execute command
 			err := uninstallCmd.RunE(uninstallCmd, tc.args)
@@ -72,6 +84,10 @@ func TestUninstallCommandExecution(t *testing.T) {
 
 // TestStatusCommandExists tests that the status command is properly defined
 func TestStatusCommandExists(t *testing.T) {
+	t.Parallel()
+
+	statusCmd := newTestCommand(t, "status")
+
 	if statusCmd == nil {
 		t.Fatal("status command is not defined")
 	}
@@ -87,10 +103,9 @@ func TestStatusCommandExists(t *testing.T) {
 
 // TestStatusCommandExecution tests the actual execution of the status command
 func TestStatusCommandExecution(t *testing.T) {
-	// Capture output to prevent test noise
-	old := os.Stdout
-	os.Stdout = os.NewFile(0, os.DevNull)
-	defer func() { os.Stdout = old }()
+	statusCmd := newTestCommand(t, "status")
+
+	discardStdout(t)
 
 	// Execute status command
 	err := statusCmd.RunE(statusCmd, []string{})
@@ -99,8 +114,41 @@ func TestStatusCommandExecution(t *testing.T) {
 	}
 }
 
+// TestParseSince tests the accepted formats of status --since
+func TestParseSince(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"2025-09-01", time.Date(2025, 9, 1, 0, 0, 0, 0, time.Local)},
+		{"2025-09-01T08:30:00Z", time.Date(2025, 9, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if err != nil || !got.Equal(tt.expected) {
+			t.Errorf("parseSince(%q) = %v, %v; expected %v", tt.value, got, err, tt.expected)
+		}
+	}
+
+	for _, value := range []string{"yesterday", "-1h", "-3d", "2025-13-01"} {
+		if _, err := parseSince(value, now); err == nil || !strings.Contains(err.Error(), "invalid --since value") {
+			t.Errorf("Expected error for %q, got %v", value, err)
+		}
+	}
+}
+
 // TestConfigCommandExists tests that the config command is properly defined
 func TestConfigCommandExists(t *testing.T) {
+	t.Parallel()
+
+	configCmd := newTestCommand(t, "config")
+
 	if configCmd == nil {
 		t.Fatal("config command is not defined")
 	}
@@ -116,10 +164,9 @@ func TestConfigCommandExists(t *testing.T) {
 
 // TestConfigCommandExecution tests the actual execution of the config command
 func TestConfigCommandExecution(t *testing.T) {
-	// Capture output to prevent test noise
-	old := os.Stdout
-	os.Stdout = os.NewFile(0, os.DevNull)
-	defer func() { os.Stdout = old }()
+	configCmd := newTestCommand(t, "config")
+
+	discardStdout(t)
 
 	// Execute config command
 	err := configCmd.RunE(configCmd, []string{})
@@ -130,6 +177,10 @@ func TestConfigCommandExecution(t *testing.T) {
 
 // TestVersionCommandExists tests that the version command exists
 func TestVersionCommandExists(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Version command is added automatically by Cobra when rootCmd.Version is set
 	commands := rootCmd.Commands()
 	found := false
@@ -146,6 +197,10 @@ func TestVersionCommandExists(t *testing.T) {
 
 // TestCompletionCommandExists tests that the completion command exists
 func TestCompletionCommandExists(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Completion command should exist
 	commands := rootCmd.Commands()
 	found := false
@@ -162,6 +217,10 @@ func TestCompletionCommandExists(t *testing.T) {
 
 // TestTutorialCommandExists tests that the tutorial command exists
 func TestTutorialCommandExists(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Tutorial command should exist
 	commands := rootCmd.Commands()
 	found := false
@@ -178,6 +237,14 @@ func TestTutorialCommandExists(t *testing.T) {
 
 // TestAllCommandsHaveRunE tests that all main commands have execution functions
 func TestAllCommandsHaveRunE(t *testing.T) {
+	t.Parallel()
+
+	hireCmd := newTestCommand(t, "hire")
+	listCmd := newTestCommand(t, "list")
+	statusCmd := newTestCommand(t, "status")
+	configCmd := newTestCommand(t, "config")
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	commandsToTest := []*cobra.Command{
 		hireCmd,
 		listCmd,
@@ -201,6 +268,14 @@ func TestAllCommandsHaveRunE(t *testing.T) {
 
 // TestCommandHelpText tests that all commands have proper help text
 func TestCommandHelpText(t *testing.T) {
+	t.Parallel()
+
+	hireCmd := newTestCommand(t, "hire")
+	listCmd := newTestCommand(t, "list")
+	statusCmd := newTestCommand(t, "status")
+	configCmd := newTestCommand(t, "config")
+	uninstallCmd := newTestCommand(t, "uninstall")
+
 	commandsToTest := []*cobra.Command{
 		hireCmd,
 		listCmd,
@@ -225,3 +300,298 @@ func TestCommandHelpText(t *testing.T) {
 		})
 	}
 }
+
+// TestShowCommandExists tests that the show command is properly defined
+func TestShowCommandExists(t *testing.T) {
+	t.Parallel()
+
+	showCmd := newTestCommand(t, "show")
+
+	if showCmd == nil {
+		t.Fatal("show command is not defined")
+	}
+
+	if showCmd.Use != "show <chatmate name>" {
+		t.Errorf("Unexpected show command use: %s", showCmd.Use)
+	}
+
+	if showCmd.Flags().Lookup("raw") == nil {
+		t.Error("show command missing --raw flag")
+	}
+}
+
+// TestRenderCommand tests writing the content of a chatmate to stdout and
+// to a file
+func TestRenderCommand(t *testing.T) {
+	renderCmd := newTestCommand(t, "render")
+
+	if renderCmd.Use != "render <chatmate name>" {
+		t.Errorf("Unexpected render command use: %s", renderCmd.Use)
+	}
+
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	t.Setenv(manager.PromptsDirEnv, promptsDir)
+
+	out := captureOutput(func() {
+		if err := renderCmd.RunE(renderCmd, []string{"Solve Issue"}); err != nil {
+			t.Errorf("render failed: %v", err)
+		}
+	})
+	if !strings.HasPrefix(out, "---") || !strings.Contains(out, "Solve Issue") {
+		t.Errorf("Expected the chatmode file content, got:\n%s", out)
+	}
+
+	file := filepath.Join(t.TempDir(), "solve-issue.chatmode.md")
+	setFlags(t, renderCmd, "--output", file)
+	captureOutput(func() {
+		if err := renderCmd.RunE(renderCmd, []string{"Solve Issue"}); err != nil {
+			t.Errorf("render --output failed: %v", err)
+		}
+	})
+	written, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read rendered file: %v", err)
+	}
+	if string(written) != out {
+		t.Error("The file should contain what render prints to stdout")
+	}
+
+	if _, err := os.Stat(promptsDir); !os.IsNotExist(err) {
+		t.Error("render must not install the chatmate")
+	}
+}
+
+// TestValidateCommandExists tests that the validate command is properly defined
+func TestValidateCommandExists(t *testing.T) {
+	t.Parallel()
+
+	validateCmd := newTestCommand(t, "validate")
+
+	if validateCmd == nil {
+		t.Fatal("validate command is not defined")
+	}
+
+	if validateCmd.Use != "validate" {
+		t.Errorf("Unexpected validate command use: %s", validateCmd.Use)
+	}
+
+	if validateCmd.Flags().Lookup("json") == nil {
+		t.Error("validate command missing --json flag")
+	}
+
+	if validateCmd.Flags().Lookup("write-probe") == nil {
+		t.Error("validate command missing --write-probe flag")
+	}
+
+	if validateCmd.Flags().Lookup("clean-sync-conflicts") == nil {
+		t.Error("validate command missing --clean-sync-conflicts flag")
+	}
+}
+
+// TestGenerateShimCommand tests writing the hire.sh compatibility wrapper
+func TestGenerateShimCommand(t *testing.T) {
+	generateShimCmd := newTestCommand(t, "generate-shim")
+
+	path := filepath.Join(t.TempDir(), "hire.sh")
+
+	setFlags(t, generateShimCmd, "--output", path)
+
+	if err := generateShimCmd.RunE(generateShimCmd, []string{}); err != nil {
+		t.Fatalf("generate-shim failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Shim was not written: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+		t.Errorf("Shim should be executable, got mode %v", info.Mode())
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read shim: %v", err)
+	}
+	if !strings.Contains(string(content), "chatmate hire --force") {
+		t.Error("Shim should delegate install to chatmate hire")
+	}
+}
+
+// TestExportCommand tests exporting chatmates to a directory
+func TestExportCommand(t *testing.T) {
+	exportCmd := newTestCommand(t, "export")
+
+	dir := filepath.Join(t.TempDir(), "export")
+
+	captureOutput(func() {
+		if err := exportCmd.RunE(exportCmd, []string{dir, "Solve Issue"}); err != nil {
+			t.Fatalf("export failed: %v", err)
+		}
+	})
+
+	if _, err := os.Stat(filepath.Join(dir, "Solve Issue.chatmode.md")); err != nil {
+		t.Errorf("Exported chatmate missing: %v", err)
+	}
+
+	if err := exportCmd.Args(exportCmd, []string{}); err == nil {
+		t.Error("export should require a directory argument")
+	}
+}
+
+// TestReportCommand tests writing the offline HTML report
+func TestReportCommand(t *testing.T) {
+	reportCmd := newTestCommand(t, "report")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("CHATMATE_HEADLESS", "1")
+
+	if err := reportCmd.RunE(reportCmd, nil); err == nil || !strings.Contains(err.Error(), "--html") {
+		t.Errorf("Expected report without --html to fail, got %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "chatmates.html")
+	if err := reportCmd.Flags().Set("html", path); err != nil {
+		t.Fatalf("Failed to set --html: %v", err)
+	}
+	captureOutput(func() {
+		if err := reportCmd.RunE(reportCmd, nil); err != nil {
+			t.Fatalf("report failed: %v", err)
+		}
+	})
+
+	page, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read the report: %v", err)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "<strong>Solve Issue</strong>", "<h2>Validation</h2>", "<h3>daily-dev</h3>", "isn&#39;t working"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("Expected the report to contain %q", want)
+		}
+	}
+	if strings.Contains(string(page), "<script") || strings.Contains(string(page), "<link") {
+		t.Error("Expected a self-contained report without scripts or external resources")
+	}
+}
+
+// TestImportCommand tests installing chatmates from a directory
+func TestImportCommand(t *testing.T) {
+	importCmd := newTestCommand(t, "import")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("CHATMATE_HEADLESS", "1")
+
+	srcDir := t.TempDir()
+	content := "---\ndescription: 'Imported'\n---\n"
+	if err := os.WriteFile(filepath.Join(srcDir, "Imported.chatmode.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create chatmate: %v", err)
+	}
+
+	captureOutput(func() {
+		if err := importCmd.RunE(importCmd, []string{srcDir}); err != nil {
+			t.Fatalf("import failed: %v", err)
+		}
+	})
+
+	promptsDir, err := platform.GetHeadlessPromptsDir()
+	if err != nil {
+		t.Fatalf("Failed to get headless prompts directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Imported.chatmode.md")); err != nil {
+		t.Errorf("Imported chatmate missing from headless prompts directory: %v", err)
+	}
+}
+
+// TestQuickstartCommand tests the one-shot setup
+func TestQuickstartCommand(t *testing.T) {
+	quickstartCmd := newTestCommand(t, "quickstart")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("CHATMATE_HEADLESS", "0")
+
+	out := captureOutput(func() {
+		if err := quickstartCmd.RunE(quickstartCmd, nil); err != nil {
+			t.Fatalf("quickstart failed: %v", err)
+		}
+	})
+
+	promptsDir, err := platform.GetVSCodePromptsDir()
+	if err != nil {
+		t.Fatalf("Failed to get prompts directory: %v", err)
+	}
+	for _, name := range manager.RecommendedChatmates {
+		if _, err := os.Stat(filepath.Join(promptsDir, name+".chatmode.md")); err != nil {
+			t.Errorf("Recommended chatmate %s not installed: %v", name, err)
+		}
+	}
+
+	settings, err := os.ReadFile(filepath.Join(filepath.Dir(promptsDir), "settings.json"))
+	if err != nil || !strings.Contains(string(settings), `"chat.promptFiles": true`) {
+		t.Errorf("Expected prompt files to be enabled, got %q (%v)", settings, err)
+	}
+	if !strings.Contains(out, "@Solve Issue") {
+		t.Errorf("Expected summary to explain the first step, got:\n%s", out)
+	}
+
+	// Running it again keeps the installation and succeeds
+	captureOutput(func() {
+		if err := quickstartCmd.RunE(quickstartCmd, nil); err != nil {
+			t.Errorf("repeated quickstart failed: %v", err)
+		}
+	})
+}
+
+// TestTroubleshootCommand tests selecting and running troubleshooting flows
+func TestTroubleshootCommand(t *testing.T) {
+	troubleshootCmd := newTestCommand(t, "troubleshoot")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("CHATMATE_HEADLESS", "1")
+
+	out := captureOutput(func() {
+		if err := troubleshootCmd.RunE(troubleshootCmd, nil); err != nil {
+			t.Errorf("listing flows failed: %v", err)
+		}
+	})
+	for _, flow := range []string{"not-showing", "permissions", "wrong-editor"} {
+		if !strings.Contains(out, flow) {
+			t.Errorf("Expected flow %s to be listed, got:\n%s", flow, out)
+		}
+	}
+
+	if err := troubleshootCmd.RunE(troubleshootCmd, []string{"it", "is", "slow"}); err == nil {
+		t.Error("Expected error for a problem without a flow")
+	}
+
+	// Headless mode explains why chatmates cannot show up in VS Code
+	setFlags(t, troubleshootCmd, "--json")
+	var runErr error
+	out = captureOutput(func() {
+		runErr = troubleshootCmd.RunE(troubleshootCmd, []string{"chatmates not showing"})
+	})
+	if runErr == nil {
+		t.Error("Expected unresolved problems in headless mode")
+	}
+	var diagnosis manager.Diagnosis
+	if err := json.Unmarshal([]byte(out), &diagnosis); err != nil {
+		t.Fatalf("Invalid JSON output: %v\n%s", err, out)
+	}
+	if diagnosis.Flow != "not-showing" || len(diagnosis.Findings) != 1 || diagnosis.Findings[0].Fix == "" {
+		t.Errorf("Expected the editor-mode finding with a fix, got %+v", diagnosis)
+	}
+}
@@ -1,17 +1,18 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
+	"github.com/jonassiebler/chatmate/internal/output"
 	"github.com/spf13/cobra"
 )
 
-// completionCmd represents the completion command
-var completionCmd = &cobra.Command{
-	Use:   "completion [bash|zsh|fish|powershell]",
-	Short: "🚀 Generate shell completion scripts",
-	Long: `Generate shell completion scripts for chatmate.
+// NewCompletionCmd creates the completion command.
+func NewCompletionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "🚀 Generate shell completion scripts",
+		Long: `Generate shell completion scripts for chatmate.
 
 The completion scripts allow you to use tab completion for chatmate commands,
 flags, and arguments in your shell. This greatly improves the user experience
@@ -43,10 +44,10 @@ Installation:
 
 For persistent installation, add the appropriate command to your shell's
 configuration file (.bashrc, .zshrc, etc.).`,
-	DisableFlagsInUseLine: true,
-	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
-	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
-	Example: `  # Generate bash completion
+		DisableFlagsInUseLine: true,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		Example: `  # Generate bash completion
   chatmate completion bash
 
   # Install bash completion on Linux
@@ -62,24 +63,23 @@ configuration file (.bashrc, .zshrc, etc.).`,
   # Test completion (after installation)
   chatmate <TAB>          # Show available commands
   chatmate hire <TAB>     # Show hire command options`,
-	Run: func(cmd *cobra.Command, args []string) {
-		var err error
-		switch args[0] {
-		case "bash":
-			err = cmd.Root().GenBashCompletion(os.Stdout)
-		case "zsh":
-			err = cmd.Root().GenZshCompletion(os.Stdout)
-		case "fish":
-			err = cmd.Root().GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			err = cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
-		}
-		if err != nil {
-			fmt.Printf("Error generating completion: %v\n", err)
-		}
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(completionCmd)
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			switch args[0] {
+			case "bash":
+				err = cmd.Root().GenBashCompletion(os.Stdout)
+			case "zsh":
+				err = cmd.Root().GenZshCompletion(os.Stdout)
+			case "fish":
+				err = cmd.Root().GenFishCompletion(os.Stdout, true)
+			case "powershell":
+				err = cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+			}
+			if err != nil {
+				output.Errorf("Error generating completion: %v\n", err)
+			}
+		},
+	}
+
+	return cmd
 }
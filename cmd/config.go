@@ -3,21 +3,34 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
 	"github.com/spf13/cobra"
 )
 
-var (
-	configShow bool
-)
+// configOptions holds the flags of the config command.
+type configOptions struct {
+	show bool
+}
+
+// NewConfigCmd creates the config command.
+func NewConfigCmd(deps *Deps) *cobra.Command {
+	opts := &configOptions{}
 
-// configCmd represents the config command
-var configCmd = &cobra.Command{
-	Use:   "config",
-	Short: "Show ChatMate configuration settings and system paths",
-	Long: `Display detailed ChatMate configuration information including file paths,
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show ChatMate configuration and manage its settings",
+		Long: `Display detailed ChatMate configuration information including file paths,
 platform details, and system environment settings.
 
+⚙️  Settings:
+Defaults for the global options are kept in a configuration file,
+config.yaml in the ChatMate config directory ($XDG_CONFIG_HOME/chatmate,
+%APPDATA%\chatmate on Windows), or the file named by CHATMATE_CONFIG.
+Manage them with 'chatmate config set', 'get', 'unset', and 'list'.
+Command-line flags and environment variables take precedence over them.
+
 🔧 Configuration Details:
 • ChatMate installation directory and embedded resources
 • VS Code user directory and prompts path  
@@ -37,7 +50,7 @@ platform details, and system environment settings.
 • Displays resolved file paths with expansion
 • Indicates which paths are accessible and writable
 • Platform-specific directory conventions (Windows/macOS/Linux)`,
-	Example: `  # Show complete configuration information
+		Example: `  # Show complete configuration information
   chatmate config
   
   # Save configuration for support requests
@@ -47,28 +60,219 @@ platform details, and system environment settings.
   chatmate config    # Check paths and configuration
   chatmate status    # Verify system integration
   chatmate list      # Test chatmate discovery`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		chatMateManager, err := manager.NewChatMateManager()
-		if err != nil {
-			return fmt.Errorf("failed to initialize ChatMate manager: %w", err)
-		}
-
-		// For now, we only support showing config
-		// In the future, we could add config management features
-		chatMateManager.Status().ShowConfig()
-		return nil
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(configCmd)
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if app.Structured() {
+				return app.Write(app.Manager.Status().Config(), "configuration")
+			}
+			view.Config(app.Manager.Status().Config(), app.Manager.PromptsDirLabel())
+			return nil
+		}),
+	}
 
 	// Add flags for future extensibility
-	configCmd.Flags().BoolVarP(&configShow, "show", "s", true,
+	cmd.Flags().BoolVarP(&opts.show, "show", "s", true,
 		"Show current configuration (default)")
 
 	// Hidden flag for future extension
-	_ = configCmd.Flags().MarkHidden("show") // Add examples
-	configCmd.Example = `  # Show current ChatMate configuration
-  chatmate config`
+	_ = cmd.Flags().MarkHidden("show") // Add examples
+	cmd.Example = `  # Show current ChatMate configuration
+  chatmate config
+
+  # Print the prompts directory in a script
+  chatmate config --output json | jq -r .promptsDir
+
+  # Install chatmates for VS Code Insiders by default
+  chatmate config set editor insiders
+
+  # Show every setting
+  chatmate config list`
+
+	cmd.AddCommand(
+		newConfigGetCmd(deps),
+		newConfigListCmd(deps),
+		newConfigSetCmd(deps),
+		newConfigUnsetCmd(deps),
+	)
+
+	return cmd
+}
+
+// newConfigGetCmd creates the config get command.
+func newConfigGetCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Long: `Print the value of a setting of the configuration file. Nothing is printed
+when the setting is not set.`,
+		Example: `  # Print the editor chatmates are installed for by default
+  chatmate config get editor`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSettingKeys,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			key, err := settings.FindKey(args[0])
+			if err != nil {
+				return err
+			}
+			file, _, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+
+			value := file.Get(key)
+			if app.Structured() {
+				return app.Write(map[string]string{"key": key.Name, "value": value}, "setting")
+			}
+			if value != "" {
+				fmt.Fprintln(app.Stdout, value)
+			}
+			return nil
+		}),
+	}
+}
+
+// newConfigListCmd creates the config list command.
+func newConfigListCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show every setting and its value",
+		Long: `Show every setting of the configuration file with its value, what it does,
+and the values it accepts.`,
+		Example: `  # Show every setting
+  chatmate config list
+
+  # Machine-readable output
+  chatmate config list --output json`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+
+			entries := file.List()
+			if app.Structured() {
+				return app.Write(map[string]any{"path": path, "settings": entries}, "settings")
+			}
+			output.Printf("Configuration File: %s\n", path)
+			output.Println()
+			for _, entry := range entries {
+				value := entry.Value
+				if value == "" {
+					value = "(not set)"
+				}
+				output.Printf("  %-11s %s\n", entry.Key, value)
+				output.Printf("  %-11s %s\n", "", entry.Description)
+			}
+			return nil
+		}),
+	}
+}
+
+// newConfigSetCmd creates the config set command.
+func newConfigSetCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change a setting",
+		Long: `Change a setting of the configuration file, creating the file if needed.
+
+🔑 Settings:
+• promptsDir: Prompts directory to manage chatmates in, instead of the
+  editor's; "~" is the home directory
+• editor: VS Code build or fork to install chatmates for: stable,
+  insiders, vscodium, oss, or cursor (like --editor)
+• output: Default output format of informational commands: text, json,
+  or yaml (like --output)
+• ascii: true to print ASCII markers instead of emoji (like CHATMATE_ASCII)
+• assumeYes: true to answer yes to every confirmation prompt (like --yes)`,
+		Example: `  # Install chatmates for VS Code Insiders by default
+  chatmate config set editor insiders
+
+  # Keep chatmates in a dotfiles repository
+  chatmate config set promptsDir ~/dotfiles/vscode/prompts
+
+  # Never ask for confirmation
+  chatmate config set assumeYes true`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeSettingKeys,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			key, err := settings.FindKey(args[0])
+			if err != nil {
+				return err
+			}
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+			if err := file.Set(key, args[1]); err != nil {
+				return err
+			}
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ Set %s to %s in %s\n", key.Name, file.Get(key), path)
+			return nil
+		}),
+	}
+}
+
+// newConfigUnsetCmd creates the config unset command.
+func newConfigUnsetCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a setting, so its default applies",
+		Example: `  # Detect the editor again
+  chatmate config unset editor`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSettingKeys,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			key, err := settings.FindKey(args[0])
+			if err != nil {
+				return err
+			}
+			path, err := settings.Path()
+			if err != nil {
+				return err
+			}
+			// Unsetting an invalid value repairs the file
+			file, err := settings.Load(path)
+			if file == nil {
+				return err
+			}
+			file.Unset(key)
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ Removed %s from %s\n", key.Name, path)
+			return nil
+		}),
+	}
+}
+
+// readSettingsFile reads the configuration file and returns it with its path.
+func readSettingsFile() (*settings.Settings, string, error) {
+	path, err := settings.Path()
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := settings.Load(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w; fix the file or remove the setting with 'chatmate config unset'", err)
+	}
+	return file, path, nil
+}
+
+// completeSettingKeys completes the key argument of the config subcommands.
+func completeSettingKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		if key, err := settings.FindKey(args[0]); err == nil && len(args) == 1 {
+			return key.Values, cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	keys := make([]string, 0, len(settings.Keys))
+	for _, key := range settings.Keys {
+		keys = append(keys, key.Name+"\t"+key.Description)
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
 }
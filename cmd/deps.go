@@ -0,0 +1,31 @@
+package cmd
+
+import "github.com/jonassiebler/chatmate/internal/manager"
+
+// Deps holds the dependencies shared by the commands.
+//
+// Commands are built by constructors that receive a Deps instead of reading
+// package-level state, so each command tree is independent: tests can build
+// their own tree and run in parallel, and other programs can embed the
+// commands with their own dependencies.
+//
+// Example:
+//
+//	deps := cmd.DefaultDeps()
+//	root := cmd.NewRootCmd(deps)
+//	root.SetArgs([]string{"list"})
+//	err := root.Execute()
+type Deps struct {
+	// NewManager creates the ChatMate manager a command works with.
+	NewManager func() (*manager.ChatMateManager, error)
+}
+
+// DefaultDeps returns the dependencies used by the chatmate binary.
+//
+// Returns:
+//   - *Deps: Dependencies that create managers for the current user
+func DefaultDeps() *Deps {
+	return &Deps{
+		NewManager: manager.NewChatMateManager,
+	}
+}
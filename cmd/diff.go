@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// diffOptions holds the flags of the diff command.
+type diffOptions struct {
+	names   []string
+	summary bool
+}
+
+// NewDiffCmd creates the diff command.
+func NewDiffCmd(deps *Deps) *cobra.Command {
+	opts := &diffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show how installed chatmates differ from the shipped versions",
+		Long: `Compare the installed chatmates with the versions shipped with ChatMate and
+print a unified diff for every chatmate whose content differs, without
+changing anything.
+
+Installed copies drift when they are edited locally, or become stale when a
+newer ChatMate release ships changed chatmates. The diffs go from the shipped
+version (available/) to the installed copy (installed/), so added lines are
+local edits or lines the shipped version no longer has.
+
+Only chatmates that ship with ChatMate are compared; user-created chatmates
+have nothing to be compared with. Use --summary for the number of changed
+lines per chatmate, 'chatmate outdated' to see what 'chatmate update' would
+replace, and 'chatmate verify' to detect changes made outside ChatMate.`,
+		Example: `  # Show every installed chatmate that differs from the shipped version
+  chatmate diff
+
+  # Compare specific chatmates
+  chatmate diff --name "Solve Issue" --name "Testing"
+
+  # Number of changed lines per chatmate
+  chatmate diff --summary
+
+  # Chatmates that differ, for scripts
+  chatmate diff --output json | jq -r '.chatmates[] | select(.changed) | .name'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			report, err := app.Manager.Diff(opts.names)
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				if opts.summary {
+					for i := range report.Chatmates {
+						report.Chatmates[i].Diff = ""
+					}
+				}
+				return app.Write(report, "diff")
+			}
+			view.Diff(report, opts.summary)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringSliceVar(&opts.names, "name", nil,
+		"compare only this chatmate, by display name or filename (can be used multiple times)")
+	cmd.Flags().BoolVar(&opts.summary, "summary", false,
+		"print the number of changed lines per chatmate instead of the diffs")
+
+	return cmd
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// doctorOptions holds the flags of the doctor command.
+type doctorOptions struct {
+	fix bool
+}
+
+// NewDoctorCmd creates the doctor command.
+func NewDoctorCmd(deps *Deps) *cobra.Command {
+	opts := &doctorOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the ChatMate setup and repair common problems",
+		Long: `Run every health check of the ChatMate setup and report each one as
+pass, warn, or fail, with a hint on how to fix it.
+
+🩺 Checks Performed:
+• VS Code is detected on this machine
+• The GitHub Copilot Chat extension is installed
+• The prompts directory exists and is writable
+• Installed files without a matching available chatmate (orphans)
+• Installed chatmates with missing or malformed YAML frontmatter
+• Installed chatmates still named the way older releases named them
+• Installed files with the same display name, which make @-mentions ambiguous
+
+🔧 Automatic Repairs (--fix):
+• Recreate a missing prompts directory
+• Restore write access to the prompts directory
+• Reinstall chatmates whose frontmatter is corrupted, if they are still
+  available (this replaces local edits to these files)
+• Rename chatmates with the "Chatmate - " prefix of older releases to their
+  current names, keeping local edits
+
+Other problems, such as orphaned files or duplicate names, are only
+reported: they may be your own chatmates. Use 'chatmate troubleshoot' to follow a specific symptom.
+
+The command exits with a non-zero status if a failed check remains.`,
+		Example: `  # Check the setup
+  chatmate doctor
+
+  # Check and repair what can be repaired safely
+  chatmate doctor --fix
+
+  # Machine-readable findings
+  chatmate doctor --output json | jq '.findings[] | select(.status != "pass")'`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.fix && app.Structured() {
+				return fmt.Errorf("--fix cannot be combined with --output %s", app.Config.Output)
+			}
+
+			doctor := app.Manager.Doctor()
+			report := doctor.Run()
+
+			if app.Structured() {
+				if err := app.Write(report, "doctor report"); err != nil {
+					return err
+				}
+			} else {
+				output.Println("🩺 Checking ChatMate setup...")
+				output.Printf("Prompts Directory: %s\n\n", report.PromptsDir)
+				printDoctorReport(report)
+
+				if opts.fix && len(report.Fixable()) > 0 {
+					output.Println()
+					fixed, err := doctor.Fix(app.Context, report)
+					if err != nil {
+						return err
+					}
+					output.Printf("%s Applied %d repair(s)\n", output.SymbolSuccess, fixed)
+					output.Println("\n🔁 Checking again...")
+					report = doctor.Run()
+					printDoctorReport(report)
+				} else if len(report.Fixable()) > 0 {
+					output.Println("\n💡 Apply the automatic repairs with 'chatmate doctor --fix'")
+				}
+			}
+
+			if failed := report.Count(manager.CheckFail); failed > 0 {
+				// The findings already explain the failure; usage text adds noise
+				cmd.SilenceUsage = true
+				return fmt.Errorf("doctor found %d failed check(s)", failed)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.fix, "fix", false, "apply safe repairs, such as recreating the prompts directory and reinstalling corrupted chatmates")
+	cmd.Flags().Bool("json", false, "print the findings as JSON, same as --output json")
+
+	return cmd
+}
+
+// printDoctorReport prints every finding of a doctor report and a summary.
+func printDoctorReport(report *manager.DoctorReport) {
+	for _, finding := range report.Findings {
+		printFinding(finding)
+	}
+
+	output.Printf("\n%d passed, %d warnings, %d failed\n",
+		report.Count(manager.CheckPass), report.Count(manager.CheckWarn), report.Count(manager.CheckFail))
+}
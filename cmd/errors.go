@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// Exit codes of the chatmate binary. Errors of a kind scripts may want to
+// handle (see manager.ErrorKind) have their own code; 2 is left out because
+// shells and other tools use it for usage errors.
+const (
+	// ExitFailure is the exit code of any other error
+	ExitFailure = 1
+	// ExitNotFound is the exit code when a chatmate name matches nothing
+	ExitNotFound = 3
+	// ExitPromptsDirMissing is the exit code when the prompts directory
+	// does not exist or cannot be used
+	ExitPromptsDirMissing = 4
+	// ExitPermissionDenied is the exit code when the operating system
+	// refused a file operation
+	ExitPermissionDenied = 5
+	// ExitValidationFailed is the exit code when chatmates or the setup
+	// failed validation
+	ExitValidationFailed = 6
+)
+
+// exitCodes maps the kinds of errors to their exit codes.
+var exitCodes = map[error]int{
+	manager.ErrChatmateNotFound:  ExitNotFound,
+	manager.ErrPromptsDirMissing: ExitPromptsDirMissing,
+	manager.ErrPermissionDenied:  ExitPermissionDenied,
+	manager.ErrValidationFailed:  ExitValidationFailed,
+}
+
+// remedies tells what to do about each kind of error.
+var remedies = map[error]string{
+	manager.ErrChatmateNotFound:  "Run 'chatmate list' to see the chatmates and their names",
+	manager.ErrPromptsDirMissing: "Run 'chatmate hire' to create the prompts directory, or choose another one with 'chatmate config set promptsDir <dir>'",
+	manager.ErrPermissionDenied:  "Check the owner and permissions of the file or directory above; 'chatmate doctor' checks the whole setup",
+	manager.ErrValidationFailed:  "Fix the reported chatmates, or reinstall them with 'chatmate hire --force'; 'chatmate validate' lists every problem",
+}
+
+// ExitCode returns the exit code of the chatmate binary for the error of a
+// command: 0 without an error, a code of its own for the kinds of errors
+// scripts may want to handle, and ExitFailure otherwise.
+//
+// Parameters:
+//   - err: The error returned by Execute
+//
+// Returns:
+//   - int: The exit code
+//
+// Example:
+//
+//	if err := cmd.Execute(); err != nil {
+//		os.Exit(cmd.ExitCode(err))
+//	}
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[manager.ErrorKind(err)]; ok {
+		return code
+	}
+	return ExitFailure
+}
+
+// printRemedy prints what to do about the error of a command, if its kind
+// has a known remedy.
+func printRemedy(err error) {
+	if remedy, ok := remedies[manager.ErrorKind(err)]; ok {
+		output.Errorf("💡 %s\n", remedy)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// exportOptions holds the flags of the export command.
+type exportOptions struct {
+	force bool
+}
+
+// NewExportCmd creates the export command.
+func NewExportCmd(deps *Deps) *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export <directory> [chatmate names...]",
+		Short: "Write chatmate files to a directory outside VS Code",
+		Long: `Write chatmate files to any directory instead of the VS Code prompts directory.
+
+This is the fallback when VS Code is not installed on this machine, such as on
+a server or in a container: export the chatmates, then copy them to the
+prompts directory of a machine that runs VS Code, or use them with other tools.
+
+Exported files are not tracked as installed, and the VS Code prompts directory
+is left untouched. Existing files in the directory are skipped unless --force
+is given.`,
+		Example: `  # Export all available chatmates
+  chatmate export ./chatmates
+
+  # Export specific chatmates
+  chatmate export ./chatmates "Solve Issue" "Code Review"
+
+  # Overwrite previously exported files
+  chatmate export --force ./chatmates`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			destDir := utils.ExpandPath(args[0])
+			exported, err := app.Manager.Installer().Export(app.Context, destDir, args[1:], opts.force)
+			if err != nil {
+				return err
+			}
+
+			output.Printf("\n✅ Exported %d chatmate(s) to %s\n", exported, destDir)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite existing files in the directory")
+
+	return cmd
+}
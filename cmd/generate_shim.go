@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/shim"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/spf13/cobra"
+)
+
+// generateShimOptions holds the flags of the generate-shim command.
+type generateShimOptions struct {
+	output string
+}
+
+// NewGenerateShimCmd creates the generate-shim command.
+func NewGenerateShimCmd() *cobra.Command {
+	opts := &generateShimOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "generate-shim",
+		Short: "Generate the hire.sh compatibility wrapper",
+		Long: `Generate a hire.sh script that delegates to the chatmate binary.
+
+The original shell implementation of hire.sh has been retired. The generated
+wrapper accepts the legacy commands and options so existing documentation and
+automation keep working, and prints a deprecation warning pointing to the
+equivalent chatmate command:
+
+  hire.sh [install]   ->  chatmate hire --force
+  hire.sh uninstall   ->  chatmate uninstall --all
+  hire.sh list        ->  chatmate list --available
+
+The script is written to stdout unless --output is given.`,
+		Example: `  # Replace a legacy hire.sh with the wrapper
+  chatmate generate-shim --output hire.sh
+
+  # Inspect the generated script
+  chatmate generate-shim | less`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.output == "" {
+				return shim.WriteHireScript(output.Stdout(), version)
+			}
+
+			if err := files.CheckWrite("write", opts.output); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(opts.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", opts.output, err)
+			}
+
+			if err := shim.WriteHireScript(file, version); err != nil {
+				_ = file.Close()
+				return fmt.Errorf("failed to write %s: %w", opts.output, err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to write %s: %w", opts.output, err)
+			}
+
+			// Existing files keep their mode on open; the wrapper must be executable
+			if err := os.Chmod(opts.output, 0755); err != nil {
+				return fmt.Errorf("failed to make %s executable: %w", opts.output, err)
+			}
+
+			output.Printf("✅ Wrote %s\n", opts.output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "write the script to this file instead of stdout")
+
+	return cmd
+}
@@ -1,23 +1,51 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jonassiebler/chatmate/cmd/view"
 	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
 	"github.com/spf13/cobra"
 )
 
-var (
-	hireSpecific []string
-	hireForce    bool
-)
+// hireOptions holds the flags of the hire command.
+type hireOptions struct {
+	specific       []string
+	force          bool
+	stdin          bool
+	name           string
+	requireEditor  bool
+	resume         bool
+	explain        bool
+	fromRegistry   []string
+	git            string
+	ref            string
+	path           string
+	file           string
+	url            string
+	sha256         string
+	asOf           string
+	acceptBreaking bool
+	workspace      bool
+	concurrency    int
+}
 
-// hireCmd represents the hire command
-var hireCmd = &cobra.Command{
-	Use:   "hire [chatmate names...]",
-	Short: "Install chatmate agents for VS Code Copilot Chat",
-	Long: `Install chatmate agents to enhance your VS Code Copilot Chat experience.
+// NewHireCmd creates the hire command.
+func NewHireCmd(deps *Deps) *cobra.Command {
+	opts := &hireOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "hire [chatmate names...]",
+		Aliases: []string{"i", "install"},
+		Short:   "Install chatmate agents for VS Code Copilot Chat",
+		Long: `Install chatmate agents to enhance your VS Code Copilot Chat experience.
 	
 🎯 Installation Options:
 • Install all available chatmates (recommended for first-time users)
@@ -37,11 +65,43 @@ var hireCmd = &cobra.Command{
 3. Handles existing files with smart overwrite logic
 4. Reports installation status and any conflicts
 
+Use --from-registry to install community chatmates published in the
+chatmate registry (see 'chatmate browse').
+
+Use --git to install chatmates straight from a git repository, e.g. your
+team's prompts repository, and --ref to pick a branch or tag. The repository
+is cloned shallowly into the ChatMate cache directory and fetched again
+after an hour; its chatmates are read from its mates directory, or its root
+if it has none, and validated before they are installed. Name chatmates to
+install only those. To keep installing and updating from a repository, add
+it with 'chatmate source add' instead.
+
+Use --path to install your own chatmates from a local directory, or --file
+to install a single .chatmode.md file. They are validated, checksummed, and
+tracked like repository chatmates, instead of being copied by hand. Name
+chatmates after --path to install only those.
+
+Use --url to download a single .chatmode.md file over https, such as the raw
+file of a chatmate shared in a gist, and --sha256 to refuse it unless it has
+the checksum its author published. Downloads larger than 10 MB are refused.
+
+Append a version to a name ("Solve Issue@1.4.0"), or use --as-of with a
+date, to install an earlier release of a chatmate from the registry, for
+example when an update changed its behavior. 'chatmate update' and
+'chatmate sync' keep a pinned release until it is replaced with --force.
+
+Use --workspace to install into the .github/prompts directory of the
+current repository instead, so the chatmates are versioned with the project
+and shared with everyone who works on it.
+
+Use --explain to see the sources, target directory, name matching, and
+policies for an installation without installing anything.
+
 ⚠️  Requirements:
 • VS Code installed and accessible
 • VS Code Copilot Chat extension enabled
 • Write permissions to VS Code user directory`,
-	Example: `  # Install all available chatmates (recommended for new users)
+		Example: `  # Install all available chatmates (recommended for new users)
   chatmate hire
   
   # Install specific chatmates by name (preferred method)
@@ -54,43 +114,225 @@ var hireCmd = &cobra.Command{
   chatmate hire --force
   
   # Force reinstall specific chatmates
-  chatmate hire --force "Solve Issue" "Testing"`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		chatMateManager, err := manager.NewChatMateManager()
-		if err != nil {
-			return fmt.Errorf("failed to initialize ChatMate manager: %w", err)
-		}
+  chatmate hire --force "Solve Issue" "Testing"
 
-		// Handle specific chatmates from args or --specific flag
-		var specificChatmates []string
-		if len(args) > 0 {
-			specificChatmates = args
-		} else if len(hireSpecific) > 0 {
-			specificChatmates = hireSpecific
-		}
+  # Install a chatmate read from stdin
+  cat MyAgent.chatmode.md | chatmate hire --stdin --name "My Agent"`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.resume && (opts.stdin || len(args) > 0 || len(opts.specific) > 0) {
+				return fmt.Errorf("cannot specify chatmate names or --stdin when using --resume flag")
+			}
+			if opts.explain && (opts.resume || opts.stdin) {
+				return fmt.Errorf("--explain cannot be used with --resume or --stdin")
+			}
+			if len(opts.fromRegistry) > 0 && (opts.resume || opts.stdin || opts.explain || len(args) > 0 || len(opts.specific) > 0) {
+				return fmt.Errorf("--from-registry cannot be combined with chatmate names, --stdin, --resume, or --explain")
+			}
+			if opts.asOf != "" && (opts.resume || opts.stdin) {
+				return fmt.Errorf("--as-of cannot be used with --resume or --stdin")
+			}
+			if opts.git != "" && (opts.resume || opts.stdin || opts.explain || opts.asOf != "" || len(opts.fromRegistry) > 0) {
+				return fmt.Errorf("--git cannot be combined with --stdin, --resume, --explain, --as-of, or --from-registry")
+			}
+			if opts.ref != "" && opts.git == "" {
+				return fmt.Errorf("--ref requires --git")
+			}
+			if opts.path != "" && opts.file != "" {
+				return fmt.Errorf("--path and --file cannot be used together")
+			}
+			local := opts.path != "" || opts.file != ""
+			if local && (opts.resume || opts.stdin || opts.explain || opts.asOf != "" || len(opts.fromRegistry) > 0 || opts.git != "") {
+				return fmt.Errorf("--path and --file cannot be combined with --stdin, --resume, --explain, --as-of, --from-registry, or --git")
+			}
+			if opts.file != "" && (len(args) > 0 || len(opts.specific) > 0) {
+				return fmt.Errorf("cannot specify chatmate names when using --file; use --path to pick chatmates from a directory")
+			}
+			if opts.url != "" && (local || opts.resume || opts.stdin || opts.explain || opts.asOf != "" || len(opts.fromRegistry) > 0 || opts.git != "" || len(args) > 0 || len(opts.specific) > 0) {
+				return fmt.Errorf("--url cannot be combined with chatmate names, --path, --file, --stdin, --resume, --explain, --as-of, --from-registry, or --git")
+			}
+			if opts.sha256 != "" && opts.url == "" {
+				return fmt.Errorf("--sha256 requires --url")
+			}
+			if opts.workspace {
+				if err := app.Manager.UseWorkspace(""); err != nil {
+					return err
+				}
+			}
 
-		if len(specificChatmates) > 0 {
-			fmt.Printf("Installing specific chatmates: %s\n", strings.Join(specificChatmates, ", "))
-			return chatMateManager.Installer().InstallSpecific(specificChatmates, hireForce)
-		}
+			// Handle specific chatmates from args or --specific flag
+			var specificChatmates []string
+			if len(args) > 0 {
+				specificChatmates = args
+			} else if len(opts.specific) > 0 {
+				specificChatmates = opts.specific
+			}
 
-		// Install all chatmates
-		fmt.Println("Installing all available chatmates...")
-		return chatMateManager.Installer().InstallAll(hireForce)
-	},
-}
+			// Versions are downloaded from the registry
+			names := specificChatmates
+			if opts.git != "" || local {
+				names = nil
+			}
+			if len(opts.fromRegistry) > 0 {
+				names = opts.fromRegistry
+			}
+			pins, err := pinnedReleases(names, opts.asOf, time.Now())
+			if err != nil {
+				return err
+			}
+			if len(pins) > 0 && opts.explain {
+				return fmt.Errorf("--explain cannot be used with chatmate versions or --as-of")
+			}
+
+			// Describe the plan instead of installing
+			if opts.explain {
+				explanation, err := app.Manager.Installer().ExplainInstall(specificChatmates, opts.force)
+				if err != nil {
+					return err
+				}
+				view.Explanation(explanation)
+				return nil
+			}
+
+			// A workspace is used by whoever opens it, so the editor does not
+			// have to be installed here (e.g., in CI)
+			if opts.workspace {
+				output.Printf("📁 Installing into the workspace: %s\n", app.Manager.PromptsDir)
+			} else if err := checkEditor(app.Manager, opts.requireEditor); err != nil {
+				return err
+			}
+			if err := migrateLegacyNames(app); err != nil {
+				return err
+			}
+			if opts.concurrency < 0 {
+				return fmt.Errorf("--concurrency must not be negative, got %d", opts.concurrency)
+			} else if opts.concurrency > 0 {
+				app.Manager.Concurrency = opts.concurrency
+			}
+			installer := app.Manager.Installer()
+			installer.AcceptBreaking = opts.acceptBreaking
+			// Explain how to get chatmates approved that the policy held back
+			defer func() { view.QueuedApprovals(installer.QueuedApprovals()) }()
 
-func init() {
-	rootCmd.AddCommand(hireCmd)
+			// Continue an interrupted installation with its original options
+			if opts.resume {
+				if checkpoint := installer.Interrupted(); checkpoint != nil {
+					output.Printf("Resuming installation started %s: %d installed, %d remaining\n",
+						output.FormatDateTime(checkpoint.StartedAt), checkpoint.Completed, len(checkpoint.Pending))
+				}
+				_, err := installer.Resume(app.Context)
+				return resumable(installer, err)
+			}
+
+			// Install earlier releases from the registry
+			if len(pins) > 0 {
+				output.Printf("Installing chatmate releases from the registry: %s\n", strings.Join(pinNames(pins), ", "))
+				_, err := installer.InstallPinned(app.Context, pins, opts.force)
+				return err
+			}
+
+			// Clone a repository and install its chatmates
+			if opts.git != "" {
+				location := opts.git
+				if opts.ref != "" {
+					location += "#" + opts.ref
+				}
+				output.Printf("Installing chatmates from the git repository %s\n", location)
+				_, err := installer.InstallFromGit(app.Context, opts.git, opts.ref, specificChatmates, opts.force)
+				return err
+			}
+
+			// Install the user's own chatmates from the local filesystem
+			if opts.path != "" {
+				dir := utils.ExpandPath(opts.path)
+				output.Printf("Installing chatmates from %s\n", dir)
+				_, err := installer.InstallFromDirectory(app.Context, dir, specificChatmates, opts.force)
+				return err
+			}
+
+			// Download a chatmate shared as a file on the web
+			if opts.url != "" {
+				output.Printf("Installing the chatmate %s\n", opts.url)
+				_, err := installer.InstallFromURL(app.Context, opts.url, opts.sha256, opts.force)
+				return err
+			}
+			if opts.file != "" {
+				file := utils.ExpandPath(opts.file)
+				output.Printf("Installing the chatmate %s\n", file)
+				_, err := installer.InstallFromFile(app.Context, file, opts.force)
+				return err
+			}
+
+			// Download community chatmates from the registry
+			if len(opts.fromRegistry) > 0 {
+				output.Printf("Installing chatmates from the registry: %s\n", strings.Join(opts.fromRegistry, ", "))
+				_, err := installer.InstallFromRegistry(app.Context, opts.fromRegistry, opts.force)
+				return err
+			}
+
+			// Handle chatmate content piped through stdin
+			if opts.stdin {
+				if len(args) > 0 || len(opts.specific) > 0 {
+					return fmt.Errorf("cannot specify chatmate names when using --stdin flag")
+				}
+				if opts.name == "" {
+					return fmt.Errorf("--name is required when using --stdin flag")
+				}
+				_, err := installer.InstallFromReader(opts.name, cmd.InOrStdin(), opts.force)
+				return err
+			}
+
+			if len(specificChatmates) > 0 {
+				output.Printf("Installing specific chatmates: %s\n", strings.Join(specificChatmates, ", "))
+				_, err := installer.InstallSpecific(app.Context, specificChatmates, opts.force)
+				return err
+			}
+
+			// Install all chatmates
+			output.Println("Installing all available chatmates...")
+			return installAll(app.Context, installer, opts.force)
+		}),
+	}
 
 	// Add flags
-	hireCmd.Flags().StringSliceVarP(&hireSpecific, "specific", "s", []string{},
+	cmd.Flags().StringSliceVarP(&opts.specific, "specific", "s", []string{},
 		"Install specific chatmates by name (can be used multiple times)")
-	hireCmd.Flags().BoolVarP(&hireForce, "force", "f", false,
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false,
 		"Force reinstall even if chatmates are already installed")
+	cmd.Flags().BoolVar(&opts.stdin, "stdin", false,
+		"Read chatmate content from stdin (requires --name)")
+	cmd.Flags().StringVar(&opts.name, "name", "",
+		"Name for the chatmate installed from stdin")
+	cmd.Flags().BoolVar(&opts.requireEditor, "require-editor", false,
+		"Fail instead of warning when VS Code is not detected")
+	cmd.Flags().BoolVar(&opts.resume, "resume", false,
+		"Continue an interrupted installation where it stopped")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false,
+		"Describe what would be installed and why, without installing")
+	cmd.Flags().StringSliceVar(&opts.fromRegistry, "from-registry", []string{},
+		"Install chatmates from the registry by name (can be used multiple times)")
+	cmd.Flags().StringVar(&opts.git, "git", "",
+		"Install chatmates from a git repository (URL or path)")
+	cmd.Flags().StringVar(&opts.ref, "ref", "",
+		"Branch or tag of the --git repository (default: its default branch)")
+	cmd.Flags().StringVar(&opts.path, "path", "",
+		"Install chatmates from a local directory")
+	cmd.Flags().StringVar(&opts.file, "file", "",
+		"Install a chatmate from a local .chatmode.md file")
+	cmd.Flags().StringVar(&opts.url, "url", "",
+		"Install a chatmate downloaded from an https URL of a .chatmode.md file")
+	cmd.Flags().StringVar(&opts.sha256, "sha256", "",
+		"SHA-256 the --url download must have (hex encoded)")
+	cmd.Flags().StringVar(&opts.asOf, "as-of", "",
+		"Install the releases of the named chatmates current on a date (2006-01-02, RFC 3339)")
+	cmd.Flags().BoolVar(&opts.acceptBreaking, "accept-breaking", false,
+		"Replace installed registry chatmates with releases marked as breaking without asking")
+	cmd.Flags().BoolVar(&opts.workspace, "workspace", false,
+		"Install into the .github/prompts directory of the current repository")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 0,
+		"Number of chatmates to install at the same time; 1 installs them one after another (default: the concurrency setting, or 4)")
 
 	// Add some examples in the help
-	hireCmd.Example = `  # Install all available chatmates
+	cmd.Example = `  # Install all available chatmates
   chatmate hire
 
   # Install specific chatmates using flag
@@ -103,5 +345,205 @@ func init() {
   chatmate hire --force
   
   # Force reinstall specific chatmates
-  chatmate hire --force "Code Review"`
+  chatmate hire --force "Code Review"
+
+  # Install a chatmate piped through stdin
+  cat MyAgent.chatmode.md | chatmate hire --stdin --name "My Agent"
+
+  # Install into the current repository's .github/prompts, to commit with it
+  chatmate hire --workspace "Review PR"
+
+  # Refuse to install when VS Code is not installed (e.g., in provisioning scripts)
+  chatmate hire --require-editor
+
+  # Continue an installation that was interrupted (Ctrl-C, crash)
+  chatmate hire --resume
+
+  # Explain what would be installed, from where, and why, without installing
+  chatmate hire --explain "Solve Issue"
+
+  # Install a community chatmate from the registry (see 'chatmate browse')
+  chatmate hire --from-registry "Rust Reviewer"
+
+  # Install the chatmates of your team's repository, at its v2 tag
+  chatmate hire --git https://github.com/acme/prompts --ref v2
+
+  # Install a single chatmate from a repository
+  chatmate hire --git https://github.com/acme/prompts "Deploy Helper"
+
+  # Install your own chatmates from a directory, or a single file
+  chatmate hire --path ./my-prompts/
+  chatmate hire --file MyAgent.chatmode.md
+
+  # Download a shared chatmate, verifying its published checksum
+  chatmate hire --url https://example.com/Agent.chatmode.md --sha256 <sha256>
+
+  # Go back to an earlier release of a chatmate, replacing the installed one
+  chatmate hire --force "Solve Issue@1.4.0"
+
+  # Install the release of a chatmate that was current on a date
+  chatmate hire --force --as-of 2024-12-01 "Solve Issue"`
+
+	return cmd
+}
+
+// pinnedReleases returns the chatmates to install from a registry release:
+// the names with a version ("Solve Issue@1.4.0"), or every name when asOf
+// (--as-of) is set, in which case a version given with a name wins.
+//
+// Parameters:
+//   - names: The chatmate names given on the command line
+//   - asOf: A date in local time ("2006-01-02") or an RFC 3339 timestamp;
+//     a date includes the releases of that whole day
+//   - now: Later points in time are treated as now
+//
+// Returns:
+//   - []manager.Pin: The releases to install; nil when no version was asked for
+//   - error: Invalid --as-of value, --as-of without names, or names with and
+//     without a version mixed without --as-of
+func pinnedReleases(names []string, asOf string, now time.Time) ([]manager.Pin, error) {
+	var at time.Time
+	if asOf != "" {
+		if len(names) == 0 {
+			return nil, fmt.Errorf("--as-of requires chatmate names")
+		}
+		if date, err := time.ParseInLocation("2006-01-02", asOf, time.Local); err == nil {
+			at = date.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		} else if timestamp, err := time.Parse(time.RFC3339, asOf); err == nil {
+			at = timestamp
+		} else {
+			return nil, fmt.Errorf("invalid --as-of value %q: use a date such as 2024-12-01 or an RFC 3339 timestamp", asOf)
+		}
+		if at.After(now) {
+			at = now
+		}
+	}
+
+	var pins []manager.Pin
+	versioned := 0
+	for _, name := range names {
+		pin := manager.ParsePin(name)
+		if pin.Version != "" {
+			versioned++
+		} else {
+			pin.AsOf = at
+		}
+		pins = append(pins, pin)
+	}
+	if at.IsZero() && versioned == 0 {
+		return nil, nil
+	}
+	if at.IsZero() && versioned < len(pins) {
+		return nil, fmt.Errorf("give a version for every chatmate (\"Solve Issue@1.4.0\") or install the others separately")
+	}
+	return pins, nil
+}
+
+// pinNames returns the pins as written on the command line, for output.
+func pinNames(pins []manager.Pin) []string {
+	names := make([]string, len(pins))
+	for n, pin := range pins {
+		names[n] = pin.String()
+	}
+	return names
+}
+
+// installAll shows what installing all available chatmates would do and
+// installs them once the user confirms.
+func installAll(ctx context.Context, installer *manager.InstallerService, force bool) error {
+	plan, err := installer.PlanInstall(force)
+	if err != nil {
+		return err
+	}
+	if plan.Available == 0 {
+		output.Println("No chatmates available to install")
+		return nil
+	}
+
+	// Safety confirmation - show what will be installed
+	view.InstallPlan(plan)
+	if len(plan.Install) == 0 && len(plan.Collisions) > 0 {
+		output.Println("\n⚠️  Nothing to install; resolve the case collisions above first")
+		return nil
+	}
+	if len(plan.Install) == 0 {
+		output.Println("\n✅ All repository chatmates are already installed")
+		return nil
+	}
+
+	forceMsg := ""
+	if force {
+		forceMsg = " (with force reinstall)"
+	}
+	if !output.Confirm("\nDo you want to proceed with installing these chatmates%s?", forceMsg) {
+		output.Println("❌ Installation operation cancelled by user")
+		return nil
+	}
+
+	output.Printf("\nProceeding with installation...\n")
+	_, err = installer.InstallPlanned(ctx, plan)
+	return resumable(installer, err)
+}
+
+// resumable points out 'chatmate hire --resume' when an installation failed
+// part way and can be continued.
+func resumable(installer *manager.InstallerService, err error) error {
+	if err == nil || installer.Interrupted() == nil {
+		return err
+	}
+	if errors.Is(err, context.Canceled) {
+		output.Println("💡 Run 'chatmate hire --resume' to continue")
+	} else {
+		output.Println("💡 Fix the problem and run 'chatmate hire --resume' to continue")
+	}
+	return err
+}
+
+// migrateLegacyNames gives installed chatmates that still have the filename
+// an older release gave them ("Chatmate - Solve Issue.chatmode.md") their
+// current name before chatmates are installed, so they are updated in place
+// instead of installed a second time. Each renamed file is printed through
+// the manager's Progress function.
+func migrateLegacyNames(app *App) error {
+	if _, err := app.Manager.Installer().MigrateLegacyNames(app.Context); err != nil {
+		return fmt.Errorf("failed to rename legacy chatmate files: %w", err)
+	}
+	return nil
+}
+
+// checkEditor warns when the editor chatmates are installed for (VS Code
+// unless another one is chosen with --editor) does not appear to be
+// installed, because chatmates written to the prompts directory have no
+// effect without it.
+// With requireEditor (--require-editor) the installation is blocked instead.
+// In headless mode the missing editor is expected, so only the target
+// directory is reported.
+func checkEditor(chatMateManager *manager.ChatMateManager, requireEditor bool) error {
+	editor := chatMateManager.TargetEditor()
+	detection := platform.DetectEditor(editor)
+	if detection.Found {
+		output.Debugf("%s detected: %s\n", editor.Name, detection.Evidence)
+		return nil
+	}
+
+	if requireEditor {
+		if detection.Headless {
+			return fmt.Errorf("%s was not detected and this looks like a server-only environment; run 'chatmate hire' on the machine where it runs, or use 'chatmate export <dir>'", editor.Name)
+		}
+		return fmt.Errorf("%s was not detected; install it first, choose the editor you use with --editor, or use 'chatmate export <dir>' to write chatmates to another directory", editor.Name)
+	}
+
+	if chatMateManager.Headless {
+		output.Printf("🖥️  Headless mode: installing into %s\n", chatMateManager.PromptsDir)
+		output.Println("   Use 'chatmate export <dir>' to copy chatmates to a machine with VS Code.")
+		return nil
+	}
+
+	output.Warnf("%s was not detected; chatmates installed into %s only take effect once it is installed", editor.Name, chatMateManager.PromptsDir)
+	if detection.Headless {
+		output.Println("   This looks like a server-only environment (SSH session or no display).")
+		output.Println("   With VS Code Remote - SSH, chatmates belong on your local machine: run 'chatmate hire' there.")
+	}
+	output.Println("   Use 'chatmate export <dir>' to write chatmates elsewhere, or --require-editor to stop here.")
+	return nil
 }
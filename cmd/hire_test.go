@@ -4,11 +4,20 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
 )
 
 // TestHireCommandExists tests that the hire command is properly defined
 func TestHireCommandExists(t *testing.T) {
+	t.Parallel()
+
+	hireCmd := newTestCommand(t, "hire")
+
 	if hireCmd == nil {
 		t.Fatal("hire command is not defined")
 	}
@@ -24,6 +33,10 @@ func TestHireCommandExists(t *testing.T) {
 
 // TestHireCommandFlags tests that required flags are defined
 func TestHireCommandFlags(t *testing.T) {
+	t.Parallel()
+
+	hireCmd := newTestCommand(t, "hire")
+
 	// Test that force flag exists
 	forceFlag := hireCmd.Flags().Lookup("force")
 	if forceFlag == nil {
@@ -35,6 +48,20 @@ func TestHireCommandFlags(t *testing.T) {
 	if specificFlag == nil {
 		t.Error("hire command missing --specific flag")
 	}
+
+	// Test that stdin flags exist
+	if hireCmd.Flags().Lookup("stdin") == nil {
+		t.Error("hire command missing --stdin flag")
+	}
+	if hireCmd.Flags().Lookup("name") == nil {
+		t.Error("hire command missing --name flag")
+	}
+	if hireCmd.Flags().Lookup("explain") == nil {
+		t.Error("hire command missing --explain flag")
+	}
+	if hireCmd.Flags().Lookup("workspace") == nil {
+		t.Error("hire command missing --workspace flag")
+	}
 }
 
 // TestHireCommandExecution tests the actual execution of the hire command
@@ -82,9 +109,8 @@ This is a test chatmate for testing.`
 		r, w, _ := os.Pipe()
 		os.Stdout = w
 
-		// Reset flags
-		hireSpecific = []string{}
-		hireForce = false
+		// Fresh command with default flags
+		hireCmd := newTestCommand(t, "hire")
 
 		// Execute command (would normally fail due to missing directories, but that's expected)
 		err := hireCmd.RunE(hireCmd, []string{})
@@ -109,8 +135,9 @@ This is a test chatmate for testing.`
 	})
 
 	t.Run("specific chatmates flag", func(t *testing.T) {
-		// Test that specific flag sets the variable correctly
-		hireSpecific = []string{"Test"}
+		// Test that specific flag sets the option correctly
+		hireCmd := newTestCommand(t, "hire")
+		setFlags(t, hireCmd, "--specific", "Test")
 		args := []string{}
 
 		// This should use the specific chatmates from the flag
@@ -120,15 +147,15 @@ This is a test chatmate for testing.`
 		}
 
 		// Verify the flag was processed
-		if len(hireSpecific) == 0 {
+		if specific, _ := hireCmd.Flags().GetStringSlice("specific"); len(specific) == 0 {
 			t.Error("Specific flag should have been set")
 		}
 	})
 
 	t.Run("force flag behavior", func(t *testing.T) {
 		// Test force flag
-		hireForce = true
-		hireSpecific = []string{}
+		hireCmd := newTestCommand(t, "hire")
+		setFlags(t, hireCmd, "--force")
 
 		err := hireCmd.RunE(hireCmd, []string{})
 		if err != nil {
@@ -136,7 +163,7 @@ This is a test chatmate for testing.`
 		}
 
 		// The force flag should be processed
-		if !hireForce {
+		if force, _ := hireCmd.Flags().GetBool("force"); !force {
 			t.Error("Force flag should have been set")
 		}
 	})
@@ -168,14 +195,10 @@ func TestHireCommandArguments(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Capture output to prevent test noise
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
-			// Reset flags
-			hireSpecific = []string{}
-			hireForce = false
+			// Fresh command with default flags
+			hireCmd := newTestCommand(t, "hire")
 
 			// Execute command and check argument handling
 			err := hireCmd.RunE(hireCmd, tc.args)
@@ -187,3 +210,131 @@ func TestHireCommandArguments(t *testing.T) {
 		})
 	}
 }
+
+// TestHireRequireEditor tests that --require-editor blocks installation without VS Code
+func TestHireRequireEditor(t *testing.T) {
+	hireCmd := newTestCommand(t, "hire")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("PATH", t.TempDir())
+	if platform.DetectVSCode().Found {
+		t.Skip("VS Code is installed system-wide on this machine")
+	}
+
+	if hireCmd.Flags().Lookup("require-editor") == nil {
+		t.Fatal("hire command missing --require-editor flag")
+	}
+
+	chatMateManager := &manager.ChatMateManager{PromptsDir: filepath.Join(home, "prompts")}
+	if err := checkEditor(chatMateManager, true); err == nil {
+		t.Error("Expected --require-editor to block installation without VS Code")
+	}
+
+	if err := checkEditor(chatMateManager, false); err != nil {
+		t.Errorf("Missing VS Code should only warn by default: %v", err)
+	}
+
+	chatMateManager.Headless = true
+	if err := checkEditor(chatMateManager, false); err != nil {
+		t.Errorf("Headless mode should not block installation: %v", err)
+	}
+}
+
+// TestHireResumeFlag tests that --resume rejects explicit chatmate selections
+func TestHireResumeFlag(t *testing.T) {
+	hireCmd := newTestCommand(t, "hire")
+
+	if hireCmd.Flags().Lookup("resume") == nil {
+		t.Fatal("hire command missing --resume flag")
+	}
+
+	setFlags(t, hireCmd, "--resume")
+
+	if err := hireCmd.RunE(hireCmd, []string{"Solve Issue"}); err == nil {
+		t.Error("Expected error when combining --resume with chatmate names")
+	}
+}
+
+// TestHireGitFlags tests that --git and --ref reject conflicting options
+func TestHireGitFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--ref", "v2"},
+		{"--git", "https://github.com/acme/prompts", "--stdin", "--name", "Deploy"},
+		{"--git", "https://github.com/acme/prompts", "--from-registry", "Deploy"},
+		{"--git", "https://github.com/acme/prompts", "--explain"},
+	} {
+		hireCmd := newTestCommand(t, "hire")
+		setFlags(t, hireCmd, args...)
+
+		if err := hireCmd.RunE(hireCmd, nil); err == nil {
+			t.Errorf("Expected error for hire %v", args)
+		}
+	}
+}
+
+// TestHireLocalFlags tests that --path, --file, and --url reject conflicting options
+func TestHireLocalFlags(t *testing.T) {
+	for _, tc := range []struct {
+		args  []string
+		names []string
+	}{
+		{args: []string{"--path", "./prompts", "--file", "My Agent.chatmode.md"}},
+		{args: []string{"--path", "./prompts", "--git", "https://github.com/acme/prompts"}},
+		{args: []string{"--file", "My Agent.chatmode.md", "--stdin", "--name", "My Agent"}},
+		{args: []string{"--path", "./prompts", "--explain"}},
+		{args: []string{"--file", "My Agent.chatmode.md"}, names: []string{"Solve Issue"}},
+		{args: []string{"--sha256", strings.Repeat("0", 64)}},
+		{args: []string{"--url", "https://example.com/Agent.chatmode.md", "--file", "My Agent.chatmode.md"}},
+		{args: []string{"--url", "https://example.com/Agent.chatmode.md"}, names: []string{"Solve Issue"}},
+	} {
+		hireCmd := newTestCommand(t, "hire")
+		setFlags(t, hireCmd, tc.args...)
+
+		if err := hireCmd.RunE(hireCmd, tc.names); err == nil {
+			t.Errorf("Expected error for hire %v %v", tc.args, tc.names)
+		}
+	}
+}
+
+// TestPinnedReleases tests which names hire installs from a registry release
+func TestPinnedReleases(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+
+	if pins, err := pinnedReleases([]string{"Solve Issue", "Testing"}, "", now); err != nil || pins != nil {
+		t.Errorf("Expected names without versions to be installed normally, got %+v, %v", pins, err)
+	}
+
+	pins, err := pinnedReleases([]string{"Solve Issue@1.4.0"}, "", now)
+	if err != nil || len(pins) != 1 || pins[0].Name != "Solve Issue" || pins[0].Version != "1.4.0" {
+		t.Errorf("Expected the version to be pinned, got %+v, %v", pins, err)
+	}
+
+	pins, err = pinnedReleases([]string{"Solve Issue", "Testing@1.0.0"}, "2024-12-01", now)
+	if err != nil || len(pins) != 2 || pins[1].Version != "1.0.0" || !pins[1].AsOf.IsZero() {
+		t.Fatalf("Expected --as-of to pin every name, got %+v, %v", pins, err)
+	}
+	if endOfDay := time.Date(2024, 12, 2, 0, 0, 0, 0, time.Local); !pins[0].AsOf.Before(endOfDay) || pins[0].AsOf.Before(endOfDay.Add(-time.Second)) {
+		t.Errorf("Expected a date to include the whole day, got %v", pins[0].AsOf)
+	}
+
+	if pins, err := pinnedReleases([]string{"Solve Issue"}, "2030-01-01", now); err != nil || !pins[0].AsOf.Equal(now) {
+		t.Errorf("Expected a future date to mean now, got %+v, %v", pins, err)
+	}
+
+	for name, test := range map[string]struct {
+		names []string
+		asOf  string
+	}{
+		"mixed versions": {[]string{"Solve Issue@1.4.0", "Testing"}, ""},
+		"as-of alone":    {nil, "2024-12-01"},
+		"invalid date":   {[]string{"Solve Issue"}, "last week"},
+	} {
+		if _, err := pinnedReleases(test.names, test.asOf, now); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// NewHistoryCmd creates the history command.
+func NewHistoryCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history <chatmate>",
+		Short: "List the previous versions of a chatmate",
+		Long: `List the content a chatmate had before ChatMate replaced it, newest first.
+
+Whenever 'hire --force', 'update', 'sync', or a restore overwrites an
+installed chatmate with different content, the old content is kept in
+ChatMate's state directory as a numbered version. Bring one back with
+'chatmate restore <chatmate> --version <n>'. The newest 20 versions are kept
+per chatmate; files edited by hand are only kept once ChatMate replaces
+them.`,
+		Example: `  # Previous versions of a chatmate
+  chatmate history "Solve Issue"
+
+  # Bring back the content before the last update
+  chatmate restore "Solve Issue" --version 3
+
+  # As JSON, for scripts
+  chatmate history "Solve Issue" --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			versions, err := app.Manager.Versions(args[0])
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(versions, "versions")
+			}
+			view.Versions(args[0], versions)
+			return nil
+		}),
+	}
+
+	return cmd
+}
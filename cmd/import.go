@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// importOptions holds the flags of the import command.
+type importOptions struct {
+	force bool
+}
+
+// NewImportCmd creates the import command.
+func NewImportCmd(deps *Deps) *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import <directory> [chatmate names...]",
+		Short: "Install chatmate files from a directory",
+		Long: `Install chatmate files from a directory into the prompts directory.
+
+This is the counterpart of 'chatmate export': chatmates exported on another
+machine, or chatmode files from any other source, are installed as if they
+were hired. Only files ending in .chatmode.md are considered, and each must
+start with YAML frontmatter.
+
+Chatmates that are already installed are skipped unless --force is given.`,
+		Example: `  # Install every chatmode file from a directory
+  chatmate import ./chatmates
+
+  # Install specific chatmates from a directory
+  chatmate import ./chatmates "Solve Issue" "My Agent"
+
+  # Overwrite chatmates that are already installed
+  chatmate import --force ./chatmates`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			srcDir := utils.ExpandPath(args[0])
+			report, err := app.Manager.Installer().Import(app.Context, srcDir, args[1:], opts.force)
+			if err != nil {
+				return err
+			}
+			if len(report.Results) == 0 {
+				output.Printf("No chatmate files found in %s\n", srcDir)
+				return nil
+			}
+
+			output.Printf("\n✅ Imported %d chatmate(s) into %s\n", report.Installed(), report.PromptsDir)
+			view.QueuedApprovals(app.Manager.Installer().QueuedApprovals())
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "overwrite chatmates that are already installed")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// inventoryOptions holds the flags of the inventory command.
+type inventoryOptions struct {
+	format string
+}
+
+// inventoryFormats are the formats accepted by inventory --format.
+var inventoryFormats = []string{"json", "cyclonedx"}
+
+// NewInventoryCmd creates the inventory command.
+func NewInventoryCmd(deps *Deps) *cobra.Command {
+	opts := &inventoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Export the installed chatmates as a software inventory",
+		Long: `Export every chatmate installed in the prompts directory with its SHA-256
+hash, version, license, author, and provenance (where it was installed from,
+by which ChatMate version, and when), for enterprise asset management and
+security tooling.
+
+Formats:
+• json: ChatMate's own inventory format (default)
+• cyclonedx: A CycloneDX 1.5 JSON bill of materials; each chatmate is a
+  "file" component, and provenance is kept in "chatmate:" properties
+
+User-created and adopted chatmates are included; their origin tells them
+apart from chatmates installed by ChatMate. "modified" marks chatmates whose
+content changed since they were installed or adopted.`,
+		Example: `  # Export the inventory as JSON
+  chatmate inventory > chatmates.json
+
+  # Export a CycloneDX bill of materials for asset management
+  chatmate inventory --format cyclonedx > chatmates.cdx.json
+
+  # List chatmates that were changed after installation
+  chatmate inventory | jq -r '.chatmates[] | select(.modified) | .name'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			bom, err := app.Manager.BillOfMaterials()
+			if err != nil {
+				return err
+			}
+
+			switch opts.format {
+			case "json":
+				return app.WriteJSON(bom, "inventory")
+			case "cyclonedx":
+				return app.WriteStandardJSON(bom.CycloneDX(), "CycloneDX inventory")
+			default:
+				return fmt.Errorf("unknown inventory format %q (available: %s)", opts.format, strings.Join(inventoryFormats, ", "))
+			}
+		}),
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", "json", "inventory format: json or cyclonedx")
+
+	return cmd
+}
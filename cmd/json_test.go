@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+// timestampPattern matches RFC 3339 timestamps in JSON documents.
+var timestampPattern = regexp.MustCompile(`"\d{4}-\d{2}-\d{2}T[^"]+"`)
+
+// TestJSONOutputGolden tests that the JSON and YAML documents keep their
+// fields and field order, so downstream parsers don't break between releases.
+//
+// Run 'go test ./cmd -run TestJSONOutputGolden -update' after an intended
+// change of a document, and increment JSONSchemaVersion if a field was
+// removed, renamed, or changed its meaning.
+func TestJSONOutputGolden(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("CHATMATE_HEADLESS", "1")
+	t.Setenv("CHATMATE_POLICY", "")
+	t.Setenv("CHATMATE_SHARED", "")
+
+	matesDir := filepath.Join(home, "mates")
+	promptsDir := filepath.Join(home, "prompts")
+	for dir, files := range map[string][]string{
+		matesDir:   {"Review PR.chatmode.md", "Solve Issue.chatmode.md"},
+		promptsDir: {"Solve Issue.chatmode.md", "My Helper.chatmode.md"},
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		for _, name := range files {
+			content := "---\ndescription: " + strings.TrimSuffix(name, ".chatmode.md") + "\nversion: '1.0.0'\n---\nBody\n"
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to create %s: %v", name, err)
+			}
+		}
+	}
+
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		chatMateManager, err := manager.NewChatMateManager()
+		if err != nil {
+			return nil, err
+		}
+		chatMateManager.MatesDir = matesDir
+		chatMateManager.UseEmbedded = false
+		chatMateManager.PromptsDir = promptsDir
+		return chatMateManager, nil
+	}}
+
+	escapedPromptsDir, err := json.Marshal(promptsDir)
+	if err != nil {
+		t.Fatalf("Failed to encode prompts directory: %v", err)
+	}
+
+	tests := []struct {
+		golden string
+		args   []string
+	}{
+		{"list.json.golden", []string{"list", "--json"}},
+		{"list.json.golden", []string{"list", "--output", "json"}},
+		{"list-installed.json.golden", []string{"list", "--installed", "--json"}},
+		{"list-page.json.golden", []string{"list", "--json", "--limit", "2", "--page", "2"}},
+		{"status.json.golden", []string{"status", "--json"}},
+		{"status.yaml.golden", []string{"status", "--output", "yaml"}},
+		{"inventory.json.golden", []string{"inventory"}},
+		{"diff.json.golden", []string{"diff", "--output", "json"}},
+		{"which.json.golden", []string{"which", "Solve Issue", "--output", "json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var stdout bytes.Buffer
+			output.SetWriters(&stdout, &bytes.Buffer{})
+			defer output.SetWriters(nil, nil)
+
+			root := NewRootCmd(deps)
+			root.SetArgs(tt.args)
+			if err := root.Execute(); err != nil {
+				t.Fatalf("chatmate %s failed: %v", strings.Join(tt.args, " "), err)
+			}
+
+			got := strings.ReplaceAll(stdout.String(), strings.Trim(string(escapedPromptsDir), `"`), "$PROMPTS")
+			got = strings.ReplaceAll(got, matesDir, "$MATES")
+			got = timestampPattern.ReplaceAllString(got, `"$$TIMESTAMP"`)
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+					t.Fatalf("Failed to update %s: %v", path, err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", path, err)
+			}
+			if got != string(want) {
+				t.Errorf("chatmate %s output differs from %s:\n%s", strings.Join(tt.args, " "), path, got)
+			}
+		})
+	}
+}
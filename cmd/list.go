@@ -3,20 +3,46 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/jonassiebler/chatmate/cmd/view"
 	"github.com/jonassiebler/chatmate/internal/manager"
 	"github.com/spf13/cobra"
 )
 
-var (
-	listAvailable bool
-	listInstalled bool
-)
+// listOptions holds the flags of the list command.
+type listOptions struct {
+	available bool
+	installed bool
+	noCache   bool
+	long      bool
+	workspace bool
+	limit     int
+	page      int
+}
+
+// listing returns the listing the options select, with only the requested
+// page when --limit is given.
+func (opts *listOptions) listing(app *App) (*manager.Listing, error) {
+	listing, err := app.Manager.Lister().Listing(opts.available, opts.installed)
+	if err != nil {
+		return nil, err
+	}
+	if opts.limit > 0 {
+		if err := listing.Paginate(opts.limit, opts.page); err != nil {
+			return nil, err
+		}
+	}
+	return listing, nil
+}
+
+// NewListCmd creates the list command.
+func NewListCmd(deps *Deps) *cobra.Command {
+	opts := &listOptions{}
 
-// listCmd represents the list command
-var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List available and installed chatmate agents",
-	Long: `Display comprehensive information about available and installed chatmate agents.
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List available and installed chatmate agents",
+		Long: `Display comprehensive information about available and installed chatmate agents.
 	
 📋 What You'll See:
 • Available chatmates with descriptions and specializations  
@@ -33,7 +59,7 @@ var listCmd = &cobra.Command{
 • Check installation status of specific chatmates
 • Get overview of your current chatmate setup
 • Find chatmates by their specialization areas`,
-	Example: `  # List all chatmates with installation status (default)
+		Example: `  # List all chatmates with installation status (default)
   chatmate list
   
   # Show only available chatmates (not yet installed)
@@ -44,42 +70,84 @@ var listCmd = &cobra.Command{
   
   # Combine with other commands for workflows
   chatmate list --available | grep "Testing"  # Find testing-related chatmates`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		chatMateManager, err := manager.NewChatMateManager()
-		if err != nil {
-			return fmt.Errorf("failed to initialize ChatMate manager: %w", err)
-		}
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.workspace {
+				if err := app.Manager.UseWorkspace(""); err != nil {
+					return err
+				}
+			}
+			switch {
+			case opts.limit < 0:
+				return fmt.Errorf("--limit must not be negative, got %d", opts.limit)
+			case opts.page < 1:
+				return fmt.Errorf("--page must be 1 or more, got %d", opts.page)
+			case opts.page > 1 && opts.limit == 0:
+				return fmt.Errorf("--page requires --limit")
+			}
+			app.Manager.NoCache = opts.noCache
+			app.Manager.Lister().Long = opts.long
 
-		// Determine what to show based on flags
-		if listAvailable && listInstalled {
-			return chatMateManager.Lister().ListAll()
-		} else if listAvailable {
-			return chatMateManager.Lister().ListAvailable()
-		} else if listInstalled {
-			return chatMateManager.Lister().ListInstalled()
-		} else {
-			// Default: show all (both available and installed status)
-			return chatMateManager.Lister().ListAll()
-		}
-	},
-}
+			listing, err := opts.listing(app)
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(listing, "listing")
+			}
 
-func init() {
-	rootCmd.AddCommand(listCmd)
+			// Determine what to show based on flags; by default, show all
+			// (both available and installed status)
+			switch {
+			case opts.available && !opts.installed:
+				view.AvailableListing(listing)
+			case opts.installed && !opts.available:
+				view.InstalledListing(listing)
+			default:
+				view.Listing(listing, app.Manager.PromptsDirLabel())
+			}
+			return nil
+		}),
+	}
 
 	// Add flags
-	listCmd.Flags().BoolVarP(&listAvailable, "available", "a", false,
+	cmd.Flags().BoolVarP(&opts.available, "available", "a", false,
 		"Show only available chatmates")
-	listCmd.Flags().BoolVarP(&listInstalled, "installed", "i", false,
+	cmd.Flags().BoolVarP(&opts.installed, "installed", "i", false,
 		"Show only installed chatmates")
+	cmd.Flags().BoolVar(&opts.noCache, "no-cache", false,
+		"Ignore the cached inventory and rescan the chatmate directories")
+	cmd.Flags().BoolVarP(&opts.long, "long", "l", false,
+		"Show where each installed chatmate came from (source, installer version, date)")
+	cmd.Flags().BoolVar(&opts.workspace, "workspace", false,
+		"List the chatmates in the .github/prompts directory of the current repository")
+	cmd.Flags().IntVar(&opts.limit, "limit", 0,
+		"Show at most this many chatmates per page; 0 shows all of them")
+	cmd.Flags().IntVar(&opts.page, "page", 1,
+		"Page of chatmates to show with --limit, counting from 1")
+	cmd.Flags().Bool("json", false,
+		"Print the listing as JSON, same as --output json")
 
 	// Add examples
-	listCmd.Example = `  # List all chatmates (available and installed)
+	cmd.Example = `  # List all chatmates (available and installed)
   chatmate list
 
   # List only available chatmates
   chatmate list --available
   
   # List only installed chatmates
-  chatmate list --installed`
+  chatmate list --installed
+
+  # Show where installed chatmates came from (for security reviews)
+  chatmate list --installed --long
+
+  # Chatmates installed into the current repository with 'hire --workspace'
+  chatmate list --workspace
+
+  # The second page of 50 installed chatmates
+  chatmate list --installed --limit 50 --page 2
+
+  # Installed chatmates for scripts
+  chatmate list --installed --output json | jq -r '.chatmates[].name'`
+
+	return cmd
 }
@@ -1,12 +1,15 @@
 package cmd
 
 import (
-	"os"
 	"testing"
 )
 
 // TestListCommandExists tests that the list command is properly defined
 func TestListCommandExists(t *testing.T) {
+	t.Parallel()
+
+	listCmd := newTestCommand(t, "list")
+
 	if listCmd == nil {
 		t.Fatal("list command is not defined")
 	}
@@ -22,6 +25,10 @@ func TestListCommandExists(t *testing.T) {
 
 // TestListCommandFlags tests that required flags are defined
 func TestListCommandFlags(t *testing.T) {
+	t.Parallel()
+
+	listCmd := newTestCommand(t, "list")
+
 	// Test that available flag exists
 	availableFlag := listCmd.Flags().Lookup("available")
 	if availableFlag == nil {
@@ -33,6 +40,13 @@ func TestListCommandFlags(t *testing.T) {
 	if installedFlag == nil {
 		t.Error("list command missing --installed flag")
 	}
+
+	// Test that the pagination flags exist
+	for _, name := range []string{"limit", "page"} {
+		if listCmd.Flags().Lookup(name) == nil {
+			t.Errorf("list command missing --%s flag", name)
+		}
+	}
 }
 
 // TestListCommandExecution tests the actual execution of the list command
@@ -57,14 +71,10 @@ func TestListCommandExecution(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Capture output to prevent test noise
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
-			// Reset flags
-			listAvailable = false
-			listInstalled = false
+			// Fresh command with default flags
+			listCmd := newTestCommand(t, "list")
 
 			// Parse flags if provided
 			if len(tc.args) > 0 {
@@ -87,10 +97,11 @@ func TestListCommandExecution(t *testing.T) {
 
 // TestListCommandFlagBehavior tests flag behavior
 func TestListCommandFlagBehavior(t *testing.T) {
-	t.Run("available flag sets variable", func(t *testing.T) {
-		// Reset flags
-		listAvailable = false
-		listInstalled = false
+	t.Parallel()
+
+	t.Run("available flag sets option", func(t *testing.T) {
+		// Fresh command with default flags
+		listCmd := newTestCommand(t, "list")
 
 		// Parse available flag
 		err := listCmd.ParseFlags([]string{"--available"})
@@ -98,15 +109,14 @@ func TestListCommandFlagBehavior(t *testing.T) {
 			t.Fatalf("Failed to parse available flag: %v", err)
 		}
 
-		if !listAvailable {
+		if available, _ := listCmd.Flags().GetBool("available"); !available {
 			t.Error("Available flag should be true")
 		}
 	})
 
-	t.Run("installed flag sets variable", func(t *testing.T) {
-		// Reset flags
-		listAvailable = false
-		listInstalled = false
+	t.Run("installed flag sets option", func(t *testing.T) {
+		// Fresh command with default flags
+		listCmd := newTestCommand(t, "list")
 
 		// Parse installed flag
 		err := listCmd.ParseFlags([]string{"--installed"})
@@ -114,15 +124,14 @@ func TestListCommandFlagBehavior(t *testing.T) {
 			t.Fatalf("Failed to parse installed flag: %v", err)
 		}
 
-		if !listInstalled {
+		if installed, _ := listCmd.Flags().GetBool("installed"); !installed {
 			t.Error("Installed flag should be true")
 		}
 	})
 
 	t.Run("both flags can be set", func(t *testing.T) {
-		// Reset flags
-		listAvailable = false
-		listInstalled = false
+		// Fresh command with default flags
+		listCmd := newTestCommand(t, "list")
 
 		// Parse both flags
 		err := listCmd.ParseFlags([]string{"--available", "--installed"})
@@ -130,10 +139,10 @@ func TestListCommandFlagBehavior(t *testing.T) {
 			t.Fatalf("Failed to parse both flags: %v", err)
 		}
 
-		if !listAvailable {
+		if available, _ := listCmd.Flags().GetBool("available"); !available {
 			t.Error("Available flag should be true")
 		}
-		if !listInstalled {
+		if installed, _ := listCmd.Flags().GetBool("installed"); !installed {
 			t.Error("Installed flag should be true")
 		}
 	})
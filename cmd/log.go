@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// logOptions holds the flags of the log command.
+type logOptions struct {
+	since string
+	limit int
+}
+
+// NewLogCmd creates the log command.
+func NewLogCmd(deps *Deps) *cobra.Command {
+	opts := &logOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "log [chatmate]",
+		Short: "Show the history of installed, updated, and uninstalled chatmates",
+		Long: `Show every logged change to the chatmates in the prompts directory, newest
+first: when a chatmate was installed, updated (with the versions it moved
+between), or uninstalled, and where installed content came from.
+
+The log lists changes to individual chatmates; the Recent Activity section
+of 'chatmate status' lists the commands that made them. Files edited or
+removed by hand are not logged. The newest 1000 changes are kept.`,
+		Example: `  # Everything ChatMate changed
+  chatmate log
+
+  # Changes to one chatmate
+  chatmate log "Solve Issue"
+
+  # Changes of the last week, the ten newest
+  chatmate log --since 7d --limit 10
+
+  # As JSON, for scripts
+  chatmate log --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			var since time.Time
+			if opts.since != "" {
+				var err error
+				if since, err = parseSince(opts.since, time.Now()); err != nil {
+					return err
+				}
+			}
+			var name string
+			if len(args) == 1 {
+				name = args[0]
+			}
+
+			events, err := app.Manager.Activity(name, since)
+			if err != nil {
+				return err
+			}
+			if opts.limit > 0 && len(events) > opts.limit {
+				events = events[:opts.limit]
+			}
+			if app.Structured() {
+				return app.Write(events, "activity")
+			}
+			view.Activity(events)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&opts.since, "since", "",
+		"Only show changes since a duration ago (24h, 7d) or a date (2006-01-02, RFC 3339)")
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 0, "Show at most this many changes (0 shows all)")
+
+	return cmd
+}
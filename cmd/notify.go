@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jonassiebler/chatmate/internal/notify"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// notifyWebhook sends report to the webhook configured in the team policy,
+// if there is one.
+//
+// The webhook only observes the operation, so delivery failures are reported
+// as warnings and never change the outcome of the command.
+func notifyWebhook(app *App, report *notify.Report) {
+	teamPolicy, err := app.Manager.Policy()
+	if err != nil || teamPolicy.Webhook == "" {
+		return
+	}
+
+	if err := notify.Post(app.Context, teamPolicy.Webhook, teamPolicy.WebhookFormat, report); err != nil {
+		output.Warnf("Could not notify the team policy webhook: %v", err)
+		return
+	}
+	output.Debugf("Reported %s to the team policy webhook\n", report.Operation)
+}
+
+// newReport starts a report of operation on this machine's prompts directory.
+func newReport(app *App, operation string, opErr error) *notify.Report {
+	report := notify.NewReport(operation, opErr)
+	report.Version = version
+	report.PromptsDir = app.Manager.PromptsDir
+	return report
+}
+
+// writeSummary writes report to standard output in format: as a JSON or
+// YAML document for --output json and yaml, or as a chat message (see
+// notify.Markdown) for --output slack and teams.
+func writeSummary(app *App, report *notify.Report, format string) error {
+	if format == OutputJSON || format == OutputYAML {
+		app.Config.Output = format
+		return app.Write(report, "summary")
+	}
+	if _, err := io.WriteString(app.Stdout, notify.Markdown(report, format)); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
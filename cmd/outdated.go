@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// outdatedOptions holds the flags of the outdated command.
+type outdatedOptions struct {
+	all bool
+}
+
+// NewOutdatedCmd creates the outdated command.
+func NewOutdatedCmd(deps *Deps) *cobra.Command {
+	opts := &outdatedOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "List installed chatmates with a newer shipped version",
+		Long: `List the installed chatmates whose shipped version differs from the
+installed one, without changing anything.
+
+Chatmates are compared by the SHA-256 of their content, so a chatmate is
+outdated whenever its content differs, even if the frontmatter version was
+not bumped. Use --all to list the up-to-date chatmates too.
+
+For every outdated chatmate the installed and available frontmatter versions
+are shown, together with what 'chatmate update' and 'chatmate sync' do:
+• will be updated: the shipped version replaces the installed one
+• kept: edited locally: the chatmate changed since it was installed
+• kept: newer version not pinned: the available version is outside the
+  range the team policy pins the chatmate to, or the chatmate was installed
+  from a registry release
+
+Pins are version ranges in the team policy (see 'chatmate apply'):
+
+  pins:
+    Review PR: ^1.2
+    Testing: ~2.0.1
+
+'chatmate update --force' replaces kept chatmates too.`,
+		Example: `  # See which chatmates are outdated and which will be updated
+  chatmate outdated
+
+  # Every installed shipped chatmate, including the up-to-date ones
+  chatmate outdated --all
+
+  # Outdated chatmates for scripts
+  chatmate outdated --output json | jq -r '.chatmates[] | select(.state == "pinned") | .filename'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			report, err := app.Manager.Installer().Outdated()
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(report, "outdated chatmates")
+			}
+			view.Outdated(report, opts.all)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "list up-to-date chatmates too")
+
+	return cmd
+}
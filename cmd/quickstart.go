@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+	"github.com/spf13/cobra"
+)
+
+// NewQuickstartCmd creates the quickstart command.
+func NewQuickstartCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quickstart",
+		Short: "Set up ChatMate in one step",
+		Long: `Set up ChatMate without any questions: the shortest path from installing
+ChatMate to using your first chatmate.
+
+🚀 Setup Steps:
+1. Detects VS Code and the prompts directory
+2. Enables prompt files ("chat.promptFiles") in the VS Code user settings
+3. Installs the recommended chatmates: ` + strings.Join(manager.RecommendedChatmates, ", ") + `
+4. Validates the installation
+5. Prints what to do next
+
+Chatmates that are already installed are kept. In headless mode the VS Code
+settings are left alone and the summary explains how to move the chatmates
+to a machine with VS Code.`,
+		Example: `  # Set up ChatMate
+  chatmate quickstart`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if err := checkEditor(app.Manager, false); err != nil {
+				return err
+			}
+			if err := migrateLegacyNames(app); err != nil {
+				return err
+			}
+
+			enablePromptFiles(app.Manager)
+
+			if _, err := app.Manager.Installer().InstallSpecific(app.Context, manager.RecommendedChatmates, false); err != nil {
+				return err
+			}
+
+			report, err := app.Manager.Validator().ValidateInstallation(app.Context)
+			if err != nil {
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			if !report.Valid() {
+				output.Println()
+				printValidationReport(report)
+				return fmt.Errorf("setup finished with problems; fix the failed checks above and run 'chatmate quickstart' again")
+			}
+
+			printQuickstartSummary(app.Manager)
+			return nil
+		}),
+	}
+
+	return cmd
+}
+
+// enablePromptFiles turns on prompt files in the VS Code user settings.
+// A failure is only a warning, since the user can enable the setting by hand.
+func enablePromptFiles(chatMateManager *manager.ChatMateManager) {
+	settingsPath := chatMateManager.SettingsPath()
+	if settingsPath == "" {
+		return
+	}
+
+	changed, err := platform.EnablePromptFiles(settingsPath)
+	if err != nil {
+		output.Warnf("Could not enable prompt files: %v", err)
+		output.Printf("   Enable %q in the VS Code settings yourself.\n", platform.PromptFilesSetting)
+		return
+	}
+	if changed {
+		output.Printf("⚙️  Enabled %s in %s\n", platform.PromptFilesSetting, settingsPath)
+		return
+	}
+	output.Debugf("%s is already enabled in %s\n", platform.PromptFilesSetting, settingsPath)
+}
+
+// printQuickstartSummary prints the five lines a new user needs after setup.
+func printQuickstartSummary(chatMateManager *manager.ChatMateManager) {
+	output.Println()
+	if chatMateManager.Headless {
+		output.Printf("🎉 ChatMate is ready: %d chatmates installed in headless mode.\n", len(manager.RecommendedChatmates))
+		output.Println("   1. Export them: chatmate export ./chatmates")
+		output.Println("   2. On the machine with VS Code: chatmate import ./chatmates")
+		output.Println("   3. Restart VS Code, open Copilot Chat, and type: @Solve Issue <describe a bug>")
+		output.Println("   4. Learn the everyday workflows: chatmate tutorial daily-dev")
+		return
+	}
+
+	output.Printf("🎉 ChatMate is ready: %d chatmates installed.\n", len(manager.RecommendedChatmates))
+	output.Println("   1. Restart VS Code (close all windows, then reopen)")
+	output.Println("   2. Open Copilot Chat and type: @Solve Issue <describe a bug>")
+	output.Println("   3. See all chatmates: chatmate list --available")
+	output.Println("   4. Learn the everyday workflows: chatmate tutorial daily-dev")
+}
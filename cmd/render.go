@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/spf13/cobra"
+)
+
+// renderOptions holds the flags of the render command.
+type renderOptions struct {
+	output string
+}
+
+// NewRenderCmd creates the render command.
+func NewRenderCmd(deps *Deps) *cobra.Command {
+	opts := &renderOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "render <chatmate name>",
+		Short: "Print the file VS Code will see for a chatmate",
+		Long: `Print the exact file content 'chatmate hire' would write for an available
+chatmate, without installing it.
+
+The chatmate is matched and checked like 'chatmate hire' does, so a chatmate
+that renders is one that can be hired. Installation copies the content byte
+for byte: what render prints is what VS Code reads from the prompts
+directory.
+
+The content is written to stdout unless --output is given.`,
+		Example: `  # Preview a chatmate before hiring it
+  chatmate render "Solve Issue"
+
+  # Write the content to a file
+  chatmate render "Solve Issue" -o solve-issue.chatmode.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			rendering, err := app.Manager.Installer().Render(args[0])
+			if err != nil {
+				return err
+			}
+
+			if opts.output == "" {
+				_, err := output.Stdout().Write(rendering.Content)
+				return err
+			}
+
+			if err := files.CheckWrite("write", opts.output); err != nil {
+				return err
+			}
+			// Created like an installed chatmate, with the fileMode setting
+			mode := app.Manager.FileMode
+			if mode == 0 {
+				mode = manager.DefaultFileMode
+			}
+			if err := files.WriteFileAtomic(opts.output, rendering.Content, mode); err != nil {
+				return fmt.Errorf("failed to write %s: %w", opts.output, err)
+			}
+
+			output.Printf("✅ Wrote %s (installed as %s)\n", opts.output, rendering.Filename)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "write the content to this file instead of stdout")
+
+	return cmd
+}
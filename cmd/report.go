@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jonassiebler/chatmate/cmd/tutorial"
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/spf13/cobra"
+)
+
+// reportOptions holds the flags of the report command.
+type reportOptions struct {
+	html string
+}
+
+// NewReportCmd creates the report command.
+func NewReportCmd(deps *Deps) *cobra.Command {
+	opts := &reportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "report --html <file>",
+		Short: "Write an offline HTML report of the chatmates and their validation",
+		Long: `Write a single self-contained HTML page to share with teammates or attach
+to onboarding docs. It needs no network access or other files to open.
+
+📄 What's in the Report:
+• Installed and available chatmates with their descriptions, versions,
+  authors, sample prompts, status, and provenance
+• The results of 'chatmate validate'
+• The tutorials and the scenarios your chatmates feature in
+
+Use '--html -' to write the page to standard output.`,
+		Example: `  # Write the report and open it
+  chatmate report --html chatmates.html && open chatmates.html
+
+  # Attach it to the onboarding docs of a repository
+  chatmate report --html docs/chatmates.html`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.html == "" {
+				return fmt.Errorf("--html <file> is required")
+			}
+			report, err := buildReport(app)
+			if err != nil {
+				return err
+			}
+
+			var page bytes.Buffer
+			if err := view.HTMLReport(&page, report); err != nil {
+				return fmt.Errorf("failed to render report: %w", err)
+			}
+			if opts.html == "-" {
+				_, err := output.Stdout().Write(page.Bytes())
+				return err
+			}
+			if err := files.CheckWrite("write", opts.html); err != nil {
+				return err
+			}
+			if err := files.WriteFileAtomic(opts.html, page.Bytes(), 0644); err != nil {
+				return fmt.Errorf("failed to write report %s: %w", opts.html, err)
+			}
+			output.Printf("✅ Wrote the report of %d chatmates to %s\n", len(report.Chatmates), opts.html)
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&opts.html, "html", "", "Write the report as HTML to this file; - for standard output")
+
+	return cmd
+}
+
+// buildReport collects the chatmates, the validation, and the tutorials the
+// HTML report shows. A validation that cannot run leaves the report without
+// it, so the chatmates can still be shared.
+func buildReport(app *App) (*view.Report, error) {
+	app.Manager.Lister().Long = true
+	listing, err := app.Manager.Lister().Listing(true, true)
+	if err != nil {
+		return nil, err
+	}
+	available, err := app.Manager.AvailableMetadata()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := app.Manager.InstalledMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	// Installed files describe what VS Code uses; available ones the rest
+	metadata := make(map[string]manager.ChatmateMetadata)
+	for _, meta := range append(available, installed...) {
+		metadata[meta.Filename] = meta
+	}
+	report := &view.Report{
+		Version:    version,
+		Generated:  time.Now(),
+		PromptsDir: listing.PromptsDir,
+	}
+	for _, listed := range listing.Chatmates {
+		chatmate := view.ReportChatmate{ListedChatmate: listed}
+		if meta, ok := metadata[listed.Filename]; ok {
+			chatmate.Description = meta.Description
+			chatmate.Author = meta.Author.Name
+			chatmate.Version = meta.Version
+			for _, example := range meta.Examples {
+				chatmate.Examples = append(chatmate.Examples, example.Prompt)
+			}
+		}
+		report.Chatmates = append(report.Chatmates, chatmate)
+	}
+	sort.SliceStable(report.Chatmates, func(i, j int) bool { return report.Chatmates[i].Name < report.Chatmates[j].Name })
+
+	if validation, err := app.Manager.Validator().ValidateInstallation(app.Context); err == nil {
+		report.Validation = validation
+	} else {
+		output.Warnf("Could not validate the installation: %v", err)
+	}
+
+	chatmates := make([]manager.ChatmateMetadata, 0, len(metadata))
+	for _, meta := range metadata {
+		chatmates = append(chatmates, meta)
+	}
+	sort.Slice(chatmates, func(i, j int) bool { return chatmates[i].Filename < chatmates[j].Filename })
+	for _, tut := range tutorial.GetAvailableTutorials() {
+		entry := view.ReportTutorial{Name: tut.Name, Description: tut.Description}
+		for _, scenario := range tutorial.GetScenarios(tut.Name, chatmates) {
+			entry.Scenarios = append(entry.Scenarios, view.ReportScenario(scenario))
+		}
+		report.Tutorials = append(report.Tutorials, entry)
+	}
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// restoreOptions holds the flags of the restore command.
+type restoreOptions struct {
+	clean   bool
+	version int
+}
+
+// NewRestoreCmd creates the restore command.
+func NewRestoreCmd(deps *Deps) *cobra.Command {
+	opts := &restoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive> | restore <chatmate> --version <n>",
+		Short: "Restore the prompts directory from a backup, or a previous version of a chatmate",
+		Long: `Write the files of a backup made by 'chatmate backup' back into the prompts
+directory, replacing the files with the same name.
+
+The archive is the path of a backup, the name of one listed by
+'chatmate backup --list', or "latest" for the newest one. The whole archive
+is checked before anything is written, and unless automatic backups are
+disabled, the prompts directory is backed up first, so a restore can be
+undone.
+
+Files that are not in the backup are kept; with --clean, installed chatmates
+that are not in the backup are removed, so the chatmates are exactly those
+of the backup. Restored chatmates that differ from what ChatMate installed
+are reported by 'chatmate verify' and kept by 'chatmate update' unless it is
+run with --force.
+
+With --version, the argument is a chatmate and the numbered version listed
+by 'chatmate history' is written back instead. The content it replaces is
+kept as a new version, so the restore can be undone the same way.`,
+		Example: `  # Restore the newest backup, e.g. after an accidental 'uninstall --all'
+  chatmate restore latest
+
+  # Restore a listed backup exactly, removing chatmates installed since
+  chatmate restore --clean prompts-20250102T150405.000Z.tar.gz
+
+  # Restore an archive copied from another machine
+  chatmate restore ~/Downloads/prompts-20250102T150405.000Z.tar.gz
+
+  # Bring back a chatmate as it was before the last update
+  chatmate history "Solve Issue"
+  chatmate restore "Solve Issue" --version 3`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if cmd.Flags().Changed("version") {
+				if opts.clean {
+					return fmt.Errorf("--clean cannot be used with --version")
+				}
+				if opts.version < 1 {
+					return fmt.Errorf("invalid --version %d: use a version listed by 'chatmate history %s'", opts.version, args[0])
+				}
+				if !output.Confirm("Restore version %d of %s in %s?", opts.version, args[0], app.Manager.PromptsDir) {
+					output.Println("❌ Restore cancelled by user")
+					return nil
+				}
+				report, err := app.Manager.RestoreVersion(args[0], opts.version)
+				if err != nil {
+					return err
+				}
+				if app.Structured() {
+					return app.Write(report, "restore report")
+				}
+				view.VersionRestored(report)
+				return nil
+			}
+
+			action := "replacing the files with the same name"
+			if opts.clean {
+				action += " and removing the chatmates not in the backup"
+			}
+			if !output.Confirm("Restore %s into %s, %s?", args[0], app.Manager.PromptsDir, action) {
+				output.Println("❌ Restore cancelled by user")
+				return nil
+			}
+
+			report, err := app.Manager.RestoreBackup(args[0], opts.clean)
+			if err != nil {
+				if report != nil && report.Backup != nil {
+					return fmt.Errorf("%w; the prompts directory was backed up to %s before restoring", err, report.Backup.Path)
+				}
+				return err
+			}
+			if app.Structured() {
+				return app.Write(report, "restore report")
+			}
+			view.Restored(report)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.clean, "clean", false,
+		"remove installed chatmates that are not in the backup")
+	cmd.Flags().IntVar(&opts.version, "version", 0,
+		"restore this previous version of the chatmate given as argument (see 'chatmate history')")
+
+	return cmd
+}
@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 	"github.com/spf13/cobra"
 )
 
@@ -12,11 +19,21 @@ var (
 	date    = "unknown"
 )
 
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:   "chatmate",
-	Short: "Open source collection of specialized AI agents for VS Code Copilot Chat",
-	Long: `ChatMate is a CLI tool for managing specialized AI agents (chatmates) for VS Code Copilot Chat.
+// NewRootCmd creates the chatmate command with all of its subcommands.
+//
+// Every call builds a new command tree with its own flags, so the result can
+// be executed independently of other trees.
+//
+// Parameters:
+//   - deps: Dependencies passed on to the subcommands
+//
+// Returns:
+//   - *cobra.Command: The root command, ready to Execute
+func NewRootCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chatmate",
+		Short: "Open source collection of specialized AI agents for VS Code Copilot Chat",
+		Long: `ChatMate is a CLI tool for managing specialized AI agents (chatmates) for VS Code Copilot Chat.
 Each chatmate is a carefully crafted prompt designed to excel at specific development tasks.
 
 🤖 What are Chatmates?
@@ -44,7 +61,7 @@ Chatmates are specialized AI agents that bring expertise in specific areas:
   
   # Remove chatmates you don't need
   chatmate uninstall "Create PR"`,
-	Example: `  # Install all available chatmates (recommended for new users)
+		Example: `  # Install all available chatmates (recommended for new users)
   chatmate hire
   
   # List available chatmates with installation status
@@ -59,26 +76,158 @@ Chatmates are specialized AI agents that bring expertise in specific areas:
   # Remove specific chatmates
   chatmate uninstall "Create PR" "Merge PR"
   
-  # Force reinstall all chatmates (useful after updates)
-  chatmate hire --force
+  # Update installed chatmates that changed in this release
+  chatmate update
+  
+  # Install missing and update changed chatmates in one step
+  chatmate sync
+  
+  # Check the setup and repair common problems
+  chatmate doctor --fix
   
   # View system configuration and paths
   chatmate config`,
-	Version: fmt.Sprintf("%s (%s) built on %s", version, commit, date),
+		Version: fmt.Sprintf("%s (%s) built on %s", version, commit, date),
+	}
+
+	// Global flags can be added here
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "suppress informational output (errors are still printed to stderr)")
+	cmd.PersistentFlags().BoolP("yes", "y", false, "answer yes to every confirmation prompt (also $CHATMATE_ASSUME_YES)")
+	cmd.PersistentFlags().Duration("timeout", 0, "give up when the command takes longer than this (e.g., 30s); 0 means no limit")
+	cmd.PersistentFlags().String("editor", "", "VS Code build or fork to install chatmates for: stable, insiders, vscodium, oss, or cursor (default: $CHATMATE_EDITOR, or detected)")
+	cmd.PersistentFlags().Bool("dry-run", false, "show which files hire, import, uninstall, self uninstall, and validate --clean-sync-conflicts would create, overwrite, or remove without changing anything")
+	cmd.PersistentFlags().Bool("read-only", false, "never write any file (prompts directory, state, cache, settings); commands that change chatmates fail")
+	cmd.PersistentFlags().String("output", OutputText, "output format of informational commands (list, status, config, validate, ...): text, json, or yaml")
+
+	cmd.PersistentPreRunE = configureOutput
+
+	cmd.AddCommand(
+		NewAdoptCmd(deps),
+		NewAliasCmd(deps),
+		NewApplyCmd(deps),
+		NewAuthoringCmd(deps),
+		NewBackupCmd(deps),
+		NewBrowseCmd(deps),
+		NewCacheCmd(deps),
+		NewCompletionCmd(),
+		NewConfigCmd(deps),
+		NewDiffCmd(deps),
+		NewDoctorCmd(deps),
+		NewExportCmd(deps),
+		NewGenerateShimCmd(),
+		NewHireCmd(deps),
+		NewHistoryCmd(deps),
+		NewImportCmd(deps),
+		NewInventoryCmd(deps),
+		NewListCmd(deps),
+		NewLogCmd(deps),
+		NewOutdatedCmd(deps),
+		NewQuickstartCmd(deps),
+		NewRenderCmd(deps),
+		NewReportCmd(deps),
+		NewRestoreCmd(deps),
+		NewSchemaCmd(),
+		NewSelfCmd(deps),
+		NewShowCmd(deps),
+		NewSourceCmd(deps),
+		NewStatusCmd(deps),
+		NewSyncCmd(deps),
+		NewTroubleshootCmd(deps),
+		NewTutorialCmd(deps),
+		NewUninstallCmd(deps),
+		NewUpdateCmd(deps),
+		NewValidateCmd(deps),
+		NewVerifyCmd(deps),
+		NewVersionCmd(),
+		NewWhichCmd(deps),
+	)
+
+	return cmd
 }
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-// This is called by main.main(). It only needs to happen once to the rootCmd.
+// Execute builds the command tree with the default dependencies and runs it.
+// This is called by main.main().
+//
+// The command runs with a context that Ctrl-C cancels (see interruptContext).
 func Execute() error {
-	return rootCmd.Execute()
+	deps := DefaultDeps()
+	start := time.Now()
+	ctx, stop := interruptContext()
+	defer stop()
+	root := NewRootCmd(deps)
+	root.SetArgs(expandAlias(root, os.Args[1:]))
+	executed, err := root.ExecuteContextC(ctx)
+	recordSummary(deps, executed, err, time.Since(start))
+	if err != nil {
+		printRemedy(err)
+	}
+	return err
 }
 
-// GetRootCommand returns the root command for testing purposes
+// interruptContext returns a context that is cancelled by the first
+// interrupt (Ctrl-C) or termination signal, so the running operation stops
+// before its next chatmate and an interrupted installation can be resumed.
+// After the first signal the default handling is restored: a second Ctrl-C
+// terminates immediately.
+//
+// Returns:
+//   - context.Context: Context cancelled on interrupt
+//   - func(): Stops listening for signals and releases the context
+func interruptContext() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			signal.Stop(signals)
+			output.Warnf("Interrupted: stopping before the next chatmate (press Ctrl-C again to quit immediately)")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// GetRootCommand returns a new root command for testing purposes
 func GetRootCommand() *cobra.Command {
-	return rootCmd
+	return NewRootCmd(DefaultDeps())
 }
 
-func init() {
-	// Global flags can be added here
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+// configureOutput applies the global verbosity, confirmation, and read-only
+// flags to the output and file layers.
+func configureOutput(cmd *cobra.Command, args []string) error {
+	config, err := readConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	if config.settingsErr != nil {
+		output.Warnf("Ignoring the configuration file: %v", config.settingsErr)
+	}
+	output.SetPrompter(output.SelectPrompter(config.AssumeYes))
+	files.SetReadOnly(config.ReadOnly)
+	if config.ASCII != nil {
+		output.SetUnicode(!*config.ASCII)
+	}
+	output.SetLocale(config.Locale)
+
+	switch {
+	case config.Quiet:
+		output.SetLevel(output.LevelQuiet)
+		// Usage text on errors is noise for unattended runs
+		cmd.Root().SilenceUsage = true
+	case config.Verbose:
+		output.SetLevel(output.LevelVerbose)
+	default:
+		output.SetLevel(output.LevelNormal)
+	}
+
+	return nil
 }
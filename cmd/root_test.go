@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"errors"
+	"os"
 	"strings"
 	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
 )
 
 // TestExecuteFunction tests the Execute function for coverage
@@ -16,6 +22,8 @@ func TestExecuteFunction(t *testing.T) {
 
 // TestGetRootCommandFunction tests the GetRootCommand function for coverage
 func TestGetRootCommandFunction(t *testing.T) {
+	t.Parallel()
+
 	cmd := GetRootCommand()
 	if cmd == nil {
 		t.Error("GetRootCommand returned nil")
@@ -26,8 +34,50 @@ func TestGetRootCommandFunction(t *testing.T) {
 	}
 }
 
+// TestCommandTreesAreIndependent tests that flags set on one command tree
+// don't leak into another
+func TestCommandTreesAreIndependent(t *testing.T) {
+	t.Parallel()
+
+	first := newTestCommand(t, "hire")
+	second := newTestCommand(t, "hire")
+
+	setFlags(t, first, "--force", "--specific", "Testing")
+
+	if force, _ := second.Flags().GetBool("force"); force {
+		t.Error("--force set on one tree should not affect another")
+	}
+	if specific, _ := second.Flags().GetStringSlice("specific"); len(specific) != 0 {
+		t.Errorf("--specific set on one tree should not affect another, got %v", specific)
+	}
+}
+
+// TestDepsNewManager tests that commands create their manager through Deps
+func TestDepsNewManager(t *testing.T) {
+	t.Parallel()
+
+	deps := &Deps{NewManager: func() (*manager.ChatMateManager, error) {
+		return nil, errors.New("no manager in this test")
+	}}
+	root := NewRootCmd(deps)
+
+	for _, path := range [][]string{{"list"}, {"status"}, {"hire"}, {"self", "info"}} {
+		cmd, _, err := root.Find(path)
+		if err != nil {
+			t.Fatalf("Command %v not found: %v", path, err)
+		}
+		if err := cmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "no manager in this test") {
+			t.Errorf("%v should use Deps.NewManager, got %v", path, err)
+		}
+	}
+}
+
 // TestRootCommandExists tests that the root command is properly defined
 func TestRootCommandExists(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	if rootCmd == nil {
 		t.Fatal("root command is not defined")
 	}
@@ -43,6 +93,10 @@ func TestRootCommandExists(t *testing.T) {
 
 // TestRootCommandFlags tests that persistent flags are defined
 func TestRootCommandFlags(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Test that verbose flag exists
 	verboseFlag := rootCmd.PersistentFlags().Lookup("verbose")
 	if verboseFlag == nil {
@@ -52,15 +106,48 @@ func TestRootCommandFlags(t *testing.T) {
 
 // TestSubcommands tests that all expected subcommands are registered
 func TestSubcommands(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	expectedCommands := []string{
+		"adopt",
+		"alias",
+		"apply",
+		"authoring",
+		"backup",
+		"browse",
+		"cache",
 		"completion",
 		"config",
+		"diff",
+		"doctor",
+		"export",
+		"generate-shim",
 		"hire",
+		"history",
+		"import",
+		"inventory",
 		"list",
+		"log",
+		"outdated",
+		"quickstart",
+		"report",
+		"restore",
+		"schema",
+		"self",
+		"show",
+		"source",
 		"status",
+		"sync",
+		"troubleshoot",
 		"tutorial",
 		"uninstall",
+		"update",
+		"validate",
+		"verify",
 		"version",
+		"which",
 	}
 
 	commands := rootCmd.Commands()
@@ -87,6 +174,10 @@ func TestSubcommands(t *testing.T) {
 
 // TestRootCommandVersion tests the version functionality
 func TestRootCommandVersion(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Test that version is set correctly
 	expectedVersion := "dev (none) built on unknown"
 	actualVersion := rootCmd.Version
@@ -103,6 +194,10 @@ func TestRootCommandVersion(t *testing.T) {
 
 // TestRootCommandHelp tests that help information is comprehensive
 func TestRootCommandHelp(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	helpText := rootCmd.Long
 
 	if helpText == "" {
@@ -128,6 +223,10 @@ func TestRootCommandHelp(t *testing.T) {
 
 // TestRootCommandUsage tests usage examples
 func TestRootCommandUsage(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	example := rootCmd.Example
 
 	if example == "" {
@@ -150,6 +249,10 @@ func TestRootCommandUsage(t *testing.T) {
 
 // TestCommandStructure tests overall command structure
 func TestCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Test that root command has subcommands
 	if !rootCmd.HasSubCommands() {
 		t.Error("Root command should have subcommands")
@@ -168,6 +271,10 @@ func TestCommandStructure(t *testing.T) {
 
 // TestPersistentFlags tests persistent flag functionality
 func TestPersistentFlags(t *testing.T) {
+	t.Parallel()
+
+	rootCmd := NewRootCmd(DefaultDeps())
+
 	// Test verbose flag default value
 	verboseFlag := rootCmd.PersistentFlags().Lookup("verbose")
 	if verboseFlag == nil {
@@ -183,3 +290,75 @@ func TestPersistentFlags(t *testing.T) {
 		t.Errorf("Verbose flag shorthand should be 'v', got '%s'", verboseFlag.Shorthand)
 	}
 }
+
+// TestQuietFlag tests the quiet persistent flag and its interaction with verbose
+func TestQuietFlag(t *testing.T) {
+	rootCmd := NewRootCmd(DefaultDeps())
+
+	quietFlag := rootCmd.PersistentFlags().Lookup("quiet")
+	if quietFlag == nil {
+		t.Fatal("root command missing --quiet persistent flag")
+	}
+	if quietFlag.DefValue != "false" {
+		t.Errorf("Quiet flag default should be false, got %s", quietFlag.DefValue)
+	}
+
+	defer output.SetLevel(output.LevelNormal)
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Bool("quiet", true, "")
+	cmd.Flags().Bool("verbose", false, "")
+	if err := configureOutput(cmd, nil); err != nil {
+		t.Fatalf("configureOutput failed: %v", err)
+	}
+	if !output.IsQuiet() {
+		t.Error("Expected quiet output level")
+	}
+
+	_ = cmd.Flags().Set("verbose", "true")
+	if err := configureOutput(cmd, nil); err == nil {
+		t.Error("Expected error when combining --quiet and --verbose")
+	}
+}
+
+// newTestCommand returns the command at path from a new command tree, so a
+// test can set its flags without affecting other tests.
+func newTestCommand(t *testing.T, path ...string) *cobra.Command {
+	t.Helper()
+
+	root := NewRootCmd(DefaultDeps())
+	cmd, _, err := root.Find(path)
+	if err != nil || cmd == root {
+		t.Fatalf("Command %q not found: %v", strings.Join(path, " "), err)
+	}
+	return cmd
+}
+
+// discardStdout sends standard output to the null device until the test ends.
+//
+// The null device is opened rather than wrapped with os.NewFile: a wrapper of
+// a descriptor it does not own closes that descriptor when it is garbage
+// collected, which may by then belong to another test's pipe.
+func discardStdout(t *testing.T) {
+	t.Helper()
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	old := os.Stdout
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = old
+		devNull.Close()
+	})
+}
+
+// setFlags parses flag arguments into cmd, failing the test on invalid flags.
+func setFlags(t *testing.T, cmd *cobra.Command, args ...string) {
+	t.Helper()
+
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("Failed to parse flags %v: %v", args, err)
+	}
+}
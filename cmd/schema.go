@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewSchemaCmd creates the schema command.
+func NewSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [kind]",
+		Short: "Print the JSON Schema for files ChatMate reads",
+		Long: `Print a JSON Schema embedded in the chatmate binary.
+
+Editors use the schemas to autocomplete and validate files while you write
+them, such as the YAML frontmatter of a .chatmode.md file. Without a kind, the
+available schemas are listed. 'chatmate validate' checks the configuration
+file and team policy against their schemas.
+
+📐 Schemas:
+• chatmode: The YAML frontmatter of a chatmode file (description, author,
+  model, tools, examples)
+• config: The configuration file managed by 'chatmate config'
+• policy: The team policy manifest (required chatmates, pins, approval,
+  retention)
+
+The schema is written to stdout, so it can be saved next to your chatmates and
+referenced from your editor settings.`,
+		Example: `  # List the available schemas
+  chatmate schema
+
+  # Save the chatmode frontmatter schema
+  chatmate schema chatmode > chatmode.schema.json
+
+  # Save the configuration file schema
+  chatmate schema config > config.schema.json`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: assets.GetSchemaKinds(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				output.Println("Available schemas:")
+				for _, kind := range assets.GetSchemaKinds() {
+					output.Printf("  %s\n", kind)
+				}
+				output.Println("\nPrint one with 'chatmate schema <kind>'")
+				return nil
+			}
+
+			schema, err := assets.GetSchema(args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := output.Stdout().Write(schema); err != nil {
+				return fmt.Errorf("failed to write schema: %w", err)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/policy"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// TestSchemaCommand tests printing the embedded schemas
+func TestSchemaCommand(t *testing.T) {
+	schemaCmd := newTestCommand(t, "schema")
+
+	out := captureOutput(func() {
+		if err := schemaCmd.RunE(schemaCmd, []string{"chatmode"}); err != nil {
+			t.Errorf("schema chatmode failed: %v", err)
+		}
+	})
+
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(out), &schema); err != nil {
+		t.Fatalf("schema chatmode should print valid JSON: %v", err)
+	}
+
+	// The schema must describe every frontmatter field ChatMate reads
+	frontmatter := reflect.TypeOf(files.Frontmatter{})
+	for i := 0; i < frontmatter.NumField(); i++ {
+		field := strings.Split(frontmatter.Field(i).Tag.Get("yaml"), ",")[0]
+		if field == "" || field == "-" {
+			// Unknown fields and parse warnings are not frontmatter fields
+			continue
+		}
+		if _, ok := schema.Properties[field]; !ok {
+			t.Errorf("chatmode schema is missing frontmatter field %q", field)
+		}
+	}
+
+	if err := schemaCmd.RunE(schemaCmd, []string{"unknown"}); err == nil {
+		t.Error("Expected error for an unknown schema")
+	}
+
+	for _, kind := range assets.GetSchemaKinds() {
+		data, err := assets.GetSchema(kind)
+		if err != nil || !json.Valid(data) {
+			t.Errorf("Embedded schema %s is not valid JSON: %v", kind, err)
+		}
+	}
+}
+
+// TestSchemaFileFields tests that the config and policy schemas describe
+// every field ChatMate reads from those files, with the values it accepts
+func TestSchemaFileFields(t *testing.T) {
+	tests := []struct {
+		kind   string
+		fields reflect.Type
+	}{
+		{"config", reflect.TypeOf(settings.Settings{})},
+		{"policy", reflect.TypeOf(policy.Policy{})},
+		{"policy", reflect.TypeOf(policy.Approval{})},
+		{"policy", reflect.TypeOf(policy.Retention{})},
+	}
+
+	for _, tt := range tests {
+		data, err := assets.GetSchema(tt.kind)
+		if err != nil {
+			t.Fatalf("GetSchema(%q) failed: %v", tt.kind, err)
+		}
+		schema := string(data)
+		for i := 0; i < tt.fields.NumField(); i++ {
+			field := strings.Split(tt.fields.Field(i).Tag.Get("yaml"), ",")[0]
+			if !strings.Contains(schema, `"`+field+`": {`) {
+				t.Errorf("%s schema is missing %s field %q", tt.kind, tt.fields.Name(), field)
+			}
+		}
+	}
+
+	var config struct {
+		Properties map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"properties"`
+	}
+	data, _ := assets.GetSchema("config")
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("config schema is not valid JSON: %v", err)
+	}
+	for _, key := range settings.Keys {
+		if enum := config.Properties[key.Name].Enum; enum != nil && !reflect.DeepEqual(enum, key.Values) {
+			t.Errorf("config schema allows %v for %s, the setting %v", enum, key.Name, key.Values)
+		}
+	}
+	if enum := config.Properties["editor"].Enum; !reflect.DeepEqual(enum, platform.EditorIDs()) {
+		t.Errorf("config schema allows editors %v, want %v", enum, platform.EditorIDs())
+	}
+}
@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/self"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// NewSelfCmd creates the self command with its subcommands.
+func NewSelfCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "self",
+		Short: "Manage the ChatMate installation itself",
+		Long: `Manage ChatMate itself rather than the chatmates it installs.
+
+Use the subcommands to inspect or remove the files ChatMate keeps on this
+machine: its config, state, and cache directories, and the shell completions and man
+pages installed by the helper scripts.`,
+		Example: `  # Show how ChatMate was installed and how to update it
+  chatmate self info
+
+  # Remove everything ChatMate created, including installed chatmates
+  chatmate self uninstall --chatmates`,
+	}
+
+	cmd.AddCommand(newSelfInfoCmd(deps))
+	cmd.AddCommand(newSelfUninstallCmd(deps))
+
+	return cmd
+}
+
+// newSelfInfoCmd creates the self info command.
+func newSelfInfoCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show how ChatMate was installed and where it keeps its files",
+		Long: `Report how the running chatmate binary was installed and where ChatMate
+keeps its files, to guide you toward the right update mechanism.
+
+🔍 Install methods:
+• homebrew: update with 'brew upgrade chatmate'
+• go install: update with 'go install github.com/jonassiebler/chatmate@latest'
+• development build: built from a source checkout or run with 'go run'
+• manual: a downloaded release binary; it can be replaced in place if writable`,
+		Example: `  # Show installation details
+  chatmate self info
+
+  # Machine-readable output
+  chatmate self info --output json`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			info, err := self.DetectInstall()
+			if err != nil {
+				return fmt.Errorf("failed to detect installation: %w", err)
+			}
+
+			if app.Structured() {
+				return app.Write(struct {
+					Version string `json:"version"`
+					*self.InstallInfo
+					PromptsDir string `json:"promptsDir"`
+					Headless   bool   `json:"headless"`
+				}{version, info, app.Manager.PromptsDir, app.Manager.Headless}, "installation info")
+			}
+
+			output.Println("=== ChatMate Installation ===")
+			output.Printf("Version: %s\n", version)
+			output.Printf("Install Method: %s\n", info.Method)
+			output.Printf("Binary: %s\n", info.BinaryPath)
+			output.Printf("State Directory: %s\n", info.StateDir)
+			output.Printf("Cache Directory: %s\n", info.CacheDir)
+			output.Printf("%s: %s\n", app.Manager.PromptsDirLabel(), app.Manager.PromptsDir)
+
+			switch {
+			case info.SelfUpdate:
+				output.Println("Self-Update: applicable (binary can be replaced in place)")
+			case info.Method == self.MethodManual:
+				output.Println("Self-Update: not applicable (binary is not writable)")
+			case info.Method == self.MethodDevelopment:
+				output.Println("Self-Update: not applicable (development build)")
+			default:
+				output.Printf("Self-Update: not applicable (managed by %s)\n", info.Method)
+			}
+			output.Printf("Update With: %s\n", info.UpdateHint)
+
+			return nil
+		}),
+	}
+
+	cmd.Flags().Bool("json", false, "print installation details as JSON, same as --output json")
+
+	return cmd
+}
+
+// selfUninstallOptions holds the flags of the self uninstall command.
+type selfUninstallOptions struct {
+	chatmates bool
+}
+
+// newSelfUninstallCmd creates the self uninstall command.
+func newSelfUninstallCmd(deps *Deps) *cobra.Command {
+	opts := &selfUninstallOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove ChatMate's own files from this machine",
+		Long: `Remove the files ChatMate created outside the prompts directory so it can be
+uninstalled cleanly.
+
+🗑️  Removed:
+• ChatMate's config directory (configuration file and team policy)
+• ChatMate's state directory (last operation summary, operation history, adopted chatmates registry, installation checkpoint)
+• ChatMate's cache directory (cached inventory)
+• ChatMate's data directory, which holds the headless prompts directory
+• Shell completions and man pages installed by the helper scripts
+• Installed repository chatmates, with --chatmates
+
+📝 Left untouched (and reported):
+• The chatmate binary itself — remove it with the tool that installed it
+• User-created chatmates and the VS Code prompts directory
+• A configuration file named by CHATMATE_CONFIG, and a directory that holds
+  the prompts directory (the data directory in headless mode) while files
+  are left in the prompts directory
+• Files that could not be removed (e.g., system-wide files without permission)
+• Completion loading lines added to your shell configuration`,
+		Example: `  # Preview what would be removed
+  chatmate self uninstall --dry-run
+
+  # Remove ChatMate's files and its installed chatmates without prompting
+  chatmate self uninstall --chatmates --yes`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			artifacts, err := self.Artifacts()
+			if err != nil {
+				return err
+			}
+			artifacts, kept := keepPromptsDir(artifacts, app.Manager.PromptsDir)
+
+			// Don't recreate the cache directory that is about to be removed
+			app.Manager.NoCache = true
+
+			inventory, err := app.Manager.Inventory()
+			if err != nil {
+				return err
+			}
+
+			var repositoryChatmates, userCreated []string
+			for _, filename := range inventory.Installed {
+				if inventory.IsAvailable(filename) {
+					repositoryChatmates = append(repositoryChatmates, filename)
+				} else {
+					userCreated = append(userCreated, filename)
+				}
+			}
+
+			var toRemove []string
+			if opts.chatmates {
+				toRemove = repositoryChatmates
+			}
+			removesPromptsDir := len(kept) > 0 && promptsDirEmptied(app.Manager.PromptsDir, toRemove)
+			if removesPromptsDir {
+				artifacts, kept = append(artifacts, kept...), nil
+			}
+
+			output.Println("🗑️  CHATMATE SELF-UNINSTALL")
+			if len(artifacts) == 0 && len(toRemove) == 0 {
+				output.Println("No ChatMate files found to remove")
+			} else {
+				output.Println("The following will be REMOVED:")
+				for _, artifact := range artifacts {
+					output.Printf("  ❌ %s: %s\n", artifact.Description, artifact.Path)
+				}
+				for _, filename := range toRemove {
+					output.Printf("  ❌ chatmate: %s\n", filename)
+				}
+			}
+
+			var failures []string
+			if len(artifacts) > 0 || len(toRemove) > 0 {
+				if !output.Confirm("\nDo you want to proceed?") {
+					output.Println("❌ Self-uninstall cancelled by user")
+					return nil
+				}
+
+				output.Println("")
+
+				// Chatmates first: uninstalling them touches the cache directory
+				for _, filename := range toRemove {
+					if err := app.Manager.Uninstaller().UninstallChatmate(filename); err != nil {
+						failures = append(failures, err.Error())
+					}
+				}
+
+				for _, artifact := range artifacts {
+					remove := self.Remove
+					if app.Config.DryRun {
+						// Record the removal in the manager's dry run instead
+						remove = func(artifact self.Artifact) error {
+							return app.Manager.FS.Remove(artifact.Path)
+						}
+					}
+					if err := remove(artifact); err != nil {
+						failures = append(failures, err.Error())
+						continue
+					}
+					if app.Config.DryRun {
+						output.Printf("❌ %s (would be removed)\n", artifact.Path)
+						continue
+					}
+					output.Printf("❌ %s (removed)\n", artifact.Path)
+				}
+			}
+
+			printSelfUninstallUntouched(app.Manager, opts.chatmates, removesPromptsDir, repositoryChatmates, userCreated, kept, failures)
+
+			if len(failures) > 0 {
+				return fmt.Errorf("failed to remove %d item(s)", len(failures))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.chatmates, "chatmates", false, "also remove installed repository chatmates")
+
+	return cmd
+}
+
+// keepPromptsDir splits off the artifacts that hold the prompts directory,
+// so removing them does not remove the chatmates: on Windows, the headless
+// prompts directory is in the config directory.
+func keepPromptsDir(artifacts []self.Artifact, promptsDir string) (removable, kept []self.Artifact) {
+	for _, artifact := range artifacts {
+		if isWithin(artifact.Path, promptsDir) {
+			kept = append(kept, artifact)
+			continue
+		}
+		removable = append(removable, artifact)
+	}
+	return removable, kept
+}
+
+// promptsDirEmptied reports whether the prompts directory is missing or has
+// no files left once the chatmates in removed are uninstalled, so the
+// directories kept by keepPromptsDir can be removed after all.
+func promptsDirEmptied(promptsDir string, removed []string) bool {
+	entries, err := os.ReadDir(promptsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !slices.Contains(removed, entry.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWithin reports whether path is dir or below it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// printSelfUninstallUntouched reports what self uninstall did not remove;
+// removedChatmates tells whether repository chatmates were removed
+// (--chatmates), removedPromptsDir whether the emptied prompts directory was
+// removed with a directory holding it, and kept lists the artifacts kept by
+// keepPromptsDir.
+func printSelfUninstallUntouched(chatMateManager *manager.ChatMateManager, removedChatmates, removedPromptsDir bool, repositoryChatmates, userCreated []string, kept []self.Artifact, failures []string) {
+	output.Println("\n📝 Left untouched:")
+
+	if info, err := self.DetectInstall(); err == nil {
+		switch info.Method {
+		case self.MethodHomebrew:
+			output.Printf("  • chatmate binary: %s (remove it with 'brew uninstall chatmate')\n", info.BinaryPath)
+		default:
+			output.Printf("  • chatmate binary: %s (delete it to finish uninstalling)\n", info.BinaryPath)
+		}
+	}
+
+	if !removedPromptsDir {
+		output.Printf("  • %s: %s\n", chatMateManager.PromptsDirLabel(), chatMateManager.PromptsDir)
+	}
+
+	for _, artifact := range kept {
+		output.Printf("  • %s: %s (it holds the prompts directory, which still has files)\n", artifact.Description, artifact.Path)
+	}
+
+	configDir, _ := state.RoamingDir()
+	if path := os.Getenv(settings.Env); path != "" && !isWithin(configDir, path) {
+		output.Printf("  • configuration file: %s (named by %s)\n", path, settings.Env)
+	}
+
+	if !removedChatmates && len(repositoryChatmates) > 0 {
+		output.Printf("  • %d installed repository chatmate(s) (use --chatmates to remove them)\n", len(repositoryChatmates))
+	}
+
+	if len(userCreated) > 0 {
+		output.Printf("  • %d user-created chatmate(s):\n", len(userCreated))
+		for _, filename := range userCreated {
+			output.Printf("      %s\n", filename)
+		}
+	}
+
+	output.Println("  • completion loading lines added to ~/.bashrc or ~/.zshrc by install-completions.sh")
+
+	for _, failure := range failures {
+		output.Warnf("%s", failure)
+	}
+}
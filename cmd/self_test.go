@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/self"
+)
+
+// TestSelfUninstallCommand tests previewing and removing ChatMate's own files
+func TestSelfUninstallCommand(t *testing.T) {
+	selfUninstallCmd := newTestCommand(t, "self", "uninstall")
+
+	if runtime.GOOS != "linux" {
+		t.Skip("state and cache directories are redirected through XDG variables on Linux only")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	dataDir := filepath.Join(home, "data", "chatmate")
+	if err := os.MkdirAll(filepath.Join(dataDir, "prompts"), 0755); err != nil {
+		t.Fatalf("Failed to create data directory: %v", err)
+	}
+
+	summaryFile := filepath.Join(home, "state", "chatmate", "last-run.json")
+	if err := os.MkdirAll(filepath.Dir(summaryFile), 0755); err != nil {
+		t.Fatalf("Failed to create state directory: %v", err)
+	}
+	if err := os.WriteFile(summaryFile, []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to create summary: %v", err)
+	}
+
+	configFile := filepath.Join(home, "config", "chatmate", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(configFile), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(configFile, []byte("assumeYes: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to create config file: %v", err)
+	}
+
+	defer output.SetAssumeYes(false)
+	output.SetAssumeYes(true)
+
+	setFlags(t, selfUninstallCmd, "--dry-run")
+	out := captureOutput(func() {
+		if err := selfUninstallCmd.RunE(selfUninstallCmd, []string{}); err != nil {
+			t.Errorf("self uninstall --dry-run failed: %v", err)
+		}
+	})
+	for _, path := range []string{summaryFile, configFile} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Dry run must not remove files: %v", err)
+		}
+	}
+	if !strings.Contains(out, "Dry run: nothing was changed") || !strings.Contains(out, filepath.Dir(configFile)+" (would be removed)") {
+		t.Errorf("Dry run should report the config directory as removed, got:\n%s", out)
+	}
+	if strings.Contains(out, "(removed)") {
+		t.Errorf("Dry run must not report directories as removed, got:\n%s", out)
+	}
+
+	setFlags(t, selfUninstallCmd, "--dry-run=false")
+	captureOutput(func() {
+		if err := selfUninstallCmd.RunE(selfUninstallCmd, []string{}); err != nil {
+			t.Errorf("self uninstall --yes failed: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(home, "state", "chatmate")); !os.IsNotExist(err) {
+		t.Error("State directory should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(home, "cache", "chatmate")); !os.IsNotExist(err) {
+		t.Error("Cache directory should not be recreated")
+	}
+	if _, err := os.Stat(filepath.Dir(configFile)); !os.IsNotExist(err) {
+		t.Error("Config directory should be removed")
+	}
+	if _, err := os.Stat(dataDir); !os.IsNotExist(err) {
+		t.Error("Data directory should be removed")
+	}
+}
+
+// TestKeepPromptsDir tests that self uninstall keeps the directories that
+// hold the prompts directory
+func TestKeepPromptsDir(t *testing.T) {
+	configDir := filepath.Join(t.TempDir(), "chatmate")
+	artifacts := []self.Artifact{
+		{Description: "config directory", Path: configDir},
+		{Description: "cache directory", Path: configDir + "-cache"},
+	}
+
+	removable, kept := keepPromptsDir(artifacts, filepath.Join(configDir, "prompts"))
+	if len(kept) != 1 || kept[0].Path != configDir {
+		t.Errorf("The config directory holding the prompts directory should be kept, got %v", kept)
+	}
+	if len(removable) != 1 || removable[0].Path != configDir+"-cache" {
+		t.Errorf("A sibling with a common prefix should be removable, got %v", removable)
+	}
+
+	if removable, kept := keepPromptsDir(artifacts, filepath.Join(t.TempDir(), "prompts")); len(kept) != 0 || len(removable) != 2 {
+		t.Errorf("Artifacts outside the prompts directory should be removable, got %v and %v", removable, kept)
+	}
+}
+
+// TestPromptsDirEmptied tests when the directories holding the prompts
+// directory can be removed
+func TestPromptsDirEmptied(t *testing.T) {
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	if !promptsDirEmptied(promptsDir, nil) {
+		t.Error("A missing prompts directory should count as empty")
+	}
+
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"A.chatmode.md", "Mine.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if promptsDirEmptied(promptsDir, []string{"A.chatmode.md"}) {
+		t.Error("A prompts directory with a user-created chatmate left should not count as empty")
+	}
+	if !promptsDirEmptied(promptsDir, []string{"A.chatmode.md", "Mine.chatmode.md"}) {
+		t.Error("A prompts directory whose files are all removed should count as empty")
+	}
+}
+
+// TestSelfInfoCommand tests that self info reports the installation
+func TestSelfInfoCommand(t *testing.T) {
+	selfInfoCmd := newTestCommand(t, "self", "info")
+
+	out := captureOutput(func() {
+		if err := selfInfoCmd.RunE(selfInfoCmd, []string{}); err != nil {
+			t.Errorf("self info failed: %v", err)
+		}
+	})
+	for _, expected := range []string{"Install Method:", "Binary:", "Cache Directory:", "Update With:"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("self info output should contain %q", expected)
+		}
+	}
+
+	setFlags(t, selfInfoCmd, "--json")
+	out = captureOutput(func() {
+		if err := selfInfoCmd.RunE(selfInfoCmd, []string{}); err != nil {
+			t.Errorf("self info --json failed: %v", err)
+		}
+	})
+
+	var info map[string]any
+	if err := json.Unmarshal([]byte(out), &info); err != nil {
+		t.Fatalf("self info --json should print JSON: %v\n%s", err, out)
+	}
+	for _, key := range []string{"version", "method", "binaryPath", "stateDir", "cacheDir", "selfUpdate", "updateHint", "promptsDir"} {
+		if _, ok := info[key]; !ok {
+			t.Errorf("JSON output missing %q", key)
+		}
+	}
+}
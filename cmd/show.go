@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// showOptions holds the flags of the show command.
+type showOptions struct {
+	raw bool
+}
+
+// NewShowCmd creates the show command.
+func NewShowCmd(deps *Deps) *cobra.Command {
+	opts := &showOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show <chatmate name>",
+		Short: "Show details and content of a chatmate agent",
+		Long: `Display a single chatmate agent together with its full prompt content.
+
+📄 What You'll See:
+• Display name and filename of the chatmate
+• Whether it ships with ChatMate or was created by you
+• Installation status in the VS Code prompts directory
+• The complete chatmode file content
+
+🔗 Unix-Style Composition:
+• Use --raw to write only the file content to stdout
+• Pipe the output into other tools, or back into 'chatmate hire --stdin'`,
+		Example: `  # Show a chatmate with its details
+  chatmate show "Solve Issue"
+
+  # Write only the raw chatmode content to stdout
+  chatmate show "Solve Issue" --raw
+
+  # Create a customized copy of a chatmate
+  chatmate show "Testing" --raw | sed 's/Testing/QA/' | chatmate hire --stdin --name "My QA"`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			details, err := app.Manager.Lister().Details(args[0])
+			if err != nil {
+				return err
+			}
+			return view.Details(details, opts.raw)
+		}),
+	}
+
+	// Add flags
+	cmd.Flags().BoolVar(&opts.raw, "raw", false,
+		"Write only the chatmate file content to stdout")
+
+	return cmd
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/spf13/cobra"
+)
+
+// NewSourceCmd creates the source command.
+func NewSourceCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "source",
+		Short: "Show and configure where chatmates are installed from",
+		Long: `Show and configure the sources chatmates are installed from.
+
+Besides the chatmates built into ChatMate, chatmates can come from:
+• A directory, e.g. a folder on a shared drive
+• A git repository, cloned into the ChatMate cache directory and fetched
+  again after an hour; chatmates are read from its mates directory, or its
+  root if it has none. Add "#<branch or tag>" to pin a branch or tag.
+• An https URL of an index in the format of the community registry, whose
+  chatmates are verified against their SHA-256
+
+The chatmates of every source are listed, installed, and updated like the
+built-in ones. A chatmate provided by several sources is installed from the
+first of them, starting with the built-in chatmates. The sources are stored
+in the configuration file (see 'chatmate config').`,
+		Example: `  # Show the sources and their chatmates
+  chatmate source
+
+  # Install chatmates from your team's repository
+  chatmate source add https://github.com/acme/chatmates.git
+  chatmate hire --all
+
+  # Stop using a source
+  chatmate source remove https://github.com/acme/chatmates.git`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			return listSources(app)
+		}),
+	}
+
+	cmd.AddCommand(
+		newSourceAddCmd(deps),
+		newSourceListCmd(deps),
+		newSourceRemoveCmd(deps),
+	)
+
+	return cmd
+}
+
+// newSourceListCmd creates the source list command.
+func newSourceListCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show the sources and the number of chatmates of each",
+		Long: `Show the sources in the order chatmates are looked up in, with the
+number of chatmates each provides. Git repositories are cloned or fetched,
+and indexes downloaded, as needed to count them.`,
+		Example: `  # Sources that cannot be read
+  chatmate source list --output json | jq -r '.sources[] | select(.error) | .location'`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			return listSources(app)
+		}),
+	}
+}
+
+// newSourceAddCmd creates the source add command.
+func newSourceAddCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <directory|git URL|https URL>",
+		Short: "Install chatmates from another source",
+		Long: `Add a source of chatmates, which is looked up after the built-in
+chatmates and the sources added before it. Relative directories are stored
+as absolute paths.`,
+		Example: `  # A directory on a shared drive
+  chatmate source add /mnt/team/chatmates
+
+  # The v2 tag of a git repository
+  chatmate source add https://github.com/acme/chatmates.git#v2
+
+  # An index published like the community registry
+  chatmate source add https://chatmates.acme.com/index.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			source, err := manager.ParseSource(args[0], "")
+			if err != nil {
+				return err
+			}
+			spec := args[0]
+			if dir, ok := source.(*manager.DirectorySource); ok {
+				if info, err := os.Stat(dir.Dir); err != nil || !info.IsDir() {
+					return fmt.Errorf("%s is not a directory", dir.Dir)
+				}
+				spec = dir.Dir
+			}
+
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+			if err := file.AddSource(spec); err != nil {
+				return err
+			}
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ Added the %s source %s; 'chatmate list' shows its chatmates\n", source.Kind(), spec)
+			return nil
+		}),
+	}
+}
+
+// newSourceRemoveCmd creates the source remove command.
+func newSourceRemoveCmd(deps *Deps) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <source>",
+		Aliases: []string{"rm"},
+		Short:   "Stop installing chatmates from a source",
+		Long: `Remove a source of chatmates. Chatmates installed from it stay
+installed; 'chatmate sync --prune' removes them.`,
+		Example: `  # Stop using a source
+  chatmate source remove https://github.com/acme/chatmates.git`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSources,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			file, path, err := readSettingsFile()
+			if err != nil {
+				return err
+			}
+			// Directories are stored as absolute paths
+			err = file.RemoveSource(args[0])
+			if source, parseErr := manager.ParseSource(args[0], ""); err != nil && parseErr == nil {
+				if dir, ok := source.(*manager.DirectorySource); ok && file.RemoveSource(dir.Dir) == nil {
+					err = nil
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if err := settings.Save(path, file); err != nil {
+				return err
+			}
+			output.Printf("✅ Removed source %s from %s\n", args[0], path)
+			return nil
+		}),
+	}
+}
+
+// listSources prints the built-in and configured sources.
+func listSources(app *App) error {
+	_, path, err := readSettingsFile()
+	if err != nil {
+		return err
+	}
+	sources := app.Manager.ListSources()
+
+	if app.Structured() {
+		return app.Write(map[string]any{"path": path, "sources": sources}, "sources")
+	}
+	output.Printf("Sources (%s):\n", path)
+	for _, source := range sources {
+		origin := ""
+		if source.Builtin {
+			origin = ", built-in"
+		}
+		if source.Error != "" {
+			output.Printf("  ❌ %s (%s%s): %s\n", source.Location, source.Kind, origin, source.Error)
+			continue
+		}
+		output.Printf("  %s (%s%s): %d chatmate(s)\n", source.Location, source.Kind, origin, source.Chatmates)
+	}
+	if len(sources) == 1 {
+		output.Println("  (no other sources; add one with 'chatmate source add <directory|git URL|https URL>')")
+	}
+	return nil
+}
+
+// completeSources completes the source argument of source remove.
+func completeSources(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	file, _, err := readSettingsFile()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return file.Sources, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/settings"
+)
+
+// TestSourceAddRemove tests configuring sources through the source command
+func TestSourceAddRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(settings.Env, path)
+	output.SetWriters(&bytes.Buffer{}, &bytes.Buffer{})
+	defer output.SetWriters(nil, nil)
+
+	run := func(args ...string) error {
+		root := NewRootCmd(DefaultDeps())
+		root.SetArgs(args)
+		return root.Execute()
+	}
+
+	dir := t.TempDir()
+	t.Chdir(filepath.Dir(dir))
+	if err := run("source", "add", filepath.Base(dir)); err != nil {
+		t.Fatalf("source add failed: %v", err)
+	}
+	if content, err := os.ReadFile(path); err != nil || !strings.Contains(string(content), "- "+dir) {
+		t.Errorf("Expected the absolute directory in the config file, got %q, %v", content, err)
+	}
+	for _, source := range []string{dir, filepath.Join(dir, "missing"), "http://example.com/index.json"} {
+		if err := run("source", "add", source); err == nil {
+			t.Errorf("Expected source add %s to be rejected", source)
+		}
+	}
+	if err := run("source", "remove", filepath.Base(dir)); err != nil {
+		t.Fatalf("source remove failed: %v", err)
+	}
+	if err := run("source", "remove", dir); err == nil {
+		t.Error("Expected removing a missing source to fail")
+	}
+}
@@ -2,16 +2,29 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/cmd/view"
 	"github.com/spf13/cobra"
 )
 
-// statusCmd represents the status command
-var statusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show ChatMate installation status and system information",
-	Long: `Display comprehensive status information about your ChatMate installation,
+// statusOptions holds the flags of the status command.
+type statusOptions struct {
+	noCache bool
+	since   string
+	check   bool
+}
+
+// NewStatusCmd creates the status command.
+func NewStatusCmd(deps *Deps) *cobra.Command {
+	opts := &statusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show ChatMate installation status and system information",
+		Long: `Display comprehensive status information about your ChatMate installation,
 VS Code integration, and system configuration.
 
 🔍 System Checks:
@@ -26,6 +39,10 @@ VS Code integration, and system configuration.
 • Count of installed/available chatmates
 • System platform and environment details
 • Troubleshooting hints for common issues
+• Recent operations (what, when, outcome) from the operation history;
+  use --since to only show operations after a point in time
+• Required chatmates from the team policy; use --check to only check the
+  policy and fail when a required chatmate is missing
 
 🎯 Use Cases:
 • Verify ChatMate is properly installed and configured
@@ -36,27 +53,101 @@ VS Code integration, and system configuration.
 💡 Troubleshooting:
 • If VS Code isn't detected, ensure it's in your PATH
 • If prompts directory is missing, it will be created automatically
-• Run this command after any major system or VS Code updates`,
-	Example: `  # Show complete ChatMate installation status
+• Run this command after any major system or VS Code updates
+• Results are cached until the prompts directory changes; use --no-cache to rescan`,
+		Example: `  # Show complete ChatMate installation status
   chatmate status
   
   # Common troubleshooting workflow
   chatmate status          # Check system health
   chatmate list           # Verify chatmate availability  
-  chatmate hire --force   # Force reinstall if needed
+  chatmate update         # Update chatmates that changed
   
+  # Only show operations from the last week
+  chatmate status --since 7d
+
+  # Fail when chatmates required by the team policy are missing (CI, login scripts)
+  chatmate status --check
+
   # Get status info for support requests
-  chatmate status > chatmate-status.txt`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		chatMateManager, err := manager.NewChatMateManager()
-		if err != nil {
-			return fmt.Errorf("failed to initialize ChatMate manager: %w", err)
-		}
+  chatmate status > chatmate-status.txt
 
-		return chatMateManager.Status().ShowStatus()
-	},
+  # Count installed chatmates in a script
+  chatmate status --output json | jq .installed`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			app.Manager.NoCache = opts.noCache
+
+			status := app.Manager.Status()
+			if opts.check {
+				compliance, err := app.Manager.PolicyCompliance()
+				if err != nil {
+					return err
+				}
+				view.Compliance(compliance)
+				return compliance.Error()
+			}
+			if opts.since != "" {
+				since, err := parseSince(opts.since, time.Now())
+				if err != nil {
+					return err
+				}
+				status.Since = since
+			}
+			if app.Structured() {
+				report, err := status.Report()
+				if err != nil {
+					return err
+				}
+				return app.Write(report, "status report")
+			}
+			details, err := status.Details(app.Context)
+			if err != nil {
+				return err
+			}
+			view.Status(details)
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.noCache, "no-cache", false,
+		"Ignore the cached inventory and rescan the chatmate directories")
+	cmd.Flags().StringVar(&opts.since, "since", "",
+		"Only show operations since a duration ago (24h, 7d) or a date (2006-01-02, RFC 3339)")
+	cmd.Flags().BoolVar(&opts.check, "check", false,
+		"Only check the team policy and fail when required chatmates are missing")
+	cmd.Flags().Bool("json", false,
+		"Print the status as JSON, same as --output json (without the health checks; see 'chatmate validate --json')")
+
+	return cmd
 }
 
-func init() {
-	rootCmd.AddCommand(statusCmd)
+// parseSince converts the value of --since to a point in time.
+//
+// Parameters:
+//   - value: A duration before now (e.g., "90m", "24h", or "7d" for days), a
+//     date in local time ("2006-01-02"), or an RFC 3339 timestamp
+//   - now: The current time durations are subtracted from
+//
+// Returns:
+//   - time.Time: The earliest time of operations to show
+//   - error: Error if the value is not in one of the accepted formats
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid --since value %q: use a duration such as 24h or 7d, a date such as 2025-09-01, or an RFC 3339 timestamp", value)
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/spf13/cobra"
+)
+
+// summarySkipCommands lists commands that don't manage chatmates and
+// therefore don't overwrite the last operation summary.
+var summarySkipCommands = map[string]bool{
+	"authoring":                     true,
+	"completion":                    true,
+	"export":                        true,
+	"generate-shim":                 true,
+	cobra.ShellCompRequestCmd:       true,
+	cobra.ShellCompNoDescRequestCmd: true,
+	"help":                          true,
+	"schema":                        true,
+	"self":                          true,
+	"tutorial":                      true,
+	"version":                       true,
+}
+
+// historySkipCommands lists read-only commands that update the last operation
+// summary but are not recorded in the operation history, so the recent
+// operations table in status only shows operations that changed something.
+var historySkipCommands = map[string]bool{
+	"browse":       true,
+	"diff":         true,
+	"doctor":       true,
+	"history":      true,
+	"inventory":    true,
+	"list":         true,
+	"log":          true,
+	"render":       true,
+	"report":       true,
+	"show":         true,
+	"status":       true,
+	"troubleshoot": true,
+	"validate":     true,
+	"verify":       true,
+	"which":        true,
+}
+
+// recordSummary writes a machine-readable summary of the executed command to
+// the state directory so shell prompts and status lines can display it, and
+// appends it to the operation history shown by status.
+//
+// Failures are only reported in verbose mode because the summary is a
+// convenience and must never change the outcome of the command itself.
+func recordSummary(deps *Deps, executed *cobra.Command, cmdErr error, elapsed time.Duration) {
+	// Read-only mode and dry runs write no state at all
+	if executed == nil || !executed.HasParent() || files.ReadOnly() || globalDryRun(executed) {
+		return
+	}
+	// Skipping a command also skips its subcommands
+	for c := executed; c != nil; c = c.Parent() {
+		if summarySkipCommands[c.Name()] {
+			return
+		}
+	}
+
+	summary := state.Summary{
+		Command:    executed.Name(),
+		Success:    cmdErr == nil,
+		DurationMs: elapsed.Milliseconds(),
+		Timestamp:  time.Now().UTC(),
+	}
+	if cmdErr != nil {
+		summary.Error = cmdErr.Error()
+	}
+
+	if chatMateManager, err := deps.NewManager(); err == nil {
+		installed, available, outdated, err := chatMateManager.Status().Counts()
+		if err == nil {
+			summary.Installed = installed
+			summary.Available = available
+			summary.Outdated = outdated
+		}
+	}
+
+	if err := state.WriteSummary(summary); err != nil {
+		output.Debugf("Could not write operation summary: %v\n", err)
+	}
+	// An explained operation was not performed
+	explained, _ := executed.Flags().GetBool("explain")
+	if !historySkipCommands[executed.Name()] && !explained {
+		if err := state.AppendHistory(summary); err != nil {
+			output.Debugf("Could not record operation history: %v\n", err)
+		}
+	}
+}
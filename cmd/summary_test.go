@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestRecordSummary tests that executed commands write the last operation summary
+func TestRecordSummary(t *testing.T) {
+	listCmd := newTestCommand(t, "list")
+	statusCmd := newTestCommand(t, "status")
+	selfUninstallCmd := newTestCommand(t, "self", "uninstall")
+	versionCmd := newTestCommand(t, "version")
+	deps := DefaultDeps()
+
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir)
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	t.Setenv("LOCALAPPDATA", tmpDir)
+
+	recordSummary(deps, listCmd, nil, 1500*time.Millisecond)
+
+	summary, err := state.ReadSummary()
+	if err != nil {
+		t.Fatalf("ReadSummary failed: %v", err)
+	}
+	if summary.Command != "list" || !summary.Success {
+		t.Errorf("Unexpected summary: %+v", summary)
+	}
+	if summary.Available == 0 {
+		t.Error("Summary should count available chatmates")
+	}
+	if summary.DurationMs != 1500 {
+		t.Errorf("Summary should record the elapsed time, got %dms", summary.DurationMs)
+	}
+
+	recordSummary(deps, statusCmd, errors.New("boom"), 0)
+
+	summary, err = state.ReadSummary()
+	if err != nil {
+		t.Fatalf("ReadSummary failed: %v", err)
+	}
+	if summary.Success || summary.Error != "boom" {
+		t.Errorf("Failed command should be recorded as unsuccessful: %+v", summary)
+	}
+
+	// Commands that don't manage chatmates must not overwrite the summary
+	recordSummary(deps, versionCmd, nil, 0)
+
+	summary, err = state.ReadSummary()
+	if err != nil {
+		t.Fatalf("ReadSummary failed: %v", err)
+	}
+	if summary.Command != "status" {
+		t.Errorf("version command should not overwrite summary, got %s", summary.Command)
+	}
+
+	// Subcommands of skipped commands are skipped as well
+	recordSummary(deps, selfUninstallCmd, nil, 0)
+
+	summary, err = state.ReadSummary()
+	if err != nil {
+		t.Fatalf("ReadSummary failed: %v", err)
+	}
+	if summary.Command != "status" {
+		t.Errorf("self uninstall should not overwrite summary, got %s", summary.Command)
+	}
+
+	// Only operations that change something are added to the history
+	recordSummary(deps, newTestCommand(t, "hire"), nil, 0)
+
+	history, err := state.ReadHistory()
+	if err != nil {
+		t.Fatalf("ReadHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].Command != "hire" {
+		t.Errorf("Expected only hire in the history, got %+v", history)
+	}
+}
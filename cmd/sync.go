@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/notify"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// syncOptions holds the flags of the sync command.
+type syncOptions struct {
+	prune          bool
+	dryRun         bool
+	output         string
+	acceptBreaking bool
+}
+
+// NewSyncCmd creates the sync command.
+func NewSyncCmd(deps *Deps) *cobra.Command {
+	opts := &syncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Make the installed chatmates match the available ones",
+		Long: `Bring the prompts directory in line with the chatmates shipped with this
+ChatMate release in one step.
+
+🔄 How It Works:
+• Available chatmates that are not installed are installed
+• Installed chatmates whose shipped content changed are updated; chatmates
+  you edited since they were installed, and chatmates whose shipped version
+  is outside the range the team policy pins them to, are kept; updates the
+  registry marks as breaking show their changelog and are confirmed on
+  their own (--yes does not confirm them; use --accept-breaking)
+• With --prune, chatmates that were installed from ChatMate but are no longer
+  available are removed
+• Chatmates you created, imported, adopted, or installed from the registry
+  are never touched
+
+The plan is printed and confirmed before anything changes. Use --dry-run to
+see the plan only, and the global --yes to skip the confirmation in scripts.
+
+If the team policy sets a webhook URL, a JSON report of the outcome is
+POSTed to it after every sync. Use --output slack or --output teams to print
+only a compact Markdown summary, ready to be posted to a chat channel, and
+--output json or yaml to print only that report.`,
+		Example: `  # Install missing and update changed chatmates
+  chatmate sync
+
+  # See what would change, including orphans that would be removed
+  chatmate sync --prune --dry-run
+
+  # Sync without prompting, e.g. from a login script
+  chatmate sync --prune --yes`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if !slices.Contains(applyOutputs, opts.output) {
+				return fmt.Errorf("unknown output format %q (available: %s)", opts.output, strings.Join(applyOutputs, ", "))
+			}
+			summary := opts.output != OutputText
+			if summary {
+				// Standard output only carries the summary
+				output.SetLevel(output.LevelQuiet)
+			}
+
+			if !opts.dryRun {
+				if err := checkEditor(app.Manager, false); err != nil {
+					return err
+				}
+				if err := migrateLegacyNames(app); err != nil {
+					return err
+				}
+			}
+			// Explain how to get chatmates approved that the policy held back
+			defer func() { view.QueuedApprovals(app.Manager.Installer().QueuedApprovals()) }()
+
+			app.Manager.Installer().AcceptBreaking = opts.acceptBreaking
+			result, err := app.Manager.Installer().Sync(app.Context, opts.prune, opts.dryRun)
+			if result == nil || opts.dryRun {
+				return err
+			}
+			if changed := len(result.Installed) + len(result.Updated) + len(result.Pruned); changed > 0 {
+				output.Printf("\n✅ Synced %d chatmate(s)\n", changed)
+			}
+
+			report := syncReport(app, result, err)
+			notifyWebhook(app, report)
+			if summary {
+				if writeErr := writeSummary(app, report, opts.output); writeErr != nil && err == nil {
+					return writeErr
+				}
+			}
+			return err
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, "remove chatmates installed from ChatMate that are no longer available")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "show the plan without changing anything")
+	cmd.Flags().BoolVar(&opts.acceptBreaking, "accept-breaking", false, "install updates the registry marks as breaking without asking")
+	cmd.Flags().StringVar(&opts.output, "output", "text",
+		"output format: text, json or yaml for a summary document, or slack or teams for a chat-ready Markdown summary")
+
+	return cmd
+}
+
+// syncReport describes the outcome of a sync for the team policy webhook and
+// chat summaries.
+func syncReport(app *App, result *manager.SyncResult, syncErr error) *notify.Report {
+	report := newReport(app, "sync", syncErr)
+	for _, change := range []struct {
+		action    string
+		filenames []string
+	}{
+		{"installed", result.Installed},
+		{"updated", result.Updated},
+		{"removed", result.Pruned},
+	} {
+		for _, filename := range change.filenames {
+			report.Changes = append(report.Changes, notify.Change{Chatmate: manager.DisplayName(filename), Action: change.action})
+		}
+	}
+	for _, failure := range result.Failed {
+		report.Failures = append(report.Failures, notify.Failure{Chatmate: manager.DisplayName(failure.Filename), Error: failure.Err.Error()})
+	}
+	return report
+}
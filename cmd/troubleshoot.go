@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewTroubleshootCmd creates the troubleshoot command.
+func NewTroubleshootCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "troubleshoot [problem]",
+		Short: "Diagnose and fix common problems step by step",
+		Long: `Walk through a guided troubleshooting flow for a common problem.
+
+Each flow runs the checks that matter for the problem in order, skips checks
+that depend on one that failed, and explains how to fix every problem it
+finds. Fixes that are safe to automate are offered one at a time; after
+applying them the flow runs again to confirm the result.
+
+🩺 Troubleshooting Flows:
+• not-showing: Chatmates are not showing in Copilot Chat
+• permissions: Permission errors while installing or removing chatmates
+• wrong-editor: Chatmates are installed for a different editor (VS Code
+  Insiders, VSCodium, Cursor) than the one you use
+
+Describe the problem in your own words or use a flow name. Without a
+problem, the available flows are listed.
+
+The command exits with a non-zero status if a failed check remains.`,
+		Example: `  # Find out why chatmates don't show up
+  chatmate troubleshoot "chatmates not showing"
+
+  # Apply every available fix without asking
+  chatmate troubleshoot permissions --yes
+
+  # Machine-readable findings, without applying fixes
+  chatmate troubleshoot wrong-editor --output json`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			troubleshooter := app.Manager.Troubleshooter()
+
+			if len(args) == 0 {
+				listTroubleshootFlows(troubleshooter)
+				return nil
+			}
+
+			flow, err := troubleshooter.FindFlow(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			diagnosis := troubleshooter.Run(flow)
+			if app.Structured() {
+				if err := app.Write(diagnosis, "diagnosis"); err != nil {
+					return err
+				}
+			} else {
+				output.Printf("🩺 Troubleshooting: %s\n\n", flow.Description)
+				if applyFixes(app.Context, diagnosis) > 0 {
+					output.Println("\n🔁 Checking again...")
+					diagnosis = troubleshooter.Run(flow)
+					printDiagnosis(diagnosis)
+				}
+				printTroubleshootSummary(diagnosis)
+			}
+
+			if failed := countFailed(diagnosis); failed > 0 {
+				// The findings already explain the failure; usage text adds noise
+				cmd.SilenceUsage = true
+				return fmt.Errorf("troubleshooting found %d unresolved problem(s)", failed)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().Bool("json", false, "print the findings as JSON without applying fixes, same as --output json")
+
+	return cmd
+}
+
+// listTroubleshootFlows prints the available troubleshooting flows.
+func listTroubleshootFlows(troubleshooter *manager.TroubleshooterService) {
+	output.Println("🩺 Troubleshooting flows:")
+	output.Println()
+	for _, flow := range troubleshooter.Flows() {
+		output.Printf("  %-14s %s\n", flow.Name, flow.Description)
+	}
+	output.Println()
+	output.Println("💡 Start one with: chatmate troubleshoot <flow>, or describe the problem in quotes")
+}
+
+// applyFixes prints each finding and offers the available fixes.
+//
+// It returns the number of fixes that were applied.
+func applyFixes(ctx context.Context, diagnosis *manager.Diagnosis) int {
+	applied := 0
+	for _, finding := range diagnosis.Findings {
+		printFinding(finding)
+		if !finding.CanApply() {
+			continue
+		}
+
+		if !output.Confirm("   Apply this fix now?") {
+			continue
+		}
+
+		if err := finding.Apply(ctx); err != nil {
+			output.Printf("   %s Fix failed: %v\n", output.SymbolFailure, err)
+			continue
+		}
+		output.Printf("   %s Fixed\n", output.SymbolSuccess)
+		applied++
+	}
+	return applied
+}
+
+// printDiagnosis prints every finding of a diagnosis.
+func printDiagnosis(diagnosis *manager.Diagnosis) {
+	for _, finding := range diagnosis.Findings {
+		printFinding(finding)
+	}
+}
+
+// printFinding prints one finding with its fix.
+func printFinding(finding manager.Finding) {
+	symbol := output.SymbolSuccess
+	switch finding.Status {
+	case manager.CheckWarn:
+		symbol = output.SymbolWarning
+	case manager.CheckFail:
+		symbol = output.SymbolFailure
+	}
+
+	output.Printf("%s %s: %s\n", symbol, finding.Name, finding.Message)
+	if len(finding.Files) > 0 {
+		output.Printf("   %s\n", strings.Join(finding.Files, "\n   "))
+	}
+	if finding.Fix != "" {
+		output.Printf("   💡 %s\n", finding.Fix)
+	}
+}
+
+// printTroubleshootSummary prints the outcome of a troubleshooting flow.
+func printTroubleshootSummary(diagnosis *manager.Diagnosis) {
+	output.Println()
+	if len(diagnosis.Problems()) == 0 {
+		output.Println("🎉 No problems found.")
+	} else {
+		output.Printf("%d problem(s) remain; follow the 💡 suggestions above.\n", len(diagnosis.Problems()))
+	}
+	output.Println("💡 Restart VS Code after changing chatmates or settings so it reloads them.")
+}
+
+// countFailed returns the number of failed findings.
+func countFailed(diagnosis *manager.Diagnosis) int {
+	failed := 0
+	for _, finding := range diagnosis.Problems() {
+		if finding.Status == manager.CheckFail {
+			failed++
+		}
+	}
+	return failed
+}
@@ -1,17 +1,20 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/jonassiebler/chatmate/cmd/tutorial"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
 	"github.com/spf13/cobra"
 )
 
-// tutorialCmd represents the tutorial command
-var tutorialCmd = &cobra.Command{
-	Use:   "tutorial [tutorial-name]",
-	Short: "Interactive tutorials for learning ChatMate",
-	Long: `Launch interactive tutorials to learn ChatMate features and best practices.
+// NewTutorialCmd creates the tutorial command.
+func NewTutorialCmd(deps *Deps) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tutorial [tutorial-name]",
+		Short: "Interactive tutorials for learning ChatMate",
+		Long: `Launch interactive tutorials to learn ChatMate features and best practices.
 	
 🎓 Available Tutorials:
 • first-time: Complete beginner's guide to ChatMate
@@ -32,7 +35,7 @@ var tutorialCmd = &cobra.Command{
 • Context-aware guidance based on your setup
 • Progress tracking and checkpoints
 • Integration with VS Code workflows`,
-	Example: `  # Start the beginner tutorial
+		Example: `  # Start the beginner tutorial
   chatmate tutorial first-time
   
   # Learn daily development workflows
@@ -49,55 +52,54 @@ var tutorialCmd = &cobra.Command{
   
   # List all available tutorials
   chatmate tutorial`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			return listTutorials()
-		}
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if len(args) == 0 {
+				return listTutorials()
+			}
 
-		tutorialName := args[0]
-		return runTutorial(tutorialName, tutorial.PromptToContinue)
-	},
+			tutorialName := args[0]
+			return runTutorial(app.Context, app.Manager, tutorialName, tutorial.PromptToContinue)
+		}),
+	}
+
+	return cmd
 }
 
 // listTutorials shows all available tutorials
 func listTutorials() error {
-	fmt.Println("📚 Available ChatMate Tutorials:")
-	fmt.Println("")
+	output.Println("📚 Available ChatMate Tutorials:")
+	output.Println("")
 
 	tutorials := tutorial.GetAvailableTutorials()
 
 	for i, tut := range tutorials {
-		fmt.Printf("%d. 🎓 %s (%s)\n", i+1, tut.Name, tut.Level)
-		fmt.Printf("   %s\n", tut.Description)
-		fmt.Printf("   ⏱️  Duration: %s\n", tut.Duration)
-		fmt.Printf("   🚀 Start: chatmate tutorial %s\n", tut.Name)
-		fmt.Println("")
+		output.Printf("%d. 🎓 %s (%s)\n", i+1, tut.Name, tut.Level)
+		output.Printf("   %s\n", tut.Description)
+		output.Printf("   ⏱️  Duration: %s\n", tut.Duration)
+		output.Printf("   🚀 Start: chatmate tutorial %s\n", tut.Name)
+		output.Println("")
 	}
 
-	fmt.Println("💡 Tip: Start with 'first-time' if you're new to ChatMate!")
+	output.Println("💡 Tip: Start with 'first-time' if you're new to ChatMate!")
 	return nil
 }
 
 // runTutorial runs the specified tutorial
-func runTutorial(name string, prompt tutorial.PromptFunc) error {
+func runTutorial(ctx context.Context, chatMateManager *manager.ChatMateManager, name string, prompt tutorial.PromptFunc) error {
 	switch name {
 	case "first-time":
-		return tutorial.RunFirstTimeTutorial(prompt)
+		return tutorial.RunFirstTimeTutorial(ctx, chatMateManager, prompt)
 	case "daily-dev":
-		return tutorial.RunDailyDevTutorial(prompt)
+		return tutorial.RunDailyDevTutorial(ctx, chatMateManager, prompt)
 	case "team-lead":
-		return tutorial.RunTeamLeadTutorial(prompt)
+		return tutorial.RunTeamLeadTutorial(chatMateManager, prompt)
 	case "debugging":
-		return tutorial.RunDebuggingTutorial(prompt)
+		return tutorial.RunDebuggingTutorial(chatMateManager, prompt)
 	case "testing":
-		return tutorial.RunTestingTutorial(prompt)
+		return tutorial.RunTestingTutorial(chatMateManager, prompt)
 	default:
-		fmt.Printf("❌ Tutorial '%s' not found.\n\n", name)
-		fmt.Println("Run 'chatmate tutorial' to see available tutorials.")
+		output.Printf("❌ Tutorial '%s' not found.\n\n", name)
+		output.Println("Run 'chatmate tutorial' to see available tutorials.")
 		return nil
 	}
 }
-
-func init() {
-	rootCmd.AddCommand(tutorialCmd)
-}
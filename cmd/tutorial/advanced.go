@@ -1,105 +1,108 @@
 package tutorial
 
-import "fmt"
+import (
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
 
 // RunTeamLeadTutorial runs the team leadership workflow tutorial
-func RunTeamLeadTutorial(prompt PromptFunc) error {
-	fmt.Println("👑 ChatMate Team Leadership Tutorial")
-	fmt.Println("====================================")
-	fmt.Println("")
+func RunTeamLeadTutorial(chatMateManager *manager.ChatMateManager, prompt PromptFunc) error {
+	output.Println("👑 ChatMate Team Leadership Tutorial")
+	output.Println("====================================")
+	output.Println("")
 
-	fmt.Println("Learn how to use ChatMate for team leadership, code reviews, and project management.")
-	fmt.Println("")
+	output.Println("Learn how to use ChatMate for team leadership, code reviews, and project management.")
+	output.Println("")
 
 	if !prompt("Ready to learn team leadership workflows?") {
 		return nil
 	}
 
-	teamScenarios := GetTeamLeadScenarios()
+	teamScenarios := GetTeamLeadScenarios(tutorialChatmates(chatMateManager, "team-lead"))
 	showScenarios("👥 Team Leadership Scenarios:", teamScenarios, prompt)
 
-	fmt.Println("🎯 Team Leadership Best Practices:")
-	fmt.Println("• Maintain consistent code review standards")
-	fmt.Println("• Create clear, actionable issues and documentation")
-	fmt.Println("• Use code reviews as learning opportunities")
-	fmt.Println("• Regular maintenance of issue quality and organization")
-	fmt.Println("")
+	output.Println("🎯 Team Leadership Best Practices:")
+	output.Println("• Maintain consistent code review standards")
+	output.Println("• Create clear, actionable issues and documentation")
+	output.Println("• Use code reviews as learning opportunities")
+	output.Println("• Regular maintenance of issue quality and organization")
+	output.Println("")
 
-	fmt.Println("✅ Team Leadership Tutorial Complete!")
+	output.Println("✅ Team Leadership Tutorial Complete!")
 	return nil
 }
 
 // RunDebuggingTutorial runs the advanced debugging tutorial
-func RunDebuggingTutorial(prompt PromptFunc) error {
-	fmt.Println("🐛 Advanced Debugging with Solve Issue Chatmate")
-	fmt.Println("===============================================")
-	fmt.Println("")
+func RunDebuggingTutorial(chatMateManager *manager.ChatMateManager, prompt PromptFunc) error {
+	output.Println("🐛 Advanced Debugging with Solve Issue Chatmate")
+	output.Println("===============================================")
+	output.Println("")
 
-	fmt.Println("Master advanced debugging techniques using the Solve Issue chatmate.")
-	fmt.Println("")
+	output.Println("Master advanced debugging techniques using the Solve Issue chatmate.")
+	output.Println("")
 
 	if !prompt("Ready for advanced debugging techniques?") {
 		return nil
 	}
 
-	debugScenarios := GetDebuggingScenarios()
+	debugScenarios := GetDebuggingScenarios(tutorialChatmates(chatMateManager, "debugging"))
 	showScenarios("🔍 Advanced Debugging Scenarios:", debugScenarios, prompt)
 
-	fmt.Println("🎯 Advanced Debugging Best Practices:")
-	fmt.Println("• Systematic root cause analysis")
-	fmt.Println("• Include comprehensive diagnostic information")
-	fmt.Println("• Document your debugging process for future reference")
-	fmt.Println("• Easy to isolate and fix issues")
-	fmt.Println("")
+	output.Println("🎯 Advanced Debugging Best Practices:")
+	output.Println("• Systematic root cause analysis")
+	output.Println("• Include comprehensive diagnostic information")
+	output.Println("• Document your debugging process for future reference")
+	output.Println("• Easy to isolate and fix issues")
+	output.Println("")
 
-	fmt.Println("✅ Advanced Debugging Tutorial Complete!")
+	output.Println("✅ Advanced Debugging Tutorial Complete!")
 	return nil
 }
 
 // RunTestingTutorial runs the comprehensive testing tutorial
-func RunTestingTutorial(prompt PromptFunc) error {
-	fmt.Println("🧪 Comprehensive Testing with Testing Chatmate")
-	fmt.Println("===============================================")
-	fmt.Println("")
+func RunTestingTutorial(chatMateManager *manager.ChatMateManager, prompt PromptFunc) error {
+	output.Println("🧪 Comprehensive Testing with Testing Chatmate")
+	output.Println("===============================================")
+	output.Println("")
 
-	fmt.Println("Learn comprehensive testing strategies using the Testing chatmate.")
-	fmt.Println("")
+	output.Println("Learn comprehensive testing strategies using the Testing chatmate.")
+	output.Println("")
 
 	if !prompt("Ready to learn comprehensive testing?") {
 		return nil
 	}
 
-	testScenarios := GetTestingScenarios()
+	testScenarios := GetTestingScenarios(tutorialChatmates(chatMateManager, "testing"))
 	showScenarios("🧪 Testing Scenarios:", testScenarios, prompt)
 
-	fmt.Println("🎯 Testing Best Practices:")
-	fmt.Println("• Write tests before or during development (TDD/BDD)")
-	fmt.Println("• Cover happy path, edge cases, and error scenarios")
-	fmt.Println("• Use descriptive test names and clear assertions")
-	fmt.Println("• Keep tests independent and repeatable")
-	fmt.Println("• Regularly review and update test coverage")
-	fmt.Println("")
+	output.Println("🎯 Testing Best Practices:")
+	output.Println("• Write tests before or during development (TDD/BDD)")
+	output.Println("• Cover happy path, edge cases, and error scenarios")
+	output.Println("• Use descriptive test names and clear assertions")
+	output.Println("• Keep tests independent and repeatable")
+	output.Println("• Regularly review and update test coverage")
+	output.Println("")
 
-	fmt.Println("✅ Comprehensive Testing Tutorial Complete!")
+	output.Println("✅ Comprehensive Testing Tutorial Complete!")
 	return nil
 }
 
 // showScenarios displays scenarios with interactive examples
 func showScenarios(title string, scenarios []ScenarioInfo, prompt PromptFunc) {
-	fmt.Println(title)
-	fmt.Println("")
+	output.Println(title)
+	output.Println("")
 
 	for i, scenario := range scenarios {
-		fmt.Printf("%d. %s\n", i+1, scenario.Title)
-		fmt.Printf("   %s\n", scenario.Description)
-		fmt.Printf("   Example: %s\n", scenario.Example)
-		fmt.Println("")
+		output.Printf("%d. %s\n", i+1, scenario.Title)
+		output.Printf("   %s\n", scenario.Description)
+		output.Printf("   Example: %s\n", scenario.Example)
+		output.Println("")
 
-		fmt.Println("   💡 Tips:")
+		output.Println("   💡 Tips:")
 		for _, tip := range scenario.Tips {
-			fmt.Printf("   • %s\n", tip)
+			output.Printf("   • %s\n", tip)
 		}
-		fmt.Println("")
+		output.Println("")
 
 		if i < len(scenarios)-1 {
 			if !prompt("Ready for the next scenario?") {
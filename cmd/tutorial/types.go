@@ -1,10 +1,7 @@
 package tutorial
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strings"
+	"github.com/jonassiebler/chatmate/internal/output"
 )
 
 // PromptFunc defines the function signature for user interaction prompts
@@ -29,13 +26,5 @@ type ScenarioInfo struct {
 
 // PromptToContinue asks the user if they want to continue and returns their response
 func PromptToContinue(message string) bool {
-	fmt.Printf("❓ %s [Y/n]: ", message)
-
-	scanner := bufio.NewScanner(os.Stdin)
-	if !scanner.Scan() {
-		return false
-	}
-
-	response := strings.TrimSpace(strings.ToLower(scanner.Text()))
-	return response == "" || response == "y" || response == "yes"
+	return output.ConfirmDefault(true, "❓ %s", message)
 }
@@ -2,18 +2,20 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/jonassiebler/chatmate/cmd/tutorial"
+	"github.com/jonassiebler/chatmate/internal/manager"
 )
 
 func TestRunDailyDevTutorial(t *testing.T) {
 	mockPrompt := func(msg string) bool { return false }
 	output := captureOutput(func() {
-		err := tutorial.RunDailyDevTutorial(mockPrompt)
+		err := tutorial.RunDailyDevTutorial(context.Background(), testTutorialManager(t), mockPrompt)
 		if err != nil {
 			t.Errorf("RunDailyDevTutorial returned error: %v", err)
 		}
@@ -26,7 +28,7 @@ func TestRunDailyDevTutorial(t *testing.T) {
 func TestRunTeamLeadTutorial(t *testing.T) {
 	mockPrompt := func(msg string) bool { return false }
 	output := captureOutput(func() {
-		err := tutorial.RunTeamLeadTutorial(mockPrompt)
+		err := tutorial.RunTeamLeadTutorial(testTutorialManager(t), mockPrompt)
 		if err != nil {
 			t.Errorf("RunTeamLeadTutorial returned error: %v", err)
 		}
@@ -39,7 +41,7 @@ func TestRunTeamLeadTutorial(t *testing.T) {
 func TestRunDebuggingTutorial(t *testing.T) {
 	mockPrompt := func(msg string) bool { return false }
 	output := captureOutput(func() {
-		err := tutorial.RunDebuggingTutorial(mockPrompt)
+		err := tutorial.RunDebuggingTutorial(testTutorialManager(t), mockPrompt)
 		if err != nil {
 			t.Errorf("RunDebuggingTutorial returned error: %v", err)
 		}
@@ -52,7 +54,7 @@ func TestRunDebuggingTutorial(t *testing.T) {
 func TestRunTestingTutorial(t *testing.T) {
 	mockPrompt := func(msg string) bool { return false }
 	output := captureOutput(func() {
-		err := tutorial.RunTestingTutorial(mockPrompt)
+		err := tutorial.RunTestingTutorial(testTutorialManager(t), mockPrompt)
 		if err != nil {
 			t.Errorf("RunTestingTutorial returned error: %v", err)
 		}
@@ -62,6 +64,35 @@ func TestRunTestingTutorial(t *testing.T) {
 	}
 }
 
+// testTutorialManager returns a manager of the embedded chatmates with an
+// empty prompts directory
+func testTutorialManager(t *testing.T) *manager.ChatMateManager {
+	t.Helper()
+
+	chatMateManager, err := manager.NewChatMateManager()
+	if err != nil {
+		t.Fatalf("NewChatMateManager failed: %v", err)
+	}
+	chatMateManager.UseEmbedded = true
+	chatMateManager.PromptsDir = t.TempDir()
+	return chatMateManager
+}
+
+// TestTutorialUsesManager tests that tutorials show the prompts directory of
+// the manager they are given rather than the default one
+func TestTutorialUsesManager(t *testing.T) {
+	chatMateManager := testTutorialManager(t)
+	output := captureOutput(func() {
+		err := runTutorial(context.Background(), chatMateManager, "daily-dev", func(string) bool { return true })
+		if err != nil {
+			t.Errorf("runTutorial returned error: %v", err)
+		}
+	})
+	if !strings.Contains(output, chatMateManager.PromptsDir) {
+		t.Errorf("Expected the status of %s, got: %s", chatMateManager.PromptsDir, output)
+	}
+}
+
 // helper to capture stdout
 func captureOutput(f func()) string {
 	old := os.Stdout
@@ -82,7 +113,7 @@ func TestRunTutorial_FirstTime(t *testing.T) {
 	mockPrompt := func(msg string) bool { return false }
 
 	output := captureOutput(func() {
-		err := runTutorial("first-time", mockPrompt)
+		err := runTutorial(context.Background(), testTutorialManager(t), "first-time", mockPrompt)
 		if err != nil {
 			t.Errorf("runTutorial returned error: %v", err)
 		}
@@ -95,7 +126,7 @@ func TestRunTutorial_FirstTime(t *testing.T) {
 
 func TestRunTutorial_Unknown(t *testing.T) {
 	output := captureOutput(func() {
-		err := runTutorial("unknown-tutorial", nil)
+		err := runTutorial(context.Background(), testTutorialManager(t), "unknown-tutorial", nil)
 		if err != nil {
 			t.Errorf("runTutorial returned error: %v", err)
 		}
@@ -105,3 +136,58 @@ func TestRunTutorial_Unknown(t *testing.T) {
 		t.Errorf("Expected not found message, got: %s", output)
 	}
 }
+
+// TestTutorialScenarioExamples tests that scenarios are built from chatmate metadata
+func TestTutorialScenarioExamples(t *testing.T) {
+	chatMateManager := &manager.ChatMateManager{UseEmbedded: true, PromptsDir: t.TempDir()}
+	chatmates, err := chatMateManager.AvailableMetadata()
+	if err != nil {
+		t.Fatalf("AvailableMetadata failed: %v", err)
+	}
+
+	scenarioSets := map[string][]tutorial.ScenarioInfo{
+		"daily-dev": tutorial.GetDailyDevScenarios(chatmates),
+		"team-lead": tutorial.GetTeamLeadScenarios(chatmates),
+		"debugging": tutorial.GetDebuggingScenarios(chatmates),
+		"testing":   tutorial.GetTestingScenarios(chatmates),
+	}
+	for name, scenarios := range scenarioSets {
+		if len(scenarios) == 0 {
+			t.Errorf("Tutorial %s should have scenarios from the shipped chatmates", name)
+		}
+		for _, scenario := range scenarios {
+			if !strings.HasPrefix(scenario.Example, "@"+scenario.Chatmate+" ") || len(scenario.Tips) == 0 {
+				t.Errorf("Scenario %q should come from the metadata of %s: %+v", scenario.Title, scenario.Chatmate, scenario)
+			}
+		}
+	}
+
+	// Examples may only reference tutorials that exist
+	known := make(map[string]bool)
+	for _, info := range tutorial.GetAvailableTutorials() {
+		known[info.Name] = true
+	}
+	for _, chatmate := range chatmates {
+		for _, example := range chatmate.Examples {
+			for _, name := range example.Tutorials {
+				if !known[name] {
+					t.Errorf("%s references unknown tutorial %q", chatmate.Filename, name)
+				}
+			}
+		}
+	}
+
+	// Only the given chatmates contribute scenarios
+	var testingOnly []manager.ChatmateMetadata
+	for _, chatmate := range chatmates {
+		if chatmate.Name == "Testing" {
+			testingOnly = append(testingOnly, chatmate)
+		}
+	}
+	if scenarios := tutorial.GetDebuggingScenarios(testingOnly); len(scenarios) != 0 {
+		t.Errorf("Expected no debugging scenarios without Solve Issue, got %d", len(scenarios))
+	}
+	if scenarios := tutorial.GetTestingScenarios(testingOnly); len(scenarios) != len(scenarioSets["testing"]) {
+		t.Errorf("Expected all testing scenarios from Testing, got %d", len(scenarios))
+	}
+}
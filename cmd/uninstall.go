@@ -4,19 +4,27 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/output"
 	"github.com/spf13/cobra"
 )
 
-var (
-	uninstallAll bool
-)
+// uninstallOptions holds the flags of the uninstall command.
+type uninstallOptions struct {
+	all       bool
+	explain   bool
+	workspace bool
+}
 
-// uninstallCmd represents the uninstall command
-var uninstallCmd = &cobra.Command{
-	Use:   "uninstall [chatmate names...]",
-	Short: "Uninstall chatmate agents from VS Code",
-	Long: `Remove chatmate agents from your VS Code Copilot Chat setup.
+// NewUninstallCmd creates the uninstall command.
+func NewUninstallCmd(deps *Deps) *cobra.Command {
+	opts := &uninstallOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "uninstall [chatmate names...]",
+		Aliases: []string{"rm"},
+		Short:   "Uninstall chatmate agents from VS Code",
+		Long: `Remove chatmate agents from your VS Code Copilot Chat setup.
 	
 🗑️  Uninstall Options:
 • Remove specific chatmates by name
@@ -36,8 +44,9 @@ var uninstallCmd = &cobra.Command{
 💡 Pro Tips:
 • Use 'chatmate list --installed' first to see what's available to remove
 • Uninstalling doesn't affect your VS Code settings or other extensions
-• You can reinstall anytime without losing functionality`,
-	Example: `  # Uninstall a specific chatmate
+• You can reinstall anytime without losing functionality
+• Add --explain to see what would be removed and why, without removing it`,
+		Example: `  # Uninstall a specific chatmate
   chatmate uninstall "Solve Issue"
   
   # Uninstall multiple chatmates at once
@@ -49,45 +58,64 @@ var uninstallCmd = &cobra.Command{
   # Common workflow: check what's installed, then remove unused ones
   chatmate list --installed
   chatmate uninstall "Documentation" "Optimize Issues"`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		chatMateManager, err := manager.NewChatMateManager()
-		if err != nil {
-			return fmt.Errorf("failed to initialize ChatMate manager: %w", err)
-		}
-
-		// Handle uninstall all flag
-		if uninstallAll {
-			if len(args) > 0 {
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.all && len(args) > 0 {
 				return fmt.Errorf("cannot specify chatmate names when using --all flag")
 			}
-			fmt.Println("Uninstalling all chatmates...")
-			return chatMateManager.Uninstaller().UninstallAll()
-		}
-
-		// Handle specific chatmate uninstall
-		if len(args) == 0 {
-			return fmt.Errorf("must specify chatmate names to uninstall or use --all flag")
-		}
-
-		fmt.Printf("Uninstalling chatmates: %s\n", strings.Join(args, ", "))
-		return chatMateManager.Uninstaller().UninstallSpecific(args)
-	},
-}
+			if !opts.all && len(args) == 0 {
+				return fmt.Errorf("must specify chatmate names to uninstall or use --all flag")
+			}
+			if opts.workspace {
+				if err := app.Manager.UseWorkspace(""); err != nil {
+					return err
+				}
+			}
+
+			// Describe the plan instead of uninstalling
+			if opts.explain {
+				explanation, err := app.Manager.Uninstaller().ExplainUninstall(args)
+				if err != nil {
+					return err
+				}
+				view.Explanation(explanation)
+				return nil
+			}
+
+			// Handle uninstall all flag
+			if opts.all {
+				output.Println("Uninstalling all chatmates...")
+				return app.Manager.Uninstaller().UninstallAll(app.Context)
+			}
 
-func init() {
-	rootCmd.AddCommand(uninstallCmd)
+			// Handle specific chatmate uninstall
+			output.Printf("Uninstalling chatmates: %s\n", strings.Join(args, ", "))
+			return app.Manager.Uninstaller().UninstallSpecific(app.Context, args)
+		}),
+	}
 
 	// Add flags
-	uninstallCmd.Flags().BoolVarP(&uninstallAll, "all", "a", false,
+	cmd.Flags().BoolVarP(&opts.all, "all", "a", false,
 		"Uninstall all installed chatmates")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false,
+		"Describe what would be removed and why, without uninstalling")
+	cmd.Flags().BoolVar(&opts.workspace, "workspace", false,
+		"Uninstall from the .github/prompts directory of the current repository")
 
 	// Add examples
-	uninstallCmd.Example = `  # Uninstall a specific chatmate
+	cmd.Example = `  # Uninstall a specific chatmate
   chatmate uninstall "Solve Issue"
 
   # Uninstall multiple chatmates
   chatmate uninstall "Solve Issue" "Create PR"
   
   # Uninstall all chatmates
-  chatmate uninstall --all`
+  chatmate uninstall --all
+
+  # Explain what --all would remove and what it preserves
+  chatmate uninstall --all --explain
+
+  # Remove a chatmate installed into the current repository
+  chatmate uninstall --workspace "Review PR"`
+
+	return cmd
 }
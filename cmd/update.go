@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// updateOptions holds the flags of the update command.
+type updateOptions struct {
+	force          bool
+	dryRun         bool
+	acceptBreaking bool
+}
+
+// NewUpdateCmd creates the update command.
+func NewUpdateCmd(deps *Deps) *cobra.Command {
+	opts := &updateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "update [chatmate names...]",
+		Aliases: []string{"up"},
+		Short:   "Update installed chatmates whose shipped version changed",
+		Long: `Bring installed chatmates up to date with the versions shipped with this
+ChatMate release, reinstalling only the chatmates that changed.
+
+🔄 How It Works:
+• Every installed chatmate that ships with ChatMate is compared with the
+  shipped version; the frontmatter 'version:' is shown when it changes
+• Chatmates with unchanged content are left alone
+• Chatmates you edited since they were installed are kept, so local changes
+  are not lost, and so are chatmates installed from a registry release or
+  whose shipped version is outside the range the team policy pins them to;
+  use --force to replace them too
+• An update to a release the registry marks as breaking shows its changelog
+  and must be confirmed on its own; --yes does not confirm it, so use
+  --accept-breaking in scripts
+• User-created chatmates are never touched
+
+Unlike 'chatmate hire --force', which rewrites every chatmate, update only
+replaces what changed. Use --dry-run to see the plan without changing
+anything, and 'chatmate outdated' to list what is outdated and what is
+kept.`,
+		Example: `  # Update every installed chatmate that changed
+  chatmate update
+
+  # See what would be updated
+  chatmate update --dry-run
+
+  # Update specific chatmates, replacing local edits
+  chatmate update --force "Solve Issue" "Testing"`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if !opts.dryRun {
+				if err := checkEditor(app.Manager, false); err != nil {
+					return err
+				}
+				if err := migrateLegacyNames(app); err != nil {
+					return err
+				}
+			}
+
+			app.Manager.Installer().AcceptBreaking = opts.acceptBreaking
+			updated, err := app.Manager.Installer().Update(app.Context, args, opts.force, opts.dryRun)
+			if err != nil {
+				return err
+			}
+			if updated > 0 {
+				output.Printf("\n✅ Updated %d chatmate(s)\n", updated)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "also replace chatmates that were edited locally")
+	cmd.Flags().BoolVarP(&opts.dryRun, "dry-run", "n", false, "show what would be updated without changing anything")
+	cmd.Flags().BoolVar(&opts.acceptBreaking, "accept-breaking", false, "install updates the registry marks as breaking without asking")
+
+	return cmd
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// validateOptions holds the flags of the validate command.
+type validateOptions struct {
+	writeProbe         bool
+	cleanSyncConflicts bool
+}
+
+// NewValidateCmd creates the validate command.
+func NewValidateCmd(deps *Deps) *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the ChatMate installation",
+		Long: `Run validation checks against your ChatMate installation and report the
+result of each check.
+
+🔍 Checks Performed:
+• Prompts directory exists, is a directory, and is writable
+• Available chatmates have valid filenames
+• Installed chatmates are readable and well-formed
+• Installed files without a matching available chatmate (orphans)
+• Installed chatmates still named the way older releases named them
+  ("Chatmate - Solve Issue.chatmode.md")
+• Installed files with the same display name, such as "Solve Issue" and
+  "solve issue", which make @-mentions ambiguous
+• Installed files whose name only differs in case from an available
+  chatmate, which is the same file on macOS and Windows; the chatmate is
+  not installed or updated until the file is renamed or uninstalled
+• Conflict copies left by cloud-sync clients (Dropbox, OneDrive, Nextcloud,
+  Syncthing), which show up as duplicate chatmates in Copilot Chat
+• Display names over 64 characters, which the chat mode picker cuts off,
+  and installed paths over 260 characters, which Windows tools without long
+  path support cannot open; a shorter name is suggested for each
+• Installed chatmates and a prompts directory with more permissions than
+  the fileMode and dirMode settings (0644 and 0755 by default), or that you
+  cannot read and write (not checked on Windows)
+
+📋 Output:
+• One line per check with its status and message
+• Affected files listed below checks that did not pass
+• --output json or yaml prints the full report for scripts and CI pipelines
+
+Write access is checked by querying permissions, so nothing is created in the
+prompts directory. On filesystems where that is unreliable (some network or
+FUSE mounts), --write-probe checks by creating and removing a temporary file.
+
+--clean-sync-conflicts lists the conflict copies and removes them after
+confirmation, before the checks run. Compare them with the original first:
+a conflict copy may hold the only copy of an edit.
+
+The command exits with a non-zero status if any check fails.`,
+		Example: `  # Validate the installation
+  chatmate validate
+
+  # Machine-readable report
+  chatmate validate --output json | jq '.checks[] | select(.status != "pass")'
+
+  # Remove cloud-sync conflict copies from the prompts directory
+  chatmate validate --clean-sync-conflicts`,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if opts.cleanSyncConflicts {
+				if err := cleanSyncConflicts(app.Context, app.Manager, app.Config.DryRun); err != nil {
+					return err
+				}
+			}
+
+			validator := app.Manager.Validator()
+			validator.WriteProbe = opts.writeProbe
+
+			report, err := validator.ValidateInstallation(app.Context)
+			if err != nil {
+				return err
+			}
+
+			if app.Structured() {
+				if err := app.Write(report, "validation report"); err != nil {
+					return err
+				}
+			} else {
+				printValidationReport(report)
+				for _, check := range report.Checks {
+					if check.Name == "sync-conflicts" && check.Status == manager.CheckWarn {
+						output.Println("\n💡 Remove the conflict copies with 'chatmate validate --clean-sync-conflicts'")
+					}
+				}
+			}
+
+			if !report.Valid() {
+				// The report already explains the failure; usage text adds noise
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%w: %d of %d checks failed", manager.ErrValidationFailed,
+					report.Count(manager.CheckFail), len(report.Checks))
+			}
+
+			return nil
+		}),
+	}
+
+	cmd.Flags().Bool("json", false, "print the validation report as JSON, same as --output json")
+	cmd.Flags().BoolVar(&opts.writeProbe, "write-probe", false, "check write access by creating a temporary file in the prompts directory")
+	cmd.Flags().BoolVar(&opts.cleanSyncConflicts, "clean-sync-conflicts", false, "remove cloud-sync conflict copies from the prompts directory")
+
+	return cmd
+}
+
+// printValidationReport renders a validation report for humans.
+func printValidationReport(report *manager.Report) {
+	output.Println("🔍 Validating ChatMate installation...")
+	output.Printf("Prompts Directory: %s\n\n", report.PromptsDir)
+
+	for _, check := range report.Checks {
+		symbol := output.SymbolSuccess
+		switch check.Status {
+		case manager.CheckWarn:
+			symbol = output.SymbolWarning
+		case manager.CheckFail:
+			symbol = output.SymbolFailure
+		}
+
+		output.Printf("%s %s: %s\n", symbol, check.Name, check.Message)
+		if len(check.Files) > 0 {
+			output.Printf("   %s\n", strings.Join(check.Files, "\n   "))
+		}
+	}
+
+	output.Printf("\n%d passed, %d warnings, %d failed\n",
+		report.Count(manager.CheckPass), report.Count(manager.CheckWarn), report.Count(manager.CheckFail))
+}
+
+// cleanSyncConflicts removes cloud-sync conflict copies from the prompts
+// directory after the user confirmed the list; dryRun tells whether the
+// global --dry-run only records the removals.
+func cleanSyncConflicts(ctx context.Context, chatMateManager *manager.ChatMateManager, dryRun bool) error {
+	conflicts, err := chatMateManager.FindSyncConflicts()
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		output.Println("No sync-conflict copies found")
+		return nil
+	}
+
+	output.Printf("Sync-conflict copies to be REMOVED (%d):\n", len(conflicts))
+	for _, filename := range conflicts {
+		output.Printf("  ❌ %s\n", filename)
+	}
+
+	if !output.Confirm("\nDo you want to remove these files?") {
+		output.Println("❌ Cleanup cancelled by user")
+		return nil
+	}
+
+	removed, err := chatMateManager.Uninstaller().RemoveSyncConflicts(ctx, conflicts)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		output.Printf("✅ Would remove %d sync-conflict copies\n\n", removed)
+		return nil
+	}
+	output.Printf("✅ Removed %d sync-conflict copies\n\n", removed)
+	return nil
+}
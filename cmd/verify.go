@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/notify"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// verifyOptions holds the flags of the verify command.
+type verifyOptions struct {
+	installed bool
+	every     time.Duration
+}
+
+// NewVerifyCmd creates the verify command.
+func NewVerifyCmd(deps *Deps) *cobra.Command {
+	opts := &verifyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Detect chatmates that changed outside ChatMate",
+		Long: `Verify the installed chatmates against the SHA-256 hashes ChatMate recorded
+when it installed or adopted them.
+
+ChatMate records a new hash whenever it changes a chatmate, so a mismatch is
+an early warning for a file edited by hand, damaged by a sync tool, or
+tampered with. Chatmates without a recorded hash, such as files created by
+hand, are not verified.
+
+🚨 Alerts:
+When a chatmate was modified or deleted, the finding is recorded in the
+operation history (see 'chatmate status') and reported to the team policy
+webhook, if one is configured (see 'chatmate apply'). Without --every the
+command exits with an error, so CI jobs and cron fail.
+
+⏱️  Scheduled verification:
+With --every, ChatMate keeps running and verifies the prompts directory again
+at that interval until it is interrupted (Ctrl-C) or --timeout expires. A
+finding is alerted once, not again on every round until something changes.`,
+		Example: `  # Verify the installed chatmates once
+  chatmate verify --installed
+
+  # Keep verifying every hour, e.g. as a service
+  chatmate verify --installed --every 1h
+
+  # Machine-readable result
+  chatmate verify --installed --output json`,
+		Args: cobra.NoArgs,
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			if !opts.installed {
+				return fmt.Errorf("nothing to verify; use --installed to verify the installed chatmates")
+			}
+			if opts.every < 0 {
+				return fmt.Errorf("--every must not be negative")
+			}
+
+			alerted := ""
+			for {
+				report, err := app.Manager.VerifyIntegrity()
+				if err != nil && opts.every == 0 {
+					return err
+				}
+				if err != nil {
+					output.Warnf("Could not verify the installed chatmates: %v", err)
+				} else {
+					if app.Structured() {
+						if err := app.Write(report, "integrity report"); err != nil {
+							return err
+						}
+					} else {
+						view.Integrity(report)
+					}
+
+					// Alert each finding once, not on every round
+					if findings := integrityFindings(report); findings != alerted {
+						if !report.OK() {
+							alertIntegrity(app, report)
+						}
+						alerted = findings
+					}
+					if opts.every == 0 && !report.OK() {
+						return fmt.Errorf("%d chatmate(s) changed outside ChatMate", len(report.Issues()))
+					}
+				}
+				if opts.every == 0 {
+					return nil
+				}
+
+				output.Debugf("Verifying again in %s\n", opts.every)
+				select {
+				case <-app.Context.Done():
+					return nil
+				case <-time.After(opts.every):
+				}
+			}
+		}),
+	}
+
+	cmd.Flags().BoolVar(&opts.installed, "installed", true,
+		"verify the installed chatmates against their recorded hashes")
+	cmd.Flags().DurationVar(&opts.every, "every", 0,
+		"keep running and verify again at this interval (e.g., 1h); 0 verifies once")
+
+	return cmd
+}
+
+// integrityFindings returns a key of the chatmates that failed verification
+// and why, to tell whether a round found something new.
+func integrityFindings(report *manager.IntegrityReport) string {
+	var findings []string
+	for _, issue := range report.Issues() {
+		findings = append(findings, issue.Filename+"@"+issue.Actual)
+	}
+	return strings.Join(findings, "\n")
+}
+
+// alertIntegrity records chatmates that changed outside ChatMate in the
+// operation history and reports them to the team policy webhook.
+//
+// Like the summary, alerts observe the verification: failures are reported
+// without changing the outcome of the command.
+func alertIntegrity(app *App, report *manager.IntegrityReport) {
+	names := make([]string, 0, len(report.Issues()))
+	for _, issue := range report.Issues() {
+		names = append(names, issue.Name)
+	}
+	verifyErr := fmt.Errorf("%d chatmate(s) changed outside ChatMate: %s", len(names), strings.Join(names, ", "))
+
+	summary := state.Summary{
+		Command:   "verify",
+		Success:   false,
+		Error:     verifyErr.Error(),
+		Installed: len(report.Verified) + len(report.Modified) + len(report.Unrecorded),
+		Timestamp: report.CheckedAt,
+	}
+	if err := state.AppendHistory(summary); err != nil {
+		output.Debugf("Could not record the verification in the operation history: %v\n", err)
+	}
+
+	webhookReport := newReport(app, "verify", verifyErr)
+	for _, issue := range report.Modified {
+		webhookReport.Failures = append(webhookReport.Failures, notify.Failure{Chatmate: issue.Name, Error: "modified outside ChatMate"})
+	}
+	for _, issue := range report.Missing {
+		webhookReport.Failures = append(webhookReport.Failures, notify.Failure{Chatmate: issue.Name, Error: "deleted outside ChatMate"})
+	}
+	notifyWebhook(app, webhookReport)
+}
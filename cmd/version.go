@@ -1,17 +1,18 @@
 package cmd
 
 import (
-	"fmt"
 	"runtime"
 
+	"github.com/jonassiebler/chatmate/internal/output"
 	"github.com/spf13/cobra"
 )
 
-// versionCmd represents the version command
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "🏷️  Show chatmate version information",
-	Long: `Display detailed version information about chatmate including:
+// NewVersionCmd creates the version command.
+func NewVersionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "🏷️  Show chatmate version information",
+		Long: `Display detailed version information about chatmate including:
 
 • Version number (semantic versioning)
 • Build commit hash
@@ -25,7 +26,7 @@ This information is useful for:
 • Verifying installation and updates
 • Development and debugging
 • Compliance and security audits`,
-	Example: `  # Show basic version
+		Example: `  # Show basic version
   chatmate version
   
   # Show version in CI/automation (exit code 0)
@@ -33,43 +34,42 @@ This information is useful for:
   
   # Include in bug reports
   chatmate version --full`,
-	Run: func(cmd *cobra.Command, args []string) {
-		quiet, _ := cmd.Flags().GetBool("quiet")
-		full, _ := cmd.Flags().GetBool("full")
+		Run: func(cmd *cobra.Command, args []string) {
+			quiet, _ := cmd.Flags().GetBool("quiet")
+			full, _ := cmd.Flags().GetBool("full")
 
-		if quiet {
-			fmt.Printf("%s\n", version)
-			return
-		}
+			if quiet {
+				output.Printf("%s\n", version)
+				return
+			}
 
-		fmt.Printf("🏷️  Chatmate Version Information\n")
-		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		fmt.Printf("Version:      %s\n", version)
-		fmt.Printf("Commit:       %s\n", commit)
-		fmt.Printf("Built:        %s\n", date)
+			output.Printf("🏷️  Chatmate Version Information\n")
+			output.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+			output.Printf("Version:      %s\n", version)
+			output.Printf("Commit:       %s\n", commit)
+			output.Printf("Built:        %s\n", date)
 
-		if full {
-			fmt.Printf("\n🔧 Build Information\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("Go Version:   %s\n", runtime.Version())
-			fmt.Printf("Platform:     %s/%s\n", runtime.GOOS, runtime.GOARCH)
-			fmt.Printf("Compiler:     %s\n", runtime.Compiler)
+			if full {
+				output.Printf("\n🔧 Build Information\n")
+				output.Printf("━━━━━━━━━━━━━━━━━━━━\n")
+				output.Printf("Go Version:   %s\n", runtime.Version())
+				output.Printf("Platform:     %s/%s\n", runtime.GOOS, runtime.GOARCH)
+				output.Printf("Compiler:     %s\n", runtime.Compiler)
 
-			fmt.Printf("\n📊 Runtime Information\n")
-			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━\n")
-			fmt.Printf("Goroutines:   %d\n", runtime.NumGoroutine())
-			fmt.Printf("CPUs:         %d\n", runtime.NumCPU())
+				output.Printf("\n📊 Runtime Information\n")
+				output.Printf("━━━━━━━━━━━━━━━━━━━━━━\n")
+				output.Printf("Goroutines:   %d\n", runtime.NumGoroutine())
+				output.Printf("CPUs:         %d\n", runtime.NumCPU())
 
-			var m runtime.MemStats
-			runtime.ReadMemStats(&m)
-			fmt.Printf("Memory:       %.2f MB\n", float64(m.Alloc)/1024/1024)
-		}
-	},
-}
+				var m runtime.MemStats
+				runtime.ReadMemStats(&m)
+				output.Printf("Memory:       %.2f MB\n", float64(m.Alloc)/1024/1024)
+			}
+		},
+	}
 
-func init() {
-	rootCmd.AddCommand(versionCmd)
+	cmd.Flags().BoolP("quiet", "q", false, "show only version number")
+	cmd.Flags().BoolP("full", "f", false, "show full build and runtime information")
 
-	versionCmd.Flags().BoolP("quiet", "q", false, "show only version number")
-	versionCmd.Flags().BoolP("full", "f", false, "show full build and runtime information")
+	return cmd
 }
@@ -0,0 +1,454 @@
+// Package view renders the results of ChatMate operations as the text that
+// commands print.
+//
+// The manager services return typed results (listings, reports, plans) and
+// leave presentation to this package, so the same results can be written as
+// text, as JSON or YAML, or used by programs embedding ChatMate.
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// Listing prints all chatmates of a listing with their installation status.
+//
+// Parameters:
+//   - listing: The chatmates to print, as returned by ListerService.Listing
+//   - label: How the prompts directory is named (see PromptsDirLabel)
+func Listing(listing *manager.Listing, label string) {
+	output.Printf("ChatMate Agents in %s: %s\n\n", label, listing.PromptsDir)
+
+	var available, installed int
+	for _, chatmate := range listing.Chatmates {
+		if !chatmate.Available {
+			continue
+		}
+		available++
+		if chatmate.Installed {
+			installed++
+			output.Printf("✅ %s%s\n", chatmate.Name, modifiedMark(chatmate))
+			provenance(chatmate)
+		} else {
+			output.Printf("⬜ %s\n", chatmate.Name)
+		}
+	}
+
+	if listing.Pages() > 0 {
+		page(listing)
+		return
+	}
+	if available == 0 {
+		output.Println("No chatmates available")
+		return
+	}
+	output.Printf("\nSummary: %d/%d chatmates installed\n", installed, available)
+}
+
+// page prints which page of a paginated listing was shown, and how to see
+// the next one.
+func page(listing *manager.Listing) {
+	output.Printf("\nPage %d of %d (%d chatmates)\n", listing.Page, listing.Pages(), listing.Total)
+	if listing.Page < listing.Pages() {
+		output.Printf("Next page: --page %d\n", listing.Page+1)
+	}
+}
+
+// AvailableListing prints a numbered list of the available chatmates.
+func AvailableListing(listing *manager.Listing) {
+	output.Println("Available ChatMate Agents:")
+
+	if len(listing.Chatmates) == 0 {
+		output.Println("No chatmates available")
+		return
+	}
+
+	for i, chatmate := range listing.Chatmates {
+		output.Printf("%d. %s\n", listing.Offset()+i+1, chatmate.Name)
+	}
+	if listing.Pages() > 0 {
+		page(listing)
+		return
+	}
+	output.Printf("\nTotal: %d chatmates available\n", len(listing.Chatmates))
+}
+
+// InstalledListing prints a numbered list of the installed chatmates, with
+// their provenance in long listings.
+func InstalledListing(listing *manager.Listing) {
+	output.Printf("Installed ChatMate Agents in: %s\n", listing.PromptsDir)
+
+	if len(listing.Chatmates) == 0 {
+		output.Println("No chatmates are currently installed")
+		return
+	}
+
+	for i, chatmate := range listing.Chatmates {
+		output.Printf("%d. ✅ %s%s\n", listing.Offset()+i+1, chatmate.Name, modifiedMark(chatmate))
+		provenance(chatmate)
+	}
+	if listing.Pages() > 0 {
+		page(listing)
+		return
+	}
+	output.Printf("\nTotal: %d chatmates installed\n", len(listing.Chatmates))
+}
+
+// modifiedMark marks a listed chatmate that was edited since it was installed.
+func modifiedMark(chatmate manager.ListedChatmate) string {
+	if chatmate.Modified {
+		return " (modified)"
+	}
+	return ""
+}
+
+// provenance prints where an installed chatmate came from, and who owns it
+// in a shared prompts directory, below its listing entry. Only long listings
+// carry the provenance; nothing is printed otherwise.
+func provenance(chatmate manager.ListedChatmate) {
+	if chatmate.Provenance == "" {
+		return
+	}
+	output.Printf("     from: %s\n", chatmate.Provenance)
+	if chatmate.Owner != "" {
+		output.Printf("     owner: %s\n", chatmate.Owner)
+	}
+}
+
+// Details prints a single chatmate with its metadata and content.
+//
+// Parameters:
+//   - details: The chatmate, as returned by ListerService.Details
+//   - raw: If true, writes only the file content to standard output, which
+//     makes the output suitable for piping into other tools
+//
+// Returns:
+//   - error: Failure to write the raw content
+func Details(details *manager.ChatmateDetails, raw bool) error {
+	if raw {
+		_, err := output.Stdout().Write([]byte(details.Content))
+		return err
+	}
+
+	output.Printf("Name: %s\n", details.Name)
+	output.Printf("File: %s\n", details.Filename)
+	output.Printf("Source: %s\n", details.Source)
+	if details.Installed {
+		output.Printf("Status: ✅ installed\n")
+		output.Printf("Provenance: %s\n", details.Provenance)
+		if details.Owner != "" {
+			output.Printf("Owner: %s\n", details.Owner)
+		}
+	} else {
+		output.Printf("Status: ⬜ not installed\n")
+	}
+	if meta := details.Metadata; meta != nil {
+		if meta.Author.Name != "" {
+			output.Printf("Author: %s\n", meta.Author)
+		}
+		if meta.License != "" {
+			output.Printf("License: %s\n", meta.License)
+		}
+		if meta.Version != "" {
+			output.Printf("Version: %s\n", meta.Version)
+		}
+		if len(meta.Examples) > 0 {
+			output.Println("Examples:")
+			for _, example := range meta.Examples {
+				output.Printf("  %s\n", example.Prompt)
+			}
+		}
+	}
+	output.Printf("\n%s", details.Content)
+	if !strings.HasSuffix(details.Content, "\n") {
+		output.Println()
+	}
+	return nil
+}
+
+// Location prints where an installed chatmate is.
+//
+// The path alone is written to standard output, even with --quiet, so it can
+// be used in shell pipelines such as code "$(chatmate which 'Solve Issue')".
+//
+// Parameters:
+//   - location: The chatmate, as returned by ListerService.Which
+//   - long: If true, also prints the source path and provenance
+//
+// Returns:
+//   - error: Failure to write the path
+func Location(location *manager.ChatmateLocation, long bool) error {
+	if _, err := fmt.Fprintln(output.Stdout(), location.Path); err != nil || !long {
+		return err
+	}
+	if location.SourcePath != "" {
+		output.Printf("Source: %s\n", location.SourcePath)
+	}
+	if location.Provenance != "" {
+		output.Printf("Provenance: %s\n", location.Provenance)
+	}
+	if location.Modified {
+		output.Printf("Modified: yes, edited since it was installed\n")
+	}
+	return nil
+}
+
+// Catalog prints the chatmates that can be installed, marking the ones
+// published in the registry.
+//
+// Parameters:
+//   - entries: The chatmates, as returned by ListerService.Browse
+//   - registryURL: URL of the registry; empty when none is configured
+func Catalog(entries []manager.CatalogEntry, registryURL string) {
+	if registryURL != "" {
+		output.Printf("Chatmate Registry (%s):\n", registryURL)
+	}
+	if len(entries) == 0 {
+		output.Println("No chatmates found matching the search term")
+		return
+	}
+
+	remote := 0
+	for _, entry := range entries {
+		status := "⬜"
+		if entry.Installed {
+			status = "✅"
+		}
+		label := entry.Name
+		if entry.Remote != nil {
+			remote++
+			label += " [registry]"
+			if entry.Author != "" {
+				label += " by " + entry.Author
+			}
+		}
+		output.Printf("  %s %s\n", status, label)
+		if entry.Description != "" {
+			output.Printf("     %s\n", entry.Description)
+		}
+	}
+
+	output.Printf("\n%d chatmates, %d from the registry\n", len(entries), remote)
+	if remote > 0 {
+		output.Println("Install registry chatmates with: chatmate hire --from-registry \"<name>\"")
+	}
+}
+
+// Outdated prints the outdated chatmates with what 'chatmate update' does
+// with them.
+//
+// Parameters:
+//   - report: The outdated chatmates, as returned by InstallerService.Outdated
+func Outdated(report *manager.OutdatedReport, all bool) {
+	if len(report.Chatmates) == 0 && !all {
+		output.Printf("✅ All %d installed chatmate(s) are up to date\n", len(report.Current))
+		return
+	}
+
+	chatmates := report.Chatmates
+	if all {
+		chatmates = append(append([]manager.ChatmateUpdate{}, report.Chatmates...), report.Current...)
+		sort.Slice(chatmates, func(i, j int) bool { return chatmates[i].Filename < chatmates[j].Filename })
+	}
+	rows := [][]string{{"CHATMATE", "INSTALLED", "AVAILABLE", "PIN", "UPDATE"}}
+	for _, update := range chatmates {
+		action := "will be updated"
+		switch update.State {
+		case manager.UpdateCurrent:
+			action = "up to date"
+		case manager.UpdateModified:
+			action = "kept: edited locally"
+		case manager.UpdatePinned:
+			action = "kept: newer version not pinned"
+		}
+		rows = append(rows, []string{manager.DisplayName(update.Filename),
+			versionOrDash(update.InstalledVersion), versionOrDash(update.AvailableVersion), versionOrDash(update.Pin), action})
+	}
+	output.PrintTable(rows)
+
+	output.Printf("\n%d up to date, %d outdated", len(report.Current), len(report.Chatmates))
+	if len(report.Chatmates) == 0 {
+		output.Println("; nothing to update")
+		return
+	}
+	updates := report.Updates()
+	output.Printf("; %d will be updated by 'chatmate update'", updates)
+	if kept := len(report.Chatmates) - updates; kept > 0 {
+		output.Printf(", use 'chatmate update --force' to replace the other %d", kept)
+	}
+	output.Println()
+}
+
+// Versions prints the previous versions of a chatmate, newest first.
+func Versions(name string, versions []state.Version) {
+	if len(versions) == 0 {
+		output.Printf("No previous versions of %s; they are kept when ChatMate replaces an installed chatmate\n", name)
+		return
+	}
+	rows := [][]string{{"VERSION", "REPLACED", "SIZE"}}
+	for _, version := range versions {
+		rows = append(rows, []string{fmt.Sprint(version.Number), output.FormatDateTime(version.Archived), output.FormatSize(version.Bytes)})
+	}
+	output.PrintTable(rows)
+	output.Printf("Restore one with: chatmate restore %q --version <n>\n", manager.DisplayName(versions[0].Filename))
+}
+
+// VersionRestored prints the outcome of 'chatmate restore --version'.
+func VersionRestored(report *manager.VersionRestoreReport) {
+	output.Printf("✅ Restored version %d of %s into %s\n", report.Version, manager.DisplayName(report.Filename), report.PromptsDir)
+	if report.Replaced != nil {
+		output.Printf("Undo with: chatmate restore %q --version %d\n", manager.DisplayName(report.Filename), report.Replaced.Number)
+	}
+}
+
+// versionOrDash returns v, or "-" when it is empty.
+func versionOrDash(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+// Integrity prints the result of verifying the installed chatmates against
+// their recorded hashes.
+func Integrity(report *manager.IntegrityReport) {
+	for _, issue := range report.Modified {
+		output.Printf("❌ %s: modified outside ChatMate (recorded %s)\n", issue.Name, output.FormatDateTime(issue.RecordedAt))
+		output.Printf("     expected sha256: %s\n", issue.Expected)
+		output.Printf("     actual sha256:   %s\n", issue.Actual)
+	}
+	for _, issue := range report.Missing {
+		output.Printf("❌ %s: deleted outside ChatMate (recorded %s)\n", issue.Name, output.FormatDateTime(issue.RecordedAt))
+	}
+	if len(report.Unrecorded) > 0 {
+		output.Debugf("Not verified (no recorded hash): %s\n", strings.Join(report.Unrecorded, ", "))
+	}
+
+	if report.OK() {
+		output.Printf("✅ %d chatmate(s) verified in %s", len(report.Verified), report.PromptsDir)
+	} else {
+		output.Printf("⚠️  %d of %d chatmate(s) changed unexpectedly in %s",
+			len(report.Issues()), len(report.Verified)+len(report.Issues()), report.PromptsDir)
+	}
+	if len(report.Unrecorded) > 0 {
+		output.Printf("; %d without a recorded hash not verified", len(report.Unrecorded))
+	}
+	output.Println()
+}
+
+// Diff prints how installed chatmates differ from the shipped versions.
+//
+// Parameters:
+//   - report: The comparison, as returned by ChatMateManager.Diff
+//   - summary: If true, prints a table with the number of changed lines per
+//     chatmate instead of the unified diffs
+func Diff(report *manager.DiffReport, summary bool) {
+	changed := report.Changed()
+	if len(report.Chatmates) == 0 {
+		output.Println("No installed chatmates ship with ChatMate; nothing to compare")
+		return
+	}
+
+	if !summary {
+		// Only the diffs are printed, so they can be piped into patch tools
+		for _, chatmate := range changed {
+			output.Printf("%s", chatmate.Diff)
+		}
+		if len(changed) == 0 {
+			output.Printf("✅ %d installed chatmate(s) match the shipped versions\n", len(report.Chatmates))
+		}
+		return
+	}
+
+	rows := [][]string{{"CHATMATE", "INSTALLED", "AVAILABLE", "CHANGES"}}
+	for _, chatmate := range report.Chatmates {
+		changes := "identical"
+		if chatmate.Changed {
+			changes = fmt.Sprintf("+%d -%d", chatmate.Added, chatmate.Removed)
+		}
+		rows = append(rows, []string{chatmate.Name,
+			versionOrDash(chatmate.InstalledVersion), versionOrDash(chatmate.AvailableVersion), changes})
+	}
+	output.PrintTable(rows)
+	output.Printf("\n%d of %d installed chatmate(s) differ from the shipped versions\n", len(changed), len(report.Chatmates))
+}
+
+// BackupCreated prints the archive made by 'chatmate backup'.
+func BackupCreated(report *manager.BackupReport) {
+	output.Printf("💾 Backed up %d file(s) from %s\n", report.Backup.Files, report.PromptsDir)
+	output.Printf("   %s (%s)\n", report.Backup.Path, output.FormatSize(report.Backup.Bytes))
+	if len(report.Rotated) > 0 {
+		output.Printf("Removed %d older backup(s) beyond the backupKeep setting\n", len(report.Rotated))
+	}
+}
+
+// Backups prints the archives of the prompts directory, newest first.
+func Backups(backups []manager.Backup) {
+	if len(backups) == 0 {
+		output.Println("No backups yet; create one with 'chatmate backup'")
+		return
+	}
+	rows := [][]string{{"BACKUP", "CREATED", "REASON", "FILES", "SIZE"}}
+	for _, backup := range backups {
+		reason := backup.Reason
+		if reason == "" {
+			reason = "manual"
+		}
+		rows = append(rows, []string{backup.Name, output.FormatDateTime(backup.Created), reason,
+			fmt.Sprint(backup.Files), output.FormatSize(backup.Bytes)})
+	}
+	output.PrintTable(rows)
+}
+
+// Restored prints the outcome of 'chatmate restore'.
+func Restored(report *manager.RestoreReport) {
+	output.Printf("✅ Restored %d file(s) from %s into %s\n", len(report.Restored), report.Archive, report.PromptsDir)
+	for _, filename := range report.Removed {
+		output.Printf("  ❌ removed %s (not in the backup)\n", manager.DisplayName(filename))
+	}
+	if report.Backup != nil {
+		output.Printf("Undo with: chatmate restore %s\n", report.Backup.Name)
+	}
+}
+
+// Activity prints the logged changes to chatmates, newest first.
+func Activity(events []state.ActivityEvent) {
+	if len(events) == 0 {
+		output.Println("No changes logged yet")
+		return
+	}
+	rows := [][]string{{"WHEN", "ACTION", "CHATMATE", "VERSION", "SOURCE"}}
+	for _, event := range events {
+		version := event.Version
+		if event.PreviousVersion != "" && event.PreviousVersion != event.Version {
+			version = event.PreviousVersion + " → " + version
+		}
+		rows = append(rows, []string{output.FormatDateTime(event.Timestamp), event.Action,
+			manager.DisplayName(event.Filename), version, event.Source})
+	}
+	output.PrintTable(rows)
+}
+
+// DryRun prints the file changes a dry run recorded instead of making them.
+func DryRun(changes []files.Change) {
+	if len(changes) == 0 {
+		output.Println("\nDry run: no files would change")
+		return
+	}
+	output.Printf("\nDry run: nothing was changed; %d file change(s) would be made:\n", len(changes))
+	rows := make([][]string, 0, len(changes))
+	for _, change := range changes {
+		path := change.Path
+		if change.To != "" {
+			path += " → " + change.To
+		}
+		rows = append(rows, []string{"  " + change.Kind, path})
+	}
+	output.PrintTable(rows)
+}
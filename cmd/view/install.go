@@ -0,0 +1,163 @@
+package view
+
+import (
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// InstallResult prints the outcome of installing a single chatmate.
+//
+// It is set as the manager's Progress function, so every outcome is printed
+// as it happens, including those of installations that fail part way.
+func InstallResult(result manager.InstallResult) {
+	symbol := "✅"
+	switch result.Status {
+	case manager.InstallSkipped:
+		symbol = "⏭️ "
+	case manager.InstallQueued:
+		symbol = "⏳"
+	case manager.InstallExported:
+		symbol = "📤"
+	case manager.InstallMigrated:
+		symbol = "🔀"
+	}
+	output.Printf("%s %s (%s)\n", symbol, result.Filename, result.Detail)
+}
+
+// InstallPlan prints what installing all available chatmates would do, so
+// the user can confirm it.
+//
+// Parameters:
+//   - plan: The plan, as returned by InstallerService.PlanInstall
+func InstallPlan(plan *manager.InstallPlan) {
+	output.Printf("📦 INSTALLATION CONFIRMATION\n")
+
+	modified := make(map[string]bool, len(plan.Modified))
+	for _, filename := range plan.Modified {
+		modified[filename] = true
+	}
+
+	if len(plan.Install) > 0 {
+		action := "INSTALLED"
+		reinstalls := 0
+		for _, planned := range plan.Install {
+			if planned.Status == manager.InstallReinstalled {
+				reinstalls++
+			}
+		}
+		if reinstalls > 0 {
+			action = "INSTALLED/REINSTALLED"
+		}
+		output.Printf("Repository chatmates to be %s (%d):\n", action, len(plan.Install))
+		for _, planned := range plan.Install {
+			status := "✅"
+			if planned.Status == manager.InstallReinstalled {
+				status = "🔄"
+			}
+			note := ""
+			if modified[planned.Filename] {
+				note = " (modified locally; the edits are replaced)"
+			}
+			output.Printf("  %s %s%s\n", status, planned.Name, note)
+		}
+	}
+
+	if len(plan.Skip) > 0 {
+		output.Printf("\nRepository chatmates already installed (will be SKIPPED) (%d):\n", len(plan.Skip))
+		for _, filename := range plan.Skip {
+			note := ""
+			if modified[filename] {
+				note = " (modified locally, use --force to replace)"
+			}
+			output.Printf("  ⏭️  %s%s\n", manager.DisplayName(filename), note)
+		}
+	}
+
+	if len(plan.Preserve) > 0 {
+		output.Printf("\nUser-created chatmates (will be PRESERVED) (%d):\n", len(plan.Preserve))
+		for _, filename := range plan.Preserve {
+			output.Printf("  📝 %s\n", manager.DisplayName(filename))
+		}
+	}
+
+	if len(plan.Collisions) > 0 {
+		output.Printf("\nRepository chatmates that only differ in case from an installed file (will be SKIPPED) (%d):\n", len(plan.Collisions))
+		for _, collision := range plan.Collisions {
+			output.Printf("  ⚠️  %s (installed as %s; uninstall or rename it to install %s)\n",
+				manager.DisplayName(collision.Filename), collision.Installed, collision.Filename)
+		}
+	}
+
+	output.Printf("\nDirectory: %s\n", plan.PromptsDir)
+}
+
+// QueuedApprovals explains how to get installations approved that the team
+// policy held back, if there are any.
+//
+// The instructions include the allowlist entries to add to the team policy,
+// so users can forward them to whoever maintains the policy.
+//
+// Parameters:
+//   - queued: The queued installations, as returned by
+//     InstallerService.QueuedApprovals; nil prints nothing
+func QueuedApprovals(queued *manager.QueuedApprovals) {
+	if queued == nil {
+		return
+	}
+
+	output.Printf("\n⏳ %d chatmate(s) need approval before they can be installed (team policy: %s)\n",
+		len(queued.Requests), queued.PolicyPath)
+	output.Println("   Ask your policy administrator to approve them by adding to the policy:")
+	output.Println()
+	output.Println("   approval:")
+	output.Println("     allowlist:")
+	for _, request := range queued.Requests {
+		output.Printf("       - chatmate: %s\n", manager.DisplayName(request.Filename))
+		output.Printf("         sha256: %s\n", request.Checksum)
+	}
+	output.Println()
+	if queued.Signed {
+		output.Println("   Each entry must be signed with the policy's approval key.")
+	}
+	if queued.RequestsPath != "" {
+		output.Printf("   Requests are saved in %s.\n", queued.RequestsPath)
+	}
+	output.Println("   Run the same command again once they are approved.")
+}
+
+// Explanation prints what an installation or uninstallation would do.
+//
+// Parameters:
+//   - e: The explanation, as returned by ExplainInstall or ExplainUninstall
+func Explanation(e *manager.Explanation) {
+	output.Printf("🔎 What 'chatmate %s' would do (nothing is changed)\n", e.Operation)
+
+	output.Printf("\n=== Sources ===\n")
+	for _, source := range e.Sources {
+		output.Printf("• %s\n", source)
+	}
+
+	output.Printf("\n=== Target Directory ===\n")
+	output.Printf("%s\n", e.TargetDir)
+
+	output.Printf("\n=== Matching ===\n")
+	for _, rule := range e.Matching {
+		output.Printf("• %s\n", rule)
+	}
+
+	output.Printf("\n=== Policies ===\n")
+	for _, policy := range e.Policies {
+		output.Printf("• %s\n", policy)
+	}
+
+	output.Printf("\n=== Plan ===\n")
+	if len(e.Actions) == 0 {
+		output.Println("Nothing to do")
+		return
+	}
+	rows := [][]string{{"ACTION", "CHATMATE", "WHY"}}
+	for _, a := range e.Actions {
+		rows = append(rows, []string{a.Action, a.Name, a.Reason})
+	}
+	output.PrintTable(rows)
+}
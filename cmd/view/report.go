@@ -0,0 +1,169 @@
+package view
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+)
+
+// Report is everything the HTML report shows.
+//
+// Fields:
+//   - Version: The ChatMate version that generated the report
+//   - Generated: When the report was generated
+//   - PromptsDir: The prompts directory the chatmates are installed into
+//   - Chatmates: The installed and available chatmates, sorted by name
+//   - Validation: The validation of the installation; nil if it could not run
+//   - Tutorials: The tutorials and the scenarios the chatmates feature in
+type Report struct {
+	Version    string
+	Generated  time.Time
+	PromptsDir string
+	Chatmates  []ReportChatmate
+	Validation *manager.Report
+	Tutorials  []ReportTutorial
+}
+
+// ReportChatmate is a chatmate in the HTML report.
+//
+// Fields:
+//   - ListedChatmate: Name, filename, status, and provenance
+//   - Description: The description of the frontmatter
+//   - Author: Who wrote the chatmate
+//   - Version: The version of the chatmate
+//   - Examples: Sample prompts
+type ReportChatmate struct {
+	manager.ListedChatmate
+	Description string
+	Author      string
+	Version     string
+	Examples    []string
+}
+
+// ReportTutorial is a tutorial in the HTML report.
+//
+// Fields:
+//   - Name: The name to run it with, e.g. "daily-dev"
+//   - Description: What the tutorial is about
+//   - Scenarios: The scenarios featured in the tutorial
+type ReportTutorial struct {
+	Name        string
+	Description string
+	Scenarios   []ReportScenario
+}
+
+// ReportScenario is a tutorial scenario in the HTML report.
+//
+// Fields:
+//   - Title: Name of the scenario
+//   - Description: When the scenario applies
+//   - Chatmate: The chatmate the scenario uses
+//   - Example: A prompt for the scenario
+//   - Tips: Advice for getting good results
+type ReportScenario struct {
+	Title       string
+	Description string
+	Chatmate    string
+	Example     string
+	Tips        []string
+}
+
+// Installed returns the number of installed chatmates.
+func (r *Report) Installed() int {
+	count := 0
+	for _, chatmate := range r.Chatmates {
+		if chatmate.Installed {
+			count++
+		}
+	}
+	return count
+}
+
+// reportTemplate renders a Report as a single HTML page without external
+// resources, so it can be mailed or attached to onboarding docs.
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="chatmate {{.Version}}">
+<title>ChatMate Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #1f2328; line-height: 1.5; }
+h1, h2 { border-bottom: 1px solid #d1d9e0; padding-bottom: .3rem; }
+table { border-collapse: collapse; width: 100%; margin: 1rem 0; }
+th, td { border: 1px solid #d1d9e0; padding: .4rem .6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { background: #f6f8fa; padding: .1rem .3rem; border-radius: 4px; font-size: 90%; }
+.muted { color: #59636e; }
+.status { white-space: nowrap; font-weight: 600; }
+.pass, .installed { color: #1a7f37; }
+.warn, .modified { color: #9a6700; }
+.fail { color: #d1242f; }
+ul.examples { margin: .3rem 0 0; padding-left: 1.2rem; }
+</style>
+</head>
+<body>
+<h1>ChatMate Report</h1>
+<p class="muted">Generated {{date .Generated}} by chatmate {{.Version}} for <code>{{.PromptsDir}}</code></p>
+
+<h2>Chatmates</h2>
+<p>{{.Installed}} of {{len .Chatmates}} chatmates installed.</p>
+<table>
+<tr><th>Chatmate</th><th>Status</th><th>Description</th></tr>
+{{- range .Chatmates}}
+<tr id="{{.Filename}}">
+<td><strong>{{.Name}}</strong>{{if .Version}} <span class="muted">{{.Version}}</span>{{end}}{{if .Author}}<br><span class="muted">by {{.Author}}</span>{{end}}</td>
+<td class="status">{{if .Modified}}<span class="modified">modified</span>{{else if .Installed}}<span class="installed">installed</span>{{else}}available{{end}}{{if and .Installed (not .Available)}}<br><span class="muted">user-created</span>{{end}}</td>
+<td>{{.Description}}{{if .Provenance}}<br><span class="muted">From {{.Provenance}}</span>{{end}}
+{{- if .Examples}}<ul class="examples">{{range .Examples}}<li><code>{{.}}</code></li>{{end}}</ul>{{end}}</td>
+</tr>
+{{- end}}
+</table>
+
+<h2>Validation</h2>
+{{- with .Validation}}
+<p>{{.Count "pass"}} passed, {{.Count "warn"}} warnings, {{.Count "fail"}} failed.</p>
+<table>
+<tr><th>Check</th><th>Result</th><th>Details</th></tr>
+{{- range .Checks}}
+<tr><td>{{.Name}}</td><td class="status {{.Status}}">{{.Status}}</td><td>{{.Message}}{{if .Files}}<ul class="examples">{{range .Files}}<li><code>{{.}}</code></li>{{end}}</ul>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="muted">The installation could not be validated; run <code>chatmate validate</code> for details.</p>
+{{- end}}
+
+<h2>Tutorials</h2>
+<p>Run a tutorial with <code>chatmate tutorial &lt;name&gt;</code>.</p>
+{{- range .Tutorials}}
+<h3>{{.Name}}</h3>
+<p>{{.Description}}</p>
+{{- if .Scenarios}}
+<ul>
+{{- range .Scenarios}}
+<li><strong>{{.Title}}</strong> with {{.Chatmate}}{{if .Description}}: {{.Description}}{{end}}<br><code>{{.Example}}</code>
+{{- if .Tips}}<ul class="examples">{{range .Tips}}<li>{{.}}</li>{{end}}</ul>{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// HTMLReport writes a report as a single self-contained HTML page.
+//
+// Parameters:
+//   - w: Where to write the page
+//   - report: What the page shows
+//
+// Returns:
+//   - error: Template execution or write error
+func HTMLReport(w io.Writer, report *Report) error {
+	return reportTemplate.Execute(w, report)
+}
@@ -0,0 +1,235 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// Status prints the status report with health checks, the team policy, and
+// recent activity.
+//
+// Parameters:
+//   - details: The status, as returned by StatusService.Details
+func Status(details *manager.StatusDetails) {
+	report, config := details.Report, details.Config
+
+	output.Println("=== ChatMate Status ===")
+
+	// Directory Information
+	promptsDir(config, details.PromptsDirLabel)
+	if report.Headless {
+		output.Println("Mode: headless (VS Code not detected; use 'chatmate export' to copy chatmates to a machine with VS Code)")
+	}
+	if !report.Embedded {
+		output.Printf("Mates Source Directory: %s\n", config.MatesDir)
+	} else {
+		output.Println("Using embedded chatmate resources")
+	}
+
+	// Check directory existence
+	if details.PromptsDirError != nil {
+		output.Printf("❌ Prompts directory is unusable: %v\n", details.PromptsDirError)
+	} else if !report.PromptsDirExists {
+		output.Printf("❌ Prompts directory does not exist: %s\n", report.PromptsDir)
+	} else {
+		output.Printf("✅ Prompts directory exists: %s\n", report.PromptsDir)
+	}
+
+	// Installation Statistics
+	output.Printf("\n=== Installation Statistics ===\n")
+	output.Printf("Available Chatmates: %s\n", output.FormatNumber(report.Available))
+	output.Printf("Installed Chatmates: %s\n", output.FormatNumber(report.Installed))
+	if report.Modified > 0 {
+		output.Printf("Modified Chatmates: %s (edited locally, kept by 'chatmate update')\n", output.FormatNumber(report.Modified))
+	}
+	if report.Adopted > 0 {
+		output.Printf("Adopted Chatmates: %s\n", output.FormatNumber(report.Adopted))
+	}
+	if report.Ignored > 0 {
+		output.Printf("Ignored Files: %s (%s)\n", output.FormatNumber(report.Ignored), manager.IgnoreFilename)
+	}
+	if report.Available > 0 {
+		percentage := float64(report.Installed) / float64(report.Available) * 100
+		output.Printf("Installation Coverage: %.1f%%\n", percentage)
+	}
+
+	// Health checks
+	health := details.Health
+	output.Printf("\n=== Health ===\n")
+	output.Printf("Checks: %d passed, %d warnings, %d failed\n",
+		health.Count(manager.CheckPass), health.Count(manager.CheckWarn), health.Count(manager.CheckFail))
+	for _, check := range health.Checks {
+		if check.Status != manager.CheckPass {
+			output.Warnf("%s: %s", check.Name, check.Message)
+		}
+	}
+
+	// Required chatmates from the team policy
+	teamPolicy(details)
+
+	// Configuration Information
+	output.Printf("\n=== Configuration ===\n")
+	output.Printf("Using Embedded Resources: %t\n", report.Embedded)
+
+	// Recent Activity from the operation history
+	recentActivity(details)
+}
+
+// teamPolicy prints the team policy section of the status report when the
+// policy requires chatmates or installations are awaiting approval.
+//
+// The policy is informational here; 'chatmate status --check' fails when it
+// is not met. A policy that cannot be read is reported but doesn't fail the
+// status report.
+func teamPolicy(details *manager.StatusDetails) {
+	if details.PolicyError != nil {
+		output.Printf("\n=== Team Policy ===\n")
+		output.Printf("Team policy unavailable: %v\n", details.PolicyError)
+		return
+	}
+	compliance := details.Policy
+	if len(compliance.Required) == 0 && len(details.PendingApprovals) == 0 {
+		return
+	}
+
+	output.Printf("\n=== Team Policy ===\n")
+	output.Printf("Policy: %s\n", compliance.PolicyPath)
+	complianceEntries(compliance)
+	if !compliance.Compliant() {
+		output.Warnf("%v", compliance.Error())
+	}
+	for _, request := range details.PendingApprovals {
+		output.Printf("  ⏳ %s (awaiting approval since %s)\n", manager.DisplayName(request.Filename), output.FormatDateTime(request.RequestedAt))
+	}
+}
+
+// recentActivity prints the latest operations from the operation history.
+func recentActivity(details *manager.StatusDetails) {
+	output.Printf("\n=== Recent Activity ===\n")
+
+	if details.HistoryError != nil {
+		output.Printf("Operation history unavailable: %v\n", details.HistoryError)
+		return
+	}
+
+	recent := details.Report.RecentOperations
+	if len(recent) == 0 {
+		if details.Since.IsZero() {
+			output.Println("No operations recorded yet")
+		} else {
+			output.Printf("No operations recorded since %s\n", output.FormatDateTime(details.Since))
+		}
+		return
+	}
+
+	rows := [][]string{{"WHEN", "COMMAND", "OUTCOME"}}
+	for _, entry := range recent {
+		rows = append(rows, []string{output.FormatDateTime(entry.Timestamp), entry.Command, OperationOutcome(entry)})
+	}
+	output.PrintTable(rows)
+
+	if details.EarlierOperations > 0 {
+		output.Printf("... and %s earlier operation(s)\n", output.FormatNumber(details.EarlierOperations))
+	}
+}
+
+// OperationOutcome describes the outcome of a recorded operation in a few
+// words: the duration of a successful operation, or the first line of the
+// error of a failed one.
+func OperationOutcome(entry state.Summary) string {
+	if !entry.Success {
+		message := strings.SplitN(entry.Error, "\n", 2)[0]
+		if utf8.RuneCountInString(message) > 60 {
+			message = string([]rune(message)[:57]) + "..."
+		}
+		return fmt.Sprintf("%s %s", output.SymbolFailure, message)
+	}
+
+	duration := time.Duration(entry.DurationMs) * time.Millisecond
+	return fmt.Sprintf("%s in %s", output.SymbolSuccess, duration.Round(time.Millisecond))
+}
+
+// Config prints the ChatMate configuration.
+//
+// Parameters:
+//   - config: The configuration, as returned by StatusService.Config
+//   - label: How the prompts directory is named (see PromptsDirLabel)
+func Config(config *manager.ConfigReport, label string) {
+	output.Println("=== ChatMate Configuration ===")
+	output.Printf("Script Directory: %s\n", config.ScriptDir)
+	output.Printf("Mates Directory: %s\n", config.MatesDir)
+	promptsDir(config, label)
+	output.Printf("Using Embedded Resources: %t\n", config.Embedded)
+	output.Printf("Headless Mode: %t\n", config.Headless)
+	output.Printf("Shared Prompts Directory: %t\n", config.Shared)
+	if config.StateDir != "" {
+		output.Printf("State Directory (this machine only): %s\n", config.StateDir)
+	}
+	if config.ConfigDir != "" {
+		output.Printf("Config Directory (safe to sync): %s\n", config.ConfigDir)
+	}
+	if config.SummaryPath != "" {
+		output.Printf("Last Operation Summary: %s\n", config.SummaryPath)
+	}
+	if config.InventoryCache != "" {
+		output.Printf("Inventory Cache: %s\n", config.InventoryCache)
+	}
+	output.Printf("Ignore List: %s\n", config.IgnoreList)
+	if config.ConfigFile != "" {
+		output.Printf("Config File: %s\n", config.ConfigFile)
+	}
+	if config.TeamPolicy != "" {
+		output.Printf("Team Policy: %s\n", config.TeamPolicy)
+	}
+	if config.Registry != "" {
+		output.Printf("Chatmate Registry: %s\n", config.Registry)
+	}
+	for _, source := range config.Sources {
+		output.Printf("Chatmate Source: %s\n", source)
+	}
+}
+
+// promptsDir prints the prompts directory, and the symlink it was resolved
+// from when it was configured as one.
+func promptsDir(config *manager.ConfigReport, label string) {
+	if config.ConfiguredPromptsDir == "" {
+		output.Printf("%s: %s\n", label, config.PromptsDir)
+		return
+	}
+	output.Printf("%s: %s\n", label, config.ConfiguredPromptsDir)
+	output.Printf("  → symlink resolved to: %s\n", config.PromptsDir)
+}
+
+// Compliance prints whether each chatmate the team policy requires is
+// installed.
+//
+// Parameters:
+//   - compliance: The policy state, as returned by PolicyCompliance
+func Compliance(compliance *manager.Compliance) {
+	if len(compliance.Required) == 0 {
+		output.Printf("No required chatmates (team policy: %s)\n", compliance.PolicyPath)
+		return
+	}
+
+	output.Printf("Team policy: %s\n", compliance.PolicyPath)
+	complianceEntries(compliance)
+}
+
+// complianceEntries lists the required chatmates with their state.
+func complianceEntries(compliance *manager.Compliance) {
+	for _, filename := range compliance.Installed {
+		output.Printf("  %s %s\n", output.SymbolSuccess, manager.DisplayName(filename))
+	}
+	for _, filename := range compliance.Missing {
+		output.Printf("  %s %s (not installed)\n", output.SymbolFailure, manager.DisplayName(filename))
+	}
+	for _, name := range compliance.Unknown {
+		output.Printf("  %s %s (not available)\n", output.SymbolFailure, name)
+	}
+}
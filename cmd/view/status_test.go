@@ -0,0 +1,20 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestOperationOutcome tests the outcome column of the Recent Activity section
+func TestOperationOutcome(t *testing.T) {
+	outcome := OperationOutcome(state.Summary{Success: true, DurationMs: 1234})
+	if !strings.Contains(outcome, "in 1.234s") {
+		t.Errorf("Successful outcome should include the duration, got %q", outcome)
+	}
+	outcome = OperationOutcome(state.Summary{Error: strings.Repeat("x", 100) + "\nsecond line"})
+	if strings.Contains(outcome, "second line") || !strings.HasSuffix(outcome, "...") {
+		t.Errorf("Failed outcome should show the truncated first line of the error, got %q", outcome)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/jonassiebler/chatmate/cmd/view"
+	"github.com/spf13/cobra"
+)
+
+// whichOptions holds the flags of the which command.
+type whichOptions struct {
+	long bool
+}
+
+// NewWhichCmd creates the which command.
+func NewWhichCmd(deps *Deps) *cobra.Command {
+	opts := &whichOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "which <chatmate name>",
+		Short: "Print the path of an installed chatmate",
+		Long: `Print the absolute path of an installed chatmate file, and nothing else,
+so it can be used in shell pipelines. The name is resolved like in
+'chatmate show': display name, filename, or filename without .chatmode.md.
+
+📍 More Details:
+• --long also prints the chatmate it was installed from and its provenance
+• --output json prints the path, source path, and provenance as a document
+• The path is printed even with --quiet; a chatmate that is not installed
+  is an error (exit code 3)`,
+		Example: `  # Open an installed chatmate in VS Code
+  code "$(chatmate which 'Solve Issue')"
+
+  # Show where it came from, too
+  chatmate which "Solve Issue" --long
+
+  # Compare the installed file with the one it was installed from
+  chatmate which "Solve Issue" --output json | jq -r '.sourcePath'`,
+		Args: cobra.ExactArgs(1),
+		RunE: deps.Run(func(cmd *cobra.Command, args []string, app *App) error {
+			location, err := app.Manager.Lister().Which(args[0])
+			if err != nil {
+				return err
+			}
+			if app.Structured() {
+				return app.Write(location, "chatmate location")
+			}
+			return view.Location(location, opts.long)
+		}),
+	}
+
+	cmd.Flags().BoolVarP(&opts.long, "long", "l", false,
+		"Also print the source path and provenance of the chatmate")
+
+	return cmd
+}
@@ -3,19 +3,25 @@ package assets
 import (
 	"embed"
 	"io/fs"
+	"sync"
 )
 
 //go:embed mates/*.chatmode.md
 var embeddedMates embed.FS
 
-// GetEmbeddedMates returns the embedded mates filesystem
-func GetEmbeddedMates() fs.FS {
-	matesFS, err := fs.Sub(embeddedMates, "mates")
+// matesFS is the mates directory of embeddedMates, created on first use.
+var matesFS = sync.OnceValue(func() fs.FS {
+	sub, err := fs.Sub(embeddedMates, "mates")
 	if err != nil {
 		// This should never happen with valid embed
 		panic("failed to access embedded mates: " + err.Error())
 	}
-	return matesFS
+	return sub
+})
+
+// GetEmbeddedMates returns the embedded mates filesystem
+func GetEmbeddedMates() fs.FS {
+	return matesFS()
 }
 
 // GetEmbeddedMatesList returns a list of all embedded chatmate filenames
@@ -37,8 +43,15 @@ func GetEmbeddedMatesList() ([]string, error) {
 	return files, nil
 }
 
-// GetEmbeddedMateContent returns the content of a specific embedded chatmate file
+// GetEmbeddedMateContent returns the content of a specific embedded chatmate
+// file. Every call returns a new copy; use OpenEmbeddedMate to read it as a
+// stream instead.
 func GetEmbeddedMateContent(filename string) ([]byte, error) {
-	matesFS := GetEmbeddedMates()
-	return fs.ReadFile(matesFS, filename)
+	return fs.ReadFile(GetEmbeddedMates(), filename)
+}
+
+// OpenEmbeddedMate opens a specific embedded chatmate file for reading,
+// without copying its content into memory first.
+func OpenEmbeddedMate(filename string) (fs.File, error) {
+	return GetEmbeddedMates().Open(filename)
 }
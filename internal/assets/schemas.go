@@ -0,0 +1,51 @@
+package assets
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+//go:embed schemas/*.schema.json
+var embeddedSchemas embed.FS
+
+// schemaSuffix is the filename suffix of embedded JSON Schemas.
+const schemaSuffix = ".schema.json"
+
+// GetSchemaKinds returns the kinds of files an embedded JSON Schema exists for,
+// sorted by name (e.g., "chatmode").
+func GetSchemaKinds() []string {
+	entries, err := fs.ReadDir(embeddedSchemas, "schemas")
+	if err != nil {
+		// This should never happen with valid embed
+		panic("failed to access embedded schemas: " + err.Error())
+	}
+
+	var kinds []string
+	for _, entry := range entries {
+		if kind, ok := strings.CutSuffix(entry.Name(), schemaSuffix); ok && !entry.IsDir() {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// GetSchema returns the embedded JSON Schema for a kind of file.
+//
+// Parameters:
+//   - kind: The kind of file, as returned by GetSchemaKinds
+//
+// Returns:
+//   - []byte: The JSON Schema document
+//   - error: Error if there is no schema for kind
+func GetSchema(kind string) ([]byte, error) {
+	for _, known := range GetSchemaKinds() {
+		if known == kind {
+			return embeddedSchemas.ReadFile("schemas/" + kind + schemaSuffix)
+		}
+	}
+	return nil, fmt.Errorf("unknown schema %q (available: %s)", kind, strings.Join(GetSchemaKinds(), ", "))
+}
@@ -0,0 +1,290 @@
+package assets
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// supportedKeywords are the JSON Schema keywords ValidateSchema enforces.
+// Annotations such as "description" are accepted and ignored; the embedded
+// schemas use no other keyword, which the tests check.
+var supportedKeywords = []string{
+	"$ref", "additionalProperties", "enum", "items", "minLength", "minimum",
+	"oneOf", "pattern", "properties", "required", "type", "uniqueItems",
+}
+
+// annotationKeywords are the JSON Schema keywords that describe a value
+// without constraining it.
+var annotationKeywords = []string{"$schema", "definitions", "description", "examples", "title"}
+
+// ValidateSchema checks a document against the embedded JSON Schema for a
+// kind of file.
+//
+// Only the keywords the embedded schemas use are enforced (a subset of
+// draft-07), so the check does not need a JSON Schema library. The document
+// is the generic value a YAML or JSON decoder produces: maps with string
+// keys, slices, strings, booleans, and numbers.
+//
+// Parameters:
+//   - kind: The kind of file, as returned by GetSchemaKinds
+//   - document: The decoded file
+//
+// Returns:
+//   - []string: The violations, each prefixed with the path of the value,
+//     such as "retention.maxAge: ..."; empty when the document is valid
+//   - error: Error if there is no schema for kind
+//
+// Example:
+//
+// violations, err := assets.ValidateSchema("config", document)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to check config file: %w", err)
+//	}
+func ValidateSchema(kind string, document any) ([]string, error) {
+	data, err := GetSchema(kind)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", kind, err)
+	}
+
+	v := &schemaValidator{root: root}
+	v.validate(root, document, "")
+	return v.violations, nil
+}
+
+// schemaValidator collects the violations of a document.
+type schemaValidator struct {
+	root       map[string]any
+	violations []string
+}
+
+// fail records a violation at path.
+func (v *schemaValidator) fail(path, format string, args ...any) {
+	if path == "" {
+		path = "(root)"
+	}
+	v.violations = append(v.violations, path+": "+fmt.Sprintf(format, args...))
+}
+
+// validate checks value against schema, recording violations at path.
+func (v *schemaValidator) validate(schema map[string]any, value any, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		if resolved := v.resolve(ref); resolved != nil {
+			v.validate(resolved, value, path)
+		} else {
+			v.fail(path, "unresolvable $ref %q", ref)
+		}
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return hasType(value, t) }) {
+		v.fail(path, "must be %s, got %s", strings.Join(types, " or "), typeName(value))
+		return
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equalValues(e, value) }) {
+		allowed := make([]string, 0, len(enum))
+		for _, e := range enum {
+			allowed = append(allowed, fmt.Sprint(e))
+		}
+		v.fail(path, "must be one of %s, got %v", strings.Join(allowed, ", "), value)
+	}
+
+	if variants, ok := schema["oneOf"].([]any); ok {
+		matches := 0
+		for _, variant := range variants {
+			if sub, ok := variant.(map[string]any); ok && v.matches(sub, value) {
+				matches++
+			}
+		}
+		if matches != 1 {
+			v.fail(path, "must match exactly one of %d alternatives, matches %d", len(variants), matches)
+		}
+	}
+
+	switch value := value.(type) {
+	case string:
+		if minLength, ok := schema["minLength"].(float64); ok && float64(utf8.RuneCountInString(value)) < minLength {
+			v.fail(path, "must be at least %d character(s) long", int(minLength))
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				v.fail(path, "invalid pattern %q in schema: %v", pattern, err)
+			} else if !re.MatchString(value) {
+				v.fail(path, "%q does not match %s", value, pattern)
+			}
+		}
+	case map[string]any:
+		v.validateObject(schema, value, path)
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			for i := range value {
+				if slices.ContainsFunc(value[:i], func(e any) bool { return equalValues(e, value[i]) }) {
+					v.fail(fmt.Sprintf("%s[%d]", path, i), "duplicates an earlier item")
+				}
+			}
+		}
+	default:
+		if number, ok := toNumber(value); ok {
+			if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+				v.fail(path, "must be at least %v, got %v", minimum, value)
+			}
+		}
+	}
+}
+
+// validateObject checks the required, properties, and additionalProperties
+// keywords of schema against an object.
+func (v *schemaValidator) validateObject(schema map[string]any, object map[string]any, path string) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, present := object[key]; !present {
+					v.fail(path, "missing required property %q", key)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := key
+		if path != "" {
+			child = path + "." + key
+		}
+		if property, ok := properties[key].(map[string]any); ok {
+			v.validate(property, object[key], child)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(child, "unknown property")
+			}
+		case map[string]any:
+			v.validate(additional, object[key], child)
+		}
+	}
+}
+
+// matches reports whether value is valid against schema, without recording
+// violations.
+func (v *schemaValidator) matches(schema map[string]any, value any) bool {
+	probe := &schemaValidator{root: v.root}
+	probe.validate(schema, value, "")
+	return len(probe.violations) == 0
+}
+
+// resolve returns the schema a "#/definitions/name" reference points to, or
+// nil if there is none.
+func (v *schemaValidator) resolve(ref string) map[string]any {
+	name, ok := strings.CutPrefix(ref, "#/definitions/")
+	if !ok {
+		return nil
+	}
+	definitions, _ := v.root["definitions"].(map[string]any)
+	resolved, _ := definitions[name].(map[string]any)
+	return resolved
+}
+
+// schemaTypes returns the types the "type" keyword allows: a single name or
+// a list of names.
+func schemaTypes(keyword any) []string {
+	switch keyword := keyword.(type) {
+	case string:
+		return []string{keyword}
+	case []any:
+		var types []string
+		for _, t := range keyword {
+			if name, ok := t.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+// hasType reports whether value is of the JSON Schema type name.
+func hasType(value any, name string) bool {
+	switch name {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := toNumber(value)
+		return ok
+	case "integer":
+		number, ok := toNumber(value)
+		return ok && number == math.Trunc(number)
+	}
+	return false
+}
+
+// typeName returns the JSON Schema type of value, for messages.
+func typeName(value any) string {
+	for _, name := range []string{"object", "array", "string", "boolean", "null", "integer", "number"} {
+		if hasType(value, name) {
+			return name
+		}
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// toNumber returns value as a float64 if it is a number of any Go type a
+// decoder produces.
+func toNumber(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// equalValues compares two decoded values, treating numbers of different Go
+// types as equal when their values are.
+func equalValues(a, b any) bool {
+	if x, ok := toNumber(a); ok {
+		y, ok := toNumber(b)
+		return ok && x == y
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,149 @@
+package assets
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSchemaKeywords tests that the embedded schemas only use keywords
+// ValidateSchema enforces, so no constraint is silently skipped
+func TestSchemaKeywords(t *testing.T) {
+	var walk func(kind, path string, schema map[string]any)
+	walk = func(kind, path string, schema map[string]any) {
+		for keyword, value := range schema {
+			if !slices.Contains(supportedKeywords, keyword) && !slices.Contains(annotationKeywords, keyword) {
+				t.Errorf("%s schema uses unsupported keyword %q at %s", kind, keyword, path)
+			}
+			switch keyword {
+			case "properties", "definitions":
+				for name, sub := range value.(map[string]any) {
+					walk(kind, path+"/"+keyword+"/"+name, sub.(map[string]any))
+				}
+			case "items", "additionalProperties":
+				if sub, ok := value.(map[string]any); ok {
+					walk(kind, path+"/"+keyword, sub)
+				}
+			case "oneOf":
+				for _, sub := range value.([]any) {
+					walk(kind, path+"/oneOf", sub.(map[string]any))
+				}
+			}
+		}
+	}
+
+	for _, kind := range GetSchemaKinds() {
+		data, err := GetSchema(kind)
+		if err != nil {
+			t.Fatalf("GetSchema(%q) failed: %v", kind, err)
+		}
+		var schema map[string]any
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatalf("%s schema is not valid JSON: %v", kind, err)
+		}
+		walk(kind, "#", schema)
+	}
+}
+
+// TestValidateSchema tests checking YAML documents against the embedded schemas
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		document string
+		want     []string
+	}{
+		{
+			name:     "config example",
+			kind:     "config",
+			document: "promptsDir: ~/prompts\neditor: insiders\nascii: true\nbackupKeep: 10\nfileMode: \"0600\"\nhookTimeout: 1m30s\naliases:\n  fix: hire \"Solve Issue\"\nsources:\n  - https://github.com/acme/chatmates.git\n",
+		},
+		{
+			name:     "config unknown key",
+			kind:     "config",
+			document: "edtor: insiders\n",
+			want:     []string{"edtor: unknown property"},
+		},
+		{
+			name:     "config wrong values",
+			kind:     "config",
+			document: "editor: emacs\nascii: yes please\nbackupKeep: -1\nconcurrency: 1.5\nfileMode: \"0800\"\n",
+			want: []string{
+				"ascii: must be boolean, got string",
+				"backupKeep: must be at least 0, got -1",
+				"concurrency: must be integer, got number",
+				"editor: must be one of",
+				`fileMode: "0800" does not match`,
+			},
+		},
+		{
+			name:     "config duplicate source",
+			kind:     "config",
+			document: "sources: [a, b, a]\naliases:\n  fix: \"\"\n",
+			want:     []string{"aliases.fix: must be at least 1 character(s) long", "sources[2]: duplicates an earlier item"},
+		},
+		{
+			name:     "policy example",
+			kind:     "policy",
+			document: "required: [Review PR, Testing]\npins:\n  Review PR: ^1.2\nwebhook: https://hooks.example.com/x\nwebhookFormat: slack\napproval:\n  required: true\n  allowlist:\n    - chatmate: Rust Reviewer\nretention:\n  maxSize: 20MB\n  maxAge: 30d\n  maxVersions: 2\n",
+		},
+		{
+			name:     "policy retention numbers",
+			kind:     "policy",
+			document: "retention:\n  maxSize: 0\n  maxAge: 0\n",
+		},
+		{
+			name:     "policy wrong values",
+			kind:     "policy",
+			document: "webhook: ftp://example.com\nwebhookFormat: discord\napproval:\n  allowlist:\n    - sha256: abc\nretention:\n  maxAge: a month\n",
+			want: []string{
+				"approval.allowlist[0]: missing required property \"chatmate\"",
+				"approval.allowlist[0].sha256: \"abc\" does not match",
+				"retention.maxAge: \"a month\" does not match",
+				"webhook: \"ftp://example.com\" does not match",
+				"webhookFormat: must be one of json, slack, teams, got discord",
+			},
+		},
+		{
+			name:     "chatmode author",
+			kind:     "chatmode",
+			document: "description: Reviews code\nauthor:\n  email: someone@example.com\nexamples:\n  - \"@Review\"\n  - prompt: \"@Review this\"\n",
+			want:     []string{"author: must match exactly one of 2 alternatives, matches 0"},
+		},
+		{
+			name:     "not an object",
+			kind:     "config",
+			document: "- editor\n",
+			want:     []string{"(root): must be object, got array"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document any
+			if err := yaml.Unmarshal([]byte(tt.document), &document); err != nil {
+				t.Fatalf("invalid test document: %v", err)
+			}
+
+			violations, err := ValidateSchema(tt.kind, document)
+			if err != nil {
+				t.Fatalf("ValidateSchema failed: %v", err)
+			}
+			if len(violations) != len(tt.want) {
+				t.Fatalf("ValidateSchema() = %q, want %d violation(s) starting with %q", violations, len(tt.want), tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(violations[i], want) {
+					t.Errorf("violation %d = %q, want prefix %q", i, violations[i], want)
+				}
+			}
+		})
+	}
+
+	if _, err := ValidateSchema("unknown", nil); err == nil {
+		t.Error("Expected error for an unknown schema")
+	}
+}
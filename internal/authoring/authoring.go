@@ -0,0 +1,186 @@
+// Package authoring sets up VS Code for writing chatmates by hand.
+//
+// Setup installs a snippets file with templates for new chatmates and their
+// examples, stores the chatmode frontmatter JSON Schema (see
+// 'chatmate schema chatmode') next to the user's VS Code settings, and
+// associates the schema with .chatmode.md files, so the frontmatter is
+// completed and validated while it is typed.
+package authoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// SnippetsFilename is the name of the snippets file in the VS Code user
+// snippets directory.
+const SnippetsFilename = "chatmate.code-snippets"
+
+// SchemaFilename is the name of the chatmode frontmatter schema written next
+// to the VS Code settings.
+const SchemaFilename = "chatmode.schema.json"
+
+// ChatmodePattern is the file pattern the schema is associated with.
+const ChatmodePattern = "*.chatmode.md"
+
+// snippet is a VS Code snippet definition.
+type snippet struct {
+	Scope       string   `json:"scope"`
+	Prefix      []string `json:"prefix"`
+	Description string   `json:"description"`
+	Body        []string `json:"body"`
+}
+
+// chatmateName is a snippet variable transform that turns the filename
+// "Chatmate - Solve Issue.chatmode.md" into the display name "Solve Issue".
+const chatmateName = `${TM_FILENAME_BASE/^(?:Chatmate - )?(.*?)(?:\.chatmode)?$/$1/}`
+
+// snippets are the templates installed by Setup, keyed by name.
+var snippets = map[string]snippet{
+	"New chatmate": {
+		Scope:       "chatmode,markdown",
+		Prefix:      []string{"chatmate", "---"},
+		Description: "Frontmatter and outline of a new chatmate",
+		Body: []string{
+			"---",
+			"description: '${1:What this chatmate does}'",
+			"author: '${2:Your Name}'",
+			"license: '${3:MIT}'",
+			"model: '${4:Claude Sonnet 4}'",
+			"tools: [${5:'codebase', 'editFiles', 'search'}]",
+			"examples:",
+			"  - '@" + chatmateName + " ${6:A typical request}'",
+			"---",
+			"",
+			"You are ${7:an expert in ...}. Your goal is to ${8:...}.",
+			"",
+			"## Workflow",
+			"",
+			"1. ${9:Understand the request}",
+			"2. ${10:Do the work}",
+			"3. ${11:Verify the result}",
+			"$0",
+		},
+	},
+	"Chatmate example": {
+		Scope:       "chatmode,markdown",
+		Prefix:      []string{"example"},
+		Description: "Example invocation with a tutorial scenario, for the examples: list",
+		Body: []string{
+			"  - prompt: '@" + chatmateName + " ${1:A typical request}'",
+			"    title: '${2:Scenario}'",
+			"    description: '${3:When the scenario applies}'",
+			"    tips:",
+			"      - '${4:Advice for good results}'",
+			"$0",
+		},
+	},
+}
+
+// Snippets returns the content of the snippets file.
+func Snippets() ([]byte, error) {
+	data, err := json.MarshalIndent(snippets, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snippets: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Result describes what Setup did.
+//
+// Fields:
+//   - SnippetsPath: The snippets file
+//   - SchemaPath: The chatmode frontmatter schema file
+//   - SettingsPath: The VS Code settings file
+//   - Changed: Paths of the files that were created or updated
+//   - SettingsErr: Why the schema could not be associated in the settings;
+//     the snippets and schema are still installed
+type Result struct {
+	SnippetsPath string
+	SchemaPath   string
+	SettingsPath string
+	Changed      []string
+	SettingsErr  error
+}
+
+// Setup installs the authoring support for the VS Code user directory that
+// contains settingsPath.
+//
+// The snippets and schema files belong to ChatMate and are overwritten with
+// the current version. The settings are only extended; an existing schema
+// association for other files is reported in Result.SettingsErr instead of
+// being merged (see platform.AssociateYAMLSchema).
+//
+// Parameters:
+//   - settingsPath: The VS Code user settings.json
+//
+// Returns:
+//   - *Result: The installed files and what changed
+//   - error: Failure to write the snippets or schema file
+//
+// Example:
+//
+// result, err := authoring.Setup(manager.SettingsPath())
+//
+//	if err != nil {
+//	   return fmt.Errorf("authoring setup failed: %w", err)
+//	}
+func Setup(settingsPath string) (*Result, error) {
+	userDir := filepath.Dir(settingsPath)
+	result := &Result{
+		SnippetsPath: filepath.Join(userDir, "snippets", SnippetsFilename),
+		SchemaPath:   filepath.Join(userDir, "chatmate", SchemaFilename),
+		SettingsPath: settingsPath,
+	}
+
+	content, err := Snippets()
+	if err != nil {
+		return nil, err
+	}
+	if err := result.write(result.SnippetsPath, content); err != nil {
+		return nil, err
+	}
+
+	schema, err := assets.GetSchema("chatmode")
+	if err != nil {
+		return nil, err
+	}
+	if err := result.write(result.SchemaPath, schema); err != nil {
+		return nil, err
+	}
+
+	changed, err := platform.AssociateYAMLSchema(settingsPath, result.SchemaPath, ChatmodePattern)
+	if err != nil {
+		result.SettingsErr = err
+	} else if changed {
+		result.Changed = append(result.Changed, settingsPath)
+	}
+
+	return result, nil
+}
+
+// write stores content at path unless it already has that content.
+func (r *Result) write(path string, content []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return nil
+	}
+
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := files.WriteFileAtomic(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	r.Changed = append(r.Changed, path)
+	return nil
+}
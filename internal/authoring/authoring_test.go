@@ -0,0 +1,57 @@
+package authoring
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSetup tests installing snippets, the schema, and the schema association
+func TestSetup(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "Code", "User", "settings.json")
+
+	result, err := Setup(settingsPath)
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	if result.SettingsErr != nil {
+		t.Errorf("Unexpected settings error: %v", result.SettingsErr)
+	}
+	if len(result.Changed) != 3 {
+		t.Errorf("Expected snippets, schema, and settings to change, got %v", result.Changed)
+	}
+
+	var installed map[string]snippet
+	content, err := os.ReadFile(result.SnippetsPath)
+	if err != nil {
+		t.Fatalf("Snippets not written: %v", err)
+	}
+	if err := json.Unmarshal(content, &installed); err != nil {
+		t.Fatalf("Snippets are not valid JSON: %v", err)
+	}
+	if len(installed["New chatmate"].Body) == 0 {
+		t.Errorf("Expected the new chatmate snippet, got %v", installed)
+	}
+
+	if schema, err := os.ReadFile(result.SchemaPath); err != nil || !json.Valid(schema) {
+		t.Errorf("Schema not written as valid JSON: %v", err)
+	}
+
+	settings, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("Settings not written: %v", err)
+	}
+	var parsed struct {
+		Schemas map[string]string `json:"yaml.schemas"`
+	}
+	if err := json.Unmarshal(settings, &parsed); err != nil || parsed.Schemas[result.SchemaPath] != ChatmodePattern {
+		t.Errorf("Expected the schema to be associated with %s, got %s (%v)", ChatmodePattern, settings, err)
+	}
+
+	// Running setup again changes nothing
+	result, err = Setup(settingsPath)
+	if err != nil || len(result.Changed) != 0 {
+		t.Errorf("Expected no changes on repeated setup, got %v, %v", result.Changed, err)
+	}
+}
@@ -0,0 +1,152 @@
+// Package cache stores disposable results that are expensive to recompute.
+//
+// Cache files are stored in the platform-specific cache directory (see
+// platform.GetChatMateCacheDir). Every entry carries a key describing the
+// inputs it was computed from; entries whose key no longer matches are
+// ignored, and deleting the cache directory is always safe.
+//
+// Files:
+//   - inventory.json: Last computed chatmate inventory
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// InventoryFilename is the name of the cached inventory file.
+const InventoryFilename = "inventory.json"
+
+// Inventory is a cached snapshot of available and installed chatmates.
+//
+// Fields:
+//   - Key: Invalidation key describing the directories the inventory was computed from
+//   - Available: Filenames of chatmates available for installation
+//   - Installed: Filenames of chatmates installed in the prompts directory
+//   - Outdated: Number of installed chatmates whose content differs from the shipped version
+//   - Ignored: Filenames in the prompts directory excluded by the ignore list
+//   - ComputedAt: When the inventory was computed
+type Inventory struct {
+	Key        string    `json:"key"`
+	Available  []string  `json:"available"`
+	Installed  []string  `json:"installed"`
+	Outdated   int       `json:"outdated"`
+	Ignored    []string  `json:"ignored,omitempty"`
+	ComputedAt time.Time `json:"computedAt"`
+
+	// Lookup sets, built on first use
+	availableSet map[string]bool
+	installedSet map[string]bool
+}
+
+// IsAvailable reports whether filename is available for installation.
+func (inv *Inventory) IsAvailable(filename string) bool {
+	if inv.availableSet == nil {
+		inv.availableSet = newSet(inv.Available)
+	}
+	return inv.availableSet[filename]
+}
+
+// IsInstalled reports whether filename is installed in the prompts directory.
+func (inv *Inventory) IsInstalled(filename string) bool {
+	if inv.installedSet == nil {
+		inv.installedSet = newSet(inv.Installed)
+	}
+	return inv.installedSet[filename]
+}
+
+// newSet builds a lookup set from a list of filenames.
+func newSet(filenames []string) map[string]bool {
+	set := make(map[string]bool, len(filenames))
+	for _, filename := range filenames {
+		set[filename] = true
+	}
+	return set
+}
+
+// InventoryPath returns the full path of the cached inventory file.
+func InventoryPath() (string, error) {
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, InventoryFilename), nil
+}
+
+// ReadInventory loads a cached inventory if it matches the given key.
+//
+// Parameters:
+//   - path: Location of the cached inventory file
+//   - key: Invalidation key the inventory must have been computed with
+//
+// Returns:
+//   - *Inventory: The cached inventory
+//   - bool: False if no usable inventory exists (missing, unreadable, or stale)
+func ReadInventory(path, key string) (*Inventory, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var inventory Inventory
+	if err := json.Unmarshal(data, &inventory); err != nil {
+		return nil, false
+	}
+
+	if inventory.Key != key {
+		return nil, false
+	}
+
+	return &inventory, true
+}
+
+// WriteInventory stores an inventory in the cache.
+//
+// Parameters:
+//   - path: Location of the cached inventory file
+//   - inventory: The inventory to cache
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteInventory(path string, inventory *Inventory) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(inventory)
+	if err != nil {
+		return fmt.Errorf("failed to encode inventory: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write inventory %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// RemoveInventory deletes the cached inventory, if any.
+//
+// Parameters:
+//   - path: Location of the cached inventory file
+//
+// Returns:
+//   - error: File removal error other than the file not existing
+func RemoveInventory(path string) error {
+	if err := files.CheckWrite("remove", path); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove inventory %s: %w", path, err)
+	}
+	return nil
+}
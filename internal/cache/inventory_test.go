@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInventoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", InventoryFilename)
+
+	if _, ok := ReadInventory(path, "key"); ok {
+		t.Error("ReadInventory should miss when no cache exists")
+	}
+
+	inventory := &Inventory{
+		Key:        "key",
+		Available:  []string{"A.chatmode.md", "B.chatmode.md"},
+		Installed:  []string{"A.chatmode.md"},
+		Outdated:   1,
+		ComputedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if err := WriteInventory(path, inventory); err != nil {
+		t.Fatalf("WriteInventory failed: %v", err)
+	}
+
+	cached, ok := ReadInventory(path, "key")
+	if !ok {
+		t.Fatal("ReadInventory should hit after WriteInventory")
+	}
+	if len(cached.Available) != 2 || len(cached.Installed) != 1 || cached.Outdated != 1 {
+		t.Errorf("Unexpected cached inventory: %+v", cached)
+	}
+	if !cached.ComputedAt.Equal(inventory.ComputedAt) {
+		t.Errorf("Expected ComputedAt %v, got %v", inventory.ComputedAt, cached.ComputedAt)
+	}
+
+	if !cached.IsAvailable("B.chatmode.md") || cached.IsInstalled("B.chatmode.md") {
+		t.Error("Lookup sets do not match the cached lists")
+	}
+
+	if _, ok := ReadInventory(path, "other"); ok {
+		t.Error("ReadInventory should miss when the key changed")
+	}
+
+	if err := RemoveInventory(path); err != nil {
+		t.Fatalf("RemoveInventory failed: %v", err)
+	}
+	if _, ok := ReadInventory(path, "key"); ok {
+		t.Error("ReadInventory should miss after RemoveInventory")
+	}
+	if err := RemoveInventory(path); err != nil {
+		t.Errorf("RemoveInventory should ignore missing files: %v", err)
+	}
+}
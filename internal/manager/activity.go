@@ -0,0 +1,74 @@
+// Package manager provides the activity log of ChatMate agents.
+package manager
+
+import (
+	"slices"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// recordActivity appends a change to the activity log.
+//
+// The log is a record for the user, like the operation history; failing to
+// write it does not undo the change, so errors are only reported in debug
+// output.
+func (cm *ChatMateManager) recordActivity(event state.ActivityEvent) {
+	if cm.activityPath == "" {
+		return
+	}
+
+	event.PromptsDir = cm.PromptsDir
+	event.Timestamp = time.Now().UTC()
+	if err := state.AppendActivity(cm.activityPath, event); err != nil {
+		cm.out().Debugf("Could not log the %s of %s: %v\n", event.Action, event.Filename, err)
+	}
+}
+
+// Activity returns the logged changes to chatmates in the prompts
+// directory, newest first.
+//
+// Changes are logged when chatmates are installed, updated, or uninstalled
+// by ChatMate; files edited or removed by hand are not in the log.
+//
+// Parameters:
+//   - name: Only return changes to this chatmate, given as display name or
+//     filename; empty for all chatmates
+//   - since: Only return changes made at or after this time; zero for all
+//
+// Returns:
+//   - []state.ActivityEvent: The logged changes
+//   - error: Activity log read error
+//
+// Example:
+//
+// events, err := manager.Activity("Solve Issue", time.Time{})
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to read the activity log: %w", err)
+//	}
+//
+//	for _, event := range events {
+//	   fmt.Printf("%s %s\n", event.Action, event.Filename)
+//	}
+func (cm *ChatMateManager) Activity(name string, since time.Time) ([]state.ActivityEvent, error) {
+	if cm.activityPath == "" {
+		return []state.ActivityEvent{}, nil
+	}
+
+	events, err := state.ReadActivity(cm.activityPath)
+	if err != nil {
+		return nil, err
+	}
+	recent := []state.ActivityEvent{}
+	for _, event := range slices.Backward(events) {
+		if event.PromptsDir != cm.PromptsDir || event.Timestamp.Before(since) {
+			continue
+		}
+		if name == "" || files.SameFilename(event.Filename, name) || files.SameFilename(DisplayName(event.Filename), name) {
+			recent = append(recent, event)
+		}
+	}
+	return recent, nil
+}
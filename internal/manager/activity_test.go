@@ -0,0 +1,72 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestActivity tests logging installs, updates, and uninstalls of chatmates
+func TestActivity(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	stateDir := t.TempDir()
+	write := func(name, version string) {
+		content := "---\ndescription: test\nversion: " + version + "\n---\n"
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	write("Solve Issue.chatmode.md", "1.0.0")
+	write("Testing.chatmode.md", "1.0.0")
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(stateDir, state.ProvenanceFilename),
+		activityPath:   filepath.Join(stateDir, state.ActivityFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	for _, name := range []string{"Solve Issue.chatmode.md", "Testing.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	write("Solve Issue.chatmode.md", "1.1.0")
+	if err := cm.Installer().InstallChatmate("Solve Issue.chatmode.md", true); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if err := cm.Uninstaller().UninstallChatmate("Solve Issue.chatmode.md"); err != nil {
+		t.Fatalf("UninstallChatmate failed: %v", err)
+	}
+
+	events, err := cm.Activity("Solve Issue", time.Time{})
+	if err != nil {
+		t.Fatalf("Activity failed: %v", err)
+	}
+	expected := []state.ActivityEvent{
+		{Action: state.ActivityUninstall, Version: "1.1.0"},
+		{Action: state.ActivityUpdate, Source: state.SourceDirectory, Version: "1.1.0", PreviousVersion: "1.0.0"},
+		{Action: state.ActivityInstall, Source: state.SourceDirectory, Version: "1.0.0"},
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), events)
+	}
+	for i, want := range expected {
+		got := events[i]
+		if got.Action != want.Action || got.Source != want.Source || got.Version != want.Version ||
+			got.PreviousVersion != want.PreviousVersion || got.Filename != "Solve Issue.chatmode.md" ||
+			got.PromptsDir != promptsDir || got.Timestamp.IsZero() {
+			t.Errorf("Event %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if all, err := cm.Activity("", time.Time{}); err != nil || len(all) != 4 {
+		t.Errorf("Expected 4 events for all chatmates, got %d, %v", len(all), err)
+	}
+	if recent, err := cm.Activity("", time.Now().Add(time.Hour)); err != nil || len(recent) != 0 {
+		t.Errorf("Expected no events in the future, got %d, %v", len(recent), err)
+	}
+}
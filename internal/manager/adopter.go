@@ -0,0 +1,271 @@
+// Package manager provides adoption of foreign prompt files for ChatMate agents.
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/security"
+)
+
+// AdopterService handles bringing existing prompt files under management.
+type AdopterService struct {
+	manager *ChatMateManager
+}
+
+// NewAdopterService creates a new adopter service.
+func NewAdopterService(manager *ChatMateManager) *AdopterService {
+	return &AdopterService{manager: manager}
+}
+
+// AdoptionStatus describes an adopted file compared to when it was adopted.
+type AdoptionStatus string
+
+const (
+	// AdoptionUnchanged means the content still matches the recorded checksum
+	AdoptionUnchanged AdoptionStatus = "unchanged"
+	// AdoptionModified means the content changed since it was adopted
+	AdoptionModified AdoptionStatus = "modified"
+	// AdoptionMissing means the file no longer exists in the prompts directory
+	AdoptionMissing AdoptionStatus = "missing"
+)
+
+// AdoptedChatmate is an adopted prompt file and its current status.
+type AdoptedChatmate struct {
+	state.ManagedFile
+	Status AdoptionStatus `json:"status"`
+}
+
+// Candidates returns installed prompt files that ChatMate neither ships nor manages.
+//
+// Returns:
+//   - []string: Filenames that can be adopted
+//   - error: Inventory or registry failure
+func (a *AdopterService) Candidates() ([]string, error) {
+	inventory, err := a.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	adopted := a.manager.adoptedSet()
+
+	var candidates []string
+	for _, filename := range inventory.Installed {
+		if !inventory.IsAvailable(filename) && !adopted[filename] && !isSyncConflict(filename) {
+			candidates = append(candidates, filename)
+		}
+	}
+	return candidates, nil
+}
+
+// Adopt records existing prompt files as user-managed chatmates.
+//
+// Each file is validated like a chatmate (safe filename, size limit, YAML
+// frontmatter) and recorded with a checksum of its content, so later
+// operations can tell whether it changed. Adopted files are no longer
+// reported as orphaned. Files that fail validation are reported and skipped.
+//
+// Parameters:
+//   - names: Display names or filenames to adopt; all candidates if empty
+//
+// Returns:
+//   - int: Number of files adopted
+//   - error: Lookup, registry, or validation failure
+//
+// Example:
+//
+// adopted, err := adopter.Adopt([]string{"My Agent"})
+//
+//	if err != nil {
+//	   return fmt.Errorf("adoption failed: %w", err)
+//	}
+func (a *AdopterService) Adopt(names []string) (int, error) {
+	if a.manager.registryPath == "" {
+		return 0, fmt.Errorf("adoption requires a state directory")
+	}
+
+	candidates, err := a.Candidates()
+	if err != nil {
+		return 0, err
+	}
+
+	toAdopt := candidates
+	if len(names) > 0 {
+		toAdopt = make([]string, 0, len(names))
+		for _, name := range names {
+			filename, found := a.manager.findChatmate(name, candidates)
+			if !found {
+				return 0, fmt.Errorf("no adoptable prompt file found: %s", name)
+			}
+			toAdopt = append(toAdopt, filename)
+		}
+	}
+
+	if len(toAdopt) == 0 {
+		a.manager.out().Println("No prompt files to adopt")
+		return 0, nil
+	}
+
+	registry, err := state.ReadRegistry(a.manager.registryPath)
+	if err != nil {
+		return 0, err
+	}
+
+	adopted := 0
+	var failed []string
+	for _, filename := range toAdopt {
+		checksum, err := a.validate(filename)
+		if err != nil {
+			a.manager.out().Warnf("%s: %v", filename, err)
+			failed = append(failed, filename)
+			continue
+		}
+
+		registry.Set(state.ManagedFile{
+			Filename:   filename,
+			PromptsDir: a.manager.PromptsDir,
+			Origin:     state.OriginAdopted,
+			Checksum:   checksum,
+			RecordedAt: time.Now().UTC(),
+		})
+		a.manager.out().Printf("📥 %s (adopted)\n", filename)
+		adopted++
+	}
+
+	if adopted > 0 {
+		if err := state.WriteRegistry(a.manager.registryPath, registry); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(failed) > 0 {
+		return adopted, fmt.Errorf("%d file(s) could not be adopted: %s", len(failed), strings.Join(failed, ", "))
+	}
+	return adopted, nil
+}
+
+// Release stops managing adopted files without touching them.
+//
+// Parameters:
+//   - names: Display names or filenames of adopted files
+//
+// Returns:
+//   - int: Number of files released
+//   - error: Lookup or registry failure
+func (a *AdopterService) Release(names []string) (int, error) {
+	if a.manager.registryPath == "" {
+		return 0, fmt.Errorf("adoption requires a state directory")
+	}
+
+	registry, err := state.ReadRegistry(a.manager.registryPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var adopted []string
+	for _, file := range registry.In(a.manager.PromptsDir) {
+		adopted = append(adopted, file.Filename)
+	}
+
+	released := 0
+	for _, name := range names {
+		filename, found := a.manager.findChatmate(name, adopted)
+		if !found {
+			return released, fmt.Errorf("not an adopted chatmate: %s", name)
+		}
+		registry.Remove(a.manager.PromptsDir, filename)
+		a.manager.out().Printf("📤 %s (released)\n", filename)
+		released++
+	}
+
+	if released > 0 {
+		if err := state.WriteRegistry(a.manager.registryPath, registry); err != nil {
+			return 0, err
+		}
+	}
+	return released, nil
+}
+
+// Adopted returns the adopted files in the prompts directory and their status.
+//
+// Returns:
+//   - []AdoptedChatmate: Adopted files, sorted by filename
+//   - error: Registry failure
+func (a *AdopterService) Adopted() ([]AdoptedChatmate, error) {
+	if a.manager.registryPath == "" {
+		return nil, nil
+	}
+
+	registry, err := state.ReadRegistry(a.manager.registryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var adopted []AdoptedChatmate
+	for _, file := range registry.In(a.manager.PromptsDir) {
+		status := AdoptionUnchanged
+		content, err := a.manager.FS.ReadFile(filepath.Join(a.manager.PromptsDir, file.Filename))
+		switch {
+		case os.IsNotExist(err):
+			status = AdoptionMissing
+		case err != nil || checksum(content) != file.Checksum:
+			status = AdoptionModified
+		}
+		adopted = append(adopted, AdoptedChatmate{ManagedFile: file, Status: status})
+	}
+	return adopted, nil
+}
+
+// validate checks that a prompt file can be managed as a chatmate and
+// returns the checksum of its content.
+func (a *AdopterService) validate(filename string) (string, error) {
+	if err := security.ValidateChatmateFilename(filename); err != nil {
+		return "", errorf(ErrValidationFailed, "security validation failed: %w", err)
+	}
+
+	content, err := a.manager.FS.ReadFile(filepath.Join(a.manager.PromptsDir, filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to read prompt file: %w", err)
+	}
+	if err := security.ValidateContentLength(content, 10*1024*1024); err != nil { // 10MB limit
+		return "", errorf(ErrValidationFailed, "content validation failed: %w", err)
+	}
+	if err := a.manager.checkFrontmatter(filename, content); err != nil {
+		return "", err
+	}
+
+	return checksum(content), nil
+}
+
+// adoptedSet returns the filenames adopted in the prompts directory.
+//
+// The registry is optional: without one, or when it cannot be read,
+// nothing is adopted.
+func (cm *ChatMateManager) adoptedSet() map[string]bool {
+	adopted := make(map[string]bool)
+	if cm.registryPath == "" {
+		return adopted
+	}
+
+	registry, err := state.ReadRegistry(cm.registryPath)
+	if err != nil {
+		cm.out().Debugf("Could not read registry: %v\n", err)
+		return adopted
+	}
+	for _, file := range registry.In(cm.PromptsDir) {
+		adopted[file.Filename] = true
+	}
+	return adopted
+}
+
+// checksum returns the hex-encoded SHA-256 of content.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,104 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestAdopterService tests adopting, listing, and releasing foreign prompt files
+func TestAdopterService(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	content := []byte("---\ndescription: test\n---\n")
+
+	shipped := "Chatmate - Solve Issue.chatmode.md"
+	if err := os.WriteFile(filepath.Join(matesDir, shipped), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	files := map[string][]byte{
+		shipped:                  content,
+		"My Agent.chatmode.md":   content,
+		"Team.chatmode.md":       content,
+		"Broken.chatmode.md":     []byte("# no frontmatter"),
+		"Notes (v2).chatmode.md": content,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, registryPath: filepath.Join(t.TempDir(), "managed.json")}
+	cm.adopter = NewAdopterService(cm)
+	cm.validator = NewValidatorService(cm)
+
+	candidates, err := cm.Adopter().Candidates()
+	if err != nil {
+		t.Fatalf("Candidates failed: %v", err)
+	}
+	if len(candidates) != 4 {
+		t.Errorf("Expected 4 candidates, got %v", candidates)
+	}
+
+	adopted, err := cm.Adopter().Adopt([]string{"My Agent"})
+	if err != nil || adopted != 1 {
+		t.Fatalf("Expected 1 adopted file, got %d (%v)", adopted, err)
+	}
+
+	// Adopting everything else skips files that fail validation
+	adopted, err = cm.Adopter().Adopt(nil)
+	if err == nil {
+		t.Error("Expected an error for files that fail validation")
+	}
+	if adopted != 1 {
+		t.Errorf("Expected only Team to be adopted, got %d", adopted)
+	}
+
+	if _, err := cm.Adopter().Adopt([]string{"Solve Issue"}); err == nil {
+		t.Error("Shipped chatmates cannot be adopted")
+	}
+
+	// Adopted files are not orphans
+	report, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	for _, check := range report.Checks {
+		if check.Name == "orphaned-files" {
+			for _, file := range check.Files {
+				if file == "My Agent.chatmode.md" || file == "Team.chatmode.md" {
+					t.Errorf("Adopted file reported as orphaned: %s", file)
+				}
+			}
+		}
+	}
+
+	// Changes since adoption are detected
+	if err := os.WriteFile(filepath.Join(promptsDir, "Team.chatmode.md"), []byte("---\ndescription: edited\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	list, err := cm.Adopter().Adopted()
+	if err != nil {
+		t.Fatalf("Adopted failed: %v", err)
+	}
+	statuses := make(map[string]AdoptionStatus)
+	for _, file := range list {
+		statuses[file.Filename] = file.Status
+	}
+	if statuses["My Agent.chatmode.md"] != AdoptionUnchanged || statuses["Team.chatmode.md"] != AdoptionModified {
+		t.Errorf("Unexpected adoption statuses: %v", statuses)
+	}
+
+	released, err := cm.Adopter().Release([]string{"Team"})
+	if err != nil || released != 1 {
+		t.Fatalf("Expected 1 released file, got %d (%v)", released, err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Team.chatmode.md")); err != nil {
+		t.Errorf("Releasing must keep the file: %v", err)
+	}
+	if _, err := cm.Adopter().Release([]string{"Team"}); err == nil {
+		t.Error("Expected error when releasing a file that is not adopted")
+	}
+}
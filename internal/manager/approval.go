@@ -0,0 +1,133 @@
+// Package manager provides the installation approval workflow for ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// approved reports whether installing a chatmate that is not installed yet
+// is approved by the team policy.
+//
+// An installation that is not approved is queued as an approval request,
+// reported, and remembered for QueuedApprovals; the caller skips it.
+// Installations that are approved clear their pending request. A policy that
+// cannot be read blocks the installation, since approval cannot be checked.
+//
+// Parameters:
+//   - filename: The chatmate filename
+//   - source: Where the content comes from (see state.SourceEmbedded)
+//   - location: The repository URL, directory, or download URL of the content
+//   - content: The content that would be installed
+//
+// Returns:
+//   - bool: Whether to install the chatmate
+//   - error: Policy read error
+func (i *InstallerService) approved(filename, source, location string, content []byte) (bool, error) {
+	teamPolicy, err := i.manager.Policy()
+	if err != nil {
+		return false, fmt.Errorf("cannot check whether %s is approved: %w", filename, err)
+	}
+
+	sum := checksum(content)
+	if teamPolicy.Approves([]string{filename, DisplayName(filename)}, sum) {
+		i.manager.updateApprovals(func(queue *state.ApprovalQueue) bool {
+			return queue.Remove(i.manager.PromptsDir, filename)
+		})
+		return true, nil
+	}
+
+	request := state.ApprovalRequest{
+		Filename:    filename,
+		PromptsDir:  i.manager.PromptsDir,
+		Source:      source,
+		Location:    location,
+		Checksum:    sum,
+		RequestedAt: time.Now().UTC(),
+	}
+	i.manager.updateApprovals(func(queue *state.ApprovalQueue) bool {
+		queue.Add(request)
+		return true
+	})
+	i.queued = append(i.queued, request)
+
+	i.record(filename, InstallQueued, "needs approval; request queued")
+	return false, nil
+}
+
+// QueuedApprovals describes the installations queued by an installer and
+// how to get them approved.
+//
+// Fields:
+//   - PolicyPath: The team policy the allowlist entries are added to
+//   - Requests: The queued installations, with the checksum to approve
+//   - Signed: Whether each allowlist entry must be signed with the policy's
+//     approval key
+//   - RequestsPath: Where the requests are saved, if they are remembered
+type QueuedApprovals struct {
+	PolicyPath   string                  `json:"policyPath"`
+	Requests     []state.ApprovalRequest `json:"requests"`
+	Signed       bool                    `json:"signed"`
+	RequestsPath string                  `json:"requestsPath,omitempty"`
+}
+
+// QueuedApprovals returns the installations queued for approval by this
+// installer, so users can forward the allowlist entries to whoever
+// maintains the team policy.
+//
+// Returns:
+//   - *QueuedApprovals: The queued installations; nil if there are none
+func (i *InstallerService) QueuedApprovals() *QueuedApprovals {
+	if len(i.queued) == 0 {
+		return nil
+	}
+
+	queued := &QueuedApprovals{
+		PolicyPath:   i.manager.policyPath,
+		Requests:     i.queued,
+		RequestsPath: i.manager.approvalsPath,
+	}
+	if teamPolicy, err := i.manager.Policy(); err == nil {
+		queued.Signed = teamPolicy.Approval.PublicKey != ""
+	}
+	return queued
+}
+
+// PendingApprovals returns the installations into the prompts directory that
+// are waiting for approval.
+//
+// Returns:
+//   - []state.ApprovalRequest: The pending requests, sorted by filename
+//   - error: Approval queue read error
+func (cm *ChatMateManager) PendingApprovals() ([]state.ApprovalRequest, error) {
+	if cm.approvalsPath == "" {
+		return nil, nil
+	}
+	queue, err := state.ReadApprovals(cm.approvalsPath)
+	if err != nil {
+		return nil, err
+	}
+	return queue.In(cm.PromptsDir), nil
+}
+
+// updateApprovals applies change to the approval queue and stores the queue
+// when change reports a modification.
+//
+// The queue only informs users and administrators; failing to update it
+// does not change whether a chatmate is installed, so errors are reported
+// as warnings.
+func (cm *ChatMateManager) updateApprovals(change func(queue *state.ApprovalQueue) bool) {
+	if cm.approvalsPath == "" || cm.dryRun != nil {
+		return
+	}
+
+	queue, err := state.ReadApprovals(cm.approvalsPath)
+	if err == nil && change(queue) {
+		err = state.WriteApprovals(cm.approvalsPath, queue)
+	}
+	if err != nil {
+		cm.out().Warnf("Could not update the approval requests: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestApprovalWorkflow tests queueing installations that the team policy has not approved
+func TestApprovalWorkflow(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+
+	content := []byte("---\ndescription: test\n---\n")
+	for _, name := range []string{"Chatmate - Testing.chatmode.md", "Chatmate - Review PR.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	policyContent := "required:\n  - Testing\napproval:\n  required: true\n"
+	if err := os.WriteFile(policyPath, []byte(policyContent), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, policyPath: policyPath,
+		approvalsPath: filepath.Join(t.TempDir(), state.ApprovalsFilename)}
+	cm.installer = NewInstallerService(cm)
+
+	// Required chatmates are approved; others are queued instead of installed
+	if _, err := cm.Installer().InstallSpecific(context.Background(), []string{"Testing", "Review PR"}, false); err != nil {
+		t.Fatalf("InstallSpecific failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - Testing.chatmode.md")); err != nil {
+		t.Errorf("Expected the required chatmate to be installed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - Review PR.chatmode.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected the unapproved chatmate not to be installed, got %v", err)
+	}
+	pending, err := cm.PendingApprovals()
+	if err != nil || len(pending) != 1 || pending[0].Filename != "Chatmate - Review PR.chatmode.md" ||
+		pending[0].Checksum != checksum(content) || pending[0].Source != state.SourceDirectory {
+		t.Errorf("Expected one approval request, got %+v, %v", pending, err)
+	}
+
+	// Once the allowlist approves it, the installation goes ahead and the request is cleared
+	policyContent += "  allowlist:\n    - chatmate: Review PR\n      sha256: " + checksum(content) + "\n"
+	if err := os.WriteFile(policyPath, []byte(policyContent), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	if _, err := cm.Installer().InstallSpecific(context.Background(), []string{"Review PR"}, false); err != nil {
+		t.Fatalf("InstallSpecific failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - Review PR.chatmode.md")); err != nil {
+		t.Errorf("Expected the approved chatmate to be installed: %v", err)
+	}
+	if pending, err := cm.PendingApprovals(); err != nil || len(pending) != 0 {
+		t.Errorf("Expected the request to be cleared, got %+v, %v", pending, err)
+	}
+}
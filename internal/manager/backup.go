@@ -0,0 +1,487 @@
+// Package manager provides backups of the prompts directory.
+package manager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/shared"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// DefaultBackupKeep is the number of backups kept unless the backupKeep
+// setting chooses another number.
+const DefaultBackupKeep = 10
+
+// Reasons recorded in the names of automatic backups.
+const (
+	BackupReasonUninstallAll = "uninstall-all"
+	BackupReasonForce        = "force"
+	BackupReasonRestore      = "restore"
+)
+
+// backupPrefix and backupExt surround the time and reason in the name of a
+// backup, e.g. "prompts-20240102T150405.000Z-force.tar.gz". A backup made in
+// the same millisecond as an existing one with the same reason gets a
+// counter before the extension, as in "prompts-20240102T150405.000Z-force.2.tar.gz".
+const (
+	backupPrefix     = "prompts-"
+	backupExt        = ".tar.gz"
+	backupTimeFormat = "20060102T150405.000Z"
+)
+
+// Backup is an archive of the prompts directory.
+//
+// Fields:
+//   - Name: The archive filename in the backups directory
+//   - Path: Full path of the archive
+//   - Created: When the archive was made
+//   - Reason: The operation an automatic backup was made before (e.g.,
+//     "uninstall-all"); empty for backups made with 'chatmate backup'
+//   - Files: Number of files in the archive
+//   - Bytes: Size of the archive
+type Backup struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Created time.Time `json:"created"`
+	Reason  string    `json:"reason,omitempty"`
+	Files   int       `json:"files"`
+	Bytes   int64     `json:"bytes"`
+}
+
+// BackupReport is the result of making a backup.
+//
+// Fields:
+//   - PromptsDir: The prompts directory that was archived
+//   - Backup: The new archive
+//   - Rotated: Older archives removed because more than BackupKeep were kept
+type BackupReport struct {
+	PromptsDir string   `json:"promptsDir"`
+	Backup     Backup   `json:"backup"`
+	Rotated    []string `json:"rotated,omitempty"`
+}
+
+// RestoreReport is the result of restoring a backup.
+//
+// Fields:
+//   - PromptsDir: The prompts directory that was restored
+//   - Archive: The archive that was restored
+//   - Backup: The archive of the prompts directory made before restoring;
+//     nil when automatic backups are disabled or the directory was empty
+//   - Restored: Files written from the archive, sorted
+//   - Removed: Chatmates not in the archive that were removed, with --clean
+type RestoreReport struct {
+	PromptsDir string   `json:"promptsDir"`
+	Archive    string   `json:"archive"`
+	Backup     *Backup  `json:"backup,omitempty"`
+	Restored   []string `json:"restored"`
+	Removed    []string `json:"removed,omitempty"`
+}
+
+// CreateBackup archives the files of the prompts directory into a
+// timestamped tar.gz in the backups directory (see state.BackupsDir), then
+// removes the oldest archives beyond BackupKeep.
+//
+// Parameters:
+//   - reason: Why the backup is made, recorded in its name; empty for a
+//     backup the user asked for
+//
+// Returns:
+//   - *BackupReport: The new archive and the rotated ones
+//   - error: Read, archive, or write error, or backups are not available
+//
+// Example:
+//
+//	report, err := manager.CreateBackup("")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(report.Backup.Path)
+func (cm *ChatMateManager) CreateBackup(reason string) (*BackupReport, error) {
+	if cm.backupsDir == "" {
+		return nil, fmt.Errorf("backups are not available: the state directory could not be found")
+	}
+	if cm.promptsDirErr != nil {
+		return nil, errorf(ErrPromptsDirMissing, "prompts directory is unusable: %w", cm.promptsDirErr)
+	}
+
+	names, err := cm.backupFiles()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for _, name := range names {
+		path := filepath.Join(cm.PromptsDir, name)
+		content, err := cm.FS.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		modTime := time.Now()
+		if info, err := cm.FS.Stat(path); err == nil {
+			modTime = info.ModTime()
+		}
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: modTime, Typeflag: tar.TypeReg}
+		if err := archive.WriteHeader(header); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", name, err)
+		}
+		if _, err := archive.Write(content); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	created := time.Now().UTC()
+	base := backupPrefix + created.Format(backupTimeFormat)
+	if reason != "" {
+		base += "-" + reason
+	}
+	if err := files.CheckWrite("write", filepath.Join(cm.backupsDir, base+backupExt)); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cm.backupsDir, cm.dirMode()); err != nil {
+		return nil, fmt.Errorf("failed to create backups directory: %w", err)
+	}
+	// Names only record the millisecond: never replace a backup made in
+	// the same one, count up instead
+	var filename, path string
+	for count := 1; ; count++ {
+		filename = base + backupExt
+		if count > 1 {
+			filename = base + "." + strconv.Itoa(count) + backupExt
+		}
+		path = filepath.Join(cm.backupsDir, filename)
+		err := files.CreateFileAtomic(path, buf.Bytes(), cm.fileMode())
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("failed to write backup %s: %w", path, err)
+		}
+	}
+
+	report := &BackupReport{
+		PromptsDir: cm.PromptsDir,
+		Backup: Backup{
+			Name:    filename,
+			Path:    path,
+			Created: created.Truncate(time.Millisecond),
+			Reason:  reason,
+			Files:   len(names),
+			Bytes:   int64(buf.Len()),
+		},
+	}
+	report.Rotated, err = cm.rotateBackups()
+	return report, err
+}
+
+// Backups lists the archives in the backups directory, newest first.
+//
+// Returns:
+//   - []Backup: The archives; empty when there are none
+//   - error: Directory or archive read error
+func (cm *ChatMateManager) Backups() ([]Backup, error) {
+	backups := []Backup{}
+	if cm.backupsDir == "" {
+		return backups, nil
+	}
+	entries, err := os.ReadDir(cm.backupsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backups directory %s: %w", cm.backupsDir, err)
+	}
+
+	modTimes := map[string]time.Time{}
+	counts := map[string]int{}
+	for _, entry := range entries {
+		created, reason, count, ok := parseBackupName(entry.Name())
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+		counts[entry.Name()] = count
+		backup := Backup{Name: entry.Name(), Path: filepath.Join(cm.backupsDir, entry.Name()), Created: created, Reason: reason}
+		if info, err := entry.Info(); err == nil {
+			backup.Bytes = info.Size()
+			modTimes[backup.Name] = info.ModTime()
+		}
+		if contents, err := readBackup(backup.Path); err == nil {
+			backup.Files = len(contents)
+		}
+		backups = append(backups, backup)
+	}
+	// Names only record the millisecond, so archives made within the same
+	// millisecond are ordered by their counter, then by when their files
+	// were written
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].Created.Equal(backups[j].Created) {
+			return backups[i].Created.After(backups[j].Created)
+		}
+		if counts[backups[i].Name] != counts[backups[j].Name] {
+			return counts[backups[i].Name] > counts[backups[j].Name]
+		}
+		if !modTimes[backups[i].Name].Equal(modTimes[backups[j].Name]) {
+			return modTimes[backups[i].Name].After(modTimes[backups[j].Name])
+		}
+		return backups[i].Name > backups[j].Name
+	})
+	return backups, nil
+}
+
+// RestoreBackup writes the files of an archive back into the prompts
+// directory, replacing the installed ones with the same name.
+//
+// The whole archive is read and checked before anything is written, and
+// unless automatic backups are disabled, the prompts directory is archived
+// first, so a restore can be undone by restoring that backup. Provenance is
+// kept: restored chatmates that differ from what ChatMate installed are
+// reported by 'chatmate verify' and kept by 'chatmate update'.
+//
+// Parameters:
+//   - archive: Path of a tar.gz made by CreateBackup, the name of a backup
+//     in the backups directory, or "latest" for the newest one
+//   - clean: If true, installed chatmates that are not in the archive are
+//     removed, so the chatmates are exactly those of the archive
+//
+// Returns:
+//   - *RestoreReport: What was restored and removed
+//   - error: Unknown or malformed archive, backup, or file write error
+//
+// Example:
+//
+//	report, err := manager.RestoreBackup("latest", false)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Restored %d files\n", len(report.Restored))
+func (cm *ChatMateManager) RestoreBackup(archive string, clean bool) (*RestoreReport, error) {
+	path, err := cm.findBackup(archive)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := readBackup(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cm.ensurePromptsDir(); err != nil {
+		return nil, err
+	}
+
+	report := &RestoreReport{PromptsDir: cm.PromptsDir, Archive: path, Restored: []string{}}
+	if report.Backup, err = cm.backupBefore(BackupReasonRestore); err != nil {
+		return nil, err
+	}
+	defer cm.invalidateInventory()
+
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		written, err := cm.writeChatmate(name, contents[name])
+		if err != nil {
+			return report, err
+		}
+		if written {
+			report.Restored = append(report.Restored, name)
+		}
+	}
+
+	if clean {
+		inventory, err := cm.Inventory()
+		if err != nil {
+			return report, err
+		}
+		for _, filename := range inventory.Installed {
+			if _, ok := contents[filename]; ok {
+				continue
+			}
+			removed, err := cm.removeChatmate(filename)
+			if err != nil {
+				return report, err
+			}
+			if removed {
+				cm.forgetProvenance(filename)
+				report.Removed = append(report.Removed, filename)
+			}
+		}
+	}
+	return report, nil
+}
+
+// backupBefore archives the prompts directory before a destructive
+// operation, unless automatic backups are disabled (AutoBackup) or the
+// directory has no files.
+//
+// Parameters:
+//   - reason: The operation, e.g. BackupReasonUninstallAll
+//
+// Returns:
+//   - *Backup: The new archive; nil when no backup was made
+//   - error: Error if the backup failed; the operation must not continue
+func (cm *ChatMateManager) backupBefore(reason string) (*Backup, error) {
+	if !cm.AutoBackup || cm.backupsDir == "" {
+		return nil, nil
+	}
+	if names, err := cm.backupFiles(); err != nil || len(names) == 0 {
+		return nil, err
+	}
+	report, err := cm.CreateBackup(reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up the prompts directory before %s (disable automatic backups with 'chatmate config set autoBackup false'): %w", reason, err)
+	}
+	cm.out().Printf("💾 Backed up the prompts directory to %s\n", report.Backup.Path)
+	return &report.Backup, nil
+}
+
+// backupFiles returns the names of the files in the prompts directory that
+// are archived: every regular file except the shared-mode lock and
+// manifest, sorted. A missing directory has none.
+func (cm *ChatMateManager) backupFiles() ([]string, error) {
+	entries, err := cm.FS.ReadDir(cm.PromptsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts directory %s: %w", cm.PromptsDir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || entry.Name() == shared.LockFilename || entry.Name() == shared.ManifestFilename {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// rotateBackups removes the oldest archives beyond BackupKeep and returns
+// their paths. A BackupKeep of 0 keeps every archive.
+func (cm *ChatMateManager) rotateBackups() ([]string, error) {
+	if cm.BackupKeep <= 0 {
+		return nil, nil
+	}
+	backups, err := cm.Backups()
+	if err != nil || len(backups) <= cm.BackupKeep {
+		return nil, err
+	}
+
+	var rotated []string
+	for _, backup := range backups[cm.BackupKeep:] {
+		if err := os.Remove(backup.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return rotated, fmt.Errorf("failed to remove old backup %s: %w", backup.Path, err)
+		}
+		rotated = append(rotated, backup.Path)
+	}
+	return rotated, nil
+}
+
+// findBackup resolves the archive argument of RestoreBackup to a path.
+func (cm *ChatMateManager) findBackup(archive string) (string, error) {
+	if archive == "latest" {
+		backups, err := cm.Backups()
+		if err != nil {
+			return "", err
+		}
+		if len(backups) == 0 {
+			return "", fmt.Errorf("no backups found in %s", cm.backupsDir)
+		}
+		return backups[0].Path, nil
+	}
+	if info, err := os.Stat(archive); err == nil && !info.IsDir() {
+		return archive, nil
+	}
+	if cm.backupsDir != "" && filepath.Base(archive) == archive {
+		for _, name := range []string{archive, archive + backupExt} {
+			path := filepath.Join(cm.backupsDir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("backup not found: %s (see 'chatmate backup --list')", archive)
+}
+
+// readBackup reads the files of an archive made by CreateBackup. Archives
+// with entries other than files at the top level, such as directories,
+// links, or paths leaving the prompts directory, are rejected.
+func readBackup(path string) (map[string][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup %s: %w", path, err)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	contents := make(map[string][]byte)
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup %s: %w", path, err)
+		}
+		name := header.Name
+		if header.Typeflag != tar.TypeReg || name != filepath.Base(name) || strings.ContainsAny(name, `/\`) || !filepath.IsLocal(name) {
+			return nil, fmt.Errorf("invalid backup %s: unexpected entry %q; only files of the prompts directory are restored", path, name)
+		}
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup %s: %w", path, err)
+		}
+		contents[name] = content
+	}
+	return contents, nil
+}
+
+// parseBackupName returns the time, reason, and counter (1 when there is
+// none) in the name of an archive made by CreateBackup, and whether name is
+// one.
+func parseBackupName(name string) (time.Time, string, int, bool) {
+	rest, ok := strings.CutPrefix(name, backupPrefix)
+	if !ok {
+		return time.Time{}, "", 0, false
+	}
+	rest, ok = strings.CutSuffix(rest, backupExt)
+	if !ok {
+		return time.Time{}, "", 0, false
+	}
+	count := 1
+	if i := strings.LastIndex(rest, "."); i >= 0 {
+		if n, err := strconv.Atoi(rest[i+1:]); err == nil && n > 1 {
+			rest, count = rest[:i], n
+		}
+	}
+	stamp, reason, _ := strings.Cut(rest, "-")
+	created, err := time.Parse(backupTimeFormat, stamp)
+	if err != nil {
+		return time.Time{}, "", 0, false
+	}
+	return created, reason, count, true
+}
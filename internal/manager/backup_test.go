@@ -0,0 +1,146 @@
+package manager
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/shared"
+)
+
+// TestChatMateManager_BackupRestore tests archiving the prompts directory,
+// rotating old archives, and restoring them
+func TestChatMateManager_BackupRestore(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	backupsDir := filepath.Join(t.TempDir(), "backups")
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md"} {
+		write(matesDir, name, "---\ndescription: test\n---\nShipped\n")
+		write(promptsDir, name, "---\ndescription: test\n---\nInstalled\n")
+	}
+	write(promptsDir, shared.LockFilename, "lock")
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, backupsDir: backupsDir, AutoBackup: true, BackupKeep: 3}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	created, err := cm.CreateBackup("")
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if created.Backup.Files != 2 || created.Backup.Reason != "" || len(created.Rotated) != 0 {
+		t.Errorf("Expected both chatmates without the lock file to be archived, got %+v", created)
+	}
+
+	// Uninstalling everything is preceded by an automatic backup
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+	if err := cm.Uninstaller().UninstallAll(context.Background()); err != nil {
+		t.Fatalf("UninstallAll failed: %v", err)
+	}
+	backups, err := cm.Backups()
+	if err != nil || len(backups) != 2 || backups[0].Reason != BackupReasonUninstallAll || backups[0].Files != 2 {
+		t.Fatalf("Expected an automatic backup before uninstalling, got %+v, %v", backups, err)
+	}
+
+	write(promptsDir, "C.chatmode.md", "---\ndescription: test\n---\nNew\n")
+	restored, err := cm.RestoreBackup("latest", true)
+	if err != nil {
+		t.Fatalf("RestoreBackup failed: %v", err)
+	}
+	if !slices.Equal(restored.Restored, []string{"A.chatmode.md", "B.chatmode.md"}) || !slices.Equal(restored.Removed, []string{"C.chatmode.md"}) {
+		t.Errorf("Expected A and B to be restored and C to be removed, got %+v", restored)
+	}
+	if restored.Backup == nil || restored.Backup.Reason != BackupReasonRestore {
+		t.Errorf("Expected a backup before restoring, got %+v", restored.Backup)
+	}
+	if content, _ := os.ReadFile(filepath.Join(promptsDir, "A.chatmode.md")); !strings.Contains(string(content), "Installed") {
+		t.Errorf("Expected the installed content to be restored, got %q", content)
+	}
+
+	// Only the newest BackupKeep archives are kept
+	created, err = cm.CreateBackup("")
+	if err != nil || len(created.Rotated) != 1 || filepath.Base(created.Rotated[0]) != backups[1].Name {
+		t.Errorf("Expected the oldest backup to be rotated, got %+v, %v", created, err)
+	}
+	if backups, _ := cm.Backups(); len(backups) != 3 {
+		t.Errorf("Expected 3 backups to be kept, got %+v", backups)
+	}
+
+	if _, err := cm.RestoreBackup("prompts-missing.tar.gz", false); err == nil {
+		t.Error("Expected an error for an unknown backup")
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	archive.WriteHeader(&tar.Header{Name: "../evil.chatmode.md", Mode: 0644, Size: 4, Typeflag: tar.TypeReg})
+	archive.Write([]byte("evil"))
+	archive.Close()
+	gz.Close()
+	evil := filepath.Join(t.TempDir(), "evil.tar.gz")
+	if err := os.WriteFile(evil, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+	if _, err := cm.RestoreBackup(evil, false); err == nil || !strings.Contains(err.Error(), "unexpected entry") {
+		t.Errorf("Expected an archive leaving the prompts directory to be rejected, got %v", err)
+	}
+}
+
+// TestCreateBackupSameMillisecond tests that backups made within the same
+// millisecond get distinct names instead of replacing each other
+func TestCreateBackupSameMillisecond(t *testing.T) {
+	promptsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(promptsDir, "A.chatmode.md"), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to write chatmate: %v", err)
+	}
+	cm := &ChatMateManager{PromptsDir: promptsDir, backupsDir: filepath.Join(t.TempDir(), "backups"), BackupKeep: 100}
+
+	const count = 50
+	var wg sync.WaitGroup
+	names := make([]string, count)
+	for i := range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report, err := cm.CreateBackup(BackupReasonForce)
+			if err != nil {
+				t.Errorf("CreateBackup failed: %v", err)
+				return
+			}
+			names[i] = report.Backup.Name
+		}()
+	}
+	wg.Wait()
+
+	backups, err := cm.Backups()
+	if err != nil || len(backups) != count {
+		t.Fatalf("Expected %d backups, got %d: %v, %v", count, len(backups), names, err)
+	}
+	for _, backup := range backups {
+		if backup.Reason != BackupReasonForce || backup.Files != 1 {
+			t.Errorf("Unexpected backup %+v", backup)
+		}
+	}
+
+	created, reason, counter, ok := parseBackupName("prompts-20240102T150405.000Z-uninstall-all.3.tar.gz")
+	if !ok || reason != BackupReasonUninstallAll || counter != 3 || created.Year() != 2024 {
+		t.Errorf("Unexpected parse of a counted name: %v, %q, %d, %v", created, reason, counter, ok)
+	}
+	if _, reason, counter, ok := parseBackupName("prompts-20240102T150405.000Z.tar.gz"); !ok || reason != "" || counter != 1 {
+		t.Errorf("Unexpected parse of a plain name: %q, %d, %v", reason, counter, ok)
+	}
+}
@@ -0,0 +1,267 @@
+// Package manager provides the software inventory of installed ChatMate agents.
+package manager
+
+import (
+	"crypto/rand"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// BillOfMaterials lists the chatmates installed in the prompts directory for
+// asset management tooling.
+//
+// Fields:
+//   - GeneratedAt: When the inventory was taken
+//   - Tool: The ChatMate version that took the inventory
+//   - PromptsDir: The prompts directory that was inventoried
+//   - Chatmates: The installed chatmates, sorted by filename
+type BillOfMaterials struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Tool        string              `json:"tool"`
+	PromptsDir  string              `json:"promptsDir"`
+	Chatmates   []InstalledChatmate `json:"chatmates"`
+}
+
+// InstalledChatmate is an entry of the BillOfMaterials.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename in the prompts directory
+//   - SHA256: Hex-encoded SHA-256 of the current content
+//   - Size: Content size in bytes
+//   - Version: Version of the chatmate from its frontmatter; without one,
+//     for chatmates installed by ChatMate this is the version of ChatMate
+//     that shipped or installed them
+//   - Description, Author, License: From the frontmatter, when it parses
+//   - Origin: "installed" (recorded provenance), "adopted", or "unknown"
+//   - Provenance: Where the chatmate was installed from, if recorded
+//   - Modified: Whether the content changed since it was installed or adopted
+type InstalledChatmate struct {
+	Name        string            `json:"name"`
+	Filename    string            `json:"filename"`
+	SHA256      string            `json:"sha256"`
+	Size        int               `json:"size"`
+	Version     string            `json:"version,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Author      string            `json:"author,omitempty"`
+	License     string            `json:"license,omitempty"`
+	Origin      string            `json:"origin"`
+	Provenance  *state.Provenance `json:"provenance,omitempty"`
+	Modified    bool              `json:"modified"`
+}
+
+// Origins of inventoried chatmates.
+const (
+	OriginInstalled = "installed"
+	OriginUnknown   = "unknown"
+)
+
+// BillOfMaterials takes an inventory of the installed chatmates with their
+// hashes, versions, and provenance.
+//
+// Every installed file is listed, including user-created and adopted
+// chatmates and files whose frontmatter does not parse, so the inventory is
+// complete for asset management. Ignored files are left out.
+//
+// Returns:
+//   - *BillOfMaterials: The inventory
+//   - error: Prompts directory access or read error
+//
+// Example:
+//
+// bom, err := manager.BillOfMaterials()
+//
+//	if err != nil {
+//	   return fmt.Errorf("inventory failed: %w", err)
+//	}
+func (cm *ChatMateManager) BillOfMaterials() (*BillOfMaterials, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := cm.provenanceLog()
+	adopted := make(map[string]state.ManagedFile)
+	if cm.registryPath != "" {
+		if registry, err := state.ReadRegistry(cm.registryPath); err == nil {
+			for _, file := range registry.In(cm.PromptsDir) {
+				adopted[file.Filename] = file
+			}
+		}
+	}
+
+	bom := &BillOfMaterials{
+		GeneratedAt: time.Now().UTC(),
+		Tool:        cm.Version,
+		PromptsDir:  cm.PromptsDir,
+		Chatmates:   []InstalledChatmate{},
+	}
+	for _, filename := range inventory.Installed {
+		path := filepath.Join(cm.PromptsDir, filename)
+		content, err := cm.FS.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+		}
+
+		chatmate := InstalledChatmate{
+			Name:     cm.getDisplayName(filename),
+			Filename: filename,
+			SHA256:   checksum(content),
+			Size:     len(content),
+			Origin:   OriginUnknown,
+		}
+		if meta, err := files.ParseFrontmatter(content); err == nil {
+			chatmate.Description = meta.Description
+			chatmate.Author = meta.Author.String()
+			chatmate.License = meta.License
+			chatmate.Version = meta.Version
+		}
+		if record, ok := provenance.Get(cm.PromptsDir, filename); ok {
+			chatmate.Origin = OriginInstalled
+			chatmate.Provenance = &record
+			if chatmate.Version == "" {
+				chatmate.Version = record.InstallerVersion
+			}
+			chatmate.Modified = record.Checksum != chatmate.SHA256
+		} else if file, ok := adopted[filename]; ok {
+			chatmate.Origin = file.Origin
+			chatmate.Modified = file.Checksum != chatmate.SHA256
+		}
+
+		bom.Chatmates = append(bom.Chatmates, chatmate)
+	}
+
+	return bom, nil
+}
+
+// CycloneDX is a CycloneDX 1.5 bill of materials, limited to the fields
+// ChatMate fills in.
+type CycloneDX struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cycloneDXComponent `json:"components"`
+	} `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXComponent struct {
+	Type               string               `json:"type"`
+	BOMRef             string               `json:"bom-ref,omitempty"`
+	Name               string               `json:"name"`
+	Version            string               `json:"version,omitempty"`
+	Description        string               `json:"description,omitempty"`
+	Author             string               `json:"author,omitempty"`
+	Hashes             []cycloneDXHash      `json:"hashes,omitempty"`
+	Licenses           []cycloneDXLicense   `json:"licenses,omitempty"`
+	ExternalReferences []cycloneDXReference `json:"externalReferences,omitempty"`
+	Properties         []cycloneDXProperty  `json:"properties,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cycloneDXReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDX converts the inventory into a CycloneDX 1.5 JSON document.
+//
+// Each chatmate becomes a "file" component with its SHA-256 hash, license
+// expression, and author. Provenance that has no CycloneDX field is kept in
+// properties prefixed with "chatmate:".
+//
+// Returns:
+//   - *CycloneDX: The document, ready to be encoded as JSON
+func (b *BillOfMaterials) CycloneDX() *CycloneDX {
+	doc := &CycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + newUUID(),
+		Version:      1,
+		Components:   []cycloneDXComponent{},
+	}
+	doc.Metadata.Timestamp = b.GeneratedAt.Format(time.RFC3339)
+	doc.Metadata.Tools.Components = []cycloneDXComponent{{
+		Type:               "application",
+		Name:               "chatmate",
+		Version:            b.Tool,
+		ExternalReferences: []cycloneDXReference{{Type: "vcs", URL: RepositoryURL}},
+	}}
+	doc.Metadata.Component = cycloneDXComponent{
+		Type:        "application",
+		Name:        "VS Code prompts",
+		Description: "Chatmates installed in " + b.PromptsDir,
+		Properties:  []cycloneDXProperty{{Name: "chatmate:promptsDir", Value: b.PromptsDir}},
+	}
+
+	for _, chatmate := range b.Chatmates {
+		component := cycloneDXComponent{
+			Type:        "file",
+			BOMRef:      chatmate.Filename,
+			Name:        chatmate.Name,
+			Version:     chatmate.Version,
+			Description: chatmate.Description,
+			Author:      chatmate.Author,
+			Hashes:      []cycloneDXHash{{Alg: "SHA-256", Content: chatmate.SHA256}},
+			Properties: []cycloneDXProperty{
+				{Name: "chatmate:filename", Value: chatmate.Filename},
+				{Name: "chatmate:origin", Value: chatmate.Origin},
+				{Name: "chatmate:modified", Value: fmt.Sprint(chatmate.Modified)},
+			},
+		}
+		if chatmate.License != "" {
+			component.Licenses = []cycloneDXLicense{{Expression: chatmate.License}}
+		}
+		if p := chatmate.Provenance; p != nil {
+			component.Properties = append(component.Properties,
+				cycloneDXProperty{Name: "chatmate:source", Value: p.Source},
+				cycloneDXProperty{Name: "chatmate:installedAt", Value: p.InstalledAt.Format(time.RFC3339)})
+			if p.Location != "" {
+				component.Properties = append(component.Properties, cycloneDXProperty{Name: "chatmate:location", Value: p.Location})
+			}
+			if p.InstallerVersion != "" {
+				component.Properties = append(component.Properties, cycloneDXProperty{Name: "chatmate:installerVersion", Value: p.InstallerVersion})
+			}
+			if p.Source == state.SourceEmbedded {
+				component.ExternalReferences = []cycloneDXReference{{Type: "distribution", URL: p.Location}}
+			}
+		}
+		doc.Components = append(doc.Components, component)
+	}
+
+	return doc
+}
+
+// newUUID returns a random (version 4) UUID for the CycloneDX serial number.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
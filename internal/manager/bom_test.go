@@ -0,0 +1,69 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestBillOfMaterials tests the inventory of installed chatmates and its CycloneDX form
+func TestBillOfMaterials(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	content := []byte("---\ndescription: 'A'\nauthor: 'Jane Doe'\nlicense: 'MIT'\n---\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - A.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Mine.chatmode.md"), []byte("no frontmatter"), 0644); err != nil {
+		t.Fatalf("Failed to create user file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Version: "1.2.3",
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	if err := cm.Installer().InstallChatmate("Chatmate - A.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+
+	bom, err := cm.BillOfMaterials()
+	if err != nil {
+		t.Fatalf("BillOfMaterials failed: %v", err)
+	}
+	if len(bom.Chatmates) != 2 {
+		t.Fatalf("Expected both installed files, got %+v", bom.Chatmates)
+	}
+
+	installed := bom.Chatmates[0]
+	if installed.Name != "A" || installed.SHA256 != checksum(content) || installed.Version != "1.2.3" || installed.License != "MIT" ||
+		installed.Author != "Jane Doe" || installed.Origin != OriginInstalled || installed.Provenance == nil || installed.Modified {
+		t.Errorf("Unexpected installed entry: %+v", installed)
+	}
+	if mine := bom.Chatmates[1]; mine.Origin != OriginUnknown || mine.Provenance != nil || mine.SHA256 == "" {
+		t.Errorf("Unexpected user-created entry: %+v", mine)
+	}
+
+	doc := bom.CycloneDX()
+	if doc.BOMFormat != "CycloneDX" || !strings.HasPrefix(doc.SerialNumber, "urn:uuid:") || len(doc.SerialNumber) != len("urn:uuid:")+36 {
+		t.Errorf("Unexpected CycloneDX header: %+v", doc)
+	}
+	if len(doc.Components) != 2 || doc.Components[0].Hashes[0].Alg != "SHA-256" || doc.Components[0].Licenses[0].Expression != "MIT" {
+		t.Errorf("Unexpected CycloneDX components: %+v", doc.Components)
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Errorf("CycloneDX document should encode: %v", err)
+	}
+
+	// Changing an installed chatmate is reported
+	if err := os.WriteFile(filepath.Join(promptsDir, "Chatmate - A.chatmode.md"), []byte("changed"), 0644); err != nil {
+		t.Fatalf("Failed to modify file: %v", err)
+	}
+	cm.invalidateInventory()
+	if bom, err = cm.BillOfMaterials(); err != nil || !bom.Chatmates[0].Modified {
+		t.Errorf("Expected the changed chatmate to be modified, got %+v, %v", bom, err)
+	}
+}
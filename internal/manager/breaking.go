@@ -0,0 +1,98 @@
+// Package manager provides confirmation of breaking chatmate updates.
+package manager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/registry"
+)
+
+// maxChangelogLines limits the changelog excerpt shown for each breaking
+// release.
+const maxChangelogLines = 10
+
+// breakingReleases returns the releases marked as breaking that an update of
+// a registry chatmate from one version to another installs.
+func breakingReleases(entry registry.Entry, from, to string) []registry.Release {
+	var breaking []registry.Release
+	for _, release := range entry.Changes(from, to) {
+		if release.Breaking {
+			breaking = append(breaking, release)
+		}
+	}
+	return breaking
+}
+
+// markBreaking sets the breaking releases of updates, according to the
+// registry metadata of the chatmates.
+//
+// Shipped chatmates that the registry also publishes releases for get the
+// breaking markers of those releases. Updates are not held back when the
+// registry is not configured or cannot be read.
+func (i *InstallerService) markBreaking(ctx context.Context, updates []ChatmateUpdate) {
+	if i.manager.Registry == nil || len(updates) == 0 {
+		return
+	}
+	index, err := i.manager.RegistryIndex(ctx, false)
+	if err != nil {
+		i.manager.out().Debugf("Could not check the registry for breaking changes: %v\n", err)
+		return
+	}
+
+	for n := range updates {
+		update := &updates[n]
+		entry, ok := index.Find(update.Filename)
+		if !ok || update.AvailableVersion == "" {
+			continue
+		}
+		update.Breaking = breakingReleases(entry, update.InstalledVersion, update.AvailableVersion)
+	}
+}
+
+// acceptBreaking shows the changelog of the breaking releases an update
+// installs and asks whether to install it anyway.
+//
+// With AcceptBreaking set, breaking updates are installed without asking.
+// The global --yes does not accept them: the chatmate is kept and a warning
+// names --accept-breaking, so scripts never change the behavior of a
+// chatmate by accident.
+//
+// Parameters:
+//   - filename: The chatmate filename
+//   - version: The version being installed
+//   - breaking: The breaking releases the update installs
+//
+// Returns:
+//   - bool: True when the update may be installed
+func (i *InstallerService) acceptBreaking(filename, version string, breaking []registry.Release) bool {
+	if len(breaking) == 0 || i.AcceptBreaking {
+		return true
+	}
+
+	out := i.manager.out()
+	displayName := i.manager.getDisplayName(filename)
+	out.Printf("\n⚠️  Updating %s to %s installs breaking changes:\n", displayName, version)
+	for _, release := range breaking {
+		out.Printf("  %s:\n", release.Version)
+		changelog := strings.TrimSpace(release.Changelog)
+		if changelog == "" {
+			out.Println("    (no changelog published)")
+			continue
+		}
+		lines := strings.Split(changelog, "\n")
+		if len(lines) > maxChangelogLines {
+			lines = append(lines[:maxChangelogLines], "…")
+		}
+		for _, line := range lines {
+			out.Printf("    %s\n", strings.TrimRight(line, " \t\r"))
+		}
+	}
+
+	if output.AssumeYes() {
+		out.Warnf("%s was kept at its installed version; use --accept-breaking to install breaking changes with --yes", displayName)
+		return false
+	}
+	return out.Confirm("Install %s %s with breaking changes?", displayName, version)
+}
@@ -0,0 +1,76 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/registry"
+)
+
+// TestBreakingUpdates tests that updates to releases marked as breaking show
+// their changelog and need their own confirmation
+func TestBreakingUpdates(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	writeVersion := func(version string) {
+		content := "---\ndescription: test\nversion: '" + version + "'\n---\n" + version + "\n"
+		if err := os.WriteFile(filepath.Join(matesDir, "Solve Issue.chatmode.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	writeVersion("1.0.0")
+
+	sum := strings.Repeat("a", 64)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"chatmates": [{"name": "Solve Issue", "filename": "Solve Issue.chatmode.md", "version": "2.0.0",
+			"url": "current.chatmode.md", "sha256": %q, "breaking": true, "changelog": "Asks before closing issues",
+			"versions": [{"version": "1.5.0", "url": "old.chatmode.md", "sha256": %q}]}]}`, sum, sum)
+	}))
+	defer server.Close()
+
+	var stdout bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Output: &stdout, ErrOutput: &bytes.Buffer{},
+		Registry: &registry.Client{URL: server.URL + "/index.json", HTTP: server.Client()}}
+	cm.installer = NewInstallerService(cm)
+	if err := cm.Installer().InstallChatmate("Solve Issue.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	writeVersion("2.0.0")
+	cm.invalidateInventory()
+
+	// --yes alone does not accept breaking changes
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 0 {
+		t.Errorf("Expected the breaking update to be held back, got %d, %v", updated, err)
+	}
+	if !strings.Contains(stdout.String(), "1.0.0 → 2.0.0; breaking changes") || !strings.Contains(stdout.String(), "Asks before closing issues") {
+		t.Errorf("Expected the plan and the changelog, got %q", stdout.String())
+	}
+	if result, err := cm.Installer().Sync(context.Background(), false, false); err != nil || len(result.Updated) != 0 {
+		t.Errorf("Expected sync to hold back the breaking update, got %+v, %v", result, err)
+	}
+
+	// Declined interactively
+	output.SetAssumeYes(false)
+	output.SetInput(strings.NewReader("y\nn\n"))
+	defer output.SetInput(nil)
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 0 {
+		t.Errorf("Expected the declined breaking update to be kept, got %d, %v", updated, err)
+	}
+
+	output.SetAssumeYes(true)
+	cm.Installer().AcceptBreaking = true
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 1 {
+		t.Errorf("Expected --accept-breaking to install the update, got %d, %v", updated, err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package manager provides detection of chatmate filenames that only differ
+// in case or Unicode normalization.
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// CaseCollision is an available chatmate whose filename only differs in
+// case from an installed file, such as "Solve Issue.chatmode.md" and
+// "solve issue.chatmode.md". On case-insensitive filesystems (the default on
+// macOS and Windows) both names refer to the same file, so installing the
+// chatmate would overwrite the installed file under its old name; on other
+// filesystems it would add a second chatmate with the same @-mention.
+//
+// Fields:
+//   - Filename: The available chatmate
+//   - Installed: The installed file it collides with
+type CaseCollision struct {
+	Filename  string `json:"filename"`
+	Installed string `json:"installed"`
+}
+
+// String describes the collision and how to resolve it.
+func (c CaseCollision) String() string {
+	return fmt.Sprintf("%s only differs in case from the installed %s; uninstall or rename %s to install it",
+		c.Filename, c.Installed, c.Installed)
+}
+
+// caseVariant returns the entry of filenames that equals filename apart from
+// case, if there is one. An entry equal to filename is not a variant, even in
+// another Unicode normalization (see files.SameFilename).
+//
+// Parameters:
+//   - filename: The filename to look for
+//   - filenames: The filenames to search, e.g. the installed chatmates
+//
+// Returns:
+//   - string: The variant
+//   - bool: Whether filenames contains a variant
+func caseVariant(filename string, filenames []string) (string, bool) {
+	var variant string
+	normalized := files.NormalizeFilename(filename)
+	for _, other := range filenames {
+		otherNormalized := files.NormalizeFilename(other)
+		if otherNormalized == normalized {
+			return "", false
+		}
+		if variant == "" && strings.EqualFold(otherNormalized, normalized) {
+			variant = other
+		}
+	}
+	return variant, variant != ""
+}
+
+// installedCaseVariant returns the installed file that only differs in case
+// from filename, if there is one. A prompts directory that cannot be read
+// has none.
+func (cm *ChatMateManager) installedCaseVariant(filename string) (CaseCollision, bool) {
+	installed, err := cm.GetInstalledChatmates()
+	if err != nil {
+		return CaseCollision{}, false
+	}
+	variant, found := caseVariant(filename, installed)
+	return CaseCollision{Filename: filename, Installed: variant}, found
+}
+
+// canonicalFilename returns the name under which filename is stored in the
+// prompts directory. On case-insensitive filesystems a file written as
+// "Solve Issue.chatmode.md" keeps the name it was created with, e.g.
+// "solve issue.chatmode.md", and state must be recorded under that name to
+// match the directory.
+func (cm *ChatMateManager) canonicalFilename(filename string) string {
+	entries, err := cm.FS.ReadDir(cm.PromptsDir)
+	if err != nil {
+		return filename
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, normalizedName(cm.FS.FileSystem(), cm.PromptsDir, entry.Name()))
+	}
+	if variant, found := caseVariant(filename, names); found {
+		return variant
+	}
+	return filename
+}
+
+// normalizedName returns the NFC form of the name of a file in dir, if fsys
+// finds the file under it, and name otherwise.
+func normalizedName(fsys files.FileSystem, dir, name string) string {
+	normalized := files.NormalizeFilename(name)
+	if normalized == name {
+		return name
+	}
+	if _, err := fsys.Stat(filepath.Join(dir, normalized)); err != nil {
+		return name
+	}
+	return normalized
+}
@@ -0,0 +1,194 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"golang.org/x/text/unicode/norm"
+)
+
+// TestCaseCollisions tests that filenames only differing in case are
+// reported instead of overwriting each other
+func TestCaseCollisions(t *testing.T) {
+	if variant, ok := caseVariant("Solve Issue.chatmode.md", []string{"A.chatmode.md", "solve issue.chatmode.md"}); !ok || variant != "solve issue.chatmode.md" {
+		t.Errorf("Expected the lowercase variant, got %q, %t", variant, ok)
+	}
+	if _, ok := caseVariant("Solve Issue.chatmode.md", []string{"solve issue.chatmode.md", "Solve Issue.chatmode.md"}); ok {
+		t.Error("A file that exists under the exact name is no variant")
+	}
+
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	sourceDir := t.TempDir()
+	content := []byte("---\ndescription: test\n---\n")
+	for dir, names := range map[string][]string{
+		matesDir:   {"Solve Issue.chatmode.md", "Testing.chatmode.md"},
+		promptsDir: {"solve issue.chatmode.md"},
+		sourceDir:  {"testing.chatmode.md", "Deploy.chatmode.md"},
+	} {
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+		}
+	}
+
+	var errOut bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Output: io.Discard, ErrOutput: &errOut,
+		Sources: []Source{&DirectorySource{Dir: sourceDir}}}
+	cm.installer = NewInstallerService(cm)
+	cm.validator = NewValidatorService(cm)
+
+	available, err := cm.GetAvailableChatmates()
+	if err != nil || !slices.Equal(available, []string{"Deploy.chatmode.md", "Solve Issue.chatmode.md", "Testing.chatmode.md"}) {
+		t.Errorf("Expected the case variant of a source to be ignored, got %v, %v", available, err)
+	}
+	if !strings.Contains(errOut.String(), "Ignoring testing.chatmode.md") {
+		t.Errorf("Expected a warning about the ignored variant, got %q", errOut.String())
+	}
+
+	plan, err := cm.Installer().PlanInstall(true)
+	if err != nil {
+		t.Fatalf("PlanInstall failed: %v", err)
+	}
+	if len(plan.Collisions) != 1 || plan.Collisions[0] != (CaseCollision{Filename: "Solve Issue.chatmode.md", Installed: "solve issue.chatmode.md"}) {
+		t.Errorf("Expected the collision in the plan, got %+v", plan.Collisions)
+	}
+	if len(plan.Install) != 2 || len(plan.Preserve) != 0 {
+		t.Errorf("Expected Deploy and Testing to be installed and nothing preserved, got %+v", plan)
+	}
+
+	sync, err := cm.Installer().PlanSync(true)
+	if err != nil || len(sync.Collisions) != 1 || len(sync.Prune)+len(sync.Orphaned)+len(sync.Preserved) != 0 {
+		t.Errorf("Expected sync to keep the variant, got %+v, %v", sync, err)
+	}
+
+	report, err := cm.Installer().InstallSpecific(context.Background(), []string{"Solve Issue"}, true)
+	if err != nil || report.Count(InstallSkipped) != 1 {
+		t.Fatalf("Expected the colliding chatmate to be skipped, got %+v, %v", report, err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Solve Issue.chatmode.md")); err == nil {
+		t.Error("The colliding chatmate must not be written")
+	}
+
+	validation, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	index := slices.IndexFunc(validation.Checks, func(check CheckResult) bool { return check.Name == "case-collisions" })
+	if index < 0 || validation.Checks[index].Status != CheckWarn || !slices.Equal(validation.Checks[index].Files, []string{"solve issue.chatmode.md"}) {
+		t.Errorf("Expected a case-collisions warning, got %+v", validation.Checks)
+	}
+
+	// State is recorded under the name the file has in the prompts directory
+	if name := cm.canonicalFilename("Solve Issue.chatmode.md"); name != "solve issue.chatmode.md" {
+		t.Errorf("Expected the stored name, got %q", name)
+	}
+	if name := cm.canonicalFilename("Testing.chatmode.md"); name != "Testing.chatmode.md" {
+		t.Errorf("Expected a new file to keep its name, got %q", name)
+	}
+	cm.provenancePath = filepath.Join(t.TempDir(), state.ProvenanceFilename)
+	cm.recordProvenance("Solve Issue.chatmode.md", state.SourceEmbedded, "", content)
+	if _, ok := cm.Provenance("solve issue.chatmode.md"); !ok {
+		t.Error("Expected the provenance to be recorded under the stored name")
+	}
+}
+
+// nfdFS stores filenames in Unicode NFD and finds files in any
+// normalization, as HFS+ on macOS does.
+type nfdFS struct {
+	*files.MemFS
+}
+
+func (n nfdFS) ReadFile(path string) ([]byte, error) { return n.MemFS.ReadFile(norm.NFD.String(path)) }
+
+func (n nfdFS) Stat(path string) (fs.FileInfo, error) {
+	return n.MemFS.Stat(norm.NFD.String(path))
+}
+
+func (n nfdFS) ReadDir(path string) ([]fs.DirEntry, error) {
+	return n.MemFS.ReadDir(norm.NFD.String(path))
+}
+
+func (n nfdFS) Remove(path string) error { return n.MemFS.Remove(norm.NFD.String(path)) }
+
+func (n nfdFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return n.MemFS.WriteFile(norm.NFD.String(path), data, perm)
+}
+
+func (n nfdFS) Rename(oldPath, newPath string) error {
+	return n.MemFS.Rename(norm.NFD.String(oldPath), norm.NFD.String(newPath))
+}
+
+// TestUnicodeNormalization tests that chatmates whose filename the
+// filesystem stores in NFD are recognized as installed
+func TestUnicodeNormalization(t *testing.T) {
+	const nfc = "Caf\u00e9 Helper.chatmode.md"
+	nfd := norm.NFD.String(nfc)
+
+	fsys := nfdFS{files.NewMemFS()}
+	root := filepath.Join(string(filepath.Separator), "chatmate-nfd-test")
+	cm := &ChatMateManager{MatesDir: filepath.Join(root, "mates"), PromptsDir: filepath.Join(root, "prompts"),
+		FS: files.Policy{Backend: fsys}, Output: io.Discard, ErrOutput: io.Discard,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	if err := fsys.MkdirAll(cm.MatesDir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := fsys.WriteFile(filepath.Join(cm.MatesDir, nfc), []byte("---\ndescription: Coffee\n---\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if entries, _ := fsys.MemFS.ReadDir(cm.MatesDir); len(entries) != 1 || entries[0].Name() != nfd {
+		t.Fatalf("Expected the filesystem to store the name in NFD, got %v", entries)
+	}
+
+	// The chatmate was copied into the prompts directory by hand
+	if err := fsys.MkdirAll(cm.PromptsDir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := fsys.WriteFile(filepath.Join(cm.PromptsDir, nfc), []byte("---\ndescription: Coffee\n---\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	available, err := cm.GetAvailableChatmates()
+	if err != nil || !slices.Equal(available, []string{nfc}) {
+		t.Errorf("Expected the available chatmate in NFC, got %q, %v", available, err)
+	}
+	if filename, found := cm.findChatmate(norm.NFD.String("Caf\u00e9 Helper"), available); !found || filename != nfc {
+		t.Errorf("Expected a name in NFD to match, got %q, %v", filename, found)
+	}
+	if installed, err := cm.GetInstalledChatmates(); err != nil || !slices.Equal(installed, []string{nfc}) {
+		t.Errorf("Expected the installed chatmate in NFC, got %q, %v", installed, err)
+	}
+	plan, err := cm.Installer().PlanInstall(false)
+	if err != nil || len(plan.Install) != 0 || len(plan.Collisions) != 0 {
+		t.Errorf("Expected the installed chatmate to be recognized, got %+v, %v", plan, err)
+	}
+	cm.recordProvenance(nfd, state.SourceDirectory, cm.MatesDir, []byte("---\ndescription: Coffee\n---\n"))
+	for _, filename := range []string{nfc, nfd} {
+		if record, ok := cm.Provenance(filename); !ok || record.Filename != nfc {
+			t.Errorf("Expected the provenance of %q under its NFC name, got %+v", filename, record)
+		}
+	}
+
+	// Filesystems that tell both forms apart keep the name as it is
+	memFS := files.NewMemFS()
+	if err := memFS.MkdirAll(root, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := memFS.WriteFile(filepath.Join(root, nfd), []byte("---\ndescription: Coffee\n---\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if names, err := scanChatmateDir(memFS, root); err != nil || !slices.Equal(names, []string{nfd}) {
+		t.Errorf("Expected the NFD name to be kept, got %q, %v", names, err)
+	}
+}
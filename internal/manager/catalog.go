@@ -0,0 +1,388 @@
+// Package manager provides access to the chatmate registry for ChatMate agents.
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/security"
+)
+
+// CatalogEntry is a chatmate that can be installed, either shipped with
+// ChatMate or published in the registry.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename
+//   - Description: What the chatmate does
+//   - Author: Who published it, if known
+//   - Source: state.SourceRegistry for registry chatmates, otherwise where
+//     the shipped chatmates are read from (see state.SourceEmbedded)
+//   - Installed: Whether a file with this name is installed
+//   - Remote: The registry entry; nil for shipped chatmates
+type CatalogEntry struct {
+	Name        string
+	Filename    string
+	Description string
+	Author      string
+	Source      string
+	Installed   bool
+	Remote      *registry.Entry
+}
+
+// RegistryIndex returns the index of the chatmate registry.
+//
+// The index is cached (see registry.Client.Index); when the registry cannot
+// be reached, the last cached index is used and a warning is printed.
+//
+// Parameters:
+//   - ctx: Cancels the request (e.g., the command's --timeout)
+//   - refresh: Whether to fetch the index even if the cached copy is fresh
+//
+// Returns:
+//   - *registry.Index: The registry index
+//   - error: Registry disabled, unreachable without a cached copy, or invalid
+func (cm *ChatMateManager) RegistryIndex(ctx context.Context, refresh bool) (*registry.Index, error) {
+	if cm.Registry == nil {
+		return nil, errors.New("the chatmate registry is not configured")
+	}
+
+	index, err := cm.Registry.Index(ctx, refresh)
+	if err != nil {
+		return nil, err
+	}
+	if index.Stale {
+		cm.out().Warnf("Could not reach the chatmate registry; using the list from %s", output.FormatDateTime(index.FetchedAt))
+	}
+	return index, nil
+}
+
+// Catalog lists every chatmate that can be installed: the chatmates shipped
+// with ChatMate merged with the ones published in the registry.
+//
+// A registry chatmate with the same filename as a shipped one is left out,
+// so 'chatmate hire' and 'chatmate browse' always agree on what a name
+// refers to. When the registry cannot be read at all, a warning is printed
+// and only the shipped chatmates are listed.
+//
+// Parameters:
+//   - ctx: Cancels the registry request
+//   - refresh: Whether to fetch the registry index even if the cached copy
+//     is fresh
+//
+// Returns:
+//   - []CatalogEntry: The chatmates, sorted by display name
+//   - error: Chatmate source error
+//
+// Example:
+//
+// catalog, err := manager.Catalog(ctx, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to list chatmates: %w", err)
+//	}
+func (cm *ChatMateManager) Catalog(ctx context.Context, refresh bool) ([]CatalogEntry, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+	shipped, err := cm.AvailableMetadata()
+	if err != nil {
+		return nil, err
+	}
+	index, err := cm.RegistryIndex(ctx, refresh)
+	if err != nil {
+		cm.out().Warnf("Registry chatmates are not listed: %v", err)
+		index = &registry.Index{}
+	}
+
+	var catalog []CatalogEntry
+	for _, meta := range shipped {
+		source, _ := cm.chatmateSource(meta.Filename)
+		catalog = append(catalog, CatalogEntry{
+			Name:        meta.Name,
+			Filename:    meta.Filename,
+			Description: meta.Description,
+			Author:      meta.Author.String(),
+			Source:      source,
+			Installed:   inventory.IsInstalled(meta.Filename),
+		})
+	}
+	for _, entry := range index.Chatmates {
+		if inventory.IsAvailable(entry.Filename) {
+			continue
+		}
+		catalog = append(catalog, CatalogEntry{
+			Name:        entry.Name,
+			Filename:    entry.Filename,
+			Description: entry.Description,
+			Author:      entry.Author,
+			Source:      state.SourceRegistry,
+			Installed:   inventory.IsInstalled(entry.Filename),
+			Remote:      &entry,
+		})
+	}
+
+	sort.Slice(catalog, func(a, b int) bool {
+		return strings.ToLower(catalog[a].Name) < strings.ToLower(catalog[b].Name)
+	})
+	return catalog, nil
+}
+
+// Browse returns the chatmates that can be installed, including the ones
+// published in the registry.
+//
+// Parameters:
+//   - ctx: Cancels the registry request
+//   - searchTerm: Only chatmates whose name or description contains it are
+//     returned; all chatmates when empty
+//   - refresh: Whether to fetch the registry index even if the cached copy
+//     is fresh
+//
+// Returns:
+//   - []CatalogEntry: The matching chatmates, sorted by name
+//   - error: Chatmate source error
+func (l *ListerService) Browse(ctx context.Context, searchTerm string, refresh bool) ([]CatalogEntry, error) {
+	catalog, err := l.manager.Catalog(ctx, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	searchLower := strings.ToLower(searchTerm)
+	var matches []CatalogEntry
+	for _, entry := range catalog {
+		if strings.Contains(strings.ToLower(entry.Name), searchLower) ||
+			strings.Contains(strings.ToLower(entry.Description), searchLower) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches, nil
+}
+
+// InstallFromRegistry downloads chatmates published in the registry and
+// installs them.
+//
+// Downloads are verified against the checksum listed in the registry index,
+// and the download URL is recorded as the provenance of each chatmate.
+// Chatmates that are already installed are skipped unless force is set;
+// replacing one with a release the registry marks as breaking shows its
+// changelog and asks first (see AcceptBreaking).
+//
+// Parameters:
+//   - ctx: Cancels the registry requests, and stops the installation before
+//     the next chatmate once done
+//   - names: Display names or filenames of registry chatmates
+//   - force: If true, overwrites installed chatmates with the same filename
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: Unknown name, registry, validation, or file operation error
+//
+// Example:
+//
+// report, err := installer.InstallFromRegistry(ctx, []string{"Rust Reviewer"}, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("registry installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromRegistry(ctx context.Context, names []string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.installFromRegistry(ctx, names, force) })
+}
+
+// installFromRegistry installs chatmates from the registry, without running the hooks around it.
+func (i *InstallerService) installFromRegistry(ctx context.Context, names []string, force bool) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	index, err := i.manager.RegistryIndex(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve every name first so a typo doesn't leave a partial installation
+	entries := make([]registry.Entry, 0, len(names))
+	for _, name := range names {
+		entry, ok := index.Find(name)
+		if !ok {
+			return nil, fmt.Errorf("chatmate %q is not in the registry (run 'chatmate browse' to see what is available)", name)
+		}
+		entries = append(entries, entry)
+	}
+	return i.installEntries(ctx, start, entries, force, false)
+}
+
+// Pin selects the release of a chatmate to install instead of its current
+// version.
+//
+// Fields:
+//   - Name: Display name or filename of the chatmate
+//   - Version: The version to install; AsOf is used when empty
+//   - AsOf: Install the latest version released at or before this time;
+//     the current version when zero and Version is empty
+type Pin struct {
+	Name    string
+	Version string
+	AsOf    time.Time
+}
+
+// ParsePin splits a chatmate name with a version, as in "Solve Issue@1.4.0",
+// into a Pin. A name without "@" selects the current version.
+func ParsePin(arg string) Pin {
+	if at := strings.LastIndex(arg, "@"); at > 0 {
+		return Pin{Name: arg[:at], Version: arg[at+1:]}
+	}
+	return Pin{Name: arg}
+}
+
+// String returns the pin as written on the command line.
+func (p Pin) String() string {
+	switch {
+	case p.Version != "":
+		return p.Name + "@" + p.Version
+	case !p.AsOf.IsZero():
+		return p.Name + " as of " + p.AsOf.Format(time.DateOnly)
+	}
+	return p.Name
+}
+
+// InstallPinned installs earlier releases of chatmates, downloaded from the
+// registry, such as the wording of a chatmate before an update changed its
+// behavior.
+//
+// Any chatmate the registry publishes releases for can be pinned, including
+// the ones shipped with ChatMate. The download is verified against the
+// checksum the registry lists for the release, and the release URL is
+// recorded as the provenance, so 'chatmate update' and 'chatmate sync' keep
+// the pinned version (see UpdatePinned). Chatmates that are already
+// installed are skipped unless force is set; replacing one with a later
+// release the registry marks as breaking asks first (see AcceptBreaking).
+//
+// Parameters:
+//   - ctx: Cancels the registry requests, and stops the installation before
+//     the next chatmate once done
+//   - pins: The chatmates and the releases to install
+//   - force: If true, overwrites installed chatmates with the same filename
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: Unknown name or version, registry, validation, or file
+//     operation error
+//
+// Example:
+//
+// report, err := installer.InstallPinned(ctx, []Pin{ParsePin("Solve Issue@1.4.0")}, true)
+//
+//	if err != nil {
+//	   return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallPinned(ctx context.Context, pins []Pin, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.installPinned(ctx, pins, force) })
+}
+
+// installPinned installs releases from the registry, without running the hooks around it.
+func (i *InstallerService) installPinned(ctx context.Context, pins []Pin, force bool) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	index, err := i.manager.RegistryIndex(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve every release first so a typo doesn't leave a partial installation
+	entries := make([]registry.Entry, 0, len(pins))
+	for _, pin := range pins {
+		entry, ok := index.Find(pin.Name)
+		if !ok {
+			return nil, fmt.Errorf("chatmate %q has no releases in the registry", pin.Name)
+		}
+		switch {
+		case pin.Version != "":
+			entry, err = entry.Release(pin.Version)
+		case !pin.AsOf.IsZero():
+			entry, err = entry.AsOf(pin.AsOf)
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return i.installEntries(ctx, start, entries, force, true)
+}
+
+// installEntries downloads and installs registry chatmates, reporting the
+// results recorded since start. With pinned, the outcome names the version
+// that was installed.
+func (i *InstallerService) installEntries(ctx context.Context, start int, entries []registry.Entry, force, pinned bool) (*InstallReport, error) {
+	if err := i.manager.ensurePromptsDir(); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if err := interrupted(ctx, "installation"); err != nil {
+			return i.reportSince(start), err
+		}
+		if err := security.ValidateChatmateFilename(entry.Filename); err != nil {
+			return i.reportSince(start), errorf(ErrValidationFailed, "security validation failed: %w", err)
+		}
+		if !security.IsPathSafe(i.manager.PromptsDir, entry.Filename) {
+			return i.reportSince(start), fmt.Errorf("destination path is not safe: %s", entry.Filename)
+		}
+
+		destPath := filepath.Join(i.manager.PromptsDir, entry.Filename)
+		status := InstallInstalled
+		if _, err := i.manager.FS.Stat(destPath); err == nil {
+			if !force {
+				i.record(entry.Filename, InstallSkipped, "already installed")
+				continue
+			}
+			status = InstallReinstalled
+		}
+
+		if status == InstallReinstalled {
+			installed, err := i.manager.FS.ReadFile(destPath)
+			if err == nil && !i.acceptBreaking(entry.Filename, entry.Version, breakingReleases(entry, frontmatterVersion(installed), entry.Version)) {
+				i.record(entry.Filename, InstallSkipped, "breaking changes not accepted")
+				continue
+			}
+		}
+
+		content, err := i.manager.Registry.Download(ctx, entry)
+		if err != nil {
+			return i.reportSince(start), err
+		}
+		if err := i.manager.checkFrontmatter(entry.Name, content); err != nil {
+			return i.reportSince(start), err
+		}
+		if status == InstallInstalled {
+			if ok, err := i.approved(entry.Filename, state.SourceRegistry, entry.URL, content); !ok {
+				if err != nil {
+					return i.reportSince(start), err
+				}
+				continue
+			}
+		}
+
+		if written, err := i.manager.writeChatmate(entry.Filename, content); !written {
+			if err != nil {
+				return i.reportSince(start), err
+			}
+			continue
+		}
+		i.manager.invalidateInventory()
+		i.manager.recordProvenance(entry.Filename, state.SourceRegistry, entry.URL, content)
+
+		detail := string(status) + " from the registry"
+		if pinned && entry.Version != "" {
+			detail = fmt.Sprintf("%s version %s from the registry", status, entry.Version)
+		}
+		i.record(entry.Filename, status, detail)
+	}
+	return i.reportSince(start), nil
+}
@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestRegistry tests merging registry chatmates into the catalog and installing them
+func TestRegistry(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	shipped := []byte("---\ndescription: shipped\n---\n")
+	remote := []byte("---\ndescription: Reviews Rust code\n---\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - Testing.chatmode.md"), shipped, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			fmt.Fprintf(w, `{"chatmates": [
+				{"name": "Rust Reviewer", "filename": "Rust Reviewer.chatmode.md", "author": "Jane", "url": "rust.chatmode.md", "sha256": %q},
+				{"name": "Testing", "filename": "Chatmate - Testing.chatmode.md", "url": "testing.chatmode.md", "sha256": %q}]}`,
+				checksum(remote), checksum(remote))
+		case "/rust.chatmode.md":
+			_, _ = w.Write(remote)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		Registry:       &registry.Client{URL: server.URL + "/index.json", HTTP: server.Client()},
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+
+	// Shipped chatmates take precedence over registry entries with the same file
+	catalog, err := cm.Catalog(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Catalog failed: %v", err)
+	}
+	if len(catalog) != 2 || catalog[0].Name != "Rust Reviewer" || catalog[0].Source != state.SourceRegistry ||
+		catalog[1].Filename != "Chatmate - Testing.chatmode.md" || catalog[1].Remote != nil {
+		t.Errorf("Unexpected catalog: %+v", catalog)
+	}
+
+	if _, err := cm.Installer().InstallFromRegistry(context.Background(), []string{"Missing"}, false); err == nil {
+		t.Error("Expected an error for a chatmate that is not in the registry")
+	}
+	if _, err := cm.Installer().InstallFromRegistry(context.Background(), []string{"rust reviewer"}, false); err != nil {
+		t.Fatalf("InstallFromRegistry failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(promptsDir, "Rust Reviewer.chatmode.md"))
+	if err != nil || string(content) != string(remote) {
+		t.Errorf("Expected the registry chatmate to be installed, got %q, %v", content, err)
+	}
+	provenance, ok := cm.Provenance("Rust Reviewer.chatmode.md")
+	if !ok || provenance.Source != state.SourceRegistry || provenance.Location != server.URL+"/rust.chatmode.md" {
+		t.Errorf("Unexpected provenance: %+v", provenance)
+	}
+
+	catalog, err = cm.Catalog(context.Background(), false)
+	if err != nil || !catalog[0].Installed {
+		t.Errorf("Expected the registry chatmate to be installed in the catalog, got %+v, %v", catalog, err)
+	}
+}
+
+// TestInstallPinned tests installing an earlier release of a shipped chatmate
+// and keeping it through updates
+func TestInstallPinned(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	current := []byte("---\ndescription: test\nversion: '1.5.0'\n---\nNew\n")
+	old := []byte("---\ndescription: test\nversion: '1.4.0'\n---\nOld\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Solve Issue.chatmode.md"), current, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			fmt.Fprintf(w, `{"chatmates": [{"name": "Solve Issue", "filename": "Solve Issue.chatmode.md", "version": "1.5.0",
+				"url": "current.chatmode.md", "sha256": %q,
+				"versions": [{"version": "1.4.0", "released": "2024-11-02T00:00:00Z", "url": "old.chatmode.md", "sha256": %q}]}]}`,
+				checksum(current), checksum(old))
+		case "/old.chatmode.md":
+			_, _ = w.Write(old)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		Registry:       &registry.Client{URL: server.URL + "/index.json", HTTP: server.Client()},
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+
+	if err := cm.Installer().InstallChatmate("Solve Issue.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if _, err := cm.Installer().InstallPinned(context.Background(), []Pin{ParsePin("Solve Issue@2.0.0")}, true); err == nil {
+		t.Error("Expected an error for a version that is not published")
+	}
+
+	pin := Pin{Name: "Solve Issue", AsOf: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)}
+	report, err := cm.Installer().InstallPinned(context.Background(), []Pin{pin}, true)
+	if err != nil {
+		t.Fatalf("InstallPinned failed: %v", err)
+	}
+	if report.Results[0].Detail != "reinstalled version 1.4.0 from the registry" {
+		t.Errorf("Unexpected result: %+v", report.Results)
+	}
+	if content, _ := os.ReadFile(filepath.Join(promptsDir, "Solve Issue.chatmode.md")); string(content) != string(old) {
+		t.Errorf("Expected the earlier release to be installed, got %q", content)
+	}
+	cm.invalidateInventory()
+
+	// The pinned release is kept unless it is replaced with force
+	updates, err := cm.Installer().CheckUpdates()
+	if err != nil || len(updates) != 1 || updates[0].State != UpdatePinned || updates[0].Versions() != "1.4.0 → 1.5.0" {
+		t.Errorf("Expected the chatmate to be pinned, got %+v, %v", updates, err)
+	}
+	if plan, err := cm.Installer().PlanSync(false); err != nil || len(plan.Pinned) != 1 || plan.Changes() != 0 {
+		t.Errorf("Expected sync to keep the pinned chatmate, got %+v, %v", plan, err)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 0 {
+		t.Errorf("Expected the pinned chatmate to be kept, got %d, %v", updated, err)
+	}
+	if updated, err := cm.Installer().Update(context.Background(), nil, true, false); err != nil || updated != 1 {
+		t.Errorf("Expected --force to replace the pinned chatmate, got %d, %v", updated, err)
+	}
+}
+
+// TestParsePin tests reading versions from chatmate names
+func TestParsePin(t *testing.T) {
+	tests := map[string]Pin{
+		"Solve Issue":       {Name: "Solve Issue"},
+		"Solve Issue@1.4.0": {Name: "Solve Issue", Version: "1.4.0"},
+		"user@host@v2":      {Name: "user@host", Version: "v2"},
+		"@scope":            {Name: "@scope"},
+	}
+	for arg, expected := range tests {
+		if pin := ParsePin(arg); pin != expected {
+			t.Errorf("ParsePin(%q) = %+v; expected %+v", arg, pin, expected)
+		}
+	}
+}
@@ -9,8 +9,9 @@
 //   - ChatMateManager: Main service for chatmate operations
 //   - InstallerService: Handles chatmate installation operations
 //   - UninstallerService: Handles chatmate removal operations
-//   - ListerService: Handles chatmate listing and display
+//   - ListerService: Handles chatmate listings and details
 //   - ValidatorService: Handles validation and status checking
+//   - AdopterService: Handles bringing existing prompt files under management
 //
 // Usage Example:
 //
@@ -21,28 +22,48 @@
 //	}
 //
 // // Install all available chatmates
-// err = manager.Installer().InstallAll(false)
+// report, err := manager.Installer().InstallAll(context.Background(), false)
 //
 //	if err != nil {
 //	   log.Fatal(err)
 //	}
 //
+//	fmt.Printf("Installed %d chatmates\n", report.Installed())
+//
 // // List installed chatmates
-// err = manager.Lister().ListInstalled()
+// listing, err := manager.Lister().Listing(false, true)
 //
 //	if err != nil {
 //	   log.Fatal(err)
 //	}
+//
+// The services return typed results and leave presentation to the caller;
+// the commands render them with the cmd/view package.
 package manager
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/jonassiebler/chatmate/internal/assets"
-	"github.com/jonassiebler/chatmate/pkg/utils"
+	"github.com/jonassiebler/chatmate/internal/cache"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/policy"
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/jonassiebler/chatmate/internal/shared"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
 )
 
 // ChatMateManager handles the core functionality for managing chatmate agents.
@@ -58,20 +79,140 @@ import (
 // Fields:
 //   - ScriptDir: Directory containing the ChatMate executable and resources
 //   - MatesDir: Directory containing chatmate source files (.chatmode.md)
-//   - PromptsDir: VS Code user prompts directory where chatmates are installed
+//   - PromptsDir: VS Code user prompts directory where chatmates are installed,
+//     with symlinks resolved
+//   - ConfiguredPromptsDir: The prompts directory before symlinks were resolved,
+//     when it differs from PromptsDir
 //   - UseEmbedded: Whether to use embedded chatmate resources or external files
+//   - NoCache: Whether to ignore the cached inventory and always recompute it
+//   - Headless: Whether chatmates are managed in a generic prompts directory
+//     because VS Code is not available (see HeadlessEnv)
+//   - Editor: The VS Code build or fork whose prompts directory chatmates are
+//     installed into (see EditorEnv and UseEditor); VS Code when zero
+//   - Workspace: The root of the workspace whose .github/prompts directory
+//     chatmates are managed in (see UseWorkspace); empty for the user
+//     prompts directory
+//   - FS: Timeout and retry policy for operations on the prompts directory,
+//     which may be on a slow network drive; the zero value has no timeout
+//   - Version: ChatMate version recorded as the installer in the provenance
+//     of installed chatmates
+//   - Registry: The registry of community chatmates offered by browse and
+//     installed with hire --from-registry; the registry is disabled when nil
+//   - Sources: Further sources of available chatmates (see ParseSource),
+//     looked up after the built-in one in order; a chatmate is read from
+//     the first source that provides it
+//   - Shared: Whether the prompts directory is shared by several users, so
+//     changes are locked and chatmate owners are recorded (see package shared)
+//   - Progress: Called with the outcome of every chatmate as it is installed,
+//     skipped, or queued for approval; nothing is reported when nil
+//   - Output: Receives the informational and diagnostic output of the
+//     services; standard output (see output.Stdout) when nil
+//   - ErrOutput: Receives warnings and other error output of the services;
+//     standard error (see output.Stderr) when nil
 type ChatMateManager struct {
 	ScriptDir   string
 	MatesDir    string
 	PromptsDir  string
 	UseEmbedded bool
+	NoCache     bool
+	Headless    bool
+	Editor      platform.EditorFlavor
+	Workspace   string
+	FS          files.Policy
+	Version     string
+	Registry    *registry.Client
+	Sources     []Source
+	Shared      bool
+	Progress    func(InstallResult)
+	Output      io.Writer
+	ErrOutput   io.Writer
+
+	ConfiguredPromptsDir string
+
+	// Whether the prompts directory is archived before destructive
+	// operations, and how many archives are kept (0 keeps all of them)
+	AutoBackup bool
+	BackupKeep int
+
+	// Maximum number of prompts directory entries scanned for installed
+	// chatmates (0 scans all of them), and whether the scan already warned
+	// about the size of the directory
+	ScanLimit  int
+	scanWarned bool
+
+	// Number of chatmates bulk installations install at the same time; 1
+	// or less installs them one after another
+	Concurrency int
+
+	// Permissions of the chatmate files, backups, and directories ChatMate
+	// creates, before the umask; zero for DefaultFileMode and DefaultDirMode
+	FileMode fs.FileMode
+	DirMode  fs.FileMode
+
+	// Shell commands run before and after installations (see withHooks),
+	// how long a hook may run, and whether the hooks chatmates declare in
+	// their frontmatter run at all
+	PreInstallHook  string
+	PostInstallHook string
+	HookTimeout     time.Duration
+	ChatmateHooks   bool
+
+	// Why the prompts directory cannot be used (e.g., a broken symlink)
+	promptsDirErr error
+
+	// Location of the cached inventory; caching is disabled when empty
+	inventoryPath string
+	// Inventory shared by all services within a run, and whether the cache
+	// file was already removed since it was last written
+	inventory      *cache.Inventory
+	inventoryStale bool
+	// Content of available chatmates read during this run, by source (see
+	// GetChatmateContent)
+	contents   map[string]sourceContent
+	contentsMu sync.Mutex
+	// The configured source each available chatmate is read from, once
+	// the sources were listed (see sourceOf)
+	origins   map[string]Source
+	sourcesMu sync.Mutex
+	// Serializes the steps of concurrent installations that read or change
+	// the prompts directory listing or the state files
+	installMu sync.Mutex
+
+	// Location of the registry of user-managed chatmates; adoption is
+	// disabled when empty
+	registryPath string
+	// Location of the bulk operation checkpoint; resuming is disabled when empty
+	checkpointPath string
+	// Location of the provenance log; provenance is not tracked when empty
+	provenancePath string
+	// Location of the activity log; changes are not logged when empty
+	activityPath string
+	// Location of the team policy; nothing is required when empty
+	policyPath string
+	// Location of the configuration file; it is not validated when empty
+	configPath string
+	// Location of the queue of installations awaiting approval; requests
+	// are not remembered when empty
+	approvalsPath string
+	// Directory of the archives of the prompts directory; backups are
+	// disabled when empty
+	backupsDir string
+	// Directory of the previous versions of replaced chatmates; versions
+	// are not kept when empty
+	versionsDir string
+	// Records changes instead of making them in a dry run (see DryRun);
+	// nil otherwise
+	dryRun *files.DryRunFS
 
 	// Service instances for modular functionality
-	installer   *InstallerService
-	uninstaller *UninstallerService
-	lister      *ListerService
-	validator   *ValidatorService
-	status      *StatusService
+	installer      *InstallerService
+	uninstaller    *UninstallerService
+	lister         *ListerService
+	validator      *ValidatorService
+	status         *StatusService
+	adopter        *AdopterService
+	troubleshooter *TroubleshooterService
+	doctor         *DoctorService
 }
 
 // NewChatMateManager creates a new ChatMateManager instance with automatic configuration.
@@ -84,7 +225,15 @@ type ChatMateManager struct {
 //   - Fallback: Uses current working directory
 //
 // The manager automatically detects the VS Code user prompts directory based on
-// the operating system and creates it if it doesn't exist.
+// the operating system and creates it if it doesn't exist. Another VS Code
+// build or fork is used when it is chosen with EditorEnv, or when it is
+// installed and VS Code is not. In headless mode (see HeadlessEnv) the
+// generic prompts directory is used instead.
+//
+// PromptsDirEnv and MatesDirEnv override the detected prompts directory and
+// mates directory, e.g. for CI. The global flags and the configuration file
+// are applied by the caller: flags take precedence over these variables,
+// which take precedence over the configuration file.
 //
 // Returns:
 //   - *ChatMateManager: Configured manager instance
@@ -127,10 +276,39 @@ func NewChatMateManager() (*ChatMateManager, error) {
 	}
 
 	matesDir := filepath.Join(scriptDir, "mates")
+	if dir := os.Getenv(MatesDirEnv); dir != "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", MatesDirEnv, err)
+		}
+		if info, err := os.Stat(absDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("invalid %s: %s is not a directory", MatesDirEnv, absDir)
+		}
+		matesDir = absDir
+		useEmbedded = false
+	}
 
-	promptsDir, err := utils.GetVSCodePromptsDir()
+	editorID := os.Getenv(EditorEnv)
+	editor, err := selectEditor(editorID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get VS Code prompts directory: %w", err)
+		return nil, fmt.Errorf("invalid %s: %w", EditorEnv, err)
+	}
+	headless := headlessMode(editor, editorID != "")
+
+	var promptsDir string
+	if headless {
+		promptsDir, err = platform.GetHeadlessPromptsDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get headless prompts directory: %w", err)
+		}
+	} else {
+		promptsDir = editor.PromptsDir
+	}
+	if dir := os.Getenv(PromptsDirEnv); dir != "" {
+		if promptsDir, err = filepath.Abs(dir); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", PromptsDirEnv, err)
+		}
+		headless = false
 	}
 
 	// Create manager instance
@@ -139,6 +317,51 @@ func NewChatMateManager() (*ChatMateManager, error) {
 		MatesDir:    matesDir,
 		PromptsDir:  promptsDir,
 		UseEmbedded: useEmbedded,
+		Headless:    headless,
+		Editor:      editor,
+		AutoBackup:  true,
+		BackupKeep:  DefaultBackupKeep,
+		ScanLimit:   DefaultScanLimit,
+		Concurrency: DefaultConcurrency,
+		HookTimeout: DefaultHookTimeout,
+	}
+	manager.FS = manager.filesystemPolicy()
+	manager.setPromptsDir(promptsDir)
+
+	// The inventory cache is optional; without a cache directory it is recomputed
+	if inventoryPath, err := cache.InventoryPath(); err == nil {
+		manager.inventoryPath = inventoryPath
+	}
+	if registryPath, err := state.RegistryPath(); err == nil {
+		manager.registryPath = registryPath
+	}
+	if checkpointPath, err := state.CheckpointPath(); err == nil {
+		manager.checkpointPath = checkpointPath
+	}
+	if provenancePath, err := state.ProvenancePath(); err == nil {
+		manager.provenancePath = provenancePath
+	}
+	if activityPath, err := state.ActivityPath(); err == nil {
+		manager.activityPath = activityPath
+	}
+	if policyPath, err := policy.Path(); err == nil {
+		manager.policyPath = policyPath
+	}
+	if configPath, err := settings.Path(); err == nil {
+		manager.configPath = configPath
+	}
+	if approvalsPath, err := state.ApprovalsPath(); err == nil {
+		manager.approvalsPath = approvalsPath
+	}
+	if backupsDir, err := state.BackupsDir(); err == nil {
+		manager.backupsDir = backupsDir
+	}
+	if versionsDir, err := state.VersionsDir(); err == nil {
+		manager.versionsDir = versionsDir
+	}
+	manager.Registry = &registry.Client{URL: registry.URL()}
+	if registryCachePath, err := registry.CachePath(); err == nil {
+		manager.Registry.CachePath = registryCachePath
 	}
 
 	// Initialize service modules
@@ -147,10 +370,270 @@ func NewChatMateManager() (*ChatMateManager, error) {
 	manager.lister = NewListerService(manager)
 	manager.validator = NewValidatorService(manager)
 	manager.status = NewStatusService(manager)
+	manager.adopter = NewAdopterService(manager)
+	manager.troubleshooter = NewTroubleshooterService(manager)
+	manager.doctor = NewDoctorService(manager)
 
 	return manager, nil
 }
 
+// HeadlessEnv is the environment variable that controls headless mode.
+//
+// Set it to "1" to always manage chatmates in the generic prompts directory,
+// or to "0" to always use the VS Code prompts directory. When it is unset,
+// headless mode is enabled if VS Code is not detected and the session looks
+// like a server (an SSH session or no display).
+const HeadlessEnv = "CHATMATE_HEADLESS"
+
+// EditorEnv is the environment variable that chooses the VS Code build or
+// fork chatmates are installed for, like the global --editor flag: one of
+// "stable" (VS Code), "insiders", "vscodium", "oss" (Code - OSS), or
+// "cursor" (see platform.EditorIDs). When it is unset, VS Code is used
+// unless it is not installed and one of the others is.
+const EditorEnv = "CHATMATE_EDITOR"
+
+// PromptsDirEnv is the environment variable that sets the prompts directory
+// chatmates are managed in, instead of the one of the editor (see EditorEnv)
+// or the headless one. The promptsDir setting of the configuration file does
+// the same; the variable takes precedence over it.
+const PromptsDirEnv = "CHATMATE_PROMPTS_DIR"
+
+// MatesDirEnv is the environment variable that sets the directory chatmates
+// are installed from, instead of the mates directory next to ChatMate or the
+// chatmates embedded in it.
+const MatesDirEnv = "CHATMATE_MATES_DIR"
+
+// selectEditor returns the editor flavor with the given ID, or detects the
+// one to use when the ID is empty.
+func selectEditor(id string) (platform.EditorFlavor, error) {
+	if id != "" {
+		return platform.GetEditorFlavor(id)
+	}
+
+	flavors, err := platform.GetEditorFlavors()
+	if err != nil {
+		return platform.EditorFlavor{}, fmt.Errorf("failed to get VS Code prompts directory: %w", err)
+	}
+	if platform.DetectEditor(flavors[0]).Found {
+		return flavors[0], nil
+	}
+	for _, flavor := range flavors[1:] {
+		if platform.DetectEditor(flavor).Found {
+			return flavor, nil
+		}
+	}
+	return flavors[0], nil
+}
+
+// headlessMode decides whether chatmates are managed in the generic prompts
+// directory rather than the editor's. An editor chosen explicitly is used
+// even when it is not detected.
+func headlessMode(editor platform.EditorFlavor, chosen bool) bool {
+	switch strings.ToLower(os.Getenv(HeadlessEnv)) {
+	case "1", "true", "yes":
+		return true
+	case "0", "false", "no":
+		return false
+	}
+	if chosen {
+		return false
+	}
+
+	detection := platform.DetectEditor(editor)
+	return !detection.Found && detection.Headless
+}
+
+// UseEditor installs chatmates for a VS Code build or fork instead of the
+// one chosen when the manager was created, e.g. for the global --editor
+// flag. It turns off headless mode.
+//
+// Parameters:
+//   - id: The editor ID (see EditorEnv)
+//
+// Returns:
+//   - error: Error if the editor is unknown
+//
+// Example:
+//
+//	if err := manager.UseEditor("insiders"); err != nil {
+//		return err
+//	}
+func (cm *ChatMateManager) UseEditor(id string) error {
+	editor, err := platform.GetEditorFlavor(id)
+	if err != nil {
+		return err
+	}
+	cm.Editor = editor
+	cm.Headless = false
+	cm.Workspace = ""
+	cm.setPromptsDir(editor.PromptsDir)
+	return nil
+}
+
+// UsePromptsDir manages chatmates in a prompts directory of the user's
+// choice instead of the one of the editor, e.g. for the promptsDir setting of
+// the configuration file. It turns off headless mode.
+//
+// Parameters:
+//   - dir: The prompts directory; relative paths are resolved against the
+//     working directory
+//
+// Returns:
+//   - error: Error if the path cannot be made absolute
+//
+// Example:
+//
+//	if err := manager.UsePromptsDir("~/dotfiles/prompts"); err != nil {
+//		return err
+//	}
+func (cm *ChatMateManager) UsePromptsDir(dir string) error {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve prompts directory %s: %w", dir, err)
+	}
+	cm.Headless = false
+	cm.Workspace = ""
+	cm.setPromptsDir(absDir)
+	return nil
+}
+
+// TargetEditor returns the editor flavor chatmates are installed for.
+func (cm *ChatMateManager) TargetEditor() platform.EditorFlavor {
+	if cm.Editor.Name != "" {
+		return cm.Editor
+	}
+	editor, err := platform.GetEditorFlavor(platform.DefaultEditor)
+	if err != nil {
+		return platform.EditorFlavor{ID: platform.DefaultEditor, Name: "VS Code", Command: "code", PromptsDir: cm.PromptsDir}
+	}
+	return editor
+}
+
+// setPromptsDir sets the prompts directory chatmates are managed in.
+func (cm *ChatMateManager) setPromptsDir(promptsDir string) {
+	cm.PromptsDir = promptsDir
+	cm.ConfiguredPromptsDir = ""
+	cm.promptsDirErr = nil
+	cm.inventory = nil
+
+	// A prompts directory symlinked elsewhere (e.g., into a dotfiles repository)
+	// is operated on at its real location so path checks see a single root
+	if resolved, err := platform.ResolveDir(promptsDir); err != nil {
+		cm.promptsDirErr = err
+	} else if resolved != promptsDir {
+		cm.PromptsDir = resolved
+		cm.ConfiguredPromptsDir = promptsDir
+	}
+	cm.Shared = shared.Enabled(cm.PromptsDir)
+}
+
+// filesystemPolicy returns the timeout and retry policy for prompts directory
+// operations, configured through files.TimeoutEnv and files.RetriesEnv.
+// Retries are announced on the error output so a slow network drive is
+// visible while the operation is still in progress.
+func (cm *ChatMateManager) filesystemPolicy() files.Policy {
+	policy, err := files.PolicyFromEnv(os.Getenv)
+	if err != nil {
+		cm.out().Warnf("%v", err)
+	}
+	policy.OnRetry = func(attempt int, err error) {
+		cm.out().Warnf("%v; retrying (%d/%d)", err, attempt, policy.Retries)
+	}
+	return policy
+}
+
+// RecommendedChatmates are the display names of the chatmates installed by
+// 'chatmate quickstart' and suggested to first-time users.
+var RecommendedChatmates = []string{"Solve Issue", "Review PR", "Testing"}
+
+// SettingsPath returns the VS Code user settings file that belongs to the
+// prompts directory, or an empty string in headless mode. For a workspace
+// it is the workspace settings file, .vscode/settings.json.
+func (cm *ChatMateManager) SettingsPath() string {
+	if cm.Headless {
+		return ""
+	}
+	if cm.Workspace != "" {
+		return filepath.Join(cm.Workspace, ".vscode", "settings.json")
+	}
+
+	// The settings live next to the prompts directory as VS Code sees it,
+	// even when the prompts directory is a symlink into a dotfiles repository
+	promptsDir := cm.PromptsDir
+	if cm.ConfiguredPromptsDir != "" {
+		promptsDir = cm.ConfiguredPromptsDir
+	}
+	return filepath.Join(filepath.Dir(promptsDir), "settings.json")
+}
+
+// PromptsDirLabel returns a description of the prompts directory for output,
+// without claiming a VS Code integration in headless mode.
+func (cm *ChatMateManager) PromptsDirLabel() string {
+	if cm.Headless {
+		return "Prompts Directory (headless)"
+	}
+	if cm.Workspace != "" {
+		return "Workspace Prompts Directory"
+	}
+	return cm.TargetEditor().Name + " Prompts Directory"
+}
+
+// ensurePromptsDir creates the prompts directory if it doesn't exist.
+//
+// It fails without creating anything when the prompts directory cannot be
+// used, such as when it is a symlink to a missing directory.
+func (cm *ChatMateManager) ensurePromptsDir() error {
+	if cm.promptsDirErr != nil {
+		return errorf(ErrPromptsDirMissing, "prompts directory is unusable: %w", cm.promptsDirErr)
+	}
+	if err := cm.FS.EnsureDirMode(cm.PromptsDir, cm.dirMode()); err != nil {
+		return fileErrorf("failed to create prompts directory: %w", err)
+	}
+	return nil
+}
+
+// out returns the printer for the output of the services, which writes to
+// the manager's Output and ErrOutput.
+//
+// Example:
+//
+//	i.manager.out().Warnf("Could not record the provenance of %s: %v", filename, err)
+func (cm *ChatMateManager) out() output.Printer {
+	return output.Printer{Out: cm.Output, Err: cm.ErrOutput}
+}
+
+// bind bounds the file operations of the manager by ctx until the returned
+// function restores the previous context, so an operation that was handed
+// a context stops at the next file operation once it is done.
+//
+// Example:
+//
+//	defer i.manager.bind(ctx)()
+func (cm *ChatMateManager) bind(ctx context.Context) func() {
+	previous := cm.FS.Context
+	cm.FS.Context = ctx
+	return func() { cm.FS.Context = previous }
+}
+
+// context returns the context bound to the manager's file operations (see
+// bind), or the background context when none is.
+func (cm *ChatMateManager) context() context.Context {
+	if cm.FS.Context == nil {
+		return context.Background()
+	}
+	return cm.FS.Context
+}
+
+// interrupted returns an error wrapping the context's error once ctx is
+// done. Operations on several chatmates check it before each one, so
+// cancellation (Ctrl-C) stops them between files rather than within one.
+func interrupted(ctx context.Context, operation string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s interrupted: %w", operation, err)
+	}
+	return nil
+}
+
 // Installer returns the installer service for chatmate installation operations.
 func (cm *ChatMateManager) Installer() *InstallerService {
 	return cm.installer
@@ -176,34 +659,79 @@ func (cm *ChatMateManager) Status() *StatusService {
 	return cm.status
 }
 
+// Adopter returns the adopter service for managing existing prompt files.
+func (cm *ChatMateManager) Adopter() *AdopterService {
+	return cm.adopter
+}
+
+// Troubleshooter returns the troubleshooter service for guided troubleshooting flows.
+func (cm *ChatMateManager) Troubleshooter() *TroubleshooterService {
+	return cm.troubleshooter
+}
+
+// Doctor returns the doctor service for health checks with automatic repairs.
+func (cm *ChatMateManager) Doctor() *DoctorService {
+	return cm.doctor
+}
+
 // GetAvailableChatmates returns all available chatmate files.
 //
-// This method retrieves chatmates from either embedded resources or external files
-// based on the UseEmbedded configuration. It's used by service modules to get
-// the list of chatmates available for operations.
+// This method retrieves chatmates from the built-in source, either embedded
+// resources or external files based on the UseEmbedded configuration, and
+// from the configured Sources. A chatmate provided by several sources is
+// listed once and read from the first of them. A configured source that
+// cannot be read is reported as a warning, so an unreachable server does not
+// stop operations on the other chatmates.
 //
 // Returns:
-//   - []string: List of available chatmate filenames
-//   - error: Directory reading or embedded resource access error
+//   - []string: Sorted list of available chatmate filenames
+//   - error: Directory reading or embedded resource access error of the
+//     built-in source
 func (cm *ChatMateManager) GetAvailableChatmates() ([]string, error) {
-	if cm.UseEmbedded {
-		// Use embedded files
-		return assets.GetEmbeddedMatesList()
-	}
-
-	// Use filesystem files
-	files, err := os.ReadDir(cm.MatesDir)
+	builtin := cm.builtinSource()
+	chatmates, err := builtin.List(cm.context())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read mates directory: %w", err)
+		if !cm.UseEmbedded {
+			err = fmt.Errorf("failed to read mates directory: %w", err)
+		}
+		return nil, err
+	}
+	if len(cm.Sources) == 0 {
+		return chatmates, nil
 	}
 
-	var chatmates []string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".chatmode.md") {
-			chatmates = append(chatmates, file.Name())
+	// Filenames that only differ in case or Unicode normalization would be
+	// installed as the same file on macOS (and in case on Windows)
+	origins := map[string]Source{}
+	seen := map[string]string{}
+	for _, filename := range chatmates {
+		seen[strings.ToLower(files.NormalizeFilename(filename))] = filename
+	}
+	for _, source := range cm.Sources {
+		listed, err := source.List(cm.context())
+		if err != nil {
+			cm.out().Warnf("Could not read chatmates from %s: %v", source.Location(), err)
+			continue
+		}
+		for _, filename := range listed {
+			key := strings.ToLower(files.NormalizeFilename(filename))
+			if first, found := seen[key]; found {
+				if !files.SameFilename(first, filename) {
+					cm.out().Warnf("Ignoring %s from %s: it only differs in case from %s, and both would be installed as the same file on macOS and Windows",
+						filename, source.Location(), first)
+				}
+				continue
+			}
+			seen[key] = filename
+			origins[filename] = source
+			chatmates = append(chatmates, filename)
 		}
 	}
+	sort.Strings(chatmates)
 
+	cm.sourcesMu.Lock()
+	cm.origins = origins
+	cm.sourcesMu.Unlock()
 	return chatmates, nil
 }
 
@@ -217,19 +745,280 @@ func (cm *ChatMateManager) GetAvailableChatmates() ([]string, error) {
 //   - []string: List of installed chatmate filenames
 //   - error: Directory reading or access error
 func (cm *ChatMateManager) GetInstalledChatmates() ([]string, error) {
-	files, err := os.ReadDir(cm.PromptsDir)
+	scan, err := files.Do(cm.FS, "list", cm.PromptsDir, func() (*dirScan, error) {
+		return scanChatmateDirLimit(cm.FS.FileSystem(), cm.PromptsDir, cm.ScanLimit)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errorf(ErrPromptsDirMissing, "failed to read prompts directory: %w", err)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to read prompts directory: %w", err)
+		return nil, fileErrorf("failed to read prompts directory: %w", err)
+	}
+	cm.warnLargePromptsDir(scan)
+
+	return scan.Chatmates, nil
+}
+
+// DefaultScanLimit is the number of prompts directory entries scanned for
+// installed chatmates unless the scanLimit setting says otherwise.
+const DefaultScanLimit = 10000
+
+// LargeDirWarning is the number of entries that are not chatmates above
+// which scanning the prompts directory warns that it is slow.
+const LargeDirWarning = 1000
+
+// warnLargePromptsDir warns, once per manager, when a scan of the prompts
+// directory stopped at ScanLimit or waded through more than LargeDirWarning
+// unrelated files.
+func (cm *ChatMateManager) warnLargePromptsDir(scan *dirScan) {
+	if cm.scanWarned {
+		return
+	}
+	switch other := scan.Entries - len(scan.Chatmates); {
+	case scan.Truncated:
+		cm.out().Warnf("Stopped scanning %s after %d entries; chatmates beyond them are not listed. Raise the limit with 'chatmate config set scanLimit 0'", cm.PromptsDir, scan.Entries)
+	case other > LargeDirWarning:
+		cm.out().Warnf("%s contains %d files that are not chatmates, which makes scanning it slow; consider a dedicated prompts directory (promptsDir setting or $%s)", cm.PromptsDir, other, PromptsDirEnv)
+	default:
+		return
+	}
+	cm.scanWarned = true
+}
+
+// scanBatchSize is the number of directory entries read per batch while scanning.
+const scanBatchSize = 256
+
+// dirScan is the result of scanning a directory for chatmates.
+//
+// Fields:
+//   - Chatmates: The chatmate filenames found, sorted
+//   - Entries: The number of directory entries scanned
+//   - Truncated: Whether the scan stopped at its limit with entries left
+type dirScan struct {
+	Chatmates []string
+	Entries   int
+	Truncated bool
+}
+
+// scanChatmateDir returns the sorted chatmate filenames in dir on fsys.
+func scanChatmateDir(fsys files.FileSystem, dir string) ([]string, error) {
+	scan, err := scanChatmateDirLimit(fsys, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	return scan.Chatmates, nil
+}
+
+// scanChatmateDirLimit scans at most limit entries of dir on fsys for
+// chatmates; a limit of 0 scans all of them.
+//
+// On filesystems that support it (see files.DirOpener), the directory is
+// read in batches and non-chatmate entries are discarded as they are read,
+// so directories shared with thousands of other files never have to be held
+// in memory or sorted as a whole, and reading stops at the limit.
+//
+// Filenames are reported in Unicode NFC (see files.NormalizeFilename) when
+// the filesystem finds the file under that name, as macOS does for the NFD
+// names it stores, so they compare equal to the names of sources and state.
+// On filesystems that tell both forms apart the name is kept as it is.
+func scanChatmateDirLimit(fsys files.FileSystem, dir string, limit int) (*dirScan, error) {
+	scan := &dirScan{}
+	add := func(entries []fs.DirEntry) {
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".chatmode.md") && !entry.IsDir() {
+				scan.Chatmates = append(scan.Chatmates, normalizedName(fsys, dir, entry.Name()))
+			}
+		}
+		scan.Entries += len(entries)
+	}
+
+	opener, ok := fsys.(files.DirOpener)
+	if !ok {
+		entries, err := fsys.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(entries) > limit {
+			entries, scan.Truncated = entries[:limit], true
+		}
+		add(entries)
+		sort.Strings(scan.Chatmates)
+		return scan, nil
+	}
+
+	f, err := opener.OpenDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	for {
+		batch := scanBatchSize
+		if limit > 0 {
+			if scan.Entries == limit {
+				// Stop here, but tell whether anything was left unscanned
+				more, _ := f.ReadDir(1)
+				scan.Truncated = len(more) > 0
+				break
+			}
+			batch = min(batch, limit-scan.Entries)
+		}
+		entries, err := f.ReadDir(batch)
+		add(entries)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	var installed []string
-	for _, file := range files {
-		if !file.IsDir() && strings.HasSuffix(file.Name(), ".chatmode.md") {
-			installed = append(installed, file.Name())
+	sort.Strings(scan.Chatmates)
+	return scan, nil
+}
+
+// sourceContent is the content of an available chatmate, and the size and
+// modification time its file had when it was read (zero for embedded ones).
+type sourceContent struct {
+	data    []byte
+	size    int64
+	modTime time.Time
+}
+
+// GetChatmateContent returns the source content of an available chatmate.
+//
+// The content is read from the source that provides the chatmate (see
+// GetAvailableChatmates). Each chatmate is read once per
+// manager, and again only when its file in a source directory changes: a
+// command that compares, installs, and reports the same chatmate shares one
+// copy, so the returned slice must not be modified.
+//
+// Parameters:
+//   - filename: The chatmate filename (e.g., "Solve Issue.chatmode.md")
+//
+// Returns:
+//   - []byte: Raw chatmate file content
+//   - error: Embedded resource or file read error
+func (cm *ChatMateManager) GetChatmateContent(filename string) ([]byte, error) {
+	if content, ok := cm.cachedContent(filename); ok {
+		return content, nil
+	}
+
+	var content sourceContent
+	source := cm.sourceOf(filename)
+	if dir, ok := source.(*DirectorySource); ok {
+		sourcePath := dir.path(filename)
+		info, err := dir.policy(cm.context()).Stat(sourcePath)
+		if err == nil {
+			content.size, content.modTime = info.Size(), info.ModTime()
+			content.data, err = dir.policy(cm.context()).ReadFile(sourcePath)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", sourcePath, err)
 		}
+	} else {
+		f, err := source.Open(cm.context(), filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if content.data, err = io.ReadAll(f); err != nil {
+			return nil, fmt.Errorf("failed to read chatmate %s from %s: %w", filename, source.Location(), err)
+		}
+	}
+
+	cm.contentsMu.Lock()
+	defer cm.contentsMu.Unlock()
+	if cm.contents == nil {
+		cm.contents = map[string]sourceContent{}
+	}
+	cm.contents[cm.contentKey(filename)] = content
+	return content.data, nil
+}
+
+// openChatmate opens the source of an available chatmate for reading, for
+// callers that need only part of it. Content already read by
+// GetChatmateContent is reused; otherwise nothing is kept in memory.
+func (cm *ChatMateManager) openChatmate(filename string) (io.ReadCloser, error) {
+	if content, ok := cm.cachedContent(filename); ok {
+		return io.NopCloser(bytes.NewReader(content)), nil
 	}
 
-	return installed, nil
+	return cm.sourceOf(filename).Open(cm.context(), filename)
+}
+
+// cachedContent returns the content of an available chatmate read earlier in
+// this run, unless its file in a source directory changed since.
+func (cm *ChatMateManager) cachedContent(filename string) ([]byte, bool) {
+	cm.contentsMu.Lock()
+	content, ok := cm.contents[cm.contentKey(filename)]
+	cm.contentsMu.Unlock()
+	dir, isDir := cm.sourceOf(filename).(*DirectorySource)
+	if !ok || !isDir {
+		return content.data, ok
+	}
+	info, err := dir.policy(cm.context()).Stat(dir.path(filename))
+	if err != nil || info.Size() != content.size || !info.ModTime().Equal(content.modTime) {
+		return nil, false
+	}
+	return content.data, true
+}
+
+// contentKey identifies the source of an available chatmate in the content
+// read during this run: its path in a source directory, or the kind and
+// location of its source and its filename.
+func (cm *ChatMateManager) contentKey(filename string) string {
+	source := cm.sourceOf(filename)
+	if dir, ok := source.(*DirectorySource); ok {
+		return dir.path(filename)
+	}
+	return source.Kind() + ":" + source.Location() + ":" + filename
+}
+
+// findChatmate resolves a chatmate name against a list of chatmate filenames.
+//
+// The name may be a display name (e.g., "Solve Issue"), a full filename
+// (e.g., "Solve Issue.chatmode.md"), or a filename without the extension.
+// Exact matches are preferred, the most specific first: a file with exactly
+// that name always wins. Otherwise display names match ignoring case, as
+// @-mentions in Copilot Chat do, but only when a single file matches, so an
+// ambiguous name never picks one of several chatmates. Names match in any
+// Unicode normalization (see files.NormalizeFilename). The result is always
+// one of filenames.
+//
+// Every command that takes chatmate names resolves them here, so 'hire',
+// 'uninstall', and their --explain output accept the same names.
+func (cm *ChatMateManager) findChatmate(name string, filenames []string) (string, bool) {
+	name = files.NormalizeFilename(name)
+	normalized := make([]string, len(filenames))
+	for i, filename := range filenames {
+		normalized[i] = files.NormalizeFilename(filename)
+	}
+
+	exact := []func(filename string) bool{
+		func(filename string) bool { return filename == name },
+		func(filename string) bool { return filename == name+".chatmode.md" },
+		func(filename string) bool { return cm.getDisplayName(filename) == name },
+	}
+	for _, matches := range exact {
+		for i, filename := range normalized {
+			if matches(filename) {
+				return filenames[i], true
+			}
+		}
+	}
+
+	var matches []string
+	display := cm.getDisplayName(name)
+	for i, filename := range normalized {
+		if strings.EqualFold(cm.getDisplayName(filename), display) {
+			matches = append(matches, filenames[i])
+		}
+	}
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
 }
 
 // getDisplayName extracts a user-friendly display name from a chatmate filename.
@@ -244,6 +1033,13 @@ func (cm *ChatMateManager) GetInstalledChatmates() ([]string, error) {
 // Returns:
 //   - string: User-friendly display name
 func (cm *ChatMateManager) getDisplayName(filename string) string {
+	return DisplayName(filename)
+}
+
+// DisplayName converts a chatmate filename such as
+// "Chatmate - Solve Issue.chatmode.md" into its display name "Solve Issue",
+// for code that has no manager at hand.
+func DisplayName(filename string) string {
 	// Remove the file extension
 	name := strings.TrimSuffix(filename, ".chatmode.md")
 
@@ -254,3 +1050,40 @@ func (cm *ChatMateManager) getDisplayName(filename string) string {
 
 	return name
 }
+
+// displayNameKey returns the key under which a chatmate is mentioned in
+// Copilot Chat. Mentions ignore case, so two files whose display names only
+// differ in case are just as ambiguous.
+func displayNameKey(filename string) string {
+	return strings.ToLower(DisplayName(filename))
+}
+
+// duplicateDisplayNames groups the files that map to the same display name,
+// such as "Solve Issue.chatmode.md" and "Chatmate - Solve Issue.chatmode.md",
+// which make @-mentions ambiguous.
+//
+// Parameters:
+//   - filenames: The chatmate filenames to check
+//
+// Returns:
+//   - [][]string: The groups of two or more files sharing a display name,
+//     sorted by display name, with sorted filenames
+func duplicateDisplayNames(filenames []string) [][]string {
+	byName := make(map[string][]string)
+	for _, filename := range filenames {
+		key := displayNameKey(filename)
+		byName[key] = append(byName[key], filename)
+	}
+
+	var duplicates [][]string
+	for _, group := range byName {
+		if len(group) > 1 {
+			sort.Strings(group)
+			duplicates = append(duplicates, group)
+		}
+	}
+	sort.Slice(duplicates, func(a, b int) bool {
+		return displayNameKey(duplicates[a][0]) < displayNameKey(duplicates[b][0])
+	})
+	return duplicates
+}
@@ -1,10 +1,15 @@
 package manager
 
 import (
+	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"testing/quick"
 )
 
 // TestNewChatMateManager tests the constructor function
@@ -197,3 +202,296 @@ func TestValidatorService_NewValidatorService(t *testing.T) {
 		t.Error("Validator service manager reference incorrect")
 	}
 }
+
+// chatmateNames is a set of chatmate filenames for property tests. Display
+// names come from a small alphabet so that names differing only in case are
+// common, and some files carry the legacy "Chatmate - " prefix.
+type chatmateNames []string
+
+// Generate implements quick.Generator.
+func (chatmateNames) Generate(rand *rand.Rand, size int) reflect.Value {
+	seen := map[string]bool{}
+	var names chatmateNames
+	for i := rand.Intn(8) + 1; i > 0; i-- {
+		filename := randomChatmateName(rand) + ".chatmode.md"
+		if rand.Intn(4) == 0 {
+			filename = "Chatmate - " + filename
+		}
+		if !seen[filename] {
+			seen[filename] = true
+			names = append(names, filename)
+		}
+	}
+	return reflect.ValueOf(names)
+}
+
+// chatmateQuery is a name a user might type: a display name, or a filename
+// with or without the extension.
+type chatmateQuery string
+
+// Generate implements quick.Generator.
+func (chatmateQuery) Generate(rand *rand.Rand, size int) reflect.Value {
+	query := randomChatmateName(rand)
+	switch rand.Intn(4) {
+	case 0:
+		query += ".chatmode.md"
+	case 1:
+		query = "Chatmate - " + query
+	}
+	return reflect.ValueOf(chatmateQuery(query))
+}
+
+// randomChatmateName returns a short display name from a small alphabet.
+func randomChatmateName(rand *rand.Rand) string {
+	const alphabet = "abAB -"
+	name := make([]byte, rand.Intn(3)+1)
+	for i := range name {
+		name[i] = alphabet[rand.Intn(len(alphabet))]
+	}
+	return string(name)
+}
+
+// uniqueDisplayName reports whether no other file in names has the display
+// name of filename, ignoring case.
+func uniqueDisplayName(cm *ChatMateManager, names []string, filename string) bool {
+	for _, other := range names {
+		if other != filename && strings.EqualFold(cm.getDisplayName(other), cm.getDisplayName(filename)) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestFindChatmateProperties checks findChatmate against generated candidate
+// sets, so the rules every command resolves names by cannot drift apart again.
+func TestFindChatmateProperties(t *testing.T) {
+	cm := &ChatMateManager{}
+	config := &quick.Config{MaxCount: 500}
+
+	t.Run("resolves its own display name and filename", func(t *testing.T) {
+		property := func(names chatmateNames) bool {
+			for _, filename := range names {
+				if !uniqueDisplayName(cm, names, filename) {
+					continue
+				}
+				display := cm.getDisplayName(filename)
+				for _, query := range []string{filename, strings.TrimSuffix(filename, ".chatmode.md"), display} {
+					if got, ok := cm.findChatmate(query, names); !ok || got != filename {
+						t.Logf("findChatmate(%q, %q) = %q, %v; want %q", query, names, got, ok, filename)
+						return false
+					}
+				}
+			}
+			return true
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("ignores case of display names", func(t *testing.T) {
+		property := func(names chatmateNames) bool {
+			for _, filename := range names {
+				if !uniqueDisplayName(cm, names, filename) {
+					continue
+				}
+				display := cm.getDisplayName(filename)
+				for _, query := range []string{strings.ToUpper(display), strings.ToLower(display)} {
+					if got, ok := cm.findChatmate(query, names); !ok || got != filename {
+						t.Logf("findChatmate(%q, %q) = %q, %v; want %q", query, names, got, ok, filename)
+						return false
+					}
+				}
+			}
+			return true
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("returns one of the candidates", func(t *testing.T) {
+		property := func(names chatmateNames, query chatmateQuery) bool {
+			got, ok := cm.findChatmate(string(query), names)
+			if ok != slices.Contains(names, got) || (!ok && got != "") {
+				t.Logf("findChatmate(%q, %q) = %q, %v", query, names, got, ok)
+				return false
+			}
+			return true
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("prefers an exact filename", func(t *testing.T) {
+		property := func(names chatmateNames) bool {
+			for _, filename := range names {
+				// Files whose display name or name without the extension is
+				// the query, listed first and differing only in case
+				candidates := append([]string{
+					filename + ".chatmode.md",
+					"Chatmate - " + filename,
+					strings.ToUpper(filename),
+				}, names...)
+				if got, ok := cm.findChatmate(filename, candidates); !ok || got != filename {
+					t.Logf("findChatmate(%q, %q) = %q, %v; want %q", filename, candidates, got, ok, filename)
+					return false
+				}
+			}
+			return true
+		}
+		if err := quick.Check(property, config); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// TestGetChatmateContentReadsOnce tests that available chatmates are read
+// once per manager, and again after their file changes
+func TestGetChatmateContentReadsOnce(t *testing.T) {
+	embedded := &ChatMateManager{UseEmbedded: true}
+	filenames, err := embedded.GetAvailableChatmates()
+	if err != nil || len(filenames) == 0 {
+		t.Fatalf("GetAvailableChatmates() = %v, %v", filenames, err)
+	}
+	first, err := embedded.GetChatmateContent(filenames[0])
+	if err != nil {
+		t.Fatalf("GetChatmateContent() failed: %v", err)
+	}
+	second, _ := embedded.GetChatmateContent(filenames[0])
+	if len(first) == 0 || &first[0] != &second[0] {
+		t.Error("Expected embedded content to be shared instead of copied again")
+	}
+
+	matesDir := t.TempDir()
+	path := filepath.Join(matesDir, "Agent.chatmode.md")
+	if err := os.WriteFile(path, []byte("---\ndescription: old\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cm := &ChatMateManager{MatesDir: matesDir}
+	if content, err := cm.GetChatmateContent("Agent.chatmode.md"); err != nil || !strings.Contains(string(content), "old") {
+		t.Fatalf("GetChatmateContent() = %q, %v", content, err)
+	}
+	if err := os.WriteFile(path, []byte("---\ndescription: newer\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if content, err := cm.GetChatmateContent("Agent.chatmode.md"); err != nil || !strings.Contains(string(content), "newer") {
+		t.Errorf("Expected a changed file to be read again, got %q, %v", content, err)
+	}
+
+	metadata, err := cm.AvailableMetadata()
+	if err != nil || len(metadata) != 1 || metadata[0].Description != "newer" {
+		t.Errorf("AvailableMetadata() = %+v, %v", metadata, err)
+	}
+}
+
+// TestChatMateManager_Editor tests choosing the VS Code build or fork
+// chatmates are installed for
+func TestChatMateManager_Editor(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the VS Code prompts directory is redirected through HOME on Linux only")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("PATH", filepath.Join(home, "bin"))
+	t.Setenv(HeadlessEnv, "0")
+	config := filepath.Join(home, ".config")
+
+	t.Run("chosen with the environment", func(t *testing.T) {
+		t.Setenv(EditorEnv, "insiders")
+		cm, err := NewChatMateManager()
+		if err != nil {
+			t.Fatalf("NewChatMateManager failed: %v", err)
+		}
+		if want := filepath.Join(config, "Code - Insiders", "User", "prompts"); cm.PromptsDir != want {
+			t.Errorf("Expected prompts directory %s, got %s", want, cm.PromptsDir)
+		}
+		if label := cm.PromptsDirLabel(); label != "VS Code Insiders Prompts Directory" {
+			t.Errorf("Unexpected label %q", label)
+		}
+	})
+
+	t.Run("unknown editor", func(t *testing.T) {
+		t.Setenv(EditorEnv, "notepad")
+		if _, err := NewChatMateManager(); err == nil || !strings.Contains(err.Error(), EditorEnv) {
+			t.Errorf("Expected an error naming %s, got %v", EditorEnv, err)
+		}
+	})
+
+	t.Run("detected when VS Code is missing", func(t *testing.T) {
+		if err := os.MkdirAll(filepath.Join(config, "Cursor", "User"), 0755); err != nil {
+			t.Fatalf("Failed to create Cursor user directory: %v", err)
+		}
+		cm, err := NewChatMateManager()
+		if err != nil {
+			t.Fatalf("NewChatMateManager failed: %v", err)
+		}
+		if cm.Editor.ID != "cursor" || cm.PromptsDir != filepath.Join(config, "Cursor", "User", "prompts") {
+			t.Errorf("Expected Cursor to be detected, got %+v in %s", cm.Editor, cm.PromptsDir)
+		}
+
+		if err := os.MkdirAll(filepath.Join(config, "Code", "User"), 0755); err != nil {
+			t.Fatalf("Failed to create VS Code user directory: %v", err)
+		}
+		if cm, err = NewChatMateManager(); err != nil || cm.Editor.ID != "stable" {
+			t.Errorf("Expected VS Code to be preferred, got %+v, %v", cm.Editor, err)
+		}
+	})
+
+	t.Run("UseEditor", func(t *testing.T) {
+		cm, err := NewChatMateManager()
+		if err != nil {
+			t.Fatalf("NewChatMateManager failed: %v", err)
+		}
+		cm.Headless = true
+		if err := cm.UseEditor("vscodium"); err != nil {
+			t.Fatalf("UseEditor failed: %v", err)
+		}
+		if cm.Headless || cm.PromptsDir != filepath.Join(config, "VSCodium", "User", "prompts") {
+			t.Errorf("Expected the VSCodium prompts directory, got %s (headless %v)", cm.PromptsDir, cm.Headless)
+		}
+		if err := cm.UseEditor("notepad"); err == nil {
+			t.Error("Expected an error for an unknown editor")
+		}
+	})
+}
+
+// TestChatMateManager_DirectoryOverrides tests overriding the detected
+// prompts and mates directories with environment variables
+func TestChatMateManager_DirectoryOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv(HeadlessEnv, "1")
+	promptsDir := filepath.Join(home, "ci", "prompts")
+	matesDir := filepath.Join(home, "ci", "mates")
+	if err := os.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("Failed to create mates directory: %v", err)
+	}
+
+	t.Setenv(PromptsDirEnv, promptsDir)
+	t.Setenv(MatesDirEnv, matesDir)
+	cm, err := NewChatMateManager()
+	if err != nil {
+		t.Fatalf("NewChatMateManager failed: %v", err)
+	}
+	if cm.PromptsDir != promptsDir || cm.Headless {
+		t.Errorf("Expected prompts directory %s outside headless mode, got %s (headless %v)", promptsDir, cm.PromptsDir, cm.Headless)
+	}
+	if cm.MatesDir != matesDir || cm.UseEmbedded {
+		t.Errorf("Expected mates directory %s instead of the embedded chatmates, got %s (embedded %v)", matesDir, cm.MatesDir, cm.UseEmbedded)
+	}
+
+	// The --editor flag takes precedence over the environment
+	if err := cm.UseEditor("stable"); err != nil || cm.PromptsDir == promptsDir {
+		t.Errorf("Expected UseEditor to replace the prompts directory, got %s, %v", cm.PromptsDir, err)
+	}
+
+	t.Setenv(MatesDirEnv, filepath.Join(home, "missing"))
+	if _, err := NewChatMateManager(); err == nil || !strings.Contains(err.Error(), MatesDirEnv) {
+		t.Errorf("Expected an error naming %s for a missing directory, got %v", MatesDirEnv, err)
+	}
+}
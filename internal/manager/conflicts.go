@@ -0,0 +1,130 @@
+// Package manager provides cloud-sync conflict detection for ChatMate agents.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/jonassiebler/chatmate/pkg/security"
+)
+
+// syncConflictPatterns match the names sync clients give to conflicting
+// copies of a file. Copilot Chat shows such copies of a chatmode file as
+// additional, bogus chatmates.
+var syncConflictPatterns = []*regexp.Regexp{
+	// Dropbox, Nextcloud: "Solve Issue (conflicted copy 2024-01-02).chatmode.md",
+	// "Solve Issue (Jane's conflicted copy).chatmode.md"
+	regexp.MustCompile(`(?i)\(.*conflicted copy.*\)`),
+	// Syncthing: "Solve Issue.chatmode.sync-conflict-20240102-150405-ABCDEFG.md"
+	regexp.MustCompile(`(?i)\.sync-conflict-\d{8}-\d{6}`),
+	// ownCloud: "Solve Issue_conflict-20240102-150405.chatmode.md"
+	regexp.MustCompile(`(?i)_conflict-\d{8}-\d{6}`),
+	// OneDrive, Google Drive, and others: "Solve Issue (Conflict).chatmode.md"
+	regexp.MustCompile(`(?i)\((\S+ )?conflict( \d+)?\)`),
+}
+
+// isSyncConflict reports whether filename looks like a sync-conflict copy.
+func isSyncConflict(filename string) bool {
+	for _, pattern := range syncConflictPatterns {
+		if pattern.MatchString(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindSyncConflicts returns the cloud-sync conflict copies in the prompts directory.
+//
+// Sync clients such as Dropbox, OneDrive, Nextcloud, and Syncthing keep both
+// versions of a file that changed on two machines, renaming one of them.
+// In the prompts directory these copies show up as duplicate chatmates.
+// A missing prompts directory has no conflicts.
+//
+// Returns:
+//   - []string: Sorted filenames of the conflict copies
+//   - error: Failure to read the prompts directory
+//
+// Example:
+//
+// conflicts, err := manager.FindSyncConflicts()
+//
+//	if err != nil {
+//	   return fmt.Errorf("conflict detection failed: %w", err)
+//	}
+func (cm *ChatMateManager) FindSyncConflicts() ([]string, error) {
+	entries, err := cm.FS.ReadDir(cm.PromptsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompts directory %s: %w", cm.PromptsDir, err)
+	}
+
+	patterns, err := cm.IgnorePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for _, entry := range entries {
+		if !entry.IsDir() && isSyncConflict(entry.Name()) && !isIgnored(entry.Name(), patterns) {
+			conflicts = append(conflicts, entry.Name())
+		}
+	}
+	sort.Strings(conflicts)
+
+	return conflicts, nil
+}
+
+// RemoveSyncConflicts deletes sync-conflict copies from the prompts directory.
+//
+// Only files recognized as conflict copies are removed, so the list returned
+// by FindSyncConflicts can be passed in after the user confirmed it.
+//
+// Parameters:
+//   - ctx: Stops the cleanup before the next file once done
+//   - filenames: Conflict copies to remove
+//
+// Returns:
+//   - int: Number of files removed
+//   - error: Validation or file operation error
+//
+// Example:
+//
+// removed, err := uninstaller.RemoveSyncConflicts(ctx, conflicts)
+//
+//	if err != nil {
+//	   return fmt.Errorf("conflict cleanup failed: %w", err)
+//	}
+func (u *UninstallerService) RemoveSyncConflicts(ctx context.Context, filenames []string) (int, error) {
+	defer u.manager.bind(ctx)()
+	removed := 0
+	for _, filename := range filenames {
+		if err := interrupted(ctx, "cleanup"); err != nil {
+			return removed, err
+		}
+		if !isSyncConflict(filename) {
+			return removed, fmt.Errorf("not a sync-conflict copy: %s", filename)
+		}
+		// Conflict names contain characters chatmate names may not, so only
+		// require a plain filename inside the prompts directory
+		if filepath.Base(filename) != filename || !security.IsPathSafe(u.manager.PromptsDir, filename) {
+			return removed, fmt.Errorf("file path is not safe: %s", filename)
+		}
+
+		path := filepath.Join(u.manager.PromptsDir, filename)
+		if err := u.manager.FS.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove sync-conflict copy %s: %w", path, err)
+		}
+		u.manager.invalidateInventory()
+
+		u.manager.out().Printf("❌ %s (%s)\n", filename, u.manager.outcome("removed", "would be removed"))
+		removed++
+	}
+
+	return removed, nil
+}
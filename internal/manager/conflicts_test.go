@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestChatMateManager_SyncConflicts tests detecting and removing cloud-sync conflict copies
+func TestChatMateManager_SyncConflicts(t *testing.T) {
+	names := map[string]bool{
+		"Chatmate - Solve Issue (conflicted copy 2024-01-02).chatmode.md":      true,
+		"Chatmate - Solve Issue (Jane's conflicted copy).chatmode.md":          true,
+		"Chatmate - Testing.chatmode.sync-conflict-20240102-150405-ABCDEFG.md": true,
+		"Chatmate - Testing_conflict-20240102-150405.chatmode.md":              true,
+		"Chatmate - Testing (Conflict).chatmode.md":                            true,
+		"Chatmate - Solve Issue.chatmode.md":                                   false,
+		"Conflict Resolution.chatmode.md":                                      false,
+		"Chatmate - Merge Conflicts.chatmode.md":                               false,
+		"My Agent (copy).chatmode.md":                                          false,
+	}
+	for name, expected := range names {
+		if got := isSyncConflict(name); got != expected {
+			t.Errorf("isSyncConflict(%q) = %t, expected %t", name, got, expected)
+		}
+	}
+
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	content := []byte("---\ndescription: test\n---\n")
+	original := "Chatmate - Solve Issue.chatmode.md"
+	conflict := "Chatmate - Solve Issue (conflicted copy 2024-01-02).chatmode.md"
+	if err := os.WriteFile(filepath.Join(matesDir, original), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	for _, name := range []string{original, conflict} {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.uninstaller = NewUninstallerService(cm)
+	cm.validator = NewValidatorService(cm)
+
+	conflicts, err := cm.FindSyncConflicts()
+	if err != nil {
+		t.Fatalf("FindSyncConflicts failed: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != conflict {
+		t.Fatalf("Expected [%s], got %v", conflict, conflicts)
+	}
+
+	report, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	for _, check := range report.Checks {
+		switch check.Name {
+		case "sync-conflicts":
+			if check.Status != CheckWarn || len(check.Files) != 1 {
+				t.Errorf("Expected sync-conflicts warning for one file, got %+v", check)
+			}
+		case "orphaned-files", "installed-chatmates":
+			if check.Status != CheckPass {
+				t.Errorf("Conflict copies should only be reported once, %s: %+v", check.Name, check)
+			}
+		}
+	}
+
+	if _, err := cm.Uninstaller().RemoveSyncConflicts(context.Background(), []string{original}); err == nil {
+		t.Error("RemoveSyncConflicts must refuse files that are not conflict copies")
+	}
+
+	removed, err := cm.Uninstaller().RemoveSyncConflicts(context.Background(), conflicts)
+	if err != nil {
+		t.Fatalf("RemoveSyncConflicts failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Expected 1 removed file, got %d", removed)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, original)); err != nil {
+		t.Errorf("Original chatmate must be kept: %v", err)
+	}
+	if conflicts, _ := cm.FindSyncConflicts(); len(conflicts) != 0 {
+		t.Errorf("Expected no conflicts after cleanup, got %v", conflicts)
+	}
+}
@@ -0,0 +1,166 @@
+// Package manager provides content comparison of installed and shipped ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// DiffReport compares installed chatmates with the versions shipped with
+// ChatMate.
+//
+// Fields:
+//   - PromptsDir: The prompts directory that was compared
+//   - Chatmates: The compared chatmates, sorted by filename
+type DiffReport struct {
+	PromptsDir string         `json:"promptsDir"`
+	Chatmates  []ChatmateDiff `json:"chatmates"`
+}
+
+// Changed returns the chatmates whose installed content differs from the
+// shipped one.
+func (r *DiffReport) Changed() []ChatmateDiff {
+	var changed []ChatmateDiff
+	for _, chatmate := range r.Chatmates {
+		if chatmate.Changed {
+			changed = append(changed, chatmate)
+		}
+	}
+	return changed
+}
+
+// ChatmateDiff is the difference between an installed chatmate and the
+// version shipped with ChatMate.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename
+//   - Changed: Whether the installed content differs from the shipped one
+//   - InstalledVersion: The frontmatter version of the installed chatmate
+//   - AvailableVersion: The frontmatter version of the shipped chatmate
+//   - Added: Number of lines only in the installed chatmate
+//   - Removed: Number of lines only in the shipped chatmate
+//   - Diff: Unified diff from the shipped to the installed content; empty
+//     when unchanged
+type ChatmateDiff struct {
+	Name             string `json:"name"`
+	Filename         string `json:"filename"`
+	Changed          bool   `json:"changed"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	AvailableVersion string `json:"availableVersion,omitempty"`
+	Added            int    `json:"added"`
+	Removed          int    `json:"removed"`
+	Diff             string `json:"diff,omitempty"`
+}
+
+// Diff compares installed chatmates with the versions shipped with ChatMate,
+// line by line, without changing anything.
+//
+// Only installed chatmates that ChatMate ships are compared; user-created
+// chatmates have nothing to be compared with. The unified diffs go from the
+// shipped version ("available/") to the installed copy ("installed/"), so
+// added lines are local edits or lines removed from the shipped version
+// since the chatmate was installed.
+//
+// Parameters:
+//   - names: Display names or filenames to compare; every installed shipped
+//     chatmate if empty
+//
+// Returns:
+//   - *DiffReport: The comparison
+//   - error: Unknown or not installed chatmate, or a read error
+//
+// Example:
+//
+//	report, err := manager.Diff([]string{"Solve Issue"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, chatmate := range report.Changed() {
+//		fmt.Print(chatmate.Diff)
+//	}
+func (cm *ChatMateManager) Diff(names []string) (*DiffReport, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	var filenames []string
+	for _, filename := range inventory.Installed {
+		if inventory.IsAvailable(filename) {
+			filenames = append(filenames, filename)
+		}
+	}
+	if len(names) > 0 {
+		selected := make([]string, 0, len(names))
+		for _, name := range names {
+			filename, ok := cm.findChatmate(name, filenames)
+			if !ok {
+				return nil, errorf(ErrChatmateNotFound, "chatmate not installed or not shipped with ChatMate: %s", name)
+			}
+			selected = append(selected, filename)
+		}
+		filenames = selected
+	}
+
+	report := &DiffReport{PromptsDir: cm.PromptsDir, Chatmates: []ChatmateDiff{}}
+	for _, filename := range filenames {
+		shipped, err := cm.GetChatmateContent(filename)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(cm.PromptsDir, filename)
+		installed, err := cm.FS.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+		}
+
+		chatmate, err := diffChatmate(filename, shipped, installed)
+		if err != nil {
+			return nil, err
+		}
+		chatmate.Name = cm.getDisplayName(filename)
+		report.Chatmates = append(report.Chatmates, chatmate)
+	}
+	return report, nil
+}
+
+// diffChatmate compares the shipped and installed content of a chatmate.
+func diffChatmate(filename string, shipped, installed []byte) (ChatmateDiff, error) {
+	chatmate := ChatmateDiff{
+		Filename:         filename,
+		InstalledVersion: frontmatterVersion(installed),
+		AvailableVersion: frontmatterVersion(shipped),
+	}
+	if string(shipped) == string(installed) {
+		return chatmate, nil
+	}
+	chatmate.Changed = true
+
+	a, b := difflib.SplitLines(string(shipped)), difflib.SplitLines(string(installed))
+	for _, op := range difflib.NewMatcher(a, b).GetOpCodes() {
+		if op.Tag != 'e' {
+			chatmate.Removed += op.I2 - op.I1
+			chatmate.Added += op.J2 - op.J1
+		}
+	}
+
+	diff := difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: "available/" + filename,
+		ToFile:   "installed/" + filename,
+		Context:  diffContext,
+	}
+	text, err := difflib.GetUnifiedDiffString(diff)
+	if err != nil {
+		return chatmate, fmt.Errorf("failed to compare %s: %w", filename, err)
+	}
+	chatmate.Diff = text
+	return chatmate, nil
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestChatMateManager_Diff tests comparing installed chatmates with the
+// shipped versions
+func TestChatMateManager_Diff(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	shipped := "---\ndescription: test\nversion: '1.1.0'\n---\nline 1\nline 2\nline 3\n"
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte(shipped), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	for name, content := range map[string]string{
+		"A.chatmode.md":    shipped,
+		"B.chatmode.md":    "---\ndescription: test\nversion: '1.0.0'\n---\nline 1\nlocal edit\nline 3\nline 4\n",
+		"Mine.chatmode.md": "mine",
+	} {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create installed file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	report, err := cm.Diff(nil)
+	if err != nil {
+		t.Fatalf("Diff failed: %v", err)
+	}
+	if len(report.Chatmates) != 2 || report.Chatmates[0].Changed || report.Chatmates[0].Diff != "" {
+		t.Fatalf("Expected the installed shipped chatmates with A unchanged, got %+v", report.Chatmates)
+	}
+	changed := report.Changed()
+	if len(changed) != 1 || changed[0].Name != "B" {
+		t.Fatalf("Expected only B to differ, got %+v", changed)
+	}
+
+	b := changed[0]
+	if b.Added != 3 || b.Removed != 2 || b.InstalledVersion != "1.0.0" || b.AvailableVersion != "1.1.0" {
+		t.Errorf("Unexpected comparison of B: %+v", b)
+	}
+	for _, line := range []string{"--- available/B.chatmode.md", "+++ installed/B.chatmode.md", "-version: '1.1.0'", "+version: '1.0.0'", "-line 2", "+local edit", "+line 4", " line 3"} {
+		if !strings.Contains(b.Diff, line+"\n") {
+			t.Errorf("Expected the diff to contain %q, got:\n%s", line, b.Diff)
+		}
+	}
+
+	report, err = cm.Diff([]string{"B.chatmode.md"})
+	if err != nil || len(report.Chatmates) != 1 || report.Chatmates[0].Filename != "B.chatmode.md" {
+		t.Errorf("Expected only B to be compared, got %+v, %v", report, err)
+	}
+	for _, name := range []string{"C", "Mine"} {
+		if _, err := cm.Diff([]string{name}); err == nil {
+			t.Errorf("Expected an error for %s, which is not installed or not shipped", name)
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Package manager provides a health check of the ChatMate setup.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// DoctorService checks everything chatmates depend on in one run.
+//
+// Unlike a troubleshooting flow, which follows one symptom, the doctor runs
+// every check and reports pass, warn, or fail with a remediation hint for
+// each. Safe repairs, such as recreating the prompts directory, are applied
+// by Fix.
+type DoctorService struct {
+	manager *ChatMateManager
+}
+
+// NewDoctorService creates a new doctor service.
+func NewDoctorService(manager *ChatMateManager) *DoctorService {
+	return &DoctorService{manager: manager}
+}
+
+// DoctorReport is the result of a doctor run.
+//
+// Fields:
+//   - PromptsDir: The prompts directory that was checked
+//   - Findings: The outcome of every check with its remediation hint
+type DoctorReport struct {
+	PromptsDir string    `json:"promptsDir"`
+	Findings   []Finding `json:"findings"`
+}
+
+// Count returns the number of findings with the given status.
+func (r *DoctorReport) Count(status CheckStatus) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Fixable returns the findings that did not pass and can be repaired
+// automatically.
+func (r *DoctorReport) Fixable() []Finding {
+	var fixable []Finding
+	for _, finding := range r.Findings {
+		if finding.Status != CheckPass && finding.CanApply() {
+			fixable = append(fixable, finding)
+		}
+	}
+	return fixable
+}
+
+// Run performs every doctor check.
+//
+// Run only inspects the setup; repairs are applied by Fix, after which the
+// checks can be run again.
+//
+// Returns:
+//   - *DoctorReport: The findings of all checks, in a fixed order
+//
+// Example:
+//
+// report := doctor.Run()
+//
+//	if report.Count(CheckFail) > 0 {
+//	   fmt.Println("ChatMate setup has problems")
+//	}
+func (d *DoctorService) Run() *DoctorReport {
+	report := &DoctorReport{PromptsDir: d.manager.PromptsDir}
+	troubleshooter := NewTroubleshooterService(d.manager)
+
+	report.Findings = append(report.Findings,
+		d.checkEditorMode(troubleshooter),
+		d.checkCopilotChat(),
+	)
+
+	directory := d.checkPromptsDirectory(troubleshooter)
+	report.Findings = append(report.Findings, directory)
+	if directory.Status == CheckFail {
+		// The chatmates cannot be inspected without a usable directory
+		return report
+	}
+
+	report.Findings = append(report.Findings,
+		d.checkOrphanedFiles(),
+		d.checkFrontmatter(),
+		d.checkLegacyNames(),
+		d.checkDuplicateNames(),
+	)
+	return report
+}
+
+// Fix applies the automatic repairs of a report.
+//
+// A repair that fails does not stop the others; all failures are reported
+// at the end. Once ctx is done, the remaining repairs are not applied.
+//
+// Parameters:
+//   - ctx: Stops applying repairs once done
+//   - report: The report of a previous Run
+//
+// Returns:
+//   - int: Number of repairs that were applied
+//   - error: Summary of the repairs that failed, or cancellation
+//
+// Example:
+//
+// fixed, err := doctor.Fix(ctx, doctor.Run())
+//
+//	if err != nil {
+//	   return fmt.Errorf("repair failed: %w", err)
+//	}
+func (d *DoctorService) Fix(ctx context.Context, report *DoctorReport) (int, error) {
+	fixed, failed := 0, 0
+	for _, finding := range report.Fixable() {
+		if err := interrupted(ctx, "repair"); err != nil {
+			return fixed, err
+		}
+		d.manager.out().Printf("🔧 %s: %s\n", finding.Name, finding.Fix)
+		if err := finding.Apply(ctx); err != nil {
+			d.manager.out().Warnf("%s: %v", finding.Name, err)
+			failed++
+			continue
+		}
+		fixed++
+	}
+
+	if failed > 0 {
+		return fixed, fmt.Errorf("%d repair(s) failed", failed)
+	}
+	return fixed, nil
+}
+
+// checkEditorMode checks that VS Code is detected. Headless mode is chosen
+// on purpose, so unlike in a troubleshooting flow it is only a warning.
+func (d *DoctorService) checkEditorMode(troubleshooter *TroubleshooterService) Finding {
+	finding := troubleshooter.checkEditorMode()
+	if d.manager.Headless {
+		finding.Status = CheckWarn
+		finding.Severity = SeverityWarning
+	}
+	return finding
+}
+
+// checkCopilotChat checks that the GitHub Copilot Chat extension, which
+// provides chat modes, is installed.
+func (d *DoctorService) checkCopilotChat() Finding {
+	const check = "copilot-chat"
+
+	if d.manager.Headless {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: "Headless mode does not use VS Code extensions"}}
+	}
+
+	path, found := platform.FindVSCodeExtension(platform.CopilotChatExtension)
+	if !found {
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+				Message: "The GitHub Copilot Chat extension was not found; chatmates are only available in Copilot Chat"},
+			Fix: fmt.Sprintf("Install it from the Extensions view, or with: code --install-extension %s", platform.CopilotChatExtension),
+		}
+	}
+
+	return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+		Message: fmt.Sprintf("GitHub Copilot Chat is installed: %s", path)}}
+}
+
+// checkPromptsDirectory checks that the prompts directory exists and is
+// writable. A missing directory is recreated by Fix without installing
+// anything into it.
+func (d *DoctorService) checkPromptsDirectory(troubleshooter *TroubleshooterService) Finding {
+	finding := troubleshooter.checkPromptsDirectory()
+
+	if _, err := d.manager.FS.Stat(d.manager.PromptsDir); os.IsNotExist(err) && d.manager.promptsDirErr == nil {
+		finding.Fix = fmt.Sprintf("Create %s with 'chatmate doctor --fix', then install chatmates with 'chatmate hire'", d.manager.PromptsDir)
+		finding.apply = func(context.Context) error { return d.manager.ensurePromptsDir() }
+	}
+	return finding
+}
+
+// checkOrphanedFiles checks for installed chatmates that are no longer
+// available. They are never removed automatically: they may be the user's
+// own chatmates.
+func (d *DoctorService) checkOrphanedFiles() Finding {
+	inventory, err := d.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: "orphaned-files", Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	finding := NewTroubleshooterService(d.manager).validatorFinding(NewValidatorService(d.manager), func(v *ValidatorService, report *Report) {
+		v.validateOrphanedFiles(report, inventory)
+	})
+	if finding.Status != CheckPass {
+		finding.Fix = "Keep your own chatmates with 'chatmate adopt', and remove the others with 'chatmate uninstall' or 'chatmate sync --prune'"
+	}
+	return finding
+}
+
+// checkFrontmatter checks that every installed chatmate starts with valid
+// YAML frontmatter, without which Copilot Chat does not show it correctly.
+// Fix reinstalls the corrupted chatmates that are still available.
+func (d *DoctorService) checkFrontmatter() Finding {
+	const check = "frontmatter"
+
+	inventory, err := d.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	var malformed, issues, reinstallable []string
+	for _, filename := range inventory.Installed {
+		// Conflict copies are diagnosed by 'chatmate validate'
+		if isSyncConflict(filename) {
+			continue
+		}
+		content, err := d.manager.FS.ReadFile(filepath.Join(d.manager.PromptsDir, filename))
+		if err == nil {
+			_, err = files.ParseFrontmatter(content)
+		}
+		if err == nil {
+			continue
+		}
+		malformed = append(malformed, filename)
+		issues = append(issues, fmt.Sprintf("%s: %v", filename, err))
+		if inventory.IsAvailable(filename) {
+			reinstallable = append(reinstallable, filename)
+		}
+	}
+
+	if len(malformed) == 0 {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: fmt.Sprintf("All %d installed chatmates have valid frontmatter", len(inventory.Installed))}}
+	}
+
+	finding := Finding{
+		CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+			Message: fmt.Sprintf("Found %d chatmates with malformed frontmatter: %s", len(malformed), strings.Join(issues, "; ")),
+			Files:   malformed},
+		Fix: "Repair the YAML frontmatter between the --- lines at the top of these files",
+	}
+	if len(reinstallable) > 0 {
+		finding.Fix = fmt.Sprintf("Reinstall %d of them with 'chatmate doctor --fix' (this replaces local edits to these files)", len(reinstallable))
+		if len(reinstallable) < len(malformed) {
+			finding.Fix += "; repair the frontmatter of the others by hand"
+		}
+		finding.apply = func(ctx context.Context) error {
+			defer d.manager.bind(ctx)()
+			installer := NewInstallerService(d.manager)
+			for _, filename := range reinstallable {
+				if err := interrupted(ctx, "reinstallation"); err != nil {
+					return err
+				}
+				if err := installer.InstallChatmate(filename, true); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return finding
+}
+
+// checkLegacyNames checks for installed chatmates that still have the
+// filename an older release gave them. Fix renames them; a legacy file with
+// local changes whose chatmate is also installed under its current name is
+// left for the duplicate-names check.
+func (d *DoctorService) checkLegacyNames() Finding {
+	finding := NewTroubleshooterService(d.manager).validatorFinding(NewValidatorService(d.manager), func(v *ValidatorService, report *Report) {
+		v.validateLegacyNames(report)
+	})
+	if finding.Status != CheckWarn {
+		return finding
+	}
+
+	finding.Fix = "Rename or remove the files with local changes by hand; the chatmates are also installed under their current names"
+	legacy, err := d.manager.LegacyFiles()
+	if err == nil && slices.ContainsFunc(legacy, func(file LegacyFile) bool { return file.Action != LegacyKeep }) {
+		finding.Fix = "Rename them to their current names with 'chatmate doctor --fix'"
+		finding.apply = func(ctx context.Context) error {
+			_, err := NewInstallerService(d.manager).MigrateLegacyNames(ctx)
+			return err
+		}
+	}
+	return finding
+}
+
+// checkDuplicateNames checks that no two installed files map to the same
+// display name. Which file should keep the name is the user's choice, so
+// there is no automatic repair.
+func (d *DoctorService) checkDuplicateNames() Finding {
+	inventory, err := d.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: "duplicate-names", Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	finding := NewTroubleshooterService(d.manager).validatorFinding(NewValidatorService(d.manager), func(v *ValidatorService, report *Report) {
+		v.validateDuplicateNames(report, inventory)
+	})
+	if finding.Status != CheckPass {
+		finding.Fix = "Rename or remove all but one file of each name in the prompts directory, for example with 'chatmate uninstall <file>'"
+	}
+	return finding
+}
@@ -0,0 +1,84 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestDoctor tests the doctor checks and their automatic repairs
+func TestDoctor(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+
+	content := []byte("---\ndescription: test\n---\nShipped\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - A.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	doctor := NewDoctorService(cm)
+
+	findings := func(report *DoctorReport) map[string]Finding {
+		byName := make(map[string]Finding)
+		for _, finding := range report.Findings {
+			byName[finding.Name] = finding
+		}
+		return byName
+	}
+
+	// A missing prompts directory stops the checks and is recreated
+	report := doctor.Run()
+	if directory := findings(report)["prompts-directory"]; directory.Status != CheckFail || !directory.CanApply() {
+		t.Fatalf("Expected a fixable prompts-directory failure, got %+v", directory)
+	}
+	if _, ok := findings(report)["frontmatter"]; ok {
+		t.Error("Chatmates should not be checked without a prompts directory")
+	}
+	if fixed, err := doctor.Fix(context.Background(), report); err != nil || fixed != 1 {
+		t.Fatalf("Expected the prompts directory to be recreated, got %d, %v", fixed, err)
+	}
+	if info, err := os.Stat(promptsDir); err != nil || !info.IsDir() {
+		t.Fatalf("Expected the prompts directory to exist: %v", err)
+	}
+
+	// A corrupted chatmate is reinstalled, a user's own file is only reported
+	if err := cm.Installer().InstallChatmate("Chatmate - A.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Chatmate - A.chatmode.md"), []byte("---\ndescription: [broken\n"), 0644); err != nil {
+		t.Fatalf("Failed to corrupt test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Mine.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create user file: %v", err)
+	}
+	cm.invalidateInventory()
+
+	report = doctor.Run()
+	byName := findings(report)
+	if frontmatter := byName["frontmatter"]; frontmatter.Status != CheckWarn || !frontmatter.CanApply() ||
+		len(frontmatter.Files) != 1 || frontmatter.Files[0] != "Chatmate - A.chatmode.md" {
+		t.Errorf("Expected a fixable frontmatter warning for A, got %+v", frontmatter)
+	}
+	if orphaned := byName["orphaned-files"]; orphaned.Status != CheckWarn || orphaned.CanApply() || orphaned.Fix == "" {
+		t.Errorf("Expected an orphaned-files warning with a manual fix, got %+v", orphaned)
+	}
+
+	if fixed, err := doctor.Fix(context.Background(), report); err != nil || fixed != 1 {
+		t.Fatalf("Expected the corrupted chatmate to be reinstalled, got %d, %v", fixed, err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(promptsDir, "Chatmate - A.chatmode.md")); string(got) != string(content) {
+		t.Errorf("Expected the shipped content after the repair, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Mine.chatmode.md")); err != nil {
+		t.Errorf("Expected the user's file to be kept: %v", err)
+	}
+	if frontmatter := findings(doctor.Run())["frontmatter"]; frontmatter.Status != CheckPass {
+		t.Errorf("Expected valid frontmatter after the repair, got %+v", frontmatter)
+	}
+}
@@ -0,0 +1,50 @@
+// Package manager provides dry runs of ChatMate operations.
+package manager
+
+import "github.com/jonassiebler/chatmate/pkg/utils/files"
+
+// DryRun makes the manager record the changes its operations would make to
+// the prompts directory instead of making them, for the global --dry-run
+// option.
+//
+// Operations run as usual on a files.DryRunFS, so their output reports what
+// they would do. Nothing else is written either: provenance, the activity
+// log, adoptions, approval requests, checkpoints, the inventory cache, and
+// the manifest of a shared prompts directory are left alone, and no backup
+// is made. Call DryRun before the first operation.
+//
+// Returns:
+//   - *files.DryRunFS: The filesystem recording the changes (see
+//     files.DryRunFS.Changes)
+//
+// Example:
+//
+//	dryRun := manager.DryRun()
+//	if err := manager.Uninstaller().UninstallAll(ctx); err != nil {
+//	   return err
+//	}
+//	for _, change := range dryRun.Changes() {
+//	   fmt.Println(change.Kind, change.Path)
+//	}
+func (cm *ChatMateManager) DryRun() *files.DryRunFS {
+	cm.dryRun = files.NewDryRunFS(cm.FS.FileSystem())
+	cm.FS.Backend = cm.dryRun
+	cm.AutoBackup = false
+
+	// Files that are only written are disabled; those that are also read
+	// (provenance, adoptions, approvals) are kept and their writes skipped
+	cm.activityPath = ""
+	cm.checkpointPath = ""
+	cm.inventoryPath = ""
+	cm.inventory = nil
+	return cm.dryRun
+}
+
+// outcome returns done for the report of a change, or planned when the
+// change is only recorded by a dry run.
+func (cm *ChatMateManager) outcome(done, planned string) string {
+	if cm.dryRun != nil {
+		return planned
+	}
+	return done
+}
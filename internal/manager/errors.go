@@ -0,0 +1,94 @@
+// Package manager provides the kinds of errors of ChatMate operations.
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Kinds of errors the services return, for callers to branch on with
+// errors.Is. The errors keep their messages and causes; the kind is an
+// additional error they wrap (see ErrorKind).
+var (
+	// ErrChatmateNotFound marks a chatmate name that matches no available
+	// or installed chatmate
+	ErrChatmateNotFound = errors.New("chatmate not found")
+	// ErrPromptsDirMissing marks a prompts directory that does not exist or
+	// cannot be used, e.g. a broken symlink
+	ErrPromptsDirMissing = errors.New("prompts directory does not exist")
+	// ErrPermissionDenied marks a file operation the operating system refused
+	ErrPermissionDenied = errors.New("permission denied")
+	// ErrValidationFailed marks a chatmate or setup that failed validation
+	ErrValidationFailed = errors.New("validation failed")
+)
+
+// errorKinds lists the kinds ErrorKind reports, most specific first.
+var errorKinds = []error{ErrChatmateNotFound, ErrPromptsDirMissing, ErrValidationFailed, ErrPermissionDenied}
+
+// Error is an error of a kind, wrapping the error that describes it.
+//
+// Error returns the message of Err unchanged, and errors.Is matches both
+// Kind and the causes wrapped by Err.
+//
+// Fields:
+//   - Kind: The kind of error (see ErrChatmateNotFound and friends)
+//   - Err: The error with the message and cause
+type Error struct {
+	Kind error
+	Err  error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind and the described error, for errors.Is and errors.As.
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// errorf formats an error like fmt.Errorf and marks it with kind.
+//
+// Example:
+//
+//	return errorf(ErrChatmateNotFound, "chatmate not found: %s", name)
+func errorf(kind error, format string, a ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, a...)}
+}
+
+// fileErrorf formats an error of a failed file operation like fmt.Errorf,
+// marking it with ErrPermissionDenied when the operating system refused it.
+func fileErrorf(format string, a ...any) error {
+	err := fmt.Errorf(format, a...)
+	if errors.Is(err, fs.ErrPermission) {
+		return &Error{Kind: ErrPermissionDenied, Err: err}
+	}
+	return err
+}
+
+// ErrorKind returns the kind of err: ErrChatmateNotFound,
+// ErrPromptsDirMissing, ErrValidationFailed, or ErrPermissionDenied, which
+// also covers permission errors of the operating system that were not
+// marked. Other errors have no kind and nil is returned.
+//
+// Example:
+//
+//	switch manager.ErrorKind(err) {
+//	case manager.ErrChatmateNotFound:
+//		fmt.Println("Run 'chatmate list' to see the chatmates")
+//	case nil:
+//		fmt.Println(err)
+//	}
+func ErrorKind(err error) error {
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	if errors.Is(err, fs.ErrPermission) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestErrorKinds tests that errors keep their messages and causes and can be
+// matched by kind
+func TestErrorKinds(t *testing.T) {
+	tmpDir := t.TempDir()
+	matesDir := filepath.Join(tmpDir, "mates")
+	if err := os.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("Failed to create mates directory: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: filepath.Join(tmpDir, "prompts")}
+	cm.lister = NewListerService(cm)
+
+	_, err := cm.Lister().Details("Missing Agent")
+	if !errors.Is(err, ErrChatmateNotFound) || ErrorKind(err) != ErrChatmateNotFound {
+		t.Errorf("Expected ErrChatmateNotFound, got %v", err)
+	}
+	if err == nil || err.Error() != "chatmate not found: Missing Agent" {
+		t.Errorf("Expected the message to be unchanged, got %v", err)
+	}
+
+	_, err = cm.GetInstalledChatmates()
+	if !errors.Is(err, ErrPromptsDirMissing) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected ErrPromptsDirMissing wrapping fs.ErrNotExist, got %v", err)
+	}
+
+	err = fileErrorf("failed to write %s: %w", "A.chatmode.md", &fs.PathError{Op: "open", Path: "A.chatmode.md", Err: fs.ErrPermission})
+	if ErrorKind(err) != ErrPermissionDenied || !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("Expected ErrPermissionDenied wrapping fs.ErrPermission, got %v", err)
+	}
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "A.chatmode.md" {
+		t.Errorf("Expected the cause to be kept, got %v", err)
+	}
+	if err := fileErrorf("failed to write: %w", io.ErrShortWrite); ErrorKind(err) != nil {
+		t.Errorf("Expected no kind for other file errors, got %v", ErrorKind(err))
+	}
+	if ErrorKind(&fs.PathError{Op: "open", Path: "x", Err: fs.ErrPermission}) != ErrPermissionDenied {
+		t.Error("Expected unmarked permission errors to be ErrPermissionDenied")
+	}
+
+	err = fmt.Errorf("hire: %w", errorf(ErrValidationFailed, "security validation failed for %s", "A.chatmode.md"))
+	if ErrorKind(err) != ErrValidationFailed {
+		t.Errorf("Expected wrapped ErrValidationFailed, got %v", err)
+	}
+	if ErrorKind(errors.New("something else")) != nil || ErrorKind(nil) != nil {
+		t.Error("Expected no kind for other errors")
+	}
+}
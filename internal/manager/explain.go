@@ -0,0 +1,315 @@
+// Package manager provides explanations of what ChatMate operations will do.
+package manager
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// Explanation describes what an operation would do and why, without doing it.
+//
+// It is more descriptive than a list of changes: besides the planned action
+// for each chatmate it records where chatmates come from, where they go, how
+// the requested names are matched, and which policies decide the outcome.
+//
+// Fields:
+//   - Operation: What is explained (e.g., "hire")
+//   - Sources: Where the chatmates are read from
+//   - TargetDir: The prompts directory the operation works on
+//   - Matching: How requested names are resolved to chatmate files
+//   - Policies: Rules that affect the outcome (overwrite, preservation, limits)
+//   - Actions: The planned action for each chatmate involved, in order
+type Explanation struct {
+	Operation string
+	Sources   []string
+	TargetDir string
+	Matching  []string
+	Policies  []string
+	Actions   []ExplainedAction
+}
+
+// ExplainedAction is the planned action for one chatmate.
+//
+// Fields:
+//   - Name: Display name, or the requested name when nothing matched
+//   - Filename: The chatmate file; empty when nothing matched
+//   - Action: What would happen (install, reinstall, skip, preserve, uninstall, not found)
+//   - Reason: Why this action was chosen
+type ExplainedAction struct {
+	Name     string
+	Filename string
+	Action   string
+	Reason   string
+}
+
+// Actions of an ExplainedAction.
+const (
+	ActionInstall   = "install"
+	ActionReinstall = "reinstall"
+	ActionSkip      = "skip"
+	ActionPreserve  = "preserve"
+	ActionUninstall = "uninstall"
+	ActionNotFound  = "not found"
+)
+
+// Count returns the number of chatmates with the given action.
+func (e *Explanation) Count(action string) int {
+	count := 0
+	for _, a := range e.Actions {
+		if a.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// ExplainInstall describes what an installation would do, without installing.
+//
+// The plan follows the same rules as InstallAll (when agentNames is empty)
+// and InstallSpecific, so it can be used to learn what 'chatmate hire' does
+// before running it.
+//
+// Parameters:
+//   - agentNames: Display names to install; all available chatmates if empty
+//   - force: Whether installed chatmates would be overwritten (--force)
+//
+// Returns:
+//   - *Explanation: What the installation would do and why
+//   - error: Chatmate discovery failure
+//
+// Example:
+//
+// explanation, err := installer.ExplainInstall([]string{"Solve Issue"}, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to explain installation: %w", err)
+//	}
+//
+//	fmt.Printf("%d actions\n", len(explanation.Actions))
+func (i *InstallerService) ExplainInstall(agentNames []string, force bool) (*Explanation, error) {
+	inventory, err := i.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := i.manager.newExplanation("hire")
+
+	if len(agentNames) == 0 {
+		explanation.Matching = []string{
+			fmt.Sprintf("No names given: every available chatmate (%s) is selected", output.FormatNumber(len(inventory.Available))),
+		}
+	} else {
+		explanation.Matching = []string{
+			"Each name must equal a chatmate's display name exactly (case-sensitive)",
+			"The display name is the filename without the \"Chatmate - \" prefix and the .chatmode.md extension",
+			"Names are installed in the given order; an unknown name stops the installation with an error",
+		}
+	}
+
+	if force {
+		explanation.Policies = append(explanation.Policies, "--force: installed chatmates are overwritten with the shipped version, including local edits")
+	} else {
+		explanation.Policies = append(explanation.Policies, "Chatmates that are already installed are skipped; use --force to overwrite them")
+	}
+	explanation.Policies = append(explanation.Policies,
+		"Filenames are validated and chatmate content is limited to 10 MB",
+		"Existing files that ChatMate doesn't ship (user-created chatmates) are never modified")
+	if len(agentNames) == 0 {
+		explanation.Policies = append(explanation.Policies,
+			confirmationPolicy("installing"),
+			"Progress is checkpointed after every chatmate; an interrupted run continues with 'chatmate hire --resume'")
+		if checkpoint := i.pendingCheckpoint(); checkpoint != nil {
+			explanation.Policies = append(explanation.Policies,
+				fmt.Sprintf("An interrupted installation has %d chatmate(s) remaining; a new installation replaces it", len(checkpoint.Pending)))
+		}
+	}
+	explanation.Policies = append(explanation.Policies, i.manager.directoryPolicies()...)
+
+	installAction := func(filename string) ExplainedAction {
+		action := ExplainedAction{Name: i.manager.getDisplayName(filename), Filename: filename}
+		variant, collides := caseVariant(filename, inventory.Installed)
+		switch {
+		case collides:
+			action.Action, action.Reason = ActionSkip, "the installed "+variant+" only differs in case and would be overwritten on case-insensitive filesystems"
+		case !inventory.IsInstalled(filename):
+			action.Action, action.Reason = ActionInstall, "not installed yet"
+		case force:
+			action.Action, action.Reason = ActionReinstall, "already installed, overwritten because of --force"
+		default:
+			action.Action, action.Reason = ActionSkip, "already installed"
+		}
+		return action
+	}
+
+	if len(agentNames) == 0 {
+		for _, filename := range inventory.Available {
+			explanation.Actions = append(explanation.Actions, installAction(filename))
+		}
+		for _, filename := range inventory.Installed {
+			if !inventory.IsAvailable(filename) {
+				explanation.Actions = append(explanation.Actions, ExplainedAction{
+					Name:     i.manager.getDisplayName(filename),
+					Filename: filename,
+					Action:   ActionPreserve,
+					Reason:   "user-created chatmate, not shipped with ChatMate",
+				})
+			}
+		}
+		return explanation, nil
+	}
+
+	for _, name := range agentNames {
+		filename, exists := i.manager.findChatmate(name, inventory.Available)
+		if !exists {
+			explanation.Actions = append(explanation.Actions, ExplainedAction{
+				Name:   name,
+				Action: ActionNotFound,
+				Reason: "no available chatmate has this name",
+			})
+			continue
+		}
+		explanation.Actions = append(explanation.Actions, installAction(filename))
+	}
+
+	return explanation, nil
+}
+
+// ExplainUninstall describes what an uninstallation would do, without
+// removing anything.
+//
+// The plan follows the same rules as UninstallAll (when agentNames is empty)
+// and UninstallSpecific.
+//
+// Parameters:
+//   - agentNames: Display names to uninstall; all repository chatmates if empty
+//
+// Returns:
+//   - *Explanation: What the uninstallation would do and why
+//   - error: Chatmate discovery failure
+//
+// Example:
+//
+// explanation, err := uninstaller.ExplainUninstall(nil)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to explain uninstallation: %w", err)
+//	}
+//
+//	fmt.Printf("%d actions\n", len(explanation.Actions))
+func (u *UninstallerService) ExplainUninstall(agentNames []string) (*Explanation, error) {
+	inventory, err := u.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := u.manager.newExplanation("uninstall")
+
+	if len(agentNames) == 0 {
+		explanation.Matching = []string{
+			"--all: every installed chatmate that ChatMate ships is selected",
+		}
+		explanation.Policies = append(explanation.Policies,
+			"User-created chatmates (installed files ChatMate doesn't ship) are preserved",
+			confirmationPolicy("uninstalling"))
+	} else {
+		explanation.Matching = []string{
+			"Each name must equal the display name of an installed chatmate exactly (case-sensitive)",
+			"The display name is the filename without the \"Chatmate - \" prefix and the .chatmode.md extension",
+			"Names are removed in the given order; an unknown name stops the uninstallation with an error",
+		}
+		explanation.Policies = append(explanation.Policies,
+			"Named chatmates are removed without confirmation, including user-created ones")
+	}
+	explanation.Policies = append(explanation.Policies,
+		"Only the chatmate files are removed; VS Code settings and chat history are kept")
+	explanation.Policies = append(explanation.Policies, u.manager.directoryPolicies()...)
+
+	if len(agentNames) == 0 {
+		for _, filename := range inventory.Installed {
+			action := ExplainedAction{Name: u.manager.getDisplayName(filename), Filename: filename}
+			if inventory.IsAvailable(filename) {
+				action.Action, action.Reason = ActionUninstall, "installed repository chatmate"
+			} else {
+				action.Action, action.Reason = ActionPreserve, "user-created chatmate, not shipped with ChatMate"
+			}
+			explanation.Actions = append(explanation.Actions, action)
+		}
+		return explanation, nil
+	}
+
+	for _, name := range agentNames {
+		filename, exists := u.manager.findChatmate(name, inventory.Installed)
+		if !exists {
+			explanation.Actions = append(explanation.Actions, ExplainedAction{
+				Name:   name,
+				Action: ActionNotFound,
+				Reason: "no installed chatmate has this name",
+			})
+			continue
+		}
+		reason := "installed repository chatmate"
+		if !inventory.IsAvailable(filename) {
+			reason = "installed user-created chatmate, named explicitly"
+		}
+		explanation.Actions = append(explanation.Actions, ExplainedAction{
+			Name:     name,
+			Filename: filename,
+			Action:   ActionUninstall,
+			Reason:   reason,
+		})
+	}
+
+	return explanation, nil
+}
+
+// newExplanation starts an explanation with the sources and target directory
+// of the manager.
+func (cm *ChatMateManager) newExplanation(operation string) *Explanation {
+	explanation := &Explanation{Operation: operation, TargetDir: cm.PromptsDir}
+
+	if cm.UseEmbedded {
+		explanation.Sources = append(explanation.Sources, "Chatmates embedded in the chatmate binary")
+	} else {
+		explanation.Sources = append(explanation.Sources, fmt.Sprintf("Chatmate files in %s (development checkout)", cm.MatesDir))
+	}
+	for _, source := range cm.Sources {
+		explanation.Sources = append(explanation.Sources, fmt.Sprintf("Chatmates from the %s source %s", source.Kind(), source.Location()))
+	}
+
+	switch {
+	case cm.Headless:
+		explanation.TargetDir += fmt.Sprintf(" (headless mode: the generic prompts directory, see %s)", HeadlessEnv)
+	case cm.ConfiguredPromptsDir != "":
+		explanation.TargetDir += fmt.Sprintf(" (symlinked from %s)", cm.ConfiguredPromptsDir)
+	}
+
+	return explanation
+}
+
+// directoryPolicies describes the policies that apply to every operation on
+// the prompts directory.
+func (cm *ChatMateManager) directoryPolicies() []string {
+	var policies []string
+
+	if patterns, err := cm.IgnorePatterns(); err == nil && len(patterns) > 0 {
+		policies = append(policies, fmt.Sprintf("Files matching %s (%s) are ignored", IgnoreFilename, strings.Join(patterns, ", ")))
+	}
+	if cm.FS.Timeout > 0 {
+		policies = append(policies, fmt.Sprintf("Each file operation times out after %s; reads are retried up to %d time(s), writes are not", cm.FS.Timeout, cm.FS.Retries))
+	}
+	if cm.promptsDirErr != nil {
+		policies = append(policies, fmt.Sprintf("The prompts directory is unusable, so the operation would fail: %v", cm.promptsDirErr))
+	}
+
+	return policies
+}
+
+// confirmationPolicy describes whether a bulk operation asks for confirmation.
+func confirmationPolicy(doing string) string {
+	if output.AssumeYes() {
+		return fmt.Sprintf("--yes: %s starts without asking for confirmation", doing)
+	}
+	return fmt.Sprintf("The full list is shown and confirmed before %s starts (skip with --yes)", doing)
+}
@@ -0,0 +1,118 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExplain tests the install and uninstall plans shown by --explain
+func TestExplain(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - B.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Mine.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create installed file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	explanation, err := cm.Installer().ExplainInstall(nil, false)
+	if err != nil {
+		t.Fatalf("ExplainInstall failed: %v", err)
+	}
+	if explanation.TargetDir != promptsDir || len(explanation.Sources) == 0 || len(explanation.Policies) == 0 {
+		t.Errorf("Expected sources, target directory, and policies, got %+v", explanation)
+	}
+	if explanation.Count(ActionInstall) != 1 || explanation.Count(ActionSkip) != 1 || explanation.Count(ActionPreserve) != 1 {
+		t.Errorf("Expected B installed, A skipped, and Mine preserved, got %+v", explanation.Actions)
+	}
+
+	explanation, err = cm.Installer().ExplainInstall([]string{"A", "Missing"}, true)
+	if err != nil {
+		t.Fatalf("ExplainInstall failed: %v", err)
+	}
+	if len(explanation.Actions) != 2 || explanation.Actions[0].Action != ActionReinstall || explanation.Actions[1].Action != ActionNotFound {
+		t.Errorf("Expected A reinstalled and Missing not found, got %+v", explanation.Actions)
+	}
+
+	explanation, err = cm.Uninstaller().ExplainUninstall(nil)
+	if err != nil {
+		t.Fatalf("ExplainUninstall failed: %v", err)
+	}
+	if explanation.Count(ActionUninstall) != 1 || explanation.Count(ActionPreserve) != 1 {
+		t.Errorf("Expected A uninstalled and Mine preserved, got %+v", explanation.Actions)
+	}
+
+	explanation, err = cm.Uninstaller().ExplainUninstall([]string{"Mine", "B"})
+	if err != nil {
+		t.Fatalf("ExplainUninstall failed: %v", err)
+	}
+	if explanation.Actions[0].Action != ActionUninstall || explanation.Actions[1].Action != ActionNotFound {
+		t.Errorf("Expected Mine uninstalled and B not found, got %+v", explanation.Actions)
+	}
+
+	// Explaining never changes the prompts directory
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Mine.chatmode.md"} {
+		if _, err := os.Stat(filepath.Join(promptsDir, name)); err != nil {
+			t.Errorf("Expected %s to be untouched: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - B.chatmode.md")); !os.IsNotExist(err) {
+		t.Error("Explaining should not install anything")
+	}
+}
+
+// TestSpecificNames tests that hire, uninstall, and --explain resolve
+// chatmates by display name in any case and by filename
+func TestSpecificNames(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(matesDir, "Solve Issue.chatmode.md"), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+	installed := filepath.Join(promptsDir, "Solve Issue.chatmode.md")
+
+	for _, name := range []string{"solve issue", "Solve Issue.chatmode.md", "Solve Issue"} {
+		explanation, err := cm.Installer().ExplainInstall([]string{name}, true)
+		if err != nil || explanation.Actions[0].Action == ActionNotFound {
+			t.Errorf("ExplainInstall(%q) = %+v, %v; want the chatmate found", name, explanation, err)
+		}
+		if _, err := cm.Installer().InstallSpecific(context.Background(), []string{name}, false); err != nil {
+			t.Fatalf("InstallSpecific(%q) failed: %v", name, err)
+		}
+		if _, err := os.Stat(installed); err != nil {
+			t.Fatalf("Expected %q to install the chatmate: %v", name, err)
+		}
+
+		explanation, err = cm.Uninstaller().ExplainUninstall([]string{name})
+		if err != nil || explanation.Actions[0].Action != ActionUninstall {
+			t.Errorf("ExplainUninstall(%q) = %+v, %v; want the chatmate uninstalled", name, explanation, err)
+		}
+		if err := cm.Uninstaller().UninstallSpecific(context.Background(), []string{name}); err != nil {
+			t.Fatalf("UninstallSpecific(%q) failed: %v", name, err)
+		}
+		if _, err := os.Stat(installed); !os.IsNotExist(err) {
+			t.Fatalf("Expected %q to uninstall the chatmate, got %v", name, err)
+		}
+	}
+
+	if _, err := cm.Installer().InstallSpecific(context.Background(), []string{"Solve"}, false); !errors.Is(err, ErrChatmateNotFound) {
+		t.Errorf("Expected a partial name not to be found, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Package manager provides install hooks for ChatMate agents.
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// DefaultHookTimeout is how long a hook may run unless the hookTimeout
+// setting says otherwise.
+const DefaultHookTimeout = 30 * time.Second
+
+// Hooks run around installations.
+const (
+	HookPreInstall  = "preInstall"
+	HookPostInstall = "postInstall"
+)
+
+// HookResult is the outcome of a hook.
+//
+// A failed hook is reported, but never stops an installation: the chatmates
+// are installed whether or not the hooks around them succeed.
+//
+// Fields:
+//   - Hook: When the hook ran: HookPreInstall or HookPostInstall
+//   - Chatmate: The chatmate that declared the hook in its frontmatter;
+//     empty for the hooks of the configuration file
+//   - Command: The shell command that ran
+//   - Error: Why the hook failed; empty when it succeeded
+type HookResult struct {
+	Hook     string `json:"hook"`
+	Chatmate string `json:"chatmate,omitempty"`
+	Command  string `json:"command"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Failed returns whether the hook failed.
+func (r HookResult) Failed() bool {
+	return r.Error != ""
+}
+
+// runHook runs a hook command with the shell and returns its outcome.
+//
+// The command runs in the prompts directory, or the current directory if it
+// does not exist yet, with these environment variables:
+//   - CHATMATE_HOOK: HookPreInstall or HookPostInstall
+//   - CHATMATE_PROMPTS_DIR: The prompts directory
+//   - CHATMATE_CHATMATE: The path of the chatmate, for chatmate hooks
+//   - CHATMATE_INSTALLED: The paths of the installed chatmates, one per
+//     line, for the postInstall hook of the configuration file
+//
+// The hook is stopped after HookTimeout. Its output is shown in verbose mode,
+// and the last line of it is part of the error when it fails.
+//
+// Parameters:
+//   - ctx: Stops the hook once done
+//   - hook: HookPreInstall or HookPostInstall
+//   - chatmate: The file of the chatmate that declared the hook, in the
+//     prompts directory; empty for the hooks of the configuration file
+//   - command: The shell command
+//   - env: Additional environment variables, as "KEY=value"
+//
+// Returns:
+//   - HookResult: The outcome, also reported as a warning if the hook failed
+func (cm *ChatMateManager) runHook(ctx context.Context, hook, chatmate, command string, env ...string) HookResult {
+	result := HookResult{Hook: hook, Chatmate: chatmate, Command: command}
+	out := cm.out()
+
+	timeout := cm.HookTimeout
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	if info, err := os.Stat(cm.PromptsDir); err == nil && info.IsDir() {
+		cmd.Dir = cm.PromptsDir
+	}
+	cmd.Env = append(os.Environ(), "CHATMATE_HOOK="+hook, "CHATMATE_PROMPTS_DIR="+cm.PromptsDir)
+	if chatmate != "" {
+		cmd.Env = append(cmd.Env, "CHATMATE_CHATMATE="+filepath.Join(cm.PromptsDir, chatmate))
+	}
+	cmd.Env = append(cmd.Env, env...)
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+	cmd.WaitDelay = time.Second
+
+	out.Debugf("Running %s hook: %s\n", hook, command)
+	err := cmd.Run()
+	if text := strings.TrimSpace(output.String()); text != "" {
+		out.Debugf("%s\n", text)
+	}
+	if err == nil {
+		return result
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	} else if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); lines[len(lines)-1] != "" {
+		err = fmt.Errorf("%w: %s", err, lines[len(lines)-1])
+	}
+	result.Error = err.Error()
+	if result.Chatmate != "" {
+		out.Warnf("The %s hook of %s failed: %s", hook, result.Chatmate, result.Error)
+	} else {
+		out.Warnf("The %s hook failed: %s", hook, result.Error)
+	}
+	return result
+}
+
+// chatmateHooks runs the hook that a chatmate declares in its frontmatter
+// for the given point of its installation.
+//
+// Hooks of chatmates are arbitrary commands from whoever wrote the
+// chatmate, so they only run when ChatmateHooks is enabled; otherwise the
+// user is told that they were skipped. Nothing runs in a dry run.
+//
+// Returns:
+//   - []HookResult: The outcome of the hook; nil when none ran
+func (cm *ChatMateManager) chatmateHooks(hook, filename string, content []byte) []HookResult {
+	meta, err := files.ParseFrontmatter(content)
+	if err != nil {
+		return nil
+	}
+	command := meta.Hooks.PreInstall
+	if hook == HookPostInstall {
+		command = meta.Hooks.PostInstall
+	}
+	if command == "" {
+		return nil
+	}
+
+	switch {
+	case cm.dryRun != nil:
+		cm.out().Debugf("Dry run: not running the %s hook of %s\n", hook, filename)
+		return nil
+	case !cm.ChatmateHooks:
+		cm.out().Warnf("Skipped the %s hook of %s; run 'chatmate config set chatmateHooks true' to allow chatmates to run commands", hook, filename)
+		return nil
+	}
+	return []HookResult{cm.runHook(cm.context(), hook, filename, command)}
+}
+
+// withHooks runs an installation between the preInstall and postInstall
+// hooks of the configuration file.
+//
+// The postInstall hook only runs if a chatmate was installed, and neither
+// hook runs in a dry run. Hook failures are added to the report and never
+// change the outcome of the installation.
+//
+// Parameters:
+//   - ctx: Stops the hooks once done
+//   - install: Installs the chatmates and returns its report
+//
+// Returns:
+//   - *InstallReport: The report of install with the outcomes of the hooks
+//   - error: The error of install
+func (i *InstallerService) withHooks(ctx context.Context, install func() (*InstallReport, error)) (*InstallReport, error) {
+	cm := i.manager
+	if cm.dryRun != nil || cm.PreInstallHook == "" && cm.PostInstallHook == "" {
+		return install()
+	}
+
+	var hooks []HookResult
+	if cm.PreInstallHook != "" {
+		hooks = append(hooks, cm.runHook(ctx, HookPreInstall, "", cm.PreInstallHook))
+	}
+	report, err := install()
+	if report == nil {
+		return report, err
+	}
+	if cm.PostInstallHook != "" && report.Installed() > 0 {
+		var installed []string
+		for _, result := range report.Results {
+			if result.Status == InstallInstalled || result.Status == InstallReinstalled {
+				installed = append(installed, filepath.Join(cm.PromptsDir, result.Filename))
+			}
+		}
+		hooks = append(hooks, cm.runHook(ctx, HookPostInstall, "", cm.PostInstallHook, "CHATMATE_INSTALLED="+strings.Join(installed, "\n")))
+	}
+	report.Hooks = hooks
+	return report, err
+}
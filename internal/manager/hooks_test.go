@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestInstallerService_Hooks tests the hooks of the configuration file and of
+// chatmates, which report failures without stopping the installation
+func TestInstallerService_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks are POSIX shell commands")
+	}
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	logPath := filepath.Join(t.TempDir(), "hooks.log")
+
+	mates := map[string]string{
+		"A.chatmode.md": "---\ndescription: A\nhooks:\n  postInstall: 'echo \"chatmate $CHATMATE_CHATMATE\" >> " + logPath + "'\n---\n",
+		"B.chatmode.md": "---\ndescription: B\nhooks:\n  preInstall: 'echo broken >&2; exit 3'\n---\n",
+		"C.chatmode.md": "---\ndescription: C\n---\n",
+	}
+	for name, content := range mates {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Concurrency: 1, ErrOutput: &stderr,
+		PreInstallHook:  "echo pre $CHATMATE_HOOK >> " + logPath,
+		PostInstallHook: `printf 'post %s\n' "$CHATMATE_INSTALLED" >> ` + logPath + "; exit 1"}
+	cm.installer = NewInstallerService(cm)
+
+	// Chatmate hooks are skipped until they are allowed
+	report, err := cm.Installer().InstallSpecific(context.Background(), []string{"A"}, false)
+	if err != nil {
+		t.Fatalf("InstallSpecific failed: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Hooks != nil || !strings.Contains(stderr.String(), "chatmateHooks") {
+		t.Errorf("Expected the hook of A to be skipped with a hint, got %+v, %q", report.Results, stderr.String())
+	}
+	if len(report.Hooks) != 2 || report.Hooks[0].Failed() || !report.Hooks[1].Failed() || report.Hooks[1].Hook != HookPostInstall {
+		t.Errorf("Expected the pre hook to succeed and the post hook to fail, got %+v", report.Hooks)
+	}
+
+	cm.ChatmateHooks = true
+	report, err = cm.Installer().InstallAll(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Expected failed hooks not to stop the installation, got %v", err)
+	}
+	if report.Installed() != 3 {
+		t.Errorf("Expected all chatmates to be installed, got %+v", report.Results)
+	}
+	for _, result := range report.Results {
+		switch result.Filename {
+		case "A.chatmode.md":
+			if len(result.Hooks) != 1 || result.Hooks[0].Failed() {
+				t.Errorf("Expected the post hook of A to succeed, got %+v", result.Hooks)
+			}
+		case "B.chatmode.md":
+			if len(result.Hooks) != 1 || result.Hooks[0].Error != "exit status 3: broken" {
+				t.Errorf("Expected the pre hook of B to fail, got %+v", result.Hooks)
+			}
+		}
+	}
+
+	log, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("Failed to read the hook log: %v", err)
+	}
+	want := "pre preInstall\npost " + filepath.Join(promptsDir, "A.chatmode.md") + "\n" +
+		"pre preInstall\nchatmate " + filepath.Join(promptsDir, "A.chatmode.md") + "\n"
+	if !strings.HasPrefix(string(log), want) || !strings.Contains(string(log), filepath.Join(promptsDir, "C.chatmode.md")) {
+		t.Errorf("Unexpected hooks:\n%s", log)
+	}
+
+	// Hooks that take too long are stopped
+	cm.PreInstallHook, cm.PostInstallHook, cm.HookTimeout = "sleep 5", "", 100*time.Millisecond
+	started := time.Now()
+	report, err = cm.Installer().InstallSpecific(context.Background(), []string{"C"}, true)
+	if err != nil || len(report.Hooks) != 1 || !strings.Contains(report.Hooks[0].Error, "timed out") || time.Since(started) > 3*time.Second {
+		t.Errorf("Expected the hook to time out, got %+v, %v", report, err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package manager provides ignore-list functionality for ChatMate agents.
+package manager
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFilename is the name of the ignore list in the prompts directory.
+//
+// Each line holds a filename pattern (see filepath.Match) for files that
+// ChatMate should neither count nor warn about, such as prompt files
+// managed by other tools. Blank lines and lines starting with # are skipped.
+// Chatmates shipped with ChatMate are never ignored.
+const IgnoreFilename = ".chatmateignore"
+
+// IgnorePath returns the full path of the ignore list.
+func (cm *ChatMateManager) IgnorePath() string {
+	return filepath.Join(cm.PromptsDir, IgnoreFilename)
+}
+
+// IgnorePatterns returns the filename patterns from the ignore list.
+//
+// A missing ignore list has no patterns. Invalid patterns are skipped with
+// a warning so a typo cannot stop every command.
+//
+// Returns:
+//   - []string: Valid patterns, in file order
+//   - error: Failure to read an existing ignore list
+//
+// Example:
+//
+// patterns, err := manager.IgnorePatterns()
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to read ignore list: %w", err)
+//	}
+func (cm *ChatMateManager) IgnorePatterns() ([]string, error) {
+	content, err := cm.FS.ReadFile(cm.IgnorePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ignore list: %w", err)
+	}
+
+	var patterns []string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			cm.out().Warnf("%s:%d: invalid pattern %q skipped", cm.IgnorePath(), line, pattern)
+			continue
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ignore list: %w", err)
+	}
+
+	return patterns, nil
+}
+
+// isIgnored reports whether filename matches one of the ignore patterns.
+func isIgnored(filename string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, filename); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestChatMateManager_IgnoreList tests excluding foreign prompt files from the inventory
+func TestChatMateManager_IgnoreList(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	content := []byte("---\ndescription: test\n---\n")
+
+	shipped := "Chatmate - Solve Issue.chatmode.md"
+	foreign := "Other Tool - Helper.chatmode.md"
+	if err := os.WriteFile(filepath.Join(matesDir, shipped), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	for _, name := range []string{shipped, foreign, "Mine.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	ignoreList := "# Prompt files managed by other tools\nOther Tool - *\n\n[invalid\nChatmate - *\n"
+	if err := os.WriteFile(filepath.Join(promptsDir, IgnoreFilename), []byte(ignoreList), 0644); err != nil {
+		t.Fatalf("Failed to create ignore list: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, inventoryPath: filepath.Join(t.TempDir(), "inventory.json")}
+	cm.validator = NewValidatorService(cm)
+
+	patterns, err := cm.IgnorePatterns()
+	if err != nil {
+		t.Fatalf("IgnorePatterns failed: %v", err)
+	}
+	if len(patterns) != 2 {
+		t.Errorf("Expected 2 valid patterns, got %v", patterns)
+	}
+
+	inventory, err := cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if len(inventory.Ignored) != 1 || inventory.Ignored[0] != foreign {
+		t.Errorf("Expected only %s to be ignored, got %v", foreign, inventory.Ignored)
+	}
+	if !inventory.IsInstalled(shipped) {
+		t.Error("Shipped chatmates must never be ignored")
+	}
+	if inventory.IsInstalled(foreign) {
+		t.Error("Ignored files should not be reported as installed")
+	}
+
+	report, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	for _, check := range report.Checks {
+		if check.Name == "orphaned-files" {
+			for _, file := range check.Files {
+				if file == foreign {
+					t.Error("Ignored files should not be reported as orphaned")
+				}
+			}
+		}
+	}
+
+	// Editing the ignore list takes effect on the next run despite the cache
+	if err := os.WriteFile(filepath.Join(promptsDir, IgnoreFilename), []byte("# nothing ignored\n"), 0644); err != nil {
+		t.Fatalf("Failed to update ignore list: %v", err)
+	}
+	cm.inventory = nil
+	inventory, err = cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if !inventory.IsInstalled(foreign) {
+		t.Error("Files should be counted again once the pattern is removed")
+	}
+}
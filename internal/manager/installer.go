@@ -2,19 +2,47 @@
 package manager
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
 	"github.com/jonassiebler/chatmate/pkg/security"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
 )
 
 // InstallerService handles chatmate installation operations.
+//
+// Fields:
+//   - AcceptBreaking: Whether updates to releases the registry marks as
+//     breaking are installed without asking
 type InstallerService struct {
+	AcceptBreaking bool
+
 	manager *ChatMateManager
+
+	// Installations queued for approval by this installer
+	queued []state.ApprovalRequest
+	// Outcome of every chatmate handled by this installer, in order
+	results []InstallResult
+	// Whether outcomes are only collected, for a worker of installEach
+	// whose outcomes are reported once the chatmates before it are done
+	buffered bool
 }
 
 // NewInstallerService creates a new installer service.
@@ -22,9 +50,95 @@ func NewInstallerService(manager *ChatMateManager) *InstallerService {
 	return &InstallerService{manager: manager}
 }
 
+// InstallStatus is the outcome of installing a single chatmate.
+type InstallStatus string
+
+// Installation outcomes.
+const (
+	InstallInstalled   InstallStatus = "installed"
+	InstallReinstalled InstallStatus = "reinstalled"
+	InstallSkipped     InstallStatus = "skipped"
+	InstallQueued      InstallStatus = "queued"
+	InstallExported    InstallStatus = "exported"
+	InstallMigrated    InstallStatus = "migrated"
+)
+
+// InstallResult describes the outcome of installing a single chatmate.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The file the chatmate was installed as (or would have been)
+//   - Status: Outcome of the installation
+//   - Detail: Short human-readable explanation, such as "already installed"
+//   - Hooks: The outcomes of the hooks the chatmate declares in its
+//     frontmatter, if they ran
+type InstallResult struct {
+	Name     string        `json:"name"`
+	Filename string        `json:"filename"`
+	Status   InstallStatus `json:"status"`
+	Detail   string        `json:"detail"`
+	Hooks    []HookResult  `json:"hooks,omitempty"`
+}
+
+// InstallReport is the structured result of an installation.
+//
+// Like validation reports, install reports contain no presentation logic;
+// the outcome of each chatmate is also passed to ChatMateManager.Progress
+// as it happens.
+//
+// Fields:
+//   - PromptsDir: The prompts directory chatmates were installed into
+//   - Results: The outcome of every chatmate, in order
+//   - Hooks: The outcomes of the hooks of the configuration file that ran
+//     before and after the installation
+type InstallReport struct {
+	PromptsDir string          `json:"promptsDir"`
+	Results    []InstallResult `json:"results"`
+	Hooks      []HookResult    `json:"hooks,omitempty"`
+}
+
+// Count returns the number of chatmates with the given outcome.
+func (r *InstallReport) Count(status InstallStatus) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Installed returns the number of chatmates that were installed or
+// reinstalled.
+func (r *InstallReport) Installed() int {
+	return r.Count(InstallInstalled) + r.Count(InstallReinstalled)
+}
+
+// record notes the outcome of a chatmate and reports it to the manager's
+// Progress function.
+func (i *InstallerService) record(filename string, status InstallStatus, detail string) {
+	i.add(InstallResult{Name: DisplayName(filename), Filename: filename, Status: status, Detail: detail})
+}
+
+// add notes an outcome like record, for outcomes with hooks.
+func (i *InstallerService) add(result InstallResult) {
+	i.results = append(i.results, result)
+	if i.manager.Progress != nil && !i.buffered {
+		i.manager.Progress(result)
+	}
+}
+
+// reportSince returns the outcomes recorded since the given number of
+// results, i.e. those of the operation that started at that point.
+func (i *InstallerService) reportSince(start int) *InstallReport {
+	return &InstallReport{PromptsDir: i.manager.PromptsDir, Results: append([]InstallResult{}, i.results[start:]...)}
+}
+
 // checkAndRebuildIfNeeded checks if the chatmate binary needs rebuilding
 // and rebuilds it if the source files are newer than the binary.
 func (i *InstallerService) checkAndRebuildIfNeeded() error {
+	out := i.manager.out()
+
 	// Only check when using embedded assets
 	if !i.manager.UseEmbedded {
 		return nil
@@ -33,14 +147,14 @@ func (i *InstallerService) checkAndRebuildIfNeeded() error {
 	// Get current binary path
 	binaryPath, err := os.Executable()
 	if err != nil {
-		fmt.Printf("⚠️  Could not determine binary path, skipping build check: %v\n", err)
+		out.Warnf("Could not determine binary path, skipping build check: %v", err)
 		return nil
 	}
 
 	// Get binary modification time
 	binaryInfo, err := os.Stat(binaryPath)
 	if err != nil {
-		fmt.Printf("⚠️  Could not stat binary, skipping build check: %v\n", err)
+		out.Warnf("Could not stat binary, skipping build check: %v", err)
 		return nil
 	}
 	binaryTime := binaryInfo.ModTime()
@@ -70,22 +184,22 @@ func (i *InstallerService) checkAndRebuildIfNeeded() error {
 		}
 		if filepath.Ext(path) == ".md" && info.ModTime().After(binaryTime) {
 			needsRebuild = true
-			fmt.Printf("📅 Found newer file: %s (modified: %s, binary: %s)\n",
+			out.Debugf("📅 Found newer file: %s (modified: %s, binary: %s)\n",
 				filepath.Base(path),
-				info.ModTime().Format(time.RFC3339),
-				binaryTime.Format(time.RFC3339))
+				output.FormatDateTime(info.ModTime()),
+				output.FormatDateTime(binaryTime))
 			return filepath.SkipDir // Stop walking
 		}
 		return nil
 	})
 
 	if err != nil {
-		fmt.Printf("⚠️  Error checking source files, skipping build check: %v\n", err)
+		out.Warnf("Error checking source files, skipping build check: %v", err)
 		return nil
 	}
 
 	if needsRebuild {
-		fmt.Printf("🔨 Source chatmate files are newer than binary, rebuilding...\n")
+		out.Warnf("Source chatmate files are newer than binary, rebuilding...")
 		return i.rebuildBinary()
 	}
 
@@ -94,234 +208,390 @@ func (i *InstallerService) checkAndRebuildIfNeeded() error {
 
 // rebuildBinary rebuilds the chatmate binary using go build
 func (i *InstallerService) rebuildBinary() error {
-	fmt.Printf("📦 Building chatmate binary with latest chatmate files...\n")
+	i.manager.out().Debugf("📦 Building chatmate binary with latest chatmate files...\n")
 
-	// Use go build to rebuild the binary
+	// Use go build to rebuild the binary; its output is diagnostic, so it
+	// stays out of the command's results on stdout
 	cmd := exec.Command("go", "build", "-o", "chatmate")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	errOut := i.manager.ErrOutput
+	if errOut == nil {
+		errOut = output.Stderr()
+	}
+	cmd.Stdout = errOut
+	cmd.Stderr = errOut
 
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to rebuild binary: %w", err)
 	}
 
-	fmt.Printf("✅ Binary rebuilt successfully\n")
+	i.manager.out().Debugf("✅ Binary rebuilt successfully\n")
 	return nil
 }
 
-// InstallAll installs all available chatmate agents.
+// InstallPlan describes what installing all available chatmates would do.
 //
-// This method installs all chatmate files from the source directory (or embedded
-// resources) to the VS Code user prompts directory. It handles file conflicts
-// based on the force parameter.
+// Fields:
+//   - PromptsDir: The prompts directory chatmates would be installed into
+//   - Force: Whether installed chatmates are reinstalled
+//   - Available: Number of available chatmates
+//   - Install: The chatmates to install or reinstall, with the planned status
+//   - Skip: Available chatmates that are already installed and kept
+//   - Modified: Installed chatmates edited since ChatMate installed them;
+//     kept without force, their edits are replaced with it
+//   - Preserve: Installed chatmates that are not available, such as the
+//     user's own, which are never touched
+//   - Collisions: Available chatmates that are not installed because an
+//     installed file only differs from them in case
+type InstallPlan struct {
+	PromptsDir string          `json:"promptsDir"`
+	Force      bool            `json:"force"`
+	Available  int             `json:"available"`
+	Install    []InstallResult `json:"install"`
+	Skip       []string        `json:"skip"`
+	Modified   []string        `json:"modified"`
+	Preserve   []string        `json:"preserve"`
+	Collisions []CaseCollision `json:"collisions,omitempty"`
+}
+
+// PlanInstall determines what installing all available chatmates would do,
+// without changing anything, so it can be shown before it is confirmed.
 //
 // Parameters:
-//   - force: If true, overwrites existing chatmate files; if false, skips existing files
+//   - force: If true, installed chatmates are reinstalled
 //
 // Returns:
-//   - error: Installation failure or system error
+//   - *InstallPlan: The planned installation
+//   - error: Chatmate discovery failure
 //
 // Example:
 //
-// err := installer.InstallAll(false)
+// plan, err := installer.PlanInstall(false)
 //
 //	if err != nil {
-//	   return fmt.Errorf("installation failed: %w", err)
+//	   return fmt.Errorf("planning failed: %w", err)
 //	}
-func (i *InstallerService) InstallAll(force bool) error {
+//
+//	fmt.Printf("%d chatmates to install\n", len(plan.Install))
+func (i *InstallerService) PlanInstall(force bool) (*InstallPlan, error) {
 	// Check if binary needs rebuilding first
 	if err := i.checkAndRebuildIfNeeded(); err != nil {
-		fmt.Printf("⚠️  Build check failed, continuing with current binary: %v\n", err)
+		i.manager.out().Warnf("Build check failed, continuing with current binary: %v", err)
 	}
 
-	availableChatmates, err := i.manager.GetAvailableChatmates()
-	if err != nil {
-		return err
+	// A new installation replaces the checkpoint of an interrupted one
+	if checkpoint := i.pendingCheckpoint(); checkpoint != nil {
+		i.manager.out().Warnf("A previous installation was interrupted with %d chatmate(s) remaining; run 'chatmate hire --resume' to continue it instead", len(checkpoint.Pending))
 	}
 
-	if len(availableChatmates) == 0 {
-		fmt.Println("No chatmates available to install")
-		return nil
-	}
-
-	// Get installed chatmates to show what already exists
-	installedChatmates, err := i.manager.GetInstalledChatmates()
+	inventory, err := i.manager.Inventory()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create a set of installed chatmates for quick lookup
-	installedSet := make(map[string]bool)
-	for _, filename := range installedChatmates {
-		installedSet[filename] = true
-	}
-
-	// Separate into categories for user visibility
-	var toInstall []string
-	var alreadyInstalled []string
-	var userCreated []string
-
-	// Get available chatmates as a set for lookup
-	availableSet := make(map[string]bool)
-	for _, filename := range availableChatmates {
-		availableSet[filename] = true
-	}
+	plan := &InstallPlan{PromptsDir: i.manager.PromptsDir, Force: force, Available: len(inventory.Available), Modified: []string{}}
 
 	// Categorize installed chatmates
-	for _, filename := range installedChatmates {
-		if availableSet[filename] {
-			alreadyInstalled = append(alreadyInstalled, filename)
-		} else {
-			userCreated = append(userCreated, filename)
+	modified := i.manager.modifiedSet(inventory.Installed)
+	for _, filename := range inventory.Installed {
+		if modified[filename] && inventory.IsAvailable(filename) {
+			plan.Modified = append(plan.Modified, filename)
+		}
+		if _, collides := caseVariant(filename, inventory.Available); collides && !inventory.IsAvailable(filename) {
+			continue
+		}
+		if !inventory.IsAvailable(filename) {
+			plan.Preserve = append(plan.Preserve, filename)
+		} else if !force {
+			plan.Skip = append(plan.Skip, filename)
 		}
 	}
 
-	// Determine what will be installed/reinstalled
-	for _, filename := range availableChatmates {
-		if installedSet[filename] {
-			if force {
-				toInstall = append(toInstall, filename)
-			}
-		} else {
-			toInstall = append(toInstall, filename)
+	// Determine what will be installed/reinstalled; a file that only
+	// differs in case would be overwritten on case-insensitive filesystems
+	for _, filename := range inventory.Available {
+		if variant, collides := caseVariant(filename, inventory.Installed); collides {
+			plan.Collisions = append(plan.Collisions, CaseCollision{Filename: filename, Installed: variant})
+		} else if !inventory.IsInstalled(filename) {
+			plan.Install = append(plan.Install, InstallResult{Name: DisplayName(filename), Filename: filename,
+				Status: InstallInstalled, Detail: string(InstallInstalled)})
+		} else if force {
+			plan.Install = append(plan.Install, InstallResult{Name: DisplayName(filename), Filename: filename,
+				Status: InstallReinstalled, Detail: string(InstallReinstalled)})
 		}
 	}
 
-	// Safety confirmation - show what will be installed
-	fmt.Printf("📦 INSTALLATION CONFIRMATION\n")
+	return plan, nil
+}
 
-	if len(toInstall) > 0 {
-		action := "INSTALLED"
-		if force && len(alreadyInstalled) > 0 {
-			action = "INSTALLED/REINSTALLED"
-		}
-		fmt.Printf("Repository chatmates to be %s (%d):\n", action, len(toInstall))
-		for _, filename := range toInstall {
-			displayName := i.manager.getDisplayName(filename)
-			status := "✅"
-			if installedSet[filename] && force {
-				status = "🔄"
-			}
-			fmt.Printf("  %s %s\n", status, displayName)
-		}
-	}
+// InstallPlanned installs the chatmates of a plan made by PlanInstall.
+//
+// Progress is recorded after each chatmate, so an interrupted installation
+// can be continued with Resume. When the plan reinstalls chatmates, the
+// prompts directory is archived first unless AutoBackup is disabled.
+//
+// Parameters:
+//   - ctx: Stops the installation before the next chatmate once done; the
+//     checkpoint is kept, so Resume can continue it
+//   - plan: The plan to carry out
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: Installation failure or cancellation
+func (i *InstallerService) InstallPlanned(ctx context.Context, plan *InstallPlan) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.installPlanned(ctx, plan) })
+}
 
-	if !force && len(alreadyInstalled) > 0 {
-		fmt.Printf("\nRepository chatmates already installed (will be SKIPPED) (%d):\n", len(alreadyInstalled))
-		for _, filename := range alreadyInstalled {
-			displayName := i.manager.getDisplayName(filename)
-			fmt.Printf("  ⏭️  %s\n", displayName)
-		}
+// installPlanned installs the chatmates of a plan, without running the hooks around it.
+func (i *InstallerService) installPlanned(ctx context.Context, plan *InstallPlan) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	if len(plan.Install) == 0 {
+		return i.reportSince(start), nil
 	}
 
-	if len(userCreated) > 0 {
-		fmt.Printf("\nUser-created chatmates (will be PRESERVED) (%d):\n", len(userCreated))
-		for _, filename := range userCreated {
-			displayName := i.manager.getDisplayName(filename)
-			fmt.Printf("  📝 %s\n", displayName)
+	pending := make([]string, 0, len(plan.Install))
+	reinstall := false
+	for _, planned := range plan.Install {
+		pending = append(pending, planned.Filename)
+		reinstall = reinstall || planned.Status == InstallReinstalled
+	}
+	if reinstall {
+		if _, err := i.manager.backupBefore(BackupReasonForce); err != nil {
+			return i.reportSince(start), err
 		}
 	}
 
-	fmt.Printf("\nDirectory: %s\n", i.manager.PromptsDir)
+	err := i.runCheckpointed(ctx, &state.Checkpoint{
+		Operation:  checkpointOperationInstall,
+		PromptsDir: plan.PromptsDir,
+		Force:      plan.Force,
+		Pending:    pending,
+		StartedAt:  time.Now().UTC(),
+	})
+	return i.reportSince(start), err
+}
 
-	if len(toInstall) == 0 {
-		fmt.Println("\n✅ All repository chatmates are already installed")
-		return nil
+// InstallAll installs all available chatmate agents.
+//
+// This method installs all chatmate files from the source directory (or embedded
+// resources) to the VS Code user prompts directory. It handles file conflicts
+// based on the force parameter. Nothing is confirmed; use PlanInstall and
+// InstallPlanned to show the plan first.
+//
+// Parameters:
+//   - ctx: Stops the installation before the next chatmate once done
+//   - force: If true, overwrites existing chatmate files; if false, skips existing files
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: Installation failure, cancellation, or system error
+//
+// Example:
+//
+// report, err := installer.InstallAll(ctx, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallAll(ctx context.Context, force bool) (*InstallReport, error) {
+	plan, err := i.PlanInstall(force)
+	if err != nil {
+		return nil, err
 	}
+	return i.InstallPlanned(ctx, plan)
+}
 
-	forceMsg := ""
-	if force {
-		forceMsg = " (with force reinstall)"
+// checkpointOperationInstall identifies InstallAll checkpoints.
+const checkpointOperationInstall = "install"
+
+// Resume continues an installation that was interrupted.
+//
+// InstallAll records a checkpoint after every installed chatmate. If it is
+// interrupted (Ctrl-C, crash, or a failed file), Resume installs the
+// remaining chatmates with the original options, without planning and
+// confirming again.
+//
+// Parameters:
+//   - ctx: Interrupts the resumed installation again once done
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: No interrupted installation, or an installation failure
+//
+// Example:
+//
+// report, err := installer.Resume(ctx)
+//
+//	if err != nil {
+//	   return fmt.Errorf("resume failed: %w", err)
+//	}
+func (i *InstallerService) Resume(ctx context.Context) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.resume(ctx) })
+}
+
+// resume continues an interrupted installation, without running the hooks around it.
+func (i *InstallerService) resume(ctx context.Context) (*InstallReport, error) {
+	checkpoint := i.pendingCheckpoint()
+	if checkpoint == nil {
+		return nil, fmt.Errorf("no interrupted installation to resume")
 	}
-	fmt.Printf("\nDo you want to proceed with installing these chatmates%s? (y/N): ", forceMsg)
 
-	var response string
-	fmt.Scanln(&response)
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	err := i.runCheckpointed(ctx, checkpoint)
+	return i.reportSince(start), err
+}
+
+// Interrupted returns the checkpoint of an interrupted installation into
+// the current prompts directory, which Resume continues, or nil if there
+// is none.
+func (i *InstallerService) Interrupted() *state.Checkpoint {
+	return i.pendingCheckpoint()
+}
 
-	if response != "y" && response != "Y" && response != "yes" && response != "YES" {
-		fmt.Println("❌ Installation operation cancelled by user")
+// pendingCheckpoint returns the checkpoint of an interrupted installation
+// into the current prompts directory, or nil if there is none.
+func (i *InstallerService) pendingCheckpoint() *state.Checkpoint {
+	if i.manager.checkpointPath == "" {
 		return nil
 	}
 
-	fmt.Printf("\nProceeding with installation...\n")
-
-	for _, chatmate := range availableChatmates {
-		if err := i.InstallChatmate(chatmate, force); err != nil {
-			return err
+	checkpoint, err := state.ReadCheckpoint(i.manager.checkpointPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			i.manager.out().Debugf("Could not read checkpoint: %v\n", err)
 		}
+		return nil
+	}
+	if checkpoint.Operation != checkpointOperationInstall || checkpoint.PromptsDir != i.manager.PromptsDir || len(checkpoint.Pending) == 0 {
+		return nil
+	}
+	return checkpoint
+}
+
+// runCheckpointed installs the pending chatmates of a checkpoint, recording
+// progress after each one so an interrupted run can be resumed. The
+// checkpoint is removed once every chatmate is installed.
+func (i *InstallerService) runCheckpointed(ctx context.Context, checkpoint *state.Checkpoint) error {
+	i.saveCheckpoint(checkpoint)
+
+	pending := slices.Clone(checkpoint.Pending)
+	err := i.installEach(ctx, pending, checkpoint.Force, func(filename string) {
+		checkpoint.Pending = slices.DeleteFunc(checkpoint.Pending, func(p string) bool { return p == filename })
+		checkpoint.Completed++
+		i.saveCheckpoint(checkpoint)
+	})
+	if err != nil {
+		return err
 	}
 
+	if i.manager.checkpointPath != "" {
+		if err := state.RemoveCheckpoint(i.manager.checkpointPath); err != nil {
+			i.manager.out().Debugf("Could not remove checkpoint: %v\n", err)
+		}
+	}
 	return nil
 }
 
+// saveCheckpoint records progress. Failures are only reported in verbose
+// mode because the checkpoint must never change the outcome of the install.
+func (i *InstallerService) saveCheckpoint(checkpoint *state.Checkpoint) {
+	if i.manager.checkpointPath == "" {
+		return
+	}
+	if err := state.WriteCheckpoint(i.manager.checkpointPath, checkpoint); err != nil {
+		i.manager.out().Debugf("Could not write checkpoint: %v\n", err)
+	}
+}
+
 // InstallSpecific installs specific chatmate agents by name.
 //
 // This method takes a list of agent names and attempts to install each one.
-// Agent names should match the display names (e.g., "Solve Issue") rather than
-// filenames. The method automatically converts names to appropriate filenames.
+// Names are resolved like everywhere else (see findChatmate): a display name
+// in any case (e.g., "solve issue") or a filename, with or without the
+// extension.
+// Before force replaces installed chatmates, the prompts directory is
+// archived unless AutoBackup is disabled.
 //
 // Parameters:
-//   - agentNames: List of chatmate display names to install
+//   - ctx: Stops the installation before the next chatmate once done
+//   - agentNames: List of chatmate names to install
 //   - force: If true, overwrites existing files; if false, skips existing files
 //
 // Returns:
-//   - error: Installation failure or agent not found error
+//   - *InstallReport: The outcome of every chatmate that was handled
+//   - error: Installation failure, cancellation, or agent not found error
 //
 // Example:
 //
 // names := []string{"Solve Issue", "Code Review", "Testing"}
-// err := installer.InstallSpecific(names, false)
+// report, err := installer.InstallSpecific(ctx, names, false)
 //
 //	if err != nil {
 //	   return fmt.Errorf("specific installation failed: %w", err)
 //	}
-func (i *InstallerService) InstallSpecific(agentNames []string, force bool) error {
+func (i *InstallerService) InstallSpecific(ctx context.Context, agentNames []string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.installSpecific(ctx, agentNames, force) })
+}
+
+// installSpecific installs chatmates by name, without running the hooks around it.
+func (i *InstallerService) installSpecific(ctx context.Context, agentNames []string, force bool) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
 	if len(agentNames) == 0 {
-		fmt.Println("No specific chatmates specified")
-		return nil
+		return i.reportSince(start), nil
 	}
 
 	// Check if binary needs rebuilding first
 	if err := i.checkAndRebuildIfNeeded(); err != nil {
-		fmt.Printf("⚠️  Build check failed, continuing with current binary: %v\n", err)
+		i.manager.out().Warnf("Build check failed, continuing with current binary: %v", err)
 	}
 
-	availableChatmates, err := i.manager.GetAvailableChatmates()
+	inventory, err := i.manager.Inventory()
 	if err != nil {
-		return err
+		return nil, err
 	}
+	availableChatmates := inventory.Available
 
-	// Create a map for quick lookup of available chatmates
-	availableMap := make(map[string]string)
-	for _, filename := range availableChatmates {
-		displayName := i.manager.getDisplayName(filename)
-		availableMap[displayName] = filename
+	// Back up the prompts directory before installed chatmates are replaced
+	if force {
+		for _, agentName := range agentNames {
+			if filename, exists := i.manager.findChatmate(agentName, availableChatmates); exists && inventory.IsInstalled(filename) {
+				if _, err := i.manager.backupBefore(BackupReasonForce); err != nil {
+					return i.reportSince(start), err
+				}
+				break
+			}
+		}
 	}
 
-	fmt.Printf("Installing specific chatmates: %v\n", agentNames)
-
 	// Install each specified agent
 	for _, agentName := range agentNames {
-		if filename, exists := availableMap[agentName]; exists {
-			if err := i.InstallChatmate(filename, force); err != nil {
-				return err
-			}
-		} else {
-			return fmt.Errorf("chatmate not found: %s", agentName)
+		if err := interrupted(ctx, "installation"); err != nil {
+			return i.reportSince(start), err
+		}
+		filename, exists := i.manager.findChatmate(agentName, availableChatmates)
+		if !exists {
+			return i.reportSince(start), errorf(ErrChatmateNotFound, "chatmate not found: %s", agentName)
+		}
+		if err := i.InstallChatmate(filename, force); err != nil {
+			return i.reportSince(start), err
 		}
 	}
 
-	return nil
+	return i.reportSince(start), nil
 }
 
 // InstallChatmate installs a single chatmate file.
 //
 // This method handles the installation of a single chatmate file, including
 // security validation, file existence checks, and content retrieval from
-// either embedded resources or external files.
+// either embedded resources or external files. A chatmate that is not
+// installed yet and needs approval by the team policy is queued for approval
+// instead (see QueuedApprovals).
 //
 // Parameters:
-//   - filename: The chatmate filename (e.g., "Chatmate - Solve Issue.chatmode.md")
+//   - filename: The chatmate filename (e.g., "Solve Issue.chatmode.md")
 //   - force: If true, overwrites existing files; if false, skips existing files
 //
 // Returns:
@@ -335,7 +605,7 @@ func (i *InstallerService) InstallSpecific(agentNames []string, force bool) erro
 func (i *InstallerService) InstallChatmate(filename string, force bool) error {
 	// Security validation
 	if err := security.ValidateChatmateFilename(filename); err != nil {
-		return fmt.Errorf("security validation failed: %w", err)
+		return errorf(ErrValidationFailed, "security validation failed: %w", err)
 	}
 
 	// Validate destination path safety
@@ -348,56 +618,706 @@ func (i *InstallerService) InstallChatmate(filename string, force bool) error {
 
 	destPath := filepath.Join(i.manager.PromptsDir, filename)
 
+	// Never overwrite a file whose name only differs in case
+	if collision, found := i.manager.installedCaseVariant(filename); found {
+		i.record(filename, InstallSkipped, "only differs in case from the installed "+collision.Installed)
+		return nil
+	}
+
 	// Check if already installed and not forcing
 	if !force {
-		if _, err := os.Stat(destPath); err == nil {
-			fmt.Printf("⏭️  %s (already installed)\n", filename)
+		if _, err := i.manager.FS.Stat(destPath); err == nil {
+			reason := "already installed"
+			if record, ok := i.manager.Provenance(filename); ok && i.manager.isModified(filename, record) {
+				reason = "modified locally, use --force to replace"
+			}
+			i.record(filename, InstallSkipped, reason)
 			return nil
 		}
 	}
 
-	// Get file content
-	var content []byte
-	var err error
+	content, err := i.installableContent(filename)
+	if err != nil {
+		return err
+	}
 
-	if i.manager.UseEmbedded {
-		// Use embedded file
-		content, err = assets.GetEmbeddedMateContent(filename)
-		if err != nil {
-			return fmt.Errorf("failed to read embedded chatmate %s: %w", filename, err)
+	// New chatmates must not share a display name with an installed file and
+	// may need approval by the team policy
+	source, location := i.manager.chatmateSource(filename)
+	if _, err := i.manager.FS.Stat(destPath); err != nil {
+		i.manager.installMu.Lock()
+		resolved, ok, err := i.resolveDuplicateName(filename, content)
+		if ok {
+			filename, destPath = resolved, filepath.Join(i.manager.PromptsDir, resolved)
+			ok, err = i.approved(filename, source, location, content)
 		}
-	} else {
-		// Use external file
-		sourcePath := filepath.Join(i.manager.MatesDir, filename)
-		content, err = os.ReadFile(sourcePath)
-		if err != nil {
-			return fmt.Errorf("failed to read chatmate file %s: %w", sourcePath, err)
+		i.manager.installMu.Unlock()
+		if !ok {
+			return err
 		}
 	}
 
+	// Write to destination, between the hooks the chatmate declares
+	hooks := i.manager.chatmateHooks(HookPreInstall, filename, content)
+	if err := i.manager.ensurePromptsDir(); err != nil {
+		return err
+	}
+	if written, err := i.manager.writeChatmate(filename, content); !written {
+		return err
+	}
+	i.manager.installMu.Lock()
+	i.manager.invalidateInventory()
+	i.manager.recordProvenance(filename, source, location, content)
+	i.manager.installMu.Unlock()
+	hooks = append(hooks, i.manager.chatmateHooks(HookPostInstall, filename, content)...)
+
+	// Determine the status message
+	status := InstallInstalled
+	if force {
+		if _, err := i.manager.FS.Stat(destPath); err == nil {
+			status = InstallReinstalled
+		}
+	}
+
+	i.add(InstallResult{Name: DisplayName(filename), Filename: filename, Status: status, Detail: string(status), Hooks: hooks})
+	return nil
+}
+
+// installableContent returns the content of an available chatmate after the
+// content checks an installation applies.
+func (i *InstallerService) installableContent(filename string) ([]byte, error) {
+	// Get file content
+	content, err := i.manager.GetChatmateContent(filename)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate content length for security
 	if err := security.ValidateContentLength(content, 10*1024*1024); err != nil { // 10MB limit
-		return fmt.Errorf("content validation failed for %s: %w", filename, err)
+		return nil, errorf(ErrValidationFailed, "content validation failed for %s: %w", filename, err)
 	}
 
 	// Validate file extension
 	if err := security.ValidateFileExtension(filename, []string{".md"}); err != nil {
-		return fmt.Errorf("file extension validation failed: %w", err)
+		return nil, errorf(ErrValidationFailed, "file extension validation failed: %w", err)
+	}
+
+	return content, nil
+}
+
+// InstallFromReader installs a chatmate whose content is read from r.
+//
+// This method enables Unix-style composition such as piping a chatmode file
+// from another tool into ChatMate. The chatmate is installed under the given
+// display name, and the content must contain YAML frontmatter.
+//
+// Parameters:
+//   - name: Display name for the new chatmate (e.g., "My Agent")
+//   - r: Reader providing the chatmode file content
+//   - force: If true, overwrites an existing chatmate with the same name
+//
+// Returns:
+//   - *InstallReport: The outcome of the chatmate
+//   - error: Validation, read, or file operation error
+//
+// Example:
+//
+// report, err := installer.InstallFromReader("My Agent", os.Stdin, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("stdin installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromReader(name string, r io.Reader, force bool) (*InstallReport, error) {
+	return i.withHooks(context.Background(), func() (*InstallReport, error) { return i.installFromReader(name, r, force) })
+}
+
+// installFromReader installs a chatmate read from r, without running the hooks around it.
+func (i *InstallerService) installFromReader(name string, r io.Reader, force bool) (*InstallReport, error) {
+	start := len(i.results)
+	name = security.SanitizeInput(name)
+	if name == "" {
+		return nil, fmt.Errorf("a chatmate name is required")
 	}
 
-	// Write to destination
-	if err := os.WriteFile(destPath, content, 0644); err != nil {
-		return fmt.Errorf("failed to write chatmate file %s: %w", destPath, err)
+	filename := files.NormalizeFilename(name)
+	if !strings.HasSuffix(filename, ".chatmode.md") {
+		filename += ".chatmode.md"
 	}
 
-	// Determine the status message
-	status := "installed"
-	if force {
-		if _, err := os.Stat(destPath); err == nil {
-			status = "reinstalled"
+	// Security validation
+	if err := security.ValidateChatmateFilename(filename); err != nil {
+		return nil, errorf(ErrValidationFailed, "security validation failed: %w", err)
+	}
+
+	if !security.IsPathSafe(i.manager.PromptsDir, filename) {
+		return nil, fmt.Errorf("destination path is not safe: %s", filename)
+	}
+
+	// Read one byte past the limit so oversized input is detected
+	const maxSize = 10 * 1024 * 1024 // 10MB limit
+	content, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chatmate content: %w", err)
+	}
+
+	if err := security.ValidateContentLength(content, maxSize); err != nil {
+		return nil, errorf(ErrValidationFailed, "content validation failed for %s: %w", filename, err)
+	}
+
+	if err := i.manager.checkFrontmatter(name, content); err != nil {
+		return nil, err
+	}
+	i.manager.warnNameLength(filename)
+
+	if err := i.manager.ensurePromptsDir(); err != nil {
+		return nil, err
+	}
+
+	if collision, found := i.manager.installedCaseVariant(filename); found {
+		return nil, fmt.Errorf("%s", collision)
+	}
+
+	destPath := filepath.Join(i.manager.PromptsDir, filename)
+
+	status := InstallInstalled
+	if _, err := i.manager.FS.Stat(destPath); err == nil {
+		if !force {
+			return nil, fmt.Errorf("chatmate already installed: %s (use --force to overwrite)", name)
+		}
+		status = InstallReinstalled
+	} else {
+		resolved, ok, err := i.resolveDuplicateName(filename, content)
+		if !ok {
+			return i.reportSince(start), err
+		}
+		filename = resolved
+		if ok, err := i.approved(filename, state.SourceStdin, "", content); !ok {
+			return i.reportSince(start), err
 		}
 	}
 
-	fmt.Printf("✅ %s (%s)\n", filename, status)
-	return nil
+	if written, err := i.manager.writeChatmate(filename, content); !written {
+		return i.reportSince(start), err
+	}
+	i.manager.invalidateInventory()
+	i.manager.recordProvenance(filename, state.SourceStdin, "", content)
+
+	i.record(filename, status, string(status))
+	return i.reportSince(start), nil
+}
+
+// resolveDuplicateName makes sure a new chatmate does not share its display
+// name with an installed file, such as "Solve Issue.chatmode.md" next to
+// "Chatmate - Solve Issue.chatmode.md", which makes @-mentions ambiguous.
+//
+// On a collision the user is asked whether to install the chatmate under the
+// next free name, such as "Solve Issue 2", instead; otherwise it is
+// skipped. A chatmate that is already installed under another name with the
+// same content is skipped without asking, so repeated runs do not create
+// more copies.
+//
+// Parameters:
+//   - filename: The filename the chatmate would be installed under
+//   - content: The content of the chatmate
+//
+// Returns:
+//   - string: The filename to install the chatmate under
+//   - bool: false if the chatmate is skipped
+//   - error: Failure to list the installed chatmates
+func (i *InstallerService) resolveDuplicateName(filename string, content []byte) (string, bool, error) {
+	// Nothing is installed yet before the prompts directory is created
+	if _, err := i.manager.FS.Stat(i.manager.PromptsDir); os.IsNotExist(err) {
+		return filename, true, nil
+	}
+
+	// Stdin and import installs have no mates directory, so the inventory
+	// cannot be used
+	filenames, err := i.manager.GetInstalledChatmates()
+	if err != nil {
+		return "", false, err
+	}
+
+	installed := make(map[string]string, len(filenames))
+	for _, name := range filenames {
+		installed[displayNameKey(name)] = name
+	}
+
+	existing, taken := installed[displayNameKey(filename)]
+	if !taken || existing == filename {
+		return filename, true, nil
+	}
+
+	// Find the next free name, unless an earlier run already used one
+	base := strings.TrimSuffix(filename, ".chatmode.md")
+	candidate := filename
+	for n := 2; ; n++ {
+		other, taken := installed[displayNameKey(candidate)]
+		if !taken {
+			break
+		}
+		if current, err := i.manager.FS.ReadFile(filepath.Join(i.manager.PromptsDir, other)); err == nil && bytes.Equal(current, content) {
+			i.record(filename, InstallSkipped, "already installed as "+other)
+			return "", false, nil
+		}
+		candidate = fmt.Sprintf("%s %d.chatmode.md", base, n)
+	}
+
+	i.manager.out().Warnf("%s has the same display name %q as the installed %s, which makes @-mentions ambiguous",
+		filename, DisplayName(filename), existing)
+	if !i.manager.out().Confirm("Install it as %q instead?", DisplayName(candidate)) {
+		i.record(filename, InstallSkipped, "skipped: same display name as "+existing)
+		return "", false, nil
+	}
+	return candidate, true, nil
+}
+
+// Export writes available chatmates to an arbitrary directory.
+//
+// This is the fallback for machines without VS Code: the chatmode files are
+// written to destDir so they can be copied to a machine that has VS Code or
+// used by other tools. Unlike installation, the VS Code prompts directory and
+// the inventory cache are left untouched.
+//
+// Parameters:
+//   - ctx: Stops the export before the next chatmate once done
+//   - destDir: Directory to write the chatmate files to (created if missing)
+//   - agentNames: Display names or filenames to export; all chatmates if empty
+//   - force: If true, overwrites existing files in destDir
+//
+// Returns:
+//   - int: Number of chatmate files written
+//   - error: Lookup, validation, or file operation error
+//
+// Example:
+//
+// exported, err := installer.Export(ctx, "./chatmates", nil, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("export failed: %w", err)
+//	}
+func (i *InstallerService) Export(ctx context.Context, destDir string, agentNames []string, force bool) (int, error) {
+	defer i.manager.bind(ctx)()
+	available, err := i.manager.GetAvailableChatmates()
+	if err != nil {
+		return 0, err
+	}
+
+	toExport := available
+	if len(agentNames) > 0 {
+		toExport = make([]string, 0, len(agentNames))
+		for _, name := range agentNames {
+			filename, found := i.manager.findChatmate(name, available)
+			if !found {
+				return 0, errorf(ErrChatmateNotFound, "chatmate not found: %s", name)
+			}
+			toExport = append(toExport, filename)
+		}
+	}
+
+	if err := i.manager.FS.EnsureDirMode(destDir, i.manager.dirMode()); err != nil {
+		return 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	exported := 0
+	for _, filename := range toExport {
+		if err := interrupted(ctx, "export"); err != nil {
+			return exported, err
+		}
+		if err := security.ValidateChatmateFilename(filename); err != nil {
+			return exported, errorf(ErrValidationFailed, "security validation failed: %w", err)
+		}
+		if !security.IsPathSafe(destDir, filename) {
+			return exported, fmt.Errorf("destination path is not safe: %s", filename)
+		}
+
+		destPath := filepath.Join(destDir, filename)
+		if !force {
+			if _, err := i.manager.FS.Stat(destPath); err == nil {
+				i.record(filename, InstallSkipped, "already exists")
+				continue
+			}
+		}
+
+		content, err := i.manager.GetChatmateContent(filename)
+		if err != nil {
+			return exported, err
+		}
+		if err := i.manager.FS.WriteFile(destPath, content, i.manager.fileMode()); err != nil {
+			return exported, fmt.Errorf("failed to write chatmate file %s: %w", destPath, err)
+		}
+
+		i.record(filename, InstallExported, string(InstallExported))
+		exported++
+	}
+
+	return exported, nil
+}
+
+// Import installs chatmate files from a directory into the prompts directory.
+//
+// This is the counterpart of Export: chatmates exported on one machine, or
+// chatmode files from any other source, are installed from srcDir. Each file
+// must have the .chatmode.md extension and YAML frontmatter.
+//
+// Parameters:
+//   - ctx: Stops the import before the next chatmate once done
+//   - srcDir: Directory containing chatmode files
+//   - agentNames: Display names or filenames to import; all files if empty
+//   - force: If true, overwrites chatmates that are already installed
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate file; empty when srcDir
+//     contains none
+//   - error: Lookup, validation, or file operation error
+//
+// Example:
+//
+// report, err := installer.Import(ctx, "./chatmates", nil, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("import failed: %w", err)
+//	}
+func (i *InstallerService) Import(ctx context.Context, srcDir string, agentNames []string, force bool) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	return i.copyDirectory(ctx, start, srcDir, agentNames, state.SourceImport, force)
+}
+
+// copyDirectory installs the chatmode files of srcDir, or the named ones,
+// with copyChatmates, recording the absolute directory as their location.
+// A directory without chatmode files yields an empty report.
+func (i *InstallerService) copyDirectory(ctx context.Context, start int, srcDir string, agentNames []string, kind string, force bool) (*InstallReport, error) {
+	candidates, err := scanChatmateDir(i.manager.FS.FileSystem(), srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chatmate directory %s: %w", srcDir, err)
+	}
+
+	toImport := candidates
+	if len(agentNames) > 0 {
+		toImport = make([]string, 0, len(agentNames))
+		for _, name := range agentNames {
+			filename, found := i.manager.findChatmate(name, candidates)
+			if !found {
+				return nil, errorf(ErrChatmateNotFound, "chatmate not found in %s: %s", srcDir, name)
+			}
+			toImport = append(toImport, filename)
+		}
+	}
+
+	if len(toImport) == 0 {
+		return i.reportSince(start), nil
+	}
+
+	// Provenance names the directory independently of the working directory
+	sourceDir, err := filepath.Abs(srcDir)
+	if err != nil {
+		sourceDir = srcDir
+	}
+
+	read := func(filename string) ([]byte, error) {
+		sourcePath := filepath.Join(srcDir, filename)
+		content, err := i.manager.FS.ReadFile(sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", sourcePath, err)
+		}
+		return content, nil
+	}
+	return i.copyChatmates(ctx, start, toImport, read, kind, sourceDir, force)
+}
+
+// copyChatmates validates and installs chatmates that are not available to
+// the manager, such as the files of a local directory, recording them with
+// the provenance kind and location.
+func (i *InstallerService) copyChatmates(ctx context.Context, start int, filenames []string, read func(string) ([]byte, error), kind, location string, force bool) (*InstallReport, error) {
+	if err := i.manager.ensurePromptsDir(); err != nil {
+		return nil, err
+	}
+
+	operation := "installation"
+	if kind == state.SourceImport {
+		operation = "import"
+	}
+	const maxSize = 10 * 1024 * 1024 // 10MB limit
+	for _, filename := range filenames {
+		if err := interrupted(ctx, operation); err != nil {
+			return i.reportSince(start), err
+		}
+		if err := security.ValidateChatmateFilename(filename); err != nil {
+			return i.reportSince(start), errorf(ErrValidationFailed, "security validation failed: %w", err)
+		}
+		if !security.IsPathSafe(i.manager.PromptsDir, filename) {
+			return i.reportSince(start), fmt.Errorf("destination path is not safe: %s", filename)
+		}
+
+		if collision, found := i.manager.installedCaseVariant(filename); found {
+			i.record(filename, InstallSkipped, "only differs in case from the installed "+collision.Installed)
+			continue
+		}
+		destPath := filepath.Join(i.manager.PromptsDir, filename)
+		status := InstallInstalled
+		if _, err := i.manager.FS.Stat(destPath); err == nil {
+			if !force {
+				i.record(filename, InstallSkipped, "already installed")
+				continue
+			}
+			status = InstallReinstalled
+		}
+
+		content, err := read(filename)
+		if err != nil {
+			return i.reportSince(start), err
+		}
+		if err := security.ValidateContentLength(content, maxSize); err != nil {
+			return i.reportSince(start), errorf(ErrValidationFailed, "content validation failed for %s: %w", filename, err)
+		}
+		if err := i.manager.checkFrontmatter(filename, content); err != nil {
+			return i.reportSince(start), err
+		}
+		i.manager.warnNameLength(filename)
+		if status == InstallInstalled {
+			resolved, ok, err := i.resolveDuplicateName(filename, content)
+			if !ok {
+				if err != nil {
+					return i.reportSince(start), err
+				}
+				continue
+			}
+			filename = resolved
+			if ok, err := i.approved(filename, kind, location, content); !ok {
+				if err != nil {
+					return i.reportSince(start), err
+				}
+				continue
+			}
+		}
+
+		if written, err := i.manager.writeChatmate(filename, content); !written {
+			if err != nil {
+				return i.reportSince(start), err
+			}
+			continue
+		}
+		i.manager.invalidateInventory()
+		i.manager.recordProvenance(filename, kind, location, content)
+
+		i.record(filename, status, string(status))
+	}
+
+	return i.reportSince(start), nil
+}
+
+// InstallFromGit installs chatmates from a git repository.
+//
+// The repository is cloned shallowly into the ChatMate cache directory, or
+// fetched again there once the clone is older than SourceTTL, and its
+// chatmates are read from its mates directory, or its root if it has none.
+// Every chatmate is validated like an imported one and recorded with the
+// repository as its provenance, so 'chatmate update' and 'chatmate sync'
+// leave it alone.
+//
+// Parameters:
+//   - ctx: Stops the installation before the next chatmate once done
+//   - url: The repository, in any form git clone accepts except plain http
+//   - ref: The branch or tag to install from; the default branch if empty
+//   - agentNames: Display names or filenames to install; all if empty
+//   - force: If true, overwrites chatmates that are already installed
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate
+//   - error: Clone, lookup, validation, or file operation error
+//
+// Example:
+//
+//	report, err := installer.InstallFromGit(ctx, "https://github.com/acme/prompts", "v2", nil, false)
+//	if err != nil {
+//	    return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromGit(ctx context.Context, url, ref string, agentNames []string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) { return i.installFromGit(ctx, url, ref, agentNames, force) })
+}
+
+// installFromGit installs chatmates from a git repository, without running the hooks around it.
+func (i *InstallerService) installFromGit(ctx context.Context, url, ref string, agentNames []string, force bool) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	url = strings.TrimSpace(url)
+	switch {
+	case url == "":
+		return nil, fmt.Errorf("repository URL must not be empty")
+	case strings.HasPrefix(url, "http://"):
+		return nil, fmt.Errorf("repository %s must use https", url)
+	}
+
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	source := &GitSource{URL: url, Ref: ref, CacheDir: cacheDir}
+	candidates, err := source.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toInstall := candidates
+	if len(agentNames) > 0 {
+		toInstall = make([]string, 0, len(agentNames))
+		for _, name := range agentNames {
+			filename, found := i.manager.findChatmate(name, candidates)
+			if !found {
+				return nil, errorf(ErrChatmateNotFound, "chatmate not found in %s: %s", source.Location(), name)
+			}
+			toInstall = append(toInstall, filename)
+		}
+	}
+	if len(toInstall) == 0 {
+		return nil, errorf(ErrChatmateNotFound, "no chatmates found in %s", source.Location())
+	}
+
+	read := func(filename string) ([]byte, error) {
+		r, err := source.Open(ctx, filename)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	return i.copyChatmates(ctx, start, toInstall, read, state.SourceRepository, source.Location(), force)
+}
+
+// InstallFromDirectory installs the chatmates of a local directory, such as
+// a directory of chatmates written by the user.
+//
+// Only files ending in .chatmode.md are considered. Every chatmate is
+// validated like an imported one and recorded with the directory as its
+// provenance, so its checksum is tracked like that of any other installed
+// chatmate.
+//
+// Parameters:
+//   - ctx: Stops the installation before the next chatmate once done
+//   - dir: The directory containing the chatmode files
+//   - agentNames: Display names or filenames to install; all if empty
+//   - force: If true, overwrites chatmates that are already installed
+//
+// Returns:
+//   - *InstallReport: The outcome of every chatmate
+//   - error: ErrChatmateNotFound if dir contains no (or not the named)
+//     chatmates, or a validation or file operation error
+//
+// Example:
+//
+//	report, err := installer.InstallFromDirectory(ctx, "./my-prompts", nil, false)
+//	if err != nil {
+//	    return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromDirectory(ctx context.Context, dir string, agentNames []string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) {
+		defer i.manager.bind(ctx)()
+		start := len(i.results)
+		if info, err := i.manager.FS.Stat(dir); err == nil && !info.IsDir() {
+			return nil, fmt.Errorf("%s is a file; install it with --file", dir)
+		}
+		report, err := i.copyDirectory(ctx, start, dir, agentNames, state.SourcePath, force)
+		if err == nil && len(report.Results) == 0 {
+			return nil, errorf(ErrChatmateNotFound, "no chatmates found in %s", dir)
+		}
+		return report, err
+	})
+}
+
+// InstallFromFile installs a single chatmode file from the local filesystem
+// under its own filename, validated like an imported chatmate and recorded
+// with the file as its provenance.
+//
+// Parameters:
+//   - ctx: Stops the installation once done
+//   - path: The chatmode file; its name must end in .chatmode.md
+//   - force: If true, overwrites the chatmate if it is already installed
+//
+// Returns:
+//   - *InstallReport: The outcome of the chatmate
+//   - error: Read, validation, or file operation error
+//
+// Example:
+//
+//	report, err := installer.InstallFromFile(ctx, "MyAgent.chatmode.md", false)
+//	if err != nil {
+//	    return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromFile(ctx context.Context, path string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) {
+		defer i.manager.bind(ctx)()
+		start := len(i.results)
+		info, err := i.manager.FS.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("%s is a directory; install its chatmates with --path", path)
+		}
+		location, err := filepath.Abs(path)
+		if err != nil {
+			location = path
+		}
+
+		read := func(string) ([]byte, error) {
+			content, err := i.manager.FS.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+			}
+			return content, nil
+		}
+		return i.copyChatmates(ctx, start, []string{files.NormalizeFilename(filepath.Base(path))}, read, state.SourcePath, location, force)
+	})
+}
+
+// InstallFromURL downloads a chatmode file from an https URL, such as the raw
+// file of a chatmate shared in a repository or gist, and installs it under
+// the filename the URL ends in.
+//
+// The download is limited to registry.MaxChatmateSize, verified against
+// sum when one is given, and validated like an imported chatmate before it
+// is written. The URL is recorded as the provenance, so 'chatmate update'
+// and 'chatmate sync' keep the chatmate (see UpdatePinned).
+//
+// Parameters:
+//   - ctx: Cancels the download
+//   - rawURL: The chatmode file; must be an https URL whose path ends in a
+//     .chatmode.md filename
+//   - sum: The hex encoded SHA-256 the file must have; not verified when empty
+//   - force: If true, overwrites the chatmate if it is already installed
+//
+// Returns:
+//   - *InstallReport: The outcome of the chatmate
+//   - error: URL, download, checksum, validation, or file operation error
+//
+// Example:
+//
+//	report, err := installer.InstallFromURL(ctx, "https://example.com/Agent.chatmode.md", "", false)
+//	if err != nil {
+//	    return fmt.Errorf("installation failed: %w", err)
+//	}
+func (i *InstallerService) InstallFromURL(ctx context.Context, rawURL, sum string, force bool) (*InstallReport, error) {
+	return i.withHooks(ctx, func() (*InstallReport, error) {
+		defer i.manager.bind(ctx)()
+		start := len(i.results)
+		u, err := url.Parse(strings.TrimSpace(rawURL))
+		switch {
+		case err != nil:
+			return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+		case u.Scheme != "https" || u.Host == "":
+			return nil, fmt.Errorf("%s must be an https URL", rawURL)
+		}
+		if digest, err := hex.DecodeString(sum); sum != "" && (err != nil || len(digest) != sha256.Size) {
+			return nil, fmt.Errorf("--sha256 must be a hex encoded SHA-256, got %q", sum)
+		}
+		filename := files.NormalizeFilename(path.Base(u.Path))
+		if !strings.HasSuffix(filename, ".chatmode.md") {
+			return nil, errorf(ErrValidationFailed, "%s does not name a .chatmode.md file", rawURL)
+		}
+
+		read := func(string) ([]byte, error) {
+			return i.manager.Registry.DownloadURL(ctx, u.String(), sum)
+		}
+		return i.copyChatmates(ctx, start, []string{filename}, read, state.SourceURL, u.String(), force)
+	})
 }
@@ -0,0 +1,625 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// TestInstallerService_InstallFromReader tests installing a chatmate from a reader
+func TestInstallerService_InstallFromReader(t *testing.T) {
+	promptsDir := t.TempDir()
+
+	cm := &ChatMateManager{PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+
+	content := "---\ndescription: 'Piped Agent'\n---\n\n# Piped Agent\n"
+
+	report, err := cm.Installer().InstallFromReader("Piped Agent", strings.NewReader(content), false)
+	if err != nil {
+		t.Fatalf("InstallFromReader failed: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].Status != InstallInstalled || report.Results[0].Filename != "Piped Agent.chatmode.md" {
+		t.Errorf("Expected the chatmate to be reported as installed, got %+v", report.Results)
+	}
+
+	installedPath := filepath.Join(promptsDir, "Piped Agent.chatmode.md")
+	installedContent, err := os.ReadFile(installedPath)
+	if err != nil {
+		t.Fatalf("Failed to read installed file: %v", err)
+	}
+	if string(installedContent) != content {
+		t.Errorf("Installed content mismatch. Expected: %s, Got: %s", content, string(installedContent))
+	}
+
+	// Installing again without force should fail
+	if _, err := cm.Installer().InstallFromReader("Piped Agent", strings.NewReader(content), false); err == nil {
+		t.Error("Expected error when installing existing chatmate without force")
+	}
+
+	// Force should overwrite
+	if _, err := cm.Installer().InstallFromReader("Piped Agent", strings.NewReader(content), true); err != nil {
+		t.Errorf("InstallFromReader with force failed: %v", err)
+	}
+
+	// Content without frontmatter should be rejected
+	if _, err := cm.Installer().InstallFromReader("No Frontmatter", strings.NewReader("# Plain"), false); err == nil {
+		t.Error("Expected error for content without YAML frontmatter")
+	}
+
+	// Unsafe names should be rejected
+	if _, err := cm.Installer().InstallFromReader("../escape", strings.NewReader(content), false); err == nil {
+		t.Error("Expected error for unsafe chatmate name")
+	}
+}
+
+// TestInstallerService_Render tests that rendering returns the content hire
+// installs, without installing it
+func TestInstallerService_Render(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+
+	content := "---\ndescription: 'Render Me'\n---\n\n# Render Me\n"
+	if err := os.WriteFile(filepath.Join(matesDir, "Render Me.chatmode.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test chatmate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(matesDir, "Huge.chatmode.md"), make([]byte, 10*1024*1024+1), 0644); err != nil {
+		t.Fatalf("Failed to create test chatmate: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+
+	rendering, err := cm.Installer().Render("render me")
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if rendering.Name != "Render Me" || rendering.Filename != "Render Me.chatmode.md" {
+		t.Errorf("Unexpected rendering of %q as %q", rendering.Name, rendering.Filename)
+	}
+	if _, err := os.Stat(promptsDir); !os.IsNotExist(err) {
+		t.Error("Render must not create the prompts directory")
+	}
+
+	if _, err := cm.Installer().InstallSpecific(context.Background(), []string{"Render Me"}, false); err != nil {
+		t.Fatalf("InstallSpecific failed: %v", err)
+	}
+	installed, err := os.ReadFile(filepath.Join(promptsDir, rendering.Filename))
+	if err != nil {
+		t.Fatalf("Failed to read installed file: %v", err)
+	}
+	if string(installed) != string(rendering.Content) {
+		t.Errorf("Rendered content differs from the installed file:\n%s\nvs.\n%s", rendering.Content, installed)
+	}
+
+	if _, err := cm.Installer().Render("Huge"); !errors.Is(err, ErrValidationFailed) {
+		t.Errorf("Expected a validation error for content hire rejects, got %v", err)
+	}
+	if _, err := cm.Installer().Render("Missing"); !errors.Is(err, ErrChatmateNotFound) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestInstallerService_Export tests exporting chatmates outside VS Code
+func TestInstallerService_Export(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	exportDir := filepath.Join(t.TempDir(), "export")
+
+	files := []string{"Chatmate - Solve Issue.chatmode.md", "Chatmate - Testing.chatmode.md"}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(matesDir, file), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+
+	exported, err := cm.Installer().Export(context.Background(), exportDir, []string{"Testing"}, false)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if exported != 1 {
+		t.Errorf("Expected 1 exported chatmate, got %d", exported)
+	}
+	if _, err := os.Stat(filepath.Join(exportDir, files[1])); err != nil {
+		t.Errorf("Exported file missing: %v", err)
+	}
+
+	// Existing files are skipped unless forced
+	exported, err = cm.Installer().Export(context.Background(), exportDir, nil, false)
+	if err != nil {
+		t.Fatalf("Export all failed: %v", err)
+	}
+	if exported != 1 {
+		t.Errorf("Expected only the new chatmate to be exported, got %d", exported)
+	}
+	if exported, _ = cm.Installer().Export(context.Background(), exportDir, nil, true); exported != 2 {
+		t.Errorf("Expected forced export to write 2 chatmates, got %d", exported)
+	}
+
+	if _, err := cm.Installer().Export(context.Background(), exportDir, []string{"Missing"}, false); err == nil {
+		t.Error("Expected error for unknown chatmate")
+	}
+
+	// Exporting must not install into the prompts directory
+	if _, err := os.Stat(promptsDir); !os.IsNotExist(err) {
+		t.Error("Export should not create the prompts directory")
+	}
+}
+
+// TestInstallerService_Import tests installing chatmates from a directory
+func TestInstallerService_Import(t *testing.T) {
+	srcDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+
+	valid := "---\ndescription: test\n---\n"
+	files := map[string]string{
+		"Chatmate - Solve Issue.chatmode.md": valid,
+		"My Agent.chatmode.md":               valid,
+		"notes.md":                           "not a chatmate",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{PromptsDir: promptsDir}
+	cm.installer = NewInstallerService(cm)
+
+	report, err := cm.Installer().Import(context.Background(), srcDir, []string{"Solve Issue"}, false)
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if report.Installed() != 1 {
+		t.Errorf("Expected 1 imported chatmate, got %d", report.Installed())
+	}
+
+	// Already installed chatmates are skipped unless forced
+	if report, err = cm.Installer().Import(context.Background(), srcDir, nil, false); err != nil || report.Installed() != 1 || report.Count(InstallSkipped) != 1 {
+		t.Errorf("Expected only the new chatmate to be imported, got %+v (%v)", report, err)
+	}
+	if report, _ = cm.Installer().Import(context.Background(), srcDir, nil, true); report.Installed() != 2 {
+		t.Errorf("Expected forced import to install 2 chatmates, got %d", report.Installed())
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "notes.md")); !os.IsNotExist(err) {
+		t.Error("Non-chatmode files should not be imported")
+	}
+
+	if _, err := cm.Installer().Import(context.Background(), srcDir, []string{"Missing"}, false); err == nil {
+		t.Error("Expected error for unknown chatmate")
+	}
+
+	// Files without frontmatter are rejected
+	if err := os.WriteFile(filepath.Join(srcDir, "Plain.chatmode.md"), []byte("# Plain"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if _, err := cm.Installer().Import(context.Background(), srcDir, []string{"Plain"}, false); err == nil {
+		t.Error("Expected error for chatmate without YAML frontmatter")
+	}
+}
+
+// TestInstallerService_Resume tests continuing an interrupted installation from its checkpoint
+func TestInstallerService_Resume(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	checkpointPath := filepath.Join(t.TempDir(), state.CheckpointFilename)
+
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatalf("Failed to create prompts directory: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, checkpointPath: checkpointPath}
+	cm.installer = NewInstallerService(cm)
+
+	if _, err := cm.Installer().Resume(context.Background()); err == nil {
+		t.Error("Expected error when there is nothing to resume")
+	}
+
+	// Simulate an installation interrupted after the first chatmate
+	err := state.WriteCheckpoint(checkpointPath, &state.Checkpoint{
+		Operation:  checkpointOperationInstall,
+		PromptsDir: promptsDir,
+		Pending:    []string{"B.chatmode.md", "C.chatmode.md"},
+		Completed:  1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+
+	if _, err := cm.Installer().Resume(context.Background()); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	for _, name := range []string{"B.chatmode.md", "C.chatmode.md"} {
+		if _, err := os.Stat(filepath.Join(promptsDir, name)); err != nil {
+			t.Errorf("Expected %s to be installed: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "A.chatmode.md")); !os.IsNotExist(err) {
+		t.Error("Completed chatmates should not be installed again")
+	}
+	if _, err := state.ReadCheckpoint(checkpointPath); !os.IsNotExist(err) {
+		t.Errorf("Checkpoint should be removed after a successful resume, got %v", err)
+	}
+
+	// Checkpoints of another prompts directory are ignored
+	err = state.WriteCheckpoint(checkpointPath, &state.Checkpoint{
+		Operation:  checkpointOperationInstall,
+		PromptsDir: filepath.Join(t.TempDir(), "other"),
+		Pending:    []string{"A.chatmode.md"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write checkpoint: %v", err)
+	}
+	if _, err := cm.Installer().Resume(context.Background()); err == nil {
+		t.Error("Expected error for a checkpoint of another prompts directory")
+	}
+}
+
+// TestInstallerService_Concurrent tests installing with a pool of workers:
+// outcomes are reported in order, and a failure keeps its chatmate pending
+func TestInstallerService_Concurrent(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	provenancePath := filepath.Join(t.TempDir(), state.ProvenanceFilename)
+	checkpointPath := filepath.Join(t.TempDir(), state.CheckpointFilename)
+
+	var names []string
+	for n := 0; n < 20; n++ {
+		name := fmt.Sprintf("Agent %02d.chatmode.md", n)
+		content := fmt.Sprintf("---\ndescription: Agent %d\nversion: '1.0.0'\n---\n", n)
+		if n == 12 {
+			// Over the content limit, so its installation fails
+			content += strings.Repeat("x", 10*1024*1024)
+		}
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	var progress []string
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Concurrency: 4,
+		provenancePath: provenancePath, checkpointPath: checkpointPath,
+		Progress: func(result InstallResult) { progress = append(progress, result.Filename) }}
+	cm.installer = NewInstallerService(cm)
+
+	report, err := cm.Installer().InstallAll(context.Background(), false)
+	if err == nil || !strings.Contains(err.Error(), "Agent 12") {
+		t.Fatalf("Expected the installation of Agent 12 to fail, got %v", err)
+	}
+
+	// Outcomes arrive in order, up to the chatmates started before the failure
+	if !slices.IsSorted(progress) || len(progress) < 12 || slices.Contains(progress, names[12]) {
+		t.Errorf("Expected ordered outcomes without Agent 12, got %v", progress)
+	}
+	if len(report.Results) != len(progress) {
+		t.Errorf("Expected the report to match the progress, got %d and %d outcomes", len(report.Results), len(progress))
+	}
+
+	// Every reported chatmate was installed and has its provenance
+	log, err := state.ReadProvenance(provenancePath)
+	if err != nil {
+		t.Fatalf("Failed to read provenance: %v", err)
+	}
+	for _, name := range progress {
+		if _, err := os.Stat(filepath.Join(promptsDir, name)); err != nil {
+			t.Errorf("Expected %s to be installed: %v", name, err)
+		}
+		if _, ok := log.Get(promptsDir, name); !ok {
+			t.Errorf("Expected provenance for %s", name)
+		}
+	}
+
+	// The failed chatmate and those never started remain to be resumed
+	checkpoint, err := state.ReadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatalf("Expected a checkpoint after the failure: %v", err)
+	}
+	if checkpoint.Pending[0] != names[12] || checkpoint.Completed != len(progress) || checkpoint.Completed+len(checkpoint.Pending) != len(names) {
+		t.Errorf("Expected Agent 12 first of the pending chatmates, got %+v", checkpoint)
+	}
+}
+
+// TestInstallerService_Cancelled tests that a cancelled installation stops
+// before the next chatmate and can be resumed
+func TestInstallerService_Cancelled(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	checkpointPath := filepath.Join(t.TempDir(), state.CheckpointFilename)
+
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, checkpointPath: checkpointPath}
+	cm.installer = NewInstallerService(cm)
+
+	// Interrupt once the first chatmate is installed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cm.Progress = func(InstallResult) { cancel() }
+
+	report, err := cm.Installer().InstallAll(ctx, false)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the installation to be cancelled, got %v", err)
+	}
+	if report.Installed() != 1 {
+		t.Errorf("Expected one chatmate installed before the interruption, got %+v", report.Results)
+	}
+	if cm.FS.Context != nil {
+		t.Error("The file operations should no longer be bound to the context")
+	}
+
+	checkpoint := cm.Installer().Interrupted()
+	if checkpoint == nil || len(checkpoint.Pending) != 2 {
+		t.Fatalf("Expected two chatmates left to resume, got %+v", checkpoint)
+	}
+
+	cm.Progress = nil
+	report, err = cm.Installer().Resume(context.Background())
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if report.Installed() != 2 {
+		t.Errorf("Expected the remaining chatmates to be installed, got %+v", report.Results)
+	}
+}
+
+// TestInstallerService_FaultRecovery tests that file operations failing in
+// the middle of an installation are reported, leave nothing half-written,
+// and can be resumed once the filesystem works again
+func TestInstallerService_FaultRecovery(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	checkpointPath := filepath.Join(t.TempDir(), state.CheckpointFilename)
+
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		fault files.Fault
+		want  error
+	}{
+		{"write failure", files.Fault{Op: "write", Pattern: "B.chatmode.md", Kind: files.FaultIO, After: 1}, syscall.EIO},
+		{"permission error", files.Fault{Op: "write", Pattern: "*.chatmode.md", Kind: files.FaultPermission, After: 2}, fs.ErrPermission},
+		{"partial read", files.Fault{Op: "read", Pattern: "B.chatmode.md", Kind: files.FaultPartialRead, After: 1}, io.ErrUnexpectedEOF},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.RemoveAll(promptsDir); err != nil {
+				t.Fatalf("Failed to clean prompts directory: %v", err)
+			}
+			cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, checkpointPath: checkpointPath}
+			cm.installer = NewInstallerService(cm)
+			cm.FS.Backend = files.NewFaultFS(files.OS{}, []files.Fault{tt.fault})
+
+			report, err := cm.Installer().InstallAll(context.Background(), false)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected the installation to fail with %v, got %v", tt.want, err)
+			}
+			if report.Installed() != 1 {
+				t.Errorf("Expected one chatmate installed before the fault, got %+v", report.Results)
+			}
+			if _, err := os.Stat(filepath.Join(promptsDir, "B.chatmode.md")); !os.IsNotExist(err) {
+				t.Errorf("The chatmate that failed must not be installed, got %v", err)
+			}
+
+			checkpoint := cm.Installer().Interrupted()
+			if checkpoint == nil || !slices.Equal(checkpoint.Pending, []string{"B.chatmode.md", "C.chatmode.md"}) {
+				t.Fatalf("Expected the failed and remaining chatmates to be resumable, got %+v", checkpoint)
+			}
+
+			cm.FS.Backend = nil
+			report, err = cm.Installer().Resume(context.Background())
+			if err != nil {
+				t.Fatalf("Resume failed: %v", err)
+			}
+			if report.Installed() != 2 {
+				t.Errorf("Expected the remaining chatmates to be installed, got %+v", report.Results)
+			}
+			for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+				if content, err := os.ReadFile(filepath.Join(promptsDir, name)); err != nil || string(content) != "---\ndescription: test\n---\n" {
+					t.Errorf("Expected %s to be installed completely, got %q, %v", name, content, err)
+				}
+			}
+			if cm.Installer().Interrupted() != nil {
+				t.Error("The checkpoint should be removed after a successful resume")
+			}
+		})
+	}
+}
+
+// TestInstallFromGit tests installing chatmates from a git repository
+func TestInstallFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	repo := t.TempDir()
+	commitFile(t, repo, "Deploy.chatmode.md", "---\ndescription: Deploy v1\n---\n")
+	if out, err := exec.Command("git", "-C", repo, "tag", "v1").CombinedOutput(); err != nil {
+		t.Fatalf("git tag failed: %v: %s", err, out)
+	}
+	commitFile(t, repo, "Deploy.chatmode.md", "---\ndescription: Deploy v2\n---\n")
+	commitFile(t, repo, "Broken.chatmode.md", "no frontmatter\n")
+	commitFile(t, repo, "README.md", "# Prompts\n")
+
+	newManager := func() *ChatMateManager {
+		cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), Output: io.Discard, ErrOutput: io.Discard,
+			provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+		cm.installer = NewInstallerService(cm)
+		return cm
+	}
+
+	cm := newManager()
+	report, err := cm.Installer().InstallFromGit(context.Background(), repo, "v1", []string{"Deploy"}, false)
+	if err != nil || report.Installed() != 1 {
+		t.Fatalf("Expected Deploy to be installed, got %+v, %v", report, err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(cm.PromptsDir, "Deploy.chatmode.md")); !strings.Contains(string(content), "Deploy v1") {
+		t.Errorf("Expected the chatmate of the v1 tag, got %q", content)
+	}
+	record, ok := cm.Provenance("Deploy.chatmode.md")
+	if !ok || record.Source != state.SourceRepository || record.Location != repo+"#v1" {
+		t.Errorf("Expected the repository in the provenance, got %+v", record)
+	}
+
+	if _, err := newManager().Installer().InstallFromGit(context.Background(), repo, "", nil, false); err == nil || !strings.Contains(err.Error(), "frontmatter") {
+		t.Errorf("Expected the chatmate without frontmatter to be rejected, got %v", err)
+	}
+	if _, err := newManager().Installer().InstallFromGit(context.Background(), repo, "", []string{"Missing"}, false); !errors.Is(err, ErrChatmateNotFound) {
+		t.Errorf("Expected a missing chatmate to be reported, got %v", err)
+	}
+	if _, err := newManager().Installer().InstallFromGit(context.Background(), "http://example.com/prompts.git", "", nil, false); err == nil {
+		t.Error("Expected a plain http repository to be rejected")
+	}
+}
+
+// TestInstallFromPath tests installing chatmates from a local directory or file
+func TestInstallFromPath(t *testing.T) {
+	dir := t.TempDir()
+	for filename, content := range map[string]string{
+		"Mine.chatmode.md":  "---\ndescription: Mine\n---\n",
+		"Other.chatmode.md": "---\ndescription: Other\n---\n",
+		"notes.md":          "# Notes\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	newManager := func() *ChatMateManager {
+		cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), Output: io.Discard, ErrOutput: io.Discard,
+			provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+		cm.installer = NewInstallerService(cm)
+		return cm
+	}
+
+	cm := newManager()
+	report, err := cm.Installer().InstallFromDirectory(context.Background(), dir, []string{"Mine"}, false)
+	if err != nil || report.Installed() != 1 {
+		t.Fatalf("Expected Mine to be installed, got %+v, %v", report, err)
+	}
+	record, ok := cm.Provenance("Mine.chatmode.md")
+	if !ok || record.Source != state.SourcePath || record.Location != dir || record.Checksum == "" {
+		t.Errorf("Expected the directory in the provenance, got %+v", record)
+	}
+	if report, err := cm.Installer().InstallFromDirectory(context.Background(), dir, nil, false); err != nil || report.Installed() != 1 {
+		t.Errorf("Expected only Other to be installed, got %+v, %v", report, err)
+	}
+
+	cm = newManager()
+	file := filepath.Join(dir, "Mine.chatmode.md")
+	if report, err := cm.Installer().InstallFromFile(context.Background(), file, false); err != nil || report.Installed() != 1 {
+		t.Fatalf("Expected the file to be installed, got %+v, %v", report, err)
+	}
+	if record, ok := cm.Provenance("Mine.chatmode.md"); !ok || record.Source != state.SourcePath || record.Location != file {
+		t.Errorf("Expected the file in the provenance, got %+v", record)
+	}
+
+	for name, install := range map[string]func() error{
+		"empty directory": func() error {
+			_, err := newManager().Installer().InstallFromDirectory(context.Background(), t.TempDir(), nil, false)
+			return err
+		},
+		"file as directory": func() error {
+			_, err := newManager().Installer().InstallFromDirectory(context.Background(), file, nil, false)
+			return err
+		},
+		"directory as file": func() error {
+			_, err := newManager().Installer().InstallFromFile(context.Background(), dir, false)
+			return err
+		},
+		"not a chatmode file": func() error {
+			_, err := newManager().Installer().InstallFromFile(context.Background(), filepath.Join(dir, "notes.md"), false)
+			return err
+		},
+		"missing file": func() error {
+			_, err := newManager().Installer().InstallFromFile(context.Background(), filepath.Join(dir, "Missing.chatmode.md"), false)
+			return err
+		},
+	} {
+		if err := install(); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+// TestInstallFromURL tests installing a chatmate downloaded from a URL
+func TestInstallFromURL(t *testing.T) {
+	const content = "---\ndescription: Shared\n---\n"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gist/Shared Agent.chatmode.md":
+			fmt.Fprint(w, content)
+		case "/gist/Broken.chatmode.md":
+			fmt.Fprint(w, "no frontmatter\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	newManager := func() *ChatMateManager {
+		cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), Output: io.Discard, ErrOutput: io.Discard,
+			Registry: &registry.Client{HTTP: server.Client()}, provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+		cm.installer = NewInstallerService(cm)
+		return cm
+	}
+	rawURL := server.URL + "/gist/Shared%20Agent.chatmode.md"
+
+	cm := newManager()
+	report, err := cm.Installer().InstallFromURL(context.Background(), rawURL, checksum([]byte(content)), false)
+	if err != nil || report.Installed() != 1 {
+		t.Fatalf("Expected the chatmate to be installed, got %+v, %v", report, err)
+	}
+	record, ok := cm.Provenance("Shared Agent.chatmode.md")
+	if !ok || record.Source != state.SourceURL || record.Location != rawURL {
+		t.Errorf("Expected the URL in the provenance, got %+v", record)
+	}
+
+	for _, tc := range []struct {
+		url, sum string
+	}{
+		{url: rawURL, sum: strings.Repeat("0", 64)},
+		{url: rawURL, sum: "not-a-checksum"},
+		{url: server.URL + "/gist/Broken.chatmode.md"},
+		{url: server.URL + "/gist/Missing.chatmode.md"},
+		{url: server.URL + "/gist/README.md"},
+		{url: "http://example.com/Agent.chatmode.md"},
+	} {
+		cm := newManager()
+		if _, err := cm.Installer().InstallFromURL(context.Background(), tc.url, tc.sum, false); err == nil {
+			t.Errorf("Expected %s with checksum %q to be rejected", tc.url, tc.sum)
+		}
+		if installed, _ := cm.GetInstalledChatmates(); len(installed) > 0 {
+			t.Errorf("Expected nothing to be installed from %s, got %v", tc.url, installed)
+		}
+	}
+}
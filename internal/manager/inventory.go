@@ -0,0 +1,198 @@
+// Package manager provides cached inventory functionality for ChatMate agents.
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/cache"
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// inventoryCacheVersion is bumped whenever the inventory format or key changes.
+const inventoryCacheVersion = 2
+
+// Inventory returns the available, installed, and outdated chatmates.
+//
+// Computing the inventory reads both chatmate directories and compares every
+// installed chatmate with its shipped version. The result is cached and
+// reused until the modification time of the prompts directory, the ignore
+// list, or the chatmate sources change, so repeated status and list
+// invocations respond instantly on large directories and network
+// filesystems. Git and HTTPS sources can change without notice, so the
+// cache file is not used while they are configured. Files matching the ignore list are excluded from Installed
+// and recorded in Ignored. Within a run the
+// inventory is computed at most once and shared by all services. Commands
+// that modify the prompts directory drop the cache. Set NoCache to ignore
+// the cache file.
+//
+// A missing prompts directory is treated as having nothing installed.
+//
+// Returns:
+//   - *cache.Inventory: The current inventory
+//   - error: Chatmate discovery failure
+//
+// Example:
+//
+// inventory, err := manager.Inventory()
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to get inventory: %w", err)
+//	}
+//
+// fmt.Printf("%d/%d installed\n", len(inventory.Installed), len(inventory.Available))
+func (cm *ChatMateManager) Inventory() (*cache.Inventory, error) {
+	key := cm.inventoryKey()
+
+	// Reuse the inventory computed earlier in this run
+	if cm.inventory != nil && cm.inventory.Key == key {
+		return cm.inventory, nil
+	}
+
+	useCache := cm.inventoryPath != "" && !cm.NoCache && cm.localSources()
+	if useCache {
+		if inventory, ok := cache.ReadInventory(cm.inventoryPath, key); ok {
+			cm.out().Debugf("Using cached inventory from %s\n", output.FormatDateTime(inventory.ComputedAt))
+			cm.inventory = inventory
+			return inventory, nil
+		}
+	}
+
+	inventory, err := cm.computeInventory()
+	if err != nil {
+		return nil, err
+	}
+	inventory.Key = key
+
+	if useCache {
+		if err := cache.WriteInventory(cm.inventoryPath, inventory); err != nil {
+			cm.out().Debugf("Failed to cache inventory: %v\n", err)
+		}
+		cm.inventoryStale = false
+	}
+
+	cm.inventory = inventory
+	return inventory, nil
+}
+
+// invalidateInventory drops the cached inventory after the prompts directory changed.
+//
+// Overwriting an installed chatmate does not change the directory's
+// modification time, so modifying operations must invalidate explicitly.
+func (cm *ChatMateManager) invalidateInventory() {
+	cm.inventory = nil
+
+	// Batch operations invalidate once per file; remove the cache file only once
+	if cm.inventoryPath == "" || cm.inventoryStale {
+		return
+	}
+	if err := cache.RemoveInventory(cm.inventoryPath); err != nil {
+		cm.out().Debugf("Failed to invalidate inventory cache: %v\n", err)
+	}
+	cm.inventoryStale = true
+}
+
+// inventoryKey describes the inputs an inventory is computed from.
+//
+// Embedded chatmates can only change when the executable is replaced, so the
+// executable's modification time stands in for the source directory.
+func (cm *ChatMateManager) inventoryKey() string {
+	source := cm.MatesDir
+	if cm.UseEmbedded {
+		if execPath, err := os.Executable(); err == nil {
+			source = execPath
+		}
+	}
+
+	key := fmt.Sprintf("v%d|%t|%s|%s|%s|%s|%s",
+		inventoryCacheVersion, cm.UseEmbedded,
+		source, cm.modTimeKey(source),
+		cm.PromptsDir, cm.modTimeKey(cm.PromptsDir),
+		cm.modTimeKey(cm.IgnorePath()))
+	for _, source := range cm.Sources {
+		key += "|" + source.Kind() + ":" + source.Location()
+		if dir, ok := source.(*DirectorySource); ok {
+			key += ":" + cm.modTimeKey(dir.Dir)
+		}
+	}
+	return key
+}
+
+// localSources returns whether every configured source is the embedded
+// chatmates or a directory, whose changes show in the inventory key.
+func (cm *ChatMateManager) localSources() bool {
+	for _, source := range cm.Sources {
+		switch source.(type) {
+		case EmbeddedSource, *DirectorySource:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// modTimeKey returns the modification time of path as a cache key component.
+func (cm *ChatMateManager) modTimeKey(path string) string {
+	info, err := cm.FS.Stat(path)
+	if err != nil {
+		return "missing"
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size())
+}
+
+// computeInventory builds the inventory from the chatmate directories.
+func (cm *ChatMateManager) computeInventory() (*cache.Inventory, error) {
+	available, err := cm.GetAvailableChatmates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available chatmates: %w", err)
+	}
+
+	installed, err := cm.GetInstalledChatmates()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to get installed chatmates: %w", err)
+	}
+
+	patterns, err := cm.IgnorePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &cache.Inventory{
+		Available:  available,
+		ComputedAt: time.Now().UTC(),
+	}
+
+	// Files other tools manage are left out of every count and check
+	for _, filename := range installed {
+		if !inventory.IsAvailable(filename) && isIgnored(filename, patterns) {
+			inventory.Ignored = append(inventory.Ignored, filename)
+			continue
+		}
+		inventory.Installed = append(inventory.Installed, filename)
+	}
+
+	for _, filename := range inventory.Installed {
+		if !inventory.IsAvailable(filename) {
+			continue
+		}
+
+		shipped, err := cm.GetChatmateContent(filename)
+		if err != nil {
+			continue
+		}
+		current, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, filename))
+		if err != nil {
+			continue
+		}
+		if !bytes.Equal(shipped, current) {
+			inventory.Outdated++
+		}
+	}
+
+	return inventory, nil
+}
@@ -0,0 +1,217 @@
+package manager
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// TestChatMateManager_Inventory tests inventory caching and invalidation
+func TestChatMateManager_Inventory(t *testing.T) {
+	tmpDir := t.TempDir()
+	matesDir := filepath.Join(tmpDir, "mates")
+	promptsDir := filepath.Join(tmpDir, "prompts")
+	for _, dir := range []string{matesDir, promptsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+	}
+
+	content := []byte("---\ndescription: 'Agent'\n---\n\n# Agent\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Agent.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test chatmate: %v", err)
+	}
+	installedPath := filepath.Join(promptsDir, "Agent.chatmode.md")
+	if err := os.WriteFile(installedPath, content, 0644); err != nil {
+		t.Fatalf("Failed to install test chatmate: %v", err)
+	}
+
+	cm := &ChatMateManager{
+		MatesDir:      matesDir,
+		PromptsDir:    promptsDir,
+		inventoryPath: filepath.Join(tmpDir, "cache", "inventory.json"),
+	}
+	cm.installer = NewInstallerService(cm)
+
+	// newRun drops the in-memory inventory, as if a new command was started
+	newRun := func() { cm.inventory = nil }
+
+	inventory, err := cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if len(inventory.Available) != 1 || len(inventory.Installed) != 1 || inventory.Outdated != 0 {
+		t.Fatalf("Unexpected inventory: %+v", inventory)
+	}
+
+	// The inventory is shared within a run
+	if again, _ := cm.Inventory(); again != inventory {
+		t.Error("Expected inventory to be reused within a run")
+	}
+
+	// Editing a file in place does not change the directory mtime, so the
+	// cached inventory is still served
+	info, err := os.Stat(promptsDir)
+	if err != nil {
+		t.Fatalf("Failed to stat prompts directory: %v", err)
+	}
+	if err := os.WriteFile(installedPath, []byte("---\nlocal edit\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify installed chatmate: %v", err)
+	}
+	if err := os.Chtimes(promptsDir, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Failed to restore directory mtime: %v", err)
+	}
+
+	newRun()
+	inventory, err = cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if inventory.Outdated != 0 {
+		t.Errorf("Expected cached inventory, got %d outdated", inventory.Outdated)
+	}
+
+	// NoCache forces recomputation
+	cm.NoCache = true
+	newRun()
+	inventory, err = cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if inventory.Outdated != 1 {
+		t.Errorf("Expected recomputed inventory with 1 outdated, got %d", inventory.Outdated)
+	}
+	cm.NoCache = false
+
+	// Reinstalling invalidates the cache even though the directory mtime is unchanged
+	if err := cm.Installer().InstallChatmate("Agent.chatmode.md", true); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if err := os.Chtimes(promptsDir, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Failed to restore directory mtime: %v", err)
+	}
+
+	newRun()
+	inventory, err = cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if inventory.Outdated != 0 {
+		t.Errorf("Expected fresh inventory after install, got %d outdated", inventory.Outdated)
+	}
+
+	// Adding a file changes the directory mtime and invalidates the cache
+	if err := os.WriteFile(filepath.Join(promptsDir, "Other.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create chatmate: %v", err)
+	}
+	if err := os.Chtimes(promptsDir, time.Now().Add(time.Minute), time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("Failed to update directory mtime: %v", err)
+	}
+
+	newRun()
+	inventory, err = cm.Inventory()
+	if err != nil {
+		t.Fatalf("Inventory failed: %v", err)
+	}
+	if len(inventory.Installed) != 2 {
+		t.Errorf("Expected 2 installed chatmates after directory change, got %d", len(inventory.Installed))
+	}
+}
+
+// TestScanChatmateDir tests batched directory scanning with early filtering
+func TestScanChatmateDir(t *testing.T) {
+	dir := t.TempDir()
+
+	// More entries than a single batch, mostly unrelated prompt files
+	for i := 0; i < scanBatchSize*2+10; i++ {
+		name := fmt.Sprintf("prompt-%03d.prompt.md", i)
+		if i%50 == 0 {
+			name = fmt.Sprintf("Agent %03d.chatmode.md", i)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("---\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "Folder.chatmode.md"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	chatmates, err := scanChatmateDir(files.OS{}, dir)
+	if err != nil {
+		t.Fatalf("scanChatmateDir failed: %v", err)
+	}
+
+	expected := []string{
+		"Agent 000.chatmode.md", "Agent 050.chatmode.md", "Agent 100.chatmode.md",
+		"Agent 150.chatmode.md", "Agent 200.chatmode.md", "Agent 250.chatmode.md",
+		"Agent 300.chatmode.md", "Agent 350.chatmode.md", "Agent 400.chatmode.md",
+		"Agent 450.chatmode.md", "Agent 500.chatmode.md",
+	}
+	if strings.Join(chatmates, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected sorted chatmates %v, got %v", expected, chatmates)
+	}
+
+	if _, err := scanChatmateDir(files.OS{}, filepath.Join(dir, "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist for missing directory, got %v", err)
+	}
+
+	// Limited scans stop after limit entries, on filesystems with and
+	// without batched reads
+	for _, fsys := range []files.FileSystem{files.OS{}, struct{ files.FileSystem }{files.OS{}}} {
+		scan, err := scanChatmateDirLimit(fsys, dir, 100)
+		if err != nil {
+			t.Fatalf("scanChatmateDirLimit failed: %v", err)
+		}
+		if scan.Entries != 100 || !scan.Truncated || !slices.IsSorted(scan.Chatmates) {
+			t.Errorf("Expected 100 truncated entries with %T, got %+v", fsys, scan)
+		}
+		if scan, err := scanChatmateDirLimit(fsys, dir, scanBatchSize*2+11); err != nil || scan.Truncated || len(scan.Chatmates) != len(expected) {
+			t.Errorf("Expected an exact limit to scan everything with %T, got %+v, %v", fsys, scan, err)
+		}
+	}
+}
+
+// TestLargePromptsDirWarning tests the warnings about prompts directories
+// with many unrelated files
+func TestLargePromptsDirWarning(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < LargeDirWarning+1; i++ {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("note-%04d.md", i)), nil, 0644); err != nil {
+			t.Fatalf("Failed to create note: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Solve Issue.chatmode.md"), []byte("---\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to create chatmate: %v", err)
+	}
+
+	var errOut bytes.Buffer
+	cm := &ChatMateManager{PromptsDir: dir, ErrOutput: &errOut}
+	installed, err := cm.GetInstalledChatmates()
+	if err != nil || len(installed) != 1 {
+		t.Fatalf("Expected 1 installed chatmate, got %v, %v", installed, err)
+	}
+	if !strings.Contains(errOut.String(), fmt.Sprintf("%d files that are not chatmates", LargeDirWarning+1)) {
+		t.Errorf("Expected a warning about unrelated files, got %q", errOut.String())
+	}
+
+	// The warning is printed once per manager
+	errOut.Reset()
+	if _, err := cm.GetInstalledChatmates(); err != nil || errOut.Len() != 0 {
+		t.Errorf("Expected no second warning, got %q, %v", errOut.String(), err)
+	}
+
+	// Hitting the scan limit says so, and how to raise it
+	cm = &ChatMateManager{PromptsDir: dir, ErrOutput: &errOut, ScanLimit: 10}
+	if _, err := cm.GetInstalledChatmates(); err != nil || !strings.Contains(errOut.String(), "Stopped scanning") || !strings.Contains(errOut.String(), "scanLimit") {
+		t.Errorf("Expected a warning about the scan limit, got %q, %v", errOut.String(), err)
+	}
+}
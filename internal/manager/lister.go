@@ -3,12 +3,21 @@ package manager
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/cache"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 )
 
-// ListerService handles chatmate listing and display operations.
+// ListerService handles chatmate listing and lookup operations.
+//
+// Fields:
+//   - Long: Whether listings show where each installed chatmate came from
 type ListerService struct {
+	Long bool
+
 	manager *ChatMateManager
 }
 
@@ -17,273 +26,352 @@ func NewListerService(manager *ChatMateManager) *ListerService {
 	return &ListerService{manager: manager}
 }
 
-// ListAll displays all available and installed chatmate agents.
+// Listing is the machine-readable form of a chatmate listing.
 //
-// This method provides a comprehensive overview of the chatmate ecosystem,
-// showing both available and installed agents with clear visual indicators.
+// Fields:
+//   - PromptsDir: The prompts directory the chatmates are installed in
+//   - Chatmates: The listed chatmates, sorted by filename
+//   - Total: The number of chatmates on all pages of a paginated listing
+//   - Limit: The number of chatmates per page of a paginated listing
+//   - Page: The page of a paginated listing, counting from 1
+type Listing struct {
+	PromptsDir string           `json:"promptsDir"`
+	Chatmates  []ListedChatmate `json:"chatmates"`
+	Total      int              `json:"total,omitempty"`
+	Limit      int              `json:"limit,omitempty"`
+	Page       int              `json:"page,omitempty"`
+}
+
+// Paginate keeps one page of the listing's chatmates, so a prompts
+// directory with thousands of chatmates can be listed a screen at a time.
+//
+// Parameters:
+//   - limit: The number of chatmates per page; must be positive
+//   - page: The page to keep, counting from 1
 //
 // Returns:
-//   - error: System error or listing failure
+//   - error: A page past the last one
 //
 // Example:
 //
-//err := lister.ListAll()
-//if err != nil {
-//    return fmt.Errorf("listing failed: %w", err)
-//}
-func (l *ListerService) ListAll() error {
-	availableChatmates, err := l.manager.GetAvailableChatmates()
-	if err != nil {
-		return err
+//	listing, _ := manager.Lister().Listing(false, true)
+//	if err := listing.Paginate(50, 2); err == nil {
+//		fmt.Printf("chatmates 51-%d of %d\n", 50+len(listing.Chatmates), listing.Total)
+//	}
+func (l *Listing) Paginate(limit, page int) error {
+	if limit < 1 || page < 1 {
+		return fmt.Errorf("invalid page %d of %d chatmates", page, limit)
 	}
-
-	installedChatmates, err := l.manager.GetInstalledChatmates()
-	if err != nil {
-		return err
+	l.Total, l.Limit, l.Page = len(l.Chatmates), limit, page
+	if pages := l.Pages(); page > pages && page > 1 {
+		return fmt.Errorf("page %d is out of range; the listing has %d page(s)", page, pages)
 	}
+	start := (page - 1) * limit
+	end := min(start+limit, len(l.Chatmates))
+	l.Chatmates = l.Chatmates[start:end]
+	return nil
+}
 
-	// Create a set of installed chatmates for quick lookup
-	installedSet := make(map[string]bool)
-	for _, filename := range installedChatmates {
-		installedSet[filename] = true
-	}
-
-	fmt.Printf("ChatMate Agents in VS Code Prompts Directory: %s\n\n", l.manager.PromptsDir)
-
-	if len(availableChatmates) == 0 {
-		fmt.Println("No chatmates available")
-		return nil
+// Pages returns the number of pages of a paginated listing, and 0 for a
+// listing that is not paginated.
+func (l *Listing) Pages() int {
+	if l.Limit == 0 {
+		return 0
 	}
+	return max(1, (l.Total+l.Limit-1)/l.Limit)
+}
 
-	// Sort chatmates for consistent display
-	sort.Strings(availableChatmates)
-
-	// Display all chatmates with installation status
-	for _, filename := range availableChatmates {
-		displayName := l.manager.getDisplayName(filename)
-		if installedSet[filename] {
-			fmt.Printf("✅ %s\n", displayName)
-		} else {
-			fmt.Printf("⬜ %s\n", displayName)
-		}
+// Offset returns the number of chatmates on the pages before the listed
+// ones.
+func (l *Listing) Offset() int {
+	if l.Page == 0 {
+		return 0
 	}
-
-	// Summary
-	installedCount := len(installedChatmates)
-	availableCount := len(availableChatmates)
-	fmt.Printf("\nSummary: %d/%d chatmates installed\n", installedCount, availableCount)
-
-	return nil
+	return (l.Page - 1) * l.Limit
 }
 
-// ListAvailable displays all available chatmate agents.
+// ListedChatmate is an entry of a Listing.
 //
-// This method shows only the chatmates that are available for installation,
-// regardless of their current installation status.
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename
+//   - Available: Whether ChatMate ships the chatmate
+//   - Installed: Whether the chatmate is installed in the prompts directory
+//   - Modified: Whether the installed chatmate was edited since ChatMate
+//     installed it, so its content no longer matches the recorded checksum
+//   - Provenance: Where an installed chatmate came from, in long listings
+//   - Owner: Who installed the chatmate into a shared prompts directory, in
+//     long listings
+type ListedChatmate struct {
+	Name       string `json:"name"`
+	Filename   string `json:"filename"`
+	Available  bool   `json:"available"`
+	Installed  bool   `json:"installed"`
+	Modified   bool   `json:"modified,omitempty"`
+	Provenance string `json:"provenance,omitempty"`
+	Owner      string `json:"owner,omitempty"`
+}
+
+// Listing returns the chatmates a listing shows.
 //
-// Returns:
-//   - error: System error or listing failure
+// With Long set, installed chatmates carry their provenance and owner.
 //
-// Example:
+// Parameters:
+//   - available: Whether to include available chatmates
+//   - installed: Whether to include installed chatmates; with neither,
+//     both are included
 //
-//err := lister.ListAvailable()
-//if err != nil {
-//    return fmt.Errorf("listing available failed: %w", err)
-//}
-func (l *ListerService) ListAvailable() error {
-	availableChatmates, err := l.manager.GetAvailableChatmates()
+// Returns:
+//   - *Listing: The listed chatmates
+//   - error: Chatmate discovery failure
+func (l *ListerService) Listing(available, installed bool) (*Listing, error) {
+	inventory, err := l.manager.Inventory()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if !available && !installed {
+		available, installed = true, true
 	}
 
-	fmt.Println("Available ChatMate Agents:")
-
-	if len(availableChatmates) == 0 {
-		fmt.Println("No chatmates available")
-		return nil
+	var filenames []string
+	if available {
+		filenames = append(filenames, inventory.Available...)
 	}
+	if installed {
+		for _, filename := range inventory.Installed {
+			if !available || !inventory.IsAvailable(filename) {
+				filenames = append(filenames, filename)
+			}
+		}
+	}
+	sort.Strings(filenames)
 
-	// Sort chatmates for consistent display
-	sort.Strings(availableChatmates)
+	return l.listing(filenames, inventory), nil
+}
 
-	// Display available chatmates
-	for i, filename := range availableChatmates {
-		displayName := l.manager.getDisplayName(filename)
-		fmt.Printf("%d. %s\n", i+1, displayName)
+// listing builds a listing of the given chatmates.
+func (l *ListerService) listing(filenames []string, inventory *cache.Inventory) *Listing {
+	var describe func(filename string) string
+	var owners map[string]string
+	if l.Long {
+		describe = l.manager.provenanceDescriber()
+		owners = l.manager.sharedOwners()
 	}
 
-	fmt.Printf("\nTotal: %d chatmates available\n", len(availableChatmates))
-	return nil
+	var installed []string
+	for _, filename := range filenames {
+		if inventory.IsInstalled(filename) {
+			installed = append(installed, filename)
+		}
+	}
+	modified := l.manager.modifiedSet(installed)
+
+	listing := &Listing{PromptsDir: l.manager.PromptsDir, Chatmates: make([]ListedChatmate, 0, len(filenames))}
+	for _, filename := range filenames {
+		chatmate := ListedChatmate{
+			Name:      l.manager.getDisplayName(filename),
+			Filename:  filename,
+			Available: inventory.IsAvailable(filename),
+			Installed: inventory.IsInstalled(filename),
+			Modified:  modified[filename],
+		}
+		if l.Long && chatmate.Installed {
+			chatmate.Provenance = describe(filename)
+			chatmate.Owner = owners[filename]
+		}
+		listing.Chatmates = append(listing.Chatmates, chatmate)
+	}
+	return listing
 }
 
-// ListInstalled displays all currently installed chatmate agents.
+// Search finds chatmate agents matching a search term.
 //
-// This method shows only the chatmates that are currently installed in the
-// VS Code prompts directory.
+// This method searches through available chatmate display names and returns
+// matches based on case-insensitive substring matching.
+//
+// Parameters:
+//   - searchTerm: The term to search for in chatmate names
 //
 // Returns:
-//   - error: System error or listing failure
+//   - *Listing: The matching chatmates, sorted by filename
+//   - error: Empty search term or chatmate discovery failure
 //
 // Example:
 //
-//err := lister.ListInstalled()
-//if err != nil {
-//    return fmt.Errorf("listing installed failed: %w", err)
-//}
-func (l *ListerService) ListInstalled() error {
-	installedChatmates, err := l.manager.GetInstalledChatmates()
-	if err != nil {
-		return err
+// matches, err := lister.Search("code")
+//
+//	if err != nil {
+//	   return fmt.Errorf("search failed: %w", err)
+//	}
+func (l *ListerService) Search(searchTerm string) (*Listing, error) {
+	if searchTerm == "" {
+		return nil, fmt.Errorf("search term cannot be empty")
 	}
 
-	fmt.Printf("Installed ChatMate Agents in: %s\n", l.manager.PromptsDir)
-
-	if len(installedChatmates) == 0 {
-		fmt.Println("No chatmates are currently installed")
-		return nil
+	inventory, err := l.manager.Inventory()
+	if err != nil {
+		return nil, err
 	}
 
-	// Sort chatmates for consistent display
-	sort.Strings(installedChatmates)
-
-	// Display installed chatmates
-	for i, filename := range installedChatmates {
+	// Search for matches
+	var matches []string
+	searchLower := strings.ToLower(searchTerm)
+	for _, filename := range inventory.Available {
 		displayName := l.manager.getDisplayName(filename)
-		fmt.Printf("%d. ✅ %s\n", i+1, displayName)
+		if strings.Contains(strings.ToLower(displayName), searchLower) {
+			matches = append(matches, filename)
+		}
 	}
+	sort.Strings(matches)
 
-	fmt.Printf("\nTotal: %d chatmates installed\n", len(installedChatmates))
-	return nil
+	return l.listing(matches, inventory), nil
 }
 
-// ListUninstalled displays chatmate agents that are available but not installed.
+// ChatmateDetails describes a single chatmate and its content.
 //
-// This method shows only the chatmates that could be installed but are not
-// currently present in the VS Code prompts directory.
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename
+//   - Source: "repository" for available chatmates, "user-created" for
+//     chatmates that are only installed
+//   - Installed: Whether the chatmate is installed in the prompts directory
+//   - Provenance: Where an installed chatmate came from
+//   - Owner: Who installed the chatmate into a shared prompts directory
+//   - Metadata: The frontmatter of the chatmate; nil if it cannot be read
+//   - Content: The complete chatmode file
+type ChatmateDetails struct {
+	Name       string             `json:"name"`
+	Filename   string             `json:"filename"`
+	Source     string             `json:"source"`
+	Installed  bool               `json:"installed"`
+	Provenance string             `json:"provenance,omitempty"`
+	Owner      string             `json:"owner,omitempty"`
+	Metadata   *files.Frontmatter `json:"metadata,omitempty"`
+	Content    string             `json:"content"`
+}
+
+// Details returns a single chatmate and its content.
+//
+// The chatmate is resolved by display name or filename, first among the
+// available chatmates and then among installed (user-created) chatmates.
+//
+// Parameters:
+//   - name: Display name or filename of the chatmate
 //
 // Returns:
-//   - error: System error or listing failure
+//   - *ChatmateDetails: The chatmate with its metadata and content
+//   - error: Chatmate not found or content retrieval failure
 //
 // Example:
 //
-//err := lister.ListUninstalled()
-//if err != nil {
-//    return fmt.Errorf("listing uninstalled failed: %w", err)
-//}
-func (l *ListerService) ListUninstalled() error {
-	availableChatmates, err := l.manager.GetAvailableChatmates()
-	if err != nil {
-		return err
-	}
-
-	installedChatmates, err := l.manager.GetInstalledChatmates()
+// details, err := lister.Details("Solve Issue")
+//
+//	if err != nil {
+//	   return fmt.Errorf("show failed: %w", err)
+//	}
+func (l *ListerService) Details(name string) (*ChatmateDetails, error) {
+	inventory, err := l.manager.Inventory()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create a set of installed chatmates for quick lookup
-	installedSet := make(map[string]bool)
-	for _, filename := range installedChatmates {
-		installedSet[filename] = true
-	}
+	details := &ChatmateDetails{Source: "repository"}
+	var content []byte
 
-	// Find uninstalled chatmates
-	var uninstalled []string
-	for _, filename := range availableChatmates {
-		if !installedSet[filename] {
-			uninstalled = append(uninstalled, filename)
+	if match, ok := l.manager.findChatmate(name, inventory.Available); ok {
+		details.Filename = match
+		content, err = l.manager.GetChatmateContent(match)
+		if err != nil {
+			return nil, err
+		}
+	} else if match, ok := l.manager.findChatmate(name, inventory.Installed); ok {
+		details.Filename = match
+		details.Source = "user-created"
+		content, err = l.manager.FS.ReadFile(filepath.Join(l.manager.PromptsDir, match))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read installed chatmate %s: %w", match, err)
 		}
+	} else {
+		return nil, errorf(ErrChatmateNotFound, "chatmate not found: %s", name)
 	}
 
-	fmt.Println("Uninstalled ChatMate Agents (Available for Installation):")
-
-	if len(uninstalled) == 0 {
-		fmt.Println("All available chatmates are already installed")
-		return nil
+	details.Name = l.manager.getDisplayName(details.Filename)
+	details.Content = string(content)
+	details.Installed = inventory.IsInstalled(details.Filename)
+	if details.Installed {
+		details.Provenance = l.manager.provenanceDescriber()(details.Filename)
+		details.Owner = l.manager.sharedOwners()[details.Filename]
 	}
-
-	// Sort chatmates for consistent display
-	sort.Strings(uninstalled)
-
-	// Display uninstalled chatmates
-	for i, filename := range uninstalled {
-		displayName := l.manager.getDisplayName(filename)
-		fmt.Printf("%d. ⬜ %s\n", i+1, displayName)
+	if meta, err := files.ParseFrontmatter(content); err == nil {
+		details.Metadata = meta
 	}
 
-	fmt.Printf("\nTotal: %d chatmates available for installation\n", len(uninstalled))
-	return nil
+	return details, nil
 }
 
-// Search finds chatmate agents matching a search term.
+// ChatmateLocation is where an installed chatmate is and where it came from.
 //
-// This method searches through available chatmate display names and returns
-// matches based on case-insensitive substring matching.
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: Filename in the prompts directory
+//   - Path: Absolute path of the installed file
+//   - SourcePath: Path of the available chatmate with the same filename in
+//     the mates directory; empty for embedded and user-created chatmates
+//   - Provenance: Where the installed file came from, in one line (see
+//     ListedChatmate.Provenance)
+//   - Modified: Whether the file was edited since ChatMate installed it
+type ChatmateLocation struct {
+	Name       string `json:"name"`
+	Filename   string `json:"filename"`
+	Path       string `json:"path"`
+	SourcePath string `json:"sourcePath,omitempty"`
+	Provenance string `json:"provenance,omitempty"`
+	Modified   bool   `json:"modified,omitempty"`
+}
+
+// Which returns where an installed chatmate is.
+//
+// The chatmate is resolved by display name or filename among the installed
+// chatmates, like Details does.
 //
 // Parameters:
-//   - searchTerm: The term to search for in chatmate names
+//   - name: Display name or filename of the chatmate
 //
 // Returns:
-//   - error: System error or search failure
+//   - *ChatmateLocation: The installed file and where it came from
+//   - error: Chatmate not installed, or the prompts directory cannot be read
 //
 // Example:
 //
-//err := lister.Search("code")
-//if err != nil {
-//    return fmt.Errorf("search failed: %w", err)
-//}
-func (l *ListerService) Search(searchTerm string) error {
-	if searchTerm == "" {
-		return fmt.Errorf("search term cannot be empty")
-	}
-
-	availableChatmates, err := l.manager.GetAvailableChatmates()
+//	location, err := lister.Which("Solve Issue")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(location.Path)
+func (l *ListerService) Which(name string) (*ChatmateLocation, error) {
+	inventory, err := l.manager.Inventory()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	installedChatmates, err := l.manager.GetInstalledChatmates()
-	if err != nil {
-		return err
+	filename, ok := l.manager.findChatmate(name, inventory.Installed)
+	if !ok {
+		return nil, errorf(ErrChatmateNotFound, "chatmate not installed: %s", name)
 	}
-
-	// Create a set of installed chatmates for quick lookup
-	installedSet := make(map[string]bool)
-	for _, filename := range installedChatmates {
-		installedSet[filename] = true
-	}
-
-	// Search for matches
-	var matches []string
-	searchLower := strings.ToLower(searchTerm)
-
-	for _, filename := range availableChatmates {
-		displayName := l.manager.getDisplayName(filename)
-		if strings.Contains(strings.ToLower(displayName), searchLower) {
-			matches = append(matches, filename)
-		}
+	path := filepath.Join(l.manager.PromptsDir, filename)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
 	}
 
-	fmt.Printf("Search Results for '%s':\n", searchTerm)
-
-	if len(matches) == 0 {
-		fmt.Println("No chatmates found matching the search term")
-		return nil
+	location := &ChatmateLocation{
+		Name:       l.manager.getDisplayName(filename),
+		Filename:   filename,
+		Path:       path,
+		Provenance: l.manager.provenanceDescriber()(filename),
+		Modified:   l.manager.modifiedSet([]string{filename})[filename],
 	}
-
-	// Sort matches for consistent display
-	sort.Strings(matches)
-
-	// Display search results
-	for i, filename := range matches {
-		displayName := l.manager.getDisplayName(filename)
-		status := "⬜"
-		if installedSet[filename] {
-			status = "✅"
-		}
-		fmt.Printf("%d. %s %s\n", i+1, status, displayName)
+	if dir, ok := l.manager.sourceOf(filename).(*DirectorySource); ok && inventory.IsAvailable(filename) {
+		location.SourcePath = dir.path(filename)
 	}
-
-	fmt.Printf("\nFound %d chatmates matching '%s'\n", len(matches), searchTerm)
-	return nil
+	return location, nil
 }
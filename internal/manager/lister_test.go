@@ -0,0 +1,134 @@
+package manager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestListerService_Details tests reading a chatmate's details
+func TestListerService_Details(t *testing.T) {
+	tmpDir := t.TempDir()
+	matesDir := filepath.Join(tmpDir, "mates")
+	promptsDir := filepath.Join(tmpDir, "prompts")
+	if err := os.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("Failed to create mates directory: %v", err)
+	}
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatalf("Failed to create prompts directory: %v", err)
+	}
+
+	content := "---\ndescription: 'Show Me'\n---\n\n# Show Me\n"
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - Show Me.chatmode.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test chatmate: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.lister = NewListerService(cm)
+
+	details, err := cm.Lister().Details("Show Me")
+	if err != nil {
+		t.Fatalf("Details failed: %v", err)
+	}
+	if details.Content != content {
+		t.Errorf("Content should equal the file content. Expected: %q, Got: %q", content, details.Content)
+	}
+	if details.Name != "Show Me" || details.Installed || details.Metadata == nil || details.Metadata.Description != "Show Me" {
+		t.Errorf("Unexpected details: %+v", details)
+	}
+
+	if _, err := cm.Lister().Details("Missing Agent"); err == nil {
+		t.Error("Expected error for unknown chatmate")
+	}
+}
+
+// TestListerService_Which tests resolving installed chatmates to their files
+func TestListerService_Which(t *testing.T) {
+	tmpDir := t.TempDir()
+	matesDir := filepath.Join(tmpDir, "mates")
+	promptsDir := filepath.Join(tmpDir, "prompts")
+	for dir, names := range map[string][]string{
+		matesDir:   {"Solve Issue.chatmode.md", "Review PR.chatmode.md"},
+		promptsDir: {"Solve Issue.chatmode.md", "My Helper.chatmode.md"},
+	} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("# "+name+"\n"), 0644); err != nil {
+				t.Fatalf("Failed to create %s: %v", name, err)
+			}
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	cm.lister = NewListerService(cm)
+
+	location, err := cm.Lister().Which("solve issue")
+	if err != nil {
+		t.Fatalf("Which failed: %v", err)
+	}
+	if location.Path != filepath.Join(promptsDir, "Solve Issue.chatmode.md") || location.SourcePath != filepath.Join(matesDir, "Solve Issue.chatmode.md") {
+		t.Errorf("Unexpected location: %+v", location)
+	}
+
+	location, err = cm.Lister().Which("My Helper.chatmode.md")
+	if err != nil || location.Name != "My Helper" || location.SourcePath != "" {
+		t.Errorf("Expected a user-created chatmate without source path, got %+v, %v", location, err)
+	}
+
+	if _, err := cm.Lister().Which("Review PR"); !errors.Is(err, ErrChatmateNotFound) {
+		t.Errorf("Expected ErrChatmateNotFound for a chatmate that is not installed, got %v", err)
+	}
+}
+
+// TestListingPaginate tests splitting listings into pages
+func TestListingPaginate(t *testing.T) {
+	newListing := func() *Listing {
+		listing := &Listing{}
+		for _, name := range []string{"A", "B", "C", "D", "E"} {
+			listing.Chatmates = append(listing.Chatmates, ListedChatmate{Name: name})
+		}
+		return listing
+	}
+	names := func(listing *Listing) string {
+		var names []string
+		for _, chatmate := range listing.Chatmates {
+			names = append(names, chatmate.Name)
+		}
+		return strings.Join(names, "")
+	}
+
+	for _, tt := range []struct {
+		limit, page int
+		want        string
+		pages       int
+		offset      int
+	}{
+		{2, 1, "AB", 3, 0},
+		{2, 3, "E", 3, 4},
+		{5, 1, "ABCDE", 1, 0},
+		{10, 1, "ABCDE", 1, 0},
+	} {
+		listing := newListing()
+		if err := listing.Paginate(tt.limit, tt.page); err != nil {
+			t.Fatalf("Paginate(%d, %d) failed: %v", tt.limit, tt.page, err)
+		}
+		if got := names(listing); got != tt.want || listing.Pages() != tt.pages || listing.Offset() != tt.offset || listing.Total != 5 {
+			t.Errorf("Paginate(%d, %d): expected %s of %d pages at %d, got %s of %d pages at %d (total %d)",
+				tt.limit, tt.page, tt.want, tt.pages, tt.offset, got, listing.Pages(), listing.Offset(), listing.Total)
+		}
+	}
+
+	if err := newListing().Paginate(2, 4); err == nil || !strings.Contains(err.Error(), "3 page(s)") {
+		t.Errorf("Expected an out of range error, got %v", err)
+	}
+	if err := (&Listing{}).Paginate(10, 1); err != nil {
+		t.Errorf("Expected the first page of an empty listing, got %v", err)
+	}
+	if pages := newListing().Pages(); pages != 0 {
+		t.Errorf("Expected 0 pages without pagination, got %d", pages)
+	}
+}
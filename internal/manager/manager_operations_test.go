@@ -1,10 +1,19 @@
 package manager
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 )
 
 // TestChatMateManager_GetAvailableChatmates tests retrieving available chatmates
@@ -277,3 +286,175 @@ func TestChatMateManager_UninstallChatmate(t *testing.T) {
 		t.Fatalf("UninstallChatmate failed on non-existent file: %v", err)
 	}
 }
+
+// TestChatMateManager_MemFS tests installing, validating, and uninstalling
+// on an in-memory filesystem
+func TestChatMateManager_MemFS(t *testing.T) {
+	memFS := files.NewMemFS()
+	root := filepath.Join(string(filepath.Separator), "chatmate-memfs-test")
+	matesDir := filepath.Join(root, "mates")
+	promptsDir := filepath.Join(root, "prompts")
+
+	if err := memFS.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md"} {
+		if err := memFS.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, FS: files.Policy{Backend: memFS}}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+	cm.validator = NewValidatorService(cm)
+
+	report, err := cm.Installer().InstallAll(context.Background(), false)
+	if err != nil || report.Installed() != 2 {
+		t.Fatalf("Expected both chatmates to be installed, got %+v, %v", report, err)
+	}
+	if installed, err := cm.GetInstalledChatmates(); err != nil || len(installed) != 2 {
+		t.Errorf("Expected two installed chatmates, got %v, %v", installed, err)
+	}
+
+	validation, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	for _, check := range validation.Checks {
+		if check.Status == CheckFail {
+			t.Errorf("Unexpected failed check %s: %s", check.Name, check.Message)
+		}
+	}
+
+	if err := cm.Uninstaller().UninstallChatmate("A.chatmode.md"); err != nil {
+		t.Fatalf("UninstallChatmate failed: %v", err)
+	}
+	if _, err := memFS.Stat(filepath.Join(promptsDir, "A.chatmode.md")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the chatmate to be removed, got %v", err)
+	}
+
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Errorf("Nothing should be written to the real filesystem, got %v", err)
+	}
+}
+
+// TestChatMateManager_SymlinkedPromptsDir tests operating on a prompts
+// directory that is symlinked into another location
+func TestChatMateManager_SymlinkedPromptsDir(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the VS Code prompts directory is redirected through HOME on Linux only")
+	}
+
+	home, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp directory: %v", err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv(HeadlessEnv, "0")
+
+	userDir := filepath.Join(home, ".config", "Code", "User")
+	if err := os.MkdirAll(userDir, 0755); err != nil {
+		t.Fatalf("Failed to create VS Code user directory: %v", err)
+	}
+	link := filepath.Join(userDir, "prompts")
+	target := filepath.Join(home, "dotfiles", "prompts")
+
+	t.Run("resolved symlink", func(t *testing.T) {
+		if err := os.MkdirAll(target, 0755); err != nil {
+			t.Fatalf("Failed to create dotfiles directory: %v", err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+		defer os.Remove(link)
+
+		cm, err := NewChatMateManager()
+		if err != nil {
+			t.Fatalf("NewChatMateManager failed: %v", err)
+		}
+		if cm.PromptsDir != target {
+			t.Errorf("Expected resolved prompts directory %s, got %s", target, cm.PromptsDir)
+		}
+		if cm.ConfiguredPromptsDir != link {
+			t.Errorf("Expected configured prompts directory %s, got %s", link, cm.ConfiguredPromptsDir)
+		}
+		if _, err := cm.Installer().InstallFromReader("Linked", strings.NewReader("---\ndescription: x\n---\n"), false); err != nil {
+			t.Fatalf("Install through symlink failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(target, "Linked.chatmode.md")); err != nil {
+			t.Errorf("Chatmate should be written to the symlink target: %v", err)
+		}
+	})
+
+	t.Run("broken symlink", func(t *testing.T) {
+		if err := os.Symlink(filepath.Join(home, "missing"), link); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+		defer os.Remove(link)
+
+		cm, err := NewChatMateManager()
+		if err != nil {
+			t.Fatalf("NewChatMateManager should not fail for a broken symlink: %v", err)
+		}
+		_, err = cm.Installer().InstallFromReader("Linked", strings.NewReader("---\ndescription: x\n---\n"), false)
+		if err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Errorf("Expected broken symlink error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(home, "missing")); !os.IsNotExist(err) {
+			t.Error("The symlink target must not be created")
+		}
+
+		report, err := cm.Validator().ValidateInstallation(context.Background())
+		if err != nil {
+			t.Fatalf("ValidateInstallation failed: %v", err)
+		}
+		if report.Valid() {
+			t.Error("Validation should fail for a broken prompts directory symlink")
+		}
+	})
+}
+
+// TestChatMateManager_Output tests that the services print to the manager's
+// writers instead of the global ones
+func TestChatMateManager_Output(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(matesDir, "A.chatmode.md"), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	// A provenance log that cannot be written makes the installation warn
+	blocked := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocked, nil, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	var global, out, errOut bytes.Buffer
+	output.SetWriters(&global, &global)
+	defer output.SetWriters(nil, nil)
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Output: &out, ErrOutput: &errOut,
+		provenancePath: filepath.Join(blocked, state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	if err := cm.Installer().InstallChatmate("A.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if !strings.Contains(errOut.String(), "Could not record where A.chatmode.md came from") {
+		t.Errorf("Expected the warning on the error output, got %q", errOut.String())
+	}
+
+	if err := cm.Uninstaller().UninstallAll(context.Background()); err != nil {
+		t.Fatalf("UninstallAll failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "UNINSTALL CONFIRMATION") || !strings.Contains(out.String(), "(y/N): yes (--yes)") {
+		t.Errorf("Expected the confirmation on the output, got %q", out.String())
+	}
+	if global.Len() != 0 {
+		t.Errorf("Nothing should be written to the global writers, got %q", global.String())
+	}
+}
@@ -0,0 +1,121 @@
+// Package manager provides access to chatmate frontmatter metadata.
+package manager
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// ChatmateMetadata is the parsed frontmatter of a chatmate.
+//
+// Fields:
+//   - Name: Display name of the chatmate (e.g., "Solve Issue")
+//   - Filename: The chatmate filename
+//   - Frontmatter: Description, examples, and the other metadata fields
+type ChatmateMetadata struct {
+	Name     string
+	Filename string
+	files.Frontmatter
+}
+
+// InstalledMetadata returns the metadata of the chatmates in the prompts directory.
+//
+// Ignored files and sync-conflict copies are left out. Files whose
+// frontmatter cannot be parsed are skipped, since 'chatmate validate'
+// reports them.
+//
+// Returns:
+//   - []ChatmateMetadata: Metadata of installed chatmates, sorted by filename
+//   - error: Prompts directory access error
+//
+// Example:
+//
+// chatmates, err := manager.InstalledMetadata()
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to read chatmates: %w", err)
+//	}
+func (cm *ChatMateManager) InstalledMetadata() ([]ChatmateMetadata, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	var chatmates []ChatmateMetadata
+	for _, filename := range inventory.Installed {
+		if isSyncConflict(filename) {
+			continue
+		}
+		f, err := cm.FS.Open(filepath.Join(cm.PromptsDir, filename))
+		if err != nil {
+			cm.out().Debugf("Could not read %s: %v\n", filename, err)
+			continue
+		}
+		if meta, ok := cm.readMetadata(filename, f); ok {
+			chatmates = append(chatmates, meta)
+		}
+	}
+	return chatmates, nil
+}
+
+// AvailableMetadata returns the metadata of the chatmates shipped with ChatMate.
+//
+// Returns:
+//   - []ChatmateMetadata: Metadata of available chatmates, sorted by filename
+//   - error: Chatmate source access error
+func (cm *ChatMateManager) AvailableMetadata() ([]ChatmateMetadata, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	var chatmates []ChatmateMetadata
+	for _, filename := range inventory.Available {
+		f, err := cm.openChatmate(filename)
+		if err != nil {
+			return nil, err
+		}
+		if meta, ok := cm.readMetadata(filename, f); ok {
+			chatmates = append(chatmates, meta)
+		}
+	}
+	return chatmates, nil
+}
+
+// checkFrontmatter checks chatmate content before it is written to the
+// prompts directory. Content is accepted if its frontmatter can be read
+// tolerantly (see files.ParseFrontmatter); YAML that other tools may read
+// differently is reported as a warning.
+func (cm *ChatMateManager) checkFrontmatter(name string, content []byte) error {
+	meta, err := files.ParseFrontmatter(content)
+	if err != nil {
+		return fmt.Errorf("chatmate %s has invalid frontmatter: %w", name, err)
+	}
+	for _, warning := range meta.Warnings {
+		cm.out().Warnf("Frontmatter of %s %s", name, warning)
+	}
+	return nil
+}
+
+// readMetadata parses the frontmatter of a chatmate read from f, reporting
+// failures in verbose mode. Only the frontmatter is read, so the bodies of
+// large chatmates are never loaded; f is closed.
+func (cm *ChatMateManager) readMetadata(filename string, f io.ReadCloser) (ChatmateMetadata, bool) {
+	defer f.Close()
+	frontmatter, err := files.ReadFrontmatter(f)
+	if err != nil {
+		cm.out().Debugf("Skipping metadata of %s: %v\n", filename, err)
+		return ChatmateMetadata{}, false
+	}
+	for _, warning := range frontmatter.Warnings {
+		cm.out().Debugf("Frontmatter of %s %s\n", filename, warning)
+	}
+	return ChatmateMetadata{
+		Name:        cm.getDisplayName(filename),
+		Filename:    filename,
+		Frontmatter: *frontmatter,
+	}, true
+}
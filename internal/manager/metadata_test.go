@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestChatMateManager_InstalledMetadata tests reading the frontmatter of installed chatmates
+func TestChatMateManager_InstalledMetadata(t *testing.T) {
+	promptsDir := t.TempDir()
+	installed := map[string]string{
+		"My Agent.chatmode.md": "---\ndescription: 'Mine'\nexamples:\n  - prompt: '@My Agent Review this'\n    tutorials: ['daily-dev']\n---\n",
+		"Plain.chatmode.md":    "# No frontmatter",
+	}
+	for name, content := range installed {
+		if err := os.WriteFile(filepath.Join(promptsDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: promptsDir}
+	chatmates, err := cm.InstalledMetadata()
+	if err != nil {
+		t.Fatalf("InstalledMetadata failed: %v", err)
+	}
+	if len(chatmates) != 1 {
+		t.Fatalf("Expected only the chatmate with frontmatter, got %+v", chatmates)
+	}
+	if chatmates[0].Name != "My Agent" || chatmates[0].Description != "Mine" || !chatmates[0].Examples[0].InTutorial("daily-dev") {
+		t.Errorf("Unexpected metadata: %+v", chatmates[0])
+	}
+}
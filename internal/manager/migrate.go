@@ -0,0 +1,248 @@
+// Package manager provides the migration of legacy chatmate filenames.
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/cache"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// LegacyPrefix is the filename prefix older releases gave the chatmates they
+// shipped, as in "Chatmate - Solve Issue.chatmode.md". The chatmates are now
+// shipped under their display name ("Solve Issue.chatmode.md").
+const LegacyPrefix = "Chatmate - "
+
+// LegacyAction is what the migration does with a legacy-named chatmate.
+type LegacyAction string
+
+// Migration actions.
+const (
+	// LegacyRename renames the file to its current name
+	LegacyRename LegacyAction = "rename"
+	// LegacyRemove removes the file because the chatmate is already
+	// installed under its current name and the file holds no changes
+	LegacyRemove LegacyAction = "remove"
+	// LegacyKeep leaves the file alone because the chatmate is already
+	// installed under its current name but the file has local changes
+	LegacyKeep LegacyAction = "keep"
+)
+
+// LegacyFile is an installed chatmate that still has a legacy filename.
+//
+// Fields:
+//   - Filename: The legacy filename in the prompts directory
+//   - Current: The name the chatmate is shipped under now
+//   - Action: What the migration does with the file
+type LegacyFile struct {
+	Filename string       `json:"filename"`
+	Current  string       `json:"current"`
+	Action   LegacyAction `json:"action"`
+}
+
+// currentName returns the name a chatmate with a legacy filename is shipped
+// under now, if it is still shipped.
+//
+// A source that still ships the legacy filename itself, such as an older
+// mates directory, keeps it.
+func currentName(filename string, inventory *cache.Inventory) (string, bool) {
+	if !strings.HasPrefix(filename, LegacyPrefix) || inventory.IsAvailable(filename) {
+		return "", false
+	}
+	current := strings.TrimPrefix(filename, LegacyPrefix)
+	return current, inventory.IsAvailable(current)
+}
+
+// LegacyFiles finds the installed chatmates that still have the filename an
+// older release gave them, and decides what the migration does with each.
+//
+// Returns:
+//   - []LegacyFile: The legacy-named chatmates, sorted by filename
+//   - error: Chatmate discovery failure
+func (cm *ChatMateManager) LegacyFiles() ([]LegacyFile, error) {
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	var legacy []LegacyFile
+	for _, filename := range inventory.Installed {
+		current, ok := currentName(filename, inventory)
+		if !ok {
+			continue
+		}
+
+		file := LegacyFile{Filename: filename, Current: current, Action: LegacyRename}
+		if inventory.IsInstalled(current) {
+			file.Action = LegacyKeep
+			if cm.unchangedCopy(filename, current) {
+				file.Action = LegacyRemove
+			}
+		}
+		legacy = append(legacy, file)
+	}
+
+	slices.SortFunc(legacy, func(a, b LegacyFile) int { return strings.Compare(a.Filename, b.Filename) })
+	return legacy, nil
+}
+
+// unchangedCopy reports whether a legacy-named file holds nothing that would
+// be lost by removing it: its content is the installed or shipped content of
+// the chatmate under its current name, or what ChatMate installed.
+func (cm *ChatMateManager) unchangedCopy(filename, current string) bool {
+	content, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, filename))
+	if err != nil {
+		return false
+	}
+	sum := checksum(content)
+
+	if installed, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, current)); err == nil && checksum(installed) == sum {
+		return true
+	}
+	if shipped, err := cm.GetChatmateContent(current); err == nil && checksum(shipped) == sum {
+		return true
+	}
+	record, ok := cm.Provenance(filename)
+	return ok && record.Checksum == sum
+}
+
+// MigrateLegacyNames gives installed chatmates with a legacy filename their
+// current name.
+//
+// A legacy-named chatmate is renamed, keeping any local changes. If the
+// chatmate is also installed under its current name, the legacy file is
+// removed when it holds no changes and kept otherwise; the duplicate-names
+// check reports the pair. The recorded state of a renamed file (provenance,
+// adoption, approval requests, and an interrupted installation) follows it.
+//
+// Parameters:
+//   - ctx: Stops the migration before the next file once done
+//
+// Returns:
+//   - *InstallReport: A migrated result for every file that was renamed or
+//     removed
+//   - error: Chatmate discovery or file operation error, or cancellation
+//
+// Example:
+//
+// report, err := installer.MigrateLegacyNames(ctx)
+//
+//	if err != nil {
+//	   return fmt.Errorf("migration failed: %w", err)
+//	}
+func (i *InstallerService) MigrateLegacyNames(ctx context.Context) (*InstallReport, error) {
+	defer i.manager.bind(ctx)()
+	start := len(i.results)
+	legacy, err := i.manager.LegacyFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range legacy {
+		if err := interrupted(ctx, "migration"); err != nil {
+			return i.reportSince(start), err
+		}
+		switch file.Action {
+		case LegacyRename:
+			renamed, err := i.manager.renameChatmate(file.Filename, file.Current)
+			if !renamed {
+				if err != nil {
+					return i.reportSince(start), err
+				}
+				continue
+			}
+			i.manager.invalidateInventory()
+			i.manager.renameState(file.Filename, file.Current)
+			i.record(file.Current, InstallMigrated, "renamed from "+file.Filename)
+		case LegacyRemove:
+			removed, err := i.manager.removeChatmate(file.Filename)
+			if !removed {
+				if err != nil {
+					return i.reportSince(start), err
+				}
+				continue
+			}
+			i.manager.invalidateInventory()
+			i.manager.renameState(file.Filename, file.Current)
+			i.record(file.Filename, InstallMigrated, "duplicate of "+file.Current+" removed")
+		}
+	}
+	return i.reportSince(start), nil
+}
+
+// renameState moves what is recorded about a chatmate file to its new name.
+//
+// Records under the new name win: those of the old name are dropped if the
+// new name already has one. The state only informs later commands, so
+// failing to update it is reported as a warning.
+func (cm *ChatMateManager) renameState(from, to string) {
+	if cm.dryRun != nil {
+		return
+	}
+	if cm.provenancePath != "" {
+		log, err := state.ReadProvenance(cm.provenancePath)
+		if err == nil {
+			if record, ok := log.Get(cm.PromptsDir, from); ok {
+				log.Remove(cm.PromptsDir, from)
+				if _, exists := log.Get(cm.PromptsDir, to); !exists {
+					record.Filename = to
+					log.Set(record)
+				}
+				err = state.WriteProvenance(cm.provenancePath, log)
+			}
+		}
+		if err != nil {
+			cm.out().Warnf("Could not move the provenance of %s to %s: %v", from, to, err)
+		}
+	}
+
+	if cm.registryPath != "" {
+		registry, err := state.ReadRegistry(cm.registryPath)
+		if err == nil {
+			if file, ok := registry.Get(cm.PromptsDir, from); ok {
+				registry.Remove(cm.PromptsDir, from)
+				if _, exists := registry.Get(cm.PromptsDir, to); !exists {
+					file.Filename = to
+					registry.Set(file)
+				}
+				err = state.WriteRegistry(cm.registryPath, registry)
+			}
+		}
+		if err != nil {
+			cm.out().Warnf("Could not move the adoption of %s to %s: %v", from, to, err)
+		}
+	}
+
+	cm.updateApprovals(func(queue *state.ApprovalQueue) bool {
+		for _, request := range queue.In(cm.PromptsDir) {
+			if request.Filename == from {
+				queue.Remove(cm.PromptsDir, from)
+				request.Filename = to
+				queue.Add(request)
+				return true
+			}
+		}
+		return false
+	})
+
+	if cm.checkpointPath == "" {
+		return
+	}
+	checkpoint, err := state.ReadCheckpoint(cm.checkpointPath)
+	if err != nil || checkpoint.PromptsDir != cm.PromptsDir {
+		return
+	}
+	if index := slices.Index(checkpoint.Pending, from); index >= 0 {
+		if slices.Contains(checkpoint.Pending, to) {
+			checkpoint.Pending = slices.Delete(checkpoint.Pending, index, index+1)
+		} else {
+			checkpoint.Pending[index] = to
+		}
+		if err := state.WriteCheckpoint(cm.checkpointPath, checkpoint); err != nil {
+			cm.out().Warnf("Could not update the interrupted installation: %v", err)
+		}
+	}
+}
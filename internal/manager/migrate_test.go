@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestMigrateLegacyNames tests that chatmates installed under the legacy
+// "Chatmate - " filenames get their current names, with their provenance
+func TestMigrateLegacyNames(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	shipped := func(name string) []byte { return []byte("---\ndescription: " + name + "\n---\nShipped\n") }
+	edited := []byte("---\ndescription: edited\n---\nEdited\n")
+	for _, name := range []string{"A", "B", "C"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name+".chatmode.md"), shipped(name), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	installed := map[string][]byte{
+		"Chatmate - A.chatmode.md":    edited,       // renamed, keeping the edit
+		"Chatmate - B.chatmode.md":    shipped("B"), // unchanged duplicate of B: removed
+		"B.chatmode.md":               shipped("B"),
+		"Chatmate - C.chatmode.md":    edited, // edited duplicate of C: kept
+		"C.chatmode.md":               shipped("C"),
+		"Chatmate - Mine.chatmode.md": edited, // not shipped: left alone
+	}
+	for filename, content := range installed {
+		if err := os.WriteFile(filepath.Join(promptsDir, filename), content, 0644); err != nil {
+			t.Fatalf("Failed to create installed file: %v", err)
+		}
+	}
+
+	provenancePath := filepath.Join(t.TempDir(), state.ProvenanceFilename)
+	log := &state.ProvenanceLog{}
+	log.Set(state.Provenance{Filename: "Chatmate - A.chatmode.md", PromptsDir: promptsDir, Source: state.SourceEmbedded, Checksum: checksum(shipped("A"))})
+	if err := state.WriteProvenance(provenancePath, log); err != nil {
+		t.Fatalf("Failed to write provenance: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, provenancePath: provenancePath}
+	cm.installer = NewInstallerService(cm)
+
+	legacy, err := cm.LegacyFiles()
+	if err != nil {
+		t.Fatalf("LegacyFiles failed: %v", err)
+	}
+	want := []LegacyFile{
+		{Filename: "Chatmate - A.chatmode.md", Current: "A.chatmode.md", Action: LegacyRename},
+		{Filename: "Chatmate - B.chatmode.md", Current: "B.chatmode.md", Action: LegacyRemove},
+		{Filename: "Chatmate - C.chatmode.md", Current: "C.chatmode.md", Action: LegacyKeep},
+	}
+	if !slices.Equal(legacy, want) {
+		t.Errorf("Expected legacy files %+v, got %+v", want, legacy)
+	}
+
+	report, err := cm.Installer().MigrateLegacyNames(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateLegacyNames failed: %v", err)
+	}
+	if report.Count(InstallMigrated) != 2 {
+		t.Errorf("Expected two migrated files, got %+v", report.Results)
+	}
+
+	names, err := cm.GetInstalledChatmates()
+	if err != nil {
+		t.Fatalf("GetInstalledChatmates failed: %v", err)
+	}
+	if want := "A.chatmode.md|B.chatmode.md|C.chatmode.md|Chatmate - C.chatmode.md|Chatmate - Mine.chatmode.md"; strings.Join(names, "|") != want {
+		t.Errorf("Expected %s to be installed, got %v", want, names)
+	}
+	if content, err := os.ReadFile(filepath.Join(promptsDir, "A.chatmode.md")); err != nil || string(content) != string(edited) {
+		t.Errorf("Expected the renamed chatmate to keep its edit, got %q (%v)", content, err)
+	}
+	if record, ok := cm.Provenance("A.chatmode.md"); !ok || record.Source != state.SourceEmbedded {
+		t.Errorf("Expected the provenance to follow the rename, got %+v", record)
+	}
+	if _, ok := cm.Provenance("Chatmate - A.chatmode.md"); ok {
+		t.Error("Expected no provenance left under the legacy name")
+	}
+
+	// The remaining legacy file is reported, without an automatic repair
+	finding := NewDoctorService(cm).checkLegacyNames()
+	if finding.Status != CheckWarn || finding.CanApply() {
+		t.Errorf("Expected a legacy-names warning with a manual fix, got %+v", finding)
+	}
+}
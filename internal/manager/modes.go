@@ -0,0 +1,28 @@
+// Package manager provides the permissions of the files ChatMate creates.
+package manager
+
+import "io/fs"
+
+// Permissions of the files and directories ChatMate creates in the prompts
+// directory unless the fileMode and dirMode settings say otherwise. The
+// process umask is applied on top, like for any other program.
+const (
+	DefaultFileMode fs.FileMode = 0644
+	DefaultDirMode  fs.FileMode = 0755
+)
+
+// fileMode returns the permissions chatmate files are created with.
+func (cm *ChatMateManager) fileMode() fs.FileMode {
+	if cm.FileMode == 0 {
+		return DefaultFileMode
+	}
+	return cm.FileMode
+}
+
+// dirMode returns the permissions directories are created with.
+func (cm *ChatMateManager) dirMode() fs.FileMode {
+	if cm.DirMode == 0 {
+		return DefaultDirMode
+	}
+	return cm.DirMode
+}
@@ -0,0 +1,146 @@
+// Package manager provides name length checks for ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxDisplayNameLength is the number of characters of a display name that
+// the chat mode picker of Copilot Chat shows in full; longer names are cut
+// off in the picker and are tedious to @-mention.
+const MaxDisplayNameLength = 64
+
+// MaxPathLength is the number of characters of the path of an installed
+// chatmate that Windows tools without long path support can open (MAX_PATH).
+const MaxPathLength = 260
+
+// Kinds of NameLengthIssue.
+const (
+	NameLengthDisplayName = "display-name"
+	NameLengthPath        = "path"
+)
+
+// NameLengthIssue is a chatmate name over one of the length limits.
+//
+// Fields:
+//   - Filename: The chatmate filename
+//   - Kind: NameLengthDisplayName or NameLengthPath
+//   - Length: The length of the display name or path, in characters
+//   - Limit: MaxDisplayNameLength or MaxPathLength
+//   - Suggestion: A filename within the limit; empty when shortening the
+//     name cannot help, e.g. because the prompts directory alone is too long
+type NameLengthIssue struct {
+	Filename   string `json:"filename"`
+	Kind       string `json:"kind"`
+	Length     int    `json:"length"`
+	Limit      int    `json:"limit"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// String describes the issue, including the suggested filename.
+func (issue NameLengthIssue) String() string {
+	what := fmt.Sprintf("display name %q", DisplayName(issue.Filename))
+	if issue.Kind == NameLengthPath {
+		what = "path of " + issue.Filename
+	}
+	text := fmt.Sprintf("%s is %d characters (limit %d)", what, issue.Length, issue.Limit)
+	if issue.Suggestion != "" {
+		text += fmt.Sprintf("; rename it to %q", issue.Suggestion)
+	}
+	return text
+}
+
+// CheckNameLength checks the display name of a chatmate against
+// MaxDisplayNameLength and, when promptsDir is set, its path in the
+// directory against MaxPathLength.
+//
+// Parameters:
+//   - filename: The chatmate filename (e.g., "Solve Issue.chatmode.md")
+//   - promptsDir: The directory the chatmate is installed into; the path is
+//     not checked when empty
+//
+// Returns:
+//   - []NameLengthIssue: The limits the name exceeds; nil when it fits
+//
+// Example:
+//
+//	for _, issue := range CheckNameLength(filename, cm.PromptsDir) {
+//		fmt.Println(issue)
+//	}
+func CheckNameLength(filename, promptsDir string) []NameLengthIssue {
+	var issues []NameLengthIssue
+	name := DisplayName(filename)
+	if length := utf8.RuneCountInString(name); length > MaxDisplayNameLength {
+		issues = append(issues, NameLengthIssue{
+			Filename:   filename,
+			Kind:       NameLengthDisplayName,
+			Length:     length,
+			Limit:      MaxDisplayNameLength,
+			Suggestion: ShortenName(name, MaxDisplayNameLength) + ".chatmode.md",
+		})
+		name = ShortenName(name, MaxDisplayNameLength)
+	}
+
+	if promptsDir == "" {
+		return issues
+	}
+	path := filepath.Join(promptsDir, filename)
+	if length := utf8.RuneCountInString(path); length > MaxPathLength {
+		issue := NameLengthIssue{Filename: filename, Kind: NameLengthPath, Length: length, Limit: MaxPathLength}
+		// The directory, a separator, and the extension take up the rest
+		if room := MaxPathLength - utf8.RuneCountInString(promptsDir) - 1 - len(".chatmode.md"); room > 0 {
+			issue.Suggestion = ShortenName(name, room) + ".chatmode.md"
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// ShortenName shortens a display name to at most limit characters.
+//
+// Whole words are dropped from the end while the name is too long, so the
+// suggestion still reads like the original; a single word longer than the
+// limit is cut. Trailing separators are removed.
+//
+// Parameters:
+//   - name: The display name
+//   - limit: The maximum number of characters
+//
+// Returns:
+//   - string: The name itself if it fits, a shortened name otherwise
+//
+// Example:
+//
+//	ShortenName("Review Pull Requests For Security Issues", 20) // "Review Pull Requests"
+func ShortenName(name string, limit int) string {
+	if utf8.RuneCountInString(name) <= limit {
+		return name
+	}
+
+	var short string
+	for _, word := range strings.Fields(name) {
+		candidate := word
+		if short != "" {
+			candidate = short + " " + word
+		}
+		if utf8.RuneCountInString(candidate) > limit {
+			break
+		}
+		short = candidate
+	}
+	if short == "" {
+		short = string([]rune(name)[:limit])
+	}
+	return strings.TrimRight(short, " -_.")
+}
+
+// warnNameLength warns when the name of a chatmate that is being added to
+// the prompts directory exceeds a length limit, with a shorter name to use.
+func (cm *ChatMateManager) warnNameLength(filename string) {
+	for _, issue := range CheckNameLength(filename, cm.PromptsDir) {
+		cm.out().Warnf("The %s", issue)
+	}
+}
@@ -0,0 +1,155 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+func TestDuplicateDisplayNames(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	filename := "Chatmate - Solve Issue.chatmode.md"
+	content := []byte("---\ndescription: shipped\n---\nShipped\n")
+	if err := os.WriteFile(filepath.Join(matesDir, filename), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "solve issue.chatmode.md"), []byte("---\ndescription: mine\n---\nMine\n"), 0644); err != nil {
+		t.Fatalf("Failed to create user file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+
+	// Without confirmation the colliding chatmate is skipped
+	output.SetInput(strings.NewReader("n\n"))
+	defer output.SetInput(nil)
+	if err := cm.Installer().InstallChatmate(filename, false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, filename)); !os.IsNotExist(err) {
+		t.Errorf("Expected the colliding chatmate to be skipped, got %v", err)
+	}
+
+	// Confirmed, it is installed under the next free name, once
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+	for range 2 {
+		if err := cm.Installer().InstallChatmate(filename, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	installed, err := cm.GetInstalledChatmates()
+	if err != nil {
+		t.Fatalf("GetInstalledChatmates failed: %v", err)
+	}
+	if want := []string{"Chatmate - Solve Issue 2.chatmode.md", "solve issue.chatmode.md"}; strings.Join(installed, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %v to be installed, got %v", want, installed)
+	}
+
+	// A duplicate created outside ChatMate is reported by validate and doctor
+	if err := os.WriteFile(filepath.Join(promptsDir, filename), content, 0644); err != nil {
+		t.Fatalf("Failed to create duplicate file: %v", err)
+	}
+	cm.invalidateInventory()
+
+	report, err := NewValidatorService(cm).ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	var duplicates CheckResult
+	for _, check := range report.Checks {
+		if check.Name == "duplicate-names" {
+			duplicates = check
+		}
+	}
+	if duplicates.Status != CheckWarn || len(duplicates.Files) != 2 || duplicates.Files[0] != filename {
+		t.Errorf("Expected a duplicate-names warning for both files, got %+v", duplicates)
+	}
+
+	finding := NewDoctorService(cm).checkDuplicateNames()
+	if finding.Status != CheckWarn || finding.CanApply() || finding.Fix == "" {
+		t.Errorf("Expected a duplicate-names warning with a manual fix, got %+v", finding)
+	}
+}
+
+// TestNameLength tests the display name and path length checks
+func TestNameLength(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		limit    int
+		expected string
+	}{
+		{"Solve Issue", 64, "Solve Issue"},
+		{"Review Pull Requests For Security Issues", 20, "Review Pull Requests"},
+		{"Review Pull-Requests", 12, "Review"},
+		{"Supercalifragilistic", 10, "Supercalif"},
+		{"Chatmate - Review Everything", 10, "Chatmate"},
+	} {
+		if got := ShortenName(tc.name, tc.limit); got != tc.expected {
+			t.Errorf("ShortenName(%q, %d) = %q, expected %q", tc.name, tc.limit, got, tc.expected)
+		}
+	}
+
+	long := strings.Repeat("Review ", 12) + "Code.chatmode.md"
+	if issues := CheckNameLength("Solve Issue.chatmode.md", strings.Repeat("d", 200)); len(issues) != 0 {
+		t.Errorf("Expected no issues for a short name, got %+v", issues)
+	}
+	issues := CheckNameLength(long, "")
+	if len(issues) != 1 || issues[0].Kind != NameLengthDisplayName || issues[0].Length != 88 || issues[0].Limit != MaxDisplayNameLength {
+		t.Fatalf("Expected a display name issue, got %+v", issues)
+	}
+	if suggestion := DisplayName(issues[0].Suggestion); len(suggestion) > MaxDisplayNameLength || !strings.HasPrefix(long, suggestion) {
+		t.Errorf("Expected a shorter prefix of the name, got %q", issues[0].Suggestion)
+	}
+	if !strings.Contains(issues[0].String(), "(limit 64); rename it to") {
+		t.Errorf("Expected the issue to suggest a name, got %q", issues[0])
+	}
+	issues = CheckNameLength("Solve Issue.chatmode.md", "/"+strings.Repeat("d", 250))
+	if len(issues) != 1 || issues[0].Kind != NameLengthPath || issues[0].Suggestion != "" {
+		t.Errorf("Expected a path issue without suggestion, got %+v", issues)
+	}
+	issues = CheckNameLength("Solve Issue.chatmode.md", "/"+strings.Repeat("d", 240))
+	if len(issues) != 1 || issues[0].Suggestion != "Solve.chatmode.md" {
+		t.Errorf("Expected a path issue suggesting a shorter name, got %+v", issues)
+	}
+
+	matesDir := t.TempDir()
+	srcDir := t.TempDir()
+	content := []byte("---\ndescription: test\n---\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Solve Issue.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, long), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	var errOut bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: t.TempDir(), Output: io.Discard, ErrOutput: &errOut}
+	cm.installer = NewInstallerService(cm)
+	cm.validator = NewValidatorService(cm)
+
+	if _, err := cm.Installer().Import(context.Background(), srcDir, nil, false); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if !strings.Contains(errOut.String(), "rename it to") {
+		t.Errorf("Expected importing a long name to warn, got %q", errOut.String())
+	}
+	report, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	index := slices.IndexFunc(report.Checks, func(check CheckResult) bool { return check.Name == "name-length" })
+	if index < 0 || report.Checks[index].Status != CheckWarn || !slices.Equal(report.Checks[index].Files, []string{long}) {
+		t.Errorf("Expected a name-length warning for %s, got %+v", long, report.Checks)
+	}
+}
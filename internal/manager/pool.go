@@ -0,0 +1,134 @@
+// Package manager provides concurrent installation for ChatMate agents.
+package manager
+
+import "context"
+
+// DefaultConcurrency is the number of chatmates a bulk installation installs
+// at the same time unless the concurrency setting says otherwise.
+const DefaultConcurrency = 4
+
+// installTask is a chatmate installed by a worker of installEach.
+//
+// Fields:
+//   - installer: Collects the outcome of the chatmate and the approval it
+//     queued, so they can be reported in order once it is done
+//   - err: Why the chatmate could not be installed
+//   - done: Closed when the worker finished
+type installTask struct {
+	installer *InstallerService
+	err       error
+	done      chan struct{}
+}
+
+// concurrency returns the number of chatmates installed at the same time.
+//
+// Shared prompts directories are changed under a lock with a manifest of
+// owners, so they are always installed into one chatmate after another.
+func (cm *ChatMateManager) concurrency() int {
+	if cm.Shared || cm.Concurrency < 1 {
+		return 1
+	}
+	return cm.Concurrency
+}
+
+// installEach installs the chatmates with a bounded pool of workers (see
+// ChatMateManager.Concurrency).
+//
+// Outcomes are reported in the order of filenames, whatever order the
+// workers finish in, and done is called for every installed chatmate in
+// that order, e.g. to record a checkpoint. No new chatmate is started once
+// ctx is done or a chatmate failed; chatmates already started are finished
+// and reported, and the error of the first failed chatmate is returned.
+//
+// Parameters:
+//   - ctx: Stops starting new chatmates once done
+//   - filenames: The chatmates to install
+//   - force: If true, overwrites existing files; if false, skips existing files
+//   - done: Called with each chatmate that was installed or skipped
+//
+// Returns:
+//   - error: The first installation failure in order, or cancellation
+func (i *InstallerService) installEach(ctx context.Context, filenames []string, force bool, done func(filename string)) error {
+	jobs := i.manager.concurrency()
+	if jobs == 1 || len(filenames) < 2 {
+		for _, filename := range filenames {
+			if err := interrupted(ctx, "installation"); err != nil {
+				return err
+			}
+			if err := i.InstallChatmate(filename, force); err != nil {
+				return err
+			}
+			done(filename)
+		}
+		return nil
+	}
+
+	var (
+		tasks    []*installTask
+		reported int
+		firstErr error
+		slots    = make(chan struct{}, jobs)
+	)
+	// report merges the outcomes of finished tasks in order, waiting for
+	// running ones if wait is set
+	report := func(wait bool) {
+		for ; reported < len(tasks); reported++ {
+			task := tasks[reported]
+			if wait {
+				<-task.done
+			} else {
+				select {
+				case <-task.done:
+				default:
+					return
+				}
+			}
+			i.merge(task.installer)
+			if task.err != nil {
+				if firstErr == nil {
+					firstErr = task.err
+				}
+				continue
+			}
+			done(filenames[reported])
+		}
+	}
+
+	for _, filename := range filenames {
+		report(false)
+		if firstErr == nil {
+			firstErr = interrupted(ctx, "installation")
+		}
+		if firstErr != nil {
+			break
+		}
+
+		slots <- struct{}{}
+		task := &installTask{
+			installer: &InstallerService{AcceptBreaking: i.AcceptBreaking, manager: i.manager, buffered: true},
+			done:      make(chan struct{}),
+		}
+		tasks = append(tasks, task)
+		go func(filename string) {
+			defer func() {
+				<-slots
+				close(task.done)
+			}()
+			task.err = task.installer.InstallChatmate(filename, force)
+		}(filename)
+	}
+	report(true)
+	return firstErr
+}
+
+// merge adds the outcomes and queued approvals of a worker's installer to
+// this installer, reporting each outcome to the manager's Progress function.
+func (i *InstallerService) merge(worker *InstallerService) {
+	i.queued = append(i.queued, worker.queued...)
+	for _, result := range worker.results {
+		i.results = append(i.results, result)
+		if i.manager.Progress != nil {
+			i.manager.Progress(result)
+		}
+	}
+}
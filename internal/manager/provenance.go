@@ -0,0 +1,212 @@
+// Package manager provides provenance tracking for ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/cache"
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// RepositoryURL is where the embedded chatmates are published, recorded as
+// their provenance location.
+const RepositoryURL = "https://github.com/jonassiebler/chatmate"
+
+// chatmateSource returns how and from where an available chatmate is read.
+func (cm *ChatMateManager) chatmateSource(filename string) (source, location string) {
+	from := cm.sourceOf(filename)
+	return from.Kind(), from.Location()
+}
+
+// recordProvenance remembers where an installed chatmate came from.
+//
+// Provenance answers "where did this prompt come from" in security reviews;
+// failing to record it does not undo the installation, so errors are
+// reported as warnings. The installation is also logged as an install, or
+// as an update of a chatmate that already had provenance. The filename is
+// recorded as it is stored in the prompts directory, which may differ in
+// case on case-insensitive filesystems.
+func (cm *ChatMateManager) recordProvenance(filename, source, location string, content []byte) {
+	filename = cm.canonicalFilename(filename)
+	record := state.Provenance{
+		Filename:         filename,
+		PromptsDir:       cm.PromptsDir,
+		Source:           source,
+		Location:         location,
+		InstallerVersion: cm.Version,
+		Checksum:         checksum(content),
+		InstalledAt:      time.Now().UTC(),
+	}
+	if meta, err := files.ParseFrontmatter(content); err == nil {
+		record.Version = meta.Version
+	}
+
+	event := state.ActivityEvent{Action: state.ActivityInstall, Filename: filename, Source: source, Version: record.Version}
+	if cm.provenancePath != "" && cm.dryRun == nil {
+		log, err := state.ReadProvenance(cm.provenancePath)
+		if err == nil {
+			if previous, ok := log.Get(cm.PromptsDir, filename); ok {
+				event.Action, event.PreviousVersion = state.ActivityUpdate, previous.Version
+			}
+			log.Set(record)
+			err = state.WriteProvenance(cm.provenancePath, log)
+		}
+		if err != nil {
+			cm.out().Warnf("Could not record where %s came from: %v", filename, err)
+		}
+	}
+	cm.recordActivity(event)
+}
+
+// forgetProvenance removes the provenance of an uninstalled chatmate and
+// logs the uninstall.
+func (cm *ChatMateManager) forgetProvenance(filename string) {
+	event := state.ActivityEvent{Action: state.ActivityUninstall, Filename: filename}
+	if cm.provenancePath != "" && cm.dryRun == nil {
+		log, err := state.ReadProvenance(cm.provenancePath)
+		if err == nil {
+			if previous, ok := log.Get(cm.PromptsDir, filename); ok {
+				event.Version = previous.Version
+				log.Remove(cm.PromptsDir, filename)
+				err = state.WriteProvenance(cm.provenancePath, log)
+			}
+		}
+		if err != nil {
+			cm.out().Debugf("Could not remove the provenance of %s: %v\n", filename, err)
+		}
+	}
+	cm.recordActivity(event)
+}
+
+// Provenance returns where an installed chatmate came from.
+//
+// Chatmates installed before provenance was tracked, copied into the prompts
+// directory by hand, or adopted (see AdopterService) have no provenance.
+//
+// Parameters:
+//   - filename: The chatmate filename in the prompts directory
+//
+// Returns:
+//   - state.Provenance: The recorded provenance
+//   - bool: Whether provenance was recorded for the chatmate
+func (cm *ChatMateManager) Provenance(filename string) (state.Provenance, bool) {
+	return cm.provenanceLog().Get(cm.PromptsDir, filename)
+}
+
+// provenanceLog returns the recorded provenance.
+//
+// The log is optional: without one, or when it cannot be read, nothing has
+// a provenance.
+func (cm *ChatMateManager) provenanceLog() *state.ProvenanceLog {
+	if cm.provenancePath == "" {
+		return &state.ProvenanceLog{}
+	}
+
+	log, err := state.ReadProvenance(cm.provenancePath)
+	if err != nil {
+		cm.out().Debugf("Could not read provenance: %v\n", err)
+		return &state.ProvenanceLog{}
+	}
+	return log
+}
+
+// modifiedSet returns the chatmates among filenames that were edited since
+// ChatMate installed them: the SHA-256 of their content differs from the
+// checksum their provenance recorded. Chatmates without provenance cannot be
+// checked and are left out.
+func (cm *ChatMateManager) modifiedSet(filenames []string) map[string]bool {
+	log := cm.provenanceLog()
+	modified := make(map[string]bool)
+	for _, filename := range filenames {
+		record, ok := log.Get(cm.PromptsDir, filename)
+		if !ok {
+			continue
+		}
+		if cm.isModified(filename, record) {
+			modified[filename] = true
+		}
+	}
+	return modified
+}
+
+// isModified reports whether an installed chatmate no longer has the content
+// its provenance record describes. A file that cannot be read is not
+// reported as modified.
+func (cm *ChatMateManager) isModified(filename string, record state.Provenance) bool {
+	content, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, filename))
+	if err != nil {
+		cm.out().Debugf("Could not read %s: %v\n", filename, err)
+		return false
+	}
+	return checksum(content) != record.Checksum
+}
+
+// provenanceDescriber returns a function summarizing where an installed
+// chatmate came from in one line, such as "embedded from
+// https://github.com/jonassiebler/chatmate by chatmate 1.2.0 on 01/09/2025 12:00".
+// The state is read once, so the function can be called for every chatmate
+// in a listing.
+func (cm *ChatMateManager) provenanceDescriber() func(filename string) string {
+	log := cm.provenanceLog()
+	adopted := cm.adoptedSet()
+
+	return func(filename string) string {
+		provenance, ok := log.Get(cm.PromptsDir, filename)
+		if !ok {
+			if adopted[filename] {
+				return "adopted (created outside ChatMate)"
+			}
+			return "unknown (installed by hand or before provenance was tracked)"
+		}
+
+		description := provenance.Source
+		if provenance.Location != "" {
+			description += " from " + provenance.Location
+		}
+		if provenance.InstallerVersion != "" {
+			description += " by chatmate " + provenance.InstallerVersion
+		}
+		return fmt.Sprintf("%s on %s", description, output.FormatDateTime(provenance.InstalledAt))
+	}
+}
+
+// orphanedFiles returns the installed chatmates that are no longer
+// available to install.
+//
+// The provenance tells chatmates installed from the mates apart from those
+// downloaded from the registry, imported, or piped in, which were never
+// available and are not orphans. Adopted files are managed on the user's
+// behalf, and legacy filenames and sync conflict copies are handled by
+// MigrateLegacyNames and RemoveSyncConflicts, so none of them are orphans
+// either, nor are files whose name only differs in case from an available
+// chatmate, which are the same file on case-insensitive filesystems. Files
+// without provenance are judged by availability alone, as
+// they may predate provenance tracking.
+func (cm *ChatMateManager) orphanedFiles(inventory *cache.Inventory) []string {
+	log := cm.provenanceLog()
+	adopted := cm.adoptedSet()
+
+	var orphaned []string
+	for _, filename := range inventory.Installed {
+		if _, legacy := currentName(filename, inventory); legacy {
+			continue
+		}
+		if inventory.IsAvailable(filename) || isSyncConflict(filename) || adopted[filename] {
+			continue
+		}
+		if _, collides := caseVariant(filename, inventory.Available); collides {
+			continue
+		}
+		if record, ok := log.Get(cm.PromptsDir, filename); ok &&
+			!isSourceKind(record.Source) {
+			continue
+		}
+		orphaned = append(orphaned, filename)
+	}
+
+	return orphaned
+}
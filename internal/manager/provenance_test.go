@@ -0,0 +1,122 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestProvenance tests recording where installed chatmates came from
+func TestProvenance(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	importDir := t.TempDir()
+
+	content := []byte("---\ndescription: test\n---\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - A.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(importDir, "Imported.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create import file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Version: "1.2.3",
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	if err := cm.Installer().InstallChatmate("Chatmate - A.chatmode.md", false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if _, err := cm.Installer().Import(context.Background(), importDir, nil, false); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if _, err := cm.Installer().InstallFromReader("Piped", strings.NewReader(string(content)), false); err != nil {
+		t.Fatalf("InstallFromReader failed: %v", err)
+	}
+
+	expected := map[string]state.Provenance{
+		"Chatmate - A.chatmode.md": {Source: state.SourceDirectory, Location: matesDir},
+		"Imported.chatmode.md":     {Source: state.SourceImport, Location: importDir},
+		"Piped.chatmode.md":        {Source: state.SourceStdin},
+	}
+	for filename, want := range expected {
+		provenance, ok := cm.Provenance(filename)
+		if !ok {
+			t.Fatalf("Expected provenance for %s", filename)
+		}
+		if provenance.Source != want.Source || provenance.Location != want.Location || provenance.InstallerVersion != "1.2.3" ||
+			provenance.Checksum != checksum(content) || provenance.InstalledAt.IsZero() {
+			t.Errorf("Unexpected provenance for %s: %+v", filename, provenance)
+		}
+	}
+
+	describe := cm.provenanceDescriber()
+	if description := describe("Chatmate - A.chatmode.md"); !strings.Contains(description, "directory from "+matesDir+" by chatmate 1.2.3") {
+		t.Errorf("Unexpected description: %s", description)
+	}
+	if description := describe("Unknown.chatmode.md"); !strings.HasPrefix(description, "unknown") {
+		t.Errorf("Expected unknown provenance, got %s", description)
+	}
+
+	if err := cm.Uninstaller().UninstallChatmate("Imported.chatmode.md"); err != nil {
+		t.Fatalf("UninstallChatmate failed: %v", err)
+	}
+	if _, ok := cm.Provenance("Imported.chatmode.md"); ok {
+		t.Error("Uninstalling should forget the provenance")
+	}
+}
+
+// TestModifiedChatmates tests that chatmates edited after installation are
+// reported as modified instead of just installed
+func TestModifiedChatmates(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	stateDir := t.TempDir()
+	for _, name := range []string{"Edited.chatmode.md", "Kept.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\nShipped\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(stateDir, state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.lister = NewListerService(cm)
+	cm.status = NewStatusService(cm)
+
+	for _, name := range []string{"Edited.chatmode.md", "Kept.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Edited.chatmode.md"), []byte("---\ndescription: test\n---\nMine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := cm.Lister().Listing(false, true)
+	if err != nil || len(listing.Chatmates) != 2 || !listing.Chatmates[0].Modified || listing.Chatmates[1].Modified {
+		t.Errorf("Expected only Edited to be listed as modified, got %+v, %v", listing, err)
+	}
+	if report, err := cm.Status().Report(); err != nil || report.Modified != 1 {
+		t.Errorf("Expected one modified chatmate in the status, got %+v, %v", report, err)
+	}
+	if plan, err := cm.Installer().PlanInstall(false); err != nil || len(plan.Modified) != 1 || plan.Modified[0] != "Edited.chatmode.md" {
+		t.Errorf("Expected the plan to name the modified chatmate, got %+v, %v", plan, err)
+	}
+
+	var results []InstallResult
+	cm.Progress = func(result InstallResult) { results = append(results, result) }
+	for _, name := range []string{"Edited.chatmode.md", "Kept.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	if len(results) != 2 || !strings.Contains(results[0].Detail, "modified locally") || results[1].Detail != "already installed" {
+		t.Errorf("Expected the modified chatmate to be skipped as modified, got %+v", results)
+	}
+}
@@ -0,0 +1,70 @@
+// Package manager provides rendering of chatmates as VS Code will see them.
+package manager
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/pkg/security"
+)
+
+// Rendering is the content an installation of a chatmate would write.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The file the chatmate is installed as in the prompts directory
+//   - Content: The exact file content VS Code reads
+type Rendering struct {
+	Name     string
+	Filename string
+	Content  []byte
+}
+
+// Render returns the content 'chatmate hire' would write for an available
+// chatmate, without installing it.
+//
+// Names are matched like InstallSpecific matches them, and the content goes
+// through the same checks as InstallChatmate, so a chatmate that renders is
+// one that can be hired. Installation writes the content byte for byte;
+// only the filename may change when a new chatmate shares its display name
+// with an installed file.
+//
+// Parameters:
+//   - name: Display name or filename of an available chatmate
+//
+// Returns:
+//   - *Rendering: The content and the file it is installed as
+//   - error: Chatmate not found, content retrieval, or validation error
+//
+// Example:
+//
+// rendering, err := installer.Render("Solve Issue")
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to render chatmate: %w", err)
+//	}
+func (i *InstallerService) Render(name string) (*Rendering, error) {
+	inventory, err := i.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	filename, ok := i.manager.findChatmate(name, inventory.Available)
+	if !ok {
+		return nil, errorf(ErrChatmateNotFound, "chatmate not found: %s", name)
+	}
+
+	if err := security.ValidateChatmateFilename(filename); err != nil {
+		return nil, errorf(ErrValidationFailed, "security validation failed: %w", err)
+	}
+	if !security.IsPathSafe(i.manager.PromptsDir, filename) {
+		return nil, fmt.Errorf("destination path is not safe: %s", filename)
+	}
+	filename = security.SanitizeInput(filename)
+
+	content, err := i.installableContent(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rendering{Name: i.manager.getDisplayName(filename), Filename: filename, Content: content}, nil
+}
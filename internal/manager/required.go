@@ -0,0 +1,220 @@
+// Package manager provides team policy enforcement for ChatMate agents.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/policy"
+)
+
+// Compliance describes how the prompts directory meets the team policy.
+//
+// Fields:
+//   - PolicyPath: The policy file that was checked
+//   - Required: The required chatmates as written in the policy
+//   - Installed: Filenames of required chatmates that are installed
+//   - Missing: Filenames of required chatmates that are available but not
+//     installed; 'chatmate apply' installs them
+//   - Unknown: Required names that are neither available nor installed, so
+//     they cannot be installed automatically
+//   - Applied: Filenames of the chatmates ApplyPolicy installed
+//   - Failed: Chatmates ApplyPolicy could not install; they stay in Missing
+type Compliance struct {
+	PolicyPath string
+	Required   []string
+	Installed  []string
+	Missing    []string
+	Unknown    []string
+	Applied    []string
+	Failed     []InstallFailure
+}
+
+// InstallFailure is a chatmate that could not be installed.
+//
+// Fields:
+//   - Filename: The chatmate filename
+//   - Err: Why the installation failed
+type InstallFailure struct {
+	Filename string
+	Err      error
+}
+
+// Compliant reports whether every required chatmate is installed.
+func (c *Compliance) Compliant() bool {
+	return len(c.Missing) == 0 && len(c.Unknown) == 0
+}
+
+// Error describes what the prompts directory lacks, for commands that fail
+// when the policy is not met.
+func (c *Compliance) Error() error {
+	if c.Compliant() {
+		return nil
+	}
+
+	var problems []string
+	if len(c.Missing) > 0 {
+		names := make([]string, len(c.Missing))
+		for i, filename := range c.Missing {
+			names[i] = DisplayName(filename)
+		}
+		problems = append(problems, fmt.Sprintf("%d required chatmate(s) not installed: %s (run 'chatmate apply')",
+			len(c.Missing), strings.Join(names, ", ")))
+	}
+	if len(c.Unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("%d required chatmate(s) not available to install: %s",
+			len(c.Unknown), strings.Join(c.Unknown, ", ")))
+	}
+	return fmt.Errorf("team policy %s is not met: %s", c.PolicyPath, strings.Join(problems, "; "))
+}
+
+// PolicyPath returns the location of the team policy file; it is empty when
+// the location cannot be determined.
+func (cm *ChatMateManager) PolicyPath() string {
+	return cm.policyPath
+}
+
+// Policy returns the team policy.
+//
+// Returns:
+//   - *policy.Policy: The policy; empty when there is no policy file
+//   - error: Policy read or decoding error
+func (cm *ChatMateManager) Policy() (*policy.Policy, error) {
+	if cm.policyPath == "" {
+		return &policy.Policy{}, nil
+	}
+	return policy.Load(cm.policyPath)
+}
+
+// PolicyCompliance checks the prompts directory against the team policy.
+//
+// A required chatmate is met when a file with its name is installed, whether
+// ChatMate installed it or not, so teams can also require chatmates they
+// distribute themselves. Without a policy file nothing is required.
+//
+// Returns:
+//   - *Compliance: Which required chatmates are installed, missing, or unknown
+//   - error: Policy or prompts directory read error
+//
+// Example:
+//
+// compliance, err := manager.PolicyCompliance()
+//
+//	if err != nil {
+//	   return fmt.Errorf("policy check failed: %w", err)
+//	}
+func (cm *ChatMateManager) PolicyCompliance() (*Compliance, error) {
+	compliance := &Compliance{PolicyPath: cm.policyPath}
+	teamPolicy, err := cm.Policy()
+	if err != nil {
+		return nil, err
+	}
+	compliance.Required = teamPolicy.Required
+	if len(teamPolicy.Required) == 0 {
+		return compliance, nil
+	}
+
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range teamPolicy.Required {
+		if filename, ok := cm.findChatmate(name, inventory.Installed); ok {
+			compliance.Installed = append(compliance.Installed, filename)
+		} else if filename, ok := cm.findChatmate(name, inventory.Available); ok {
+			compliance.Missing = append(compliance.Missing, filename)
+		} else {
+			compliance.Unknown = append(compliance.Unknown, name)
+		}
+	}
+
+	return compliance, nil
+}
+
+// printCompliance lists the required chatmates with their state.
+func (i *InstallerService) printCompliance(compliance *Compliance) {
+	for _, filename := range compliance.Installed {
+		i.manager.out().Printf("  %s %s\n", output.SymbolSuccess, DisplayName(filename))
+	}
+	for _, filename := range compliance.Missing {
+		i.manager.out().Printf("  %s %s (not installed)\n", output.SymbolFailure, DisplayName(filename))
+	}
+	for _, name := range compliance.Unknown {
+		i.manager.out().Printf("  %s %s (not available)\n", output.SymbolFailure, name)
+	}
+}
+
+// ApplyPolicy installs the required chatmates that are missing.
+//
+// Required chatmates that are already installed are left alone, even if
+// their content differs from the shipped version. Installation asks for
+// confirmation like InstallAll (see output.Confirm). A chatmate that fails to
+// install does not stop the others; all failures are reported at the end.
+//
+// Parameters:
+//   - ctx: Stops installing before the next required chatmate once done
+//
+// Returns:
+//   - *Compliance: The policy state after applying, including what was
+//     installed and what failed; nil if the policy could not be checked
+//   - error: The policy still not being met after installing (e.g., a required
+//     chatmate is not available or failed to install), a policy read error, or
+//     cancellation
+//
+// Example:
+//
+// compliance, err := installer.ApplyPolicy(ctx)
+//
+//	if err != nil {
+//	   return fmt.Errorf("apply failed: %w", err)
+//	}
+func (i *InstallerService) ApplyPolicy(ctx context.Context) (*Compliance, error) {
+	defer i.manager.bind(ctx)()
+	out := i.manager.out()
+	compliance, err := i.manager.PolicyCompliance()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(compliance.Required) == 0 {
+		out.Printf("No required chatmates (team policy: %s)\n", compliance.PolicyPath)
+		return compliance, nil
+	}
+
+	out.Printf("Team policy: %s\n", compliance.PolicyPath)
+	i.printCompliance(compliance)
+
+	if len(compliance.Missing) > 0 {
+		if !out.Confirm("\nInstall %d missing required chatmate(s)?", len(compliance.Missing)) {
+			out.Println("❌ Apply cancelled by user")
+			return compliance, compliance.Error()
+		}
+
+		out.Println()
+		var stillMissing []string
+		for index, filename := range compliance.Missing {
+			if err := interrupted(ctx, "apply"); err != nil {
+				compliance.Installed = append(compliance.Installed, compliance.Applied...)
+				compliance.Missing = append(stillMissing, compliance.Missing[index:]...)
+				return compliance, err
+			}
+			if err := i.InstallChatmate(filename, false); err != nil {
+				out.Warnf("%s: %v", filename, err)
+				compliance.Failed = append(compliance.Failed, InstallFailure{Filename: filename, Err: err})
+				stillMissing = append(stillMissing, filename)
+				continue
+			}
+			compliance.Applied = append(compliance.Applied, filename)
+		}
+		compliance.Installed = append(compliance.Installed, compliance.Applied...)
+		compliance.Missing = stillMissing
+	}
+
+	if compliance.Compliant() {
+		out.Printf("\n✅ All %d required chatmates are installed\n", len(compliance.Required))
+	}
+	return compliance, compliance.Error()
+}
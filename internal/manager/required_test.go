@@ -0,0 +1,77 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// TestPolicyCompliance tests checking and applying the required chatmates of the team policy
+func TestPolicyCompliance(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+
+	for _, name := range []string{"Chatmate - Review PR.chatmode.md", "Chatmate - Testing.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Chatmate - Review PR.chatmode.md"), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to create installed file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, policyPath: policyPath}
+	cm.installer = NewInstallerService(cm)
+	cm.status = NewStatusService(cm)
+
+	// Without a policy file nothing is required
+	compliance, err := cm.PolicyCompliance()
+	if err != nil || len(compliance.Required) != 0 || !compliance.Compliant() {
+		t.Fatalf("Expected nothing to be required, got %+v, %v", compliance, err)
+	}
+
+	if err := os.WriteFile(policyPath, []byte("required:\n  - Review PR\n  - Testing\n  - Team Only\n"), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+
+	compliance, err = cm.PolicyCompliance()
+	if err != nil {
+		t.Fatalf("PolicyCompliance failed: %v", err)
+	}
+	if len(compliance.Installed) != 1 || len(compliance.Missing) != 1 || compliance.Missing[0] != "Chatmate - Testing.chatmode.md" ||
+		len(compliance.Unknown) != 1 || compliance.Unknown[0] != "Team Only" {
+		t.Errorf("Unexpected compliance: %+v", compliance)
+	}
+	if err := compliance.Error(); err == nil || !strings.Contains(err.Error(), "Testing") {
+		t.Errorf("Expected the check to fail for the missing chatmate, got %v", err)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	// Missing chatmates are installed; unknown ones still fail the policy
+	compliance, err = cm.Installer().ApplyPolicy(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Team Only") {
+		t.Errorf("Expected apply to report the unavailable chatmate, got %v", err)
+	}
+	if len(compliance.Applied) != 1 || len(compliance.Missing) != 0 || len(compliance.Failed) != 0 {
+		t.Errorf("Expected Testing to be applied, got %+v", compliance)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - Testing.chatmode.md")); err != nil {
+		t.Errorf("Expected the missing required chatmate to be installed: %v", err)
+	}
+
+	// A chatmate the team distributes itself is met once it is installed
+	if err := os.WriteFile(filepath.Join(promptsDir, "Team Only.chatmode.md"), []byte("---\ndescription: team\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to create team file: %v", err)
+	}
+	cm.invalidateInventory()
+	if compliance, err = cm.PolicyCompliance(); err != nil || compliance.Error() != nil {
+		t.Errorf("Expected the policy to be met, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package manager provides the retention of ChatMate's history, backups,
+// versions, and cache.
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// CacheInfo reports the disk space used by ChatMate's data directories and
+// the retention limits enforced on it.
+//
+// Fields:
+//   - Usage: The state and cache directories and the space used by each
+//     kind of data
+//   - Limits: The retention limits
+//   - OverQuota: Whether the directories use more than Limits.MaxSize, e.g.
+//     because the state files themselves, which are never removed, are larger
+type CacheInfo struct {
+	*state.Usage
+	Limits    RetentionLimits `json:"limits"`
+	OverQuota bool            `json:"overQuota"`
+}
+
+// RetentionLimits describes the retention limits for reports.
+//
+// Fields:
+//   - MaxSize: Bytes the data directories may use; 0 means no limit
+//   - MaxAge: How long operations, backups, and versions are kept (e.g.,
+//     "90d"); "0" means no limit
+//   - MaxVersions: Backups kept of each state file; 0 means no limit
+//   - Source: Where the limits come from: "default", or the policy file
+type RetentionLimits struct {
+	MaxSize     int64  `json:"maxSizeBytes"`
+	MaxAge      string `json:"maxAge"`
+	MaxVersions int    `json:"maxVersions"`
+	Source      string `json:"source"`
+}
+
+// Retention returns the retention limits for the history, backups,
+// versions, and cache: state.DefaultRetention, overridden by the team policy.
+//
+// Returns:
+//   - state.Retention: The limits
+//   - string: Where the limits come from: "default", or the policy file
+//   - error: Policy read or decoding error
+func (cm *ChatMateManager) Retention() (state.Retention, string, error) {
+	teamPolicy, err := cm.Policy()
+	if err != nil {
+		return state.Retention{}, "", err
+	}
+	limits, err := teamPolicy.Retention.Limits()
+	if err != nil {
+		return state.Retention{}, "", err
+	}
+	if limits == state.DefaultRetention {
+		return limits, "default", nil
+	}
+	return limits, cm.policyPath, nil
+}
+
+// EnforceRetention removes the history entries, backups, and cached files
+// beyond the retention limits (see state.EnforceRetention).
+//
+// Returns:
+//   - *state.Pruned: What was removed
+//   - error: Policy read error, or an error removing files
+//
+// Example:
+//
+//	pruned, err := manager.EnforceRetention()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Freed %d bytes\n", pruned.Bytes)
+func (cm *ChatMateManager) EnforceRetention() (*state.Pruned, error) {
+	limits, _, err := cm.Retention()
+	if err != nil {
+		return &state.Pruned{}, err
+	}
+	return state.EnforceRetention(limits)
+}
+
+// CacheInfo reports the disk space used by the data directories and the
+// retention limits.
+//
+// Returns:
+//   - *CacheInfo: The usage and limits
+//   - error: Policy read error, or an error reading the directories
+func (cm *ChatMateManager) CacheInfo() (*CacheInfo, error) {
+	limits, source, err := cm.Retention()
+	if err != nil {
+		return nil, err
+	}
+	usage, err := state.MeasureUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	return &CacheInfo{
+		Usage: usage,
+		Limits: RetentionLimits{
+			MaxSize:     limits.MaxSize,
+			MaxAge:      formatAge(limits.MaxAge),
+			MaxVersions: limits.MaxVersions,
+			Source:      source,
+		},
+		OverQuota: limits.MaxSize > 0 && usage.Total > limits.MaxSize,
+	}, nil
+}
+
+// formatAge writes an age in whole days when it is one, e.g. "90d".
+func formatAge(age time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case age == 0:
+		return "0"
+	case age%day == 0:
+		return fmt.Sprintf("%dd", age/day)
+	default:
+		return age.String()
+	}
+}
@@ -0,0 +1,186 @@
+// Package manager provides shared prompts directory support for ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/shared"
+)
+
+// sharedLockWait is how long to wait for another user to finish changing a
+// shared prompts directory.
+const sharedLockWait = 30 * time.Second
+
+// writeChatmate writes a chatmate file into the prompts directory. The
+// content it replaces is archived as a previous version (see Versions).
+//
+// In shared mode the directory is locked while the file is written, the
+// current user is recorded as its owner in the manifest, and a chatmate that
+// another user owns, or that was changed since the manifest recorded it, is
+// only replaced after confirmation.
+//
+// Returns:
+//   - bool: Whether the file was written; false without an error when the
+//     user kept another user's chatmate
+//   - error: Lock, manifest, or file write error
+func (cm *ChatMateManager) writeChatmate(filename string, content []byte) (bool, error) {
+	path := filepath.Join(cm.PromptsDir, filename)
+	write := func() error {
+		cm.archiveReplaced(filename, content)
+		if err := cm.FS.WriteFile(path, content, cm.fileMode()); err != nil {
+			return fileErrorf("failed to write chatmate file %s: %w", path, err)
+		}
+		return nil
+	}
+	if !cm.Shared {
+		err := write()
+		return err == nil, err
+	}
+
+	return cm.changeShared(filename, "replace", write, func(manifest *shared.Manifest) {
+		manifest.Set(shared.Entry{
+			Filename:  cm.canonicalFilename(filename),
+			Owner:     shared.CurrentOwner(),
+			Checksum:  checksum(content),
+			UpdatedAt: time.Now().UTC(),
+		})
+	})
+}
+
+// removeChatmate removes a chatmate file from the prompts directory, with
+// the same locking and conflict checks as writeChatmate in shared mode.
+//
+// Returns:
+//   - bool: Whether the file was removed
+//   - error: Lock, manifest, or file removal error
+func (cm *ChatMateManager) removeChatmate(filename string) (bool, error) {
+	path := filepath.Join(cm.PromptsDir, filename)
+	remove := func() error {
+		if err := cm.FS.Remove(path); err != nil {
+			return fileErrorf("failed to remove chatmate file %s: %w", path, err)
+		}
+		return nil
+	}
+	if !cm.Shared {
+		err := remove()
+		return err == nil, err
+	}
+
+	return cm.changeShared(filename, "remove", remove, func(manifest *shared.Manifest) {
+		manifest.Remove(filename)
+	})
+}
+
+// renameChatmate renames a chatmate file in the prompts directory, with the
+// same locking and conflict checks as writeChatmate in shared mode. The
+// manifest entry of the file keeps its owner under the new name.
+//
+// Returns:
+//   - bool: Whether the file was renamed
+//   - error: Lock, manifest, or file rename error
+func (cm *ChatMateManager) renameChatmate(from, to string) (bool, error) {
+	oldPath, newPath := filepath.Join(cm.PromptsDir, from), filepath.Join(cm.PromptsDir, to)
+	rename := func() error {
+		if err := cm.FS.Rename(oldPath, newPath); err != nil {
+			return fmt.Errorf("failed to rename chatmate file %s: %w", oldPath, err)
+		}
+		return nil
+	}
+	if !cm.Shared {
+		err := rename()
+		return err == nil, err
+	}
+
+	return cm.changeShared(from, "rename", rename, func(manifest *shared.Manifest) {
+		if entry, ok := manifest.Get(from); ok {
+			manifest.Remove(from)
+			entry.Filename = to
+			manifest.Set(entry)
+		}
+	})
+}
+
+// changeShared applies change to a chatmate in a shared prompts directory
+// while holding the directory lock, then records the change in the manifest
+// with update.
+func (cm *ChatMateManager) changeShared(filename, verb string, change func() error, update func(*shared.Manifest)) (bool, error) {
+	// A dry run neither locks the directory nor records the change
+	if cm.dryRun != nil {
+		err := change()
+		return err == nil, err
+	}
+	if err := cm.ensurePromptsDir(); err != nil {
+		return false, err
+	}
+	lock, err := shared.Acquire(cm.PromptsDir, sharedLockWait)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			cm.out().Warnf("%v", err)
+		}
+	}()
+
+	manifest, err := shared.ReadManifest(cm.PromptsDir)
+	if err != nil {
+		return false, err
+	}
+
+	if conflict := cm.sharedConflict(filename, manifest); conflict != "" {
+		if !cm.out().Confirm("%s %s; %s it anyway?", cm.getDisplayName(filename), conflict, verb) {
+			cm.out().Printf("⏭️  %s (kept: %s)\n", filename, conflict)
+			return false, nil
+		}
+	}
+
+	if err := change(); err != nil {
+		return false, err
+	}
+
+	update(manifest)
+	return true, shared.WriteManifest(cm.PromptsDir, manifest)
+}
+
+// sharedConflict describes why changing an installed chatmate could
+// overwrite someone else's work, or returns an empty string if it can't.
+func (cm *ChatMateManager) sharedConflict(filename string, manifest *shared.Manifest) string {
+	current, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, filename))
+	if err != nil {
+		return ""
+	}
+	recorded, ok := manifest.Get(filename)
+	if !ok {
+		return ""
+	}
+
+	if recorded.Checksum != checksum(current) {
+		return fmt.Sprintf("was changed after %s installed it on %s", recorded.Owner, output.FormatDateTime(recorded.UpdatedAt))
+	}
+	if recorded.Owner != shared.CurrentOwner() {
+		return fmt.Sprintf("is owned by %s", recorded.Owner)
+	}
+	return ""
+}
+
+// sharedOwners returns the owner of every chatmate recorded in the manifest
+// of a shared prompts directory, or nil outside shared mode.
+func (cm *ChatMateManager) sharedOwners() map[string]string {
+	if !cm.Shared {
+		return nil
+	}
+	manifest, err := shared.ReadManifest(cm.PromptsDir)
+	if err != nil {
+		cm.out().Debugf("Could not read the shared manifest: %v\n", err)
+		return nil
+	}
+
+	owners := make(map[string]string, len(manifest.Files))
+	for _, entry := range manifest.Files {
+		owners[entry.Filename] = entry.Owner
+	}
+	return owners
+}
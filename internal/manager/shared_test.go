@@ -0,0 +1,77 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/shared"
+)
+
+func TestSharedPromptsDir(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	filename := "Chatmate - A.chatmode.md"
+	content := []byte("---\ndescription: test\n---\nShipped\n")
+	if err := os.WriteFile(filepath.Join(matesDir, filename), content, 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Shared: true}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	if err := cm.Installer().InstallChatmate(filename, false); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if owner := cm.sharedOwners()[filename]; owner != shared.CurrentOwner() {
+		t.Errorf("Expected the current user to own the chatmate, got %q", owner)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, shared.LockFilename)); !os.IsNotExist(err) {
+		t.Errorf("Expected the lock to be released, got %v", err)
+	}
+
+	// Another user installed the chatmate
+	manifest, err := shared.ReadManifest(promptsDir)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	entry, _ := manifest.Get(filename)
+	entry.Owner = "someone@else"
+	manifest.Set(entry)
+	if err := shared.WriteManifest(promptsDir, manifest); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	// Without confirmation the other user's chatmate is kept
+	if err := cm.Installer().InstallChatmate(filename, true); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if owner := cm.sharedOwners()[filename]; owner != "someone@else" {
+		t.Errorf("Expected the other user's chatmate to be kept, got owner %q", owner)
+	}
+	if err := cm.Uninstaller().UninstallChatmate(filename); err != nil {
+		t.Fatalf("UninstallChatmate failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, filename)); err != nil {
+		t.Errorf("Expected the other user's chatmate not to be removed, got %v", err)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if err := cm.Installer().InstallChatmate(filename, true); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if owner := cm.sharedOwners()[filename]; owner != shared.CurrentOwner() {
+		t.Errorf("Expected a confirmed replacement to take ownership, got %q", owner)
+	}
+	if err := cm.Uninstaller().UninstallChatmate(filename); err != nil {
+		t.Fatalf("UninstallChatmate failed: %v", err)
+	}
+	if _, ok := cm.sharedOwners()[filename]; ok {
+		t.Error("Expected an uninstalled chatmate to be removed from the manifest")
+	}
+}
@@ -0,0 +1,492 @@
+// Package manager provides the sources ChatMate agents are installed from.
+package manager
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// Source provides chatmates that can be installed.
+//
+// Every manager reads chatmates from its built-in source: the chatmates
+// embedded in the binary, or the mates directory of a development checkout
+// (see UseEmbedded and MatesDir). Further sources are configured with
+// 'chatmate source add' (see ParseSource and ChatMateManager.Sources).
+//
+// Implementations must be safe for concurrent use, as bulk installations
+// read several chatmates at the same time. Sources that read over the
+// network stop when the context they are given is done.
+type Source interface {
+	// Kind returns how chatmates from the source are recorded in their
+	// provenance, e.g. state.SourceEmbedded
+	Kind() string
+	// Location returns where the source reads chatmates from, e.g. a
+	// directory or URL
+	Location() string
+	// List returns the sorted filenames of the chatmates of the source
+	List(ctx context.Context) ([]string, error)
+	// Open opens a chatmate of the source for reading
+	Open(ctx context.Context, filename string) (io.ReadCloser, error)
+}
+
+// EmbeddedSource provides the chatmates embedded in the chatmate binary.
+type EmbeddedSource struct{}
+
+// Kind returns state.SourceEmbedded.
+func (EmbeddedSource) Kind() string { return state.SourceEmbedded }
+
+// Location returns RepositoryURL, where the embedded chatmates are published.
+func (EmbeddedSource) Location() string { return RepositoryURL }
+
+// List returns the embedded chatmates.
+func (EmbeddedSource) List(context.Context) ([]string, error) {
+	return assets.GetEmbeddedMatesList()
+}
+
+// Open opens an embedded chatmate.
+func (EmbeddedSource) Open(_ context.Context, filename string) (io.ReadCloser, error) {
+	f, err := assets.OpenEmbeddedMate(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded chatmate %s: %w", filename, err)
+	}
+	return f, nil
+}
+
+// DirectorySource provides the chatmates in a directory.
+//
+// Fields:
+//   - Dir: The directory containing the .chatmode.md files
+//   - FS: Timeout and retry policy for reading the directory; the real
+//     filesystem without a timeout when nil
+type DirectorySource struct {
+	Dir string
+	FS  *files.Policy
+}
+
+// Kind returns state.SourceDirectory.
+func (d *DirectorySource) Kind() string { return state.SourceDirectory }
+
+// Location returns the directory.
+func (d *DirectorySource) Location() string { return d.Dir }
+
+// List returns the chatmates in the directory.
+func (d *DirectorySource) List(ctx context.Context) ([]string, error) {
+	return scanChatmateDir(d.policy(ctx).FileSystem(), d.Dir)
+}
+
+// Open opens a chatmate in the directory.
+func (d *DirectorySource) Open(ctx context.Context, filename string) (io.ReadCloser, error) {
+	f, err := d.policy(ctx).Open(d.path(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chatmate file %s: %w", d.path(filename), err)
+	}
+	return f, nil
+}
+
+// path returns the path of a chatmate in the directory.
+func (d *DirectorySource) path(filename string) string {
+	return filepath.Join(d.Dir, filename)
+}
+
+// policy returns FS, or the zero policy when it is nil, bound to ctx
+// unless it is nil.
+func (d *DirectorySource) policy(ctx context.Context) files.Policy {
+	var policy files.Policy
+	if d.FS != nil {
+		policy = *d.FS
+	}
+	if ctx != nil {
+		policy.Context = ctx
+	}
+	return policy
+}
+
+// SourceTTL is how long a checkout of a git source is used before it is
+// fetched again.
+const SourceTTL = time.Hour
+
+// gitTimeout bounds cloning and fetching a git source, in addition to the
+// context it is read with.
+const gitTimeout = 2 * time.Minute
+
+// GitSource provides the chatmates of a git repository.
+//
+// The repository is cloned into the cache directory on first use and fetched
+// again once the checkout is older than SourceTTL; when fetching fails, e.g.
+// offline, the existing checkout is used. Chatmates are read from the mates
+// directory of the repository if it has one, and from its root otherwise.
+// The git command must be installed. Cloning and fetching stop when the
+// context of the first read is done, and the source then stays unreadable.
+//
+// Fields:
+//   - URL: The repository, in any form 'git clone' accepts
+//   - Ref: The branch or tag to check out; the default branch when empty
+//   - CacheDir: The directory the repository is cloned into
+type GitSource struct {
+	URL      string
+	Ref      string
+	CacheDir string
+
+	mu      sync.Mutex
+	checked bool
+	dir     string
+	err     error
+}
+
+// Kind returns state.SourceGit.
+func (g *GitSource) Kind() string { return state.SourceGit }
+
+// Location returns the repository URL, followed by "#" and the ref when
+// one is set.
+func (g *GitSource) Location() string {
+	if g.Ref != "" {
+		return g.URL + "#" + g.Ref
+	}
+	return g.URL
+}
+
+// List returns the chatmates of the repository.
+func (g *GitSource) List(ctx context.Context) ([]string, error) {
+	dir, err := g.checkout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return scanChatmateDir(files.OS{}, dir)
+}
+
+// Open opens a chatmate of the repository.
+func (g *GitSource) Open(ctx context.Context, filename string) (io.ReadCloser, error) {
+	dir, err := g.checkout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Base(filename) != filename {
+		return nil, fmt.Errorf("invalid chatmate filename %q", filename)
+	}
+	f, err := os.Open(filepath.Join(dir, filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chatmate %s from %s: %w", filename, g.Location(), err)
+	}
+	return f, nil
+}
+
+// checkout clones or refreshes the repository once per source and returns
+// the directory its chatmates are in.
+func (g *GitSource) checkout(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.checked {
+		return g.dir, g.err
+	}
+	g.checked = true
+	if g.CacheDir == "" {
+		g.err = fmt.Errorf("no cache directory to clone %s into", g.URL)
+		return "", g.err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, gitTimeout)
+	defer cancel()
+	dir := filepath.Join(g.CacheDir, "sources", sourceKey(g.Location()))
+	// Read-only mode uses an existing checkout as it is
+	writeErr := files.CheckWrite("clone", dir)
+	if info, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if writeErr != nil {
+			g.err = writeErr
+			return "", g.err
+		}
+		// Remove what an interrupted clone left behind
+		_ = os.RemoveAll(dir)
+		args := []string{"clone", "--quiet", "--depth", "1"}
+		if g.Ref != "" {
+			args = append(args, "--branch", g.Ref)
+		}
+		if err := runGit(ctx, "", append(args, "--", g.URL, dir)...); err != nil {
+			g.err = fmt.Errorf("failed to clone %s: %w", g.URL, err)
+			return "", g.err
+		}
+	} else if fetched, err := os.Stat(filepath.Join(dir, ".git", "FETCH_HEAD")); writeErr == nil &&
+		(err == nil && time.Since(fetched.ModTime()) > SourceTTL || err != nil && time.Since(info.ModTime()) > SourceTTL) {
+		ref := g.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+		if err := runGit(ctx, dir, "fetch", "--quiet", "--depth", "1", "origin", ref); err == nil {
+			_ = runGit(ctx, dir, "reset", "--quiet", "--hard", "FETCH_HEAD")
+		}
+	}
+
+	g.dir = dir
+	if info, err := os.Stat(filepath.Join(dir, "mates")); err == nil && info.IsDir() {
+		g.dir = filepath.Join(dir, "mates")
+	}
+	return g.dir, nil
+}
+
+// runGit runs git without prompting for credentials, and adds the last line
+// of its output to the error when it fails.
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("git is not installed: %w", err)
+	}
+	if err != nil {
+		if lines := strings.Split(strings.TrimSpace(output.String()), "\n"); lines[len(lines)-1] != "" {
+			return fmt.Errorf("%w: %s", err, lines[len(lines)-1])
+		}
+	}
+	return err
+}
+
+// HTTPSource provides the chatmates of an index served over HTTPS.
+//
+// The index has the format of the community registry (see package
+// registry), so a company can publish its chatmates the same way; every
+// chatmate is verified against the SHA-256 the index lists.
+//
+// Fields:
+//   - Client: Reads the index and downloads the chatmates
+type HTTPSource struct {
+	Client *registry.Client
+
+	mu    sync.Mutex
+	index *registry.Index
+	err   error
+}
+
+// Kind returns state.SourceHTTP.
+func (h *HTTPSource) Kind() string { return state.SourceHTTP }
+
+// Location returns the index URL.
+func (h *HTTPSource) Location() string { return h.Client.URL }
+
+// List returns the chatmates of the index.
+func (h *HTTPSource) List(ctx context.Context) ([]string, error) {
+	index, err := h.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filenames := make([]string, 0, len(index.Chatmates))
+	for _, entry := range index.Chatmates {
+		filenames = append(filenames, entry.Filename)
+	}
+	sort.Strings(filenames)
+	return filenames, nil
+}
+
+// Open downloads a chatmate of the index.
+func (h *HTTPSource) Open(ctx context.Context, filename string) (io.ReadCloser, error) {
+	index, err := h.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range index.Chatmates {
+		if entry.Filename != filename {
+			continue
+		}
+		content, err := h.Client.Download(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	return nil, fmt.Errorf("%s does not list %s", h.Client.URL, filename)
+}
+
+// load reads the index once per source.
+func (h *HTTPSource) load(ctx context.Context) (*registry.Index, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.index == nil && h.err == nil {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		h.index, h.err = h.Client.Index(ctx, false)
+	}
+	return h.index, h.err
+}
+
+// ParseSource returns the source a 'chatmate source add' argument stands for.
+//
+// The kind of source follows from the form of spec:
+//   - "embedded": The chatmates embedded in the binary
+//   - A git URL, i.e. starting with "git+" or "git@" or ending in ".git",
+//     optionally followed by "#" and a branch or tag: A GitSource
+//   - An https URL: An HTTPSource reading the index at the URL
+//   - Anything else: A DirectorySource; "~" is the home directory
+//
+// Parameters:
+//   - spec: The source
+//   - cacheDir: The directory git sources are cloned into and indexes are
+//     cached in; nothing is cached when empty
+//
+// Returns:
+//   - Source: The source; nothing is read until it is used
+//   - error: Error if spec is empty or a plain http URL
+//
+// Example:
+//
+//	source, err := ParseSource("https://github.com/acme/chatmates.git#v2", cacheDir)
+func ParseSource(spec, cacheDir string) (Source, error) {
+	spec = strings.TrimSpace(spec)
+	location, _, _ := strings.Cut(spec, "#")
+	switch {
+	case spec == "":
+		return nil, fmt.Errorf("source must not be empty")
+	case spec == state.SourceEmbedded:
+		return EmbeddedSource{}, nil
+	case strings.HasPrefix(spec, "git+"), strings.HasPrefix(spec, "git@"), strings.HasSuffix(location, ".git"):
+		url, ref, _ := strings.Cut(strings.TrimPrefix(spec, "git+"), "#")
+		return &GitSource{URL: url, Ref: ref, CacheDir: cacheDir}, nil
+	case strings.HasPrefix(spec, "https://"):
+		client := &registry.Client{URL: spec}
+		if cacheDir != "" {
+			client.CachePath = filepath.Join(cacheDir, "sources", sourceKey(spec)+".json")
+		}
+		return &HTTPSource{Client: client}, nil
+	case strings.HasPrefix(spec, "http://"):
+		return nil, fmt.Errorf("source %s must use https", spec)
+	}
+
+	dir, err := filepath.Abs(files.ExpandPath(spec))
+	if err != nil {
+		return nil, fmt.Errorf("invalid source directory %s: %w", spec, err)
+	}
+	return &DirectorySource{Dir: dir}, nil
+}
+
+// sourceKey names the cached copy of a source after its location.
+func sourceKey(location string) string {
+	sum := sha256.Sum256([]byte(location))
+	return hex.EncodeToString(sum[:8])
+}
+
+// isSourceKind returns whether chatmates recorded with the provenance kind
+// came from a source, so they are updated and pruned with the sources.
+func isSourceKind(kind string) bool {
+	switch kind {
+	case state.SourceEmbedded, state.SourceDirectory, state.SourceGit, state.SourceHTTP:
+		return true
+	}
+	return false
+}
+
+// UseSources replaces the configured sources with the ones specs stand for
+// (see ParseSource). Git sources are cloned into, and indexes cached in, the
+// ChatMate cache directory.
+//
+// Parameters:
+//   - specs: The sources, in the order chatmates are looked up in
+//
+// Returns:
+//   - error: Why specs could not be used; the other sources are used anyway
+func (cm *ChatMateManager) UseSources(specs []string) error {
+	cacheDir, _ := platform.GetChatMateCacheDir()
+	var sources []Source
+	var errs []error
+	for _, spec := range specs {
+		source, err := ParseSource(spec, cacheDir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		sources = append(sources, source)
+	}
+
+	cm.sourcesMu.Lock()
+	cm.Sources, cm.origins = sources, nil
+	cm.sourcesMu.Unlock()
+	return errors.Join(errs...)
+}
+
+// builtinSource returns the source the manager reads chatmates from unless
+// configured sources provide them: the embedded chatmates, or the mates
+// directory.
+func (cm *ChatMateManager) builtinSource() Source {
+	if cm.UseEmbedded {
+		return EmbeddedSource{}
+	}
+	return &DirectorySource{Dir: cm.MatesDir, FS: &cm.FS}
+}
+
+// sourceOf returns the source an available chatmate is read from: the
+// configured source that listed it, or the built-in source.
+func (cm *ChatMateManager) sourceOf(filename string) Source {
+	if len(cm.Sources) > 0 {
+		cm.sourcesMu.Lock()
+		listed := cm.origins != nil
+		cm.sourcesMu.Unlock()
+		if !listed {
+			_, _ = cm.GetAvailableChatmates()
+		}
+		cm.sourcesMu.Lock()
+		defer cm.sourcesMu.Unlock()
+		if source, ok := cm.origins[filename]; ok {
+			return source
+		}
+	}
+	return cm.builtinSource()
+}
+
+// SourceInfo describes a source, as listed by 'chatmate source list'.
+//
+// Fields:
+//   - Kind: How chatmates from the source are recorded in their provenance
+//   - Location: Where the source reads chatmates from
+//   - Builtin: Whether it is the built-in source rather than a configured one
+//   - Chatmates: The number of chatmates the source provides
+//   - Error: Why the source could not be read; empty when it could
+type SourceInfo struct {
+	Kind      string `json:"kind"`
+	Location  string `json:"location"`
+	Builtin   bool   `json:"builtin"`
+	Chatmates int    `json:"chatmates"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ListSources reads every source, so configured git repositories are cloned
+// and indexes fetched, and reports how many chatmates each provides.
+//
+// Returns:
+//   - []SourceInfo: The built-in source followed by the configured ones, in
+//     the order chatmates are looked up in
+func (cm *ChatMateManager) ListSources() []SourceInfo {
+	sources := append([]Source{cm.builtinSource()}, cm.Sources...)
+	infos := make([]SourceInfo, 0, len(sources))
+	for i, source := range sources {
+		info := SourceInfo{Kind: source.Kind(), Location: source.Location(), Builtin: i == 0}
+		if chatmates, err := source.List(cm.context()); err != nil {
+			info.Error = err.Error()
+		} else {
+			info.Chatmates = len(chatmates)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
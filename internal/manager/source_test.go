@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/mockregistry"
+)
+
+// TestSources tests installing chatmates from configured sources
+func TestSources(t *testing.T) {
+	matesDir, teamDir := t.TempDir(), t.TempDir()
+	promptsDir := filepath.Join(t.TempDir(), "prompts")
+	provenancePath := filepath.Join(t.TempDir(), state.ProvenanceFilename)
+	for dir, mates := range map[string][]string{matesDir: {"A", "B"}, teamDir: {"B", "Team"}} {
+		for _, name := range mates {
+			content := fmt.Sprintf("---\ndescription: %s from %s\n---\n", name, filepath.Base(dir))
+			if err := os.WriteFile(filepath.Join(dir, name+".chatmode.md"), []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+		}
+	}
+
+	server := mockregistry.New(t)
+	server.Add(mockregistry.Chatmate{Name: "Remote", Description: "Remote", Version: "1.0.0", Content: "---\ndescription: Remote\n---\n"})
+	remote, err := ParseSource(server.IndexURL(), "")
+	if err != nil {
+		t.Fatalf("ParseSource failed: %v", err)
+	}
+	remote.(*HTTPSource).Client = server.Client("")
+
+	var stderr bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Concurrency: 1, ErrOutput: &stderr, provenancePath: provenancePath,
+		Sources: []Source{&DirectorySource{Dir: teamDir}, &DirectorySource{Dir: filepath.Join(teamDir, "missing")}, remote}}
+	cm.installer = NewInstallerService(cm)
+
+	// A chatmate of several sources is read from the first of them, and a
+	// source that cannot be read is skipped with a warning
+	available, err := cm.GetAvailableChatmates()
+	if err != nil {
+		t.Fatalf("GetAvailableChatmates failed: %v", err)
+	}
+	if want := []string{"A.chatmode.md", "B.chatmode.md", "Remote.chatmode.md", "Team.chatmode.md"}; !slices.Equal(available, want) {
+		t.Errorf("Expected %v, got %v", want, available)
+	}
+	if !strings.Contains(stderr.String(), "missing") {
+		t.Errorf("Expected a warning about the missing source, got %q", stderr.String())
+	}
+	if content, err := cm.GetChatmateContent("B.chatmode.md"); err != nil || !strings.Contains(string(content), "from "+filepath.Base(matesDir)) {
+		t.Errorf("Expected B from the mates directory, got %q, %v", content, err)
+	}
+
+	if _, err := cm.Installer().InstallAll(context.Background(), false); err != nil {
+		t.Fatalf("InstallAll failed: %v", err)
+	}
+	log, err := state.ReadProvenance(provenancePath)
+	if err != nil {
+		t.Fatalf("Failed to read provenance: %v", err)
+	}
+	for filename, want := range map[string][2]string{
+		"A.chatmode.md":      {state.SourceDirectory, matesDir},
+		"Team.chatmode.md":   {state.SourceDirectory, teamDir},
+		"Remote.chatmode.md": {state.SourceHTTP, server.IndexURL()},
+	} {
+		if record, ok := log.Get(promptsDir, filename); !ok || record.Source != want[0] || record.Location != want[1] {
+			t.Errorf("Expected %s to be installed from %v, got %+v", filename, want, record)
+		}
+	}
+
+	infos := cm.ListSources()
+	if len(infos) != 4 || !infos[0].Builtin || infos[1].Chatmates != 2 || infos[2].Error == "" || infos[3].Chatmates != 1 {
+		t.Errorf("Unexpected sources %+v", infos)
+	}
+}
+
+// TestParseSource tests recognizing the kinds of sources
+func TestParseSource(t *testing.T) {
+	for spec, kind := range map[string]string{
+		"embedded":       state.SourceEmbedded,
+		"/srv/chatmates": state.SourceDirectory,
+		"https://github.com/acme/chatmates.git#v2": state.SourceGit,
+		"git+ssh://git@example.com/acme/chatmates": state.SourceGit,
+		"git@github.com:acme/chatmates.git":        state.SourceGit,
+		"https://chatmates.example.com/index.json": state.SourceHTTP,
+	} {
+		if source, err := ParseSource(spec, t.TempDir()); err != nil || source.Kind() != kind {
+			t.Errorf("Expected %s to be a %s source, got %v, %v", spec, kind, source, err)
+		}
+	}
+	if source, _ := ParseSource("https://github.com/acme/chatmates.git#v2", ""); source.(*GitSource).Ref != "v2" || source.Location() != "https://github.com/acme/chatmates.git#v2" {
+		t.Errorf("Expected the ref to be parsed, got %+v", source)
+	}
+	for _, spec := range []string{"", "http://example.com/index.json"} {
+		if _, err := ParseSource(spec, ""); err == nil {
+			t.Errorf("Expected ParseSource(%q) to fail", spec)
+		}
+	}
+}
+
+// TestGitSource tests reading chatmates from a git repository
+func TestGitSource(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	commitFile(t, repo, filepath.Join("mates", "Git.chatmode.md"), "---\ndescription: Git\n---\n")
+
+	source := &GitSource{URL: repo, CacheDir: t.TempDir()}
+	chatmates, err := source.List(context.Background())
+	if err != nil || !slices.Equal(chatmates, []string{"Git.chatmode.md"}) {
+		t.Fatalf("Expected the chatmate of the mates directory, got %v, %v", chatmates, err)
+	}
+	f, err := source.Open(context.Background(), "Git.chatmode.md")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer f.Close()
+	if content, _ := io.ReadAll(f); !strings.Contains(string(content), "description: Git") {
+		t.Errorf("Unexpected content %q", content)
+	}
+
+	if _, err := (&GitSource{URL: filepath.Join(repo, "missing"), CacheDir: t.TempDir()}).List(context.Background()); err == nil {
+		t.Error("Expected cloning a missing repository to fail")
+	}
+
+	// A cancelled command does not clone
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (&GitSource{URL: repo, CacheDir: t.TempDir()}).List(ctx); err == nil {
+		t.Error("Expected cloning with a cancelled context to fail")
+	}
+}
+
+// TestHTTPSourceCancelled tests that reading an index stops with the context
+func TestHTTPSourceCancelled(t *testing.T) {
+	server := mockregistry.New(t)
+	server.Add(mockregistry.Chatmate{Name: "Remote", Description: "Remote", Version: "1.0.0", Content: "---\ndescription: Remote\n---\n"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := &HTTPSource{Client: server.Client("")}
+	if _, err := source.List(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation to stop reading the index, got %v", err)
+	}
+}
+
+// commitFile writes a file into a git repository, creating the repository
+// if needed, and commits it.
+func commitFile(t *testing.T, repo, name, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(repo, name)), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	commands := [][]string{
+		{"add", "."},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Add " + name},
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".git")); err != nil {
+		commands = append([][]string{{"init", "--quiet"}}, commands...)
+	}
+	for _, args := range commands {
+		if out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+}
@@ -1,14 +1,27 @@
-// Package manager provides status and configuration display functionality for ChatMate agents.
+// Package manager provides status and configuration reporting for ChatMate agents.
 package manager
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/settings"
+	"github.com/jonassiebler/chatmate/internal/state"
 )
 
-// StatusService handles chatmate status and configuration display operations.
+// recentActivityLimit is the number of operations shown in the Recent Activity
+// section of the status report.
+const recentActivityLimit = 5
+
+// StatusService reports the chatmate status and configuration.
+//
+// Fields:
+//   - Since: Only report operations recorded at or after this time as recent
+//     operations; the zero value reports the latest operations
 type StatusService struct {
 	manager *ChatMateManager
+	Since   time.Time
 }
 
 // NewStatusService creates a new status service.
@@ -16,110 +29,285 @@ func NewStatusService(manager *ChatMateManager) *StatusService {
 	return &StatusService{manager: manager}
 }
 
-// ShowStatus displays comprehensive status information.
+// StatusReport is the machine-readable form of the status report.
 //
-// This method provides a detailed overview of the chatmate system status,
-// including directory information, installation statistics, and configuration.
+// The health checks are not included; 'chatmate validate --json' reports
+// them in detail.
+//
+// Fields:
+//   - PromptsDir: The prompts directory, with symlinks resolved
+//   - PromptsDirExists: Whether the prompts directory exists and is usable
+//   - Headless: Whether chatmates are managed without VS Code
+//   - Embedded: Whether the embedded chatmates are used
+//   - Shared: Whether the prompts directory is shared by several users
+//   - Available, Installed, Outdated, Adopted, Ignored: Chatmate counts
+//   - Modified: Number of installed chatmates edited since ChatMate
+//     installed them
+//   - MissingRequired: Display names of the chatmates the team policy
+//     requires that are not installed
+//   - PendingApprovals: Filenames of installations awaiting approval
+//   - RecentOperations: The latest recorded operations, newest first
+type StatusReport struct {
+	PromptsDir       string          `json:"promptsDir"`
+	PromptsDirExists bool            `json:"promptsDirExists"`
+	Headless         bool            `json:"headless"`
+	Embedded         bool            `json:"embedded"`
+	Shared           bool            `json:"shared"`
+	Available        int             `json:"available"`
+	Installed        int             `json:"installed"`
+	Outdated         int             `json:"outdated"`
+	Modified         int             `json:"modified"`
+	Adopted          int             `json:"adopted"`
+	Ignored          int             `json:"ignored"`
+	MissingRequired  []string        `json:"missingRequired"`
+	PendingApprovals []string        `json:"pendingApprovals"`
+	RecentOperations []state.Summary `json:"recentOperations"`
+}
+
+// StatusDetails is everything the status report shows, including what
+// StatusReport leaves out of machine-readable output.
+//
+// Fields:
+//   - Report: The machine-readable status report
+//   - Config: Directory paths and operational modes
+//   - PromptsDirLabel: How the prompts directory is named in the report
+//   - PromptsDirError: Why the prompts directory cannot be used, if it can't
+//   - Health: The installation validation report
+//   - Policy: The state of the chatmates the team policy requires; nil if
+//     the policy could not be read
+//   - PolicyError: Why the team policy could not be read
+//   - PendingApprovals: Installations awaiting approval
+//   - Since: The earliest time of the recent operations; zero for the latest
+//   - EarlierOperations: Number of matching operations left out of
+//     Report.RecentOperations
+//   - HistoryError: Why the operation history could not be read
+type StatusDetails struct {
+	Report            *StatusReport
+	Config            *ConfigReport
+	PromptsDirLabel   string
+	PromptsDirError   error
+	Health            *Report
+	Policy            *Compliance
+	PolicyError       error
+	PendingApprovals  []state.ApprovalRequest
+	Since             time.Time
+	EarlierOperations int
+	HistoryError      error
+}
+
+// Details returns everything the status report shows.
+//
+// Like Report, a team policy or history that cannot be read does not fail
+// the details; the error is recorded instead.
+//
+// Parameters:
+//   - ctx: Bounds the health checks (see ValidatorService.ValidateInstallation)
 //
 // Returns:
-//   - error: Status retrieval failure
+//   - *StatusDetails: The status of the installation with health checks
+//   - error: Chatmate discovery or validation failure
 //
 // Example:
 //
-//err := status.ShowStatus()
-//if err != nil {
-//    return fmt.Errorf("status display failed: %w", err)
-//}
-func (s *StatusService) ShowStatus() error {
-	fmt.Println("=== ChatMate Status ===")
-
-	// Directory Information
-	fmt.Printf("VS Code Prompts Directory: %s\n", s.manager.PromptsDir)
-	if !s.manager.UseEmbedded {
-		fmt.Printf("Mates Source Directory: %s\n", s.manager.MatesDir)
-	} else {
-		fmt.Println("Using embedded chatmate resources")
+// details, err := status.Details(ctx)
+//
+//	if err != nil {
+//	   return fmt.Errorf("status failed: %w", err)
+//	}
+//
+//	fmt.Printf("%d of %d installed\n", details.Report.Installed, details.Report.Available)
+func (s *StatusService) Details(ctx context.Context) (*StatusDetails, error) {
+	report, err := s.Report()
+	if err != nil {
+		return nil, err
 	}
 
-	// Check directory existence
-	if _, err := os.Stat(s.manager.PromptsDir); os.IsNotExist(err) {
-		fmt.Printf("❌ Prompts directory does not exist: %s\n", s.manager.PromptsDir)
-	} else {
-		fmt.Printf("✅ Prompts directory exists: %s\n", s.manager.PromptsDir)
+	details := &StatusDetails{
+		Report:          report,
+		Config:          s.Config(),
+		PromptsDirLabel: s.manager.PromptsDirLabel(),
+		PromptsDirError: s.manager.promptsDirErr,
+		Since:           s.Since,
 	}
 
-	// Get chatmate counts
-	availableChatmates, err := s.manager.GetAvailableChatmates()
+	details.Health, err = NewValidatorService(s.manager).ValidateInstallation(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get available chatmates: %w", err)
+		return nil, fmt.Errorf("failed to validate installation: %w", err)
 	}
 
-	installedChatmates, err := s.manager.GetInstalledChatmates()
-	if err != nil {
-		return fmt.Errorf("failed to get installed chatmates: %w", err)
+	details.Policy, details.PolicyError = s.manager.PolicyCompliance()
+	if details.PendingApprovals, err = s.manager.PendingApprovals(); err != nil {
+		s.manager.out().Debugf("Could not read approval requests: %v\n", err)
 	}
 
-	// Installation Statistics
-	fmt.Printf("\n=== Installation Statistics ===\n")
-	fmt.Printf("Available Chatmates: %d\n", len(availableChatmates))
-	fmt.Printf("Installed Chatmates: %d\n", len(installedChatmates))
-
-	if len(availableChatmates) > 0 {
-		percentage := float64(len(installedChatmates)) / float64(len(availableChatmates)) * 100
-		fmt.Printf("Installation Coverage: %.1f%%\n", percentage)
+	if history, err := state.ReadHistory(); err == nil {
+		_, details.EarlierOperations = recentOperations(history, s.Since, recentActivityLimit)
+	} else {
+		details.HistoryError = err
 	}
+	return details, nil
+}
 
-	// Check for issues
-	orphanedCount := s.countOrphanedFiles(availableChatmates, installedChatmates)
-	if orphanedCount > 0 {
-		fmt.Printf("⚠️  Orphaned Files: %d (consider running cleanup)\n", orphanedCount)
+// Report returns the status report for machine-readable output.
+//
+// A team policy or history that cannot be read does not fail the report;
+// the affected fields are left empty.
+//
+// Returns:
+//   - *StatusReport: The status of the installation
+//   - error: Chatmate discovery failure
+func (s *StatusService) Report() (*StatusReport, error) {
+	inventory, err := s.manager.Inventory()
+	if err != nil {
+		return nil, err
 	}
 
-	// Configuration Information
-	fmt.Printf("\n=== Configuration ===\n")
-	fmt.Printf("Using Embedded Resources: %t\n", s.manager.UseEmbedded)
-
-	// Recent Activity (if any logs exist)
-	s.showRecentActivity()
+	report := &StatusReport{
+		PromptsDir:       s.manager.PromptsDir,
+		Headless:         s.manager.Headless,
+		Embedded:         s.manager.UseEmbedded,
+		Shared:           s.manager.Shared,
+		Available:        len(inventory.Available),
+		Installed:        len(inventory.Installed),
+		Outdated:         inventory.Outdated,
+		Modified:         len(s.manager.modifiedSet(inventory.Installed)),
+		Adopted:          len(s.manager.adoptedSet()),
+		Ignored:          len(inventory.Ignored),
+		MissingRequired:  []string{},
+		PendingApprovals: []string{},
+		RecentOperations: []state.Summary{},
+	}
+	if s.manager.promptsDirErr == nil {
+		_, err := s.manager.FS.Stat(s.manager.PromptsDir)
+		report.PromptsDirExists = err == nil
+	}
 
-	return nil
+	if compliance, err := s.manager.PolicyCompliance(); err == nil {
+		for _, filename := range compliance.Missing {
+			report.MissingRequired = append(report.MissingRequired, DisplayName(filename))
+		}
+		report.MissingRequired = append(report.MissingRequired, compliance.Unknown...)
+	} else {
+		s.manager.out().Debugf("Could not check the team policy: %v\n", err)
+	}
+	if pending, err := s.manager.PendingApprovals(); err == nil {
+		for _, request := range pending {
+			report.PendingApprovals = append(report.PendingApprovals, request.Filename)
+		}
+	}
+	if history, err := state.ReadHistory(); err == nil {
+		recent, _ := recentOperations(history, s.Since, recentActivityLimit)
+		report.RecentOperations = append(report.RecentOperations, recent...)
+	}
+	return report, nil
 }
 
-// ShowConfig displays the current ChatMate configuration.
+// ConfigReport is the machine-readable form of the ChatMate configuration.
 //
-// This method shows the current configuration settings for the ChatMate manager,
-// including directory paths and operational modes.
+// Paths that cannot be determined on this machine are left empty.
 //
-// Example:
-//
-//status.ShowConfig()
-func (s *StatusService) ShowConfig() {
-	fmt.Println("=== ChatMate Configuration ===")
-	fmt.Printf("Script Directory: %s\n", s.manager.ScriptDir)
-	fmt.Printf("Mates Directory: %s\n", s.manager.MatesDir)
-	fmt.Printf("VS Code Prompts Directory: %s\n", s.manager.PromptsDir)
-	fmt.Printf("Using Embedded Resources: %t\n", s.manager.UseEmbedded)
+// Fields:
+//   - ScriptDir, MatesDir: Where ChatMate runs from and reads chatmates
+//   - PromptsDir: The prompts directory, with symlinks resolved
+//   - ConfiguredPromptsDir: The symlink PromptsDir was resolved from, if any
+//   - Embedded, Headless, Shared: The operational modes
+//   - StateDir: The machine-local state directory
+//   - ConfigDir: The config directory, which is safe to sync
+//   - SummaryPath, InventoryCache, IgnoreList, TeamPolicy: ChatMate's files
+//   - Registry: URL of the chatmate registry
+//   - Sources: Locations of the configured sources of chatmates
+//   - ConfigFile: The configuration file of 'chatmate config set'
+type ConfigReport struct {
+	ScriptDir            string   `json:"scriptDir"`
+	MatesDir             string   `json:"matesDir"`
+	PromptsDir           string   `json:"promptsDir"`
+	ConfiguredPromptsDir string   `json:"configuredPromptsDir,omitempty"`
+	Embedded             bool     `json:"embedded"`
+	Headless             bool     `json:"headless"`
+	Shared               bool     `json:"shared"`
+	StateDir             string   `json:"stateDir,omitempty"`
+	ConfigDir            string   `json:"configDir,omitempty"`
+	SummaryPath          string   `json:"summaryPath,omitempty"`
+	InventoryCache       string   `json:"inventoryCache,omitempty"`
+	IgnoreList           string   `json:"ignoreList"`
+	TeamPolicy           string   `json:"teamPolicy,omitempty"`
+	ConfigFile           string   `json:"configFile,omitempty"`
+	Registry             string   `json:"registry,omitempty"`
+	Sources              []string `json:"sources,omitempty"`
 }
 
-// countOrphanedFiles counts files that are installed but not available.
-func (s *StatusService) countOrphanedFiles(available, installed []string) int {
-	availableSet := make(map[string]bool)
-	for _, filename := range available {
-		availableSet[filename] = true
+// Config returns the current ChatMate configuration.
+//
+// Returns:
+//   - *ConfigReport: Directory paths and operational modes
+func (s *StatusService) Config() *ConfigReport {
+	report := &ConfigReport{
+		ScriptDir:            s.manager.ScriptDir,
+		MatesDir:             s.manager.MatesDir,
+		PromptsDir:           s.manager.PromptsDir,
+		ConfiguredPromptsDir: s.manager.ConfiguredPromptsDir,
+		Embedded:             s.manager.UseEmbedded,
+		Headless:             s.manager.Headless,
+		Shared:               s.manager.Shared,
+		InventoryCache:       s.manager.inventoryPath,
+		IgnoreList:           s.manager.IgnorePath(),
+		TeamPolicy:           s.manager.policyPath,
+	}
+	report.StateDir, _ = state.LocalDir()
+	report.ConfigDir, _ = state.RoamingDir()
+	report.SummaryPath, _ = state.SummaryPath()
+	report.ConfigFile, _ = settings.Path()
+	if s.manager.Registry != nil {
+		report.Registry = s.manager.Registry.URL
+	}
+	for _, source := range s.manager.Sources {
+		report.Sources = append(report.Sources, source.Location())
 	}
+	return report
+}
 
-	orphanedCount := 0
-	for _, filename := range installed {
-		if !availableSet[filename] {
-			orphanedCount++
-		}
+// Counts returns the number of installed, available, and outdated chatmates.
+//
+// A chatmate is considered outdated when it is installed and its content
+// differs from the version shipped with ChatMate. A missing prompts directory
+// is treated as having nothing installed. Counts are served from the cached
+// inventory when it is still valid.
+//
+// Returns:
+//   - installed: Number of installed chatmates
+//   - available: Number of available chatmates
+//   - outdated: Number of installed repository chatmates with changed content
+//   - err: Chatmate discovery failure
+func (s *StatusService) Counts() (installed, available, outdated int, err error) {
+	inventory, err := s.manager.Inventory()
+	if err != nil {
+		return 0, 0, 0, err
 	}
 
-	return orphanedCount
+	return len(inventory.Installed), len(inventory.Available), inventory.Outdated, nil
 }
 
-// showRecentActivity displays recent activity information if available.
-func (s *StatusService) showRecentActivity() {
-	fmt.Printf("\n=== Recent Activity ===\n")
-	fmt.Println("(Activity logging not yet implemented)")
+// recentOperations selects the operations to show from the history.
+//
+// Parameters:
+//   - history: Recorded operations, oldest first
+//   - since: Drop operations recorded before this time; zero keeps all
+//   - limit: Maximum number of operations to return
+//
+// Returns:
+//   - []state.Summary: Up to limit operations, newest first
+//   - int: Number of matching operations that were left out
+func recentOperations(history []state.Summary, since time.Time, limit int) ([]state.Summary, int) {
+	var matching []state.Summary
+	for i := len(history) - 1; i >= 0; i-- {
+		if !since.IsZero() && history[i].Timestamp.Before(since) {
+			continue
+		}
+		matching = append(matching, history[i])
+	}
+
+	if len(matching) <= limit {
+		return matching, 0
+	}
+	return matching[:limit], len(matching) - limit
 }
@@ -0,0 +1,32 @@
+package manager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestStatusService_RecentOperations tests selecting and describing operations for the Recent Activity table
+func TestStatusService_RecentOperations(t *testing.T) {
+	start := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	var history []state.Summary
+	for i := 0; i < 8; i++ {
+		history = append(history, state.Summary{Command: fmt.Sprintf("op-%d", i), Success: true, Timestamp: start.Add(time.Duration(i) * time.Hour)})
+	}
+
+	recent, earlier := recentOperations(history, time.Time{}, 5)
+	if len(recent) != 5 || recent[0].Command != "op-7" || recent[4].Command != "op-3" || earlier != 3 {
+		t.Errorf("Expected the 5 newest operations newest first and 3 earlier, got %v, %d", recent, earlier)
+	}
+
+	recent, earlier = recentOperations(history, start.Add(6*time.Hour), 5)
+	if len(recent) != 2 || recent[1].Command != "op-6" || earlier != 0 {
+		t.Errorf("Expected operations since the given time only, got %v, %d", recent, earlier)
+	}
+
+	if recent, _ := recentOperations(nil, time.Time{}, 5); len(recent) != 0 {
+		t.Errorf("Expected no operations for an empty history, got %v", recent)
+	}
+}
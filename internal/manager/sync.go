@@ -0,0 +1,259 @@
+// Package manager provides synchronization of installed ChatMate agents.
+package manager
+
+import (
+	"context"
+	"fmt"
+)
+
+// SyncPlan lists the changes that make the installed chatmates match the
+// available ones.
+//
+// Fields:
+//   - Install: Available chatmates that are not installed
+//   - Update: Installed chatmates whose available content changed
+//   - Modified: Installed chatmates that changed but were edited locally;
+//     they are kept so local changes are not lost
+//   - Pinned: Installed chatmates pinned to a registry release or whose
+//     available version is outside their team policy pin; they are kept
+//   - Prune: Chatmates installed from the chatmate source that are no longer
+//     available, when pruning
+//   - Orphaned: The same chatmates when not pruning; they are kept
+//   - Preserved: Installed chatmates that are not available and were not
+//     installed from the chatmate source (e.g., created by the user, imported,
+//     or installed from the registry); sync never touches them
+//   - Collisions: Available chatmates that only differ in case from an
+//     installed file; neither is installed nor removed, since both names
+//     refer to the same file on case-insensitive filesystems
+type SyncPlan struct {
+	Install    []string
+	Update     []ChatmateUpdate
+	Modified   []ChatmateUpdate
+	Pinned     []ChatmateUpdate
+	Prune      []string
+	Orphaned   []string
+	Preserved  []string
+	Collisions []CaseCollision
+}
+
+// Changes returns the number of chatmates the plan installs, updates, or
+// removes.
+func (p *SyncPlan) Changes() int {
+	return len(p.Install) + len(p.Update) + len(p.Prune)
+}
+
+// SyncResult describes what a sync changed.
+//
+// Fields:
+//   - Installed: Chatmates that were installed
+//   - Updated: Chatmates that were updated
+//   - Pruned: Orphaned chatmates that were removed
+//   - Failed: Chatmates that could not be changed, with their errors
+type SyncResult struct {
+	Installed []string
+	Updated   []string
+	Pruned    []string
+	Failed    []InstallFailure
+}
+
+// Error summarizes the failures of the sync, or returns nil if there were
+// none.
+func (r *SyncResult) Error() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d chatmate(s) could not be synced", len(r.Failed))
+}
+
+// PlanSync compares the installed chatmates with the available ones.
+//
+// Orphans (installed chatmates that are no longer available) are only
+// planned for removal when they were installed from the chatmate source, as
+// recorded in their provenance; everything else is preserved. Sync conflict
+// copies and ignored files are left out.
+//
+// Parameters:
+//   - prune: Whether to plan the removal of orphans
+//
+// Returns:
+//   - *SyncPlan: The planned changes
+//   - error: Chatmate source or prompts directory read error
+func (i *InstallerService) PlanSync(prune bool) (*SyncPlan, error) {
+	inventory, err := i.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &SyncPlan{}
+	for _, filename := range inventory.Available {
+		if variant, collides := caseVariant(filename, inventory.Installed); collides {
+			plan.Collisions = append(plan.Collisions, CaseCollision{Filename: filename, Installed: variant})
+		} else if !inventory.IsInstalled(filename) {
+			plan.Install = append(plan.Install, filename)
+		}
+	}
+
+	updates, err := i.CheckUpdates()
+	if err != nil {
+		return nil, err
+	}
+	for _, update := range updates {
+		switch update.State {
+		case UpdateAvailable:
+			plan.Update = append(plan.Update, update)
+		case UpdateModified:
+			plan.Modified = append(plan.Modified, update)
+		case UpdatePinned:
+			plan.Pinned = append(plan.Pinned, update)
+		}
+	}
+
+	provenance := i.manager.provenanceLog()
+	for _, filename := range inventory.Installed {
+		if inventory.IsAvailable(filename) || isSyncConflict(filename) {
+			continue
+		}
+		// Legacy filenames are renamed by MigrateLegacyNames
+		if _, legacy := currentName(filename, inventory); legacy {
+			continue
+		}
+		// Removing a case variant would remove the available chatmate too
+		if _, collides := caseVariant(filename, inventory.Available); collides {
+			continue
+		}
+		record, ok := provenance.Get(i.manager.PromptsDir, filename)
+		fromSource := ok && isSourceKind(record.Source)
+		switch {
+		case !fromSource:
+			plan.Preserved = append(plan.Preserved, filename)
+		case prune:
+			plan.Prune = append(plan.Prune, filename)
+		default:
+			plan.Orphaned = append(plan.Orphaned, filename)
+		}
+	}
+	return plan, nil
+}
+
+// Sync makes the installed chatmates match the available ones: missing
+// chatmates are installed, changed ones updated, and, with prune, orphans
+// installed from the chatmate source removed.
+//
+// The plan is displayed and confirmed before anything is changed (see
+// output.Confirm). Chatmates edited locally and chatmates that did not come
+// from the chatmate source are never changed, and updates to releases the
+// registry marks as breaking are confirmed on their own (see Update). A
+// chatmate that fails to sync does not stop the others; all failures are
+// reported at the end.
+//
+// Parameters:
+//   - ctx: Stops the sync before the next chatmate once done
+//   - prune: Whether to remove orphans installed from the chatmate source
+//   - dryRun: If true, only displays the plan
+//
+// Returns:
+//   - *SyncResult: What was changed and what failed; nil if the plan could
+//     not be made
+//   - error: Plan error, cancellation, or a summary of the chatmates that
+//     failed to sync
+//
+// Example:
+//
+// result, err := installer.Sync(ctx, false, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("sync failed: %w", err)
+//	}
+func (i *InstallerService) Sync(ctx context.Context, prune, dryRun bool) (*SyncResult, error) {
+	defer i.manager.bind(ctx)()
+	out := i.manager.out()
+	plan, err := i.PlanSync(prune)
+	if err != nil {
+		return nil, err
+	}
+	i.markBreaking(ctx, plan.Update)
+	i.printSyncPlan(plan)
+
+	result := &SyncResult{}
+	if plan.Changes() == 0 {
+		out.Println("✅ Installed chatmates are in sync")
+		return result, nil
+	}
+	if dryRun {
+		out.Printf("\n%d chatmate(s) would be changed\n", plan.Changes())
+		return result, nil
+	}
+	if !out.Confirm("\nSync %d chatmate(s)?", plan.Changes()) {
+		out.Println("❌ Sync cancelled by user")
+		return result, nil
+	}
+
+	out.Println()
+	for _, filename := range plan.Install {
+		if err := interrupted(ctx, "sync"); err != nil {
+			return result, err
+		}
+		if err := i.InstallChatmate(filename, false); err != nil {
+			out.Warnf("%s: %v", filename, err)
+			result.Failed = append(result.Failed, InstallFailure{Filename: filename, Err: err})
+			continue
+		}
+		result.Installed = append(result.Installed, filename)
+	}
+	for _, update := range plan.Update {
+		if err := interrupted(ctx, "sync"); err != nil {
+			return result, err
+		}
+		if !i.acceptBreaking(update.Filename, update.AvailableVersion, update.Breaking) {
+			continue
+		}
+		if err := i.InstallChatmate(update.Filename, true); err != nil {
+			out.Warnf("%s: %v", update.Filename, err)
+			result.Failed = append(result.Failed, InstallFailure{Filename: update.Filename, Err: err})
+			continue
+		}
+		result.Updated = append(result.Updated, update.Filename)
+	}
+	for _, filename := range plan.Prune {
+		if err := interrupted(ctx, "sync"); err != nil {
+			return result, err
+		}
+		if err := i.manager.Uninstaller().UninstallChatmate(filename); err != nil {
+			out.Warnf("%s: %v", filename, err)
+			result.Failed = append(result.Failed, InstallFailure{Filename: filename, Err: err})
+			continue
+		}
+		result.Pruned = append(result.Pruned, filename)
+	}
+	return result, result.Error()
+}
+
+// printSyncPlan displays the changes of a sync plan, and the chatmates it
+// leaves alone.
+func (i *InstallerService) printSyncPlan(plan *SyncPlan) {
+	out := i.manager.out()
+	for _, filename := range plan.Install {
+		out.Printf("  ➕ %s (install)\n", i.manager.getDisplayName(filename))
+	}
+	for _, update := range plan.Update {
+		out.Printf("  🔄 %s (update: %s%s)\n", i.manager.getDisplayName(update.Filename), update.Versions(), update.breakingNote())
+	}
+	for _, filename := range plan.Prune {
+		out.Printf("  ➖ %s (remove: no longer available)\n", i.manager.getDisplayName(filename))
+	}
+	for _, filename := range plan.Orphaned {
+		out.Printf("  ➖ %s (no longer available; use --prune to remove)\n", i.manager.getDisplayName(filename))
+	}
+	for _, update := range plan.Modified {
+		out.Printf("  ⚠️  %s (%s; edited locally, kept)\n", i.manager.getDisplayName(update.Filename), update.Versions())
+	}
+	for _, update := range plan.Pinned {
+		out.Printf("  📌 %s (%s; %s, kept)\n", i.manager.getDisplayName(update.Filename), update.Versions(), update.pinned())
+	}
+	for _, filename := range plan.Preserved {
+		out.Printf("  👤 %s (not from the chatmate source, kept)\n", i.manager.getDisplayName(filename))
+	}
+	for _, collision := range plan.Collisions {
+		out.Printf("  ⚠️  %s (installed as %s, which only differs in case; kept)\n", i.manager.getDisplayName(collision.Filename), collision.Installed)
+	}
+}
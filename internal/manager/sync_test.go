@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+func TestSync(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	content := []byte("---\ndescription: test\n---\nShipped\n")
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - B.chatmode.md", "Chatmate - Old.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - Old.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	// A user-created chatmate, and Old is no longer shipped; A changed upstream
+	if err := os.WriteFile(filepath.Join(promptsDir, "Mine.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create user file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(matesDir, "Chatmate - Old.chatmode.md")); err != nil {
+		t.Fatalf("Failed to remove test file: %v", err)
+	}
+	newContent := []byte("---\ndescription: test\n---\nNew\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Chatmate - A.chatmode.md"), newContent, 0644); err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+	cm.invalidateInventory()
+
+	plan, err := cm.Installer().PlanSync(false)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+	if len(plan.Install) != 1 || len(plan.Update) != 1 || len(plan.Prune) != 0 ||
+		len(plan.Orphaned) != 1 || len(plan.Preserved) != 1 || plan.Preserved[0] != "Mine.chatmode.md" {
+		t.Errorf("Unexpected plan: %+v", plan)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if result, err := cm.Installer().Sync(context.Background(), true, true); err != nil || len(result.Installed) != 0 {
+		t.Errorf("Expected a dry run to change nothing, got %+v, %v", result, err)
+	}
+
+	result, err := cm.Installer().Sync(context.Background(), true, false)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if len(result.Installed) != 1 || len(result.Updated) != 1 || len(result.Pruned) != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Chatmate - Old.chatmode.md")); !os.IsNotExist(err) {
+		t.Error("Expected the orphan to be pruned")
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "Mine.chatmode.md")); err != nil {
+		t.Errorf("Expected the user-created chatmate to be preserved, got %v", err)
+	}
+
+	plan, err = cm.Installer().PlanSync(true)
+	if err != nil || plan.Changes() != 0 {
+		t.Errorf("Expected nothing left to sync, got %+v, %v", plan, err)
+	}
+}
@@ -0,0 +1,622 @@
+// Package manager provides guided troubleshooting for common ChatMate problems.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// TroubleshooterService runs guided troubleshooting flows.
+//
+// A flow is a decision tree for one symptom. Its steps reuse the validation
+// checks and add checks of their own, and every problem found comes with a
+// fix the user can apply by hand and, where it is safe, apply automatically.
+type TroubleshooterService struct {
+	manager *ChatMateManager
+}
+
+// NewTroubleshooterService creates a new troubleshooter service.
+func NewTroubleshooterService(manager *ChatMateManager) *TroubleshooterService {
+	return &TroubleshooterService{manager: manager}
+}
+
+// Flow is a predefined troubleshooting decision tree for one symptom.
+//
+// Fields:
+//   - Name: Stable identifier of the flow (e.g., "not-showing")
+//   - Description: The symptom the flow diagnoses
+//   - Aliases: Phrases that select the flow, matched within the user's description
+type Flow struct {
+	Name        string
+	Description string
+	Aliases     []string
+	steps       []troubleshootStep
+}
+
+// troubleshootStep is a node of a flow's decision tree. The steps in next
+// depend on this one and only run if its check did not fail.
+type troubleshootStep struct {
+	check func(t *TroubleshooterService) Finding
+	next  []troubleshootStep
+}
+
+// Finding is the outcome of one troubleshooting step.
+//
+// Fields:
+//   - CheckResult: Name, status, severity, message, and affected files
+//   - Fix: What the user should do about a problem; empty when the check passed
+type Finding struct {
+	CheckResult
+	Fix string `json:"fix,omitempty"`
+
+	// apply performs the fix; nil when it has to be done by hand
+	apply func(ctx context.Context) error
+}
+
+// CanApply reports whether the fix can be applied automatically.
+func (f Finding) CanApply() bool {
+	return f.apply != nil
+}
+
+// Apply performs the fix of a finding.
+//
+// Parameters:
+//   - ctx: Interrupts fixes that install or remove several chatmates
+//
+// Returns:
+//   - error: The fix cannot be applied automatically, or applying it failed
+func (f Finding) Apply(ctx context.Context) error {
+	if f.apply == nil {
+		return fmt.Errorf("%s must be fixed by hand: %s", f.Name, f.Fix)
+	}
+	return f.apply(ctx)
+}
+
+// Diagnosis is the result of running a troubleshooting flow.
+//
+// Checks that depend on a failed check are not run, so Findings ends with
+// the first problem on each branch of the decision tree.
+type Diagnosis struct {
+	Flow     string    `json:"flow"`
+	Findings []Finding `json:"findings"`
+}
+
+// Problems returns the findings that did not pass.
+func (d *Diagnosis) Problems() []Finding {
+	var problems []Finding
+	for _, finding := range d.Findings {
+		if finding.Status != CheckPass {
+			problems = append(problems, finding)
+		}
+	}
+	return problems
+}
+
+// troubleshootFlows are the predefined troubleshooting flows.
+var troubleshootFlows = []Flow{
+	{
+		Name:        "not-showing",
+		Description: "Chatmates are not showing in Copilot Chat",
+		Aliases:     []string{"not showing", "not visible", "missing", "not appearing", "can't see", "cannot see", "dropdown"},
+		steps: []troubleshootStep{
+			{
+				check: (*TroubleshooterService).checkEditorMode,
+				next: []troubleshootStep{
+					{
+						check: (*TroubleshooterService).checkPromptsDirectory,
+						next: []troubleshootStep{
+							{
+								check: (*TroubleshooterService).checkChatmatesInstalled,
+								next: []troubleshootStep{
+									{check: (*TroubleshooterService).checkInstalledChatmates},
+									{check: (*TroubleshooterService).checkSyncConflicts},
+								},
+							},
+						},
+					},
+					{check: (*TroubleshooterService).checkPromptFilesSetting},
+					{check: (*TroubleshooterService).checkEditorFlavor},
+				},
+			},
+		},
+	},
+	{
+		Name:        "permissions",
+		Description: "Permission errors while installing or removing chatmates",
+		Aliases:     []string{"permission", "access denied", "access is denied", "read-only", "operation not permitted"},
+		steps: []troubleshootStep{
+			{
+				check: (*TroubleshooterService).checkPromptsDirectoryAccess,
+				next: []troubleshootStep{
+					{check: (*TroubleshooterService).checkChatmateFilesWritable},
+				},
+			},
+			{check: (*TroubleshooterService).checkStateDirectory},
+			{check: (*TroubleshooterService).checkSettingsWritable},
+		},
+	},
+	{
+		Name:        "wrong-editor",
+		Description: "Chatmates are installed for a different editor than the one you use",
+		Aliases:     []string{"editor", "insiders", "vscodium", "codium", "cursor", "flavor"},
+		steps: []troubleshootStep{
+			{
+				check: (*TroubleshooterService).checkEditorMode,
+				next: []troubleshootStep{
+					{check: (*TroubleshooterService).checkEditorFlavor},
+				},
+			},
+		},
+	},
+}
+
+// Flows returns the available troubleshooting flows.
+func (t *TroubleshooterService) Flows() []Flow {
+	return troubleshootFlows
+}
+
+// FindFlow selects the troubleshooting flow for a symptom.
+//
+// The symptom may be a flow name or a description in the user's own words,
+// such as "chatmates not showing"; descriptions are matched against the
+// aliases of each flow.
+//
+// Parameters:
+//   - symptom: Flow name or description of the problem
+//
+// Returns:
+//   - *Flow: The matching flow
+//   - error: No flow matches the symptom
+//
+// Example:
+//
+// flow, err := troubleshooter.FindFlow("chatmates not showing")
+//
+//	if err != nil {
+//	   return err
+//	}
+//
+// diagnosis := troubleshooter.Run(flow)
+func (t *TroubleshooterService) FindFlow(symptom string) (*Flow, error) {
+	normalized := strings.ToLower(strings.TrimSpace(symptom))
+
+	for i := range troubleshootFlows {
+		if troubleshootFlows[i].Name == normalized {
+			return &troubleshootFlows[i], nil
+		}
+	}
+	for i := range troubleshootFlows {
+		for _, alias := range troubleshootFlows[i].Aliases {
+			if strings.Contains(normalized, alias) {
+				return &troubleshootFlows[i], nil
+			}
+		}
+	}
+
+	names := make([]string, 0, len(troubleshootFlows))
+	for _, flow := range troubleshootFlows {
+		names = append(names, flow.Name)
+	}
+	return nil, fmt.Errorf("no troubleshooting flow for %q; available flows: %s", symptom, strings.Join(names, ", "))
+}
+
+// Run walks the decision tree of a flow and returns its findings.
+//
+// Run only inspects the installation; fixes are applied through
+// Finding.Apply, after which the flow can be run again.
+func (t *TroubleshooterService) Run(flow *Flow) *Diagnosis {
+	diagnosis := &Diagnosis{Flow: flow.Name}
+	t.runSteps(flow.steps, diagnosis)
+	return diagnosis
+}
+
+// runSteps runs the steps of one level of a decision tree in order,
+// descending into the dependent steps of every check that did not fail.
+func (t *TroubleshooterService) runSteps(steps []troubleshootStep, diagnosis *Diagnosis) {
+	for _, step := range steps {
+		finding := step.check(t)
+		diagnosis.Findings = append(diagnosis.Findings, finding)
+		if finding.Status != CheckFail {
+			t.runSteps(step.next, diagnosis)
+		}
+	}
+}
+
+// validatorFinding runs one validation check and turns its result into a finding.
+func (t *TroubleshooterService) validatorFinding(validator *ValidatorService, check func(v *ValidatorService, report *Report)) Finding {
+	report := &Report{PromptsDir: t.manager.PromptsDir}
+	check(validator, report)
+	return Finding{CheckResult: report.Checks[len(report.Checks)-1]}
+}
+
+// checkEditorMode checks that chatmates are installed where VS Code reads them.
+func (t *TroubleshooterService) checkEditorMode() Finding {
+	const check = "editor-mode"
+
+	if t.manager.Headless {
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+				Message: fmt.Sprintf("Headless mode: chatmates are installed into %s, which VS Code does not read", t.manager.PromptsDir)},
+			Fix: fmt.Sprintf("Copy the chatmates to the machine with VS Code ('chatmate export <dir>', then 'chatmate import <dir>' there), or set %s=0 if VS Code runs on this machine", HeadlessEnv),
+		}
+	}
+
+	editor := t.manager.TargetEditor()
+	detection := platform.DetectEditor(editor)
+	if !detection.Found {
+		fix := fmt.Sprintf("Install %s, choose the editor you use with --editor, or run ChatMate on the machine where it runs", editor.Name)
+		if detection.Headless {
+			fix = "With VS Code Remote - SSH, chatmates belong on your local machine: run 'chatmate hire' there"
+		}
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning, Message: fmt.Sprintf("%s was not detected on this machine", editor.Name)},
+			Fix:         fix,
+		}
+	}
+
+	return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+		Message: fmt.Sprintf("%s detected: %s", editor.Name, detection.Evidence)}}
+}
+
+// checkPromptsDirectory checks that the prompts directory exists and is usable.
+func (t *TroubleshooterService) checkPromptsDirectory() Finding {
+	return t.promptsDirectoryFinding(NewValidatorService(t.manager))
+}
+
+// checkPromptsDirectoryAccess checks prompts directory access by writing to it,
+// since permission queries miss some network and FUSE mounts.
+func (t *TroubleshooterService) checkPromptsDirectoryAccess() Finding {
+	validator := NewValidatorService(t.manager)
+	validator.WriteProbe = true
+	return t.promptsDirectoryFinding(validator)
+}
+
+// promptsDirectoryFinding runs the prompts-directory validation check and
+// picks the fix that matches the cause of a failure.
+func (t *TroubleshooterService) promptsDirectoryFinding(validator *ValidatorService) Finding {
+	finding := t.validatorFinding(validator, func(v *ValidatorService, report *Report) {
+		v.validatePromptsDirectory(report)
+	})
+	if finding.Status == CheckPass {
+		return finding
+	}
+
+	dir := t.manager.PromptsDir
+	switch info, err := t.manager.FS.Stat(dir); {
+	case t.manager.promptsDirErr != nil:
+		finding.Fix = fmt.Sprintf("Repair or remove the symlink at %s", t.manager.ConfiguredPromptsDir)
+	case os.IsNotExist(err):
+		finding.Fix = "Install chatmates with 'chatmate hire', which creates the directory"
+		finding.apply = t.installAvailable
+	case err == nil && !info.IsDir():
+		finding.Fix = fmt.Sprintf("Move the file at %s out of the way, then run 'chatmate hire'", dir)
+	default:
+		finding.Fix = permissionFix(dir)
+		finding.apply = func(context.Context) error { return makeWritable(dir, 0700) }
+	}
+	return finding
+}
+
+// checkChatmatesInstalled checks that any chatmates are installed.
+func (t *TroubleshooterService) checkChatmatesInstalled() Finding {
+	const check = "chatmates-installed"
+
+	inventory, err := t.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	installed := 0
+	for _, filename := range inventory.Installed {
+		if !isSyncConflict(filename) {
+			installed++
+		}
+	}
+	if installed == 0 {
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+				Message: fmt.Sprintf("No chatmates are installed in %s", t.manager.PromptsDir)},
+			Fix:   "Install them with 'chatmate hire'",
+			apply: t.installAvailable,
+		}
+	}
+
+	return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+		Message: fmt.Sprintf("%d chatmates are installed", installed)}}
+}
+
+// checkInstalledChatmates checks that installed chatmates are well-formed.
+func (t *TroubleshooterService) checkInstalledChatmates() Finding {
+	inventory, err := t.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: "installed-chatmates", Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	finding := t.validatorFinding(NewValidatorService(t.manager), func(v *ValidatorService, report *Report) {
+		v.validateInstalledChatmates(report, inventory)
+	})
+	if finding.Status != CheckPass {
+		var names []string
+		for _, filename := range finding.Files {
+			names = append(names, t.manager.getDisplayName(filename))
+		}
+		finding.Fix = "Reinstall them with 'chatmate hire --force' (this replaces local edits to these files)"
+		finding.apply = func(ctx context.Context) error {
+			_, err := NewInstallerService(t.manager).InstallSpecific(ctx, names, true)
+			return err
+		}
+	}
+	return finding
+}
+
+// checkSyncConflicts checks for cloud-sync conflict copies, which show up as
+// duplicate chatmates.
+func (t *TroubleshooterService) checkSyncConflicts() Finding {
+	finding := t.validatorFinding(NewValidatorService(t.manager), func(v *ValidatorService, report *Report) {
+		v.validateSyncConflicts(report)
+	})
+	if finding.Status == CheckWarn {
+		conflicts := finding.Files
+		finding.Fix = "Compare the copies with the originals, then remove them with 'chatmate validate --clean-sync-conflicts'"
+		finding.apply = func(ctx context.Context) error {
+			_, err := NewUninstallerService(t.manager).RemoveSyncConflicts(ctx, conflicts)
+			return err
+		}
+	}
+	return finding
+}
+
+// checkPromptFilesSetting checks that prompt files are enabled in the VS Code settings.
+func (t *TroubleshooterService) checkPromptFilesSetting() Finding {
+	const check = "prompt-files-setting"
+
+	settingsPath := t.manager.SettingsPath()
+	enabled, set, err := platform.PromptFilesEnabled(settingsPath)
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning, Message: err.Error()}}
+	}
+
+	apply := func(context.Context) error {
+		_, err := platform.EnablePromptFiles(settingsPath)
+		return err
+	}
+	switch {
+	case enabled:
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: fmt.Sprintf("%s is enabled", platform.PromptFilesSetting)}}
+	case set:
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+				Message: fmt.Sprintf("%s is disabled in %s", platform.PromptFilesSetting, settingsPath)},
+			Fix:   fmt.Sprintf("Set \"%s\": true in the VS Code settings", platform.PromptFilesSetting),
+			apply: apply,
+		}
+	default:
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+				Message: fmt.Sprintf("%s is not set in %s; VS Code versions that do not enable it by default ignore chatmates", platform.PromptFilesSetting, settingsPath)},
+			Fix:   fmt.Sprintf("Set \"%s\": true in the VS Code settings", platform.PromptFilesSetting),
+			apply: apply,
+		}
+	}
+}
+
+// checkEditorFlavor checks for VS Code builds and forks that read chatmates
+// from their own prompts directory, where ChatMate does not install.
+func (t *TroubleshooterService) checkEditorFlavor() Finding {
+	const check = "editor-flavor"
+
+	flavors, err := platform.GetEditorFlavors()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+			Message: fmt.Sprintf("failed to locate editor directories: %v", err)}}
+	}
+
+	// An editor has been used if its user directory exists; it is only a
+	// problem when it has no chatmates of its own
+	target := t.manager.TargetEditor()
+	var missing []platform.EditorFlavor
+	for _, flavor := range flavors {
+		if flavor.ID == target.ID {
+			continue
+		}
+		if _, err := os.Stat(filepath.Dir(flavor.PromptsDir)); err != nil {
+			continue
+		}
+		if chatmates, err := scanChatmateDir(files.OS{}, flavor.PromptsDir); err == nil && len(chatmates) > 0 {
+			continue
+		}
+		missing = append(missing, flavor)
+	}
+	if len(missing) == 0 {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: fmt.Sprintf("No VS Code builds or forks other than %s without chatmates found", target.Name)}}
+	}
+
+	var names, dirs, commands []string
+	for _, flavor := range missing {
+		names = append(names, flavor.Name)
+		dirs = append(dirs, flavor.PromptsDir)
+		commands = append(commands, fmt.Sprintf("chatmate export %q", flavor.PromptsDir))
+	}
+	finding := Finding{
+		CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+			Message: fmt.Sprintf("%s read chatmates from their own prompts directory, but ChatMate installs for %s (see --editor)", strings.Join(names, ", "), target.Name),
+			Files:   dirs},
+		Fix: "Copy the chatmates there with: " + strings.Join(commands, "; "),
+		apply: func(ctx context.Context) error {
+			for _, dir := range dirs {
+				if _, err := NewInstallerService(t.manager).Export(ctx, dir, nil, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	// Only a problem for sure when VS Code itself has never been started
+	if _, err := os.Stat(filepath.Dir(flavors[0].PromptsDir)); os.IsNotExist(err) {
+		finding.Status = CheckFail
+		finding.Severity = SeverityError
+	}
+	return finding
+}
+
+// checkChatmateFilesWritable checks that installed chatmates can be updated
+// and removed.
+func (t *TroubleshooterService) checkChatmateFilesWritable() Finding {
+	const check = "chatmate-files"
+
+	inventory, err := t.manager.Inventory()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+			Message: fmt.Sprintf("failed to list installed chatmates: %v", err)}}
+	}
+
+	var readOnly []string
+	for _, filename := range inventory.Installed {
+		info, err := t.manager.FS.Stat(filepath.Join(t.manager.PromptsDir, filename))
+		if err == nil && info.Mode().Perm()&0200 == 0 {
+			readOnly = append(readOnly, filename)
+		}
+	}
+	if len(readOnly) > 0 {
+		fix := "Make them writable with: chmod u+w " + quotedPaths(t.manager.PromptsDir, readOnly)
+		if runtime.GOOS == "windows" {
+			fix = "Clear the Read-only attribute in the file properties"
+		}
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+				Message: fmt.Sprintf("%d installed chatmates are read-only", len(readOnly)), Files: readOnly},
+			Fix: fix,
+			apply: func(context.Context) error {
+				for _, filename := range readOnly {
+					if err := makeWritable(filepath.Join(t.manager.PromptsDir, filename), 0200); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	}
+
+	return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+		Message: fmt.Sprintf("All %d installed chatmates are writable", len(inventory.Installed))}}
+}
+
+// checkStateDirectory checks that ChatMate can record its state, such as
+// installation checkpoints and adopted chatmates.
+func (t *TroubleshooterService) checkStateDirectory() Finding {
+	const check = "state-directory"
+
+	dir, err := platform.GetChatMateStateDir()
+	if err != nil {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+			Message: fmt.Sprintf("failed to locate the state directory: %v", err)}}
+	}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: fmt.Sprintf("State directory %s will be created when needed", dir)}}
+	}
+	if err := platform.CheckDirWritable(dir); err != nil {
+		return Finding{
+			CheckResult: CheckResult{Name: check, Status: CheckFail, Severity: SeverityError,
+				Message: fmt.Sprintf("State directory is not writable: %v", err)},
+			Fix:   permissionFix(dir),
+			apply: func(context.Context) error { return makeWritable(dir, 0700) },
+		}
+	}
+
+	return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+		Message: fmt.Sprintf("State directory %s is writable", dir)}}
+}
+
+// checkSettingsWritable checks that the VS Code settings can be updated, as
+// 'chatmate quickstart' does.
+func (t *TroubleshooterService) checkSettingsWritable() Finding {
+	const check = "settings-file"
+
+	settingsPath := t.manager.SettingsPath()
+	if settingsPath == "" {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: "Headless mode does not use the VS Code settings"}}
+	}
+
+	info, err := os.Stat(settingsPath)
+	if err != nil || info.Mode().Perm()&0200 != 0 {
+		return Finding{CheckResult: CheckResult{Name: check, Status: CheckPass, Severity: SeverityInfo,
+			Message: "VS Code settings are writable"}}
+	}
+
+	fix := fmt.Sprintf("Make the file writable with: chmod u+w %q", settingsPath)
+	if runtime.GOOS == "windows" {
+		fix = "Clear the Read-only attribute in the file properties"
+	}
+	return Finding{
+		CheckResult: CheckResult{Name: check, Status: CheckWarn, Severity: SeverityWarning,
+			Message: fmt.Sprintf("VS Code settings %s are read-only", settingsPath)},
+		Fix:   fix,
+		apply: func(context.Context) error { return makeWritable(settingsPath, 0200) },
+	}
+}
+
+// installAvailable installs every available chatmate that is not installed
+// yet. The user already confirmed the fix, so unlike 'chatmate hire' it does
+// not ask again.
+func (t *TroubleshooterService) installAvailable(ctx context.Context) error {
+	defer t.manager.bind(ctx)()
+	inventory, err := t.manager.Inventory()
+	if err != nil {
+		return err
+	}
+
+	installer := NewInstallerService(t.manager)
+	for _, filename := range inventory.Available {
+		if err := interrupted(ctx, "installation"); err != nil {
+			return err
+		}
+		if err := installer.InstallChatmate(filename, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// permissionFix describes how to regain write access to a directory.
+func permissionFix(dir string) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("Give your account Modify permission on %s (Properties > Security)", dir)
+	}
+	return fmt.Sprintf("Make it writable with: chmod u+rwx %q; if another user owns it (for example after running ChatMate with sudo), take it back with: sudo chown -R \"$(whoami)\" %q", dir, dir)
+}
+
+// makeWritable adds the given owner permission bits to a file or directory.
+func makeWritable(path string, bits os.FileMode) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, info.Mode().Perm()|bits); err != nil {
+		return fmt.Errorf("failed to change permissions of %s: %w", path, err)
+	}
+	return nil
+}
+
+// quotedPaths joins filenames in dir as quoted shell arguments.
+func quotedPaths(dir string, filenames []string) string {
+	quoted := make([]string, 0, len(filenames))
+	for _, filename := range filenames {
+		quoted = append(quoted, fmt.Sprintf("%q", filepath.Join(dir, filename)))
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestTroubleshooterService tests the guided troubleshooting flows
+func TestTroubleshooterService(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("APPDATA", filepath.Join(home, "AppData"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+
+	troubleshooter := NewTroubleshooterService(&ChatMateManager{})
+	for symptom, want := range map[string]string{
+		"chatmates not showing":        "not-showing",
+		"Permission denied on install": "permissions",
+		"I use VS Code Insiders":       "wrong-editor",
+		"wrong-editor":                 "wrong-editor",
+	} {
+		flow, err := troubleshooter.FindFlow(symptom)
+		if err != nil || flow.Name != want {
+			t.Errorf("FindFlow(%q) = %v, %v; want %s", symptom, flow, err, want)
+		}
+	}
+	if _, err := troubleshooter.FindFlow("it is slow"); err == nil {
+		t.Error("Expected error for a symptom without a flow")
+	}
+
+	t.Run("headless stops the not-showing flow", func(t *testing.T) {
+		cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), Headless: true}
+		troubleshooter := NewTroubleshooterService(cm)
+		flow, _ := troubleshooter.FindFlow("not-showing")
+
+		diagnosis := troubleshooter.Run(flow)
+		if len(diagnosis.Findings) != 1 || diagnosis.Findings[0].Name != "editor-mode" || diagnosis.Findings[0].Status != CheckFail {
+			t.Errorf("Expected only the failed editor-mode check, got %+v", diagnosis.Findings)
+		}
+	})
+
+	t.Run("missing chatmates are installed by the fix", func(t *testing.T) {
+		matesDir := t.TempDir()
+		promptsDir := filepath.Join(t.TempDir(), "User", "prompts")
+		if err := os.MkdirAll(promptsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(matesDir, "Test Agent.chatmode.md"), []byte("---\ndescription: 'Test'\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+		troubleshooter := NewTroubleshooterService(cm)
+		flow, _ := troubleshooter.FindFlow("not-showing")
+
+		diagnosis := troubleshooter.Run(flow)
+		var missing *Finding
+		for i, finding := range diagnosis.Findings {
+			if finding.Name == "chatmates-installed" {
+				missing = &diagnosis.Findings[i]
+			}
+		}
+		if missing == nil || missing.Status != CheckFail || !missing.CanApply() {
+			t.Fatalf("Expected an applicable chatmates-installed failure, got %+v", diagnosis.Findings)
+		}
+		if err := missing.Apply(context.Background()); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+
+		for _, finding := range troubleshooter.Run(flow).Findings {
+			if finding.Name == "chatmates-installed" && finding.Status != CheckPass {
+				t.Errorf("Expected chatmates to be installed after the fix, got %+v", finding)
+			}
+		}
+	})
+
+	t.Run("read-only chatmates are made writable by the fix", func(t *testing.T) {
+		promptsDir := t.TempDir()
+		chatmatePath := filepath.Join(promptsDir, "Test Agent.chatmode.md")
+		if err := os.WriteFile(chatmatePath, []byte("---\n---\n"), 0444); err != nil {
+			t.Fatal(err)
+		}
+		cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: promptsDir, Headless: true}
+		troubleshooter := NewTroubleshooterService(cm)
+		flow, _ := troubleshooter.FindFlow("permissions")
+
+		problems := troubleshooter.Run(flow).Problems()
+		if len(problems) != 1 || problems[0].Name != "chatmate-files" {
+			t.Fatalf("Expected only the chatmate-files problem, got %+v", problems)
+		}
+		if err := problems[0].Apply(context.Background()); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+		if problems := troubleshooter.Run(flow).Problems(); len(problems) != 0 {
+			t.Errorf("Expected no problems after the fix, got %+v", problems)
+		}
+	})
+}
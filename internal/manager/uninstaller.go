@@ -2,6 +2,7 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -23,42 +24,38 @@ func NewUninstallerService(manager *ChatMateManager) *UninstallerService {
 //
 // This method removes all chatmate files from the VS Code user prompts directory.
 // It performs security validation and provides detailed feedback about the operation.
+// Unless AutoBackup is disabled, the prompts directory is archived before
+// anything is removed (see CreateBackup).
+//
+// Parameters:
+//   - ctx: Stops the uninstallation before the next chatmate once done
 //
 // Returns:
-//   - error: Uninstallation failure or system error
+//   - error: Uninstallation failure, cancellation, or system error
 //
 // Example:
 //
-// err := uninstaller.UninstallAll()
+// err := uninstaller.UninstallAll(ctx)
 //
 //	if err != nil {
 //	   return fmt.Errorf("uninstallation failed: %w", err)
 //	}
-func (u *UninstallerService) UninstallAll() error {
-	// Get available chatmodes from repository (only these should be uninstalled)
-	availableChatmates, err := u.manager.GetAvailableChatmates()
-	if err != nil {
-		return err
-	}
+func (u *UninstallerService) UninstallAll(ctx context.Context) error {
+	defer u.manager.bind(ctx)()
+	out := u.manager.out()
 
-	// Get installed chatmodes
-	installedChatmates, err := u.manager.GetInstalledChatmates()
+	// Only chatmodes available in the repository should be uninstalled
+	inventory, err := u.manager.Inventory()
 	if err != nil {
 		return err
 	}
 
-	// Create a set of available chatmodes for quick lookup
-	availableSet := make(map[string]bool)
-	for _, filename := range availableChatmates {
-		availableSet[filename] = true
-	}
-
 	// Filter installed chatmodes to only include those available in repository
 	var toUninstall []string
 	var userCreated []string
 
-	for _, filename := range installedChatmates {
-		if availableSet[filename] {
+	for _, filename := range inventory.Installed {
+		if inventory.IsAvailable(filename) {
 			toUninstall = append(toUninstall, filename)
 		} else {
 			userCreated = append(userCreated, filename)
@@ -66,55 +63,56 @@ func (u *UninstallerService) UninstallAll() error {
 	}
 
 	if len(toUninstall) == 0 {
-		fmt.Println("No repository chatmates are currently installed")
+		out.Println("No repository chatmates are currently installed")
 		if len(userCreated) > 0 {
-			fmt.Printf("📝 Found %d user-created chatmate(s) (will be preserved):\n", len(userCreated))
+			out.Printf("📝 Found %d user-created chatmate(s) (will be preserved):\n", len(userCreated))
 			for _, filename := range userCreated {
 				displayName := u.manager.getDisplayName(filename)
-				fmt.Printf("  - %s\n", displayName)
+				out.Printf("  - %s\n", displayName)
 			}
 		}
 		return nil
 	}
 
 	// Safety confirmation - show what will be uninstalled and preserved
-	fmt.Printf("🚨 UNINSTALL CONFIRMATION\n")
-	fmt.Printf("Repository chatmates to be UNINSTALLED (%d):\n", len(toUninstall))
+	out.Printf("🚨 UNINSTALL CONFIRMATION\n")
+	out.Printf("Repository chatmates to be UNINSTALLED (%d):\n", len(toUninstall))
 	for _, filename := range toUninstall {
 		displayName := u.manager.getDisplayName(filename)
-		fmt.Printf("  ❌ %s\n", displayName)
+		out.Printf("  ❌ %s\n", displayName)
 	}
 
 	if len(userCreated) > 0 {
-		fmt.Printf("\nUser-created chatmates to be PRESERVED (%d):\n", len(userCreated))
+		out.Printf("\nUser-created chatmates to be PRESERVED (%d):\n", len(userCreated))
 		for _, filename := range userCreated {
 			displayName := u.manager.getDisplayName(filename)
-			fmt.Printf("  📝 %s\n", displayName)
+			out.Printf("  📝 %s\n", displayName)
 		}
 	}
 
-	fmt.Printf("\nDirectory: %s\n", u.manager.PromptsDir)
-	fmt.Print("\nDo you want to proceed with uninstalling these repository chatmates? (y/N): ")
-
-	var response string
-	fmt.Scanln(&response)
-
-	if response != "y" && response != "Y" && response != "yes" && response != "YES" {
-		fmt.Println("❌ Uninstall operation cancelled by user")
+	out.Printf("\nDirectory: %s\n", u.manager.PromptsDir)
+	if !out.Confirm("\nDo you want to proceed with uninstalling these repository chatmates?") {
+		out.Println("❌ Uninstall operation cancelled by user")
 		return nil
 	}
 
-	fmt.Printf("\nProceeding with uninstallation...\n")
+	out.Printf("\nProceeding with uninstallation...\n")
+	if _, err := u.manager.backupBefore(BackupReasonUninstallAll); err != nil {
+		return err
+	}
 
 	for _, chatmate := range toUninstall {
+		if err := interrupted(ctx, "uninstallation"); err != nil {
+			return err
+		}
 		if err := u.UninstallChatmate(chatmate); err != nil {
 			return err
 		}
 	}
 
-	fmt.Printf("\n✅ Successfully uninstalled %d repository chatmates\n", len(toUninstall))
+	out.Printf("\n✅ %s %d repository chatmates\n", u.manager.outcome("Successfully uninstalled", "Would uninstall"), len(toUninstall))
 	if len(userCreated) > 0 {
-		fmt.Printf("📝 Preserved %d user-created chatmate(s)\n", len(userCreated))
+		out.Printf("📝 Preserved %d user-created chatmate(s)\n", len(userCreated))
 	}
 	return nil
 }
@@ -122,51 +120,50 @@ func (u *UninstallerService) UninstallAll() error {
 // UninstallSpecific removes specific chatmate agents by name.
 //
 // This method takes a list of agent names and attempts to uninstall each one.
-// Agent names should match the display names (e.g., "Solve Issue") rather than
-// filenames. The method automatically converts names to appropriate filenames.
+// Names are resolved like everywhere else (see findChatmate): a display name
+// in any case (e.g., "solve issue") or a filename, with or without the
+// extension.
 //
 // Parameters:
-//   - agentNames: List of chatmate display names to uninstall
+//   - ctx: Stops the uninstallation before the next chatmate once done
+//   - agentNames: List of chatmate names to uninstall
 //
 // Returns:
-//   - error: Uninstallation failure or agent not found error
+//   - error: Uninstallation failure, cancellation, or agent not found error
 //
 // Example:
 //
 // names := []string{"Solve Issue", "Code Review", "Testing"}
-// err := uninstaller.UninstallSpecific(names)
+// err := uninstaller.UninstallSpecific(ctx, names)
 //
 //	if err != nil {
 //	   return fmt.Errorf("specific uninstallation failed: %w", err)
 //	}
-func (u *UninstallerService) UninstallSpecific(agentNames []string) error {
+func (u *UninstallerService) UninstallSpecific(ctx context.Context, agentNames []string) error {
+	defer u.manager.bind(ctx)()
 	if len(agentNames) == 0 {
-		fmt.Println("No specific chatmates specified for uninstallation")
+		u.manager.out().Println("No specific chatmates specified for uninstallation")
 		return nil
 	}
 
-	installedChatmates, err := u.manager.GetInstalledChatmates()
+	inventory, err := u.manager.Inventory()
 	if err != nil {
 		return err
 	}
 
-	// Create a map for quick lookup of installed chatmates
-	installedMap := make(map[string]string)
-	for _, filename := range installedChatmates {
-		displayName := u.manager.getDisplayName(filename)
-		installedMap[displayName] = filename
-	}
-
-	fmt.Printf("Uninstalling specific chatmates: %v\n", agentNames)
+	u.manager.out().Printf("Uninstalling specific chatmates: %v\n", agentNames)
 
 	// Uninstall each specified agent
 	for _, agentName := range agentNames {
-		if filename, exists := installedMap[agentName]; exists {
+		if err := interrupted(ctx, "uninstallation"); err != nil {
+			return err
+		}
+		if filename, exists := u.manager.findChatmate(agentName, inventory.Installed); exists {
 			if err := u.UninstallChatmate(filename); err != nil {
 				return err
 			}
 		} else {
-			return fmt.Errorf("chatmate not found or not installed: %s", agentName)
+			return errorf(ErrChatmateNotFound, "chatmate not found or not installed: %s", agentName)
 		}
 	}
 
@@ -179,7 +176,7 @@ func (u *UninstallerService) UninstallSpecific(agentNames []string) error {
 // security validation and error handling.
 //
 // Parameters:
-//   - filename: The chatmate filename (e.g., "Chatmate - Solve Issue.chatmode.md")
+//   - filename: The chatmate filename (e.g., "Solve Issue.chatmode.md")
 //
 // Returns:
 //   - error: Security validation or file operation error
@@ -191,7 +188,7 @@ func (u *UninstallerService) UninstallSpecific(agentNames []string) error {
 func (u *UninstallerService) UninstallChatmate(filename string) error {
 	// Security validation
 	if err := security.ValidateChatmateFilename(filename); err != nil {
-		return fmt.Errorf("security validation failed: %w", err)
+		return errorf(ErrValidationFailed, "security validation failed: %w", err)
 	}
 
 	// Validate path safety
@@ -205,17 +202,19 @@ func (u *UninstallerService) UninstallChatmate(filename string) error {
 	destPath := filepath.Join(u.manager.PromptsDir, filename)
 
 	// Check if file exists
-	if _, err := os.Stat(destPath); os.IsNotExist(err) {
-		fmt.Printf("⏭️  %s (not installed)\n", filename)
+	if _, err := u.manager.FS.Stat(destPath); os.IsNotExist(err) {
+		u.manager.out().Printf("⏭️  %s (not installed)\n", filename)
 		return nil
 	}
 
 	// Remove the file
-	if err := os.Remove(destPath); err != nil {
-		return fmt.Errorf("failed to remove chatmate file %s: %w", destPath, err)
+	if removed, err := u.manager.removeChatmate(filename); !removed {
+		return err
 	}
+	u.manager.invalidateInventory()
+	u.manager.forgetProvenance(filename)
 
-	fmt.Printf("❌ %s (uninstalled)\n", filename)
+	u.manager.out().Printf("❌ %s (%s)\n", filename, u.manager.outcome("uninstalled", "would be uninstalled"))
 	return nil
 }
 
@@ -223,7 +222,11 @@ func (u *UninstallerService) UninstallChatmate(filename string) error {
 //
 // This method identifies installed chatmate files that don't exist in the
 // available chatmates list and removes them. This is useful for cleaning up
-// after chatmate updates or configuration changes.
+// after chatmate updates or configuration changes. Chatmates installed from
+// the registry, imported, piped in, or adopted are kept.
+//
+// Parameters:
+//   - ctx: Stops the cleanup before the next file once done
 //
 // Returns:
 //   - error: Cleanup operation failure
@@ -231,52 +234,39 @@ func (u *UninstallerService) UninstallChatmate(filename string) error {
 //
 // Example:
 //
-// removed, err := uninstaller.CleanupOrphanedFiles()
+// removed, err := uninstaller.CleanupOrphanedFiles(ctx)
 //
 //	if err != nil {
 //	   return fmt.Errorf("cleanup failed: %w", err)
 //	}
 //
-// fmt.Printf("Removed %d orphaned files", removed)
-func (u *UninstallerService) CleanupOrphanedFiles() (int, error) {
-	installedChatmates, err := u.manager.GetInstalledChatmates()
+// output.Printf("Removed %d orphaned files", removed)
+func (u *UninstallerService) CleanupOrphanedFiles(ctx context.Context) (int, error) {
+	defer u.manager.bind(ctx)()
+	inventory, err := u.manager.Inventory()
 	if err != nil {
 		return 0, err
 	}
 
-	availableChatmates, err := u.manager.GetAvailableChatmates()
-	if err != nil {
-		return 0, err
-	}
-
-	// Create a set of available chatmates for quick lookup
-	availableSet := make(map[string]bool)
-	for _, filename := range availableChatmates {
-		availableSet[filename] = true
-	}
-
-	// Find orphaned files
-	var orphaned []string
-	for _, installed := range installedChatmates {
-		if !availableSet[installed] {
-			orphaned = append(orphaned, installed)
-		}
-	}
+	orphaned := u.manager.orphanedFiles(inventory)
 
 	if len(orphaned) == 0 {
-		fmt.Println("No orphaned chatmate files found")
+		u.manager.out().Println("No orphaned chatmate files found")
 		return 0, nil
 	}
 
-	fmt.Printf("Found %d orphaned chatmate files\n", len(orphaned))
+	u.manager.out().Printf("Found %d orphaned chatmate files\n", len(orphaned))
 
 	// Remove orphaned files
-	for _, filename := range orphaned {
+	for removed, filename := range orphaned {
+		if err := interrupted(ctx, "cleanup"); err != nil {
+			return removed, err
+		}
 		if err := u.UninstallChatmate(filename); err != nil {
-			return len(orphaned), err
+			return removed, err
 		}
 	}
 
-	fmt.Printf("✅ Cleaned up %d orphaned chatmate files\n", len(orphaned))
+	u.manager.out().Printf("✅ %s %d orphaned chatmate files\n", u.manager.outcome("Cleaned up", "Would clean up"), len(orphaned))
 	return len(orphaned), nil
 }
@@ -0,0 +1,105 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestOrphanedFiles tests that only chatmates installed from the mates, or
+// without provenance, become orphans when they are no longer available
+func TestOrphanedFiles(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	importDir := t.TempDir()
+
+	content := []byte("---\ndescription: test\n---\n")
+	for _, name := range []string{"Shipped.chatmode.md", "Dropped.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), content, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(importDir, "Imported.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create import file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	cm.uninstaller = NewUninstallerService(cm)
+
+	for _, name := range []string{"Shipped.chatmode.md", "Dropped.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	if _, err := cm.Installer().Import(context.Background(), importDir, nil, false); err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if _, err := cm.Installer().InstallFromReader("Piped", strings.NewReader(string(content)), false); err != nil {
+		t.Fatalf("InstallFromReader failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Copied.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create untracked file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(matesDir, "Dropped.chatmode.md")); err != nil {
+		t.Fatalf("Failed to drop chatmate: %v", err)
+	}
+	cm.invalidateInventory()
+
+	removed, err := cm.Uninstaller().CleanupOrphanedFiles(context.Background())
+	if err != nil {
+		t.Fatalf("CleanupOrphanedFiles failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 orphans removed, got %d", removed)
+	}
+	for name, kept := range map[string]bool{"Shipped.chatmode.md": true, "Imported.chatmode.md": true,
+		"Piped.chatmode.md": true, "Dropped.chatmode.md": false, "Copied.chatmode.md": false} {
+		if _, err := os.Stat(filepath.Join(promptsDir, name)); (err == nil) != kept {
+			t.Errorf("Expected %s kept=%v, got err %v", name, kept, err)
+		}
+	}
+}
+
+// TestUninstallAllDryRun tests that a dry run reports what it would
+// uninstall instead of claiming to have uninstalled it
+func TestUninstallAllDryRun(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	name := "Solve Issue.chatmode.md"
+	for _, dir := range []string{matesDir, promptsDir} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, Output: &stdout, ErrOutput: io.Discard}
+	cm.uninstaller = NewUninstallerService(cm)
+	cm.DryRun()
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if err := cm.Uninstaller().UninstallAll(context.Background()); err != nil {
+		t.Fatalf("UninstallAll failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, name)); err != nil {
+		t.Errorf("Dry run must not remove files: %v", err)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, name+" (would be uninstalled)") || !strings.Contains(out, "Would uninstall 1 repository chatmates") {
+		t.Errorf("Expected the dry run to report what it would uninstall, got:\n%s", out)
+	}
+	if strings.Contains(out, "Successfully uninstalled") || strings.Contains(out, "(uninstalled)") {
+		t.Errorf("Dry run must not report chatmates as uninstalled, got:\n%s", out)
+	}
+}
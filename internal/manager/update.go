@@ -0,0 +1,349 @@
+// Package manager provides targeted updates of installed ChatMate agents.
+package manager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// States of an installed chatmate compared with the shipped version.
+const (
+	// UpdateCurrent marks chatmates whose content matches the shipped version
+	UpdateCurrent = "current"
+	// UpdateAvailable marks chatmates the shipped version replaces
+	UpdateAvailable = "update"
+	// UpdateModified marks chatmates edited since they were installed; they
+	// are only replaced with force, so local edits are not lost
+	UpdateModified = "modified"
+	// UpdatePinned marks chatmates installed from a registry release (see
+	// InstallPinned), a git repository, or a URL, and chatmates whose
+	// shipped version is outside the range the team policy pins them to;
+	// they are only replaced with force, so the pin is kept
+	UpdatePinned = "pinned"
+)
+
+// ChatmateUpdate describes how an installed chatmate compares with the
+// version shipped with ChatMate.
+//
+// Fields:
+//   - Filename: The chatmate filename
+//   - State: UpdateCurrent, UpdateAvailable, UpdateModified, or UpdatePinned
+//   - InstalledVersion: The frontmatter version of the installed chatmate
+//   - AvailableVersion: The frontmatter version of the shipped chatmate
+//   - InstalledChecksum: SHA-256 of the installed content, hex encoded
+//   - AvailableChecksum: SHA-256 of the shipped content, hex encoded; the
+//     chatmate is up to date when both checksums are equal
+//   - Pin: The version range the team policy pins the chatmate to, if any
+//   - Breaking: The releases marked as breaking in the registry that the
+//     update installs; only known to Update and Sync
+type ChatmateUpdate struct {
+	Filename          string             `json:"filename"`
+	State             string             `json:"state"`
+	InstalledVersion  string             `json:"installedVersion,omitempty"`
+	AvailableVersion  string             `json:"availableVersion,omitempty"`
+	InstalledChecksum string             `json:"installedChecksum"`
+	AvailableChecksum string             `json:"availableChecksum"`
+	Pin               string             `json:"pin,omitempty"`
+	Breaking          []registry.Release `json:"breaking,omitempty"`
+}
+
+// Versions describes the version change, e.g. "1.0.0 → 1.1.0", or
+// "content changed" when the versions do not tell the chatmates apart.
+func (u ChatmateUpdate) Versions() string {
+	if u.InstalledVersion != u.AvailableVersion && u.AvailableVersion != "" {
+		installed := u.InstalledVersion
+		if installed == "" {
+			installed = "unversioned"
+		}
+		return installed + " → " + u.AvailableVersion
+	}
+	if u.State == UpdateCurrent {
+		if u.InstalledVersion != "" {
+			return u.InstalledVersion
+		}
+		return "up to date"
+	}
+	return "content changed"
+}
+
+// breakingNote marks updates that install breaking changes in plans.
+func (u ChatmateUpdate) breakingNote() string {
+	if len(u.Breaking) == 0 {
+		return ""
+	}
+	return "; breaking changes"
+}
+
+// pinned describes why the update is held back, e.g. "pinned to ^1.2".
+func (u ChatmateUpdate) pinned() string {
+	if u.Pin != "" {
+		return "pinned to " + u.Pin
+	}
+	return "pinned"
+}
+
+// OutdatedReport lists the installed chatmates whose shipped version
+// differs from the installed one.
+//
+// Fields:
+//   - PromptsDir: The prompts directory that was checked
+//   - Chatmates: The outdated chatmates, sorted by filename; their State
+//     tells whether 'chatmate update' replaces them (UpdateAvailable) or
+//     keeps them (UpdateModified, UpdatePinned)
+//   - Current: The installed chatmates that are up to date, sorted by
+//     filename
+type OutdatedReport struct {
+	PromptsDir string           `json:"promptsDir"`
+	Chatmates  []ChatmateUpdate `json:"chatmates"`
+	Current    []ChatmateUpdate `json:"current"`
+}
+
+// Updates returns the number of outdated chatmates 'chatmate update'
+// replaces; the others are kept because they were edited locally or are
+// pinned.
+func (r *OutdatedReport) Updates() int {
+	updates := 0
+	for _, update := range r.Chatmates {
+		if update.State == UpdateAvailable {
+			updates++
+		}
+	}
+	return updates
+}
+
+// Outdated returns the installed chatmates that are not up to date, without
+// changing anything.
+//
+// Returns:
+//   - *OutdatedReport: The outdated chatmates
+//   - error: Chatmate source, prompts directory, or team policy read error
+func (i *InstallerService) Outdated() (*OutdatedReport, error) {
+	updates, err := i.CheckUpdates()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OutdatedReport{PromptsDir: i.manager.PromptsDir, Chatmates: []ChatmateUpdate{}, Current: []ChatmateUpdate{}}
+	for _, update := range updates {
+		if update.State == UpdateCurrent {
+			report.Current = append(report.Current, update)
+		} else {
+			report.Chatmates = append(report.Chatmates, update)
+		}
+	}
+	return report, nil
+}
+
+// CheckUpdates compares the installed chatmates with the versions shipped
+// with ChatMate by the SHA-256 of their content.
+//
+// Only installed chatmates that ChatMate ships are compared; user-created
+// chatmates are left out. An installed chatmate whose content differs from
+// the content recorded in its provenance was edited locally and is reported
+// as UpdateModified, and one installed from a registry release as
+// UpdatePinned. So is a chatmate whose shipped version is outside the range
+// the team policy pins it to (see policy.Constraint). Chatmates installed before provenance was recorded cannot be
+// checked for local edits and are treated as unmodified.
+//
+// Returns:
+//   - []ChatmateUpdate: The installed shipped chatmates, sorted by filename
+//   - error: Chatmate source, prompts directory, or team policy read error
+//
+// Example:
+//
+// updates, err := installer.CheckUpdates()
+//
+//	if err != nil {
+//	   return fmt.Errorf("update check failed: %w", err)
+//	}
+func (i *InstallerService) CheckUpdates() ([]ChatmateUpdate, error) {
+	inventory, err := i.manager.Inventory()
+	if err != nil {
+		return nil, err
+	}
+	provenance := i.manager.provenanceLog()
+	teamPolicy, err := i.manager.Policy()
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []ChatmateUpdate
+	for _, filename := range inventory.Installed {
+		if !inventory.IsAvailable(filename) {
+			continue
+		}
+
+		shipped, err := i.manager.GetChatmateContent(filename)
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(i.manager.PromptsDir, filename)
+		current, err := i.manager.FS.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+		}
+
+		update := ChatmateUpdate{
+			Filename:          filename,
+			State:             UpdateCurrent,
+			InstalledVersion:  frontmatterVersion(current),
+			AvailableVersion:  frontmatterVersion(shipped),
+			InstalledChecksum: checksum(current),
+			AvailableChecksum: checksum(shipped),
+		}
+		if update.InstalledChecksum != update.AvailableChecksum {
+			update.State = UpdateAvailable
+			if record, ok := provenance.Get(i.manager.PromptsDir, filename); ok {
+				switch {
+				case record.Source == state.SourceRegistry || record.Source == state.SourceRepository || record.Source == state.SourceURL:
+					update.State = UpdatePinned
+				case record.Checksum != update.InstalledChecksum:
+					update.State = UpdateModified
+				}
+			}
+		}
+		if pin, ok := teamPolicy.Pin([]string{filename, DisplayName(filename)}); ok {
+			update.Pin = pin.String()
+			if update.State == UpdateAvailable && !pin.Allows(update.AvailableVersion) {
+				update.State = UpdatePinned
+			}
+		}
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// frontmatterVersion returns the version in the frontmatter of content, or
+// an empty string if there is none.
+func frontmatterVersion(content []byte) string {
+	meta, err := files.ParseFrontmatter(content)
+	if err != nil {
+		return ""
+	}
+	return meta.Version
+}
+
+// Update reinstalls the installed chatmates whose shipped version changed.
+//
+// Unlike 'chatmate hire --force', only chatmates with changed content are
+// rewritten, and chatmates edited locally or pinned to a registry release
+// are kept unless force is set. The plan is displayed and confirmed before
+// anything is written (see output.Confirm). An update to a release the
+// registry marks as breaking shows its changelog and is confirmed on its own,
+// unless AcceptBreaking is set. Before force replaces edited or pinned
+// chatmates, the prompts directory is archived unless AutoBackup is disabled.
+//
+// Parameters:
+//   - ctx: Stops the update before the next chatmate once done
+//   - agentNames: Display names or filenames to update; all installed
+//     chatmates if empty
+//   - force: If true, also replaces chatmates that were edited locally or
+//     pinned
+//   - dryRun: If true, only displays the plan
+//
+// Returns:
+//   - int: Number of chatmates updated
+//   - error: Unknown or not installed chatmate, an installation error, or
+//     cancellation
+//
+// Example:
+//
+// updated, err := installer.Update(ctx, nil, false, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("update failed: %w", err)
+//	}
+func (i *InstallerService) Update(ctx context.Context, agentNames []string, force, dryRun bool) (int, error) {
+	defer i.manager.bind(ctx)()
+	out := i.manager.out()
+	updates, err := i.CheckUpdates()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(agentNames) > 0 {
+		filenames := make([]string, len(updates))
+		for n, update := range updates {
+			filenames[n] = update.Filename
+		}
+		byFilename := make(map[string]ChatmateUpdate, len(updates))
+		for _, update := range updates {
+			byFilename[update.Filename] = update
+		}
+
+		selected := make([]ChatmateUpdate, 0, len(agentNames))
+		for _, name := range agentNames {
+			filename, ok := i.manager.findChatmate(name, filenames)
+			if !ok {
+				return 0, errorf(ErrChatmateNotFound, "chatmate not installed or not shipped with ChatMate: %s", name)
+			}
+			selected = append(selected, byFilename[filename])
+		}
+		updates = selected
+	}
+
+	if len(updates) == 0 {
+		out.Println("No installed chatmates to update")
+		return 0, nil
+	}
+
+	i.markBreaking(ctx, updates)
+
+	var toUpdate []ChatmateUpdate
+	for _, update := range updates {
+		displayName := i.manager.getDisplayName(update.Filename)
+		switch {
+		case update.State == UpdateAvailable || (force && (update.State == UpdateModified || update.State == UpdatePinned)):
+			out.Printf("  🔄 %s (%s%s)\n", displayName, update.Versions(), update.breakingNote())
+			toUpdate = append(toUpdate, update)
+		case update.State == UpdateModified:
+			out.Printf("  ⚠️  %s (%s; edited locally, use --force to replace)\n", displayName, update.Versions())
+		case update.State == UpdatePinned:
+			out.Printf("  📌 %s (%s; %s, use --force to replace)\n", displayName, update.Versions(), update.pinned())
+		default:
+			out.Printf("  ✅ %s (%s)\n", displayName, update.Versions())
+		}
+	}
+
+	if len(toUpdate) == 0 {
+		out.Println("\n✅ All installed chatmates are up to date")
+		return 0, nil
+	}
+	if dryRun {
+		out.Printf("\n%d chatmate(s) would be updated\n", len(toUpdate))
+		return 0, nil
+	}
+	if !out.Confirm("\nUpdate %d chatmate(s)?", len(toUpdate)) {
+		out.Println("❌ Update cancelled by user")
+		return 0, nil
+	}
+
+	out.Println()
+	for _, update := range toUpdate {
+		if update.State != UpdateAvailable {
+			if _, err := i.manager.backupBefore(BackupReasonForce); err != nil {
+				return 0, err
+			}
+			break
+		}
+	}
+	updated := 0
+	for _, update := range toUpdate {
+		if err := interrupted(ctx, "update"); err != nil {
+			return updated, err
+		}
+		if !i.acceptBreaking(update.Filename, update.AvailableVersion, update.Breaking) {
+			continue
+		}
+		if err := i.InstallChatmate(update.Filename, true); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
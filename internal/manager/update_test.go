@@ -0,0 +1,180 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestUpdate tests updating only the installed chatmates that changed
+func TestUpdate(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+
+	oldContent := []byte("---\ndescription: test\nversion: '1.0.0'\n---\nOld\n")
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - B.chatmode.md", "Chatmate - C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), oldContent, 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - B.chatmode.md", "Chatmate - C.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+	if provenance, ok := cm.Provenance("Chatmate - A.chatmode.md"); !ok || provenance.Version != "1.0.0" {
+		t.Errorf("Expected the chatmate version in the provenance, got %+v", provenance)
+	}
+
+	// A and B change upstream; B was also edited locally
+	newContent := []byte("---\ndescription: test\nversion: '1.1.0'\n---\nNew\n")
+	for _, name := range []string{"Chatmate - A.chatmode.md", "Chatmate - B.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), newContent, 0644); err != nil {
+			t.Fatalf("Failed to update test file: %v", err)
+		}
+	}
+	edited := []byte("---\ndescription: test\nversion: '1.0.0'\n---\nMine\n")
+	if err := os.WriteFile(filepath.Join(promptsDir, "Chatmate - B.chatmode.md"), edited, 0644); err != nil {
+		t.Fatalf("Failed to edit installed file: %v", err)
+	}
+	cm.invalidateInventory()
+
+	updates, err := cm.Installer().CheckUpdates()
+	if err != nil {
+		t.Fatalf("CheckUpdates failed: %v", err)
+	}
+	states := make(map[string]string)
+	for _, update := range updates {
+		states[update.Filename] = update.State
+	}
+	if states["Chatmate - A.chatmode.md"] != UpdateAvailable || states["Chatmate - B.chatmode.md"] != UpdateModified ||
+		states["Chatmate - C.chatmode.md"] != UpdateCurrent || updates[0].Versions() != "1.0.0 → 1.1.0" {
+		t.Errorf("Unexpected updates: %+v", updates)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, true); err != nil || updated != 0 {
+		t.Errorf("Expected a dry run to change nothing, got %d, %v", updated, err)
+	}
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 1 {
+		t.Errorf("Expected one chatmate to be updated, got %d, %v", updated, err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(promptsDir, "Chatmate - A.chatmode.md")); string(content) != string(newContent) {
+		t.Errorf("Expected A to be updated, got %q", content)
+	}
+	if content, _ := os.ReadFile(filepath.Join(promptsDir, "Chatmate - B.chatmode.md")); string(content) != string(edited) {
+		t.Errorf("Expected the local edit of B to be kept, got %q", content)
+	}
+
+	if updated, err := cm.Installer().Update(context.Background(), []string{"B"}, true, false); err != nil || updated != 1 {
+		t.Errorf("Expected --force to replace the edited chatmate, got %d, %v", updated, err)
+	}
+	if _, err := cm.Installer().Update(context.Background(), []string{"Missing"}, false, false); err == nil {
+		t.Error("Expected an error for a chatmate that is not installed")
+	}
+}
+
+// TestUpdatePins tests that updates outside the versions the team policy
+// pins a chatmate to are held back
+func TestUpdatePins(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("pins:\n  A: ^1.0\n  B.chatmode.md: ^1.0\n"), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+
+	writeVersion := func(name, version string) {
+		content := "---\ndescription: test\nversion: '" + version + "'\n---\n" + version + "\n"
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		writeVersion(name, "1.0.0")
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir, policyPath: policyPath}
+	cm.installer = NewInstallerService(cm)
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+
+	// A leaves its pinned range, B stays inside it, and C is not pinned
+	writeVersion("A.chatmode.md", "2.0.0")
+	writeVersion("B.chatmode.md", "1.1.0")
+	writeVersion("C.chatmode.md", "3.0.0")
+	cm.invalidateInventory()
+
+	report, err := cm.Installer().Outdated()
+	if err != nil {
+		t.Fatalf("Outdated failed: %v", err)
+	}
+	states := make(map[string]ChatmateUpdate)
+	for _, update := range report.Chatmates {
+		states[update.Filename] = update
+	}
+	if len(report.Chatmates) != 3 || states["A.chatmode.md"].State != UpdatePinned || states["A.chatmode.md"].Pin != "^1.0" ||
+		states["B.chatmode.md"].State != UpdateAvailable || states["B.chatmode.md"].Pin != "^1.0" ||
+		states["C.chatmode.md"].State != UpdateAvailable || states["C.chatmode.md"].Pin != "" {
+		t.Errorf("Unexpected outdated chatmates: %+v", report.Chatmates)
+	}
+
+	plan, err := cm.Installer().PlanSync(false)
+	if err != nil {
+		t.Fatalf("PlanSync failed: %v", err)
+	}
+	if len(plan.Pinned) != 1 || plan.Pinned[0].Filename != "A.chatmode.md" || len(plan.Update) != 2 {
+		t.Errorf("Expected sync to keep the pinned chatmate, got %+v", plan)
+	}
+
+	output.SetAssumeYes(true)
+	defer output.SetAssumeYes(false)
+
+	if updated, err := cm.Installer().Update(context.Background(), nil, false, false); err != nil || updated != 2 {
+		t.Errorf("Expected the chatmates inside their pins to be updated, got %d, %v", updated, err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(promptsDir, "A.chatmode.md")); !strings.Contains(string(content), "1.0.0") {
+		t.Errorf("Expected A to stay at its pinned version, got %q", content)
+	}
+
+	report, err = cm.Installer().Outdated()
+	if err != nil {
+		t.Fatalf("Outdated failed: %v", err)
+	}
+	if len(report.Chatmates) != 1 || report.Chatmates[0].Filename != "A.chatmode.md" || report.Updates() != 0 {
+		t.Errorf("Expected only the pinned chatmate to stay outdated, got %+v", report.Chatmates)
+	}
+	if len(report.Current) != 2 || report.Current[0].Filename != "B.chatmode.md" || report.Current[1].Filename != "C.chatmode.md" {
+		t.Errorf("Expected the updated chatmates to be up to date, got %+v", report.Current)
+	}
+	for _, update := range report.Current {
+		if update.InstalledChecksum == "" || update.InstalledChecksum != update.AvailableChecksum {
+			t.Errorf("Expected matching checksums for %s, got %+v", update.Filename, update)
+		}
+	}
+	if pinned := report.Chatmates[0]; pinned.InstalledChecksum == pinned.AvailableChecksum {
+		t.Errorf("Expected different checksums for the outdated chatmate, got %+v", pinned)
+	}
+
+	if err := os.WriteFile(policyPath, []byte("pins:\n  A: '>=1.0 <'\n"), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	if _, err := cm.Installer().Outdated(); err == nil {
+		t.Error("Expected an error for a malformed pin")
+	}
+}
@@ -2,17 +2,33 @@
 package manager
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/cache"
 	"github.com/jonassiebler/chatmate/pkg/security"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+	"gopkg.in/yaml.v3"
 )
 
 // ValidatorService handles chatmate validation operations.
+//
+// Fields:
+//   - WriteProbe: Verify prompts directory write access by creating and
+//     removing a temporary file instead of querying access permissions
 type ValidatorService struct {
-	manager *ChatMateManager
+	manager    *ChatMateManager
+	WriteProbe bool
 }
 
 // NewValidatorService creates a new validator service.
@@ -20,51 +36,172 @@ func NewValidatorService(manager *ChatMateManager) *ValidatorService {
 	return &ValidatorService{manager: manager}
 }
 
+// CheckStatus is the outcome of a single validation check.
+type CheckStatus string
+
+// Validation check outcomes.
+const (
+	CheckPass CheckStatus = "pass"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// Severity indicates how serious a validation finding is.
+type Severity string
+
+// Validation finding severities.
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// CheckResult describes the outcome of a single validation check.
+//
+// Fields:
+//   - Name: Stable identifier of the check (e.g., "prompts-directory")
+//   - Status: Outcome of the check (pass, warn, fail)
+//   - Severity: How serious the finding is (info, warning, error)
+//   - Message: Human-readable description of the outcome
+//   - Files: Chatmate files affected by the finding, if any
+type CheckResult struct {
+	Name     string      `json:"name"`
+	Status   CheckStatus `json:"status"`
+	Severity Severity    `json:"severity"`
+	Message  string      `json:"message"`
+	Files    []string    `json:"files,omitempty"`
+}
+
+// Report is the structured result of an installation validation.
+//
+// Reports contain no presentation logic so they can be rendered by any
+// command or serialized to JSON for programmatic use.
+type Report struct {
+	PromptsDir string        `json:"promptsDir"`
+	Checks     []CheckResult `json:"checks"`
+}
+
+// Valid reports whether no check failed.
+func (r *Report) Valid() bool {
+	return r.Count(CheckFail) == 0
+}
+
+// Count returns the number of checks with the given status.
+func (r *Report) Count(status CheckStatus) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// pass records a successful check.
+func (r *Report) pass(name, message string) {
+	r.Checks = append(r.Checks, CheckResult{Name: name, Status: CheckPass, Severity: SeverityInfo, Message: message})
+}
+
+// warn records a check that found non-fatal issues.
+func (r *Report) warn(name, message string, files ...string) {
+	r.Checks = append(r.Checks, CheckResult{Name: name, Status: CheckWarn, Severity: SeverityWarning, Message: message, Files: files})
+}
+
+// fail records a failed check.
+func (r *Report) fail(name, message string, files ...string) {
+	r.Checks = append(r.Checks, CheckResult{Name: name, Status: CheckFail, Severity: SeverityError, Message: message, Files: files})
+}
+
 // ValidateInstallation performs comprehensive validation of chatmate installation.
 //
-// This method checks the integrity of the installation environment, validates
-// all installed chatmates, and provides detailed feedback about any issues found.
+// This method checks the integrity of the installation environment and all
+// installed chatmates. It does not print anything; every finding is recorded
+// in the returned Report with its status, severity, and affected files.
+//
+// Parameters:
+//   - ctx: Bounds the file operations of the checks; a validation that was
+//     interrupted returns an error instead of a report of failed checks
 //
 // Returns:
-//   - error: Validation failure or system error
-//   - bool: True if validation passes, false if issues found
+//   - *Report: Structured validation results
+//   - error: System error that prevented validation from running, or
+//     cancellation
 //
 // Example:
 //
-// valid, err := validator.ValidateInstallation()
+// report, err := validator.ValidateInstallation(ctx)
 //
 //	if err != nil {
 //	   return fmt.Errorf("validation failed: %w", err)
 //	}
 //
-//	if !valid {
+//	if !report.Valid() {
 //	   fmt.Println("Installation has issues")
 //	}
-func (v *ValidatorService) ValidateInstallation() (bool, error) {
-	fmt.Println("Validating ChatMate installation...")
+func (v *ValidatorService) ValidateInstallation(ctx context.Context) (*Report, error) {
+	defer v.manager.bind(ctx)()
+	report, err := v.validateInstallation()
+	if interruptErr := interrupted(ctx, "validation"); interruptErr != nil {
+		return nil, interruptErr
+	}
+	return report, err
+}
+
+// validateInstallation runs the checks of ValidateInstallation.
+func (v *ValidatorService) validateInstallation() (*Report, error) {
+	report := &Report{PromptsDir: v.manager.PromptsDir}
 
 	// Check prompts directory
-	if err := v.validatePromptsDirectory(); err != nil {
-		return false, err
+	promptsDirValid := v.validatePromptsDirectory(report)
+
+	// A directory that does not answer in time cannot be inspected further
+	if promptsDirValid && !v.validateFilesystemLatency(report) {
+		return report, nil
+	}
+
+	inventory, err := v.manager.Inventory()
+	if err != nil {
+		return nil, err
 	}
 
 	// Check available chatmates
-	if err := v.validateAvailableChatmates(); err != nil {
-		return false, err
+	v.validateAvailableChatmates(report, inventory.Available)
+	v.validateChatmateMetadata(report, inventory.Available)
+
+	// Installed chatmates can only be inspected when the directory exists
+	if !promptsDirValid {
+		return report, nil
 	}
 
 	// Check installed chatmates
-	if err := v.validateInstalledChatmates(); err != nil {
-		return false, err
-	}
+	v.validateInstalledChatmates(report, inventory)
 
 	// Check for orphaned files
-	if err := v.validateOrphanedFiles(); err != nil {
-		return false, err
-	}
+	v.validateOrphanedFiles(report, inventory)
 
-	fmt.Println("✅ ChatMate installation validation completed successfully")
-	return true, nil
+	// Check for chatmates still named the way older releases named them
+	v.validateLegacyNames(report)
+
+	// Check for files that would be mentioned by the same name
+	v.validateDuplicateNames(report, inventory)
+
+	// Check for installed files that only differ in case from available ones
+	v.validateCaseCollisions(report, inventory)
+
+	// Check for copies left behind by cloud-sync clients
+	v.validateSyncConflicts(report)
+
+	// Check for names Copilot Chat or Windows tools cut off
+	v.validateNameLength(report, inventory)
+
+	// Check for files others may change or the owner cannot
+	v.validateFileModes(report, inventory)
+
+	// Check the configuration file and team policy against their schemas
+	v.validateSchemaFile(report, "config-schema", "config", "Configuration file", v.manager.configPath)
+	v.validateSchemaFile(report, "policy-schema", "policy", "Team policy", v.manager.policyPath)
+
+	return report, nil
 }
 
 // ValidateChatmate validates a specific chatmate file.
@@ -81,56 +218,53 @@ func (v *ValidatorService) ValidateInstallation() (bool, error) {
 //
 // Example:
 //
-// valid, err := validator.ValidateChatmate("Chatmate - Solve Issue.chatmode.md")
+// valid, err := validator.ValidateChatmate("Solve Issue.chatmode.md")
 //
 //	if err != nil {
 //	   return fmt.Errorf("chatmate validation failed: %w", err)
 //	}
 func (v *ValidatorService) ValidateChatmate(filename string) (bool, error) {
+	inventory, err := v.manager.Inventory()
+	if err != nil {
+		return false, err
+	}
+
+	return v.validateChatmate(filename, inventory)
+}
+
+// validateChatmate validates a chatmate file against a known inventory.
+//
+// Sharing the inventory lets callers validate many chatmates without
+// rescanning the chatmate directories for each one.
+func (v *ValidatorService) validateChatmate(filename string, inventory *cache.Inventory) (bool, error) {
 	// Security validation
 	if err := security.ValidateChatmateFilename(filename); err != nil {
-		return false, fmt.Errorf("security validation failed: %w", err)
+		return false, errorf(ErrValidationFailed, "security validation failed: %w", err)
 	}
 
 	// Validate file extension
 	if err := security.ValidateFileExtension(filename, []string{".md"}); err != nil {
-		return false, fmt.Errorf("file extension validation failed: %w", err)
+		return false, errorf(ErrValidationFailed, "file extension validation failed: %w", err)
 	}
 
 	// Check if file exists in available chatmates
-	availableChatmates, err := v.manager.GetAvailableChatmates()
-	if err != nil {
-		return false, err
-	}
-
-	found := false
-	for _, available := range availableChatmates {
-		if available == filename {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		return false, fmt.Errorf("chatmate file not found in available chatmates: %s", filename)
+	if !inventory.IsAvailable(filename) {
+		return false, errorf(ErrChatmateNotFound, "chatmate file not found in available chatmates: %s", filename)
 	}
 
 	// Validate content if installed
-	destPath := filepath.Join(v.manager.PromptsDir, filename)
-	if _, err := os.Stat(destPath); err == nil {
-		content, err := os.ReadFile(destPath)
-		if err != nil {
-			return false, fmt.Errorf("failed to read installed chatmate: %w", err)
-		}
-
+	content, err := v.manager.FS.ReadFile(filepath.Join(v.manager.PromptsDir, filename))
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read installed chatmate: %w", err)
+	}
+	if err == nil {
 		if err := security.ValidateContentLength(content, 10*1024*1024); err != nil { // 10MB limit
-			return false, fmt.Errorf("content validation failed: %w", err)
+			return false, errorf(ErrValidationFailed, "content validation failed: %w", err)
 		}
 
 		// Check for basic chatmate content structure
-		contentStr := string(content)
-		if !strings.Contains(contentStr, "---") {
-			return false, fmt.Errorf("chatmate file appears to be missing YAML frontmatter")
+		if _, err := files.ParseFrontmatter(content); err != nil {
+			return false, err
 		}
 	}
 
@@ -138,131 +272,413 @@ func (v *ValidatorService) ValidateChatmate(filename string) (bool, error) {
 }
 
 // validatePromptsDirectory checks the prompts directory status.
-func (v *ValidatorService) validatePromptsDirectory() error {
-	fmt.Printf("Checking prompts directory: %s\n", v.manager.PromptsDir)
+//
+// It returns true if the directory exists and can be inspected further.
+func (v *ValidatorService) validatePromptsDirectory(report *Report) bool {
+	const check = "prompts-directory"
+
+	if v.manager.promptsDirErr != nil {
+		report.fail(check, fmt.Sprintf("prompts directory is unusable: %v", v.manager.promptsDirErr))
+		return false
+	}
 
 	// Check if directory exists
-	info, err := os.Stat(v.manager.PromptsDir)
+	info, err := v.manager.FS.Stat(v.manager.PromptsDir)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("prompts directory does not exist: %s", v.manager.PromptsDir)
+		report.fail(check, fmt.Sprintf("prompts directory does not exist: %s", v.manager.PromptsDir))
+		return false
 	}
 	if err != nil {
-		return fmt.Errorf("failed to check prompts directory: %w", err)
+		report.fail(check, fmt.Sprintf("failed to check prompts directory: %v", err))
+		return false
 	}
 
 	// Check if it's actually a directory
 	if !info.IsDir() {
-		return fmt.Errorf("prompts path exists but is not a directory: %s", v.manager.PromptsDir)
+		report.fail(check, fmt.Sprintf("prompts path exists but is not a directory: %s", v.manager.PromptsDir))
+		return false
 	}
 
 	// Check permissions
 	if err := v.checkDirectoryPermissions(v.manager.PromptsDir); err != nil {
-		return fmt.Errorf("prompts directory permission issue: %w", err)
+		report.fail(check, fmt.Sprintf("prompts directory permission issue: %v", err))
+		return true
 	}
 
-	fmt.Println("✅ Prompts directory is valid")
-	return nil
+	report.pass(check, "Prompts directory is valid")
+	return true
 }
 
-// validateAvailableChatmates checks available chatmates.
-func (v *ValidatorService) validateAvailableChatmates() error {
-	fmt.Println("Checking available chatmates...")
+// slowFilesystemThreshold is the listing duration above which the prompts
+// directory is reported as being on a slow filesystem.
+const slowFilesystemThreshold = time.Second
 
-	availableChatmates, err := v.manager.GetAvailableChatmates()
+// validateFilesystemLatency measures how fast the prompts directory can be
+// listed, so network drives that make operations slow or hang are diagnosed.
+//
+// It returns false if the directory did not answer within the timeout.
+func (v *ValidatorService) validateFilesystemLatency(report *Report) bool {
+	const check = "filesystem-latency"
+
+	dir := v.manager.PromptsDir
+	start := time.Now()
+	_, err := files.Do(v.manager.FS, "list", dir, func() ([]string, error) {
+		return scanChatmateDir(v.manager.FS.FileSystem(), dir)
+	})
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	var slow *files.SlowFilesystemError
+	if errors.As(err, &slow) {
+		report.fail(check, err.Error())
+		return false
+	}
 	if err != nil {
-		return fmt.Errorf("failed to get available chatmates: %w", err)
+		report.fail(check, fmt.Sprintf("failed to list prompts directory: %v", err))
+		return true
+	}
+
+	if elapsed > slowFilesystemThreshold {
+		report.warn(check, fmt.Sprintf("Listing the prompts directory took %s; it may be on a slow network drive (operations time out after %s, see %s)",
+			elapsed, v.manager.FS.Timeout, files.TimeoutEnv))
+		return true
 	}
 
+	report.pass(check, fmt.Sprintf("Prompts directory responds in %s", elapsed))
+	return true
+}
+
+// validateAvailableChatmates checks available chatmates.
+func (v *ValidatorService) validateAvailableChatmates(report *Report, availableChatmates []string) {
+	const check = "available-chatmates"
+
 	if len(availableChatmates) == 0 {
-		return fmt.Errorf("no chatmates available for installation")
+		report.fail(check, "no chatmates available for installation")
+		return
 	}
 
 	// Validate each available chatmate
+	var invalid []string
 	for _, filename := range availableChatmates {
 		if err := security.ValidateChatmateFilename(filename); err != nil {
-			return fmt.Errorf("invalid chatmate filename %s: %w", filename, err)
+			invalid = append(invalid, filename)
+		}
+	}
+
+	if len(invalid) > 0 {
+		report.fail(check, fmt.Sprintf("found %d invalid chatmate filenames", len(invalid)), invalid...)
+		return
+	}
+
+	report.pass(check, fmt.Sprintf("Found %d valid available chatmates", len(availableChatmates)))
+}
+
+// validateChatmateMetadata checks the frontmatter of available chatmates
+// strictly, rejecting unknown fields and YAML that other tools may read
+// differently, including that their example invocations mention the
+// chatmate and that they credit their author and state their license.
+func (v *ValidatorService) validateChatmateMetadata(report *Report, availableChatmates []string) {
+	const check = "chatmate-metadata"
+
+	var issues, invalid []string
+	for _, filename := range availableChatmates {
+		content, err := v.manager.GetChatmateContent(filename)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s: %v", filename, err))
+			invalid = append(invalid, filename)
+			continue
+		}
+
+		meta, err := files.ParseFrontmatterStrict(content)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s: %v", filename, err))
+			invalid = append(invalid, filename)
+			continue
 		}
+
+		problems := append(meta.ValidateExamples(v.manager.getDisplayName(filename)), meta.ValidateAttribution()...)
+		if len(problems) > 0 {
+			issues = append(issues, fmt.Sprintf("%s: %s", filename, strings.Join(problems, ", ")))
+			invalid = append(invalid, filename)
+		}
+	}
+
+	if len(invalid) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d chatmates with invalid metadata: %s", len(invalid), strings.Join(issues, "; ")), invalid...)
+		return
 	}
 
-	fmt.Printf("✅ Found %d valid available chatmates\n", len(availableChatmates))
-	return nil
+	report.pass(check, fmt.Sprintf("Metadata of %d available chatmates is valid", len(availableChatmates)))
 }
 
 // validateInstalledChatmates checks installed chatmates.
-func (v *ValidatorService) validateInstalledChatmates() error {
-	fmt.Println("Checking installed chatmates...")
+func (v *ValidatorService) validateInstalledChatmates(report *Report, inventory *cache.Inventory) {
+	const check = "installed-chatmates"
+	installedChatmates := inventory.Installed
 
-	installedChatmates, err := v.manager.GetInstalledChatmates()
-	if err != nil {
-		return fmt.Errorf("failed to get installed chatmates: %w", err)
-	}
+	// Adopted files are user-managed and not expected to be available
+	adopted := v.manager.adoptedSet()
 
 	// Validate each installed chatmate
+	var issues []string
+	var files []string
 	for _, filename := range installedChatmates {
-		if _, err := v.ValidateChatmate(filename); err != nil {
-			fmt.Printf("⚠️  Validation issue with %s: %v\n", filename, err)
+		// Conflict copies are reported by the sync-conflicts check
+		if isSyncConflict(filename) || adopted[filename] {
+			continue
 		}
+		if _, err := v.validateChatmate(filename, inventory); err != nil {
+			issues = append(issues, fmt.Sprintf("%s: %v", filename, err))
+			files = append(files, filename)
+		}
+	}
+
+	if len(issues) > 0 {
+		report.warn(check, fmt.Sprintf("validation issues with %d of %d installed chatmates: %s",
+			len(issues), len(installedChatmates), strings.Join(issues, "; ")), files...)
+		return
 	}
 
-	fmt.Printf("✅ Validated %d installed chatmates\n", len(installedChatmates))
-	return nil
+	report.pass(check, fmt.Sprintf("Validated %d installed chatmates", len(installedChatmates)))
 }
 
 // validateOrphanedFiles checks for orphaned files.
-func (v *ValidatorService) validateOrphanedFiles() error {
-	fmt.Println("Checking for orphaned files...")
+func (v *ValidatorService) validateOrphanedFiles(report *Report, inventory *cache.Inventory) {
+	const check = "orphaned-files"
+
+	orphaned := v.manager.orphanedFiles(inventory)
+	if len(orphaned) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d orphaned files", len(orphaned)), orphaned...)
+		return
+	}
+
+	report.pass(check, "No orphaned files found")
+}
 
-	availableChatmates, err := v.manager.GetAvailableChatmates()
+// validateLegacyNames checks for installed chatmates that still have the
+// filename an older release gave them.
+func (v *ValidatorService) validateLegacyNames(report *Report) {
+	const check = "legacy-names"
+
+	legacy, err := v.manager.LegacyFiles()
 	if err != nil {
-		return err
+		report.fail(check, fmt.Sprintf("failed to check for legacy filenames: %v", err))
+		return
+	}
+	if len(legacy) == 0 {
+		report.pass(check, "No chatmates with legacy filenames found")
+		return
+	}
+
+	var renames, files []string
+	for _, file := range legacy {
+		renames = append(renames, fmt.Sprintf("%s → %s", file.Filename, file.Current))
+		files = append(files, file.Filename)
+	}
+	report.warn(check, fmt.Sprintf("Found %d chatmates with the %q prefix of older releases: %s",
+		len(legacy), strings.TrimSpace(LegacyPrefix), strings.Join(renames, "; ")), files...)
+}
+
+// validateDuplicateNames checks for installed files that map to the same
+// display name, so an @-mention cannot tell them apart.
+func (v *ValidatorService) validateDuplicateNames(report *Report, inventory *cache.Inventory) {
+	const check = "duplicate-names"
+
+	// Conflict copies are reported by the sync-conflicts check
+	var installed []string
+	for _, filename := range inventory.Installed {
+		if !isSyncConflict(filename) {
+			installed = append(installed, filename)
+		}
+	}
+
+	duplicates := duplicateDisplayNames(installed)
+	if len(duplicates) == 0 {
+		report.pass(check, "Every installed chatmate has its own display name")
+		return
+	}
+
+	var names, files []string
+	for _, group := range duplicates {
+		names = append(names, fmt.Sprintf("%q (%s)", DisplayName(group[0]), strings.Join(group, ", ")))
+		files = append(files, group...)
+	}
+	report.warn(check, fmt.Sprintf("Found %d display names shared by several files, making @-mentions ambiguous: %s",
+		len(duplicates), strings.Join(names, "; ")), files...)
+}
+
+// validateCaseCollisions checks for installed files whose name only differs
+// in case from an available chatmate, which is then neither installed nor
+// updated.
+func (v *ValidatorService) validateCaseCollisions(report *Report, inventory *cache.Inventory) {
+	const check = "case-collisions"
+
+	var collisions, files []string
+	for _, filename := range inventory.Available {
+		if variant, collides := caseVariant(filename, inventory.Installed); collides {
+			collisions = append(collisions, CaseCollision{Filename: filename, Installed: variant}.String())
+			files = append(files, variant)
+		}
 	}
 
-	installedChatmates, err := v.manager.GetInstalledChatmates()
+	if len(files) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d installed chatmate(s) whose name only differs in case from an available one: %s",
+			len(files), strings.Join(collisions, "; ")), files...)
+		return
+	}
+
+	report.pass(check, "No installed file only differs in case from an available chatmate")
+}
+
+// validateSyncConflicts checks for cloud-sync conflict copies.
+func (v *ValidatorService) validateSyncConflicts(report *Report) {
+	const check = "sync-conflicts"
+
+	conflicts, err := v.manager.FindSyncConflicts()
 	if err != nil {
-		return err
+		report.fail(check, fmt.Sprintf("failed to check for sync conflicts: %v", err))
+		return
+	}
+
+	if len(conflicts) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d sync-conflict copies, shown as duplicate chatmates in Copilot Chat", len(conflicts)), conflicts...)
+		return
+	}
+
+	report.pass(check, "No sync-conflict copies found")
+}
+
+// validateNameLength checks the display names of the available and
+// installed chatmates, and the paths of the installed ones, against
+// MaxDisplayNameLength and MaxPathLength.
+func (v *ValidatorService) validateNameLength(report *Report, inventory *cache.Inventory) {
+	const check = "name-length"
+
+	var issues []string
+	var files []string
+	seen := map[string]bool{}
+	for _, filename := range append(slices.Clone(inventory.Available), inventory.Installed...) {
+		// Conflict copies are reported by the sync-conflicts check
+		if seen[filename] || isSyncConflict(filename) {
+			continue
+		}
+		seen[filename] = true
+
+		promptsDir := ""
+		if inventory.IsInstalled(filename) {
+			promptsDir = v.manager.PromptsDir
+		}
+		found := CheckNameLength(filename, promptsDir)
+		for _, issue := range found {
+			issues = append(issues, issue.String())
+		}
+		if len(found) > 0 {
+			files = append(files, filename)
+		}
 	}
 
-	orphanedCount := v.countOrphanedFiles(availableChatmates, installedChatmates)
-	if orphanedCount > 0 {
-		fmt.Printf("⚠️  Found %d orphaned files\n", orphanedCount)
-	} else {
-		fmt.Println("✅ No orphaned files found")
+	if len(files) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d chatmate(s) with names that may be cut off: %s", len(files), strings.Join(issues, "; ")), files...)
+		return
 	}
 
-	return nil
+	report.pass(check, fmt.Sprintf("Every chatmate name is at most %d characters", MaxDisplayNameLength))
 }
 
-// countOrphanedFiles counts files that are installed but not available.
-func (v *ValidatorService) countOrphanedFiles(available, installed []string) int {
-	availableSet := make(map[string]bool)
-	for _, filename := range available {
-		availableSet[filename] = true
+// validateFileModes checks the permissions of the prompts directory and the
+// installed chatmates against the configured DirMode and FileMode. Entries
+// that grant more than those modes, or that the owner cannot read and write,
+// are reported. Windows does not use permission bits, and directories on
+// another backend (see files.Policy.Backend) have none, so neither is checked.
+func (v *ValidatorService) validateFileModes(report *Report, inventory *cache.Inventory) {
+	const check = "file-modes"
+
+	if _, ok := v.manager.FS.FileSystem().(files.OS); !ok || runtime.GOOS == "windows" {
+		report.pass(check, "Permissions are not checked on this filesystem")
+		return
 	}
 
-	orphanedCount := 0
-	for _, filename := range installed {
-		if !availableSet[filename] {
-			orphanedCount++
+	var issues []string
+	var found []string
+	checkMode := func(name, path string, allowed, owner fs.FileMode) {
+		info, err := v.manager.FS.Stat(path)
+		if err != nil {
+			return
+		}
+		perm := info.Mode().Perm()
+		if perm&^allowed != 0 || perm&owner != owner {
+			issues = append(issues, fmt.Sprintf("%s (%04o)", name, perm))
+			found = append(found, name)
 		}
 	}
 
-	return orphanedCount
+	fileMode, dirMode := v.manager.fileMode(), v.manager.dirMode()
+	checkMode(v.manager.PromptsDir, v.manager.PromptsDir, dirMode, 0700)
+	for _, filename := range inventory.Installed {
+		checkMode(filename, filepath.Join(v.manager.PromptsDir, filename), fileMode, 0600)
+	}
+
+	if len(found) > 0 {
+		report.warn(check, fmt.Sprintf("Found %d path(s) with permissions other than %04o for files and %04o for directories: %s",
+			len(found), fileMode, dirMode, strings.Join(issues, ", ")), found...)
+		return
+	}
+
+	report.pass(check, fmt.Sprintf("Installed chatmates have at most mode %04o", fileMode))
 }
 
-// checkDirectoryPermissions validates directory access permissions.
-func (v *ValidatorService) checkDirectoryPermissions(dir string) error {
-	// Try to create a temporary file to check write permissions
-	tempFile := filepath.Join(dir, ".chatmate_temp_permission_check")
-	file, err := os.Create(tempFile)
+// validateSchemaFile checks a YAML file ChatMate reads against the embedded
+// JSON Schema of its kind (see assets.ValidateSchema), so a misspelled or
+// malformed setting is reported before a command stops on it.
+func (v *ValidatorService) validateSchemaFile(report *Report, check, kind, label, path string) {
+	if path == "" {
+		report.pass(check, label+" location is unknown; not checked")
+		return
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		report.pass(check, fmt.Sprintf("No %s at %s", strings.ToLower(label), path))
+		return
+	}
 	if err != nil {
-		return fmt.Errorf("no write permission: %w", err)
+		report.fail(check, fmt.Sprintf("Cannot read %s %s: %v", strings.ToLower(label), path, err), path)
+		return
+	}
+
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		report.fail(check, fmt.Sprintf("%s %s is not valid YAML: %v", label, path, err), path)
+		return
+	}
+	if document == nil {
+		report.pass(check, fmt.Sprintf("%s %s is empty", label, path))
+		return
 	}
-	file.Close()
 
-	// Clean up the temporary file
-	if err := os.Remove(tempFile); err != nil {
-		return fmt.Errorf("cleanup failed: %w", err)
+	violations, err := assets.ValidateSchema(kind, document)
+	if err != nil {
+		report.fail(check, err.Error(), path)
+		return
+	}
+	if len(violations) > 0 {
+		report.fail(check, fmt.Sprintf("%s %s does not match the schema ('chatmate schema %s'): %s",
+			label, path, kind, strings.Join(violations, "; ")), path)
+		return
 	}
 
-	return nil
+	report.pass(check, fmt.Sprintf("%s %s matches the schema", label, path))
+}
+
+// checkDirectoryPermissions validates directory access permissions.
+//
+// Access is checked without touching the directory unless WriteProbe is set.
+// Permissions are a property of the real filesystem, so directories on
+// another backend (see files.Policy.Backend) are not checked.
+func (v *ValidatorService) checkDirectoryPermissions(dir string) error {
+	if _, ok := v.manager.FS.FileSystem().(files.OS); !ok {
+		return nil
+	}
+	if v.WriteProbe {
+		return platform.WriteProbe(dir)
+	}
+	return platform.CheckDirWritable(dir)
 }
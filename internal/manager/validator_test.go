@@ -0,0 +1,224 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// TestValidatorService_ValidateInstallation tests the structured validation report
+func TestValidatorService_ValidateInstallation(t *testing.T) {
+	tmpDir := t.TempDir()
+	matesDir := filepath.Join(tmpDir, "mates")
+	promptsDir := filepath.Join(tmpDir, "prompts")
+	if err := os.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("Failed to create mates directory: %v", err)
+	}
+
+	content := []byte("---\ndescription: 'Agent'\n---\n\n# Agent\n")
+	if err := os.WriteFile(filepath.Join(matesDir, "Agent.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create test chatmate: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir}
+	validator := NewValidatorService(cm)
+
+	// Missing prompts directory fails validation
+	report, err := validator.ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	if report.Valid() {
+		t.Error("Expected report to be invalid without prompts directory")
+	}
+	if report.PromptsDir != promptsDir {
+		t.Errorf("Expected prompts dir %s, got %s", promptsDir, report.PromptsDir)
+	}
+
+	// Installed chatmate plus an orphaned file
+	if err := os.MkdirAll(promptsDir, 0755); err != nil {
+		t.Fatalf("Failed to create prompts directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Agent.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to install test chatmate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Orphan.chatmode.md"), content, 0644); err != nil {
+		t.Fatalf("Failed to create orphaned chatmate: %v", err)
+	}
+
+	report, err = validator.ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+	if !report.Valid() {
+		t.Errorf("Expected valid report, got %+v", report.Checks)
+	}
+
+	var orphaned *CheckResult
+	for i := range report.Checks {
+		if report.Checks[i].Name == "orphaned-files" {
+			orphaned = &report.Checks[i]
+		}
+	}
+	if orphaned == nil {
+		t.Fatal("Report missing orphaned-files check")
+	}
+	if orphaned.Status != CheckWarn || orphaned.Severity != SeverityWarning {
+		t.Errorf("Expected orphaned-files warning, got %s/%s", orphaned.Status, orphaned.Severity)
+	}
+	if len(orphaned.Files) != 1 || orphaned.Files[0] != "Orphan.chatmode.md" {
+		t.Errorf("Expected orphaned file to be reported, got %v", orphaned.Files)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Report should be JSON serializable: %v", err)
+	}
+	if !strings.Contains(string(data), `"status":"warn"`) {
+		t.Errorf("Unexpected JSON report: %s", data)
+	}
+}
+
+// TestValidatorService_FilesystemLatency tests the slow filesystem diagnostic
+func TestValidatorService_FilesystemLatency(t *testing.T) {
+	promptsDir := t.TempDir()
+	cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: promptsDir, FS: files.DefaultPolicy()}
+	cm.validator = NewValidatorService(cm)
+
+	report, err := cm.Validator().ValidateInstallation(context.Background())
+	if err != nil {
+		t.Fatalf("ValidateInstallation failed: %v", err)
+	}
+
+	found := false
+	for _, check := range report.Checks {
+		if check.Name == "filesystem-latency" {
+			found = true
+			if check.Status != CheckPass {
+				t.Errorf("Expected a local directory to respond quickly, got %+v", check)
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a filesystem-latency check in the report")
+	}
+}
+
+// TestValidateFileModes tests reporting installed chatmates with permissions
+// beyond the configured FileMode
+func TestValidateFileModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not use permission bits")
+	}
+	promptsDir := t.TempDir()
+	if err := os.Chmod(promptsDir, 0755); err != nil {
+		t.Fatalf("Failed to chmod prompts directory: %v", err)
+	}
+	content := []byte("---\ndescription: test\n---\n")
+	for name, perm := range map[string]os.FileMode{"Private.chatmode.md": 0600, "Shared.chatmode.md": 0666} {
+		path := filepath.Join(promptsDir, name)
+		if err := os.WriteFile(path, content, perm); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := os.Chmod(path, perm); err != nil {
+			t.Fatalf("Failed to chmod test file: %v", err)
+		}
+	}
+
+	cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: promptsDir, FS: files.DefaultPolicy()}
+	cm.validator = NewValidatorService(cm)
+	fileModes := func() CheckResult {
+		report, err := cm.Validator().ValidateInstallation(context.Background())
+		if err != nil {
+			t.Fatalf("ValidateInstallation failed: %v", err)
+		}
+		for _, check := range report.Checks {
+			if check.Name == "file-modes" {
+				return check
+			}
+		}
+		t.Fatal("Expected a file-modes check")
+		return CheckResult{}
+	}
+
+	if check := fileModes(); check.Status != CheckWarn || !slices.Equal(check.Files, []string{"Shared.chatmode.md"}) ||
+		!strings.Contains(check.Message, "Shared.chatmode.md (0666)") {
+		t.Errorf("Expected a warning for Shared.chatmode.md, got %+v", check)
+	}
+
+	// A stricter DirMode reports the prompts directory as well
+	cm.FileMode, cm.DirMode = 0600, 0700
+	if check := fileModes(); check.Status != CheckWarn || !slices.Contains(check.Files, promptsDir) {
+		t.Errorf("Expected a warning for the prompts directory, got %+v", check)
+	}
+
+	cm.FileMode, cm.DirMode = 0666, 0755
+	if check := fileModes(); check.Status != CheckPass {
+		t.Errorf("Expected the check to pass, got %+v", check)
+	}
+}
+
+// TestValidateSchemaFiles tests checking the configuration file and team
+// policy against their schemas
+func TestValidateSchemaFiles(t *testing.T) {
+	dir := t.TempDir()
+	cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), FS: files.DefaultPolicy(),
+		configPath: filepath.Join(dir, "config.yaml"), policyPath: filepath.Join(dir, "policy.yaml")}
+	cm.validator = NewValidatorService(cm)
+	checks := func() map[string]CheckResult {
+		report, err := cm.Validator().ValidateInstallation(context.Background())
+		if err != nil {
+			t.Fatalf("ValidateInstallation failed: %v", err)
+		}
+		results := make(map[string]CheckResult)
+		for _, check := range report.Checks {
+			results[check.Name] = check
+		}
+		return results
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	// Missing files have nothing to check
+	results := checks()
+	for _, name := range []string{"config-schema", "policy-schema"} {
+		if check := results[name]; check.Status != CheckPass || !strings.Contains(check.Message, "No ") {
+			t.Errorf("Expected %s to pass without a file, got %+v", name, check)
+		}
+	}
+
+	write("config.yaml", "editor: insiders\nbackupKeep: 3\n")
+	write("policy.yaml", "required:\n  - Review PR\nretention:\n  maxAge: 30d\n")
+	results = checks()
+	for _, name := range []string{"config-schema", "policy-schema"} {
+		if check := results[name]; check.Status != CheckPass || !strings.Contains(check.Message, "matches the schema") {
+			t.Errorf("Expected %s to pass for a valid file, got %+v", name, check)
+		}
+	}
+
+	write("config.yaml", "edtor: insiders\nbackupKeep: -3\n")
+	write("policy.yaml", "webhookFormat: discord\n")
+	results = checks()
+	if check := results["config-schema"]; check.Status != CheckFail || !slices.Equal(check.Files, []string{cm.configPath}) ||
+		!strings.Contains(check.Message, "backupKeep: must be at least 0") || !strings.Contains(check.Message, "edtor: unknown property") {
+		t.Errorf("Expected config-schema to fail with both violations, got %+v", check)
+	}
+	if check := results["policy-schema"]; check.Status != CheckFail || !strings.Contains(check.Message, "webhookFormat: must be one of") {
+		t.Errorf("Expected policy-schema to fail, got %+v", check)
+	}
+
+	write("config.yaml", "editor: [unterminated\n")
+	if check := checks()["config-schema"]; check.Status != CheckFail || !strings.Contains(check.Message, "not valid YAML") {
+		t.Errorf("Expected config-schema to fail for malformed YAML, got %+v", check)
+	}
+}
@@ -0,0 +1,148 @@
+// Package manager provides integrity verification of installed ChatMate agents.
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// IntegrityReport is the result of verifying the installed chatmates against
+// the hashes recorded when ChatMate installed or adopted them.
+//
+// Fields:
+//   - CheckedAt: When the verification ran
+//   - PromptsDir: The prompts directory that was verified
+//   - Verified: Filenames of the chatmates whose content is unchanged
+//   - Modified: Chatmates whose content changed since it was recorded
+//   - Missing: Recorded chatmates that are no longer in the prompts directory
+//   - Unrecorded: Filenames of installed chatmates without a recorded hash,
+//     such as files created by hand; they cannot be verified
+type IntegrityReport struct {
+	CheckedAt  time.Time        `json:"checkedAt"`
+	PromptsDir string           `json:"promptsDir"`
+	Verified   []string         `json:"verified"`
+	Modified   []IntegrityIssue `json:"modified"`
+	Missing    []IntegrityIssue `json:"missing"`
+	Unrecorded []string         `json:"unrecorded"`
+}
+
+// IntegrityIssue is a chatmate that failed verification.
+//
+// Fields:
+//   - Name: Display name of the chatmate
+//   - Filename: The chatmate filename in the prompts directory
+//   - Origin: How the hash was recorded: "installed" or "adopted"
+//   - RecordedAt: When the hash was recorded
+//   - Expected: The recorded SHA-256, hex encoded
+//   - Actual: The SHA-256 of the current content; empty for missing files
+type IntegrityIssue struct {
+	Name       string    `json:"name"`
+	Filename   string    `json:"filename"`
+	Origin     string    `json:"origin"`
+	RecordedAt time.Time `json:"recordedAt"`
+	Expected   string    `json:"expected"`
+	Actual     string    `json:"actual,omitempty"`
+}
+
+// OK reports whether every recorded chatmate is present and unchanged.
+func (r *IntegrityReport) OK() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0
+}
+
+// Issues returns the modified and missing chatmates, in that order.
+func (r *IntegrityReport) Issues() []IntegrityIssue {
+	return append(slices.Clone(r.Modified), r.Missing...)
+}
+
+// VerifyIntegrity compares the installed chatmates with the SHA-256 hashes
+// recorded when they were installed (see Provenance) or adopted.
+//
+// ChatMate records a new hash whenever it changes a chatmate, so a mismatch
+// means the file was changed outside ChatMate: edited by hand, damaged by a
+// sync tool, or tampered with. The prompts directory is read again on every
+// call, so the method can be called periodically.
+//
+// Returns:
+//   - *IntegrityReport: The verification result
+//   - error: Prompts directory access or read error
+//
+// Example:
+//
+//	report, err := manager.VerifyIntegrity()
+//	if err != nil {
+//		return err
+//	}
+//	if !report.OK() {
+//		fmt.Printf("%d chatmates changed unexpectedly\n", len(report.Issues()))
+//	}
+func (cm *ChatMateManager) VerifyIntegrity() (*IntegrityReport, error) {
+	cm.inventory = nil
+	inventory, err := cm.Inventory()
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string]IntegrityIssue)
+	for _, record := range cm.provenanceLog().Files {
+		if record.PromptsDir == cm.PromptsDir {
+			recorded[record.Filename] = IntegrityIssue{Origin: OriginInstalled, RecordedAt: record.InstalledAt, Expected: record.Checksum}
+		}
+	}
+	if cm.registryPath != "" {
+		if registry, err := state.ReadRegistry(cm.registryPath); err == nil {
+			for _, file := range registry.In(cm.PromptsDir) {
+				if _, ok := recorded[file.Filename]; !ok {
+					recorded[file.Filename] = IntegrityIssue{Origin: file.Origin, RecordedAt: file.RecordedAt, Expected: file.Checksum}
+				}
+			}
+		}
+	}
+
+	report := &IntegrityReport{
+		CheckedAt:  time.Now().UTC(),
+		PromptsDir: cm.PromptsDir,
+		Verified:   []string{},
+		Modified:   []IntegrityIssue{},
+		Missing:    []IntegrityIssue{},
+		Unrecorded: []string{},
+	}
+	for _, filename := range inventory.Installed {
+		if _, ok := recorded[filename]; !ok {
+			report.Unrecorded = append(report.Unrecorded, filename)
+		}
+	}
+
+	filenames := make([]string, 0, len(recorded))
+	for filename := range recorded {
+		filenames = append(filenames, filename)
+	}
+	slices.Sort(filenames)
+	for _, filename := range filenames {
+		issue := recorded[filename]
+		issue.Name = cm.getDisplayName(filename)
+		issue.Filename = filename
+
+		path := filepath.Join(cm.PromptsDir, filename)
+		content, err := cm.FS.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			report.Missing = append(report.Missing, issue)
+		case err != nil:
+			return nil, fmt.Errorf("failed to read chatmate file %s: %w", path, err)
+		default:
+			issue.Actual = checksum(content)
+			if issue.Actual == issue.Expected {
+				report.Verified = append(report.Verified, filename)
+			} else {
+				report.Modified = append(report.Modified, issue)
+			}
+		}
+	}
+	return report, nil
+}
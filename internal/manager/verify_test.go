@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestVerifyIntegrity tests detecting chatmates changed outside ChatMate
+func TestVerifyIntegrity(t *testing.T) {
+	matesDir := t.TempDir()
+	promptsDir := t.TempDir()
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := os.WriteFile(filepath.Join(matesDir, name), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(promptsDir, "Mine.chatmode.md"), []byte("mine"), 0644); err != nil {
+		t.Fatalf("Failed to create user file: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: promptsDir,
+		provenancePath: filepath.Join(t.TempDir(), state.ProvenanceFilename)}
+	cm.installer = NewInstallerService(cm)
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := cm.Installer().InstallChatmate(name, false); err != nil {
+			t.Fatalf("InstallChatmate failed: %v", err)
+		}
+	}
+
+	report, err := cm.VerifyIntegrity()
+	if err != nil {
+		t.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+	if !report.OK() || len(report.Verified) != 3 || strings.Join(report.Unrecorded, ",") != "Mine.chatmode.md" {
+		t.Errorf("Expected the installed chatmates to verify, got %+v", report)
+	}
+
+	if err := os.WriteFile(filepath.Join(promptsDir, "A.chatmode.md"), []byte("tampered"), 0644); err != nil {
+		t.Fatalf("Failed to modify chatmate: %v", err)
+	}
+	if err := os.Remove(filepath.Join(promptsDir, "B.chatmode.md")); err != nil {
+		t.Fatalf("Failed to remove chatmate: %v", err)
+	}
+	report, err = cm.VerifyIntegrity()
+	if err != nil {
+		t.Fatalf("VerifyIntegrity failed: %v", err)
+	}
+	if report.OK() || len(report.Modified) != 1 || len(report.Missing) != 1 || strings.Join(report.Verified, ",") != "C.chatmode.md" {
+		t.Fatalf("Expected a modified and a missing chatmate, got %+v", report)
+	}
+	if modified := report.Modified[0]; modified.Filename != "A.chatmode.md" || modified.Actual != checksum([]byte("tampered")) ||
+		modified.Expected == modified.Actual || modified.Origin != OriginInstalled {
+		t.Errorf("Unexpected modified chatmate: %+v", modified)
+	}
+	if missing := report.Missing[0]; missing.Filename != "B.chatmode.md" || missing.Actual != "" {
+		t.Errorf("Unexpected missing chatmate: %+v", missing)
+	}
+	if len(report.Issues()) != 2 {
+		t.Errorf("Expected two issues, got %+v", report.Issues())
+	}
+
+	// Changes made by ChatMate record a new hash
+	if err := cm.Installer().InstallChatmate("A.chatmode.md", true); err != nil {
+		t.Fatalf("InstallChatmate failed: %v", err)
+	}
+	if report, err := cm.VerifyIntegrity(); err != nil || len(report.Modified) != 0 {
+		t.Errorf("Expected a reinstalled chatmate to verify, got %+v, %v", report, err)
+	}
+}
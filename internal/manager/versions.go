@@ -0,0 +1,139 @@
+// Package manager provides the previous versions of replaced chatmates.
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// VersionRestoreReport is the result of restoring a previous version of a
+// chatmate.
+//
+// Fields:
+//   - PromptsDir: The prompts directory the chatmate was restored in
+//   - Filename: The restored chatmate
+//   - Version: The version that was restored
+//   - Replaced: The version the replaced content was archived as; nil when
+//     the chatmate was not installed or already had the restored content
+type VersionRestoreReport struct {
+	PromptsDir string         `json:"promptsDir"`
+	Filename   string         `json:"filename"`
+	Version    int            `json:"version"`
+	Replaced   *state.Version `json:"replaced,omitempty"`
+}
+
+// archiveReplaced archives the installed content of a chatmate that is about
+// to be replaced with content, so it can be brought back with RestoreVersion.
+// Nothing is archived for a new chatmate, unchanged content, or in a dry
+// run; a failure to archive is reported but does not stop the replacement.
+func (cm *ChatMateManager) archiveReplaced(filename string, content []byte) {
+	if cm.versionsDir == "" || cm.dryRun != nil {
+		return
+	}
+	installed, err := cm.FS.ReadFile(filepath.Join(cm.PromptsDir, filename))
+	if err != nil || bytes.Equal(installed, content) {
+		return
+	}
+	if _, err := state.ArchiveVersion(cm.versionsDir, cm.PromptsDir, cm.canonicalFilename(filename), installed); err != nil {
+		cm.out().Warnf("Could not keep the previous version of %s: %v", filename, err)
+	}
+}
+
+// Versions lists the previous versions of a chatmate, newest first.
+//
+// A version is archived whenever ChatMate replaces the content of an
+// installed chatmate, e.g. with 'hire --force', 'update', or a restore. The
+// newest state.MaxVersions versions are kept per chatmate.
+//
+// Parameters:
+//   - name: The chatmate, given as display name or filename; chatmates that
+//     are no longer installed are found by filename
+//
+// Returns:
+//   - []state.Version: The versions; empty when none were archived
+//   - error: Versions directory read error
+//
+// Example:
+//
+// versions, err := manager.Versions("Solve Issue")
+//
+//	if err != nil {
+//	   return err
+//	}
+//
+//	for _, version := range versions {
+//	   fmt.Printf("%d %s\n", version.Number, version.Archived)
+//	}
+func (cm *ChatMateManager) Versions(name string) ([]state.Version, error) {
+	if cm.versionsDir == "" {
+		return []state.Version{}, nil
+	}
+	return state.ListVersions(cm.versionsDir, cm.PromptsDir, cm.versionedFilename(name))
+}
+
+// RestoreVersion writes a previous version of a chatmate back into the
+// prompts directory. The content it replaces is archived as a new version
+// first, so the restore can be undone the same way.
+//
+// Parameters:
+//   - name: The chatmate, given as display name or filename
+//   - number: The version to restore (see state.Version.Number)
+//
+// Returns:
+//   - *VersionRestoreReport: The restored version and the archived one
+//   - error: Unknown version, or read or write error
+//
+// Example:
+//
+// report, err := manager.RestoreVersion("Solve Issue", 2)
+//
+//	if err != nil {
+//	   return err
+//	}
+func (cm *ChatMateManager) RestoreVersion(name string, number int) (*VersionRestoreReport, error) {
+	if cm.versionsDir == "" {
+		return nil, fmt.Errorf("versions are not available: the state directory could not be found")
+	}
+	filename := cm.versionedFilename(name)
+	content, err := state.ReadVersion(cm.versionsDir, cm.PromptsDir, filename, number)
+	if err != nil {
+		return nil, errorf(ErrChatmateNotFound, "%w", err)
+	}
+	before, err := state.ListVersions(cm.versionsDir, cm.PromptsDir, filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := cm.ensurePromptsDir(); err != nil {
+		return nil, err
+	}
+
+	report := &VersionRestoreReport{PromptsDir: cm.PromptsDir, Filename: filename, Version: number}
+	defer cm.invalidateInventory()
+	if _, err := cm.writeChatmate(filename, content); err != nil {
+		return report, err
+	}
+
+	after, err := state.ListVersions(cm.versionsDir, cm.PromptsDir, filename)
+	if err == nil && len(after) > 0 && (len(before) == 0 || after[0].Number > before[0].Number) {
+		report.Replaced = &after[0]
+	}
+	return report, nil
+}
+
+// versionedFilename returns the filename of the chatmate name refers to: an
+// installed chatmate if one matches, the name itself otherwise.
+func (cm *ChatMateManager) versionedFilename(name string) string {
+	if installed, err := cm.GetInstalledChatmates(); err == nil {
+		if filename, ok := cm.findChatmate(name, installed); ok {
+			return filename
+		}
+	}
+	if !strings.HasSuffix(name, ".md") {
+		return name + ".chatmode.md"
+	}
+	return name
+}
@@ -0,0 +1,51 @@
+package manager
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestVersions tests archiving replaced chatmates and restoring a previous
+// version
+func TestVersions(t *testing.T) {
+	cm := &ChatMateManager{MatesDir: t.TempDir(), PromptsDir: t.TempDir(), Output: io.Discard, ErrOutput: io.Discard,
+		versionsDir: t.TempDir()}
+	filename := "Mine.chatmode.md"
+
+	for _, description := range []string{"First", "Second", "Second"} {
+		if _, err := cm.writeChatmate(filename, []byte("---\ndescription: "+description+"\n---\n")); err != nil {
+			t.Fatalf("writeChatmate failed: %v", err)
+		}
+	}
+
+	// Only the second write replaced different content
+	versions, err := cm.Versions("Mine")
+	if err != nil || len(versions) != 1 || versions[0].Number != 1 || versions[0].Filename != filename {
+		t.Fatalf("Expected one version of Mine, got %+v, %v", versions, err)
+	}
+
+	report, err := cm.RestoreVersion("Mine", 1)
+	if err != nil {
+		t.Fatalf("RestoreVersion failed: %v", err)
+	}
+	if report.Replaced == nil || report.Replaced.Number != 2 {
+		t.Errorf("Expected the replaced content to be archived as version 2, got %+v", report)
+	}
+	if content, err := os.ReadFile(filepath.Join(cm.PromptsDir, filename)); err != nil || !strings.Contains(string(content), "First") {
+		t.Errorf("Expected the first version to be installed, got %q, %v", content, err)
+	}
+	if content, err := os.ReadFile(report.Replaced.Path); err != nil || !strings.Contains(string(content), "Second") {
+		t.Errorf("Expected the second version to be archived, got %q, %v", content, err)
+	}
+
+	if _, err := cm.RestoreVersion("Mine", 5); !errors.Is(err, ErrChatmateNotFound) {
+		t.Errorf("Expected an unknown version to be reported, got %v", err)
+	}
+	if versions, err := cm.Versions("Missing"); err != nil || len(versions) != 0 {
+		t.Errorf("Expected no versions of an unknown chatmate, got %+v, %v", versions, err)
+	}
+}
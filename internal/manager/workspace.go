@@ -0,0 +1,68 @@
+// Package manager provides workspace-level installation of ChatMate agents.
+package manager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WorkspacePromptsDir is the prompts directory of a workspace, relative to
+// its root. VS Code Copilot Chat reads chatmodes from it next to those in
+// the user prompts directory, so chatmates installed there are versioned
+// with the repository and shared by everyone who opens it.
+const WorkspacePromptsDir = ".github/prompts"
+
+// UseWorkspace manages the chatmates of a workspace in its .github/prompts
+// directory instead of the user prompts directory, for the --workspace
+// option of hire, list, and uninstall. It turns off headless mode.
+//
+// The workspace is the repository containing dir: the closest directory,
+// starting at dir, with a .git directory or file. Outside a repository dir
+// itself is the workspace.
+//
+// Parameters:
+//   - dir: A directory inside the workspace; the working directory when empty
+//
+// Returns:
+//   - error: Error if the working directory cannot be determined
+//
+// Example:
+//
+//	if err := manager.UseWorkspace(""); err != nil {
+//		return err
+//	}
+//	fmt.Println(manager.PromptsDir) // <repository>/.github/prompts
+func (cm *ChatMateManager) UseWorkspace(dir string) error {
+	if dir == "" {
+		workDir, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get working directory: %w", err)
+		}
+		dir = workDir
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve workspace directory: %w", err)
+	}
+
+	cm.Workspace = workspaceRoot(dir)
+	cm.Headless = false
+	cm.setPromptsDir(filepath.Join(cm.Workspace, filepath.FromSlash(WorkspacePromptsDir)))
+	return nil
+}
+
+// workspaceRoot returns the root of the repository containing dir, or dir
+// when it is not in a repository.
+func workspaceRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
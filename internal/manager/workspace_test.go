@@ -0,0 +1,54 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestChatMateManager_Workspace tests managing the chatmates of a repository
+// in its .github/prompts directory
+func TestChatMateManager_Workspace(t *testing.T) {
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	subDir := filepath.Join(repo, "src", "pkg")
+	matesDir := filepath.Join(root, "mates")
+	for _, dir := range []string{filepath.Join(repo, ".git"), subDir, matesDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(matesDir, "A.chatmode.md"), []byte("---\ndescription: test\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to write chatmate: %v", err)
+	}
+
+	cm := &ChatMateManager{MatesDir: matesDir, PromptsDir: filepath.Join(root, "user", "prompts"), Headless: true}
+	cm.installer = NewInstallerService(cm)
+	if err := cm.UseWorkspace(subDir); err != nil {
+		t.Fatalf("UseWorkspace failed: %v", err)
+	}
+	promptsDir := filepath.Join(repo, ".github", "prompts")
+	if cm.Workspace != repo || cm.PromptsDir != promptsDir || cm.Headless {
+		t.Fatalf("Expected the repository prompts directory, got %s in %s (headless %v)", cm.PromptsDir, cm.Workspace, cm.Headless)
+	}
+	if label := cm.PromptsDirLabel(); label != "Workspace Prompts Directory" {
+		t.Errorf("Unexpected label %q", label)
+	}
+	if settings := cm.SettingsPath(); settings != filepath.Join(repo, ".vscode", "settings.json") {
+		t.Errorf("Expected the workspace settings, got %s", settings)
+	}
+
+	if report, err := cm.Installer().InstallAll(context.Background(), false); err != nil || report.Installed() != 1 {
+		t.Fatalf("Expected the chatmate to be installed, got %+v, %v", report, err)
+	}
+	if _, err := os.Stat(filepath.Join(promptsDir, "A.chatmode.md")); err != nil {
+		t.Errorf("Expected the chatmate in the workspace: %v", err)
+	}
+
+	// Outside a repository the directory itself is the workspace
+	outside := filepath.Join(root, "scratch")
+	if err := cm.UseWorkspace(outside); err != nil || cm.PromptsDir != filepath.Join(outside, ".github", "prompts") {
+		t.Errorf("Expected the directory itself as the workspace, got %s, %v", cm.PromptsDir, err)
+	}
+}
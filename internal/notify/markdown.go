@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Chat formats a Report can be rendered in.
+const (
+	// FormatJSON is the Report itself, for webhooks of custom services
+	FormatJSON = "json"
+	// FormatSlack is Slack mrkdwn, for Slack incoming webhooks
+	FormatSlack = "slack"
+	// FormatTeams is Markdown as rendered by Microsoft Teams incoming webhooks
+	FormatTeams = "teams"
+)
+
+// Formats lists the formats accepted by Post, in documentation order.
+var Formats = []string{FormatJSON, FormatSlack, FormatTeams}
+
+// Markdown renders report as a compact message for posting to a chat channel,
+// such as an announcement that the team's chatmates were updated.
+//
+// Parameters:
+//   - report: The report to render
+//   - format: FormatSlack or FormatTeams; they differ in bold and code syntax
+//
+// Returns:
+//   - string: The message, a handful of lines long
+//
+// Example:
+//
+// message := notify.Markdown(report, notify.FormatSlack)
+func Markdown(report *Report, format string) string {
+	bold := func(s string) string { return "**" + s + "**" }
+	if format == FormatSlack {
+		bold = func(s string) string { return "*" + s + "*" }
+	}
+
+	var b strings.Builder
+	outcome := "✅ succeeded"
+	if !report.Success {
+		outcome = "❌ failed"
+	}
+	fmt.Fprintf(&b, "%s on `%s` %s\n", bold("ChatMate "+report.Operation), report.Hostname, outcome)
+
+	if len(report.Changes) == 0 && len(report.Failures) == 0 {
+		b.WriteString("No chatmates changed\n")
+	}
+	actions := make(map[string][]string)
+	var order []string
+	for _, change := range report.Changes {
+		if _, seen := actions[change.Action]; !seen {
+			order = append(order, change.Action)
+		}
+		actions[change.Action] = append(actions[change.Action], change.Chatmate)
+	}
+	for _, action := range order {
+		names := actions[action]
+		fmt.Fprintf(&b, "%s (%d): %s\n", bold(strings.ToUpper(action[:1])+action[1:]), len(names), strings.Join(names, ", "))
+	}
+	if len(report.Failures) > 0 {
+		failures := make([]string, len(report.Failures))
+		for i, failure := range report.Failures {
+			failures[i] = fmt.Sprintf("%s (%s)", failure.Chatmate, failure.Error)
+		}
+		fmt.Fprintf(&b, "%s (%d): %s\n", bold("Failed"), len(failures), strings.Join(failures, ", "))
+	} else if report.Error != "" {
+		fmt.Fprintf(&b, "%s: %s\n", bold("Error"), report.Error)
+	}
+
+	fmt.Fprintf(&b, "_ChatMate %s · %s_\n", report.Version, report.Timestamp.UTC().Format("2006-01-02 15:04 UTC"))
+	return b.String()
+}
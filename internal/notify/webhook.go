@@ -0,0 +1,159 @@
+// Package notify reports the outcome of ChatMate operations to other systems.
+//
+// Platform teams rolling out chatmates across an organization configure a
+// webhook in the team policy (see package policy). After 'chatmate apply' and
+// 'chatmate sync', ChatMate POSTs a JSON Report to it, so adoption and
+// failures on every machine can be observed in one place.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Timeout bounds a webhook delivery, so an unreachable endpoint delays a
+// command by at most this long.
+const Timeout = 10 * time.Second
+
+// Report is the JSON document sent to a webhook.
+//
+// Fields:
+//   - Operation: The command that ran (e.g., "apply")
+//   - Hostname: The machine the command ran on
+//   - Version: The ChatMate version
+//   - PromptsDir: The prompts directory that was changed
+//   - Success: Whether the operation succeeded
+//   - Error: Why the operation failed, if it did
+//   - Changes: The chatmates that were changed
+//   - Failures: The chatmates that could not be changed
+//   - Timestamp: When the operation finished
+type Report struct {
+	Operation  string    `json:"operation"`
+	Hostname   string    `json:"hostname"`
+	Version    string    `json:"chatmateVersion"`
+	PromptsDir string    `json:"promptsDir"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	Changes    []Change  `json:"changes"`
+	Failures   []Failure `json:"failures"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Change is a chatmate changed by an operation.
+//
+// Fields:
+//   - Chatmate: Display name of the chatmate
+//   - Action: What happened (e.g., "installed")
+type Change struct {
+	Chatmate string `json:"chatmate"`
+	Action   string `json:"action"`
+}
+
+// Failure is a chatmate an operation could not change.
+//
+// Fields:
+//   - Chatmate: Display name of the chatmate
+//   - Error: Why it failed
+type Failure struct {
+	Chatmate string `json:"chatmate"`
+	Error    string `json:"error"`
+}
+
+// NewReport starts a report for operation on this machine.
+//
+// Parameters:
+//   - operation: The command that ran (e.g., "apply")
+//   - opErr: The error the operation returned, nil on success
+//
+// Returns:
+//   - *Report: A report without changes or failures yet
+func NewReport(operation string, opErr error) *Report {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	report := &Report{
+		Operation: operation,
+		Hostname:  hostname,
+		Success:   opErr == nil,
+		Changes:   []Change{},
+		Failures:  []Failure{},
+		Timestamp: time.Now().UTC(),
+	}
+	if opErr != nil {
+		report.Error = opErr.Error()
+	}
+	return report
+}
+
+// Post sends report to the webhook at url.
+//
+// With FormatJSON (or an empty format) the Report is the request body. With
+// FormatSlack and FormatTeams the body is {"text": ...} holding the Markdown
+// rendering, which the incoming webhooks of both chat services post as a
+// message. Delivery is attempted once, within Timeout; a response status
+// outside 2xx is an error.
+//
+// Parameters:
+//   - ctx: Cancels the delivery (e.g., the command's --timeout)
+//   - url: The webhook URL
+//   - format: One of Formats; empty means FormatJSON
+//   - report: The report to send
+//
+// Returns:
+//   - error: Unknown format, encoding, connection, or response status error
+//
+// Example:
+//
+// err := notify.Post(ctx, teamPolicy.Webhook, teamPolicy.WebhookFormat, report)
+//
+//	if err != nil {
+//	   output.Warnf("Could not notify the webhook: %v", err)
+//	}
+func Post(ctx context.Context, url, format string, report *Report) error {
+	var payload any
+	switch format {
+	case "", FormatJSON:
+		payload = report
+	case FormatSlack, FormatTeams:
+		payload = struct {
+			Text string `json:"text"`
+		}{Markdown(report, format)}
+	default:
+		return fmt.Errorf("unknown webhook format %q", format)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "chatmate/"+report.Version)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to reach webhook: %w", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestPost tests delivering a report to a webhook
+func TestPost(t *testing.T) {
+	var received Report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request: %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode report: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	report := NewReport("apply", errors.New("1 required chatmate(s) not available"))
+	report.Version = "1.2.3"
+	report.Changes = append(report.Changes, Change{Chatmate: "Testing", Action: "installed"})
+	report.Failures = append(report.Failures, Failure{Chatmate: "Team Only", Error: "not available"})
+
+	if err := Post(context.Background(), server.URL, FormatJSON, report); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if received.Operation != "apply" || received.Hostname == "" || received.Success || received.Error == "" ||
+		len(received.Changes) != 1 || len(received.Failures) != 1 || received.Version != "1.2.3" {
+		t.Errorf("Unexpected report received: %+v", received)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := Post(context.Background(), failing.URL, "", NewReport("apply", nil)); err == nil {
+		t.Error("Expected an error for a failing webhook")
+	}
+}
+
+// TestMarkdown tests rendering reports for chat channels
+func TestMarkdown(t *testing.T) {
+	report := NewReport("apply", nil)
+	report.Hostname = "dev-1"
+	report.Version = "1.2.3"
+	report.Changes = []Change{{Chatmate: "Testing", Action: "installed"}, {Chatmate: "Review PR", Action: "installed"}}
+
+	slack := Markdown(report, FormatSlack)
+	if !strings.HasPrefix(slack, "*ChatMate apply* on `dev-1` ✅ succeeded\n") || !strings.Contains(slack, "*Installed* (2): Testing, Review PR\n") {
+		t.Errorf("Unexpected Slack message:\n%s", slack)
+	}
+
+	report.Success = false
+	report.Failures = []Failure{{Chatmate: "Team Only", Error: "not available to install"}}
+	teams := Markdown(report, FormatTeams)
+	if !strings.Contains(teams, "**ChatMate apply**") || !strings.Contains(teams, "❌ failed") ||
+		!strings.Contains(teams, "**Failed** (1): Team Only (not available to install)") {
+		t.Errorf("Unexpected Teams message:\n%s", teams)
+	}
+
+	var received struct {
+		Text string `json:"text"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+	if err := Post(context.Background(), server.URL, FormatSlack, report); err != nil || received.Text != Markdown(report, FormatSlack) {
+		t.Errorf("Expected the Slack message as text, got %q, %v", received.Text, err)
+	}
+	if err := Post(context.Background(), server.URL, "xml", report); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,296 @@
+package output
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/term"
+)
+
+var (
+	prompter Prompter = InputPrompter{}
+	input    *bufio.Reader
+
+	// Reader over os.Stdin, rebuilt when os.Stdin is replaced
+	stdinReader *bufio.Reader
+	stdinFile   *os.File
+)
+
+// maxConfirmAttempts is how often an unrecognized answer is asked again on a terminal.
+const maxConfirmAttempts = 3
+
+// confirmWords are the answers accepted in addition to English, by language
+// code of the user's locale.
+var confirmWords = map[string]struct{ yes, no []string }{
+	"de": {yes: []string{"j", "ja"}, no: []string{"nein"}},
+	"es": {yes: []string{"s", "si", "sí"}},
+	"fr": {yes: []string{"o", "oui"}, no: []string{"non"}},
+	"it": {yes: []string{"s", "si", "sì"}},
+	"nl": {yes: []string{"j", "ja"}, no: []string{"nee"}},
+	"pt": {yes: []string{"s", "sim"}, no: []string{"nao", "não"}},
+}
+
+// AssumeYesEnv is the environment variable that answers every confirmation
+// yes, like the global --yes flag: "1" or "true" enables it.
+const AssumeYesEnv = "CHATMATE_ASSUME_YES"
+
+// Prompter answers the yes/no questions asked by Confirm and ConfirmDefault.
+type Prompter interface {
+	// Ask writes question with its hint ("(y/N)" or "[Y/n]") to p and returns
+	// the answer; defaultYes is the answer for an empty response
+	Ask(p Printer, question, hint string, defaultYes bool) bool
+}
+
+// AutoApprove is a Prompter that answers every question yes without reading
+// input, printing the question with the answer so logs show what was approved.
+type AutoApprove struct{}
+
+// Ask implements Prompter.
+func (AutoApprove) Ask(p Printer, question, hint string, defaultYes bool) bool {
+	p.Printf("%s %s: yes (--yes)\n", question, hint)
+	return true
+}
+
+// InputPrompter is a Prompter that reads answers line by line from the input
+// set with SetInput, or from standard input.
+//
+// Fields:
+//   - Unattended: Answer yes instead of no when standard input runs out,
+//     because nobody can type an answer (e.g., in CI); piped answers are
+//     still read first
+type InputPrompter struct {
+	Unattended bool
+}
+
+// SetPrompter replaces the Prompter that answers confirmations.
+//
+// Passing nil restores the default, an InputPrompter that treats end of input
+// as no.
+func SetPrompter(p Prompter) {
+	mu.Lock()
+	defer mu.Unlock()
+	if p == nil {
+		p = InputPrompter{}
+	}
+	prompter = p
+}
+
+// SelectPrompter returns the Prompter for a command run.
+//
+// AutoApprove is selected with the global --yes flag (assumeYes) or when
+// CHATMATE_ASSUME_YES is "1" or "true". Otherwise answers are read from
+// standard input, unattended when it is not a terminal, so scripts and CI
+// jobs never wait for an answer nobody can give.
+//
+// Example:
+//
+//	output.SetPrompter(output.SelectPrompter(config.AssumeYes))
+func SelectPrompter(assumeYes bool) Prompter {
+	switch strings.ToLower(os.Getenv(AssumeYesEnv)) {
+	case "1", "true", "yes":
+		assumeYes = true
+	}
+	if assumeYes {
+		return AutoApprove{}
+	}
+	return InputPrompter{Unattended: !stdinIsTerminal()}
+}
+
+// SetAssumeYes makes every confirmation answer yes without asking, as
+// requested with the global --yes flag; false restores the default Prompter.
+func SetAssumeYes(enabled bool) {
+	if enabled {
+		SetPrompter(AutoApprove{})
+	} else {
+		SetPrompter(nil)
+	}
+}
+
+// AssumeYes reports whether confirmations are answered yes without anyone
+// being asked: with AutoApprove, or unattended because standard input is
+// not a terminal.
+func AssumeYes() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	switch p := prompter.(type) {
+	case AutoApprove:
+		return true
+	case InputPrompter:
+		return p.Unattended
+	}
+	return false
+}
+
+// SetInput overrides the reader confirmations are read from.
+//
+// Passing nil restores os.Stdin. Answers are read line by line from a single
+// buffered reader, so piped input can answer several questions in a row.
+func SetInput(r io.Reader) {
+	mu.Lock()
+	defer mu.Unlock()
+	input = nil
+	if r != nil {
+		input = bufio.NewReader(r)
+	}
+}
+
+// Confirm asks a yes/no question that defaults to no.
+//
+// The question is written with a "(y/N)" hint and a full line is read as the
+// answer. Answers are matched as whole words, ignoring case and surrounding
+// punctuation, in English and in the language of the user's locale (e.g.,
+// "ja" with a German locale). An empty answer, end of input (unless
+// unattended, see SelectPrompter), and any answer that is not a recognized
+// yes are treated as no; on a terminal an
+// unrecognized answer is asked again first. With SetAssumeYes the question
+// is answered yes without reading input.
+//
+// Example:
+//
+//	if !output.Confirm("\nDo you want to remove these files?") {
+//		output.Println("❌ Cleanup cancelled by user")
+//		return nil
+//	}
+//
+// Parameters:
+//   - format: The question, formatted like fmt.Sprintf, without the hint
+//   - a: Format arguments
+//
+// Returns:
+//   - bool: true if the user answered yes
+func Confirm(format string, a ...any) bool {
+	return Printer{}.ConfirmDefault(false, format, a...)
+}
+
+// Confirm asks a yes/no question that defaults to no, writing the question
+// to the printer's Out. See the package-level Confirm.
+func (p Printer) Confirm(format string, a ...any) bool {
+	return p.ConfirmDefault(false, format, a...)
+}
+
+// ConfirmDefault asks a yes/no question with the given answer for an empty
+// response, such as "[Y/n]" prompts that only pause a walkthrough.
+//
+// End of input is treated as no even when the default is yes, since nobody
+// is there to confirm; an unattended InputPrompter (standard input is not a
+// terminal, see SelectPrompter) answers yes instead. See Confirm for how
+// answers are parsed.
+func ConfirmDefault(defaultYes bool, format string, a ...any) bool {
+	return Printer{}.ConfirmDefault(defaultYes, format, a...)
+}
+
+// ConfirmDefault asks a yes/no question with the given answer for an empty
+// response, writing the question to the printer's Out. See the package-level
+// ConfirmDefault.
+func (p Printer) ConfirmDefault(defaultYes bool, format string, a ...any) bool {
+	question := fmt.Sprintf(format, a...)
+	hint := "(y/N)"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+
+	mu.RLock()
+	current := prompter
+	mu.RUnlock()
+	return current.Ask(p, question, hint, defaultYes)
+}
+
+// Ask implements Prompter.
+func (ip InputPrompter) Ask(p Printer, question, hint string, defaultYes bool) bool {
+	reader, terminal := confirmInput()
+	for attempt := 1; ; attempt++ {
+		p.Promptf("%s %s: ", question, hint)
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			p.Promptf("\n")
+			if ip.Unattended {
+				p.Printf("No answer received; assuming yes because standard input is not a terminal.\n")
+				return true
+			}
+			// Nothing left to read: never confirm on the user's behalf
+			p.Printf("No answer received; assuming no. Use --yes to confirm without a prompt.\n")
+			return false
+		}
+
+		answer := normalizeAnswer(line)
+		if answer == "" {
+			return defaultYes
+		}
+		if isYes(answer) {
+			return true
+		}
+		if isNo(answer) || !terminal || attempt == maxConfirmAttempts {
+			return false
+		}
+		p.Promptf("Please answer yes or no.\n")
+	}
+}
+
+// confirmInput returns the reader for answers and whether it is a terminal,
+// where unrecognized answers can be asked again.
+func confirmInput() (*bufio.Reader, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if input != nil {
+		return input, false
+	}
+
+	if stdinFile != os.Stdin {
+		stdinFile = os.Stdin
+		stdinReader = bufio.NewReader(os.Stdin)
+	}
+	return stdinReader, stdinIsTerminal()
+}
+
+// stdinIsTerminal reports whether standard input is a terminal rather than a
+// pipe, a file, a device such as /dev/null, or closed.
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// normalizeAnswer lowercases an answer and trims whitespace and punctuation,
+// so "Yes!" and " y " are read as a yes.
+func normalizeAnswer(line string) string {
+	return strings.ToLower(strings.TrimFunc(line, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+}
+
+// isYes reports whether an answer means yes.
+func isYes(answer string) bool {
+	if answer == "y" || answer == "yes" {
+		return true
+	}
+	return containsWord(confirmWords[localeLanguage()].yes, answer)
+}
+
+// isNo reports whether an answer means no.
+func isNo(answer string) bool {
+	if answer == "n" || answer == "no" {
+		return true
+	}
+	return containsWord(confirmWords[localeLanguage()].no, answer)
+}
+
+// containsWord reports whether words contains word.
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+// localeLanguage returns the language code of the user's message locale
+// (e.g., "de" for "de_DE.UTF-8"), or an empty string if none is set.
+func localeLanguage() string {
+	language, _ := parseLocale(localeValue("LC_ALL", "LC_MESSAGES", "LANG"))
+	return language
+}
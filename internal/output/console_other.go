@@ -0,0 +1,8 @@
+//go:build !windows
+
+package output
+
+// consoleSupportsUnicode reports whether the terminal can display UTF-8 output.
+func consoleSupportsUnicode() bool {
+	return localeSupportsUnicode()
+}
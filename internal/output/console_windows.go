@@ -0,0 +1,32 @@
+//go:build windows
+
+package output
+
+import (
+	"os"
+	"syscall"
+)
+
+// utf8CodePage is the Windows code page identifier for UTF-8.
+const utf8CodePage = 65001
+
+var procGetConsoleOutputCP = syscall.NewLazyDLL("kernel32.dll").NewProc("GetConsoleOutputCP")
+
+// consoleSupportsUnicode reports whether the Windows console can display UTF-8 output.
+//
+// Modern terminals (Windows Terminal, the VS Code integrated terminal) render
+// emoji regardless of the active code page. Classic consoles only do so when
+// the output code page is UTF-8; otherwise ASCII markers are used to avoid
+// mojibake such as "‚úÖ".
+func consoleSupportsUnicode() bool {
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != "" {
+		return true
+	}
+
+	if err := procGetConsoleOutputCP.Find(); err != nil {
+		return false
+	}
+
+	codePage, _, _ := procGetConsoleOutputCP.Call()
+	return codePage == utf8CodePage
+}
@@ -0,0 +1,162 @@
+package output
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocaleEnv overrides the locale used to format dates and numbers in reports
+// (e.g., "de_DE"). The value "iso" selects ISO-8601 dates and ungrouped
+// numbers regardless of the system locale.
+const LocaleEnv = "CHATMATE_LOCALE"
+
+// locale is the locale set by SetLocale; empty when it is not set.
+var (
+	localeMu sync.RWMutex
+	locale   string
+)
+
+// SetLocale sets the locale of the configuration file (e.g., "de_DE" or
+// "iso"). It is used when LocaleEnv is not set, before the system locale;
+// an empty value leaves the choice to the system locale.
+func SetLocale(value string) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	locale = strings.TrimSpace(value)
+}
+
+// localeFormat describes how dates and numbers are written in a locale.
+type localeFormat struct {
+	date     string
+	dateTime string
+	// Thousands separator; empty disables grouping
+	group string
+}
+
+// isoFormat is used for unset, "C", and "POSIX" locales and with LocaleEnv=iso.
+var isoFormat = localeFormat{date: "2006-01-02", dateTime: "2006-01-02 15:04:05"}
+
+// localeFormats maps "language_REGION" or "language" to its format; the
+// region-specific entry wins.
+var localeFormats = map[string]localeFormat{
+	"en":    {date: "01/02/2006", dateTime: "01/02/2006 3:04:05 PM", group: ","},
+	"en_AU": {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: ","},
+	"en_GB": {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: ","},
+	"en_IE": {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: ","},
+	"en_NZ": {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: ","},
+	"de":    {date: "02.01.2006", dateTime: "02.01.2006 15:04:05", group: "."},
+	"es":    {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: "."},
+	"fr":    {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: " "},
+	"it":    {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: "."},
+	"ja":    {date: "2006/01/02", dateTime: "2006/01/02 15:04:05", group: ","},
+	"nl":    {date: "02-01-2006", dateTime: "02-01-2006 15:04:05", group: "."},
+	"pt":    {date: "02/01/2006", dateTime: "02/01/2006 15:04:05", group: "."},
+	"zh":    {date: "2006/01/02", dateTime: "2006/01/02 15:04:05", group: ","},
+}
+
+// FormatDate formats the date of t in local time for the user's locale.
+//
+// Human-readable reports should use it instead of ad-hoc layouts; JSON
+// output keeps time.Time values, which encode as ISO-8601.
+//
+// Example:
+//
+//	output.Printf("adopted %s\n", output.FormatDate(file.RecordedAt)) // "adopted 31.12.2025" with de_DE
+func FormatDate(t time.Time) string {
+	return t.Local().Format(currentFormat("LC_TIME").date)
+}
+
+// FormatDateTime formats the date and time of t in local time for the
+// user's locale.
+func FormatDateTime(t time.Time) string {
+	return t.Local().Format(currentFormat("LC_TIME").dateTime)
+}
+
+// FormatNumber formats n with the thousands separator of the user's locale
+// (e.g., "12,345" in English and "12.345" in German).
+func FormatNumber(n int) string {
+	digits := strconv.Itoa(n)
+	group := currentFormat("LC_NUMERIC").group
+	if group == "" {
+		return digits
+	}
+
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(digit)
+	}
+	return sign + b.String()
+}
+
+// FormatSize formats a number of bytes with a binary unit, e.g. "12.5 MiB";
+// sizes below 1 KiB are written in bytes.
+func FormatSize(bytes int64) string {
+	if bytes < 1024 {
+		return FormatNumber(int(bytes)) + " B"
+	}
+	value := float64(bytes) / 1024
+	for _, unit := range []string{"KiB", "MiB", "GiB"} {
+		if value < 1024 || unit == "GiB" {
+			return strconv.FormatFloat(value, 'f', 1, 64) + " " + unit
+		}
+		value /= 1024
+	}
+	return ""
+}
+
+// currentFormat returns the format of the locale selected by LocaleEnv, or
+// else by SetLocale, or else by LC_ALL, the given category variable, and
+// LANG.
+func currentFormat(category string) localeFormat {
+	value := os.Getenv(LocaleEnv)
+	if value == "" {
+		localeMu.RLock()
+		value = locale
+		localeMu.RUnlock()
+	}
+	if strings.EqualFold(strings.TrimSpace(value), "iso") {
+		return isoFormat
+	}
+	if value == "" {
+		value = localeValue("LC_ALL", category, "LANG")
+	}
+
+	language, region := parseLocale(value)
+	if format, ok := localeFormats[language+"_"+region]; ok {
+		return format
+	}
+	if format, ok := localeFormats[language]; ok {
+		return format
+	}
+	return isoFormat
+}
+
+// localeValue returns the first non-empty of the given environment variables.
+func localeValue(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseLocale splits a POSIX locale such as "de_DE.UTF-8@euro" into its
+// lowercase language ("de") and uppercase region ("DE").
+func parseLocale(value string) (language, region string) {
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	language, region, _ = strings.Cut(strings.ReplaceAll(value, "-", "_"), "_")
+	return strings.ToLower(language), strings.ToUpper(region)
+}
@@ -0,0 +1,299 @@
+// Package output provides the presentation layer shared by ChatMate commands and services.
+//
+// All informational output should go through this package instead of calling
+// fmt.Printf directly. This keeps verbosity handling in one place so that
+// global flags such as --quiet and --verbose apply consistently across every
+// command, which matters when ChatMate runs unattended (e.g., from cron).
+//
+// Verbosity Levels:
+//   - LevelQuiet: Only errors are written (to stderr)
+//   - LevelNormal: Regular informational output (default)
+//   - LevelVerbose: Additional diagnostic output
+//
+// Usage Example:
+//
+//	output.SetLevel(output.LevelQuiet)
+//	output.Printf("Installing %d chatmates...\n", count) // suppressed
+//	output.Warnf("found %d orphaned files", orphaned)    // written to stderr
+//	output.Errorf("failed to install: %v\n", err)       // always written
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Level controls how much informational output is written.
+type Level int
+
+const (
+	// LevelQuiet suppresses all informational output; only errors are written.
+	LevelQuiet Level = iota
+	// LevelNormal writes regular informational output.
+	LevelNormal
+	// LevelVerbose additionally writes diagnostic output.
+	LevelVerbose
+)
+
+var (
+	mu       sync.RWMutex
+	level    = LevelNormal
+	stdout   io.Writer
+	stderr   io.Writer
+	warnings []string
+)
+
+// SetLevel sets the global verbosity level.
+func SetLevel(l Level) {
+	mu.Lock()
+	defer mu.Unlock()
+	level = l
+}
+
+// GetLevel returns the current global verbosity level.
+func GetLevel() Level {
+	mu.RLock()
+	defer mu.RUnlock()
+	return level
+}
+
+// IsQuiet reports whether informational output is suppressed.
+func IsQuiet() bool {
+	return GetLevel() == LevelQuiet
+}
+
+// IsVerbose reports whether diagnostic output is enabled.
+func IsVerbose() bool {
+	return GetLevel() == LevelVerbose
+}
+
+// SetWriters overrides the writers used for standard and error output.
+//
+// Passing nil for either writer restores the default (os.Stdout or os.Stderr),
+// which is resolved at write time so that callers redirecting os.Stdout keep
+// working as expected.
+func SetWriters(out, errOut io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	stdout = out
+	stderr = errOut
+}
+
+// Stdout returns the writer used for informational output.
+func Stdout() io.Writer {
+	mu.RLock()
+	defer mu.RUnlock()
+	if stdout != nil {
+		return stdout
+	}
+	return os.Stdout
+}
+
+// Stderr returns the writer used for error output.
+func Stderr() io.Writer {
+	mu.RLock()
+	defer mu.RUnlock()
+	if stderr != nil {
+		return stderr
+	}
+	return os.Stderr
+}
+
+// Printer writes output to its own writers instead of the global ones, with
+// the same verbosity handling, rendering, and warning recording as the
+// package-level functions. Services that can be embedded in other programs
+// print through a Printer so those programs can capture or discard it.
+//
+// A nil writer falls back to Stdout or Stderr at write time, so the zero
+// Printer writes where the package-level functions do.
+//
+// Fields:
+//   - Out: Receives informational output, prompts, and diagnostics
+//   - Err: Receives warnings, errors, and traces
+type Printer struct {
+	Out io.Writer
+	Err io.Writer
+}
+
+// out returns the writer for informational output.
+func (p Printer) out() io.Writer {
+	if p.Out != nil {
+		return p.Out
+	}
+	return Stdout()
+}
+
+// err returns the writer for error output.
+func (p Printer) err() io.Writer {
+	if p.Err != nil {
+		return p.Err
+	}
+	return Stderr()
+}
+
+// Printf writes formatted informational output unless quiet mode is enabled.
+func Printf(format string, a ...any) {
+	Printer{}.Printf(format, a...)
+}
+
+// Printf writes formatted informational output unless quiet mode is enabled.
+func (p Printer) Printf(format string, a ...any) {
+	if IsQuiet() {
+		return
+	}
+	fmt.Fprint(p.out(), render(fmt.Sprintf(format, a...)))
+}
+
+// Println writes an informational line unless quiet mode is enabled.
+func Println(a ...any) {
+	Printer{}.Println(a...)
+}
+
+// Println writes an informational line unless quiet mode is enabled.
+func (p Printer) Println(a ...any) {
+	if IsQuiet() {
+		return
+	}
+	fmt.Fprint(p.out(), render(fmt.Sprintln(a...)))
+}
+
+// Print writes informational output unless quiet mode is enabled.
+func Print(a ...any) {
+	Printer{}.Print(a...)
+}
+
+// Print writes informational output unless quiet mode is enabled.
+func (p Printer) Print(a ...any) {
+	if IsQuiet() {
+		return
+	}
+	fmt.Fprint(p.out(), render(fmt.Sprint(a...)))
+}
+
+// PrintTable writes rows as left-aligned columns separated by two spaces,
+// unless quiet mode is enabled. The first row is usually a header.
+func PrintTable(rows [][]string) {
+	Printer{}.PrintTable(rows)
+}
+
+// PrintTable writes rows as left-aligned columns separated by two spaces,
+// unless quiet mode is enabled. The first row is usually a header.
+func (p Printer) PrintTable(rows [][]string) {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+				break
+			}
+			line.WriteString(cell)
+			line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		p.Println(line.String())
+	}
+}
+
+// Debugf writes formatted diagnostic output when verbose mode is enabled.
+func Debugf(format string, a ...any) {
+	Printer{}.Debugf(format, a...)
+}
+
+// Debugf writes formatted diagnostic output when verbose mode is enabled.
+func (p Printer) Debugf(format string, a ...any) {
+	if !IsVerbose() {
+		return
+	}
+	fmt.Fprint(p.out(), render(fmt.Sprintf(format, a...)))
+}
+
+// Tracef writes formatted diagnostic output to stderr when verbose mode is
+// enabled, for diagnostics that must not mix with machine-readable results on
+// stdout (e.g., timing of a command run with --json).
+func Tracef(format string, a ...any) {
+	Printer{}.Tracef(format, a...)
+}
+
+// Tracef writes formatted diagnostic output to the error writer when verbose
+// mode is enabled.
+func (p Printer) Tracef(format string, a ...any) {
+	if !IsVerbose() {
+		return
+	}
+	fmt.Fprint(p.err(), render(fmt.Sprintf(format, a...)))
+}
+
+// Warnf writes a formatted warning to stderr unless quiet mode is enabled.
+//
+// Warnings are kept separate from command results on stdout so that piping
+// output into other tools is not polluted. Every warning is also recorded and
+// can be retrieved with Warnings, e.g. to embed them in structured output.
+func Warnf(format string, a ...any) {
+	Printer{}.Warnf(format, a...)
+}
+
+// Warnf writes a formatted warning to the error writer unless quiet mode is
+// enabled, and records it like the package-level Warnf.
+func (p Printer) Warnf(format string, a ...any) {
+	message := strings.TrimSuffix(fmt.Sprintf(format, a...), "\n")
+
+	mu.Lock()
+	warnings = append(warnings, message)
+	mu.Unlock()
+
+	if IsQuiet() {
+		return
+	}
+	fmt.Fprint(p.err(), render(fmt.Sprintf("%s  %s\n", SymbolWarning, message)))
+}
+
+// Warnings returns all warnings recorded since the last ResetWarnings call.
+func Warnings() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), warnings...)
+}
+
+// ResetWarnings clears the recorded warnings.
+func ResetWarnings() {
+	mu.Lock()
+	defer mu.Unlock()
+	warnings = nil
+}
+
+// Errorf writes formatted error output to stderr regardless of verbosity.
+func Errorf(format string, a ...any) {
+	Printer{}.Errorf(format, a...)
+}
+
+// Errorf writes formatted error output to the error writer regardless of
+// verbosity.
+func (p Printer) Errorf(format string, a ...any) {
+	fmt.Fprint(p.err(), render(fmt.Sprintf(format, a...)))
+}
+
+// Promptf writes an interactive prompt regardless of verbosity.
+//
+// Prompts are never suppressed because the user must see what is being asked
+// before any input is read.
+func Promptf(format string, a ...any) {
+	Printer{}.Promptf(format, a...)
+}
+
+// Promptf writes an interactive prompt regardless of verbosity.
+func (p Printer) Promptf(format string, a ...any) {
+	fmt.Fprint(p.out(), render(fmt.Sprintf(format, a...)))
+}
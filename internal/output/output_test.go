@@ -0,0 +1,337 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLevels tests that output is filtered by verbosity level
+func TestLevels(t *testing.T) {
+	var out, errOut bytes.Buffer
+	SetWriters(&out, &errOut)
+	defer SetWriters(nil, nil)
+	defer SetLevel(LevelNormal)
+
+	testCases := []struct {
+		name        string
+		level       Level
+		expectedOut string
+		expectedErr string
+	}{
+		{"quiet", LevelQuiet, "prompt", "error\n"},
+		{"normal", LevelNormal, "info\nprompt", "error\n"},
+		{"verbose", LevelVerbose, "info\ndebug\nprompt", "trace\nerror\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			out.Reset()
+			errOut.Reset()
+			SetLevel(tc.level)
+
+			Println("info")
+			Debugf("debug\n")
+			Promptf("prompt")
+			Tracef("trace\n")
+			Errorf("error\n")
+
+			if out.String() != tc.expectedOut {
+				t.Errorf("Expected stdout %q, got %q", tc.expectedOut, out.String())
+			}
+			if errOut.String() != tc.expectedErr {
+				t.Errorf("Expected stderr %q (errors always, traces when verbose), got %q", tc.expectedErr, errOut.String())
+			}
+		})
+	}
+}
+
+// TestQuietAndVerbose tests the level helper functions
+func TestQuietAndVerbose(t *testing.T) {
+	defer SetLevel(LevelNormal)
+
+	SetLevel(LevelQuiet)
+	if !IsQuiet() || IsVerbose() {
+		t.Error("Expected quiet mode")
+	}
+
+	SetLevel(LevelVerbose)
+	if IsQuiet() || !IsVerbose() {
+		t.Error("Expected verbose mode")
+	}
+}
+
+// TestWarnings tests that warnings go to stderr and are recorded
+func TestWarnings(t *testing.T) {
+	var out, errOut bytes.Buffer
+	SetWriters(&out, &errOut)
+	defer SetWriters(nil, nil)
+	defer SetLevel(LevelNormal)
+	defer ResetWarnings()
+	defer SetUnicode(SupportsUnicode())
+
+	SetUnicode(true)
+	ResetWarnings()
+	Warnf("found %d orphaned files\n", 2)
+
+	if out.Len() != 0 {
+		t.Errorf("Warnings should not be written to stdout, got %q", out.String())
+	}
+	if errOut.String() != "⚠️  found 2 orphaned files\n" {
+		t.Errorf("Unexpected warning output: %q", errOut.String())
+	}
+
+	SetLevel(LevelQuiet)
+	errOut.Reset()
+	Warnf("suppressed")
+	if errOut.Len() != 0 {
+		t.Errorf("Warnings should be suppressed in quiet mode, got %q", errOut.String())
+	}
+
+	warnings := Warnings()
+	if len(warnings) != 2 || warnings[0] != "found 2 orphaned files" {
+		t.Errorf("Unexpected recorded warnings: %v", warnings)
+	}
+}
+
+// TestPrinter tests that a Printer writes to its own writers and falls back
+// to the global ones
+func TestPrinter(t *testing.T) {
+	var global, out, errOut bytes.Buffer
+	SetWriters(&global, &global)
+	defer SetWriters(nil, nil)
+	defer ResetWarnings()
+
+	p := Printer{Out: &out, Err: &errOut}
+	p.Println("info")
+	p.PrintTable([][]string{{"A", "B"}})
+	p.Warnf("warning")
+	p.Errorf("error\n")
+
+	if out.String() != "info\nA  B\n" {
+		t.Errorf("Unexpected output: %q", out.String())
+	}
+	if !strings.Contains(errOut.String(), "warning") || !strings.HasSuffix(errOut.String(), "error\n") {
+		t.Errorf("Unexpected error output: %q", errOut.String())
+	}
+	if warnings := Warnings(); len(warnings) != 1 || warnings[0] != "warning" {
+		t.Errorf("Expected the warning to be recorded, got %v", warnings)
+	}
+	if global.Len() != 0 {
+		t.Errorf("Nothing should be written to the global writers, got %q", global.String())
+	}
+
+	Printer{Out: &out}.Warnf("fallback")
+	if !strings.Contains(global.String(), "fallback") {
+		t.Errorf("Expected a nil writer to fall back to the global one, got %q", global.String())
+	}
+}
+
+// TestASCIIFallback tests that symbols are rendered as ASCII when Unicode is unsupported
+func TestASCIIFallback(t *testing.T) {
+	var out bytes.Buffer
+	SetWriters(&out, nil)
+	defer SetWriters(nil, nil)
+	defer SetUnicode(SupportsUnicode())
+
+	SetUnicode(false)
+	Printf("%s done ✅ ⚠️  careful 📦 pkg ━━ Grüße\n", SymbolSuccess)
+
+	expected := "[OK] done [OK] [!]  careful * pkg -- Grüße\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+
+	out.Reset()
+	SetUnicode(true)
+	Printf("%s done\n", SymbolSuccess)
+	if out.String() != "✅ done\n" {
+		t.Errorf("Expected emoji output, got %q", out.String())
+	}
+}
+
+// TestLocaleSupportsUnicode tests locale-based UTF-8 detection
+func TestLocaleSupportsUnicode(t *testing.T) {
+	testCases := []struct {
+		lang     string
+		expected bool
+	}{
+		{"en_US.UTF-8", true},
+		{"de_DE.utf8", true},
+		{"C", false},
+		{"POSIX", false},
+		{"en_US.ISO-8859-1", false},
+		{"", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.lang, func(t *testing.T) {
+			t.Setenv("LC_ALL", "")
+			t.Setenv("LC_CTYPE", "")
+			t.Setenv("LANG", tc.lang)
+
+			if result := localeSupportsUnicode(); result != tc.expected {
+				t.Errorf("localeSupportsUnicode() with LANG=%q = %v, want %v", tc.lang, result, tc.expected)
+			}
+		})
+	}
+}
+
+// TestConfirm tests parsing of confirmation answers
+func TestConfirm(t *testing.T) {
+	var out bytes.Buffer
+	SetWriters(&out, &out)
+	defer SetWriters(nil, nil)
+	defer SetInput(nil)
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	testCases := []struct {
+		name       string
+		input      string
+		defaultYes bool
+		expected   bool
+	}{
+		{"yes", "yes\n", false, true},
+		{"uppercase y", "Y\n", false, true},
+		{"punctuation and spaces", "  Yes!\n", false, true},
+		{"localized yes", "ja\n", false, true},
+		{"no", "no\n", false, false},
+		{"empty defaults to no", "\n", false, false},
+		{"empty with default yes", "\n", true, true},
+		{"multi-word answer", "yes I am sure\n", false, false},
+		{"unrecognized answer", "maybe\n", false, false},
+		{"answer without newline", "y", false, true},
+		{"end of input", "", false, false},
+		{"end of input with default yes", "", true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetInput(strings.NewReader(tc.input))
+			if got := ConfirmDefault(tc.defaultYes, "Proceed?"); got != tc.expected {
+				t.Errorf("ConfirmDefault(%t) with %q = %t, want %t", tc.defaultYes, tc.input, got, tc.expected)
+			}
+		})
+	}
+
+	// Piped answers are read one line per question
+	SetInput(strings.NewReader("n\ny\n"))
+	if Confirm("First?") || !Confirm("Second?") {
+		t.Error("Expected consecutive questions to read consecutive lines")
+	}
+
+	// --yes answers without reading input
+	SetAssumeYes(true)
+	defer SetAssumeYes(false)
+	SetInput(strings.NewReader(""))
+	out.Reset()
+	if !Confirm("Remove %d files?", 3) || !strings.Contains(out.String(), "Remove 3 files? (y/N): yes") {
+		t.Errorf("Expected --yes to confirm, got output %q", out.String())
+	}
+
+	// Unattended runs read piped answers, then answer yes when input runs out
+	SetPrompter(InputPrompter{Unattended: true})
+	SetInput(strings.NewReader("n\n"))
+	out.Reset()
+	if !AssumeYes() || Confirm("First?") || !Confirm("Second?") {
+		t.Error("Expected an unattended prompter to read piped answers, then confirm")
+	}
+	if !strings.Contains(out.String(), "assuming yes") {
+		t.Errorf("Expected the unattended answer to be reported, got output %q", out.String())
+	}
+}
+
+// TestSelectPrompter tests choosing a Prompter from --yes and the environment
+func TestSelectPrompter(t *testing.T) {
+	t.Setenv(AssumeYesEnv, "")
+	if _, ok := SelectPrompter(true).(AutoApprove); !ok {
+		t.Error("Expected --yes to select AutoApprove")
+	}
+	if _, ok := SelectPrompter(false).(InputPrompter); !ok {
+		t.Error("Expected answers to be read from input without --yes")
+	}
+
+	for _, value := range []string{"1", "true", "TRUE"} {
+		t.Setenv(AssumeYesEnv, value)
+		if _, ok := SelectPrompter(false).(AutoApprove); !ok {
+			t.Errorf("Expected %s=%s to select AutoApprove", AssumeYesEnv, value)
+		}
+	}
+	t.Setenv(AssumeYesEnv, "0")
+	if _, ok := SelectPrompter(false).(AutoApprove); ok {
+		t.Errorf("Expected %s=0 not to select AutoApprove", AssumeYesEnv)
+	}
+
+	// The null device is a character device but not a terminal
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	defer devNull.Close()
+	stdin := os.Stdin
+	os.Stdin = devNull
+	defer func() { os.Stdin = stdin }()
+	if p, ok := SelectPrompter(false).(InputPrompter); !ok || !p.Unattended {
+		t.Errorf("Expected answers from %s to be unattended, got %#v", os.DevNull, SelectPrompter(false))
+	}
+}
+
+// TestFormat tests locale-aware date and number formatting
+func TestFormat(t *testing.T) {
+	moment := time.Date(2025, time.December, 31, 18, 5, 9, 0, time.Local)
+
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		locale   string
+		date     string
+		dateTime string
+		number   string
+	}{
+		{"unset", nil, "", "2025-12-31", "2025-12-31 18:05:09", "1234567"},
+		{"posix", map[string]string{"LANG": "C.UTF-8"}, "", "2025-12-31", "2025-12-31 18:05:09", "1234567"},
+		{"us", map[string]string{"LANG": "en_US.UTF-8"}, "", "12/31/2025", "12/31/2025 6:05:09 PM", "1,234,567"},
+		{"uk", map[string]string{"LANG": "en_GB.UTF-8"}, "", "31/12/2025", "31/12/2025 18:05:09", "1,234,567"},
+		{"german", map[string]string{"LANG": "de_DE.UTF-8"}, "", "31.12.2025", "31.12.2025 18:05:09", "1.234.567"},
+		{"category", map[string]string{"LANG": "en_US.UTF-8", "LC_TIME": "de_DE", "LC_NUMERIC": "fr_FR"}, "", "31.12.2025", "31.12.2025 18:05:09", "1 234 567"},
+		{"override", map[string]string{"LANG": "en_US.UTF-8", LocaleEnv: "nl_NL"}, "", "31-12-2025", "31-12-2025 18:05:09", "1.234.567"},
+		{"iso", map[string]string{"LC_ALL": "de_DE.UTF-8", LocaleEnv: "iso"}, "", "2025-12-31", "2025-12-31 18:05:09", "1234567"},
+		{"setting", map[string]string{"LANG": "en_US.UTF-8", "LC_TIME": "fr_FR"}, "de_DE", "31.12.2025", "31.12.2025 18:05:09", "1.234.567"},
+		{"setting iso", map[string]string{"LC_ALL": "de_DE.UTF-8"}, "iso", "2025-12-31", "2025-12-31 18:05:09", "1234567"},
+		{"env over setting", map[string]string{LocaleEnv: "nl_NL"}, "de_DE", "31-12-2025", "31-12-2025 18:05:09", "1.234.567"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{LocaleEnv, "LC_ALL", "LC_TIME", "LC_NUMERIC", "LANG"} {
+				t.Setenv(name, tc.env[name])
+			}
+			SetLocale(tc.locale)
+			defer SetLocale("")
+
+			if got := FormatDate(moment); got != tc.date {
+				t.Errorf("FormatDate() = %q, want %q", got, tc.date)
+			}
+			if got := FormatDateTime(moment); got != tc.dateTime {
+				t.Errorf("FormatDateTime() = %q, want %q", got, tc.dateTime)
+			}
+			if got := FormatNumber(1234567); got != tc.number {
+				t.Errorf("FormatNumber() = %q, want %q", got, tc.number)
+			}
+		})
+	}
+
+	t.Setenv("LANG", "en_US")
+	for n, want := range map[int]string{0: "0", 999: "999", 1000: "1,000", -12345: "-12,345"} {
+		if got := FormatNumber(n); got != want {
+			t.Errorf("FormatNumber(%d) = %q, want %q", n, got, want)
+		}
+	}
+	for n, want := range map[int64]string{512: "512 B", 1536: "1.5 KiB", 50 << 20: "50.0 MiB", 3 << 40: "3072.0 GiB"} {
+		if got := FormatSize(n); got != want {
+			t.Errorf("FormatSize(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Symbol is a status marker rendered as an emoji on capable terminals and as
+// an ASCII marker elsewhere.
+type Symbol struct {
+	Emoji string
+	ASCII string
+}
+
+// Status markers used throughout ChatMate output.
+var (
+	SymbolSuccess = Symbol{"✅", "[OK]"}
+	SymbolFailure = Symbol{"❌", "[X]"}
+	SymbolWarning = Symbol{"⚠️", "[!]"}
+	SymbolSkipped = Symbol{"⏭️", "[-]"}
+	SymbolPending = Symbol{"⬜", "[ ]"}
+	SymbolUpdated = Symbol{"🔄", "[~]"}
+	SymbolUser    = Symbol{"📝", "[*]"}
+)
+
+// knownSymbols lists the markers translated when falling back to ASCII.
+// Variants with the emoji variation selector must come first so the
+// replacer matches them before the bare character.
+var knownSymbols = []Symbol{
+	SymbolSuccess,
+	SymbolFailure,
+	SymbolWarning,
+	{"⚠", "[!]"},
+	SymbolSkipped,
+	{"⏭", "[-]"},
+	SymbolPending,
+	SymbolUpdated,
+	SymbolUser,
+	{"•", "-"},
+	{"→", "->"},
+	{"✓", "+"},
+	{"✔", "+"},
+	{"✗", "x"},
+}
+
+var (
+	unicodeOnce    sync.Once
+	unicodeEnabled bool
+	asciiReplacer  *strings.Replacer
+)
+
+func init() {
+	pairs := make([]string, 0, len(knownSymbols)*2)
+	for _, symbol := range knownSymbols {
+		pairs = append(pairs, symbol.Emoji, symbol.ASCII)
+	}
+	asciiReplacer = strings.NewReplacer(pairs...)
+}
+
+// String renders the symbol for the current terminal.
+func (s Symbol) String() string {
+	if SupportsUnicode() {
+		return s.Emoji
+	}
+	return s.ASCII
+}
+
+// ASCIIEnv is the environment variable that chooses between emoji and ASCII
+// markers instead of detecting what the terminal supports.
+const ASCIIEnv = "CHATMATE_ASCII"
+
+// SupportsUnicode reports whether the terminal is expected to render UTF-8
+// emoji correctly.
+//
+// The CHATMATE_ASCII environment variable overrides detection: "1" or "true"
+// forces ASCII markers, "0" or "false" forces emoji.
+func SupportsUnicode() bool {
+	unicodeOnce.Do(func() {
+		unicodeEnabled = detectUnicode()
+	})
+
+	mu.RLock()
+	defer mu.RUnlock()
+	return unicodeEnabled
+}
+
+// SetUnicode overrides terminal capability detection.
+func SetUnicode(enabled bool) {
+	unicodeOnce.Do(func() {})
+
+	mu.Lock()
+	defer mu.Unlock()
+	unicodeEnabled = enabled
+}
+
+// detectUnicode determines UTF-8 capability from the environment.
+func detectUnicode() bool {
+	switch strings.ToLower(os.Getenv(ASCIIEnv)) {
+	case "1", "true", "yes":
+		return false
+	case "0", "false", "no":
+		return true
+	}
+	return consoleSupportsUnicode()
+}
+
+// localeSupportsUnicode inspects the POSIX locale variables.
+//
+// An unset locale is treated as UTF-8 capable because modern terminals
+// default to UTF-8; an explicit non-UTF-8 locale (including "C" and "POSIX")
+// selects ASCII markers.
+func localeSupportsUnicode() bool {
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		value = strings.ToLower(value)
+		return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+	}
+	return true
+}
+
+// render adapts text to the terminal's capabilities.
+//
+// When Unicode is not supported, known status markers are replaced with
+// their ASCII equivalents, box-drawing characters become dashes, and any
+// remaining pictographic symbols are replaced with "*".
+func render(text string) string {
+	if SupportsUnicode() {
+		return text
+	}
+
+	text = asciiReplacer.Replace(text)
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\uFE0F': // emoji variation selector
+			return -1
+		case r >= '\u2500' && r <= '\u257F': // box drawing
+			return '-'
+		case unicode.Is(unicode.So, r):
+			return '*'
+		}
+		return r
+	}, text)
+}
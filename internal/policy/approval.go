@@ -0,0 +1,152 @@
+package policy
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Approval requires new chatmates to be approved before they are installed,
+// for organizations that review every prompt their developers use:
+//
+//	approval:
+//	  required: true
+//	  publicKey: MCowBQYDK2VwAyEA...
+//	  allowlist:
+//	    - chatmate: Rust Reviewer
+//	      sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//	      signature: 3q2+7w...
+//
+// Chatmates listed in Required are approved implicitly.
+//
+// Fields:
+//   - Required: Whether installing a chatmate that is not installed yet
+//     needs approval; installations that are not approved are queued as
+//     requests instead
+//   - PublicKey: Base64 Ed25519 public key (raw or PKIX DER); when set,
+//     allowlist entries only count if they carry a valid signature, so the
+//     allowlist cannot be extended without the private key
+//   - Allowlist: The approved chatmates
+type Approval struct {
+	Required  bool             `yaml:"required,omitempty"`
+	PublicKey string           `yaml:"publicKey,omitempty"`
+	Allowlist []AllowlistEntry `yaml:"allowlist,omitempty"`
+}
+
+// AllowlistEntry is an approved chatmate.
+//
+// Fields:
+//   - Chatmate: Display name or filename of the chatmate
+//   - SHA256: Hex encoded SHA-256 of the approved content; any content is
+//     approved when empty
+//   - Signature: Base64 Ed25519 signature of SignedMessage, made with the
+//     private key matching Approval.PublicKey
+type AllowlistEntry struct {
+	Chatmate  string `yaml:"chatmate"`
+	SHA256    string `yaml:"sha256,omitempty"`
+	Signature string `yaml:"signature,omitempty"`
+}
+
+// SignedMessage returns the bytes an allowlist signature covers: the line
+// "chatmate-approval", the chatmate, and the SHA-256, each followed by a
+// newline.
+func (e AllowlistEntry) SignedMessage() []byte {
+	return []byte("chatmate-approval\n" + e.Chatmate + "\n" + strings.ToLower(e.SHA256) + "\n")
+}
+
+// Approves reports whether installing a chatmate with the given content is
+// approved.
+//
+// Parameters:
+//   - names: The names the chatmate is known by (filename and display name)
+//   - checksum: Hex encoded SHA-256 of the content to install
+//
+// Returns:
+//   - bool: True when approval is not required, the chatmate is required by
+//     the policy, or a (validly signed) allowlist entry matches
+func (p *Policy) Approves(names []string, checksum string) bool {
+	if !p.Approval.Required {
+		return true
+	}
+	for _, required := range p.Required {
+		if matchesName(required, names) {
+			return true
+		}
+	}
+
+	publicKey, _ := p.Approval.publicKey()
+	for _, entry := range p.Approval.Allowlist {
+		if !matchesName(entry.Chatmate, names) {
+			continue
+		}
+		if entry.SHA256 != "" && !strings.EqualFold(entry.SHA256, checksum) {
+			continue
+		}
+		if publicKey != nil && !entry.verify(publicKey) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchesName reports whether name is one of names.
+func matchesName(name string, names []string) bool {
+	for _, candidate := range names {
+		if name == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// verify checks the signature of the entry.
+func (e AllowlistEntry) verify(publicKey ed25519.PublicKey) bool {
+	signature, err := base64.StdEncoding.DecodeString(e.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return false
+	}
+	return ed25519.Verify(publicKey, e.SignedMessage(), signature)
+}
+
+// pkixEd25519Prefix is the DER encoding of an Ed25519 SubjectPublicKeyInfo
+// up to the key, as written by 'openssl pkey -pubout -outform DER'.
+var pkixEd25519Prefix = []byte{0x30, 0x2a, 0x30, 0x05, 0x06, 0x03, 0x2b, 0x65, 0x70, 0x03, 0x21, 0x00}
+
+// publicKey decodes PublicKey; it returns nil without a key.
+func (a *Approval) publicKey() (ed25519.PublicKey, error) {
+	if a.PublicKey == "" {
+		return nil, nil
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(a.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("publicKey is not base64: %w", err)
+	}
+	if len(der) == len(pkixEd25519Prefix)+ed25519.PublicKeySize && string(der[:len(pkixEd25519Prefix)]) == string(pkixEd25519Prefix) {
+		der = der[len(pkixEd25519Prefix):]
+	}
+	if len(der) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("publicKey is not an Ed25519 public key")
+	}
+	return ed25519.PublicKey(der), nil
+}
+
+// validate reports malformed approval settings.
+func (a *Approval) validate() error {
+	if _, err := a.publicKey(); err != nil {
+		return err
+	}
+	for _, entry := range a.Allowlist {
+		if entry.Chatmate == "" {
+			return fmt.Errorf("allowlist entry without a chatmate")
+		}
+		if entry.SHA256 != "" {
+			if sum, err := hex.DecodeString(entry.SHA256); err != nil || len(sum) != 32 {
+				return fmt.Errorf("allowlist entry %s: sha256 must be a hex encoded SHA-256", entry.Chatmate)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a range of chatmate versions, written like the version
+// ranges of npm and Cargo:
+//
+//   - 1.2.3 or =1.2.3: Exactly that version
+//   - 1.2 or 1.2.x: Any 1.2 version; 1 or 1.x any 1 version; * any version
+//   - ^1.2.3: Compatible versions, >=1.2.3 <2.0.0 (>=0.2.3 <0.3.0 for ^0.2.3)
+//   - ~1.2.3: Patch versions, >=1.2.3 <1.3.0
+//   - >1.2.3, >=1.2.3, <2.0.0, <=1.9: Comparisons; missing parts are 0
+//
+// Comparisons separated by spaces or commas must all hold, and alternatives
+// are separated by "||", e.g. ">=1.2, <1.5 || ^2.0". A leading "v" is
+// ignored. A prerelease, e.g. 2.0.0-rc.1, is only in ranges that name a
+// prerelease of the same version. Versions are the frontmatter 'version:' of
+// the chatmates; a chatmate without a version is outside every range.
+type Constraint struct {
+	text         string
+	alternatives [][]comparison
+}
+
+// comparison is a single condition of a Constraint, e.g. ">=1.2.0".
+type comparison struct {
+	op      string
+	version version
+}
+
+// version is a parsed semantic version.
+type version struct {
+	major, minor, patch int
+	prerelease          string
+}
+
+// ParseConstraint parses a version range (see Constraint).
+//
+// Parameters:
+//   - text: The range, e.g. "^1.2"
+//
+// Returns:
+//   - Constraint: The parsed range
+//   - error: Error if the range is empty or malformed
+func ParseConstraint(text string) (Constraint, error) {
+	c := Constraint{text: strings.TrimSpace(text)}
+	for _, alternative := range strings.Split(text, "||") {
+		fields := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+		if len(fields) == 0 {
+			return Constraint{}, fmt.Errorf("invalid version range %q: empty range", text)
+		}
+
+		var comparisons []comparison
+		for _, field := range fields {
+			expanded, err := expand(field)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("invalid version range %q: %w", text, err)
+			}
+			comparisons = append(comparisons, expanded...)
+		}
+		c.alternatives = append(c.alternatives, comparisons)
+	}
+	return c, nil
+}
+
+// String returns the range as written.
+func (c Constraint) String() string {
+	return c.text
+}
+
+// Allows reports whether a version is in the range.
+//
+// Parameters:
+//   - v: A version, e.g. "1.2.3" or "v1.2"
+//
+// Returns:
+//   - bool: False when v is in none of the alternatives or is not a version
+func (c Constraint) Allows(v string) bool {
+	parsed, parts, err := parseVersion(v)
+	if err != nil || parts == 0 {
+		return false
+	}
+	for _, comparisons := range c.alternatives {
+		// Like npm, prereleases are only in a range that names a prerelease
+		// of the same version, so ^1.2 does not pick up 2.0.0-rc.1
+		allowed := parsed.prerelease == ""
+		for _, cmp := range comparisons {
+			if cmp.version.prerelease != "" && cmp.version.release() == parsed.release() {
+				allowed = true
+			}
+		}
+		for _, cmp := range comparisons {
+			if !cmp.holds(parsed) {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// expand turns a condition of a range into comparisons of full versions.
+func expand(field string) ([]comparison, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(field, prefix) {
+			op, field = prefix, field[len(prefix):]
+			break
+		}
+	}
+	v, parts, err := parseVersion(field)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case ">=", "<=", ">", "<":
+		if parts == 0 {
+			return nil, fmt.Errorf("%s%s: a comparison needs a version", op, field)
+		}
+		return []comparison{{op, v}}, nil
+	case "^":
+		switch {
+		case parts == 0:
+			return nil, nil
+		case v.major > 0 || parts == 1:
+			return between(v, version{major: v.major + 1}), nil
+		case v.minor > 0 || parts == 2:
+			return between(v, version{minor: v.minor + 1}), nil
+		default:
+			return between(v, version{patch: v.patch + 1}), nil
+		}
+	case "~":
+		if parts == 1 {
+			return between(v, version{major: v.major + 1}), nil
+		}
+		if parts == 0 {
+			return nil, nil
+		}
+		return between(v, version{major: v.major, minor: v.minor + 1}), nil
+	default:
+		// A full version is exact; a partial one matches what it leaves out
+		switch parts {
+		case 0:
+			return nil, nil
+		case 1:
+			return between(v, version{major: v.major + 1}), nil
+		case 2:
+			return between(v, version{major: v.major, minor: v.minor + 1}), nil
+		default:
+			return []comparison{{"=", v}}, nil
+		}
+	}
+}
+
+// between returns the comparisons of the versions from low up to, but not
+// including, high.
+func between(low, high version) []comparison {
+	return []comparison{{">=", low}, {"<", high}}
+}
+
+// holds reports whether v meets the comparison.
+func (c comparison) holds(v version) bool {
+	order := v.compare(c.version)
+	switch c.op {
+	case ">=":
+		return order >= 0
+	case ">":
+		return order > 0
+	case "<=":
+		return order <= 0
+	case "<":
+		return order < 0
+	default:
+		return order == 0
+	}
+}
+
+// parseVersion parses a version that may leave out its minor and patch
+// parts or replace them with "x" or "*".
+//
+// Returns:
+//   - version: The version; missing parts are 0
+//   - int: The number of parts given, 0 for "*"
+//   - error: Error if the version is malformed
+func parseVersion(text string) (version, int, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(text), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, prerelease, _ := strings.Cut(s, "-")
+
+	var v version
+	numbers := []*int{&v.major, &v.minor, &v.patch}
+	parts := strings.Split(s, ".")
+	if len(parts) > len(numbers) {
+		return version{}, 0, fmt.Errorf("%q is not a version", text)
+	}
+
+	given, wildcard := 0, false
+	for n, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			wildcard = true
+			continue
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || wildcard {
+			return version{}, 0, fmt.Errorf("%q is not a version", text)
+		}
+		*numbers[n] = number
+		given++
+	}
+	if prerelease != "" {
+		if given < len(numbers) {
+			return version{}, 0, fmt.Errorf("%q is not a version", text)
+		}
+		v.prerelease = prerelease
+	}
+	return v, given, nil
+}
+
+// release returns v without its prerelease.
+func (v version) release() version {
+	return version{major: v.major, minor: v.minor, patch: v.patch}
+}
+
+// compare orders v and other: negative when v is lower, positive when it is
+// higher, and 0 when they are equal. A prerelease is lower than its release.
+func (v version) compare(other version) int {
+	for _, diff := range []int{v.major - other.major, v.minor - other.minor, v.patch - other.patch} {
+		if diff != 0 {
+			return diff
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	default:
+		return strings.Compare(v.prerelease, other.prerelease)
+	}
+}
+
+// Pin returns the version range the policy pins a chatmate to.
+//
+// Parameters:
+//   - names: The names the chatmate is known by (filename and display name)
+//
+// Returns:
+//   - Constraint: The range of versions the chatmate may be updated to
+//   - bool: False when the chatmate is not pinned
+func (p *Policy) Pin(names []string) (Constraint, bool) {
+	for _, name := range names {
+		if text, ok := p.Pins[name]; ok {
+			c, err := ParseConstraint(text)
+			return c, err == nil
+		}
+	}
+	return Constraint{}, false
+}
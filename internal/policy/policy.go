@@ -0,0 +1,160 @@
+// Package policy reads the team policy that ChatMate enforces.
+//
+// A team lead or administrator distributes a policy file (for example with
+// the dotfiles, a configuration management tool, or a shared drive) to
+// describe what every team member's setup must contain:
+//
+//	# Chatmates everyone must have installed
+//	required:
+//	  - Review PR
+//	  - Testing
+//	# Versions chatmates may be updated to (see Constraint)
+//	pins:
+//	  Review PR: ^1.2
+//	# Where to report the outcome of 'chatmate apply' and 'chatmate sync'
+//	webhook: https://hooks.slack.com/services/...
+//	webhookFormat: slack
+//	# Installing other chatmates needs approval (see Approval)
+//	approval:
+//	  required: true
+//	# Disk space for history, backups, versions, and cache (see Retention)
+//	retention:
+//	  maxSize: 20MB
+//
+// 'chatmate status --check' fails when the policy is not met, and
+// 'chatmate apply' installs what is missing. 'chatmate update' and
+// 'chatmate sync' hold back updates to versions outside the pins.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/internal/notify"
+	"github.com/jonassiebler/chatmate/internal/state"
+	"gopkg.in/yaml.v3"
+)
+
+// Filename is the name of the policy file in the ChatMate config directory.
+const Filename = "policy.yaml"
+
+// Env is the environment variable that points to a policy file elsewhere,
+// such as a file on a shared drive or in a team repository.
+const Env = "CHATMATE_POLICY"
+
+// Policy is the team policy.
+//
+// Fields:
+//   - Required: Display names or filenames of the chatmates that must be
+//     installed
+//   - Pins: Version ranges (see Constraint) by display name or filename;
+//     updates to versions outside its range are held back
+//   - Webhook: HTTP(S) URL that receives a report of every apply and sync, so
+//     platform teams can observe a rollout centrally (see package notify)
+//   - WebhookFormat: How the report is sent: "json" (default), or "slack" or
+//     "teams" for a chat message posted through an incoming webhook
+//   - Approval: Whether and how new chatmates must be approved before they
+//     are installed
+//   - Retention: Limits on the disk space of the history, backups, versions,
+//     and cache
+type Policy struct {
+	Required      []string          `yaml:"required,omitempty"`
+	Pins          map[string]string `yaml:"pins,omitempty"`
+	Webhook       string            `yaml:"webhook,omitempty"`
+	WebhookFormat string            `yaml:"webhookFormat,omitempty"`
+	Approval      Approval          `yaml:"approval,omitempty"`
+	Retention     Retention         `yaml:"retention,omitempty"`
+}
+
+// Path returns the location of the policy file: the file named by Env if it
+// is set, otherwise policy.yaml in the roaming config directory (see
+// state.RoamingDir).
+//
+// On macOS the config directory used to be the state directory; a policy
+// still stored there is used until it is moved to the config directory.
+func Path() (string, error) {
+	if path := os.Getenv(Env); path != "" {
+		return path, nil
+	}
+
+	configDir, err := state.RoamingDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(configDir, Filename)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if stateDir, err := state.LocalDir(); err == nil {
+			if _, err := os.Stat(filepath.Join(stateDir, Filename)); err == nil {
+				return filepath.Join(stateDir, Filename), nil
+			}
+		}
+	}
+	return path, nil
+}
+
+// Load reads the policy stored at path.
+//
+// A missing policy file is an empty policy, which requires nothing. Unknown
+// fields, malformed webhook URLs, and malformed version ranges are rejected,
+// so a misspelled setting is reported instead of being silently ignored.
+//
+// Parameters:
+//   - path: Location of the policy file
+//
+// Returns:
+//   - *Policy: The policy
+//   - error: File read or decoding error
+//
+// Example:
+//
+// p, err := policy.Load(path)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to load team policy: %w", err)
+//	}
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Policy{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy %s: %w", path, err)
+	}
+
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode policy %s: %w", path, err)
+	}
+
+	for name, pin := range p.Pins {
+		if _, err := ParseConstraint(pin); err != nil {
+			return nil, fmt.Errorf("invalid policy %s: pins: %s: %w", path, name, err)
+		}
+	}
+	if p.Webhook != "" {
+		if u, err := url.Parse(p.Webhook); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return nil, fmt.Errorf("invalid policy %s: webhook must be an http or https URL, got %q", path, p.Webhook)
+		}
+	}
+	if p.WebhookFormat != "" && !slices.Contains(notify.Formats, p.WebhookFormat) {
+		return nil, fmt.Errorf("invalid policy %s: webhookFormat must be one of %s, got %q",
+			path, strings.Join(notify.Formats, ", "), p.WebhookFormat)
+	}
+	if err := p.Approval.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: approval: %w", path, err)
+	}
+	if _, err := p.Retention.Limits(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: retention: %w", path, err)
+	}
+
+	return &p, nil
+}
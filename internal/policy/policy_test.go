@@ -0,0 +1,240 @@
+package policy
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// TestLoad tests reading policy files
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil || len(p.Required) != 0 {
+		t.Fatalf("A missing policy should require nothing, got %+v, %v", p, err)
+	}
+
+	files := map[string]string{
+		"empty.yaml":     "",
+		"valid.yaml":     "# Team standard\nrequired:\n  - Review PR\n  - Testing\n",
+		"unknown.yaml":   "requried:\n  - Testing\n",
+		"invalid.yaml":   "required: [unclosed\n",
+		"webhook.yaml":   "webhook: 'https://hooks.example.com/chatmate'\n",
+		"badhook.yaml":   "webhook: 'hooks.example.com'\n",
+		"badformat.yaml": "webhook: 'https://hooks.example.com/chatmate'\nwebhookFormat: 'xml'\n",
+		"approval.yaml":  "approval:\n  required: true\n  allowlist:\n    - chatmate: Rust Reviewer\n",
+		"badkey.yaml":    "approval:\n  required: true\n  publicKey: 'bm90IGEga2V5'\n",
+		"pins.yaml":      "pins:\n  Review PR: ^1.2\n",
+		"badpin.yaml":    "pins:\n  Review PR: '>=1.2 <='\n",
+		"retention.yaml": "retention:\n  maxSize: 20MB\n  maxAge: 2w\n  maxVersions: 0\n",
+		"badage.yaml":    "retention:\n  maxAge: forever\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if p, err := Load(filepath.Join(dir, "empty.yaml")); err != nil || len(p.Required) != 0 {
+		t.Errorf("An empty policy should require nothing, got %+v, %v", p, err)
+	}
+	if p, err := Load(filepath.Join(dir, "valid.yaml")); err != nil || len(p.Required) != 2 || p.Required[0] != "Review PR" {
+		t.Errorf("Unexpected policy: %+v, %v", p, err)
+	}
+	if _, err := Load(filepath.Join(dir, "unknown.yaml")); err == nil {
+		t.Error("Expected an error for a misspelled field")
+	}
+	if _, err := Load(filepath.Join(dir, "invalid.yaml")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+	if p, err := Load(filepath.Join(dir, "webhook.yaml")); err != nil || p.Webhook != "https://hooks.example.com/chatmate" {
+		t.Errorf("Unexpected webhook policy: %+v, %v", p, err)
+	}
+	if _, err := Load(filepath.Join(dir, "badhook.yaml")); err == nil {
+		t.Error("Expected an error for a webhook without scheme")
+	}
+	if _, err := Load(filepath.Join(dir, "badformat.yaml")); err == nil {
+		t.Error("Expected an error for an unknown webhook format")
+	}
+	if p, err := Load(filepath.Join(dir, "approval.yaml")); err != nil || !p.Approval.Required || len(p.Approval.Allowlist) != 1 {
+		t.Errorf("Unexpected approval policy: %+v, %v", p, err)
+	}
+	if _, err := Load(filepath.Join(dir, "badkey.yaml")); err == nil {
+		t.Error("Expected an error for a malformed public key")
+	}
+	if p, err := Load(filepath.Join(dir, "pins.yaml")); err != nil || p.Pins["Review PR"] != "^1.2" {
+		t.Errorf("Unexpected pins: %+v, %v", p, err)
+	}
+	if _, err := Load(filepath.Join(dir, "badpin.yaml")); err == nil {
+		t.Error("Expected an error for a malformed version range")
+	}
+	if p, err := Load(filepath.Join(dir, "retention.yaml")); err != nil {
+		t.Errorf("Unexpected error for retention limits: %v", err)
+	} else if limits, _ := p.Retention.Limits(); limits != (state.Retention{MaxSize: 20_000_000, MaxAge: 14 * 24 * time.Hour}) {
+		t.Errorf("Unexpected retention limits %+v", limits)
+	}
+	if _, err := Load(filepath.Join(dir, "badage.yaml")); err == nil {
+		t.Error("Expected an error for a malformed retention age")
+	}
+}
+
+// TestRetentionLimits tests overriding the default retention limits
+func TestRetentionLimits(t *testing.T) {
+	if limits, err := (Retention{}).Limits(); err != nil || limits != state.DefaultRetention {
+		t.Errorf("Expected the default limits, got %+v, %v", limits, err)
+	}
+	if limits, err := (Retention{MaxSize: "1.5 KiB", MaxAge: "0"}).Limits(); err != nil || limits.MaxSize != 1536 || limits.MaxAge != 0 || limits.MaxVersions != state.DefaultRetention.MaxVersions {
+		t.Errorf("Unexpected limits %+v, %v", limits, err)
+	}
+	if limits, err := (Retention{MaxSize: "1048576", MaxAge: "36h"}).Limits(); err != nil || limits.MaxSize != 1<<20 || limits.MaxAge != 36*time.Hour {
+		t.Errorf("Unexpected limits %+v, %v", limits, err)
+	}
+	negative := -1
+	for _, retention := range []Retention{{MaxSize: "lots"}, {MaxSize: "-1MB"}, {MaxAge: "-3d"}, {MaxVersions: &negative}} {
+		if _, err := retention.Limits(); err == nil {
+			t.Errorf("Expected an error for %+v", retention)
+		}
+	}
+}
+
+// TestPath tests the policy location and its environment override
+func TestPath(t *testing.T) {
+	t.Setenv(Env, "")
+	path, err := Path()
+	if err != nil || filepath.Base(path) != Filename {
+		t.Errorf("Expected %s in the config directory, got %s, %v", Filename, path, err)
+	}
+
+	t.Setenv(Env, "/shared/team-policy.yaml")
+	if path, err := Path(); err != nil || path != "/shared/team-policy.yaml" {
+		t.Errorf("Expected the %s override, got %s, %v", Env, path, err)
+	}
+
+	// A policy left in the state directory by earlier versions is still used
+	t.Setenv(Env, "")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("APPDATA", filepath.Join(home, "config"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "state"))
+	stateDir, err := state.LocalDir()
+	if err != nil {
+		t.Fatalf("LocalDir failed: %v", err)
+	}
+	legacy := filepath.Join(stateDir, Filename)
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatalf("Failed to create state directory: %v", err)
+	}
+	if err := os.WriteFile(legacy, []byte("required: []\n"), 0644); err != nil {
+		t.Fatalf("Failed to write legacy policy: %v", err)
+	}
+	if path, err := Path(); err != nil || path != legacy {
+		t.Errorf("Expected the legacy policy %s, got %s, %v", legacy, path, err)
+	}
+}
+
+// TestApproves tests approving installations with a signed allowlist
+func TestApproves(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	sum := strings.Repeat("ab", 32)
+	signed := AllowlistEntry{Chatmate: "Rust Reviewer", SHA256: sum}
+	signed.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, signed.SignedMessage()))
+
+	p := &Policy{Required: []string{"Testing"}}
+	if !p.Approves([]string{"Other.chatmode.md", "Other"}, sum) {
+		t.Error("Without required approval every installation is approved")
+	}
+
+	p.Approval = Approval{Required: true, Allowlist: []AllowlistEntry{signed, {Chatmate: "Unsigned"}}}
+	tests := []struct {
+		names    []string
+		checksum string
+		want     bool
+	}{
+		{[]string{"Chatmate - Testing.chatmode.md", "Testing"}, sum, true},
+		{[]string{"Rust Reviewer.chatmode.md", "Rust Reviewer"}, sum, true},
+		{[]string{"Rust Reviewer.chatmode.md", "Rust Reviewer"}, strings.Repeat("cd", 32), false},
+		{[]string{"Unsigned.chatmode.md", "Unsigned"}, sum, true},
+		{[]string{"Other.chatmode.md", "Other"}, sum, false},
+	}
+	for _, tt := range tests {
+		if got := p.Approves(tt.names, tt.checksum); got != tt.want {
+			t.Errorf("Approves(%v) without a key = %t, want %t", tt.names, got, tt.want)
+		}
+	}
+
+	// With a public key only validly signed entries count
+	p.Approval.PublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	if !p.Approves([]string{"Rust Reviewer"}, sum) {
+		t.Error("Expected the signed entry to approve")
+	}
+	if p.Approves([]string{"Unsigned"}, sum) {
+		t.Error("Expected the unsigned entry to be ignored")
+	}
+	p.Approval.Allowlist[0].Chatmate = "Tampered"
+	if p.Approves([]string{"Tampered"}, sum) {
+		t.Error("Expected a tampered entry to be ignored")
+	}
+}
+
+// TestConstraint tests version ranges of pins
+func TestConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		allowed    []string
+		denied     []string
+	}{
+		{"1.2.3", []string{"1.2.3", "v1.2.3"}, []string{"1.2.4", "1.2.3-beta"}},
+		{"1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0", "1.1.9"}},
+		{"1.x", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.0"}},
+		{"*", []string{"0.0.1", "9.9.9"}, []string{"", "not a version"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0", "2.0.0-rc.1"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{">=1.2, <1.5 || ^2.0", []string{"1.2.0", "1.4.9", "2.5.0"}, []string{"1.5.0", "3.0.0"}},
+		{">1.2.3 <=1.3", []string{"1.2.4", "1.3.0"}, []string{"1.2.3", "1.3.1"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q) failed: %v", tt.constraint, err)
+			continue
+		}
+		for _, v := range tt.allowed {
+			if !c.Allows(v) {
+				t.Errorf("Expected %q to allow %q", tt.constraint, v)
+			}
+		}
+		for _, v := range tt.denied {
+			if c.Allows(v) {
+				t.Errorf("Expected %q to deny %q", tt.constraint, v)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"", "1.2.3.4", "^a", ">=", "1.x.3", "1 || ", "1.2-beta"} {
+		if _, err := ParseConstraint(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+
+	p := &Policy{Pins: map[string]string{"Review PR": "^1.2"}}
+	if c, ok := p.Pin([]string{"Review PR.chatmode.md", "Review PR"}); !ok || c.String() != "^1.2" {
+		t.Errorf("Expected the pin of Review PR, got %q, %t", c, ok)
+	}
+	if _, ok := p.Pin([]string{"Testing.chatmode.md", "Testing"}); ok {
+		t.Error("Expected Testing not to be pinned")
+	}
+}
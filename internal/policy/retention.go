@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+)
+
+// Retention overrides the limits on the disk space ChatMate's history,
+// backups, and cache may use (see state.DefaultRetention):
+//
+//	retention:
+//	  maxSize: 20MB
+//	  maxAge: 30d
+//	  maxVersions: 2
+//
+// Limits that are not set keep their default; "0" removes a limit.
+//
+// Fields:
+//   - MaxSize: Size the state and cache directories may use together, in
+//     bytes or with a unit: KB, MB, GB (powers of 1000) or KiB, MiB, GiB
+//   - MaxAge: How long operations stay in the history and backups and
+//     versions of chatmates are kept, in days ("30d"), weeks ("2w"), or as
+//     a Go duration ("720h")
+//   - MaxVersions: Number of backups kept of each state file
+type Retention struct {
+	MaxSize     string `yaml:"maxSize,omitempty"`
+	MaxAge      string `yaml:"maxAge,omitempty"`
+	MaxVersions *int   `yaml:"maxVersions,omitempty"`
+}
+
+// Limits returns the retention limits, with the defaults for those the
+// policy does not set.
+//
+// Returns:
+//   - state.Retention: The limits to enforce
+//   - error: Error if a limit is malformed
+func (r Retention) Limits() (state.Retention, error) {
+	limits := state.DefaultRetention
+	if r.MaxSize != "" {
+		size, err := parseSize(r.MaxSize)
+		if err != nil {
+			return state.Retention{}, fmt.Errorf("maxSize: %w", err)
+		}
+		limits.MaxSize = size
+	}
+	if r.MaxAge != "" {
+		age, err := parseAge(r.MaxAge)
+		if err != nil {
+			return state.Retention{}, fmt.Errorf("maxAge: %w", err)
+		}
+		limits.MaxAge = age
+	}
+	if r.MaxVersions != nil {
+		if *r.MaxVersions < 0 {
+			return state.Retention{}, fmt.Errorf("maxVersions must not be negative, got %d", *r.MaxVersions)
+		}
+		limits.MaxVersions = *r.MaxVersions
+	}
+	return limits, nil
+}
+
+// sizeUnits are the units accepted by parseSize, longest first so "MiB" is
+// not read as "B".
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+	{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// parseSize parses a size such as "50MB" or "1048576".
+func parseSize(text string) (int64, error) {
+	s := strings.TrimSpace(text)
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if len(s) > len(unit.suffix) && strings.EqualFold(s[len(s)-len(unit.suffix):], unit.suffix) {
+			s, multiplier = strings.TrimSpace(s[:len(s)-len(unit.suffix)]), unit.bytes
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("%q is not a size (e.g., 50MB)", text)
+	}
+	return int64(value * float64(multiplier)), nil
+}
+
+// parseAge parses an age such as "30d", "2w", or "720h".
+func parseAge(text string) (time.Duration, error) {
+	s := strings.TrimSpace(text)
+	if s == "0" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(s, suffix); ok {
+			if n, err := strconv.Atoi(number); err == nil && n >= 0 {
+				return time.Duration(n) * unit, nil
+			}
+		}
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%q is not an age (e.g., 30d)", text)
+	}
+	return age, nil
+}
@@ -0,0 +1,96 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/registry"
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/mockregistry"
+)
+
+// TestClientFailures tests how the client handles a registry that fails,
+// responds slowly, or serves tampered content
+func TestClientFailures(t *testing.T) {
+	server := mockregistry.New(t)
+	server.Add(mockregistry.Chatmate{Name: "Rust Reviewer", Version: "2.0.0", Content: "---\ndescription: v2\n---\n",
+		Releases: []mockregistry.Release{{Version: "1.0.0", Released: time.Date(2024, 11, 2, 0, 0, 0, 0, time.UTC), Content: "---\ndescription: v1\n---\n"}}})
+	ctx := context.Background()
+
+	t.Run("server error without a cache", func(t *testing.T) {
+		server.Fail(mockregistry.IndexPath, mockregistry.Failure{Status: http.StatusInternalServerError, Times: 1})
+		client := server.Client("")
+		if _, err := client.Index(ctx, false); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("Expected the server error, got %v", err)
+		}
+		if index, err := client.Index(ctx, false); err != nil || len(index.Chatmates) != 1 {
+			t.Errorf("Expected the registry to work again, got %+v, %v", index, err)
+		}
+	})
+
+	t.Run("server error with a cache", func(t *testing.T) {
+		client := server.Client(filepath.Join(t.TempDir(), registry.CacheFilename))
+		if _, err := client.Index(ctx, false); err != nil {
+			t.Fatalf("Index failed: %v", err)
+		}
+		server.Fail(mockregistry.IndexPath, mockregistry.Failure{Status: http.StatusBadGateway})
+		defer server.Fail(mockregistry.IndexPath, mockregistry.Failure{})
+		index, err := client.Index(ctx, true)
+		if err != nil || !index.Stale || len(index.Chatmates) != 1 {
+			t.Errorf("Expected the stale cached index, got %+v, %v", index, err)
+		}
+	})
+
+	t.Run("slow response", func(t *testing.T) {
+		server.Fail(mockregistry.IndexPath, mockregistry.Failure{Delay: time.Minute})
+		defer server.Fail(mockregistry.IndexPath, mockregistry.Failure{})
+		timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		if _, err := server.Client("").Index(timeout, false); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the request to time out, got %v", err)
+		}
+	})
+
+	t.Run("malformed index", func(t *testing.T) {
+		server.Fail(mockregistry.IndexPath, mockregistry.Failure{Body: "{", Times: 1})
+		if _, err := server.Client("").Index(ctx, false); err == nil || !strings.Contains(err.Error(), "decode") {
+			t.Errorf("Expected a decoding error, got %v", err)
+		}
+	})
+
+	t.Run("downloads", func(t *testing.T) {
+		client := server.Client("")
+		index, err := client.Index(ctx, false)
+		if err != nil {
+			t.Fatalf("Index failed: %v", err)
+		}
+		entry, _ := index.Find("Rust Reviewer")
+		if content, err := client.Download(ctx, entry); err != nil || !strings.Contains(string(content), "v2") {
+			t.Errorf("Expected the current version, got %q, %v", content, err)
+		}
+		release, err := entry.Release("1.0.0")
+		if err != nil {
+			t.Fatalf("Release failed: %v", err)
+		}
+		if content, err := client.Download(ctx, release); err != nil || !strings.Contains(string(content), "v1") {
+			t.Errorf("Expected the earlier version, got %q, %v", content, err)
+		}
+
+		path := mockregistry.DownloadPath("Rust Reviewer.chatmode.md", "")
+		server.Fail(path, mockregistry.Failure{Body: "tampered", Times: 1})
+		if _, err := client.Download(ctx, entry); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+			t.Errorf("Expected a checksum mismatch, got %v", err)
+		}
+		server.Fail(path, mockregistry.Failure{Status: http.StatusNotFound, Times: 1})
+		if _, err := client.Download(ctx, entry); err == nil || !strings.Contains(err.Error(), "404") {
+			t.Errorf("Expected the missing download to fail, got %v", err)
+		}
+		if requests := server.Requests(path); requests != 3 {
+			t.Errorf("Expected 3 downloads, got %d", requests)
+		}
+	})
+}
@@ -0,0 +1,584 @@
+// Package registry fetches the index of community chatmates.
+//
+// The registry is a JSON document served over HTTPS that lists chatmates
+// published outside the ChatMate binary:
+//
+//	{
+//	  "chatmates": [
+//	    {
+//	      "name": "Rust Reviewer",
+//	      "filename": "Rust Reviewer.chatmode.md",
+//	      "description": "Reviews Rust code for ownership and safety issues",
+//	      "author": "Jane Doe",
+//	      "version": "1.0.0",
+//	      "url": "mates/Rust Reviewer.chatmode.md",
+//	      "sha256": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+//	      "versions": [
+//	        {
+//	          "version": "0.9.0",
+//	          "released": "2024-11-02T00:00:00Z",
+//	          "url": "mates/0.9.0/Rust Reviewer.chatmode.md",
+//	          "sha256": "60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"
+//	        }
+//	      ],
+//	      "breaking": true,
+//	      "changelog": "Reports unsafe blocks as errors instead of warnings"
+//	    }
+//	  ]
+//	    }
+//	  ]
+//	}
+//
+// The optional versions list the earlier releases of a chatmate, so a
+// specific release can be installed (see Entry.Release and Entry.AsOf). A
+// version marked as breaking changes how the chatmate behaves; its changelog
+// is shown, and the update must be confirmed, before it replaces an
+// installed version (see Entry.Changes).
+// Relative chatmate URLs are resolved against the index URL. Every download
+// is verified against its SHA-256, so a compromised mirror cannot serve
+// different content than the index describes.
+//
+// The last fetched index is cached in the ChatMate cache directory (see
+// platform.GetChatMateCacheDir) for CacheTTL, and used when the registry
+// cannot be reached.
+package registry
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// DefaultURL is the index of the community registry on GitHub.
+const DefaultURL = "https://raw.githubusercontent.com/jonassiebler/chatmate/main/registry/index.json"
+
+// Env is the environment variable that points ChatMate at another registry,
+// such as a company-internal mirror.
+const Env = "CHATMATE_REGISTRY"
+
+// CacheFilename is the name of the cached index in the cache directory.
+const CacheFilename = "registry.json"
+
+// CacheTTL is how long a fetched index is used before it is fetched again.
+const CacheTTL = time.Hour
+
+// Timeout bounds a single request to the registry.
+const Timeout = 30 * time.Second
+
+// Size limits for downloaded documents.
+const (
+	maxIndexSize    = 5 * 1024 * 1024
+	MaxChatmateSize = 10 * 1024 * 1024
+)
+
+// Entry is a chatmate published in the registry.
+//
+// Fields:
+//   - Name: Display name (e.g., "Rust Reviewer")
+//   - Filename: Name of the installed file; must end in .chatmode.md
+//   - Description: What the chatmate does
+//   - Author: Who published it
+//   - Version: Version of the chatmate
+//   - URL: Where the chatmode file is downloaded from, resolved against the
+//     index URL
+//   - SHA256: Hex encoded SHA-256 of the chatmode file
+//   - Versions: Releases of the chatmate that can be installed instead of
+//     the current one
+//   - Breaking: Whether the version changes how the chatmate behaves, so
+//     teams relying on it must review the change before updating
+//   - Changelog: What changed in the version
+type Entry struct {
+	Name        string    `json:"name"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	Version     string    `json:"version,omitempty"`
+	URL         string    `json:"url"`
+	SHA256      string    `json:"sha256"`
+	Versions    []Release `json:"versions,omitempty"`
+	Breaking    bool      `json:"breaking,omitempty"`
+	Changelog   string    `json:"changelog,omitempty"`
+}
+
+// Release is a published version of a registry chatmate.
+//
+// Fields:
+//   - Version: Version of the chatmate (e.g., "1.4.0")
+//   - Released: When the version was published
+//   - URL: Where the chatmode file of this version is downloaded from,
+//     resolved against the index URL
+//   - SHA256: Hex encoded SHA-256 of the chatmode file of this version
+//   - Breaking: Whether the version changes how the chatmate behaves
+//   - Changelog: What changed in the version
+type Release struct {
+	Version   string    `json:"version"`
+	Released  time.Time `json:"released"`
+	URL       string    `json:"url"`
+	SHA256    string    `json:"sha256"`
+	Breaking  bool      `json:"breaking,omitempty"`
+	Changelog string    `json:"changelog,omitempty"`
+}
+
+// Release returns the entry for the given version of the chatmate, which
+// downloads that version instead of the current one. A leading "v" is
+// ignored, so "v1.4.0" selects version 1.4.0.
+//
+// Parameters:
+//   - version: The version to install; the current version is always known
+//
+// Returns:
+//   - Entry: The entry with the URL and checksum of the version
+//   - error: The version is not published
+func (e Entry) Release(version string) (Entry, error) {
+	version = strings.TrimPrefix(version, "v")
+	if version == strings.TrimPrefix(e.Version, "v") {
+		return e, nil
+	}
+	for _, release := range e.Versions {
+		if strings.TrimPrefix(release.Version, "v") == version {
+			return e.at(release), nil
+		}
+	}
+	return Entry{}, fmt.Errorf("%s has no version %s in the registry (available: %s)", e.Name, version, strings.Join(e.releases(), ", "))
+}
+
+// AsOf returns the entry for the latest version of the chatmate released at
+// or before t, such as the wording a team used on a given day.
+//
+// Parameters:
+//   - t: The point in time
+//
+// Returns:
+//   - Entry: The entry with the URL and checksum of the version
+//   - error: No version with a release date before t is published
+func (e Entry) AsOf(t time.Time) (Entry, error) {
+	var latest *Release
+	for n, release := range e.Versions {
+		if release.Released.IsZero() || release.Released.After(t) {
+			continue
+		}
+		if latest == nil || release.Released.After(latest.Released) {
+			latest = &e.Versions[n]
+		}
+	}
+	if latest == nil {
+		return Entry{}, fmt.Errorf("%s has no version released by %s in the registry", e.Name, t.Format(time.DateOnly))
+	}
+	return e.at(*latest), nil
+}
+
+// at returns the entry with the version, URL, and checksum of release.
+func (e Entry) at(release Release) Entry {
+	e.Version = release.Version
+	e.URL = release.URL
+	e.SHA256 = release.SHA256
+	e.Breaking = release.Breaking
+	e.Changelog = release.Changelog
+	return e
+}
+
+// Changes returns the releases an update from one version to another
+// installs, oldest first: the releases after from, up to and including to.
+//
+// Parameters:
+//   - from: The installed version; when empty, nothing is known about what
+//     is installed and only the release of to is returned
+//   - to: The version being installed; the entry's version when empty
+//
+// Returns:
+//   - []Release: The releases, with their breaking markers and changelogs
+func (e Entry) Changes(from, to string) []Release {
+	if to == "" {
+		to = e.Version
+	}
+
+	current := Release{Version: e.Version, URL: e.URL, SHA256: e.SHA256, Breaking: e.Breaking, Changelog: e.Changelog}
+	var changes []Release
+	for _, release := range append([]Release{current}, e.Versions...) {
+		if release.Version == "" {
+			continue
+		}
+		// The current version may also be listed with the releases
+		if n := slices.IndexFunc(changes, func(r Release) bool { return compareVersions(r.Version, release.Version) == 0 }); n >= 0 {
+			changes[n].Breaking = changes[n].Breaking || release.Breaking
+			if changes[n].Changelog == "" {
+				changes[n].Changelog = release.Changelog
+			}
+			continue
+		}
+		after := compareVersions(release.Version, from) > 0
+		if from == "" {
+			after = compareVersions(release.Version, to) == 0
+		}
+		if after && compareVersions(release.Version, to) <= 0 {
+			changes = append(changes, release)
+		}
+	}
+	slices.SortFunc(changes, func(a, b Release) int { return compareVersions(a.Version, b.Version) })
+	return changes
+}
+
+// compareVersions orders versions such as "1.10.0" and "1.9.2" by their
+// dot-separated parts, numerically where both parts are numbers. A leading
+// "v" is ignored and missing parts count as 0.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for n := range max(len(aParts), len(bParts)) {
+		x, y := "0", "0"
+		if n < len(aParts) {
+			x = aParts[n]
+		}
+		if n < len(bParts) {
+			y = bParts[n]
+		}
+		xNumber, xErr := strconv.Atoi(x)
+		yNumber, yErr := strconv.Atoi(y)
+		if xErr == nil && yErr == nil {
+			if order := cmp.Compare(xNumber, yNumber); order != 0 {
+				return order
+			}
+			continue
+		}
+		if order := strings.Compare(x, y); order != 0 {
+			return order
+		}
+	}
+	return 0
+}
+
+// releases returns the versions that can be installed, for error messages.
+func (e Entry) releases() []string {
+	var versions []string
+	if e.Version != "" {
+		versions = append(versions, e.Version)
+	}
+	for _, release := range e.Versions {
+		if !slices.Contains(versions, release.Version) {
+			versions = append(versions, release.Version)
+		}
+	}
+	return versions
+}
+
+// Index is the list of chatmates published in a registry.
+//
+// Fields:
+//   - URL: Where the index was fetched from
+//   - FetchedAt: When the index was fetched
+//   - Chatmates: The published chatmates
+//   - Stale: Whether the registry could not be reached and this is an
+//     expired cached copy
+type Index struct {
+	URL       string    `json:"url"`
+	FetchedAt time.Time `json:"fetchedAt"`
+	Chatmates []Entry   `json:"chatmates"`
+	Stale     bool      `json:"-"`
+}
+
+// Find returns the entry with the given display name or filename; display
+// names match case-insensitively.
+func (ix *Index) Find(name string) (Entry, bool) {
+	for _, entry := range ix.Chatmates {
+		if strings.EqualFold(files.NormalizeFilename(entry.Name), files.NormalizeFilename(name)) || files.SameFilename(entry.Filename, name) || files.SameFilename(entry.Filename, name+".chatmode.md") {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// URL returns the registry index URL: the URL named by Env if it is set,
+// otherwise DefaultURL.
+func URL() string {
+	if u := os.Getenv(Env); u != "" {
+		return u
+	}
+	return DefaultURL
+}
+
+// CachePath returns the full path of the cached index.
+func CachePath() (string, error) {
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, CacheFilename), nil
+}
+
+// Client reads a registry.
+//
+// Fields:
+//   - URL: The index URL; must be an https URL
+//   - CachePath: Where the fetched index is cached; caching is disabled
+//     when empty
+//   - HTTP: The HTTP client; http.DefaultClient when nil
+type Client struct {
+	URL       string
+	CachePath string
+	HTTP      *http.Client
+}
+
+// Index returns the registry index.
+//
+// A cached index from the same URL younger than CacheTTL is used without
+// contacting the registry, unless refresh is set. When the registry cannot
+// be reached, an older cached index is returned with Stale set, so browsing
+// and installing keep working offline.
+//
+// Parameters:
+//   - ctx: Cancels the request (e.g., the command's --timeout)
+//   - refresh: Whether to fetch the index even if the cache is fresh
+//
+// Returns:
+//   - *Index: The index
+//   - error: Connection, response, or validation error when no cached
+//     index is available
+//
+// Example:
+//
+// index, err := client.Index(ctx, false)
+//
+//	if err != nil {
+//	   return fmt.Errorf("failed to read registry: %w", err)
+//	}
+func (c *Client) Index(ctx context.Context, refresh bool) (*Index, error) {
+	cached := c.readCache()
+	if cached != nil && !refresh && time.Since(cached.FetchedAt) < CacheTTL {
+		return cached, nil
+	}
+
+	index, err := c.fetchIndex(ctx)
+	if err != nil {
+		if cached != nil {
+			cached.Stale = true
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	c.writeCache(index)
+	return index, nil
+}
+
+// Download returns the content of a chatmate published in the registry,
+// verified against the SHA-256 of its entry.
+//
+// Parameters:
+//   - ctx: Cancels the request
+//   - entry: The chatmate to download
+//
+// Returns:
+//   - []byte: The chatmode file content
+//   - error: Connection, response, size, or checksum error
+func (c *Client) Download(ctx context.Context, entry Entry) ([]byte, error) {
+	content, err := c.get(ctx, entry.URL, MaxChatmateSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", entry.Name, err)
+	}
+
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, entry.SHA256) {
+		return nil, fmt.Errorf("checksum mismatch for %s: the registry lists %s, downloaded %s", entry.Name, entry.SHA256, actual)
+	}
+	return content, nil
+}
+
+// DownloadURL returns the content of a chatmode file at an https URL that
+// is not published in a registry, such as a raw file of a repository.
+//
+// Parameters:
+//   - ctx: Cancels the request
+//   - rawURL: The file; must be an https URL
+//   - sum: The hex encoded SHA-256 the content must have; not verified
+//     when empty
+//
+// Returns:
+//   - []byte: The chatmode file content
+//   - error: URL, connection, response, size, or checksum error
+func (c *Client) DownloadURL(ctx context.Context, rawURL, sum string) ([]byte, error) {
+	if _, err := parseHTTPS(rawURL); err != nil {
+		return nil, err
+	}
+	content, err := c.get(ctx, rawURL, MaxChatmateSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", rawURL, err)
+	}
+
+	if sum != "" {
+		digest := sha256.Sum256(content)
+		if actual := hex.EncodeToString(digest[:]); !strings.EqualFold(actual, sum) {
+			return nil, fmt.Errorf("checksum mismatch for %s: expected %s, downloaded %s", rawURL, sum, actual)
+		}
+	}
+	return content, nil
+}
+
+// fetchIndex downloads and validates the index.
+func (c *Client) fetchIndex(ctx context.Context) (*Index, error) {
+	base, err := parseHTTPS(c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry URL: %w", err)
+	}
+
+	data, err := c.get(ctx, c.URL, maxIndexSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registry index: %w", err)
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode registry index %s: %w", c.URL, err)
+	}
+	index.URL = c.URL
+	index.FetchedAt = time.Now().UTC()
+
+	for i := range index.Chatmates {
+		if err := resolveEntry(base, &index.Chatmates[i]); err != nil {
+			return nil, fmt.Errorf("invalid registry index %s: %w", c.URL, err)
+		}
+	}
+	return &index, nil
+}
+
+// resolveEntry validates entry and resolves its URL against the index URL.
+func resolveEntry(base *url.URL, entry *Entry) error {
+	if entry.Name == "" {
+		return errors.New("chatmate without a name")
+	}
+	if !strings.HasSuffix(entry.Filename, ".chatmode.md") || filepath.Base(entry.Filename) != entry.Filename ||
+		strings.ContainsAny(entry.Filename, `/\`) {
+		return fmt.Errorf("%s: filename must be a plain .chatmode.md name, got %q", entry.Name, entry.Filename)
+	}
+	// Installed filenames are compared in NFC
+	entry.Filename = files.NormalizeFilename(entry.Filename)
+	if sum, err := hex.DecodeString(entry.SHA256); err != nil || len(sum) != sha256.Size {
+		return fmt.Errorf("%s: sha256 must be a hex encoded SHA-256, got %q", entry.Name, entry.SHA256)
+	}
+
+	ref, err := url.Parse(entry.URL)
+	if err != nil || entry.URL == "" {
+		return fmt.Errorf("%s: invalid url %q", entry.Name, entry.URL)
+	}
+	resolved := base.ResolveReference(ref)
+	if _, err := parseHTTPS(resolved.String()); err != nil {
+		return fmt.Errorf("%s: %w", entry.Name, err)
+	}
+	entry.URL = resolved.String()
+
+	for n := range entry.Versions {
+		release := &entry.Versions[n]
+		if release.Version == "" {
+			return fmt.Errorf("%s: release without a version", entry.Name)
+		}
+		if sum, err := hex.DecodeString(release.SHA256); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("%s %s: sha256 must be a hex encoded SHA-256, got %q", entry.Name, release.Version, release.SHA256)
+		}
+		ref, err := url.Parse(release.URL)
+		if err != nil || release.URL == "" {
+			return fmt.Errorf("%s %s: invalid url %q", entry.Name, release.Version, release.URL)
+		}
+		resolved := base.ResolveReference(ref)
+		if _, err := parseHTTPS(resolved.String()); err != nil {
+			return fmt.Errorf("%s %s: %w", entry.Name, release.Version, err)
+		}
+		release.URL = resolved.String()
+	}
+	return nil
+}
+
+// parseHTTPS parses rawURL and requires it to be an https URL, since
+// chatmates become part of every chat prompt.
+func parseHTTPS(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an https URL", rawURL)
+	}
+	return u, nil
+}
+
+// get downloads rawURL, failing when the body exceeds limit bytes.
+func (c *Client) get(ctx context.Context, rawURL string, limit int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", "chatmate")
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with %s", rawURL, response.Status)
+	}
+
+	// Read one byte past the limit so oversized documents are detected
+	data, err := io.ReadAll(io.LimitReader(response.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", rawURL, limit)
+	}
+	return data, nil
+}
+
+// readCache returns the cached index of c.URL, or nil if there is none.
+func (c *Client) readCache() *Index {
+	if c.CachePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return nil
+	}
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil || index.URL != c.URL {
+		return nil
+	}
+	return &index
+}
+
+// writeCache stores index for later runs. The cache is disposable, so
+// failures are ignored.
+func (c *Client) writeCache(index *Index) {
+	if c.CachePath == "" || files.CheckWrite("write", c.CachePath) != nil {
+		return
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.CachePath), 0755); err != nil {
+		return
+	}
+	_ = files.WriteFileAtomic(c.CachePath, data, 0644)
+}
@@ -0,0 +1,229 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testChatmate = "---\ndescription: Reviews Rust code\n---\nYou review Rust code.\n"
+
+// newTestRegistry serves an index with one chatmate and counts index requests
+func newTestRegistry(t *testing.T, sum string) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.json":
+			requests++
+			fmt.Fprintf(w, `{"chatmates": [{"name": "Rust Reviewer", "filename": "Rust Reviewer.chatmode.md",
+				"url": "mates/rust.chatmode.md", "sha256": %q}]}`, sum)
+		case "/mates/rust.chatmode.md":
+			fmt.Fprint(w, testChatmate)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// TestIndex tests fetching, caching, and offline use of the index
+func TestIndex(t *testing.T) {
+	sum := sha256.Sum256([]byte(testChatmate))
+	server, requests := newTestRegistry(t, hex.EncodeToString(sum[:]))
+	client := &Client{
+		URL:       server.URL + "/index.json",
+		CachePath: filepath.Join(t.TempDir(), "cache", CacheFilename),
+		HTTP:      server.Client(),
+	}
+
+	index, err := client.Index(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	entry, ok := index.Find("rust reviewer")
+	if !ok {
+		t.Fatalf("Expected to find the chatmate by name in %+v", index.Chatmates)
+	}
+	if entry.URL != server.URL+"/mates/rust.chatmode.md" {
+		t.Errorf("Expected the relative URL to be resolved, got %s", entry.URL)
+	}
+	if _, ok := index.Find("Rust Reviewer.chatmode.md"); !ok {
+		t.Error("Expected to find the chatmate by filename")
+	}
+
+	content, err := client.Download(context.Background(), entry)
+	if err != nil || string(content) != testChatmate {
+		t.Errorf("Download returned %q, %v", content, err)
+	}
+
+	// A fresh cache is used without contacting the registry
+	if _, err := client.Index(context.Background(), false); err != nil || *requests != 1 {
+		t.Errorf("Expected the cached index to be used, got %d requests, %v", *requests, err)
+	}
+	if _, err := client.Index(context.Background(), true); err != nil || *requests != 2 {
+		t.Errorf("Expected refresh to fetch the index, got %d requests, %v", *requests, err)
+	}
+
+	// An unreachable registry falls back to the cache
+	server.Close()
+	index, err = client.Index(context.Background(), true)
+	if err != nil || !index.Stale || len(index.Chatmates) != 1 {
+		t.Errorf("Expected the stale cached index, got %+v, %v", index, err)
+	}
+}
+
+// TestDownloadChecksumMismatch tests that tampered content is rejected
+func TestDownloadChecksumMismatch(t *testing.T) {
+	server, _ := newTestRegistry(t, strings.Repeat("0", 64))
+	client := &Client{URL: server.URL + "/index.json", HTTP: server.Client()}
+
+	index, err := client.Index(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	if _, err := client.Download(context.Background(), index.Chatmates[0]); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected a checksum mismatch, got %v", err)
+	}
+}
+
+// TestDownloadURL tests downloading a chatmate that is not in the registry
+func TestDownloadURL(t *testing.T) {
+	server, _ := newTestRegistry(t, "")
+	client := &Client{HTTP: server.Client()}
+	rawURL := server.URL + "/mates/rust.chatmode.md"
+	sum := sha256.Sum256([]byte(testChatmate))
+
+	for _, tc := range []struct {
+		url, sum string
+		wantErr  string
+	}{
+		{url: rawURL},
+		{url: rawURL, sum: strings.ToUpper(hex.EncodeToString(sum[:]))},
+		{url: rawURL, sum: strings.Repeat("0", 64), wantErr: "checksum mismatch"},
+		{url: server.URL + "/missing.chatmode.md", wantErr: "404"},
+		{url: "http://example.com/rust.chatmode.md", wantErr: "not an https URL"},
+	} {
+		content, err := client.DownloadURL(context.Background(), tc.url, tc.sum)
+		switch {
+		case tc.wantErr == "" && (err != nil || string(content) != testChatmate):
+			t.Errorf("DownloadURL(%s, %q) = %q, %v", tc.url, tc.sum, content, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("Expected DownloadURL(%s, %q) to fail with %q, got %v", tc.url, tc.sum, tc.wantErr, err)
+		}
+	}
+}
+
+// TestRelease tests selecting earlier versions of a chatmate
+func TestRelease(t *testing.T) {
+	sum := strings.Repeat("a", 64)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"chatmates": [{"name": "X", "filename": "X.chatmode.md", "version": "1.5.0", "url": "x", "sha256": %q,
+			"versions": [
+				{"version": "1.4.0", "released": "2024-11-02T00:00:00Z", "url": "1.4.0/x", "sha256": %q},
+				{"version": "1.5.0", "released": "2025-01-10T00:00:00Z", "url": "x", "sha256": %q}]}]}`, sum, sum, sum)
+	}))
+	defer server.Close()
+
+	client := &Client{URL: server.URL + "/index.json", HTTP: server.Client()}
+	index, err := client.Index(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Index failed: %v", err)
+	}
+	entry := index.Chatmates[0]
+
+	release, err := entry.Release("v1.4.0")
+	if err != nil || release.Version != "1.4.0" || release.URL != server.URL+"/1.4.0/x" {
+		t.Errorf("Expected version 1.4.0 with a resolved URL, got %+v, %v", release, err)
+	}
+	if release, err := entry.Release("1.5.0"); err != nil || release.URL != entry.URL {
+		t.Errorf("Expected the current version, got %+v, %v", release, err)
+	}
+	if _, err := entry.Release("2.0.0"); err == nil || !strings.Contains(err.Error(), "available: 1.5.0, 1.4.0") {
+		t.Errorf("Expected an unknown version to list the available ones, got %v", err)
+	}
+
+	if release, err := entry.AsOf(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)); err != nil || release.Version != "1.4.0" {
+		t.Errorf("Expected the version released by December, got %+v, %v", release, err)
+	}
+	if release, err := entry.AsOf(time.Now()); err != nil || release.Version != "1.5.0" {
+		t.Errorf("Expected the latest version, got %+v, %v", release, err)
+	}
+	if _, err := entry.AsOf(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("Expected no version before the first release")
+	}
+}
+
+// TestChanges tests listing the releases an update installs
+func TestChanges(t *testing.T) {
+	entry := Entry{Name: "X", Version: "2.0.0", Breaking: true, Changelog: "Asks before closing issues",
+		Versions: []Release{
+			{Version: "1.10.0", Breaking: true, Changelog: "Renamed the checklist"},
+			{Version: "1.9.0"},
+			{Version: "2.0.0"},
+			{Version: "1.2.0", Breaking: true},
+		}}
+
+	versions := func(releases []Release) string {
+		var v []string
+		for _, release := range releases {
+			v = append(v, release.Version)
+		}
+		return strings.Join(v, ",")
+	}
+
+	if changes := entry.Changes("1.9.0", ""); versions(changes) != "1.10.0,2.0.0" || !changes[1].Breaking || changes[1].Changelog != "Asks before closing issues" {
+		t.Errorf("Expected the releases after 1.9.0, got %+v", changes)
+	}
+	if changes := entry.Changes("v1.2.0", "1.10.0"); versions(changes) != "1.9.0,1.10.0" {
+		t.Errorf("Expected the releases up to 1.10.0, got %+v", changes)
+	}
+	if changes := entry.Changes("", "1.2.0"); versions(changes) != "1.2.0" {
+		t.Errorf("Expected only the installed release without a known version, got %+v", changes)
+	}
+	if changes := entry.Changes("2.0.0", ""); len(changes) != 0 {
+		t.Errorf("Expected no changes for the current version, got %+v", changes)
+	}
+
+	release, err := entry.Release("1.10.0")
+	if err != nil || !release.Breaking || release.Changelog != "Renamed the checklist" {
+		t.Errorf("Expected the release to carry its breaking marker, got %+v, %v", release, err)
+	}
+}
+
+// TestIndexValidation tests that insecure or malformed indexes are rejected
+func TestIndexValidation(t *testing.T) {
+	client := &Client{URL: "http://example.com/index.json"}
+	if _, err := client.Index(context.Background(), false); err == nil || !strings.Contains(err.Error(), "https") {
+		t.Errorf("Expected an http registry to be rejected, got %v", err)
+	}
+
+	tests := map[string]string{
+		"path in filename": `{"name": "X", "filename": "../X.chatmode.md", "url": "x", "sha256": "` + strings.Repeat("a", 64) + `"}`,
+		"bad checksum":     `{"name": "X", "filename": "X.chatmode.md", "url": "x", "sha256": "abc"}`,
+		"insecure url":     `{"name": "X", "filename": "X.chatmode.md", "url": "http://example.com/x", "sha256": "` + strings.Repeat("a", 64) + `"}`,
+		"insecure release": `{"name": "X", "filename": "X.chatmode.md", "url": "x", "sha256": "` + strings.Repeat("a", 64) + `",
+			"versions": [{"version": "1.0.0", "url": "http://example.com/x", "sha256": "` + strings.Repeat("a", 64) + `"}]}`,
+	}
+	for name, entry := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"chatmates": [%s]}`, entry)
+			}))
+			defer server.Close()
+
+			client := &Client{URL: server.URL, HTTP: server.Client()}
+			if _, err := client.Index(context.Background(), false); err == nil {
+				t.Error("Expected the index to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Package self manages ChatMate's own installation footprint.
+//
+// Besides the chatmates it installs into the VS Code prompts directory,
+// ChatMate creates files of its own: config, state, cache, and data directories,
+// and the shell completions and man pages installed by the scripts in scripts/.
+// This package locates those artifacts so they can be reported or removed
+// when ChatMate itself is uninstalled.
+package self
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// Artifact is a file or directory created by ChatMate outside the prompts directory.
+//
+// Fields:
+//   - Description: What the artifact is (e.g., "cache directory")
+//   - Path: Absolute path of the file or directory
+type Artifact struct {
+	Description string `json:"description"`
+	Path        string `json:"path"`
+}
+
+// Artifacts returns the ChatMate artifacts that currently exist on this system.
+//
+// Returns:
+//   - []Artifact: Existing artifacts, in a stable order
+//   - error: Failure to resolve the home, config, state, or cache directory
+//
+// Example:
+//
+//	artifacts, err := self.Artifacts()
+//	if err != nil {
+//		return fmt.Errorf("failed to locate ChatMate files: %w", err)
+//	}
+//	for _, artifact := range artifacts {
+//		fmt.Printf("%s: %s\n", artifact.Description, artifact.Path)
+//	}
+func Artifacts() ([]Artifact, error) {
+	candidates, err := candidateArtifacts()
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []Artifact
+	for _, candidate := range candidates {
+		if _, err := os.Lstat(candidate.Path); err == nil {
+			existing = append(existing, candidate)
+		}
+	}
+
+	return existing, nil
+}
+
+// Remove deletes an artifact and everything below it.
+//
+// Parameters:
+//   - artifact: The artifact to delete
+//
+// Returns:
+//   - error: Removal failure (e.g., insufficient permissions)
+func Remove(artifact Artifact) error {
+	if err := files.CheckWrite("remove", artifact.Path); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(artifact.Path); err != nil {
+		return fmt.Errorf("failed to remove %s %s: %w", artifact.Description, artifact.Path, err)
+	}
+	return nil
+}
+
+// candidateArtifacts lists every location ChatMate may have written to.
+func candidateArtifacts() ([]Artifact, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	configDir, err := platform.GetChatMateConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	stateDir, err := platform.GetChatMateStateDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state directory: %w", err)
+	}
+
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache directory: %w", err)
+	}
+
+	dataDir, err := platform.GetChatMateDataDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data directory: %w", err)
+	}
+
+	candidates := []Artifact{
+		{Description: "config directory", Path: configDir},
+		{Description: "state directory", Path: stateDir},
+		{Description: "cache directory", Path: cacheDir},
+	}
+	// On Windows the config directory is the data directory
+	if dataDir != configDir {
+		candidates = append(candidates, Artifact{Description: "data directory", Path: dataDir})
+	}
+
+	candidates = append(candidates, []Artifact{
+
+		// Written by scripts/install-completions.sh
+		{Description: "bash completion", Path: filepath.Join(homeDir, ".bash_completion.d", "chatmate")},
+		{Description: "bash completion", Path: "/etc/bash_completion.d/chatmate"},
+		{Description: "bash completion", Path: "/usr/local/etc/bash_completion.d/chatmate"},
+		{Description: "bash completion", Path: "/opt/homebrew/etc/bash_completion.d/chatmate"},
+		{Description: "zsh completion", Path: filepath.Join(homeDir, ".zsh", "completions", "_chatmate")},
+		{Description: "fish completion", Path: filepath.Join(homeDir, ".config", "fish", "completions", "chatmate.fish")},
+	}...)
+
+	// Written by scripts/install-man-pages.sh or the manual steps it prints
+	for _, manDir := range []string{
+		"/usr/local/share/man/man1",
+		filepath.Join(homeDir, ".local", "share", "man", "man1"),
+	} {
+		pages, _ := filepath.Glob(filepath.Join(manDir, "chatmate*.1"))
+		sort.Strings(pages)
+		for _, page := range pages {
+			candidates = append(candidates, Artifact{Description: "man page", Path: page})
+		}
+	}
+
+	return candidates, nil
+}
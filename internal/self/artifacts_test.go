@@ -0,0 +1,89 @@
+package self
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestArtifacts(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("artifact locations are redirected through XDG variables on Linux only")
+	}
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+
+	artifacts, err := Artifacts()
+	if err != nil {
+		t.Fatalf("Artifacts failed: %v", err)
+	}
+	for _, artifact := range artifacts {
+		if strings.HasPrefix(artifact.Path, home) {
+			t.Errorf("Unexpected artifact in empty home: %+v", artifact)
+		}
+	}
+
+	files := []string{
+		filepath.Join(home, ".config", "chatmate", "config.yaml"),
+		filepath.Join(home, "state", "chatmate", "last-run.json"),
+		filepath.Join(home, "cache", "chatmate", "inventory.json"),
+		filepath.Join(home, ".local", "share", "chatmate", "prompts", "Agent.chatmode.md"),
+		filepath.Join(home, ".zsh", "completions", "_chatmate"),
+		filepath.Join(home, ".local", "share", "man", "man1", "chatmate-hire.1"),
+	}
+	for _, file := range files {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", file, err)
+		}
+	}
+
+	artifacts, err = Artifacts()
+	if err != nil {
+		t.Fatalf("Artifacts failed: %v", err)
+	}
+
+	found := make(map[string]string)
+	for _, artifact := range artifacts {
+		found[artifact.Path] = artifact.Description
+	}
+
+	expected := map[string]string{
+		filepath.Join(home, ".config", "chatmate"):                               "config directory",
+		filepath.Join(home, "state", "chatmate"):                                 "state directory",
+		filepath.Join(home, "cache", "chatmate"):                                 "cache directory",
+		filepath.Join(home, ".local", "share", "chatmate"):                       "data directory",
+		filepath.Join(home, ".zsh", "completions", "_chatmate"):                  "zsh completion",
+		filepath.Join(home, ".local", "share", "man", "man1", "chatmate-hire.1"): "man page",
+	}
+	for path, description := range expected {
+		if found[path] != description {
+			t.Errorf("Expected %s %s to be found, got %q", description, path, found[path])
+		}
+	}
+
+	for _, artifact := range artifacts {
+		// Never touch system-wide files from a test
+		if !strings.HasPrefix(artifact.Path, home) {
+			continue
+		}
+		if err := Remove(artifact); err != nil {
+			t.Errorf("Remove failed: %v", err)
+		}
+	}
+
+	for path := range expected {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package self
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// InstallMethod describes how the running chatmate binary was installed.
+type InstallMethod string
+
+// Supported installation methods.
+const (
+	MethodHomebrew    InstallMethod = "homebrew"
+	MethodGoInstall   InstallMethod = "go install"
+	MethodDevelopment InstallMethod = "development build"
+	MethodManual      InstallMethod = "manual"
+)
+
+// InstallInfo describes the running ChatMate installation.
+//
+// Fields:
+//   - Method: How the binary was installed
+//   - BinaryPath: Resolved path of the running binary
+//   - StateDir: ChatMate's state directory
+//   - CacheDir: ChatMate's cache directory
+//   - SelfUpdate: Whether the binary can be replaced in place; false when a
+//     package manager owns it or the binary is not writable
+//   - UpdateHint: How to update ChatMate for this install method
+type InstallInfo struct {
+	Method     InstallMethod `json:"method"`
+	BinaryPath string        `json:"binaryPath"`
+	StateDir   string        `json:"stateDir"`
+	CacheDir   string        `json:"cacheDir"`
+	SelfUpdate bool          `json:"selfUpdate"`
+	UpdateHint string        `json:"updateHint"`
+}
+
+// DetectInstall reports how the running binary was installed and where
+// ChatMate keeps its files.
+//
+// Returns:
+//   - *InstallInfo: Installation details
+//   - error: Failure to resolve the executable, state, or cache path
+//
+// Example:
+//
+//	info, err := self.DetectInstall()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Installed via %s: %s\n", info.Method, info.UpdateHint)
+func DetectInstall() (*InstallInfo, error) {
+	execPath, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	if resolved, err := filepath.EvalSymlinks(execPath); err == nil {
+		execPath = resolved
+	}
+
+	stateDir, err := platform.GetChatMateStateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &InstallInfo{
+		Method:     detectMethod(execPath, os.Getenv),
+		BinaryPath: execPath,
+		StateDir:   stateDir,
+		CacheDir:   cacheDir,
+	}
+
+	switch info.Method {
+	case MethodHomebrew:
+		info.UpdateHint = "brew upgrade chatmate"
+	case MethodGoInstall:
+		info.UpdateHint = "go install github.com/jonassiebler/chatmate@latest"
+	case MethodDevelopment:
+		info.UpdateHint = "git pull && go build -o chatmate ."
+	default:
+		info.SelfUpdate = isWritable(execPath)
+		info.UpdateHint = "download the latest release from https://github.com/jonassiebler/chatmate/releases"
+	}
+
+	return info, nil
+}
+
+// detectMethod infers the install method from the binary location.
+//
+// The getenv parameter allows tests to supply the environment.
+func detectMethod(binaryPath string, getenv func(string) string) InstallMethod {
+	path := filepath.ToSlash(binaryPath)
+
+	// Binaries run with 'go run' or 'go test' live in the Go build cache
+	if strings.Contains(path, "/go-build") {
+		return MethodDevelopment
+	}
+
+	// Homebrew installs into its Cellar and links into the prefix
+	if strings.Contains(path, "/Cellar/") || strings.Contains(path, "/.linuxbrew/") {
+		return MethodHomebrew
+	}
+	if prefix := getenv("HOMEBREW_PREFIX"); prefix != "" && strings.HasPrefix(path, filepath.ToSlash(prefix)+"/") {
+		return MethodHomebrew
+	}
+
+	dir := filepath.Dir(binaryPath)
+	for _, goBin := range goBinDirs(getenv) {
+		if dir == goBin {
+			return MethodGoInstall
+		}
+	}
+
+	return MethodManual
+}
+
+// goBinDirs returns the directories 'go install' may place binaries in.
+func goBinDirs(getenv func(string) string) []string {
+	var dirs []string
+	if goBin := getenv("GOBIN"); goBin != "" {
+		dirs = append(dirs, filepath.Clean(goBin))
+	}
+
+	goPath := getenv("GOPATH")
+	if goPath == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			goPath = filepath.Join(home, "go")
+		}
+	}
+	for _, entry := range filepath.SplitList(goPath) {
+		if entry != "" {
+			dirs = append(dirs, filepath.Join(entry, "bin"))
+		}
+	}
+
+	return dirs
+}
+
+// isWritable reports whether the binary at path could be replaced in place.
+func isWritable(path string) bool {
+	return platform.CheckDirWritable(filepath.Dir(path)) == nil
+}
@@ -0,0 +1,53 @@
+package self
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectMethod(t *testing.T) {
+	env := map[string]string{
+		"HOMEBREW_PREFIX": "/home/linuxbrew/.linuxbrew",
+		"GOPATH":          filepath.FromSlash("/home/dev/go"),
+		"GOBIN":           filepath.FromSlash("/opt/gobin"),
+	}
+	getenv := func(key string) string { return env[key] }
+
+	testCases := []struct {
+		path     string
+		expected InstallMethod
+	}{
+		{"/opt/homebrew/Cellar/chatmate/1.0.2/bin/chatmate", MethodHomebrew},
+		{"/usr/local/Cellar/chatmate/1.0.2/bin/chatmate", MethodHomebrew},
+		{"/home/linuxbrew/.linuxbrew/bin/chatmate", MethodHomebrew},
+		{"/home/dev/go/bin/chatmate", MethodGoInstall},
+		{"/opt/gobin/chatmate", MethodGoInstall},
+		{"/tmp/go-build123456/b001/exe/chatmate", MethodDevelopment},
+		{"/usr/local/bin/chatmate", MethodManual},
+	}
+
+	for _, tc := range testCases {
+		path := filepath.FromSlash(tc.path)
+		if got := detectMethod(path, getenv); got != tc.expected {
+			t.Errorf("detectMethod(%s) = %q, expected %q", tc.path, got, tc.expected)
+		}
+	}
+}
+
+func TestDetectInstall(t *testing.T) {
+	info, err := DetectInstall()
+	if err != nil {
+		t.Fatalf("DetectInstall failed: %v", err)
+	}
+
+	if info.BinaryPath == "" || info.StateDir == "" || info.CacheDir == "" {
+		t.Errorf("DetectInstall should report all paths: %+v", info)
+	}
+	if info.UpdateHint == "" {
+		t.Error("DetectInstall should explain how to update")
+	}
+	// Test binaries are built by 'go test' in the build cache
+	if info.Method == MethodDevelopment && info.SelfUpdate {
+		t.Error("Development builds should not be self-updatable")
+	}
+}
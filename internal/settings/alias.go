@@ -0,0 +1,160 @@
+package settings
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Alias is a user-defined shortcut for a chatmate command line.
+//
+// Fields:
+//   - Name: What to type instead of the command, e.g. "fix"
+//   - Command: The arguments it stands for, e.g. `hire "Solve Issue"`
+type Alias struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+}
+
+// ListAliases returns the user-defined aliases, sorted by name.
+func (s *Settings) ListAliases() []Alias {
+	aliases := make([]Alias, 0, len(s.Aliases))
+	for name, command := range s.Aliases {
+		aliases = append(aliases, Alias{Name: name, Command: command})
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases
+}
+
+// SetAlias defines name as a shortcut for args, replacing an alias of the
+// same name.
+//
+// Parameters:
+//   - name: The alias; a single word that does not start with "-"
+//   - args: The command and arguments it expands to
+//
+// Returns:
+//   - error: Error if the name or the command is not accepted
+//
+// Example:
+//
+//	// 'chatmate fix' runs 'chatmate hire "Solve Issue"'
+//	err := s.SetAlias("fix", []string{"hire", "Solve Issue"})
+func (s *Settings) SetAlias(name string, args []string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsFunc(name, unicode.IsSpace) {
+		return fmt.Errorf("invalid alias %q: use a single word that does not start with -", name)
+	}
+	if len(args) == 0 || strings.TrimSpace(args[0]) == "" {
+		return fmt.Errorf("alias %s needs a command to run", name)
+	}
+	if s.Aliases == nil {
+		s.Aliases = map[string]string{}
+	}
+	s.Aliases[name] = JoinArgs(args)
+	return nil
+}
+
+// UnsetAlias removes an alias.
+//
+// Returns:
+//   - error: Error if there is no such alias
+func (s *Settings) UnsetAlias(name string) error {
+	if _, ok := s.Aliases[name]; !ok {
+		return fmt.Errorf("no alias named %q", name)
+	}
+	delete(s.Aliases, name)
+	if len(s.Aliases) == 0 {
+		s.Aliases = nil
+	}
+	return nil
+}
+
+// ExpandAlias returns the arguments the alias name stands for.
+//
+// Returns:
+//   - []string: The arguments; nil when there is no such alias
+//   - error: Error if the alias in the file cannot be split into arguments
+func (s *Settings) ExpandAlias(name string) ([]string, error) {
+	command, ok := s.Aliases[name]
+	if !ok {
+		return nil, nil
+	}
+	args, err := SplitArgs(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alias %s: %w", name, err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("invalid alias %s: no command to run", name)
+	}
+	return args, nil
+}
+
+// JoinArgs joins arguments into a command line that SplitArgs splits back,
+// quoting arguments with spaces or quotes in them.
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsFunc(arg, func(r rune) bool { return unicode.IsSpace(r) || r == '"' || r == '\'' || r == '\\' }) {
+			arg = strconv.Quote(arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitArgs splits a command line into arguments at whitespace. Double
+// quotes group words and support Go escapes; single quotes group words
+// literally.
+//
+// Returns:
+//   - []string: The arguments
+//   - error: Error for an unterminated quote
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, fmt.Errorf("unterminated quote in %q", line)
+			}
+			unquoted, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted argument %s", line[i:end+1])
+			}
+			current.WriteString(unquoted)
+			inArg, i = true, end
+		case c == '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quote in %q", line)
+			}
+			current.WriteString(line[i+1 : i+1+end])
+			inArg, i = true, i+1+end
+		case unicode.IsSpace(rune(c)):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteByte(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
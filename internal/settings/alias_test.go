@@ -0,0 +1,80 @@
+package settings
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestSplitArgs tests splitting alias command lines into arguments
+func TestSplitArgs(t *testing.T) {
+	for line, want := range map[string][]string{
+		"list --installed":           {"list", "--installed"},
+		`  hire "Solve Issue"  `:     {"hire", "Solve Issue"},
+		`hire 'Code Review' Testing`: {"hire", "Code Review", "Testing"},
+		`show "say \"hi\""`:          {"show", `say "hi"`},
+		`export --to=""`:             {"export", "--to="},
+		"":                           nil,
+	} {
+		if got, err := SplitArgs(line); err != nil || !slices.Equal(got, want) {
+			t.Errorf("SplitArgs(%q) = %q, %v; expected %q", line, got, err, want)
+		}
+	}
+	for _, line := range []string{`hire "Solve Issue`, "hire 'Solve", `hire "\q"`} {
+		if _, err := SplitArgs(line); err == nil {
+			t.Errorf("Expected SplitArgs(%q) to fail", line)
+		}
+	}
+
+	// Joined arguments split back into the same arguments
+	for _, args := range [][]string{
+		{"hire", "Solve Issue", "--force"},
+		{"show", `it's "quoted"`, `back\slash`, ""},
+		{"list", "tab\there"},
+	} {
+		if got, err := SplitArgs(JoinArgs(args)); err != nil || !slices.Equal(got, args) {
+			t.Errorf("Expected %q to survive JoinArgs, got %q (%s), %v", args, got, JoinArgs(args), err)
+		}
+	}
+}
+
+// TestAliases tests defining, saving, and removing aliases
+func TestAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	var s Settings
+	if err := s.SetAlias("fix", []string{"hire", "Solve Issue"}); err != nil {
+		t.Fatalf("SetAlias failed: %v", err)
+	}
+	for _, name := range []string{"", "-x", "two words"} {
+		if err := s.SetAlias(name, []string{"list"}); err == nil {
+			t.Errorf("Expected SetAlias(%q) to fail", name)
+		}
+	}
+	if err := s.SetAlias("empty", nil); err == nil {
+		t.Error("Expected an alias without a command to fail")
+	}
+
+	if err := Save(path, &s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if args, err := loaded.ExpandAlias("fix"); err != nil || !slices.Equal(args, []string{"hire", "Solve Issue"}) {
+		t.Errorf("Expected fix to expand to hire \"Solve Issue\", got %q, %v", args, err)
+	}
+	if args, err := loaded.ExpandAlias("missing"); err != nil || args != nil {
+		t.Errorf("Expected no expansion for a missing alias, got %q, %v", args, err)
+	}
+	if aliases := loaded.ListAliases(); len(aliases) != 1 || aliases[0].Command != `hire "Solve Issue"` {
+		t.Errorf("Expected the fix alias to be listed, got %+v", aliases)
+	}
+
+	if err := loaded.UnsetAlias("fix"); err != nil || loaded.Aliases != nil {
+		t.Errorf("Expected the last alias to be removed, got %v, %v", loaded.Aliases, err)
+	}
+	if err := loaded.UnsetAlias("fix"); err == nil {
+		t.Error("Expected removing a missing alias to fail")
+	}
+}
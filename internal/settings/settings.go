@@ -0,0 +1,567 @@
+// Package settings reads and writes the user's ChatMate configuration file.
+//
+// The file holds defaults for the global options, so they don't have to be
+// repeated on every command line:
+//
+//	# Manage chatmates in this directory instead of the detected one
+//	promptsDir: ~/dotfiles/vscode/prompts
+//	# VS Code build or fork to install chatmates for
+//	editor: insiders
+//	# Default --output format of informational commands
+//	output: json
+//	# ASCII markers instead of emoji
+//	ascii: true
+//	# Locale of the dates and numbers in reports, or iso
+//	locale: de_DE
+//	# Answer yes to every confirmation prompt, like --yes
+//	assumeYes: false
+//	# Archive the prompts directory before destructive operations
+//	autoBackup: true
+//	# Number of archives of the prompts directory to keep; 0 keeps all
+//	backupKeep: 10
+//	# Number of prompts directory entries scanned for chatmates; 0 scans all
+//	scanLimit: 10000
+//	# Number of chatmates installed at the same time; 1 installs them in turn
+//	concurrency: 4
+//	# Permissions of the chatmate files and directories ChatMate creates
+//	fileMode: "0600"
+//	dirMode: "0700"
+//	# Shell commands run before and after 'chatmate hire' installs chatmates
+//	preInstallHook: ./backup-prompts.sh
+//	postInstallHook: code --reuse-window --command workbench.action.reloadWindow
+//	# How long a hook may run
+//	hookTimeout: 30s
+//	# Run the hooks chatmates declare in their frontmatter
+//	chatmateHooks: false
+//	# Shortcuts for command lines, e.g. 'chatmate fix'
+//	aliases:
+//	  fix: hire "Solve Issue"
+//	# Further places to install chatmates from
+//	sources:
+//	  - https://github.com/acme/chatmates.git
+//
+// Command-line flags take precedence over environment variables, which take
+// precedence over the file. 'chatmate config set', 'get', 'unset', and
+// 'list' manage the file, 'chatmate alias' manages the aliases, and
+// 'chatmate source' the sources.
+package settings
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/state"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+	"gopkg.in/yaml.v3"
+)
+
+// Filename is the name of the configuration file in the ChatMate config
+// directory.
+const Filename = "config.yaml"
+
+// Env is the environment variable that points to a configuration file
+// elsewhere.
+const Env = "CHATMATE_CONFIG"
+
+// Settings is the content of the configuration file. Settings that are not
+// set are nil or empty.
+//
+// Fields:
+//   - PromptsDir: Prompts directory to manage chatmates in, instead of the
+//     one of the detected editor; "~" is the home directory
+//   - Editor: VS Code build or fork to install chatmates for (see
+//     platform.EditorIDs)
+//   - Output: Default output format of informational commands
+//   - ASCII: Whether to print ASCII markers instead of emoji
+//   - Locale: Locale of the dates and numbers in reports, such as "de_DE",
+//     or "iso" for ISO-8601 dates and ungrouped numbers
+//   - AssumeYes: Whether to answer yes to every confirmation prompt
+//   - AutoBackup: Whether to archive the prompts directory before
+//     destructive operations such as 'uninstall --all'
+//   - BackupKeep: Number of archives of the prompts directory to keep
+//   - ScanLimit: Number of prompts directory entries scanned for chatmates
+//   - Concurrency: Number of chatmates installed at the same time
+//   - FileMode: Octal permissions of the chatmate files ChatMate creates,
+//     such as "0600"
+//   - DirMode: Octal permissions of the directories ChatMate creates, such
+//     as "0700"
+//   - PreInstallHook: Shell command run before chatmates are installed
+//   - PostInstallHook: Shell command run after chatmates were installed
+//   - HookTimeout: How long a hook may run, as a duration such as "30s"
+//   - ChatmateHooks: Whether the hooks chatmates declare in their
+//     frontmatter run
+//   - Aliases: Command lines by the alias that runs them (see SetAlias)
+//   - Sources: Directories, git repositories, and https indexes chatmates
+//     are installed from besides the built-in ones (see AddSource)
+type Settings struct {
+	PromptsDir  string `yaml:"promptsDir,omitempty"`
+	Editor      string `yaml:"editor,omitempty"`
+	Output      string `yaml:"output,omitempty"`
+	ASCII       *bool  `yaml:"ascii,omitempty"`
+	Locale      string `yaml:"locale,omitempty"`
+	AssumeYes   *bool  `yaml:"assumeYes,omitempty"`
+	AutoBackup  *bool  `yaml:"autoBackup,omitempty"`
+	BackupKeep  *int   `yaml:"backupKeep,omitempty"`
+	ScanLimit   *int   `yaml:"scanLimit,omitempty"`
+	Concurrency *int   `yaml:"concurrency,omitempty"`
+	FileMode    string `yaml:"fileMode,omitempty"`
+	DirMode     string `yaml:"dirMode,omitempty"`
+
+	PreInstallHook  string `yaml:"preInstallHook,omitempty"`
+	PostInstallHook string `yaml:"postInstallHook,omitempty"`
+	HookTimeout     string `yaml:"hookTimeout,omitempty"`
+	ChatmateHooks   *bool  `yaml:"chatmateHooks,omitempty"`
+
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	Sources []string          `yaml:"sources,omitempty"`
+}
+
+// Key is a setting of the configuration file.
+//
+// Fields:
+//   - Name: The key in the file and in 'chatmate config set'
+//   - Description: What the setting does
+//   - Values: The accepted values; any value is accepted when empty
+type Key struct {
+	Name        string
+	Description string
+	Values      []string
+
+	get   func(s *Settings) string
+	set   func(s *Settings, value string) error
+	unset func(s *Settings)
+}
+
+// Keys are the settings of the configuration file.
+var Keys = []Key{
+	{
+		Name:        "promptsDir",
+		Description: "Prompts directory to manage chatmates in, instead of the editor's",
+		get:         func(s *Settings) string { return s.PromptsDir },
+		set:         setPromptsDir,
+		unset:       func(s *Settings) { s.PromptsDir = "" },
+	},
+	{
+		Name:        "editor",
+		Description: "VS Code build or fork to install chatmates for",
+		Values:      platform.EditorIDs(),
+		get:         func(s *Settings) string { return s.Editor },
+		set:         func(s *Settings, value string) error { s.Editor = strings.ToLower(value); return nil },
+		unset:       func(s *Settings) { s.Editor = "" },
+	},
+	{
+		Name:        "output",
+		Description: "Default output format of informational commands",
+		Values:      []string{"text", "json", "yaml"},
+		get:         func(s *Settings) string { return s.Output },
+		set:         func(s *Settings, value string) error { s.Output = strings.ToLower(value); return nil },
+		unset:       func(s *Settings) { s.Output = "" },
+	},
+	{
+		Name:        "ascii",
+		Description: "Print ASCII markers instead of emoji",
+		Values:      []string{"true", "false"},
+		get:         func(s *Settings) string { return formatBool(s.ASCII) },
+		set:         func(s *Settings, value string) error { return setBool(&s.ASCII, value) },
+		unset:       func(s *Settings) { s.ASCII = nil },
+	},
+	{
+		Name:        "locale",
+		Description: "Locale of the dates and numbers in reports, e.g. de_DE, or iso; instead of the system locale",
+		get:         func(s *Settings) string { return s.Locale },
+		set:         func(s *Settings, value string) error { s.Locale = value; return nil },
+		unset:       func(s *Settings) { s.Locale = "" },
+	},
+	{
+		Name:        "assumeYes",
+		Description: "Answer yes to every confirmation prompt, like --yes",
+		Values:      []string{"true", "false"},
+		get:         func(s *Settings) string { return formatBool(s.AssumeYes) },
+		set:         func(s *Settings, value string) error { return setBool(&s.AssumeYes, value) },
+		unset:       func(s *Settings) { s.AssumeYes = nil },
+	},
+	{
+		Name:        "autoBackup",
+		Description: "Archive the prompts directory before uninstall --all and forced reinstalls",
+		Values:      []string{"true", "false"},
+		get:         func(s *Settings) string { return formatBool(s.AutoBackup) },
+		set:         func(s *Settings, value string) error { return setBool(&s.AutoBackup, value) },
+		unset:       func(s *Settings) { s.AutoBackup = nil },
+	},
+	{
+		Name:        "backupKeep",
+		Description: "Number of archives of the prompts directory to keep; 0 keeps all",
+		get:         func(s *Settings) string { return formatInt(s.BackupKeep) },
+		set:         func(s *Settings, value string) error { return setCount(&s.BackupKeep, value) },
+		unset:       func(s *Settings) { s.BackupKeep = nil },
+	},
+	{
+		Name:        "scanLimit",
+		Description: "Number of prompts directory entries scanned for chatmates; 0 scans all",
+		get:         func(s *Settings) string { return formatInt(s.ScanLimit) },
+		set:         func(s *Settings, value string) error { return setCount(&s.ScanLimit, value) },
+		unset:       func(s *Settings) { s.ScanLimit = nil },
+	},
+	{
+		Name:        "concurrency",
+		Description: "Number of chatmates installed at the same time; 1 installs them one after another",
+		get:         func(s *Settings) string { return formatInt(s.Concurrency) },
+		set:         func(s *Settings, value string) error { return setCount(&s.Concurrency, value) },
+		unset:       func(s *Settings) { s.Concurrency = nil },
+	},
+	{
+		Name:        "fileMode",
+		Description: "Octal permissions of the chatmate files ChatMate creates, e.g. 0600; the umask still applies",
+		get:         func(s *Settings) string { return s.FileMode },
+		set:         func(s *Settings, value string) error { return setMode(&s.FileMode, "fileMode", value, 0600) },
+		unset:       func(s *Settings) { s.FileMode = "" },
+	},
+	{
+		Name:        "dirMode",
+		Description: "Octal permissions of the directories ChatMate creates, e.g. 0700; the umask still applies",
+		get:         func(s *Settings) string { return s.DirMode },
+		set:         func(s *Settings, value string) error { return setMode(&s.DirMode, "dirMode", value, 0700) },
+		unset:       func(s *Settings) { s.DirMode = "" },
+	},
+	{
+		Name:        "preInstallHook",
+		Description: "Shell command run before 'chatmate hire' installs chatmates",
+		get:         func(s *Settings) string { return s.PreInstallHook },
+		set:         func(s *Settings, value string) error { s.PreInstallHook = value; return nil },
+		unset:       func(s *Settings) { s.PreInstallHook = "" },
+	},
+	{
+		Name:        "postInstallHook",
+		Description: "Shell command run after 'chatmate hire' installed chatmates, e.g. to reload VS Code",
+		get:         func(s *Settings) string { return s.PostInstallHook },
+		set:         func(s *Settings, value string) error { s.PostInstallHook = value; return nil },
+		unset:       func(s *Settings) { s.PostInstallHook = "" },
+	},
+	{
+		Name:        "hookTimeout",
+		Description: "How long a hook may run before it is stopped, e.g. 30s",
+		get:         func(s *Settings) string { return s.HookTimeout },
+		set:         setHookTimeout,
+		unset:       func(s *Settings) { s.HookTimeout = "" },
+	},
+	{
+		Name:        "chatmateHooks",
+		Description: "Run the hooks chatmates declare in their frontmatter",
+		Values:      []string{"true", "false"},
+		get:         func(s *Settings) string { return formatBool(s.ChatmateHooks) },
+		set:         func(s *Settings, value string) error { return setBool(&s.ChatmateHooks, value) },
+		unset:       func(s *Settings) { s.ChatmateHooks = nil },
+	},
+}
+
+// Entry is a setting and its value, as listed by 'chatmate config list'.
+//
+// Fields:
+//   - Key: The name of the setting
+//   - Value: The value; empty when the setting is not set
+//   - Description: What the setting does
+//   - Values: The accepted values; any value is accepted when empty
+type Entry struct {
+	Key         string   `json:"key"`
+	Value       string   `json:"value"`
+	Description string   `json:"description"`
+	Values      []string `json:"values,omitempty"`
+}
+
+// List returns every setting with its value, in the order of Keys.
+func (s *Settings) List() []Entry {
+	entries := make([]Entry, 0, len(Keys))
+	for _, key := range Keys {
+		entries = append(entries, Entry{Key: key.Name, Value: s.Get(key), Description: key.Description, Values: key.Values})
+	}
+	return entries
+}
+
+// FindKey returns the setting with the given name, ignoring case.
+//
+// Parameters:
+//   - name: The key, e.g. "editor"
+//
+// Returns:
+//   - Key: The setting
+//   - error: Error listing the available keys if there is no such setting
+func FindKey(name string) (Key, error) {
+	for _, key := range Keys {
+		if strings.EqualFold(key.Name, name) {
+			return key, nil
+		}
+	}
+	names := make([]string, 0, len(Keys))
+	for _, key := range Keys {
+		names = append(names, key.Name)
+	}
+	return Key{}, fmt.Errorf("unknown setting %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Get returns the value of a setting, or an empty string when it is not set.
+func (s *Settings) Get(key Key) string {
+	return key.get(s)
+}
+
+// Set changes a setting after checking the value.
+//
+// Parameters:
+//   - key: The setting (see FindKey)
+//   - value: The new value
+//
+// Returns:
+//   - error: Error if the value is not accepted
+func (s *Settings) Set(key Key, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("%s must not be empty; use 'chatmate config unset %s' to remove it", key.Name, key.Name)
+	}
+	if len(key.Values) > 0 && !slices.Contains(key.Values, strings.ToLower(value)) {
+		return fmt.Errorf("invalid value %q for %s (available: %s)", value, key.Name, strings.Join(key.Values, ", "))
+	}
+	return key.set(s, value)
+}
+
+// Unset removes a setting, so its default applies.
+func (s *Settings) Unset(key Key) {
+	key.unset(s)
+}
+
+// ExpandedPromptsDir returns PromptsDir with a leading "~" replaced by the
+// home directory.
+func (s *Settings) ExpandedPromptsDir() string {
+	dir := s.PromptsDir
+	if dir == "~" || strings.HasPrefix(dir, "~/") || strings.HasPrefix(dir, `~\`) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, dir[1:])
+		}
+	}
+	return dir
+}
+
+// Path returns the location of the configuration file: the file named by Env
+// if it is set, otherwise config.yaml in the roaming config directory (see
+// state.RoamingDir).
+func Path() (string, error) {
+	if path := os.Getenv(Env); path != "" {
+		return path, nil
+	}
+	configDir, err := state.RoamingDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, Filename), nil
+}
+
+// Load reads the configuration file stored at path.
+//
+// A missing file has no settings. Unknown keys and invalid values are
+// rejected, so a misspelled setting is reported instead of being silently
+// ignored. When only a value is invalid, the settings are returned together
+// with the error, so the invalid setting can be unset.
+//
+// Parameters:
+//   - path: Location of the configuration file
+//
+// Returns:
+//   - *Settings: The settings; nil if the file cannot be read or decoded
+//   - error: File read, decoding, or validation error
+//
+// Example:
+//
+//	s, err := settings.Load(path)
+//	if err != nil {
+//		return fmt.Errorf("failed to load settings: %w", err)
+//	}
+func Load(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var s Settings
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&s); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+
+	for _, key := range Keys {
+		if value := s.Get(key); value != "" && len(key.Values) > 0 && !slices.Contains(key.Values, value) {
+			return &s, fmt.Errorf("invalid config file %s: invalid value %q for %s (available: %s)",
+				path, value, key.Name, strings.Join(key.Values, ", "))
+		}
+	}
+	if _, err := s.ParseHookTimeout(); err != nil {
+		return &s, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if _, _, err := s.ParseModes(); err != nil {
+		return &s, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the settings to path, creating its directory if needed.
+//
+// Parameters:
+//   - path: Location of the configuration file
+//   - s: The settings
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func Save(path string, s *Settings) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	if string(data) == "{}\n" {
+		data = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// setPromptsDir sets the prompts directory, made absolute so it does not
+// depend on the working directory. A path starting with "~" is kept, so the
+// file works on every machine it is synced to.
+func setPromptsDir(s *Settings, value string) error {
+	if value != "~" && !strings.HasPrefix(value, "~/") && !strings.HasPrefix(value, `~\`) {
+		absDir, err := filepath.Abs(value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", value, err)
+		}
+		value = absDir
+	}
+	s.PromptsDir = value
+	return nil
+}
+
+// formatBool returns "true" or "false", or an empty string for nil.
+func formatBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
+// formatInt returns value in decimal, or an empty string for nil.
+func formatInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+// setCount parses a setting that is a number of things.
+func setCount(target **int, value string) error {
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return fmt.Errorf("%q is not a number of 0 or more", value)
+	}
+	*target = &parsed
+	return nil
+}
+
+// setHookTimeout checks and sets the hookTimeout setting.
+func setHookTimeout(s *Settings, value string) error {
+	previous := s.HookTimeout
+	s.HookTimeout = value
+	if _, err := s.ParseHookTimeout(); err != nil {
+		s.HookTimeout = previous
+		return err
+	}
+	return nil
+}
+
+// ParseHookTimeout returns the hookTimeout setting as a duration.
+//
+// Returns:
+//   - time.Duration: How long a hook may run; zero when it is not set
+//   - error: Error if the setting is not a positive duration
+func (s *Settings) ParseHookTimeout() (time.Duration, error) {
+	if s.HookTimeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s.HookTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid value %q for hookTimeout: use a duration such as 30s or 2m", s.HookTimeout)
+	}
+	return timeout, nil
+}
+
+// ParseModes returns the fileMode and dirMode settings as permissions.
+//
+// Returns:
+//   - fs.FileMode: The file permissions; zero when fileMode is not set
+//   - fs.FileMode: The directory permissions; zero when dirMode is not set
+//   - error: Error if a value is not an octal mode up to 0777 that lets the
+//     owner read and write files, and list and enter directories
+func (s *Settings) ParseModes() (fs.FileMode, fs.FileMode, error) {
+	fileMode, err := parseMode("fileMode", s.FileMode, 0600)
+	if err != nil {
+		return 0, 0, err
+	}
+	dirMode, err := parseMode("dirMode", s.DirMode, 0700)
+	if err != nil {
+		return 0, 0, err
+	}
+	return fileMode, dirMode, nil
+}
+
+// parseMode parses the octal mode value of the setting name, which must
+// grant the owner permissions.
+func parseMode(name, value string, owner fs.FileMode) (fs.FileMode, error) {
+	if value == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid value %q for %s: use octal permissions such as %04o", value, name, owner)
+	}
+	if fs.FileMode(mode)&owner != owner {
+		return 0, fmt.Errorf("invalid value %q for %s: the owner needs at least %04o to manage chatmates", value, name, owner)
+	}
+	return fs.FileMode(mode), nil
+}
+
+// setMode sets the octal mode setting name, written with four digits.
+func setMode(target *string, name, value string, owner fs.FileMode) error {
+	mode, err := parseMode(name, value, owner)
+	if err != nil {
+		return err
+	}
+	*target = fmt.Sprintf("%04o", mode)
+	return nil
+}
+
+// setBool parses a boolean setting.
+func setBool(target **bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	*target = &parsed
+	return nil
+}
@@ -0,0 +1,189 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoad tests reading and validating the configuration file
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := Load(filepath.Join(dir, "missing.yaml"))
+	if err != nil || s == nil || len(s.List()) != len(Keys) || s.Editor != "" || s.ASCII != nil {
+		t.Errorf("Expected no settings for a missing file, got %+v, %v", s, err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"valid", "promptsDir: ~/prompts\neditor: insiders\noutput: json\nascii: true\nassumeYes: false\n", ""},
+		{"unknown key", "colour: true\n", "field colour not found"},
+		{"unknown editor", "editor: notepad\n", `invalid value "notepad" for editor`},
+		{"unknown output", "output: xml\n", `invalid value "xml" for output`},
+		{"not a boolean", "ascii: maybe\n", "cannot unmarshal"},
+		{"hooks", "preInstallHook: echo pre\npostInstallHook: echo post\nhookTimeout: 2m\nchatmateHooks: true\n", ""},
+		{"not a duration", "hookTimeout: soon\n", `invalid value "soon" for hookTimeout`},
+		{"modes", "fileMode: \"0600\"\ndirMode: \"0750\"\n", ""},
+		{"not octal", "fileMode: \"0689\"\n", `invalid value "0689" for fileMode`},
+		{"owner cannot write", "dirMode: \"0500\"\n", "the owner needs at least 0700"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			_, err := Load(path)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// An invalid value is returned, so it can be unset
+	path := filepath.Join(dir, "unknown editor.yaml")
+	s, err = Load(path)
+	if err == nil || s == nil || s.Editor != "notepad" {
+		t.Errorf("Expected the settings with the error, got %+v, %v", s, err)
+	}
+}
+
+// TestSetGetUnset tests changing settings and saving them
+func TestSetGetUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chatmate", Filename)
+	var s Settings
+
+	set := func(name, value string) error {
+		t.Helper()
+		key, err := FindKey(name)
+		if err != nil {
+			t.Fatalf("FindKey(%q) failed: %v", name, err)
+		}
+		return s.Set(key, value)
+	}
+	for name, value := range map[string]string{
+		"editor":      "Cursor",
+		"OUTPUT":      "yaml",
+		"ascii":       "true",
+		"locale":      "de_DE",
+		"assumeYes":   "false",
+		"backupKeep":  "3",
+		"scanLimit":   "0",
+		"concurrency": "8",
+		"hookTimeout": "45s",
+		"fileMode":    "640",
+		"dirMode":     "0750",
+	} {
+		if err := set(name, value); err != nil {
+			t.Errorf("Set(%s, %s) failed: %v", name, value, err)
+		}
+	}
+	for name, value := range map[string]string{"editor": "notepad", "ascii": "maybe", "output": "", "backupKeep": "-1", "scanLimit": "lots", "hookTimeout": "-5s", "fileMode": "01644", "dirMode": "0644"} {
+		if err := set(name, value); err == nil {
+			t.Errorf("Expected Set(%s, %q) to fail", name, value)
+		}
+	}
+	if _, err := FindKey("colour"); err == nil || !strings.Contains(err.Error(), "promptsDir") {
+		t.Errorf("Expected an error listing the settings, got %v", err)
+	}
+
+	// Relative prompts directories are made absolute; "~" is kept
+	if err := set("promptsDir", "prompts"); err != nil || !filepath.IsAbs(s.PromptsDir) {
+		t.Errorf("Expected an absolute prompts directory, got %q, %v", s.PromptsDir, err)
+	}
+	if err := set("promptsDir", "~/prompts"); err != nil || s.PromptsDir != "~/prompts" {
+		t.Errorf("Expected ~ to be kept, got %q, %v", s.PromptsDir, err)
+	}
+	home, _ := os.UserHomeDir()
+	if got := s.ExpandedPromptsDir(); got != filepath.Join(home, "prompts") {
+		t.Errorf("Expected ~ to expand to the home directory, got %q", got)
+	}
+
+	if err := Save(path, &s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := map[string]string{"promptsDir": "~/prompts", "editor": "cursor", "output": "yaml", "ascii": "true", "locale": "de_DE", "assumeYes": "false", "backupKeep": "3", "scanLimit": "0", "concurrency": "8", "hookTimeout": "45s", "fileMode": "0640", "dirMode": "0750"}
+	for _, entry := range loaded.List() {
+		if entry.Value != want[entry.Key] {
+			t.Errorf("Expected %s to be %q after saving, got %q", entry.Key, want[entry.Key], entry.Value)
+		}
+	}
+
+	for _, key := range Keys {
+		loaded.Unset(key)
+	}
+	if err := Save(path, loaded); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if content, err := os.ReadFile(path); err != nil || len(content) != 0 {
+		t.Errorf("Expected an empty file without settings, got %q, %v", content, err)
+	}
+}
+
+// TestPath tests locating the configuration file
+func TestPath(t *testing.T) {
+	t.Setenv(Env, "/custom/chatmate.yaml")
+	if path, err := Path(); err != nil || path != "/custom/chatmate.yaml" {
+		t.Errorf("Expected the file named by %s, got %q, %v", Env, path, err)
+	}
+
+	t.Setenv(Env, "")
+	path, err := Path()
+	if err != nil {
+		t.Fatalf("Path failed: %v", err)
+	}
+	if filepath.Base(path) != Filename || filepath.Base(filepath.Dir(path)) != "chatmate" {
+		t.Errorf("Expected config.yaml in the chatmate config directory, got %q", path)
+	}
+}
+
+// TestSources tests adding, saving, and removing sources
+func TestSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	var s Settings
+	for _, source := range []string{"/srv/chatmates", " https://github.com/acme/chatmates.git "} {
+		if err := s.AddSource(source); err != nil {
+			t.Fatalf("AddSource(%q) failed: %v", source, err)
+		}
+	}
+	for _, source := range []string{"", "/srv/chatmates"} {
+		if err := s.AddSource(source); err == nil {
+			t.Errorf("Expected AddSource(%q) to fail", source)
+		}
+	}
+
+	if err := Save(path, &s); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Sources) != 2 || loaded.Sources[1] != "https://github.com/acme/chatmates.git" {
+		t.Errorf("Expected the sources in order, got %q", loaded.Sources)
+	}
+
+	for _, source := range []string{"https://github.com/acme/chatmates.git", "/srv/chatmates"} {
+		if err := loaded.RemoveSource(source); err != nil {
+			t.Errorf("RemoveSource(%q) failed: %v", source, err)
+		}
+	}
+	if loaded.Sources != nil {
+		t.Errorf("Expected no sources, got %q", loaded.Sources)
+	}
+	if err := loaded.RemoveSource("/srv/chatmates"); err == nil {
+		t.Error("Expected removing a missing source to fail")
+	}
+}
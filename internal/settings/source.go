@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AddSource appends a source of chatmates, which is looked up after the
+// built-in chatmates and the sources added before it.
+//
+// Parameters:
+//   - source: A directory, git URL, or https index URL (see
+//     manager.ParseSource)
+//
+// Returns:
+//   - error: Error if the source is empty or already added
+//
+// Example:
+//
+//	err := s.AddSource("https://github.com/acme/chatmates.git")
+func (s *Settings) AddSource(source string) error {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return fmt.Errorf("source must not be empty")
+	}
+	if slices.Contains(s.Sources, source) {
+		return fmt.Errorf("%s is already a source", source)
+	}
+	s.Sources = append(s.Sources, source)
+	return nil
+}
+
+// RemoveSource removes a source of chatmates.
+//
+// Returns:
+//   - error: Error if there is no such source
+func (s *Settings) RemoveSource(source string) error {
+	i := slices.Index(s.Sources, strings.TrimSpace(source))
+	if i < 0 {
+		return fmt.Errorf("no source %q", source)
+	}
+	s.Sources = slices.Delete(s.Sources, i, i+1)
+	if len(s.Sources) == 0 {
+		s.Sources = nil
+	}
+	return nil
+}
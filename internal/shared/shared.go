@@ -0,0 +1,282 @@
+// Package shared coordinates several users managing one prompts directory.
+//
+// Some teams point every member's VS Code at a prompts directory on a network
+// share. In shared mode ChatMate keeps a manifest in that directory recording
+// who installed each chatmate, and serializes changes with an advisory lock
+// file, so two users installing at the same time cannot corrupt each other's
+// changes.
+//
+// Files in the shared prompts directory:
+//   - .chatmate-manifest.json: Owner and checksum of every chatmate written
+//     in shared mode; its presence turns shared mode on for every user
+//   - .chatmate.lock: Held while a user changes the directory
+//
+// Lock files are used instead of operating system locks because flock and
+// LockFileEx are unreliable on SMB and NFS shares.
+package shared
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// ManifestFilename is the name of the manifest in a shared prompts directory.
+const ManifestFilename = ".chatmate-manifest.json"
+
+// LockFilename is the name of the lock file in a shared prompts directory.
+const LockFilename = ".chatmate.lock"
+
+// Env is the environment variable that turns shared mode on for a prompts
+// directory that has no manifest yet. Set it to "1" once; the manifest
+// written by the next change keeps shared mode on for everyone.
+const Env = "CHATMATE_SHARED"
+
+// StaleAfter is how old a lock must be before it is considered abandoned
+// (e.g., by a crashed process or a machine that lost the share) and broken.
+const StaleAfter = 10 * time.Minute
+
+// Entry records who last wrote a chatmate in a shared prompts directory.
+//
+// Fields:
+//   - Filename: The chatmate filename
+//   - Owner: The user who wrote it, as user@host
+//   - Checksum: SHA-256 of the content that was written, hex encoded
+//   - UpdatedAt: When it was written
+type Entry struct {
+	Filename  string    `json:"filename"`
+	Owner     string    `json:"owner"`
+	Checksum  string    `json:"checksum"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Manifest lists the owners of the chatmates in a shared prompts directory.
+type Manifest struct {
+	Files []Entry `json:"files"`
+}
+
+// Get returns the entry of filename, in whichever Unicode normalization it
+// was recorded (see files.SameFilename).
+func (m *Manifest) Get(filename string) (Entry, bool) {
+	for _, entry := range m.Files {
+		if files.SameFilename(entry.Filename, filename) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Set adds or replaces the entry of a file, keeping entries sorted. The
+// filename is stored in Unicode NFC.
+func (m *Manifest) Set(entry Entry) {
+	m.Remove(entry.Filename)
+	entry.Filename = files.NormalizeFilename(entry.Filename)
+	m.Files = append(m.Files, entry)
+	sort.Slice(m.Files, func(i, j int) bool {
+		return m.Files[i].Filename < m.Files[j].Filename
+	})
+}
+
+// Remove deletes the entry of filename and reports whether it existed.
+func (m *Manifest) Remove(filename string) bool {
+	for i, entry := range m.Files {
+		if files.SameFilename(entry.Filename, filename) {
+			m.Files = append(m.Files[:i], m.Files[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether dir is managed in shared mode: it has a manifest,
+// or Env is set.
+func Enabled(dir string) bool {
+	switch strings.ToLower(os.Getenv(Env)) {
+	case "1", "true", "yes":
+		return true
+	}
+	_, err := os.Stat(filepath.Join(dir, ManifestFilename))
+	return err == nil
+}
+
+// CurrentOwner identifies the current user as user@host for ownership
+// annotations.
+func CurrentOwner() string {
+	name := "unknown"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		name = current.Username
+	} else if env := os.Getenv("USER"); env != "" {
+		name = env
+	} else if env := os.Getenv("USERNAME"); env != "" {
+		name = env
+	}
+	// Windows usernames include the domain
+	if i := strings.LastIndex(name, `\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return name
+	}
+	return name + "@" + host
+}
+
+// ReadManifest loads the manifest of dir.
+//
+// A missing manifest is returned as an empty manifest.
+//
+// Parameters:
+//   - dir: The shared prompts directory
+//
+// Returns:
+//   - *Manifest: The manifest
+//   - error: File read or decoding error
+func ReadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFilename)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Manifest{Files: []Entry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// WriteManifest stores the manifest of dir.
+//
+// The manifest is written to a temporary file and renamed into place, so
+// users reading it concurrently never see a partial manifest.
+//
+// Parameters:
+//   - dir: The shared prompts directory
+//   - manifest: The manifest to persist
+//
+// Returns:
+//   - error: Encoding or file write error
+func WriteManifest(dir string, manifest *Manifest) error {
+	if err := files.CheckWrite("write", filepath.Join(dir, ManifestFilename)); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	path := filepath.Join(dir, ManifestFilename)
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// Lock is a held advisory lock on a shared prompts directory.
+type Lock struct {
+	path string
+}
+
+// lockInfo is the content of a lock file, telling other users who holds it.
+type lockInfo struct {
+	Owner      string    `json:"owner"`
+	PID        int       `json:"pid"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+}
+
+// Acquire takes the advisory lock of dir, waiting up to wait for another
+// user to release it.
+//
+// A lock older than StaleAfter is considered abandoned and broken.
+//
+// Parameters:
+//   - dir: The shared prompts directory
+//   - wait: How long to wait for a lock held by someone else
+//
+// Returns:
+//   - *Lock: The held lock; release it with Release
+//   - error: Lock held by someone else for longer than wait, or a file error
+//
+// Example:
+//
+// lock, err := shared.Acquire(promptsDir, 30*time.Second)
+//
+//	if err != nil {
+//	   return err
+//	}
+//
+// defer lock.Release()
+func Acquire(dir string, wait time.Duration) (*Lock, error) {
+	path := filepath.Join(dir, LockFilename)
+	if err := files.CheckWrite("create", path); err != nil {
+		return nil, err
+	}
+	info, err := json.Marshal(lockInfo{Owner: CurrentOwner(), PID: os.Getpid(), AcquiredAt: time.Now().UTC()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %w", err)
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = file.Write(info)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock %s: %w", path, err)
+			}
+			return &Lock{path: path}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock %s: %w", path, err)
+		}
+
+		// Break locks left behind by crashed processes
+		if stat, err := os.Stat(path); err == nil && time.Since(stat.ModTime()) > StaleAfter {
+			os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the shared prompts directory is locked by %s; try again later or remove %s if no one is using ChatMate",
+				lockHolder(path), path)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Release gives up the lock.
+func (l *Lock) Release() error {
+	if err := os.Remove(l.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to release lock %s: %w", l.path, err)
+	}
+	return nil
+}
+
+// lockHolder describes who holds the lock at path.
+func lockHolder(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "another user"
+	}
+	var info lockInfo
+	if err := json.Unmarshal(data, &info); err != nil || info.Owner == "" {
+		return "another user"
+	}
+	return fmt.Sprintf("%s (pid %d, since %s)", info.Owner, info.PID, info.AcquiredAt.Local().Format("15:04:05"))
+}
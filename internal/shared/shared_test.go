@@ -0,0 +1,89 @@
+package shared
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestManifestRoundTrip tests recording, persisting, and removing owners
+func TestManifestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(Env, "")
+
+	if Enabled(dir) {
+		t.Error("A directory without a manifest should not be shared")
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil || len(manifest.Files) != 0 {
+		t.Fatalf("A missing manifest should read as empty, got %v, %v", manifest, err)
+	}
+
+	updatedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	manifest.Set(Entry{Filename: "B.chatmode.md", Owner: "bob@host", Checksum: "b", UpdatedAt: updatedAt})
+	manifest.Set(Entry{Filename: "A.chatmode.md", Owner: "alice@host", Checksum: "a", UpdatedAt: updatedAt})
+	// Writing again replaces the entry
+	manifest.Set(Entry{Filename: "A.chatmode.md", Owner: "bob@host", Checksum: "a2", UpdatedAt: updatedAt})
+
+	if err := WriteManifest(dir, manifest); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+	if !Enabled(dir) {
+		t.Error("A directory with a manifest should be shared")
+	}
+
+	loaded, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	if len(loaded.Files) != 2 || loaded.Files[0].Filename != "A.chatmode.md" || loaded.Files[0].Owner != "bob@host" {
+		t.Fatalf("Expected 2 sorted entries with the replaced one, got %+v", loaded.Files)
+	}
+	if !loaded.Remove("A.chatmode.md") || loaded.Remove("A.chatmode.md") {
+		t.Error("Remove should report whether the entry existed")
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the manifest in the directory, got %d entries", len(entries))
+	}
+}
+
+// TestAcquire tests that the lock excludes other users and that stale locks are broken
+func TestAcquire(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	_, err = Acquire(dir, 200*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), CurrentOwner()) {
+		t.Errorf("Expected a held lock to name its holder, got %v", err)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	lock, err = Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Expected a released lock to be available, got %v", err)
+	}
+
+	// A lock left behind by a crashed process is broken
+	old := time.Now().Add(-2 * StaleAfter)
+	if err := os.Chtimes(filepath.Join(dir, LockFilename), old, old); err != nil {
+		t.Fatalf("Failed to age lock: %v", err)
+	}
+	if _, err := Acquire(dir, 0); err != nil {
+		t.Errorf("Expected a stale lock to be broken, got %v", err)
+	}
+	if err := lock.Release(); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Package shim generates compatibility wrappers for retired ChatMate scripts.
+//
+// Before the Go CLI existed, ChatMate was installed with a hire.sh shell
+// script, and existing documentation and automation still call it. The
+// wrappers generated here keep those entry points working by translating the
+// old arguments and delegating to the chatmate binary, while warning that the
+// script is deprecated.
+package shim
+
+import (
+	"io"
+	"text/template"
+)
+
+// HireScriptName is the filename of the legacy installation script.
+const HireScriptName = "hire.sh"
+
+// hireScript is the hire.sh wrapper. It accepts the legacy commands and
+// options and runs the equivalent chatmate command.
+var hireScript = template.Must(template.New(HireScriptName).Parse(`#!/bin/bash
+
+# hire.sh - DEPRECATED compatibility wrapper for the chatmate CLI
+#
+# Generated by 'chatmate generate-shim' (chatmate {{.Version}}). Do not edit;
+# regenerate instead. The shell implementation has been retired: this script
+# translates the legacy commands and delegates to the chatmate binary.
+#
+#   ./hire.sh [install]   ->  chatmate hire --force
+#   ./hire.sh uninstall   ->  chatmate uninstall --all
+#   ./hire.sh list        ->  chatmate list --available
+#
+# Set CHATMATE_BIN to use a specific chatmate binary.
+
+set -e
+
+show_usage() {
+    cat << USAGE
+Usage: $0 [OPTIONS] [COMMAND]
+
+DEPRECATED: use the chatmate command directly (see 'chatmate --help').
+
+COMMANDS:
+    install     Install all chatmate files (default)   -> chatmate hire --force
+    uninstall   Remove all chatmate files from VS Code -> chatmate uninstall --all
+    list        List available chatmate files          -> chatmate list --available
+    help        Show this help message
+
+OPTIONS:
+    -h, --help     Show this help message
+    -v, --verbose  Enable verbose output
+    -n, --dry-run  Show the chatmate command without running it
+
+USAGE
+}
+
+VERBOSE=false
+DRY_RUN=false
+COMMAND="install"
+
+while [[ $# -gt 0 ]]; do
+    case $1 in
+        -h|--help)
+            show_usage
+            exit 0
+            ;;
+        -v|--verbose)
+            VERBOSE=true
+            shift
+            ;;
+        -n|--dry-run)
+            DRY_RUN=true
+            shift
+            ;;
+        install|uninstall|list|help)
+            COMMAND="$1"
+            shift
+            ;;
+        *)
+            echo "Error: Unknown option $1" >&2
+            show_usage
+            exit 1
+            ;;
+    esac
+done
+
+case "$COMMAND" in
+    install)
+        ARGS=(hire --force)
+        ;;
+    uninstall)
+        ARGS=(uninstall --all)
+        ;;
+    list)
+        ARGS=(list --available)
+        ;;
+    help)
+        show_usage
+        exit 0
+        ;;
+esac
+
+if [[ "$VERBOSE" == "true" ]]; then
+    ARGS+=(--verbose)
+fi
+
+echo "⚠️  hire.sh is deprecated and will be removed; use 'chatmate ${ARGS[*]}' instead" >&2
+
+if [[ "$DRY_RUN" == "true" ]]; then
+    echo "[DRY RUN] Would run: chatmate ${ARGS[*]}"
+    exit 0
+fi
+
+# Locate the chatmate binary
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+if [[ -n "$CHATMATE_BIN" ]]; then
+    :
+elif command -v chatmate >/dev/null 2>&1; then
+    CHATMATE_BIN="$(command -v chatmate)"
+elif [[ -x "$SCRIPT_DIR/chatmate" ]]; then
+    CHATMATE_BIN="$SCRIPT_DIR/chatmate"
+elif [[ -x "$SCRIPT_DIR/../chatmate" ]]; then
+    CHATMATE_BIN="$SCRIPT_DIR/../chatmate"
+else
+    echo "Error: chatmate binary not found. Install it with Homebrew or 'go build', or set CHATMATE_BIN." >&2
+    exit 1
+fi
+
+if [[ "$COMMAND" == "list" ]]; then
+    exec "$CHATMATE_BIN" "${ARGS[@]}"
+fi
+
+# hire.sh never asked for confirmation, so answer the chatmate prompt
+echo y | "$CHATMATE_BIN" "${ARGS[@]}"
+`))
+
+// WriteHireScript writes the hire.sh compatibility wrapper to w.
+//
+// Parameters:
+//   - w: Destination for the script
+//   - version: ChatMate version recorded in the script header
+//
+// Returns:
+//   - error: Template execution or write error
+func WriteHireScript(w io.Writer, version string) error {
+	return hireScript.Execute(w, struct{ Version string }{Version: version})
+}
@@ -0,0 +1,92 @@
+package shim
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWriteHireScript(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHireScript(&buf, "1.2.3"); err != nil {
+		t.Fatalf("WriteHireScript failed: %v", err)
+	}
+	script := buf.String()
+
+	for _, expected := range []string{"#!/bin/bash", "chatmate 1.2.3", "DEPRECATED", "hire --force", "uninstall --all", "list --available"} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("Script should contain %q", expected)
+		}
+	}
+}
+
+func TestHireScriptDelegates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hire.sh requires bash")
+	}
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, HireScriptName)
+	var buf bytes.Buffer
+	if err := WriteHireScript(&buf, "test"); err != nil {
+		t.Fatalf("WriteHireScript failed: %v", err)
+	}
+	if err := os.WriteFile(scriptPath, buf.Bytes(), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	// Fake chatmate binary that records its arguments and stdin
+	fakeBin := filepath.Join(dir, "fake-chatmate")
+	fake := "#!/bin/bash\necho \"args: $*\"\nread -r answer || true\necho \"answer: $answer\"\n"
+	if err := os.WriteFile(fakeBin, []byte(fake), 0755); err != nil {
+		t.Fatalf("Failed to write fake binary: %v", err)
+	}
+
+	testCases := []struct {
+		args     []string
+		expected string
+	}{
+		{nil, "args: hire --force"},
+		{[]string{"-v", "install"}, "args: hire --force --verbose"},
+		{[]string{"uninstall"}, "args: uninstall --all"},
+		{[]string{"list"}, "args: list --available"},
+		{[]string{"--dry-run"}, "[DRY RUN] Would run: chatmate hire --force"},
+	}
+
+	for _, tc := range testCases {
+		cmd := exec.Command(bash, append([]string{scriptPath}, tc.args...)...)
+		cmd.Env = append(os.Environ(), "CHATMATE_BIN="+fakeBin)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			t.Fatalf("hire.sh %v failed: %v\n%s", tc.args, err, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), tc.expected) {
+			t.Errorf("hire.sh %v: expected %q in output, got %q", tc.args, tc.expected, stdout.String())
+		}
+		if !strings.Contains(stderr.String(), "deprecated") {
+			t.Errorf("hire.sh %v: expected deprecation warning on stderr, got %q", tc.args, stderr.String())
+		}
+	}
+
+	// Modifying commands are confirmed automatically, like the old script
+	cmd := exec.Command(bash, scriptPath, "install")
+	cmd.Env = append(os.Environ(), "CHATMATE_BIN="+fakeBin)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("hire.sh install failed: %v", err)
+	}
+	if !strings.Contains(string(out), "answer: y") {
+		t.Errorf("Expected confirmation to be answered, got %q", string(out))
+	}
+}
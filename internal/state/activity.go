@@ -0,0 +1,136 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// ActivityFilename is the name of the chatmate activity log.
+const ActivityFilename = "activity.jsonl"
+
+// MaxActivityEntries is the number of events kept in the activity log; the
+// oldest events are dropped when a new one is appended.
+const MaxActivityEntries = 1000
+
+// Actions recorded in the activity log.
+const (
+	// ActivityInstall marks a chatmate installed where none was recorded
+	ActivityInstall = "install"
+	// ActivityUpdate marks a recorded chatmate replaced with new content
+	ActivityUpdate = "update"
+	// ActivityUninstall marks a chatmate removed from the prompts directory
+	ActivityUninstall = "uninstall"
+)
+
+// ActivityEvent is a change to an installed chatmate.
+//
+// Fields:
+//   - Action: What happened (see ActivityInstall and friends)
+//   - Filename: The chatmate filename in the prompts directory
+//   - PromptsDir: The prompts directory containing the file
+//   - Source: Where installed content came from (see SourceEmbedded and
+//     friends); empty for uninstalls
+//   - Version: Version of the chatmate from its frontmatter after an install
+//     or update, before an uninstall
+//   - PreviousVersion: Version that an update replaced
+//   - Timestamp: When the change was made
+type ActivityEvent struct {
+	Action          string    `json:"action"`
+	Filename        string    `json:"filename"`
+	PromptsDir      string    `json:"promptsDir"`
+	Source          string    `json:"source,omitempty"`
+	Version         string    `json:"version,omitempty"`
+	PreviousVersion string    `json:"previousVersion,omitempty"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// ActivityPath returns the full path of the activity log.
+func ActivityPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, ActivityFilename), nil
+}
+
+// AppendActivity records an event at the end of the activity log at path.
+//
+// Like the operation history, the log is stored as JSON lines, oldest first,
+// and trimmed to the newest MaxActivityEntries events.
+//
+// Parameters:
+//   - path: Location of the activity log
+//   - event: The change to record
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func AppendActivity(path string, event ActivityEvent) error {
+	events, err := ReadActivity(path)
+	if err != nil {
+		return err
+	}
+	events = append(events, event)
+	if len(events) > MaxActivityEntries {
+		events = events[len(events)-MaxActivityEntries:]
+	}
+
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("failed to encode activity: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write activity log %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadActivity loads the activity log at path, oldest first.
+//
+// A missing log is empty rather than an error, and lines that cannot be
+// decoded (e.g., from an interrupted write) are skipped.
+//
+// Parameters:
+//   - path: Location of the activity log
+//
+// Returns:
+//   - []ActivityEvent: The recorded events
+//   - error: File read error
+func ReadActivity(path string) ([]ActivityEvent, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read activity log %s: %w", path, err)
+	}
+
+	var events []ActivityEvent
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event ActivityEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
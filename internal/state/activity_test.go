@@ -0,0 +1,51 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestActivity tests appending to, trimming, and reading the activity log
+func TestActivity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", ActivityFilename)
+
+	events, err := ReadActivity(path)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("Expected an empty log before the first write, got %v, %v", events, err)
+	}
+
+	start := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxActivityEntries+5; i++ {
+		event := ActivityEvent{Action: ActivityInstall, Filename: fmt.Sprintf("%d.chatmode.md", i), Timestamp: start.Add(time.Duration(i) * time.Minute)}
+		if err := AppendActivity(path, event); err != nil {
+			t.Fatalf("AppendActivity failed: %v", err)
+		}
+	}
+
+	events, err = ReadActivity(path)
+	if err != nil {
+		t.Fatalf("ReadActivity failed: %v", err)
+	}
+	if len(events) != MaxActivityEntries {
+		t.Fatalf("Expected %d events, got %d", MaxActivityEntries, len(events))
+	}
+	if events[0].Filename != "5.chatmode.md" || !events[len(events)-1].Timestamp.Equal(start.Add((MaxActivityEntries+4)*time.Minute)) {
+		t.Errorf("Expected the oldest events to be dropped, got %+v..%+v", events[0], events[len(events)-1])
+	}
+
+	// Corrupt lines are skipped
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open activity log: %v", err)
+	}
+	_, _ = file.WriteString("{\"action\": \"unin")
+	_ = file.Close()
+
+	events, err = ReadActivity(path)
+	if err != nil || len(events) != MaxActivityEntries {
+		t.Errorf("Expected the corrupt line to be skipped, got %d events, %v", len(events), err)
+	}
+}
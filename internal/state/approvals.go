@@ -0,0 +1,142 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// ApprovalsFilename is the name of the queue of installations awaiting
+// approval.
+const ApprovalsFilename = "approvals.json"
+
+// ApprovalRequest is an installation that the team policy requires to be
+// approved first.
+//
+// Fields:
+//   - Filename: The chatmate filename that would be installed
+//   - PromptsDir: The prompts directory it would be installed into
+//   - Source: Where the chatmate would be installed from (see SourceEmbedded
+//     and friends)
+//   - Location: The repository URL, directory, or download URL of the content
+//   - Checksum: SHA-256 of the content that was requested, hex encoded
+//   - RequestedAt: When the installation was requested
+type ApprovalRequest struct {
+	Filename    string    `json:"filename"`
+	PromptsDir  string    `json:"promptsDir"`
+	Source      string    `json:"source"`
+	Location    string    `json:"location,omitempty"`
+	Checksum    string    `json:"checksum"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+// ApprovalQueue holds the pending approval requests across prompts
+// directories.
+type ApprovalQueue struct {
+	Requests []ApprovalRequest `json:"requests"`
+}
+
+// In returns the pending requests for promptsDir.
+func (q *ApprovalQueue) In(promptsDir string) []ApprovalRequest {
+	var requests []ApprovalRequest
+	for _, request := range q.Requests {
+		if request.PromptsDir == promptsDir {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Add adds or replaces the request for a file, keeping requests sorted. The
+// filename is stored in Unicode NFC.
+func (q *ApprovalQueue) Add(request ApprovalRequest) {
+	q.Remove(request.PromptsDir, request.Filename)
+	request.Filename = files.NormalizeFilename(request.Filename)
+	q.Requests = append(q.Requests, request)
+	sort.Slice(q.Requests, func(i, j int) bool {
+		if q.Requests[i].PromptsDir != q.Requests[j].PromptsDir {
+			return q.Requests[i].PromptsDir < q.Requests[j].PromptsDir
+		}
+		return q.Requests[i].Filename < q.Requests[j].Filename
+	})
+}
+
+// Remove deletes the request for filename in promptsDir and reports whether it existed.
+func (q *ApprovalQueue) Remove(promptsDir, filename string) bool {
+	for i, request := range q.Requests {
+		if request.PromptsDir == promptsDir && files.SameFilename(request.Filename, filename) {
+			q.Requests = append(q.Requests[:i], q.Requests[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalsPath returns the full path of the approval queue.
+func ApprovalsPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, ApprovalsFilename), nil
+}
+
+// ReadApprovals loads the approval queue stored at path.
+//
+// A missing queue is returned as an empty queue.
+//
+// Parameters:
+//   - path: Location of the approval queue file
+//
+// Returns:
+//   - *ApprovalQueue: The pending requests
+//   - error: File read or decoding error
+func ReadApprovals(path string) (*ApprovalQueue, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ApprovalQueue{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval requests %s: %w", path, err)
+	}
+
+	var queue ApprovalQueue
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("failed to decode approval requests %s: %w", path, err)
+	}
+
+	return &queue, nil
+}
+
+// WriteApprovals stores the approval queue at path.
+//
+// Parameters:
+//   - path: Location of the approval queue file
+//   - queue: The requests to persist
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteApprovals(path string, queue *ApprovalQueue) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode approval requests: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write approval requests %s: %w", path, err)
+	}
+
+	return nil
+}
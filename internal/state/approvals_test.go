@@ -0,0 +1,44 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestApprovalsRoundTrip tests queueing, persisting, and removing approval requests
+func TestApprovalsRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", ApprovalsFilename)
+
+	queue, err := ReadApprovals(path)
+	if err != nil || len(queue.Requests) != 0 {
+		t.Fatalf("A missing queue should read as empty, got %v, %v", queue, err)
+	}
+
+	requestedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	queue.Add(ApprovalRequest{Filename: "B.chatmode.md", PromptsDir: "/p", Source: SourceRegistry, Checksum: "b", RequestedAt: requestedAt})
+	queue.Add(ApprovalRequest{Filename: "A.chatmode.md", PromptsDir: "/p", Source: SourceEmbedded, Checksum: "a", RequestedAt: requestedAt})
+	queue.Add(ApprovalRequest{Filename: "A.chatmode.md", PromptsDir: "/other", Source: SourceEmbedded, Checksum: "a", RequestedAt: requestedAt})
+	// Requesting again replaces the request
+	queue.Add(ApprovalRequest{Filename: "A.chatmode.md", PromptsDir: "/p", Source: SourceImport, Checksum: "a2", RequestedAt: requestedAt})
+
+	if err := WriteApprovals(path, queue); err != nil {
+		t.Fatalf("WriteApprovals failed: %v", err)
+	}
+
+	loaded, err := ReadApprovals(path)
+	if err != nil {
+		t.Fatalf("ReadApprovals failed: %v", err)
+	}
+	requests := loaded.In("/p")
+	if len(requests) != 2 || requests[0].Filename != "A.chatmode.md" || requests[0].Checksum != "a2" {
+		t.Fatalf("Expected 2 sorted requests with the replaced one, got %+v", requests)
+	}
+
+	if !loaded.Remove("/p", "A.chatmode.md") || loaded.Remove("/p", "A.chatmode.md") {
+		t.Error("Remove should report whether the request existed")
+	}
+	if len(loaded.In("/p")) != 1 || len(loaded.In("/other")) != 1 {
+		t.Errorf("Unexpected requests after removal: %+v", loaded.Requests)
+	}
+}
@@ -0,0 +1,106 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// CheckpointFilename is the name of the bulk operation checkpoint file.
+const CheckpointFilename = "checkpoint.json"
+
+// Checkpoint records the progress of a bulk operation so it can be resumed
+// after an interruption (Ctrl-C, crash, or a failed file).
+//
+// Fields:
+//   - Operation: The interrupted operation (e.g., "install")
+//   - PromptsDir: The prompts directory the operation was writing to
+//   - Force: Whether existing files were being overwritten
+//   - Pending: Chatmate filenames not processed yet, in order
+//   - Completed: Number of chatmates processed so far
+//   - StartedAt: When the operation was confirmed
+//   - UpdatedAt: When the checkpoint was last written
+type Checkpoint struct {
+	Operation  string    `json:"operation"`
+	PromptsDir string    `json:"promptsDir"`
+	Force      bool      `json:"force"`
+	Pending    []string  `json:"pending"`
+	Completed  int       `json:"completed"`
+	StartedAt  time.Time `json:"startedAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// CheckpointPath returns the full path of the bulk operation checkpoint file.
+func CheckpointPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, CheckpointFilename), nil
+}
+
+// WriteCheckpoint stores a checkpoint at path.
+//
+// Parameters:
+//   - path: Location of the checkpoint file
+//   - checkpoint: The progress to persist
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteCheckpoint(path string, checkpoint *Checkpoint) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	checkpoint.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadCheckpoint loads the checkpoint stored at path.
+//
+// Parameters:
+//   - path: Location of the checkpoint file
+//
+// Returns:
+//   - *Checkpoint: The stored checkpoint
+//   - error: File read or decoding error (fs.ErrNotExist if there is no checkpoint)
+func ReadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint %s: %w", path, err)
+	}
+
+	return &checkpoint, nil
+}
+
+// RemoveCheckpoint deletes the checkpoint at path. A missing checkpoint is not an error.
+func RemoveCheckpoint(path string) error {
+	if err := files.CheckWrite("remove", path); err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint %s: %w", path, err)
+	}
+	return nil
+}
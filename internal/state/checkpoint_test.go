@@ -0,0 +1,53 @@
+package state
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckpointRoundTrip tests writing, reading, and removing a checkpoint
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", CheckpointFilename)
+
+	if _, err := ReadCheckpoint(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected not-exist error before first write, got %v", err)
+	}
+
+	checkpoint := &Checkpoint{
+		Operation:  "install",
+		PromptsDir: "/prompts",
+		Force:      true,
+		Pending:    []string{"A.chatmode.md", "B.chatmode.md"},
+		Completed:  3,
+	}
+	if err := WriteCheckpoint(path, checkpoint); err != nil {
+		t.Fatalf("WriteCheckpoint failed: %v", err)
+	}
+	if checkpoint.UpdatedAt.IsZero() {
+		t.Error("WriteCheckpoint should set UpdatedAt")
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("Temporary checkpoint file should not be left behind")
+	}
+
+	loaded, err := ReadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("ReadCheckpoint failed: %v", err)
+	}
+	if loaded.Operation != "install" || !loaded.Force || loaded.Completed != 3 || len(loaded.Pending) != 2 {
+		t.Errorf("Checkpoint mismatch: %+v", loaded)
+	}
+
+	if err := RemoveCheckpoint(path); err != nil {
+		t.Fatalf("RemoveCheckpoint failed: %v", err)
+	}
+	if err := RemoveCheckpoint(path); err != nil {
+		t.Errorf("Removing a missing checkpoint should succeed: %v", err)
+	}
+	if _, err := ReadCheckpoint(path); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected checkpoint to be removed, got %v", err)
+	}
+}
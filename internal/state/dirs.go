@@ -0,0 +1,63 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+	"path/filepath"
+)
+
+// LocalDir returns the directory of machine-local state files.
+//
+// Machine-local files describe this machine: they refer to its prompts
+// directory by absolute path (provenance, adopted files, approval requests)
+// or record what happened on it (history, checkpoints). Copying them to
+// another machine would make ChatMate report changes that never happened
+// there, so the directory must not be synced with dotfiles.
+//
+// Returns:
+//   - string: The state directory (see platform.GetChatMateStateDir)
+//   - error: Home directory lookup error
+func LocalDir() (string, error) {
+	dir, err := platform.GetChatMateStateDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get state directory: %w", err)
+	}
+	return dir, nil
+}
+
+// RoamingDir returns the directory of roaming files.
+//
+// Roaming files hold the user's and team's preferences, such as the team
+// policy, which apply on every machine. The directory is safe to sync
+// between machines with dotfiles or a roaming profile, and ChatMate never
+// writes machine-specific data to it.
+//
+// Returns:
+//   - string: The config directory (see platform.GetChatMateConfigDir)
+//   - error: Home directory lookup error
+func RoamingDir() (string, error) {
+	dir, err := platform.GetChatMateConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return dir, nil
+}
+
+// BackupsDirname is the name of the directory in the state directory that
+// holds the archives of the prompts directory.
+const BackupsDirname = "backups"
+
+// BackupsDir returns the directory of the archives of the prompts directory
+// made by 'chatmate backup' and before destructive operations.
+//
+// Returns:
+//   - string: The backups directory in the state directory (see LocalDir)
+//   - error: Home directory lookup error
+func BackupsDir() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, BackupsDirname), nil
+}
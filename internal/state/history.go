@@ -0,0 +1,118 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// HistoryFilename is the name of the operation history file.
+const HistoryFilename = "history.jsonl"
+
+// MaxHistoryEntries is the number of operations kept in the history; older
+// entries are dropped when a new one is appended.
+const MaxHistoryEntries = 100
+
+// HistoryPath returns the full path of the operation history file.
+func HistoryPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, HistoryFilename), nil
+}
+
+// AppendHistory records an operation at the end of the history.
+//
+// The history is stored as JSON lines, one Summary per operation, oldest
+// first, so other tools can follow it with standard line-based tools. It is
+// trimmed to the newest MaxHistoryEntries operations.
+//
+// Parameters:
+//   - summary: The operation to record
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func AppendHistory(summary Summary) error {
+	path, err := HistoryPath()
+	if err != nil {
+		return err
+	}
+
+	entries, err := readHistoryFile(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, summary)
+	if len(entries) > MaxHistoryEntries {
+		entries = entries[len(entries)-MaxHistoryEntries:]
+	}
+	return writeHistoryFile(path, entries)
+}
+
+// writeHistoryFile replaces the history at path with entries.
+func writeHistoryFile(path string, entries []Summary) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode history entry: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write history %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadHistory loads the recorded operations, oldest first.
+//
+// A missing history is empty rather than an error, and lines that cannot be
+// decoded (e.g., from an interrupted write) are skipped.
+//
+// Returns:
+//   - []Summary: The recorded operations
+//   - error: File read error
+func ReadHistory() ([]Summary, error) {
+	path, err := HistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	return readHistoryFile(path)
+}
+
+// readHistoryFile implements ReadHistory for the history at path.
+func readHistoryFile(path string) ([]Summary, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history %s: %w", path, err)
+	}
+
+	var entries []Summary
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry Summary
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,54 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// TestHistory tests appending to, trimming, and reading the operation history
+func TestHistory(t *testing.T) {
+	setupStateDir(t)
+
+	entries, err := ReadHistory()
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("Expected empty history before first write, got %v, %v", entries, err)
+	}
+
+	start := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		summary := Summary{Command: fmt.Sprintf("hire-%d", i), Success: true, Timestamp: start.Add(time.Duration(i) * time.Minute)}
+		if err := AppendHistory(summary); err != nil {
+			t.Fatalf("AppendHistory failed: %v", err)
+		}
+	}
+
+	entries, err = ReadHistory()
+	if err != nil {
+		t.Fatalf("ReadHistory failed: %v", err)
+	}
+	if len(entries) != MaxHistoryEntries {
+		t.Fatalf("Expected %d entries, got %d", MaxHistoryEntries, len(entries))
+	}
+	if entries[0].Command != "hire-5" || entries[len(entries)-1].Command != fmt.Sprintf("hire-%d", MaxHistoryEntries+4) {
+		t.Errorf("Expected the oldest entries to be dropped, got %s..%s", entries[0].Command, entries[len(entries)-1].Command)
+	}
+
+	// Corrupt lines are skipped
+	path, err := HistoryPath()
+	if err != nil {
+		t.Fatalf("HistoryPath failed: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open history: %v", err)
+	}
+	_, _ = file.WriteString("{\"command\": \"trunc")
+	_ = file.Close()
+
+	entries, err = ReadHistory()
+	if err != nil || len(entries) != MaxHistoryEntries {
+		t.Errorf("Expected corrupt line to be skipped, got %d entries, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,178 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// ProvenanceFilename is the name of the record of where installed chatmates
+// came from.
+const ProvenanceFilename = "provenance.json"
+
+// Sources a chatmate can be installed from.
+const (
+	// SourceEmbedded marks chatmates shipped inside the chatmate binary
+	SourceEmbedded = "embedded"
+	// SourceDirectory marks chatmates installed from a mates directory next
+	// to the binary or in the working directory (development setups)
+	SourceDirectory = "directory"
+	// SourceImport marks chatmates installed with 'chatmate import'
+	SourceImport = "import"
+	// SourceStdin marks chatmates piped into 'chatmate hire --stdin'
+	SourceStdin = "stdin"
+	// SourcePath marks chatmates installed from a local directory or file
+	// with 'chatmate hire --path' or 'chatmate hire --file'
+	SourcePath = "path"
+	// SourceRegistry marks chatmates downloaded from the chatmate registry
+	// with 'chatmate hire --from-registry'
+	SourceRegistry = "registry"
+	// SourceURL marks chatmates downloaded from an https URL with
+	// 'chatmate hire --url'
+	SourceURL = "url"
+	// SourceRepository marks chatmates installed from a git repository with
+	// 'chatmate hire --git'
+	SourceRepository = "repository"
+	// SourceGit marks chatmates installed from a git repository configured
+	// with 'chatmate source add'
+	SourceGit = "git"
+	// SourceHTTP marks chatmates installed from an index served over HTTPS
+	// configured with 'chatmate source add'
+	SourceHTTP = "http"
+)
+
+// Provenance records where an installed chatmate came from.
+//
+// Fields:
+//   - Filename: The chatmate filename in the prompts directory
+//   - PromptsDir: The prompts directory containing the file
+//   - Source: How the chatmate was installed (see SourceEmbedded and friends)
+//   - Location: Where the content was read from: the repository URL for
+//     embedded chatmates, the directory for files (the file itself for
+//     'chatmate hire --file'), the download URL for
+//     registry chatmates and 'chatmate hire --url', empty for stdin
+//   - InstallerVersion: Version of ChatMate that installed the chatmate
+//   - Version: Version of the chatmate from its frontmatter, if it has one
+//   - Checksum: SHA-256 of the installed content, hex encoded
+//   - InstalledAt: When the chatmate was installed
+type Provenance struct {
+	Filename         string    `json:"filename"`
+	PromptsDir       string    `json:"promptsDir"`
+	Source           string    `json:"source"`
+	Location         string    `json:"location,omitempty"`
+	InstallerVersion string    `json:"installerVersion"`
+	Version          string    `json:"version,omitempty"`
+	Checksum         string    `json:"checksum"`
+	InstalledAt      time.Time `json:"installedAt"`
+}
+
+// ProvenanceLog holds the provenance of installed chatmates across prompts
+// directories.
+type ProvenanceLog struct {
+	Files []Provenance `json:"files"`
+}
+
+// Get returns the provenance of filename in promptsDir, in whichever Unicode
+// normalization it was recorded (see files.SameFilename).
+func (l *ProvenanceLog) Get(promptsDir, filename string) (Provenance, bool) {
+	for _, file := range l.Files {
+		if file.PromptsDir == promptsDir && files.SameFilename(file.Filename, filename) {
+			return file, true
+		}
+	}
+	return Provenance{}, false
+}
+
+// Set adds or replaces the provenance of a file, keeping entries sorted. The
+// filename is stored in Unicode NFC.
+func (l *ProvenanceLog) Set(file Provenance) {
+	l.Remove(file.PromptsDir, file.Filename)
+	file.Filename = files.NormalizeFilename(file.Filename)
+	l.Files = append(l.Files, file)
+	sort.Slice(l.Files, func(i, j int) bool {
+		if l.Files[i].PromptsDir != l.Files[j].PromptsDir {
+			return l.Files[i].PromptsDir < l.Files[j].PromptsDir
+		}
+		return l.Files[i].Filename < l.Files[j].Filename
+	})
+}
+
+// Remove deletes the provenance of filename in promptsDir and reports whether it existed.
+func (l *ProvenanceLog) Remove(promptsDir, filename string) bool {
+	for i, file := range l.Files {
+		if file.PromptsDir == promptsDir && files.SameFilename(file.Filename, filename) {
+			l.Files = append(l.Files[:i], l.Files[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ProvenancePath returns the full path of the provenance log.
+func ProvenancePath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, ProvenanceFilename), nil
+}
+
+// ReadProvenance loads the provenance log stored at path.
+//
+// A missing log is returned as an empty log.
+//
+// Parameters:
+//   - path: Location of the provenance file
+//
+// Returns:
+//   - *ProvenanceLog: The stored provenance
+//   - error: File read or decoding error
+func ReadProvenance(path string) (*ProvenanceLog, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ProvenanceLog{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provenance %s: %w", path, err)
+	}
+
+	var log ProvenanceLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to decode provenance %s: %w", path, err)
+	}
+
+	return &log, nil
+}
+
+// WriteProvenance stores the provenance log at path.
+//
+// Parameters:
+//   - path: Location of the provenance file
+//   - log: The provenance to persist
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteProvenance(path string, log *ProvenanceLog) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode provenance: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write provenance %s: %w", path, err)
+	}
+
+	return nil
+}
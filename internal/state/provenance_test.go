@@ -0,0 +1,45 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestProvenanceRoundTrip tests recording, persisting, and removing provenance
+func TestProvenanceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", ProvenanceFilename)
+
+	log, err := ReadProvenance(path)
+	if err != nil || len(log.Files) != 0 {
+		t.Fatalf("Missing provenance should read as empty, got %v, %v", log, err)
+	}
+
+	installedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	log.Set(Provenance{Filename: "B.chatmode.md", PromptsDir: "/p", Source: SourceStdin, InstallerVersion: "1.0.0", InstalledAt: installedAt})
+	log.Set(Provenance{Filename: "A.chatmode.md", PromptsDir: "/p", Source: SourceEmbedded, Location: "https://example.com/repo", InstallerVersion: "1.0.0", InstalledAt: installedAt})
+	// Reinstalling replaces the entry
+	log.Set(Provenance{Filename: "A.chatmode.md", PromptsDir: "/p", Source: SourceImport, Location: "/exported", InstallerVersion: "1.1.0", InstalledAt: installedAt})
+
+	if err := WriteProvenance(path, log); err != nil {
+		t.Fatalf("WriteProvenance failed: %v", err)
+	}
+
+	loaded, err := ReadProvenance(path)
+	if err != nil {
+		t.Fatalf("ReadProvenance failed: %v", err)
+	}
+	if len(loaded.Files) != 2 || loaded.Files[0].Filename != "A.chatmode.md" {
+		t.Fatalf("Expected 2 sorted entries, got %+v", loaded.Files)
+	}
+	if file, ok := loaded.Get("/p", "A.chatmode.md"); !ok || file.Source != SourceImport || file.Location != "/exported" || file.InstallerVersion != "1.1.0" {
+		t.Errorf("Expected replaced entry, got %+v", file)
+	}
+
+	if !loaded.Remove("/p", "A.chatmode.md") || loaded.Remove("/p", "A.chatmode.md") {
+		t.Error("Remove should report whether the entry existed")
+	}
+	if _, ok := loaded.Get("/p", "A.chatmode.md"); ok {
+		t.Error("Removed entry should be gone")
+	}
+}
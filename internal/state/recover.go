@@ -0,0 +1,100 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// CorruptFile is a state file that could not be decoded and was moved aside.
+//
+// Fields:
+//   - Path: Where the file was
+//   - Backup: Where the corrupt content was moved to
+//   - Err: Why the file could not be decoded
+type CorruptFile struct {
+	Path   string
+	Backup string
+	Err    error
+}
+
+// backupInfix separates the name of a state file from the time its corrupt
+// content was moved aside, formatted with backupTimeFormat.
+const (
+	backupInfix      = ".corrupt-"
+	backupTimeFormat = "20060102T150405Z"
+)
+
+// recoverableFiles are the state files checked by Recover, with the type
+// their content must decode into. The history is not listed because
+// ReadHistory already skips lines it cannot decode.
+var recoverableFiles = []struct {
+	name   string
+	decode func(data []byte) error
+}{
+	{SummaryFilename, func(data []byte) error { return json.Unmarshal(data, &Summary{}) }},
+	{RegistryFilename, func(data []byte) error { return json.Unmarshal(data, &Registry{}) }},
+	{ProvenanceFilename, func(data []byte) error { return json.Unmarshal(data, &ProvenanceLog{}) }},
+	{CheckpointFilename, func(data []byte) error { return json.Unmarshal(data, &Checkpoint{}) }},
+	{ApprovalsFilename, func(data []byte) error { return json.Unmarshal(data, &ApprovalQueue{}) }},
+}
+
+// Recover moves state files that cannot be decoded out of the way.
+//
+// A state file broken by a bad manual edit, a full disk, or a crash must not
+// make every command fail. Each corrupt file is renamed to
+// "<name>.corrupt-<timestamp>" next to the original, so ChatMate continues
+// with defaults (as if the file did not exist yet) while the content stays
+// available for inspection and can be moved back once fixed.
+//
+// Returns:
+//   - []CorruptFile: The files that were moved aside
+//   - error: State directory lookup or rename error; files handled before the
+//     error are still returned
+func Recover() ([]CorruptFile, error) {
+	// Corrupt files are left in place in read-only mode
+	if files.ReadOnly() {
+		return nil, nil
+	}
+	stateDir, err := LocalDir()
+	if err != nil {
+		return nil, err
+	}
+	return recoverDir(stateDir, time.Now())
+}
+
+// recoverDir moves the corrupt state files in stateDir aside, using now for
+// the backup names.
+func recoverDir(stateDir string, now time.Time) ([]CorruptFile, error) {
+	var corrupt []CorruptFile
+	for _, file := range recoverableFiles {
+		path := filepath.Join(stateDir, file.name)
+
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return corrupt, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		decodeErr := file.decode(data)
+		if decodeErr == nil {
+			continue
+		}
+
+		backup := path + backupInfix + now.UTC().Format(backupTimeFormat)
+		if err := os.Rename(path, backup); err != nil {
+			return corrupt, fmt.Errorf("failed to move corrupt %s aside: %w", path, err)
+		}
+		corrupt = append(corrupt, CorruptFile{Path: path, Backup: backup, Err: decodeErr})
+	}
+
+	return corrupt, nil
+}
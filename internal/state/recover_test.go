@@ -0,0 +1,67 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRecover tests moving corrupt state files aside while keeping valid ones
+func TestRecover(t *testing.T) {
+	stateDir := t.TempDir()
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	corrupt, err := recoverDir(stateDir, now)
+	if err != nil || len(corrupt) != 0 {
+		t.Fatalf("Expected nothing to recover in an empty state directory, got %v, %v", corrupt, err)
+	}
+
+	files := map[string]string{
+		SummaryFilename:    `{"command": "hire", "success": true}`,
+		RegistryFilename:   `{"files": [{"filename": "Mine.chatmode.md",`,
+		CheckpointFilename: `not json`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(stateDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	corrupt, err = recoverDir(stateDir, now)
+	if err != nil {
+		t.Fatalf("recoverDir failed: %v", err)
+	}
+	if len(corrupt) != 2 {
+		t.Fatalf("Expected the registry and checkpoint to be recovered, got %+v", corrupt)
+	}
+
+	for _, file := range corrupt {
+		if file.Err == nil {
+			t.Errorf("Expected a decoding error for %s", file.Path)
+		}
+		if _, err := os.Stat(file.Path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be moved aside", file.Path)
+		}
+		backup, err := os.ReadFile(file.Backup)
+		if err != nil {
+			t.Fatalf("Expected backup %s: %v", file.Backup, err)
+		}
+		if string(backup) != files[filepath.Base(file.Path)] {
+			t.Errorf("Backup %s should keep the corrupt content, got %q", file.Backup, backup)
+		}
+		if filepath.Base(file.Backup) != filepath.Base(file.Path)+".corrupt-20250901T120000Z" {
+			t.Errorf("Unexpected backup name %s", file.Backup)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(stateDir, SummaryFilename)); err != nil {
+		t.Errorf("Valid summary should be kept: %v", err)
+	}
+
+	// Defaults are used once the corrupt files are out of the way
+	registry, err := ReadRegistry(filepath.Join(stateDir, RegistryFilename))
+	if err != nil || len(registry.Files) != 0 {
+		t.Errorf("Expected an empty registry after recovery, got %v, %v", registry, err)
+	}
+}
@@ -0,0 +1,151 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// RegistryFilename is the name of the registry of user-managed chatmates.
+const RegistryFilename = "managed.json"
+
+// ManagedFile is a prompt file ChatMate did not create but manages on the
+// user's behalf, such as a chatmate adopted with 'chatmate adopt'.
+//
+// Fields:
+//   - Filename: The chatmate filename in the prompts directory
+//   - PromptsDir: The prompts directory containing the file
+//   - Origin: How the file came under management (e.g., "adopted")
+//   - Checksum: SHA-256 of the content when it was recorded, hex encoded
+//   - RecordedAt: When the file was recorded
+type ManagedFile struct {
+	Filename   string    `json:"filename"`
+	PromptsDir string    `json:"promptsDir"`
+	Origin     string    `json:"origin"`
+	Checksum   string    `json:"checksum"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// OriginAdopted marks files adopted from an existing prompts directory.
+const OriginAdopted = "adopted"
+
+// Registry lists the user-managed chatmates across prompts directories.
+type Registry struct {
+	Files []ManagedFile `json:"files"`
+}
+
+// Get returns the entry for filename in promptsDir, in whichever Unicode
+// normalization it was recorded (see files.SameFilename).
+func (r *Registry) Get(promptsDir, filename string) (ManagedFile, bool) {
+	for _, file := range r.Files {
+		if file.PromptsDir == promptsDir && files.SameFilename(file.Filename, filename) {
+			return file, true
+		}
+	}
+	return ManagedFile{}, false
+}
+
+// Set adds or replaces the entry for a file, keeping entries sorted. The
+// filename is stored in Unicode NFC.
+func (r *Registry) Set(file ManagedFile) {
+	r.Remove(file.PromptsDir, file.Filename)
+	file.Filename = files.NormalizeFilename(file.Filename)
+	r.Files = append(r.Files, file)
+	sort.Slice(r.Files, func(i, j int) bool {
+		if r.Files[i].PromptsDir != r.Files[j].PromptsDir {
+			return r.Files[i].PromptsDir < r.Files[j].PromptsDir
+		}
+		return r.Files[i].Filename < r.Files[j].Filename
+	})
+}
+
+// Remove deletes the entry for filename in promptsDir and reports whether it existed.
+func (r *Registry) Remove(promptsDir, filename string) bool {
+	for i, file := range r.Files {
+		if file.PromptsDir == promptsDir && files.SameFilename(file.Filename, filename) {
+			r.Files = append(r.Files[:i], r.Files[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// In returns the entries for promptsDir.
+func (r *Registry) In(promptsDir string) []ManagedFile {
+	var files []ManagedFile
+	for _, file := range r.Files {
+		if file.PromptsDir == promptsDir {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// RegistryPath returns the full path of the registry of user-managed chatmates.
+func RegistryPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, RegistryFilename), nil
+}
+
+// ReadRegistry loads the registry stored at path.
+//
+// A missing registry is returned as an empty registry.
+//
+// Parameters:
+//   - path: Location of the registry file
+//
+// Returns:
+//   - *Registry: The stored registry
+//   - error: File read or decoding error
+func ReadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry %s: %w", path, err)
+	}
+
+	var registry Registry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to decode registry %s: %w", path, err)
+	}
+
+	return &registry, nil
+}
+
+// WriteRegistry stores the registry at path.
+//
+// Parameters:
+//   - path: Location of the registry file
+//   - registry: The registry to persist
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteRegistry(path string, registry *Registry) error {
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode registry: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write registry %s: %w", path, err)
+	}
+
+	return nil
+}
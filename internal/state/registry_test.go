@@ -0,0 +1,63 @@
+package state
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestRegistryRoundTrip tests recording, persisting, and removing managed files
+func TestRegistryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", RegistryFilename)
+
+	registry, err := ReadRegistry(path)
+	if err != nil {
+		t.Fatalf("Missing registry should read as empty: %v", err)
+	}
+	if len(registry.Files) != 0 {
+		t.Fatalf("Expected empty registry, got %v", registry.Files)
+	}
+
+	recordedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	registry.Set(ManagedFile{Filename: "B.chatmode.md", PromptsDir: "/p", Origin: OriginAdopted, Checksum: "b", RecordedAt: recordedAt})
+	registry.Set(ManagedFile{Filename: "A.chatmode.md", PromptsDir: "/p", Origin: OriginAdopted, Checksum: "a", RecordedAt: recordedAt})
+	registry.Set(ManagedFile{Filename: "A.chatmode.md", PromptsDir: "/other", Origin: OriginAdopted, Checksum: "o", RecordedAt: recordedAt})
+	// Setting an existing entry replaces it
+	registry.Set(ManagedFile{Filename: "A.chatmode.md", PromptsDir: "/p", Origin: OriginAdopted, Checksum: "a2", RecordedAt: recordedAt})
+
+	if err := WriteRegistry(path, registry); err != nil {
+		t.Fatalf("WriteRegistry failed: %v", err)
+	}
+
+	loaded, err := ReadRegistry(path)
+	if err != nil {
+		t.Fatalf("ReadRegistry failed: %v", err)
+	}
+
+	files := loaded.In("/p")
+	if len(files) != 2 || files[0].Filename != "A.chatmode.md" || files[1].Filename != "B.chatmode.md" {
+		t.Fatalf("Expected sorted entries for /p, got %v", files)
+	}
+	if file, ok := loaded.Get("/p", "A.chatmode.md"); !ok || file.Checksum != "a2" {
+		t.Errorf("Expected replaced entry, got %+v", file)
+	}
+	if !hasEntry(loaded, "/other", "A.chatmode.md") {
+		t.Error("Entries in other prompts directories should be kept")
+	}
+
+	if !loaded.Remove("/p", "A.chatmode.md") {
+		t.Error("Remove should report an existing entry")
+	}
+	if loaded.Remove("/p", "A.chatmode.md") {
+		t.Error("Remove should report a missing entry")
+	}
+	if _, ok := loaded.Get("/p", "A.chatmode.md"); ok {
+		t.Error("Removed entry should be gone")
+	}
+}
+
+// hasEntry reports whether the registry has an entry for filename in promptsDir
+func hasEntry(registry *Registry, promptsDir, filename string) bool {
+	_, ok := registry.Get(promptsDir, filename)
+	return ok
+}
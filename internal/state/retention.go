@@ -0,0 +1,384 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+	"github.com/jonassiebler/chatmate/pkg/utils/platform"
+)
+
+// Retention limits the disk space used by the history, the backups of
+// corrupt state files (see Recover), the previous versions of replaced
+// chatmates (see VersionsDir), and the cache, so the data directories do not
+// grow without bound. A zero limit means no limit. Archives of the prompts
+// directory (see BackupsDir) are counted as backups, but rotated by number
+// instead, and versions are also limited to MaxVersions per chatmate.
+//
+// Fields:
+//   - MaxSize: Bytes the state and cache directories may use together; when
+//     they use more, cached files, then the oldest versions, and then the
+//     oldest backups are removed
+//   - MaxAge: How long operations stay in the history and backups and
+//     versions are kept
+//   - MaxVersions: Number of backups kept of each state file
+type Retention struct {
+	MaxSize     int64
+	MaxAge      time.Duration
+	MaxVersions int
+}
+
+// DefaultRetention is the retention applied unless the team policy sets
+// other limits.
+var DefaultRetention = Retention{
+	MaxSize:     50 << 20,
+	MaxAge:      90 * 24 * time.Hour,
+	MaxVersions: 3,
+}
+
+// UsageArea is the disk space used by one kind of data.
+//
+// Fields:
+//   - Name: The kind of data: "history", "backups" (of state files and of
+//     the prompts directory), "versions" (of replaced chatmates), "state",
+//     or "cache"
+//   - Files: Number of files
+//   - Entries: Number of operations, for the history
+//   - Bytes: Total size of the files
+type UsageArea struct {
+	Name    string `json:"name"`
+	Files   int    `json:"files"`
+	Entries int    `json:"entries,omitempty"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// Usage is the disk space used by the state and cache directories.
+//
+// Fields:
+//   - StateDir: The state directory (see LocalDir)
+//   - CacheDir: The cache directory (see platform.GetChatMateCacheDir)
+//   - Areas: Usage by kind of data
+//   - Total: Bytes used by both directories
+type Usage struct {
+	StateDir string      `json:"stateDir"`
+	CacheDir string      `json:"cacheDir"`
+	Areas    []UsageArea `json:"areas"`
+	Total    int64       `json:"totalBytes"`
+}
+
+// Pruned describes what EnforceRetention removed.
+//
+// Fields:
+//   - Files: The removed backups, versions, and cached files
+//   - HistoryEntries: Number of operations removed from the history
+//   - Bytes: Disk space freed
+type Pruned struct {
+	Files          []string `json:"files,omitempty"`
+	HistoryEntries int      `json:"historyEntries,omitempty"`
+	Bytes          int64    `json:"bytes"`
+}
+
+// Empty reports whether nothing was removed.
+func (p *Pruned) Empty() bool {
+	return len(p.Files) == 0 && p.HistoryEntries == 0
+}
+
+// dataFile is a file in the state or cache directory.
+type dataFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+	// The state file a backup was made of
+	original string
+}
+
+// MeasureUsage reports the disk space used by the state and cache
+// directories.
+//
+// Returns:
+//   - *Usage: The usage by kind of data
+//   - error: Directory lookup or read error
+func MeasureUsage() (*Usage, error) {
+	stateDir, cacheDir, err := dataDirs()
+	if err != nil {
+		return nil, err
+	}
+	return measureUsage(stateDir, cacheDir)
+}
+
+// EnforceRetention removes the data that exceeds the retention limits:
+// operations, backups, and versions older than MaxAge, backups beyond
+// MaxVersions per state file, and, while the directories are larger than
+// MaxSize, cached files, then versions, and then backups, oldest first. The
+// history file itself and the other state files are never removed.
+//
+// Parameters:
+//   - r: The retention limits
+//
+// Returns:
+//   - *Pruned: What was removed
+//   - error: Directory lookup, read, or removal error; what was removed
+//     before the error is still returned
+//
+// Example:
+//
+//	pruned, err := state.EnforceRetention(state.DefaultRetention)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Freed %d bytes\n", pruned.Bytes)
+func EnforceRetention(r Retention) (*Pruned, error) {
+	// Nothing is pruned in read-only mode
+	if files.ReadOnly() {
+		return &Pruned{}, nil
+	}
+	stateDir, cacheDir, err := dataDirs()
+	if err != nil {
+		return &Pruned{}, err
+	}
+	return enforceRetention(stateDir, cacheDir, r, time.Now())
+}
+
+// dataDirs returns the state and cache directories.
+func dataDirs() (string, string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", "", err
+	}
+	cacheDir, err := platform.GetChatMateCacheDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return stateDir, cacheDir, nil
+}
+
+// measureUsage implements MeasureUsage for the given directories.
+func measureUsage(stateDir, cacheDir string) (*Usage, error) {
+	usage := &Usage{StateDir: stateDir, CacheDir: cacheDir}
+	history := UsageArea{Name: "history"}
+	backups := UsageArea{Name: "backups"}
+	versions := UsageArea{Name: "versions"}
+	other := UsageArea{Name: "state"}
+	cached := UsageArea{Name: "cache"}
+
+	stateFiles, err := listDataFiles(stateDir, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range stateFiles {
+		area := &other
+		switch {
+		case file.path == filepath.Join(stateDir, HistoryFilename):
+			area = &history
+			entries, err := readHistoryFile(file.path)
+			if err != nil {
+				return nil, err
+			}
+			history.Entries = len(entries)
+		case file.original != "", filepath.Dir(file.path) == filepath.Join(stateDir, BackupsDirname):
+			area = &backups
+		case isVersionFile(stateDir, file.path):
+			area = &versions
+		}
+		area.Files++
+		area.Bytes += file.size
+	}
+
+	cacheFiles, err := listDataFiles(cacheDir, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range cacheFiles {
+		cached.Files++
+		cached.Bytes += file.size
+	}
+
+	usage.Areas = []UsageArea{history, backups, versions, other, cached}
+	for _, area := range usage.Areas {
+		usage.Total += area.Bytes
+	}
+	return usage, nil
+}
+
+// enforceRetention implements EnforceRetention for the given directories,
+// using now to determine the age of backups and operations.
+func enforceRetention(stateDir, cacheDir string, r Retention, now time.Time) (*Pruned, error) {
+	pruned := &Pruned{}
+	remove := func(file dataFile) error {
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", file.path, err)
+		}
+		pruned.Files = append(pruned.Files, file.path)
+		pruned.Bytes += file.size
+		return nil
+	}
+
+	stateFiles, err := listDataFiles(stateDir, cacheDir)
+	if err != nil {
+		return pruned, err
+	}
+
+	// Backups by age and number, newest first
+	var backups, chatmateVersions []dataFile
+	for _, file := range stateFiles {
+		switch {
+		case file.original != "":
+			backups = append(backups, file)
+		case isVersionFile(stateDir, file.path):
+			chatmateVersions = append(chatmateVersions, file)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].modTime.After(backups[j].modTime) })
+	var kept []dataFile
+	versions := make(map[string]int)
+	for _, backup := range backups {
+		versions[backup.original]++
+		expired := r.MaxAge > 0 && now.Sub(backup.modTime) > r.MaxAge
+		if expired || (r.MaxVersions > 0 && versions[backup.original] > r.MaxVersions) {
+			if err := remove(backup); err != nil {
+				return pruned, err
+			}
+			continue
+		}
+		kept = append(kept, backup)
+	}
+
+	// Versions by age, oldest first
+	sort.SliceStable(chatmateVersions, func(i, j int) bool {
+		return chatmateVersions[i].modTime.Before(chatmateVersions[j].modTime)
+	})
+	var keptVersions []dataFile
+	for _, version := range chatmateVersions {
+		if r.MaxAge > 0 && now.Sub(version.modTime) > r.MaxAge {
+			if err := remove(version); err != nil {
+				return pruned, err
+			}
+			continue
+		}
+		keptVersions = append(keptVersions, version)
+	}
+
+	// Operations by age
+	if r.MaxAge > 0 {
+		if err := pruneHistory(filepath.Join(stateDir, HistoryFilename), now.Add(-r.MaxAge), pruned); err != nil {
+			return pruned, err
+		}
+	}
+
+	// Total size: the cache is disposable, so it goes first; versions of
+	// single chatmates go before the backups
+	if r.MaxSize > 0 {
+		usage, err := measureUsage(stateDir, cacheDir)
+		if err != nil {
+			return pruned, err
+		}
+		excess := usage.Total - r.MaxSize
+		if excess <= 0 {
+			return pruned, nil
+		}
+
+		cacheFiles, err := listDataFiles(cacheDir, "")
+		if err != nil {
+			return pruned, err
+		}
+		sort.SliceStable(cacheFiles, func(i, j int) bool { return cacheFiles[i].modTime.Before(cacheFiles[j].modTime) })
+		candidates := append(cacheFiles, keptVersions...)
+		for i := len(kept) - 1; i >= 0; i-- {
+			candidates = append(candidates, kept[i])
+		}
+		for _, file := range candidates {
+			if excess <= 0 {
+				break
+			}
+			if err := remove(file); err != nil {
+				return pruned, err
+			}
+			excess -= file.size
+		}
+	}
+	return pruned, nil
+}
+
+// isVersionFile reports whether path is a previous version of a chatmate in
+// the versions directory of stateDir.
+func isVersionFile(stateDir, path string) bool {
+	return strings.HasPrefix(path, filepath.Join(stateDir, VersionsDirname)+string(filepath.Separator))
+}
+
+// pruneHistory removes the operations recorded before cutoff from the
+// history at path, adding what was removed to pruned.
+func pruneHistory(path string, cutoff time.Time, pruned *Pruned) error {
+	entries, err := readHistoryFile(path)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+
+	var recent []Summary
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(cutoff) {
+			recent = append(recent, entry)
+		}
+	}
+	if len(recent) == len(entries) {
+		return nil
+	}
+
+	before, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read history %s: %w", path, err)
+	}
+	if err := writeHistoryFile(path, recent); err != nil {
+		return err
+	}
+	if after, err := os.Stat(path); err == nil {
+		pruned.Bytes += before.Size() - after.Size()
+	}
+	pruned.HistoryEntries += len(entries) - len(recent)
+	return nil
+}
+
+// listDataFiles returns the regular files below dir, except those below skip
+// (the cache directory is inside the state directory on Windows); a missing
+// directory has none. Backups of corrupt state files are dated by the time
+// in their name.
+func listDataFiles(dir, skip string) ([]dataFile, error) {
+	var dataFiles []dataFile
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() && path == skip {
+			return fs.SkipDir
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+
+		file := dataFile{path: path, size: info.Size(), modTime: info.ModTime()}
+		if original, stamp, ok := strings.Cut(entry.Name(), backupInfix); ok && filepath.Dir(path) == dir {
+			file.original = original
+			if movedAt, err := time.Parse(backupTimeFormat, stamp); err == nil {
+				file.modTime = movedAt
+			}
+		}
+		dataFiles = append(dataFiles, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return dataFiles, nil
+}
@@ -0,0 +1,182 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEnforceRetention tests pruning history, backups, and cache to the limits
+func TestEnforceRetention(t *testing.T) {
+	stateDir, cacheDir := t.TempDir(), t.TempDir()
+	now := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	write := func(path string, size int) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	backup := func(name string, age time.Duration) string {
+		return filepath.Join(stateDir, name+backupInfix+now.Add(-age).Format(backupTimeFormat))
+	}
+
+	write(filepath.Join(stateDir, ProvenanceFilename), 100)
+	backups := []string{
+		backup(ProvenanceFilename, time.Hour),
+		backup(ProvenanceFilename, 2*time.Hour),
+		backup(ProvenanceFilename, 3*time.Hour),
+		backup(RegistryFilename, 100*24*time.Hour),
+	}
+	for _, path := range backups {
+		write(path, 10)
+	}
+	history := filepath.Join(stateDir, HistoryFilename)
+	if err := writeHistoryFile(history, []Summary{
+		{Command: "hire", Timestamp: now.Add(-100 * 24 * time.Hour)},
+		{Command: "update", Timestamp: now.Add(-time.Hour)},
+	}); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+
+	pruned, err := enforceRetention(stateDir, cacheDir, Retention{MaxAge: 90 * 24 * time.Hour, MaxVersions: 2}, now)
+	if err != nil {
+		t.Fatalf("enforceRetention failed: %v", err)
+	}
+	if len(pruned.Files) != 2 || pruned.HistoryEntries != 1 || pruned.Bytes <= 20 {
+		t.Errorf("Expected the oldest provenance backup, the expired registry backup, and an operation to be removed, got %+v", pruned)
+	}
+	for n, path := range backups {
+		_, err := os.Stat(path)
+		if kept := n < 2; kept != (err == nil) {
+			t.Errorf("Unexpected state of backup %s: kept %v, got %v", filepath.Base(path), kept, err)
+		}
+	}
+	if entries, err := readHistoryFile(history); err != nil || len(entries) != 1 || entries[0].Command != "update" {
+		t.Errorf("Expected only the recent operation in the history, got %+v, %v", entries, err)
+	}
+
+	// Over the size limit, the cache goes first, then the oldest backups
+	write(filepath.Join(cacheDir, "inventory.json"), 500)
+	usage, err := measureUsage(stateDir, cacheDir)
+	if err != nil {
+		t.Fatalf("measureUsage failed: %v", err)
+	}
+	limit := usage.Total - 505
+	pruned, err = enforceRetention(stateDir, cacheDir, Retention{MaxSize: limit}, now)
+	if err != nil {
+		t.Fatalf("enforceRetention failed: %v", err)
+	}
+	if len(pruned.Files) != 2 || pruned.Files[0] != filepath.Join(cacheDir, "inventory.json") || pruned.Files[1] != backups[1] {
+		t.Errorf("Expected the cache and then the oldest backup to be removed, got %v", pruned.Files)
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, ProvenanceFilename)); err != nil {
+		t.Errorf("State files must never be removed: %v", err)
+	}
+
+	// Without limits nothing is removed
+	if pruned, err := enforceRetention(stateDir, cacheDir, Retention{}, now); err != nil || !pruned.Empty() {
+		t.Errorf("Expected nothing to be removed without limits, got %+v, %v", pruned, err)
+	}
+}
+
+// TestEnforceRetentionVersions tests pruning the previous versions of
+// chatmates by age and, after the cache, by size
+func TestEnforceRetentionVersions(t *testing.T) {
+	stateDir, cacheDir := t.TempDir(), t.TempDir()
+	now := time.Now()
+
+	version := func(name string, age time.Duration) string {
+		t.Helper()
+		path := filepath.Join(stateDir, VersionsDirname, "0a1b", "Solve Issue.chatmode.md", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create versions directory: %v", err)
+		}
+		if err := os.WriteFile(path, make([]byte, 100), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatalf("Failed to date %s: %v", path, err)
+		}
+		return path
+	}
+	expired := version("1.md", 100*24*time.Hour)
+	older := version("2.md", 2*time.Hour)
+	newer := version("3.md", time.Hour)
+	backup := filepath.Join(stateDir, ProvenanceFilename+backupInfix+now.Add(-3*time.Hour).UTC().Format(backupTimeFormat))
+	if err := os.WriteFile(backup, make([]byte, 100), 0644); err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	pruned, err := enforceRetention(stateDir, cacheDir, Retention{MaxAge: 90 * 24 * time.Hour}, now)
+	if err != nil {
+		t.Fatalf("enforceRetention failed: %v", err)
+	}
+	if len(pruned.Files) != 1 || pruned.Files[0] != expired {
+		t.Errorf("Expected the expired version to be removed, got %v", pruned.Files)
+	}
+
+	// Versions go before backups, even older ones
+	pruned, err = enforceRetention(stateDir, cacheDir, Retention{MaxSize: 150}, now)
+	if err != nil {
+		t.Fatalf("enforceRetention failed: %v", err)
+	}
+	if len(pruned.Files) != 2 || pruned.Files[0] != older || pruned.Files[1] != newer {
+		t.Errorf("Expected the versions to be removed, oldest first, got %v", pruned.Files)
+	}
+	if _, err := os.Stat(backup); err != nil {
+		t.Errorf("Expected the backup to be kept: %v", err)
+	}
+}
+
+// TestMeasureUsage tests reporting the disk usage by kind of data
+func TestMeasureUsage(t *testing.T) {
+	stateDir := t.TempDir()
+	cacheDir := filepath.Join(stateDir, "cache")
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		t.Fatalf("Failed to create cache directory: %v", err)
+	}
+	files := map[string]int{
+		filepath.Join(stateDir, SummaryFilename):                         3,
+		filepath.Join(stateDir, SummaryFilename+backupInfix+"x"):         5,
+		filepath.Join(cacheDir, "inventory.json"):                        7,
+		filepath.Join(cacheDir, "registry", "index.json"):                11,
+		filepath.Join(cacheDir, SummaryFilename+backupInfix+"nested"):    13,
+		filepath.Join(stateDir, VersionsDirname, "0a1b", "A.md", "1.md"): 17,
+	}
+	for path, size := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+	if err := writeHistoryFile(filepath.Join(stateDir, HistoryFilename), []Summary{{Command: "hire"}}); err != nil {
+		t.Fatalf("Failed to write history: %v", err)
+	}
+
+	usage, err := measureUsage(stateDir, cacheDir)
+	if err != nil {
+		t.Fatalf("measureUsage failed: %v", err)
+	}
+	areas := make(map[string]UsageArea)
+	for _, area := range usage.Areas {
+		areas[area.Name] = area
+	}
+	if areas["history"].Entries != 1 || areas["history"].Files != 1 {
+		t.Errorf("Unexpected history usage %+v", areas["history"])
+	}
+	if areas["backups"].Bytes != 5 || areas["versions"].Bytes != 17 || areas["state"].Bytes != 3 {
+		t.Errorf("Unexpected state usage %+v", usage.Areas)
+	}
+	// The cache inside the state directory (as on Windows) is counted once
+	if areas["cache"].Files != 3 || areas["cache"].Bytes != 31 {
+		t.Errorf("Unexpected cache usage %+v", areas["cache"])
+	}
+	if usage.Total != 56+areas["history"].Bytes {
+		t.Errorf("Unexpected total %d", usage.Total)
+	}
+}
@@ -0,0 +1,131 @@
+// Package state persists ChatMate's state files.
+//
+// State files are small JSON documents written by ChatMate itself. They are
+// intended to be read by other tools, such as shell prompts or status lines,
+// without having to run a full ChatMate command.
+//
+// Files are split by whether they may follow the user to other machines:
+//   - Machine-local files describe this machine and live in LocalDir, the
+//     platform-specific state directory; disposable caches live in the cache
+//     directory (see platform.GetChatMateCacheDir)
+//   - Roaming files hold preferences that apply on every machine and live in
+//     RoamingDir, the config directory, which users may sync with their
+//     dotfiles or roaming profile
+//
+// Machine-local files:
+//   - last-run.json: Summary of the most recent ChatMate operation
+//   - managed.json: Registry of user-managed chatmates (e.g., adopted files)
+//   - provenance.json: Where installed chatmates came from
+//   - checkpoint.json: Progress of an interrupted bulk operation
+//   - approvals.json: Installations waiting for approval by the team policy
+//   - history.jsonl: Summaries of recent operations, one JSON object per line
+//   - activity.jsonl: Installs, updates, and uninstalls of chatmates, one
+//     JSON object per line
+//
+// Roaming files:
+//   - policy.yaml: The team policy (see package policy)
+//
+// Files that cannot be decoded are moved aside by Recover, so a damaged
+// state file never makes ChatMate unusable.
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// SummaryFilename is the name of the last operation summary file.
+const SummaryFilename = "last-run.json"
+
+// Summary is a machine-readable summary of the last ChatMate operation.
+//
+// Fields:
+//   - Command: The command that was executed (e.g., "hire")
+//   - Success: Whether the command completed without error
+//   - Error: The error message if the command failed
+//   - Installed: Number of installed chatmates after the command
+//   - Available: Number of chatmates available for installation
+//   - Outdated: Number of installed chatmates whose content differs from the shipped version
+//   - DurationMs: How long the command took, in milliseconds
+//   - Timestamp: When the command finished
+type Summary struct {
+	Command    string    `json:"command"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	Installed  int       `json:"installed"`
+	Available  int       `json:"available"`
+	Outdated   int       `json:"outdated"`
+	DurationMs int64     `json:"durationMs"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// SummaryPath returns the full path of the last operation summary file.
+func SummaryPath() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, SummaryFilename), nil
+}
+
+// WriteSummary stores the summary of the last operation.
+//
+// Parameters:
+//   - summary: The operation summary to persist
+//
+// Returns:
+//   - error: Directory creation, encoding, or file write error
+func WriteSummary(summary Summary) error {
+	path, err := SummaryPath()
+	if err != nil {
+		return err
+	}
+
+	if err := files.CheckWrite("write", path); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+
+	if err := files.WriteFileAtomic(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write summary %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadSummary loads the summary of the last operation.
+//
+// Returns:
+//   - *Summary: The stored summary
+//   - error: File read or decoding error (fs.ErrNotExist if no summary exists yet)
+func ReadSummary() (*Summary, error) {
+	path, err := SummaryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary Summary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode summary %s: %w", path, err)
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,52 @@
+package state
+
+import (
+	"errors"
+	"io/fs"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// setupStateDir points the state directory at a temporary location
+func setupStateDir(t *testing.T) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir)
+	t.Setenv("XDG_STATE_HOME", tmpDir)
+	if runtime.GOOS == "windows" {
+		t.Setenv("LOCALAPPDATA", tmpDir)
+	}
+}
+
+// TestSummaryRoundTrip tests writing and reading the last operation summary
+func TestSummaryRoundTrip(t *testing.T) {
+	setupStateDir(t)
+
+	if _, err := ReadSummary(); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("Expected not-exist error before first write, got %v", err)
+	}
+
+	summary := Summary{
+		Command:   "hire",
+		Success:   true,
+		Installed: 12,
+		Available: 12,
+		Outdated:  2,
+		Timestamp: time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	if err := WriteSummary(summary); err != nil {
+		t.Fatalf("WriteSummary failed: %v", err)
+	}
+
+	loaded, err := ReadSummary()
+	if err != nil {
+		t.Fatalf("ReadSummary failed: %v", err)
+	}
+
+	if *loaded != summary {
+		t.Errorf("Summary mismatch. Expected: %+v, Got: %+v", summary, *loaded)
+	}
+}
@@ -0,0 +1,213 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// VersionsDirname is the name of the directory in the state directory that
+// holds the previous content of replaced chatmates.
+const VersionsDirname = "versions"
+
+// MaxVersions is the number of previous versions kept per chatmate; the
+// oldest is removed when a new one is archived.
+const MaxVersions = 20
+
+// versionExt ends the name of an archived version, e.g. "3.md".
+const versionExt = ".md"
+
+// VersionsDir returns the directory of the previous content of chatmates
+// replaced by 'chatmate hire --force', 'chatmate update', and restores.
+//
+// Returns:
+//   - string: The versions directory in the state directory (see LocalDir)
+//   - error: Home directory lookup error
+func VersionsDir() (string, error) {
+	stateDir, err := LocalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(stateDir, VersionsDirname), nil
+}
+
+// Version is the content a chatmate had before it was replaced.
+//
+// Fields:
+//   - Number: The version number, counting up from 1 per chatmate
+//   - Filename: The chatmate filename
+//   - Path: Full path of the archived content
+//   - Archived: When the content was replaced
+//   - Bytes: Size of the content
+type Version struct {
+	Number   int       `json:"number"`
+	Filename string    `json:"filename"`
+	Path     string    `json:"path"`
+	Archived time.Time `json:"archived"`
+	Bytes    int64     `json:"bytes"`
+}
+
+// versionDir returns the directory of the versions of a chatmate in a
+// prompts directory. Prompts directories are kept apart by a hash of their
+// path, so the user and workspace chatmates of the same name do not mix.
+func versionDir(dir, promptsDir, filename string) string {
+	sum := sha256.Sum256([]byte(promptsDir))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8]), files.NormalizeFilename(filename))
+}
+
+// ArchiveVersion stores content as the next version of a chatmate and
+// removes the oldest versions beyond MaxVersions.
+//
+// The version file is created exclusively, so two processes replacing the
+// same chatmate at once get different numbers instead of overwriting each
+// other's version.
+//
+// Parameters:
+//   - dir: The versions directory (see VersionsDir)
+//   - promptsDir: The prompts directory the chatmate is installed in
+//   - filename: The chatmate filename
+//   - content: The content that is about to be replaced
+//
+// Returns:
+//   - Version: The new version
+//   - error: Directory read, creation, or file write error
+func ArchiveVersion(dir, promptsDir, filename string, content []byte) (Version, error) {
+	versions, err := ListVersions(dir, promptsDir, filename)
+	if err != nil {
+		return Version{}, err
+	}
+	number := 1
+	if len(versions) > 0 {
+		number = versions[0].Number + 1
+	}
+
+	chatmateDir := versionDir(dir, promptsDir, filename)
+	if err := files.CheckWrite("write", chatmateDir); err != nil {
+		return Version{}, err
+	}
+	if err := os.MkdirAll(chatmateDir, 0755); err != nil {
+		return Version{}, fmt.Errorf("failed to create versions directory: %w", err)
+	}
+	path, number, err := createVersion(chatmateDir, number, content)
+	if err != nil {
+		return Version{}, fmt.Errorf("failed to archive %s: %w", filename, err)
+	}
+
+	// Versions archived by other processes in the meantime count as well
+	versions, err = ListVersions(dir, promptsDir, filename)
+	if err != nil {
+		return Version{}, err
+	}
+	for _, oldest := range versions[min(len(versions), MaxVersions):] {
+		if err := os.Remove(oldest.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return Version{}, fmt.Errorf("failed to remove version %d of %s: %w", oldest.Number, filename, err)
+		}
+	}
+
+	return Version{
+		Number:   number,
+		Filename: files.NormalizeFilename(filename),
+		Path:     path,
+		Archived: time.Now().UTC(),
+		Bytes:    int64(len(content)),
+	}, nil
+}
+
+// createVersion writes content to a new version file in chatmateDir,
+// starting at number and counting up while the file already exists.
+// It returns the path and number of the file.
+func createVersion(chatmateDir string, number int, content []byte) (string, int, error) {
+	for ; ; number++ {
+		path := filepath.Join(chatmateDir, strconv.Itoa(number)+versionExt)
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", 0, err
+		}
+
+		_, err = file.Write(content)
+		if err == nil {
+			err = file.Sync()
+		}
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+			return "", 0, err
+		}
+		return path, number, nil
+	}
+}
+
+// ListVersions returns the archived versions of a chatmate, newest first.
+//
+// Parameters:
+//   - dir: The versions directory (see VersionsDir)
+//   - promptsDir: The prompts directory the chatmate is installed in
+//   - filename: The chatmate filename
+//
+// Returns:
+//   - []Version: The versions; empty when none were archived
+//   - error: Directory read error
+func ListVersions(dir, promptsDir, filename string) ([]Version, error) {
+	versions := []Version{}
+	chatmateDir := versionDir(dir, promptsDir, filename)
+	entries, err := os.ReadDir(chatmateDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return versions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read versions of %s: %w", filename, err)
+	}
+
+	for _, entry := range entries {
+		number, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), versionExt))
+		if err != nil || number < 1 || !strings.HasSuffix(entry.Name(), versionExt) || !entry.Type().IsRegular() {
+			continue
+		}
+		version := Version{Number: number, Filename: files.NormalizeFilename(filename), Path: filepath.Join(chatmateDir, entry.Name())}
+		if info, err := entry.Info(); err == nil {
+			version.Archived = info.ModTime().UTC()
+			version.Bytes = info.Size()
+		}
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Number > versions[j].Number })
+	return versions, nil
+}
+
+// ReadVersion returns the content of an archived version of a chatmate.
+//
+// Parameters:
+//   - dir: The versions directory (see VersionsDir)
+//   - promptsDir: The prompts directory the chatmate is installed in
+//   - filename: The chatmate filename
+//   - number: The version number (see Version.Number)
+//
+// Returns:
+//   - []byte: The archived content
+//   - error: Error if the version does not exist or cannot be read
+func ReadVersion(dir, promptsDir, filename string, number int) ([]byte, error) {
+	path := filepath.Join(versionDir(dir, promptsDir, filename), strconv.Itoa(number)+versionExt)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("version %d of %s not found", number, filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version %d of %s: %w", number, filename, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestVersions tests archiving, listing, trimming, and reading the previous
+// versions of a chatmate
+func TestVersions(t *testing.T) {
+	dir := t.TempDir()
+	const promptsDir = "/home/user/prompts"
+	const filename = "Solve Issue.chatmode.md"
+
+	versions, err := ListVersions(dir, promptsDir, filename)
+	if err != nil || len(versions) != 0 {
+		t.Fatalf("Expected no versions before the first archive, got %v, %v", versions, err)
+	}
+
+	for i := 1; i <= MaxVersions+2; i++ {
+		version, err := ArchiveVersion(dir, promptsDir, filename, []byte(fmt.Sprintf("content %d", i)))
+		if err != nil {
+			t.Fatalf("ArchiveVersion failed: %v", err)
+		}
+		if version.Number != i {
+			t.Fatalf("Expected version %d, got %d", i, version.Number)
+		}
+	}
+
+	versions, err = ListVersions(dir, promptsDir, filename)
+	if err != nil {
+		t.Fatalf("ListVersions failed: %v", err)
+	}
+	if len(versions) != MaxVersions || versions[0].Number != MaxVersions+2 || versions[len(versions)-1].Number != 3 {
+		t.Fatalf("Expected versions %d..3, got %+v", MaxVersions+2, versions)
+	}
+
+	content, err := ReadVersion(dir, promptsDir, filename, 3)
+	if err != nil || string(content) != "content 3" {
+		t.Errorf("Expected the content of version 3, got %q, %v", content, err)
+	}
+	if _, err := ReadVersion(dir, promptsDir, filename, 1); err == nil {
+		t.Error("Expected trimmed version 1 to be gone")
+	}
+
+	// Versions are kept per prompts directory
+	if versions, err := ListVersions(dir, "/repo/.github/prompts", filename); err != nil || len(versions) != 0 {
+		t.Errorf("Expected no versions in another prompts directory, got %v, %v", versions, err)
+	}
+}
+
+// TestArchiveVersionConcurrent tests that chatmates replaced at the same
+// time get a version each instead of overwriting one another
+func TestArchiveVersionConcurrent(t *testing.T) {
+	dir := t.TempDir()
+	const promptsDir = "/home/user/prompts"
+	const filename = "Solve Issue.chatmode.md"
+	const writers = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := ArchiveVersion(dir, promptsDir, filename, []byte(fmt.Sprintf("content %d", i)))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("ArchiveVersion failed: %v", err)
+		}
+	}
+
+	versions, err := ListVersions(dir, promptsDir, filename)
+	if err != nil {
+		t.Fatalf("ListVersions failed: %v", err)
+	}
+	if len(versions) != writers {
+		t.Fatalf("Expected %d versions, got %+v", writers, versions)
+	}
+	contents := make(map[string]bool)
+	for _, version := range versions {
+		content, err := ReadVersion(dir, promptsDir, filename, version.Number)
+		if err != nil {
+			t.Fatalf("ReadVersion failed: %v", err)
+		}
+		contents[string(content)] = true
+	}
+	if len(contents) != writers {
+		t.Errorf("Expected every content to be kept once, got %v", contents)
+	}
+}
@@ -0,0 +1,381 @@
+// Package harness runs the chatmate binary end to end in isolated,
+// platform-specific environments, for integration tests.
+//
+// The binary is built once per test process (see Binary), every test gets a
+// fresh home directory with the directories of the platform it runs on (see
+// New), and output can be compared with golden files (see Golden):
+//
+//	func TestHire(t *testing.T) {
+//		env := harness.New(t)
+//		result := env.Run("--yes", "hire", "Testing")
+//		result.RequireSuccess(t)
+//		harness.Golden(t, "hire.golden", env.Normalize(result.Stdout))
+//	}
+//
+// Packages using Binary should call Cleanup from TestMain, so the built
+// binaries are removed:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//		harness.Cleanup()
+//		os.Exit(code)
+//	}
+package harness
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/mockregistry"
+)
+
+// update rewrites golden files with the current output instead of comparing,
+// e.g. 'go test ./test/integration -update'.
+var update = flag.Bool("update", false, "update golden files")
+
+// modulePath is the package built by Binary.
+const modulePath = "github.com/jonassiebler/chatmate"
+
+var (
+	buildMu  sync.Mutex
+	buildDir string
+	binaries = map[string]string{}
+)
+
+// Binary returns the path of a chatmate binary built from the working tree,
+// building it on first use. Binaries are built once per test process and
+// set of build tags, so tests share them instead of building their own.
+//
+// Parameters:
+//   - t: The test; it fails if the binary cannot be built
+//   - tags: Build tags, e.g. "faults" (see files.FaultsEnv)
+//
+// Returns:
+//   - string: Absolute path of the binary
+func Binary(t testing.TB, tags ...string) string {
+	t.Helper()
+	buildMu.Lock()
+	defer buildMu.Unlock()
+
+	key := strings.Join(tags, ",")
+	if path, ok := binaries[key]; ok {
+		return path
+	}
+
+	if buildDir == "" {
+		dir, err := os.MkdirTemp("", "chatmate-harness-*")
+		if err != nil {
+			t.Fatalf("Failed to create build directory: %v", err)
+		}
+		buildDir = dir
+	}
+	name := "chatmate"
+	if key != "" {
+		name += "-" + strings.Join(tags, "-")
+	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	path := filepath.Join(buildDir, name)
+
+	args := []string{"build", "-o", path}
+	if key != "" {
+		args = append(args, "-tags", key)
+	}
+	build := exec.Command("go", append(args, modulePath)...)
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build %s: %v\n%s", name, err, output)
+	}
+	binaries[key] = path
+	return path
+}
+
+// Cleanup removes the binaries built by Binary.
+func Cleanup() {
+	buildMu.Lock()
+	defer buildMu.Unlock()
+	if buildDir != "" {
+		_ = os.RemoveAll(buildDir)
+	}
+	buildDir = ""
+	binaries = map[string]string{}
+}
+
+// Env is an isolated environment to run chatmate in: a temporary home
+// directory with the VS Code, config, state, cache, and data directories of
+// the platform the test runs on, and no CHATMATE_* settings inherited from
+// the developer's environment.
+//
+// Fields:
+//   - Home: The simulated home directory (HOME, USERPROFILE)
+//   - AppData: The simulated roaming application data directory (APPDATA)
+//   - PromptsDir: The VS Code prompts directory chatmate installs into
+//   - MatesDir: The directory of the chatmates chatmate ships, once
+//     WriteMate was called; the embedded chatmates otherwise
+type Env struct {
+	Home       string
+	AppData    string
+	PromptsDir string
+	MatesDir   string
+
+	t        testing.TB
+	binary   string
+	env      map[string]string
+	registry string
+}
+
+// New returns an isolated environment running the binary built by Binary
+// without build tags, in VS Code (not headless) mode.
+func New(t testing.TB) *Env {
+	t.Helper()
+	return NewWithBinary(t, Binary(t))
+}
+
+// NewWithBinary returns an isolated environment running the given binary,
+// e.g. one built with build tags.
+func NewWithBinary(t testing.TB, binary string) *Env {
+	t.Helper()
+	root := t.TempDir()
+	e := &Env{
+		Home:    filepath.Join(root, "home"),
+		AppData: filepath.Join(root, "home", "AppData", "Roaming"),
+		t:       t,
+		binary:  binary,
+	}
+	e.env = map[string]string{
+		"HOME":              e.Home,
+		"USERPROFILE":       e.Home,
+		"APPDATA":           e.AppData,
+		"LOCALAPPDATA":      filepath.Join(e.Home, "AppData", "Local"),
+		"XDG_CONFIG_HOME":   filepath.Join(e.Home, ".config"),
+		"XDG_STATE_HOME":    filepath.Join(e.Home, ".local", "state"),
+		"XDG_CACHE_HOME":    filepath.Join(e.Home, ".cache"),
+		"XDG_DATA_HOME":     filepath.Join(e.Home, ".local", "share"),
+		"VSCODE_EXTENSIONS": filepath.Join(e.Home, ".vscode", "extensions"),
+		"CHATMATE_HEADLESS": "0",
+		"CHATMATE_EDITOR":   "stable",
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		e.PromptsDir = filepath.Join(e.Home, "Library", "Application Support", "Code", "User", "prompts")
+	case "windows":
+		e.PromptsDir = filepath.Join(e.AppData, "Code", "User", "prompts")
+	default:
+		e.PromptsDir = filepath.Join(e.Home, ".config", "Code", "User", "prompts")
+	}
+	for _, dir := range []string{e.Home, e.AppData, filepath.Dir(e.PromptsDir)} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	return e
+}
+
+// Setenv sets an environment variable for the commands run in the
+// environment, e.g. CHATMATE_REGISTRY.
+func (e *Env) Setenv(key, value string) {
+	e.env[key] = value
+}
+
+// WriteChatmate writes a chatmate into the prompts directory, as if it had
+// been installed or created by hand.
+func (e *Env) WriteChatmate(filename, content string) {
+	e.t.Helper()
+	if err := os.MkdirAll(e.PromptsDir, 0755); err != nil {
+		e.t.Fatalf("Failed to create prompts directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(e.PromptsDir, filename), []byte(content), 0644); err != nil {
+		e.t.Fatalf("Failed to write %s: %v", filename, err)
+	}
+}
+
+// WriteMate writes a chatmate into the directory chatmate installs from
+// (see MatesDir), so tests don't depend on the embedded chatmates, which
+// change between releases.
+func (e *Env) WriteMate(filename, content string) {
+	e.t.Helper()
+	if e.MatesDir == "" {
+		e.MatesDir = filepath.Join(e.Home, "mates")
+		e.Setenv("CHATMATE_MATES_DIR", e.MatesDir)
+	}
+	if err := os.MkdirAll(e.MatesDir, 0755); err != nil {
+		e.t.Fatalf("Failed to create mates directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(e.MatesDir, filename), []byte(content), 0644); err != nil {
+		e.t.Fatalf("Failed to write %s: %v", filename, err)
+	}
+}
+
+// ServeRegistry starts a mock registry (see mockregistry.New) and points
+// chatmate at it (see registry.Env).
+//
+// The test is skipped on platforms where the binary cannot be made to trust
+// the test server: Go reads SSL_CERT_FILE only on Linux and other Unix
+// systems, not on macOS and Windows.
+//
+// Returns:
+//   - *mockregistry.Server: The registry, to publish chatmates and inject
+//     failures
+func (e *Env) ServeRegistry() *mockregistry.Server {
+	e.t.Helper()
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		e.t.Skipf("the test registry cannot be trusted on %s (SSL_CERT_FILE is ignored)", runtime.GOOS)
+	}
+
+	server := mockregistry.New(e.t)
+	certFile := filepath.Join(e.Home, "registry.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(certFile, cert, 0644); err != nil {
+		e.t.Fatalf("Failed to write the registry certificate: %v", err)
+	}
+	e.Setenv("SSL_CERT_FILE", certFile)
+	e.Setenv("CHATMATE_REGISTRY", server.IndexURL())
+	e.registry = server.URL
+	return server
+}
+
+// ReadChatmate returns the content of an installed chatmate.
+func (e *Env) ReadChatmate(filename string) string {
+	e.t.Helper()
+	content, err := os.ReadFile(filepath.Join(e.PromptsDir, filename))
+	if err != nil {
+		e.t.Fatalf("Failed to read %s: %v", filename, err)
+	}
+	return string(content)
+}
+
+// Result is the outcome of a command run in an Env.
+//
+// Fields:
+//   - Args: The command-line arguments
+//   - Stdout: What the command wrote to standard output
+//   - Stderr: What the command wrote to standard error
+//   - ExitCode: The exit code
+//   - Duration: How long the command ran, including process startup
+type Result struct {
+	Args     []string
+	Stdout   string
+	Stderr   string
+	ExitCode int
+	Duration time.Duration
+}
+
+// RequireSuccess fails the test if the command did not exit with 0.
+func (r *Result) RequireSuccess(t testing.TB) {
+	t.Helper()
+	if r.ExitCode != 0 {
+		t.Fatalf("chatmate %s exited with %d\nstdout:\n%s\nstderr:\n%s", strings.Join(r.Args, " "), r.ExitCode, r.Stdout, r.Stderr)
+	}
+}
+
+// RequireFailure fails the test if the command exited with 0.
+func (r *Result) RequireFailure(t testing.TB) {
+	t.Helper()
+	if r.ExitCode == 0 {
+		t.Fatalf("Expected chatmate %s to fail\nstdout:\n%s\nstderr:\n%s", strings.Join(r.Args, " "), r.Stdout, r.Stderr)
+	}
+}
+
+// has reports whether the environment sets key.
+func (e *Env) has(key string) bool {
+	_, ok := e.env[key]
+	return ok
+}
+
+// Run runs chatmate with args in the environment, from the home directory.
+func (e *Env) Run(args ...string) *Result {
+	e.t.Helper()
+	return e.RunWithInput("", args...)
+}
+
+// RunWithInput runs chatmate with args, writing input to its standard
+// input, e.g. answers to confirmation prompts.
+func (e *Env) RunWithInput(input string, args ...string) *Result {
+	e.t.Helper()
+	cmd := exec.Command(e.binary, args...)
+	cmd.Dir = e.Home
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	for _, kv := range os.Environ() {
+		if key, _, _ := strings.Cut(kv, "="); !strings.HasPrefix(key, "CHATMATE_") && !e.has(key) {
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	for key, value := range e.env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	result := &Result{Args: args}
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start)
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		e.t.Fatalf("Failed to run chatmate %s: %v", strings.Join(args, " "), err)
+	}
+	result.Stdout, result.Stderr = stdout.String(), stderr.String()
+	return result
+}
+
+// timestampPattern matches RFC 3339 timestamps, which differ between runs.
+var timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+
+// Normalize makes output comparable across runs and platforms: the prompts
+// and home directories become $PROMPTS and $HOME, the URL of the test
+// registry $REGISTRY, timestamps $TIMESTAMP, and Windows path separators
+// slashes.
+func (e *Env) Normalize(output string) string {
+	if e.registry != "" {
+		output = strings.ReplaceAll(output, e.registry, "$REGISTRY")
+	}
+	for _, dir := range []struct{ path, name string }{{e.PromptsDir, "$PROMPTS"}, {e.Home, "$HOME"}} {
+		// Paths appear as they are in text, and escaped in JSON
+		output = strings.ReplaceAll(output, strings.ReplaceAll(dir.path, `\`, `\\`), dir.name)
+		output = strings.ReplaceAll(output, dir.path, dir.name)
+	}
+	if runtime.GOOS == "windows" {
+		output = strings.ReplaceAll(output, `\\`, "/")
+		output = strings.ReplaceAll(output, `\`, "/")
+		output = strings.ReplaceAll(output, "\r\n", "\n")
+	}
+	return timestampPattern.ReplaceAllString(output, "$$TIMESTAMP")
+}
+
+// Golden compares output with the golden file testdata/<name>, or rewrites
+// the file when the tests run with -update.
+func Golden(t testing.TB, name, output string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			t.Fatalf("Failed to update %s: %v", path, err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s (run with -update to create it): %v", path, err)
+	}
+	if output != string(want) {
+		t.Errorf("Output differs from %s (run with -update to accept it):\n%s", path, output)
+	}
+}
@@ -0,0 +1,272 @@
+// Package mockregistry provides a test double of a chatmate registry (see
+// package registry), so registry, cache, and retry behavior can be tested
+// hermetically, without the network.
+//
+// A Server serves an index in the registry schema over HTTPS, with the
+// downloads and checksums of the chatmates added to it, and can be told to
+// fail requests with an HTTP status, respond slowly, or serve content that
+// does not match its checksum:
+//
+//	func TestBrowse(t *testing.T) {
+//		server := mockregistry.New(t)
+//		server.Add(mockregistry.Chatmate{Name: "Rust Reviewer", Version: "1.0.0", Content: "..."})
+//		server.Fail(mockregistry.IndexPath, mockregistry.Failure{Status: http.StatusInternalServerError, Times: 1})
+//
+//		client := server.Client("")
+//		index, err := client.Index(ctx, false)
+//		...
+//	}
+package mockregistry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonassiebler/chatmate/internal/registry"
+)
+
+// IndexPath is the URL path of the index.
+const IndexPath = "/index.json"
+
+// Chatmate is a chatmate published in the mock registry.
+//
+// Fields:
+//   - Name: Display name (e.g., "Rust Reviewer")
+//   - Filename: Name of the installed file; Name + ".chatmode.md" when empty
+//   - Description: What the chatmate does
+//   - Author: Who published it
+//   - Version: The current version
+//   - Content: The chatmode file of the current version
+//   - Breaking: Whether the current version is marked as breaking
+//   - Changelog: What changed in the current version
+//   - Releases: Earlier versions that can be installed instead
+type Chatmate struct {
+	Name        string
+	Filename    string
+	Description string
+	Author      string
+	Version     string
+	Content     string
+	Breaking    bool
+	Changelog   string
+	Releases    []Release
+}
+
+// Release is an earlier version of a Chatmate.
+//
+// Fields:
+//   - Version: Version of the chatmate (e.g., "1.4.0")
+//   - Released: When the version was published
+//   - Content: The chatmode file of this version
+//   - Breaking: Whether the version is marked as breaking
+//   - Changelog: What changed in the version
+type Release struct {
+	Version   string
+	Released  time.Time
+	Content   string
+	Breaking  bool
+	Changelog string
+}
+
+// Failure makes requests of a path fail or misbehave.
+//
+// Fields:
+//   - Status: HTTP status to respond with instead of the content (e.g.,
+//     http.StatusInternalServerError); 0 serves the content
+//   - Delay: How long to wait before responding, e.g. to exceed a timeout;
+//     the wait ends early when the client gives up
+//   - Body: Content served instead of the real one, such as a tampered
+//     download or a malformed index; ignored when empty
+//   - Times: Number of requests the failure applies to before the path
+//     works again; 0 for every request
+type Failure struct {
+	Status int
+	Delay  time.Duration
+	Body   string
+	Times  int
+}
+
+// Server is a registry served over HTTPS by an httptest server.
+//
+// It is safe for concurrent use; chatmates and failures may be changed
+// while the server runs.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	chatmates []Chatmate
+	files     map[string]string
+	failures  map[string]*Failure
+	requests  map[string]int
+}
+
+// New starts a mock registry without chatmates. It is closed when the test
+// ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{files: map[string]string{}, failures: map[string]*Failure{}, requests: map[string]int{}}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Add publishes a chatmate, replacing one with the same filename. Its
+// versions are downloaded from DownloadPath.
+func (s *Server) Add(chatmate Chatmate) {
+	if chatmate.Filename == "" {
+		chatmate.Filename = chatmate.Name + ".chatmode.md"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n, existing := range s.chatmates {
+		if existing.Filename == chatmate.Filename {
+			s.chatmates = append(s.chatmates[:n], s.chatmates[n+1:]...)
+			break
+		}
+	}
+	s.chatmates = append(s.chatmates, chatmate)
+	s.files[DownloadPath(chatmate.Filename, "")] = chatmate.Content
+	for _, release := range chatmate.Releases {
+		s.files[DownloadPath(chatmate.Filename, release.Version)] = release.Content
+	}
+}
+
+// Fail makes the requests of path fail as described by failure, replacing
+// an earlier failure of the path. A zero Failure removes it.
+//
+// Parameters:
+//   - path: IndexPath, a DownloadPath, or any other URL path
+//   - failure: How the requests fail
+func (s *Server) Fail(path string, failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if failure == (Failure{}) {
+		delete(s.failures, path)
+		return
+	}
+	s.failures[path] = &failure
+}
+
+// Requests returns how often path was requested.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+// IndexURL returns the URL of the index, the value for registry.Env.
+func (s *Server) IndexURL() string {
+	return s.URL + IndexPath
+}
+
+// Client returns a registry client for the server that trusts its
+// certificate.
+//
+// Parameters:
+//   - cachePath: Where the index is cached; caching is disabled when empty
+func (s *Server) Client(cachePath string) *registry.Client {
+	return &registry.Client{URL: s.IndexURL(), CachePath: cachePath, HTTP: s.Server.Client()}
+}
+
+// DownloadPath returns the URL path a version of a chatmate is downloaded
+// from; an empty version is the current one.
+func DownloadPath(filename, version string) string {
+	if version == "" {
+		return "/mates/" + url.PathEscape(filename)
+	}
+	return "/mates/" + url.PathEscape(version) + "/" + url.PathEscape(filename)
+}
+
+// Checksum returns the hex encoded SHA-256 of content, as listed in the index.
+func Checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// serve handles a request: a failure of the path, the index, or a download.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	s.mu.Lock()
+	s.requests[path]++
+	var failure Failure
+	if f, ok := s.failures[path]; ok {
+		failure = *f
+		if f.Times > 0 {
+			if f.Times--; f.Times == 0 {
+				delete(s.failures, path)
+			}
+		}
+	}
+	content, ok := s.files[path]
+	if path == IndexPath {
+		content, ok = s.index(), true
+	}
+	s.mu.Unlock()
+
+	if failure.Delay > 0 {
+		select {
+		case <-time.After(failure.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failure.Status != 0 {
+		http.Error(w, http.StatusText(failure.Status), failure.Status)
+		return
+	}
+	if failure.Body != "" {
+		content, ok = failure.Body, true
+	}
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, _ = w.Write([]byte(content))
+}
+
+// index encodes the published chatmates in the registry schema. The caller
+// holds s.mu.
+func (s *Server) index() string {
+	index := struct {
+		Chatmates []registry.Entry `json:"chatmates"`
+	}{Chatmates: []registry.Entry{}}
+	for _, chatmate := range s.chatmates {
+		entry := registry.Entry{
+			Name:        chatmate.Name,
+			Filename:    chatmate.Filename,
+			Description: chatmate.Description,
+			Author:      chatmate.Author,
+			Version:     chatmate.Version,
+			URL:         DownloadPath(chatmate.Filename, "")[1:],
+			SHA256:      Checksum(chatmate.Content),
+			Breaking:    chatmate.Breaking,
+			Changelog:   chatmate.Changelog,
+		}
+		for _, release := range chatmate.Releases {
+			entry.Versions = append(entry.Versions, registry.Release{
+				Version:   release.Version,
+				Released:  release.Released,
+				URL:       DownloadPath(chatmate.Filename, release.Version)[1:],
+				SHA256:    Checksum(release.Content),
+				Breaking:  release.Breaking,
+				Changelog: release.Changelog,
+			})
+		}
+		index.Chatmates = append(index.Chatmates, entry)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		panic(fmt.Sprintf("mockregistry: failed to encode the index: %v", err))
+	}
+	return string(data)
+}
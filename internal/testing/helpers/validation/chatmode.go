@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
@@ -16,13 +17,14 @@ import (
 
 // ChatmodeHeader represents the YAML frontmatter structure
 type ChatmodeHeader struct {
-	Name        string   `yaml:"name"`
-	Description string   `yaml:"description"`
-	Author      string   `yaml:"author"`
-	Model       string   `yaml:"model"`
-	Tools       []string `yaml:"tools"`
-	Prompt      string   `yaml:"prompt"`
-	Tag         string   `yaml:"tag"`
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Author      string          `yaml:"author"`
+	Model       string          `yaml:"model"`
+	Tools       []string        `yaml:"tools"`
+	Prompt      string          `yaml:"prompt"`
+	Tag         string          `yaml:"tag"`
+	Examples    []files.Example `yaml:"examples"`
 }
 
 // ValidateChatmodeFile validates a chatmode file structure and content
@@ -0,0 +1,151 @@
+// Package chatmate is the library API for managing ChatMate agents from other
+// Go programs.
+//
+// The services of a Manager return typed results instead of printing, so a
+// program can install, list, and inspect chatmates without capturing
+// standard output. What the services still print, such as confirmation
+// prompts, warnings, and verbose diagnostics, is written to the manager's
+// Output and ErrOutput, which default to standard output and error.
+//
+// Operations on several chatmates take a context: once it is done they stop
+// before the next chatmate, and their file operations fail with its error.
+//
+// Usage Example:
+//
+//	m, err := chatmate.New()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	// Follow the installation as it happens
+//	m.Progress = func(result chatmate.InstallResult) {
+//	    log.Printf("%s: %s", result.Name, result.Status)
+//	}
+//
+//	report, err := m.Installer().InstallSpecific(ctx, []string{"Solve Issue"}, false)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d installed into %s\n", report.Installed(), report.PromptsDir)
+//
+//	listing, err := m.Lister().Listing(false, true)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, c := range listing.Chatmates {
+//	    fmt.Println(c.Name)
+//	}
+package chatmate
+
+import (
+	"github.com/jonassiebler/chatmate/internal/manager"
+)
+
+// Manager manages the chatmates of the current user; see New.
+type Manager = manager.ChatMateManager
+
+// Results of installations.
+type (
+	// InstallReport is the outcome of an installation.
+	InstallReport = manager.InstallReport
+	// InstallResult is the outcome of installing a single chatmate.
+	InstallResult = manager.InstallResult
+	// InstallStatus is the kind of outcome of an InstallResult.
+	InstallStatus = manager.InstallStatus
+	// InstallPlan describes what installing all chatmates would do.
+	InstallPlan = manager.InstallPlan
+)
+
+// Installation outcomes.
+const (
+	InstallInstalled   = manager.InstallInstalled
+	InstallReinstalled = manager.InstallReinstalled
+	InstallSkipped     = manager.InstallSkipped
+	InstallQueued      = manager.InstallQueued
+	InstallExported    = manager.InstallExported
+	InstallMigrated    = manager.InstallMigrated
+)
+
+// Results of listings, status, and validation.
+type (
+	// Listing is a list of chatmates with their installation status.
+	Listing = manager.Listing
+	// ListedChatmate is an entry of a Listing.
+	ListedChatmate = manager.ListedChatmate
+	// ChatmateDetails describes a single chatmate and its content.
+	ChatmateDetails = manager.ChatmateDetails
+	// CatalogEntry is a chatmate that can be installed, possibly from the
+	// registry.
+	CatalogEntry = manager.CatalogEntry
+	// StatusReport summarizes the installation.
+	StatusReport = manager.StatusReport
+	// StatusDetails is the status report with health checks and history.
+	StatusDetails = manager.StatusDetails
+	// ConfigReport lists the directories and modes ChatMate uses.
+	ConfigReport = manager.ConfigReport
+	// Report is the result of validating the installation.
+	Report = manager.Report
+)
+
+// Sources of available chatmates; further ones are added to Manager.Sources.
+type (
+	// Source provides chatmates that can be installed.
+	Source = manager.Source
+	// EmbeddedSource provides the chatmates embedded in ChatMate.
+	EmbeddedSource = manager.EmbeddedSource
+	// DirectorySource provides the chatmates in a directory.
+	DirectorySource = manager.DirectorySource
+	// GitSource provides the chatmates of a git repository.
+	GitSource = manager.GitSource
+	// HTTPSource provides the chatmates of an index served over HTTPS.
+	HTTPSource = manager.HTTPSource
+	// SourceInfo describes a source and how many chatmates it provides.
+	SourceInfo = manager.SourceInfo
+)
+
+// ParseSource returns the source a directory, git URL, or https index URL
+// stands for, like 'chatmate source add' does.
+//
+// Parameters:
+//   - spec: The source
+//   - cacheDir: Where git sources are cloned and indexes cached
+//
+// Returns:
+//   - Source: The source; nothing is read until it is used
+//   - error: Error if spec is not a valid source
+func ParseSource(spec, cacheDir string) (Source, error) {
+	return manager.ParseSource(spec, cacheDir)
+}
+
+// Name length limits that 'chatmate validate' checks and hire and import
+// warn about.
+const (
+	MaxDisplayNameLength = manager.MaxDisplayNameLength
+	MaxPathLength        = manager.MaxPathLength
+)
+
+// NameLengthIssue is a chatmate name over one of the length limits.
+type NameLengthIssue = manager.NameLengthIssue
+
+// CheckNameLength checks the display name of a chatmate, and its path in
+// promptsDir unless that is empty, against the name length limits.
+//
+// Parameters:
+//   - filename: The chatmate filename (e.g., "Solve Issue.chatmode.md")
+//   - promptsDir: The directory the chatmate is installed into, or ""
+//
+// Returns:
+//   - []NameLengthIssue: The limits the name exceeds, with shorter names
+func CheckNameLength(filename, promptsDir string) []NameLengthIssue {
+	return manager.CheckNameLength(filename, promptsDir)
+}
+
+// New creates a Manager for the current user, with the same directories and
+// settings the chatmate command uses.
+//
+// Returns:
+//   - *Manager: Configured manager instance
+//   - error: Configuration or directory detection error
+func New() (*Manager, error) {
+	return manager.NewChatMateManager()
+}
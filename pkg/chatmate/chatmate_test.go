@@ -0,0 +1,68 @@
+package chatmate
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/output"
+)
+
+// TestManagerReturnsResults tests that installing and listing through the
+// library returns the results without printing them
+func TestManagerReturnsResults(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(home, "cache"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("CHATMATE_HEADLESS", "1")
+	t.Setenv("CHATMATE_POLICY", "")
+	t.Setenv("CHATMATE_SHARED", "")
+
+	matesDir := filepath.Join(home, "mates")
+	if err := os.MkdirAll(matesDir, 0755); err != nil {
+		t.Fatalf("Failed to create mates directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(matesDir, "Solve Issue.chatmode.md"), []byte("---\ndescription: Solve Issue\n---\nBody\n"), 0644); err != nil {
+		t.Fatalf("Failed to create chatmate: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	output.SetWriters(&stdout, &bytes.Buffer{})
+	defer output.SetWriters(nil, nil)
+
+	m, err := New()
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	m.MatesDir = matesDir
+	m.UseEmbedded = false
+	m.PromptsDir = filepath.Join(home, "prompts")
+
+	var progress []InstallResult
+	m.Progress = func(result InstallResult) { progress = append(progress, result) }
+
+	report, err := m.Installer().InstallSpecific(context.Background(), []string{"Solve Issue"}, false)
+	if err != nil {
+		t.Fatalf("InstallSpecific failed: %v", err)
+	}
+	if report.Installed() != 1 || report.Results[0].Status != InstallInstalled || len(progress) != 1 {
+		t.Errorf("Expected one installed chatmate reported, got %+v (progress %+v)", report.Results, progress)
+	}
+
+	listing, err := m.Lister().Listing(false, true)
+	if err != nil {
+		t.Fatalf("Listing failed: %v", err)
+	}
+	if len(listing.Chatmates) != 1 || listing.Chatmates[0].Name != "Solve Issue" {
+		t.Errorf("Expected the installed chatmate to be listed, got %+v", listing.Chatmates)
+	}
+
+	if stdout.Len() != 0 {
+		t.Errorf("The library should not print results, got %q", stdout.String())
+	}
+}
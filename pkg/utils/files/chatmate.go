@@ -7,6 +7,8 @@ package files
 
 import (
 	"strings"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // GetChatmateNameFromFilename extracts the chatmate name from a filename.
@@ -36,6 +38,39 @@ func GetChatmateNameFromFilename(filename string) string {
 	return filename
 }
 
+// NormalizeFilename returns filename in Unicode normalization form C (NFC).
+//
+// The same name can be written with precomposed characters ("é", NFC) or
+// with a base letter and a combining mark ("e" and U+0301, NFD). macOS hands
+// out filenames in NFD while chatmate sources, settings, and the command
+// line use NFC, so names are normalized before they are compared or stored.
+//
+// Example:
+//
+//	name := NormalizeFilename("Cafe\u0301 Helper.chatmode.md")
+//	fmt.Println(name == "Caf\u00e9 Helper.chatmode.md") // Output: true
+//
+// Parameters:
+//   - filename: the filename to normalize
+//
+// Returns:
+//   - string: the filename in NFC; filename itself if it already is
+func NormalizeFilename(filename string) string {
+	return norm.NFC.String(filename)
+}
+
+// SameFilename reports whether two filenames are the same name, regardless
+// of their Unicode normalization (see NormalizeFilename).
+//
+// Parameters:
+//   - a, b: the filenames to compare
+//
+// Returns:
+//   - bool: true if both filenames are equal in NFC
+func SameFilename(a, b string) bool {
+	return a == b || NormalizeFilename(a) == NormalizeFilename(b)
+}
+
 // IsChatmateFile checks if a filename is a valid chatmate file.
 //
 // This function determines if a filename follows the chatmate naming convention
@@ -106,3 +106,21 @@ func TestIsChatmateFile(t *testing.T) {
 		})
 	}
 }
+
+// TestNormalizeFilename tests comparing filenames across Unicode normalizations
+func TestNormalizeFilename(t *testing.T) {
+	const nfc, nfd = "Caf\u00e9 Helper.chatmode.md", "Cafe\u0301 Helper.chatmode.md"
+
+	if got := NormalizeFilename(nfd); got != nfc {
+		t.Errorf("NormalizeFilename(%q) = %q, want %q", nfd, got, nfc)
+	}
+	if got := NormalizeFilename(nfc); got != nfc {
+		t.Errorf("NormalizeFilename(%q) = %q, want it unchanged", nfc, got)
+	}
+	if !SameFilename(nfc, nfd) {
+		t.Errorf("Expected %q and %q to be the same filename", nfc, nfd)
+	}
+	if SameFilename(nfc, "café helper.chatmode.md") {
+		t.Error("Expected filenames that differ in case to be different")
+	}
+}
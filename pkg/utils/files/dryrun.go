@@ -0,0 +1,239 @@
+package files
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kinds of Change recorded by a DryRunFS.
+const (
+	// ChangeCreate is a file that would be created
+	ChangeCreate = "create"
+	// ChangeOverwrite is an existing file that would be replaced
+	ChangeOverwrite = "overwrite"
+	// ChangeRemove is a file or directory that would be removed
+	ChangeRemove = "remove"
+	// ChangeRename is a file that would be moved to Change.To
+	ChangeRename = "rename"
+	// ChangeMkdir is a directory that would be created
+	ChangeMkdir = "mkdir"
+)
+
+// Change is a file change a dry run did not make.
+//
+// Fields:
+//   - Kind: What would happen (see ChangeCreate and friends)
+//   - Path: The file or directory that would change
+//   - To: The new path of a renamed file
+//   - Bytes: Size of the content that would be written
+type Change struct {
+	Kind  string `json:"kind"`
+	Path  string `json:"path"`
+	To    string `json:"to,omitempty"`
+	Bytes int    `json:"bytes,omitempty"`
+}
+
+// DryRunFS is a FileSystem that reads from a backend but never changes it:
+// writes, removals, renames, and new directories are recorded as Changes
+// instead, for the global --dry-run option.
+//
+// Later operations see the recorded changes, so an operation that writes a
+// file and reads it back, or lists the directory afterwards, behaves as it
+// would for real.
+//
+// Example:
+//
+//	dryRun := files.NewDryRunFS(manager.FS.FileSystem())
+//	manager.FS.Backend = dryRun
+//	// ... install chatmates ...
+//	for _, change := range dryRun.Changes() {
+//		fmt.Println(change.Kind, change.Path)
+//	}
+type DryRunFS struct {
+	backend FileSystem
+
+	mu      sync.Mutex
+	files   map[string]dryRunInfo
+	data    map[string][]byte
+	removed map[string]bool
+	changes []Change
+}
+
+// NewDryRunFS returns a FileSystem that reads from backend and records
+// changes instead of making them.
+func NewDryRunFS(backend FileSystem) *DryRunFS {
+	return &DryRunFS{backend: backend, files: map[string]dryRunInfo{}, data: map[string][]byte{}, removed: map[string]bool{}}
+}
+
+// Changes returns the changes recorded so far, in the order they were made.
+func (d *DryRunFS) Changes() []Change {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Change(nil), d.changes...)
+}
+
+// stat returns information about path as changed so far. The caller holds d.mu.
+func (d *DryRunFS) stat(path string) (fs.FileInfo, error) {
+	if d.removed[path] {
+		return nil, &fs.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+	}
+	if info, ok := d.files[path]; ok {
+		return info, nil
+	}
+	return d.backend.Stat(path)
+}
+
+// write records path with data as changed. The caller holds d.mu.
+func (d *DryRunFS) write(path string, data []byte, perm fs.FileMode) {
+	delete(d.removed, path)
+	d.files[path] = dryRunInfo{name: filepath.Base(path), size: int64(len(data)), mode: perm, modTime: time.Now()}
+	d.data[path] = append([]byte(nil), data...)
+}
+
+// ReadFile implements FileSystem.
+func (d *DryRunFS) ReadFile(path string) ([]byte, error) {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.removed[path] {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	if data, ok := d.data[path]; ok {
+		return append([]byte(nil), data...), nil
+	}
+	return d.backend.ReadFile(path)
+}
+
+// WriteFile implements FileSystem.
+func (d *DryRunFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	kind := ChangeCreate
+	if _, err := d.stat(path); err == nil {
+		kind = ChangeOverwrite
+	}
+	d.write(path, data, perm)
+	d.changes = append(d.changes, Change{Kind: kind, Path: path, Bytes: len(data)})
+	return nil
+}
+
+// Stat implements FileSystem.
+func (d *DryRunFS) Stat(path string) (fs.FileInfo, error) {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stat(path)
+}
+
+// ReadDir implements FileSystem.
+func (d *DryRunFS) ReadDir(path string) ([]fs.DirEntry, error) {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	info, err := d.stat(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]fs.DirEntry{}
+	if _, recorded := d.files[path]; !recorded || !info.IsDir() {
+		backendEntries, err := d.backend.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range backendEntries {
+			entries[entry.Name()] = entry
+		}
+	}
+	for name := range entries {
+		if d.removed[filepath.Join(path, name)] {
+			delete(entries, name)
+		}
+	}
+	for changed, info := range d.files {
+		if filepath.Dir(changed) == path {
+			entries[info.name] = fs.FileInfoToDirEntry(info)
+		}
+	}
+
+	sorted := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+	return sorted, nil
+}
+
+// Remove implements FileSystem.
+func (d *DryRunFS) Remove(path string) error {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.stat(path); err != nil {
+		return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+	}
+	delete(d.files, path)
+	delete(d.data, path)
+	d.removed[path] = true
+	d.changes = append(d.changes, Change{Kind: ChangeRemove, Path: path})
+	return nil
+}
+
+// Rename implements FileSystem.
+func (d *DryRunFS) Rename(oldPath, newPath string) error {
+	oldPath, newPath = filepath.Clean(oldPath), filepath.Clean(newPath)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	info, err := d.stat(oldPath)
+	if err != nil {
+		return &fs.PathError{Op: "rename", Path: oldPath, Err: fs.ErrNotExist}
+	}
+	data, ok := d.data[oldPath]
+	if !ok {
+		if data, err = d.backend.ReadFile(oldPath); err != nil {
+			return err
+		}
+	}
+	d.write(newPath, data, info.Mode())
+	delete(d.files, oldPath)
+	delete(d.data, oldPath)
+	d.removed[oldPath] = true
+	d.changes = append(d.changes, Change{Kind: ChangeRename, Path: oldPath, To: newPath})
+	return nil
+}
+
+// MkdirAll implements FileSystem.
+func (d *DryRunFS) MkdirAll(path string, perm fs.FileMode) error {
+	path = filepath.Clean(path)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if info, err := d.stat(path); err == nil {
+		if !info.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: path, Err: fs.ErrExist}
+		}
+		return nil
+	}
+	delete(d.removed, path)
+	d.files[path] = dryRunInfo{name: filepath.Base(path), mode: fs.ModeDir | perm, modTime: time.Now()}
+	d.changes = append(d.changes, Change{Kind: ChangeMkdir, Path: path})
+	return nil
+}
+
+// dryRunInfo describes a file or directory a DryRunFS recorded.
+type dryRunInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi dryRunInfo) Name() string       { return fi.name }
+func (fi dryRunInfo) Size() int64        { return fi.size }
+func (fi dryRunInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi dryRunInfo) ModTime() time.Time { return fi.modTime }
+func (fi dryRunInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi dryRunInfo) Sys() any           { return nil }
@@ -0,0 +1,93 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+)
+
+// TestDryRunFS tests that DryRunFS records changes, shows them to later
+// operations, and leaves the backend untouched
+func TestDryRunFS(t *testing.T) {
+	memFS := NewMemFS()
+	if err := memFS.MkdirAll("/prompts", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	for _, name := range []string{"A.chatmode.md", "B.chatmode.md", "C.chatmode.md"} {
+		if err := memFS.WriteFile("/prompts/"+name, []byte("old"), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+
+	dryRun := NewDryRunFS(memFS)
+	steps := []error{
+		dryRun.WriteFile("/prompts/A.chatmode.md", []byte("new"), 0644),
+		dryRun.WriteFile("/prompts/D.chatmode.md", []byte("added"), 0644),
+		dryRun.Remove("/prompts/B.chatmode.md"),
+		dryRun.Rename("/prompts/C.chatmode.md", "/prompts/E.chatmode.md"),
+		dryRun.MkdirAll("/other", 0755),
+		dryRun.MkdirAll("/prompts", 0755),
+	}
+	for i, err := range steps {
+		if err != nil {
+			t.Fatalf("Step %d failed: %v", i, err)
+		}
+	}
+	if err := dryRun.Remove("/prompts/B.chatmode.md"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a removed file to be gone, got %v", err)
+	}
+
+	want := []Change{
+		{Kind: ChangeOverwrite, Path: "/prompts/A.chatmode.md", Bytes: 3},
+		{Kind: ChangeCreate, Path: "/prompts/D.chatmode.md", Bytes: 5},
+		{Kind: ChangeRemove, Path: "/prompts/B.chatmode.md"},
+		{Kind: ChangeRename, Path: "/prompts/C.chatmode.md", To: "/prompts/E.chatmode.md"},
+		{Kind: ChangeMkdir, Path: "/other"},
+	}
+	changes := dryRun.Changes()
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	// Later operations see the changes
+	if data, err := dryRun.ReadFile("/prompts/A.chatmode.md"); err != nil || string(data) != "new" {
+		t.Errorf("Expected the recorded content, got %q, %v", data, err)
+	}
+	if data, err := dryRun.ReadFile("/prompts/E.chatmode.md"); err != nil || string(data) != "old" {
+		t.Errorf("Expected the renamed content, got %q, %v", data, err)
+	}
+	entries, err := dryRun.ReadDir("/prompts")
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if len(names) != 3 || names[0] != "A.chatmode.md" || names[1] != "D.chatmode.md" || names[2] != "E.chatmode.md" {
+		t.Errorf("Unexpected directory listing: %v", names)
+	}
+	if entries, err := dryRun.ReadDir("/other"); err != nil || len(entries) != 0 {
+		t.Errorf("Expected the new directory to be empty, got %v, %v", entries, err)
+	}
+
+	// The backend is untouched
+	if data, err := memFS.ReadFile("/prompts/A.chatmode.md"); err != nil || string(data) != "old" {
+		t.Errorf("Expected the backend to keep the old content, got %q, %v", data, err)
+	}
+	for _, path := range []string{"/prompts/B.chatmode.md", "/prompts/C.chatmode.md"} {
+		if _, err := memFS.Stat(path); err != nil {
+			t.Errorf("Expected %s to remain in the backend: %v", path, err)
+		}
+	}
+	for _, path := range []string{"/prompts/D.chatmode.md", "/prompts/E.chatmode.md", "/other"} {
+		if _, err := memFS.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Expected %s not to be created in the backend, got %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package files
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// FaultsEnv is the environment variable with the faults to inject into
+// prompts and mates directory operations (see ParseFaults). It is only read
+// by binaries built with the "faults" build tag, for integration tests:
+//
+//	go build -tags faults -o chatmate-faults .
+//	CHATMATE_FAULTS="write:Testing.chatmode.md=eio" ./chatmate-faults hire
+const FaultsEnv = "CHATMATE_FAULTS"
+
+// FaultKind is the failure a Fault simulates.
+type FaultKind string
+
+// The failures a Fault can simulate.
+const (
+	// FaultIO fails the operation with an I/O error (EIO), e.g. a broken disk
+	FaultIO FaultKind = "eio"
+	// FaultPermission fails the operation with a permission error
+	FaultPermission FaultKind = "permission"
+	// FaultNoSpace fails the operation because the disk is full (ENOSPC)
+	FaultNoSpace FaultKind = "nospace"
+	// FaultPartialRead returns the first half of a file with
+	// io.ErrUnexpectedEOF; only reads can be partial
+	FaultPartialRead FaultKind = "partial"
+)
+
+// faultOps are the operations a Fault can target, named as in Policy.
+var faultOps = []string{"read", "write", "stat", "list", "remove", "rename", "create"}
+
+// Fault is a failure injected into the operations of a FaultFS.
+//
+// Fields:
+//   - Op: The operation to fail: read, write, stat, list, remove, rename,
+//     create, or "*" for every operation
+//   - Pattern: filepath.Match pattern for the base name of the file operated
+//     on, e.g. "*.chatmode.md"; "*" matches every file
+//   - Kind: The failure to simulate
+//   - After: The matching operation that fails first, starting at 1; it and
+//     every later matching operation fail
+type Fault struct {
+	Op      string
+	Pattern string
+	Kind    FaultKind
+	After   int
+}
+
+// String returns the fault in the syntax of ParseFaults.
+func (f Fault) String() string {
+	return fmt.Sprintf("%s:%s=%s@%d", f.Op, f.Pattern, f.Kind, f.After)
+}
+
+// matches reports whether the fault targets an operation on path.
+func (f Fault) matches(op, path string) bool {
+	if f.Op != "*" && f.Op != op {
+		return false
+	}
+	matched, err := filepath.Match(f.Pattern, filepath.Base(path))
+	return err == nil && matched
+}
+
+// ParseFaults parses a list of faults separated by semicolons, such as the
+// value of FaultsEnv. Each fault has the form
+//
+//	<op>:<pattern>=<kind>[@<n>]
+//
+// where n is the matching operation that fails first (default 1).
+//
+// Parameters:
+//   - spec: The faults, e.g. "write:Testing.chatmode.md=eio@2; read:*=partial"
+//
+// Returns:
+//   - []Fault: The faults, in order; nil for an empty spec
+//   - error: Description of the first invalid fault
+//
+// Example:
+//
+//	faults, err := files.ParseFaults(os.Getenv(files.FaultsEnv))
+//	if err != nil {
+//		return err
+//	}
+//	policy.Backend = files.NewFaultFS(files.OS{}, faults)
+func ParseFaults(spec string) ([]Fault, error) {
+	var faults []Fault
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		target, kind, ok := strings.Cut(rule, "=")
+		op, pattern, hasPattern := strings.Cut(target, ":")
+		if !ok || !hasPattern || pattern == "" {
+			return nil, fmt.Errorf("invalid fault %q: expected <op>:<pattern>=<kind>[@<n>]", rule)
+		}
+		fault := Fault{Op: strings.ToLower(op), Pattern: pattern, After: 1}
+
+		kind, after, hasAfter := strings.Cut(kind, "@")
+		fault.Kind = FaultKind(strings.ToLower(kind))
+		if hasAfter {
+			n, err := strconv.Atoi(after)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid fault %q: %q is not a positive number", rule, after)
+			}
+			fault.After = n
+		}
+
+		if fault.Op != "*" && !slices.Contains(faultOps, fault.Op) {
+			return nil, fmt.Errorf("invalid fault %q: unknown operation %q (available: %s, *)", rule, op, strings.Join(faultOps, ", "))
+		}
+		if _, err := filepath.Match(fault.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid fault %q: %w", rule, err)
+		}
+		switch fault.Kind {
+		case FaultIO, FaultPermission, FaultNoSpace:
+		case FaultPartialRead:
+			if fault.Op != "read" {
+				return nil, fmt.Errorf("invalid fault %q: only reads can be partial", rule)
+			}
+		default:
+			return nil, fmt.Errorf("invalid fault %q: unknown kind %q (available: eio, permission, nospace, partial)", rule, kind)
+		}
+		faults = append(faults, fault)
+	}
+	return faults, nil
+}
+
+// FaultFS is a FileSystem that injects faults into the operations of
+// another, so tests can check that ChatMate reports failed writes, denied
+// access, and truncated reads, and recovers from them (e.g., with
+// 'chatmate hire --resume').
+//
+// Operations without a matching fault are passed to the backend unchanged.
+// A FaultFS is safe for concurrent use.
+//
+// Usage Example:
+//
+//	memFS := files.NewMemFS()
+//	manager.FS.Backend = files.NewFaultFS(memFS, []files.Fault{
+//		{Op: "write", Pattern: "B.chatmode.md", Kind: files.FaultIO, After: 1},
+//	})
+type FaultFS struct {
+	backend FileSystem
+
+	mu     sync.Mutex
+	faults []Fault
+	calls  []int
+}
+
+// NewFaultFS returns a FileSystem that runs operations on backend, failing
+// those that match one of faults.
+func NewFaultFS(backend FileSystem, faults []Fault) *FaultFS {
+	return &FaultFS{backend: backend, faults: faults, calls: make([]int, len(faults))}
+}
+
+// inject returns the fault for an operation on path, counting the
+// operation for every fault that targets it.
+func (f *FaultFS) inject(op, path string) (Fault, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var injected Fault
+	found := false
+	for i, fault := range f.faults {
+		if !fault.matches(op, path) {
+			continue
+		}
+		f.calls[i]++
+		if !found && f.calls[i] >= fault.After {
+			injected, found = fault, true
+		}
+	}
+	return injected, found
+}
+
+// fail returns the error of an injected fault, as package os would.
+func (f *FaultFS) fail(op, path string) error {
+	fault, ok := f.inject(op, path)
+	if !ok {
+		return nil
+	}
+	return faultError(op, path, fault.Kind)
+}
+
+// faultError returns the *fs.PathError of a simulated failure.
+func faultError(op, path string, kind FaultKind) error {
+	var err error
+	switch kind {
+	case FaultPermission:
+		err = fs.ErrPermission
+	case FaultNoSpace:
+		err = syscall.ENOSPC
+	case FaultPartialRead:
+		err = io.ErrUnexpectedEOF
+	default:
+		err = syscall.EIO
+	}
+	return &fs.PathError{Op: op, Path: path, Err: err}
+}
+
+// ReadFile implements FileSystem.
+func (f *FaultFS) ReadFile(path string) ([]byte, error) {
+	fault, ok := f.inject("read", path)
+	if !ok {
+		return f.backend.ReadFile(path)
+	}
+	if fault.Kind != FaultPartialRead {
+		return nil, faultError("read", path, fault.Kind)
+	}
+	data, err := f.backend.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return data[:len(data)/2], faultError("read", path, fault.Kind)
+}
+
+// WriteFile implements FileSystem.
+func (f *FaultFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if err := f.fail("write", path); err != nil {
+		return err
+	}
+	return f.backend.WriteFile(path, data, perm)
+}
+
+// Stat implements FileSystem.
+func (f *FaultFS) Stat(path string) (fs.FileInfo, error) {
+	if err := f.fail("stat", path); err != nil {
+		return nil, err
+	}
+	return f.backend.Stat(path)
+}
+
+// ReadDir implements FileSystem.
+func (f *FaultFS) ReadDir(path string) ([]fs.DirEntry, error) {
+	if err := f.fail("list", path); err != nil {
+		return nil, err
+	}
+	return f.backend.ReadDir(path)
+}
+
+// Remove implements FileSystem.
+func (f *FaultFS) Remove(path string) error {
+	if err := f.fail("remove", path); err != nil {
+		return err
+	}
+	return f.backend.Remove(path)
+}
+
+// Rename implements FileSystem.
+func (f *FaultFS) Rename(oldPath, newPath string) error {
+	if err := f.fail("rename", oldPath); err != nil {
+		return err
+	}
+	return f.backend.Rename(oldPath, newPath)
+}
+
+// MkdirAll implements FileSystem.
+func (f *FaultFS) MkdirAll(path string, perm fs.FileMode) error {
+	if err := f.fail("create", path); err != nil {
+		return err
+	}
+	return f.backend.MkdirAll(path, perm)
+}
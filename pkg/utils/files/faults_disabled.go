@@ -0,0 +1,7 @@
+//go:build !faults
+
+package files
+
+// faultInjection is whether PolicyFromEnv injects the faults of FaultsEnv.
+// Release builds never do; see FaultsEnv.
+const faultInjection = false
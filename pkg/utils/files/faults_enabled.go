@@ -0,0 +1,6 @@
+//go:build faults
+
+package files
+
+// faultInjection is whether PolicyFromEnv injects the faults of FaultsEnv.
+const faultInjection = true
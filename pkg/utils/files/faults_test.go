@@ -0,0 +1,125 @@
+package files
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// TestParseFaults tests parsing the faults of FaultsEnv
+func TestParseFaults(t *testing.T) {
+	faults, err := ParseFaults(" write:Testing.chatmode.md=eio@2; read:*=PARTIAL ;; *:*.md=permission")
+	if err != nil {
+		t.Fatalf("ParseFaults failed: %v", err)
+	}
+	want := []Fault{
+		{Op: "write", Pattern: "Testing.chatmode.md", Kind: FaultIO, After: 2},
+		{Op: "read", Pattern: "*", Kind: FaultPartialRead, After: 1},
+		{Op: "*", Pattern: "*.md", Kind: FaultPermission, After: 1},
+	}
+	if len(faults) != len(want) {
+		t.Fatalf("Expected %d faults, got %v", len(want), faults)
+	}
+	for i := range want {
+		if faults[i] != want[i] {
+			t.Errorf("Fault %d = %s, want %s", i, faults[i], want[i])
+		}
+	}
+
+	if faults, err := ParseFaults(""); err != nil || faults != nil {
+		t.Errorf("Expected no faults for an empty spec, got %v, %v", faults, err)
+	}
+
+	for spec, wantErr := range map[string]string{
+		"write=eio":              "expected <op>:<pattern>=<kind>",
+		"write:*":                "expected <op>:<pattern>=<kind>",
+		"copy:*=eio":             `unknown operation "copy"`,
+		"write:*=flaky":          `unknown kind "flaky"`,
+		"write:*=partial":        "only reads can be partial",
+		"write:*=eio@0":          "is not a positive number",
+		"write:[=eio":            "syntax error in pattern",
+		"read:*=eio; write:*=no": `unknown kind "no"`,
+	} {
+		if _, err := ParseFaults(spec); err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("ParseFaults(%q) = %v, want an error containing %q", spec, err, wantErr)
+		}
+	}
+}
+
+// TestFaultFS tests that FaultFS fails matching operations only
+func TestFaultFS(t *testing.T) {
+	memFS := NewMemFS()
+	if err := memFS.MkdirAll("/prompts", 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := memFS.WriteFile("/prompts/A.chatmode.md", []byte("abcdef"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	fsys := NewFaultFS(memFS, []Fault{
+		{Op: "write", Pattern: "*.chatmode.md", Kind: FaultNoSpace, After: 2},
+		{Op: "read", Pattern: "A.chatmode.md", Kind: FaultPartialRead, After: 1},
+		{Op: "*", Pattern: "locked", Kind: FaultPermission, After: 1},
+	})
+
+	// Faults start at the configured operation and affect every later one
+	if err := fsys.WriteFile("/prompts/B.chatmode.md", []byte("b"), 0644); err != nil {
+		t.Errorf("Expected the first write to succeed, got %v", err)
+	}
+	for _, name := range []string{"C.chatmode.md", "D.chatmode.md"} {
+		if err := fsys.WriteFile("/prompts/"+name, []byte("c"), 0644); !errors.Is(err, syscall.ENOSPC) {
+			t.Errorf("Expected writing %s to fail with ENOSPC, got %v", name, err)
+		}
+		if _, err := memFS.Stat("/prompts/" + name); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("A failed write must not reach the backend, got %v", err)
+		}
+	}
+	if err := fsys.WriteFile("/prompts/notes.txt", []byte("n"), 0644); err != nil {
+		t.Errorf("Expected a file that does not match to be written, got %v", err)
+	}
+
+	content, err := fsys.ReadFile("/prompts/A.chatmode.md")
+	if !errors.Is(err, io.ErrUnexpectedEOF) || string(content) != "abc" {
+		t.Errorf("Expected half of the file with io.ErrUnexpectedEOF, got %q, %v", content, err)
+	}
+	if content, err := fsys.ReadFile("/prompts/B.chatmode.md"); err != nil || string(content) != "b" {
+		t.Errorf("Expected other files to be read, got %q, %v", content, err)
+	}
+
+	for name, err := range map[string]error{
+		"create": fsys.MkdirAll("/prompts/locked", 0755),
+		"stat":   func() error { _, err := fsys.Stat("/locked"); return err }(),
+		"list":   func() error { _, err := fsys.ReadDir("/locked"); return err }(),
+		"remove": fsys.Remove("/prompts/locked"),
+		"rename": fsys.Rename("/prompts/locked", "/prompts/other"),
+	} {
+		var pathErr *fs.PathError
+		if !errors.Is(err, fs.ErrPermission) || !errors.As(err, &pathErr) || pathErr.Op != name {
+			t.Errorf("Expected %s to fail with a permission error, got %v", name, err)
+		}
+	}
+	if err := fsys.Rename("/prompts/B.chatmode.md", "/prompts/E.chatmode.md"); err != nil {
+		t.Errorf("Expected a rename that does not match to succeed, got %v", err)
+	}
+}
+
+// TestPolicyFromEnvFaults tests that only builds with the "faults" tag
+// inject the faults of FaultsEnv
+func TestPolicyFromEnvFaults(t *testing.T) {
+	env := map[string]string{FaultsEnv: "write:*=eio"}
+	policy, err := PolicyFromEnv(func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("PolicyFromEnv failed: %v", err)
+	}
+	if _, injected := policy.Backend.(*FaultFS); injected != faultInjection {
+		t.Errorf("Expected faults to be injected only with the faults build tag, got backend %T", policy.Backend)
+	}
+
+	env[FaultsEnv] = "write:*=flaky"
+	if _, err := PolicyFromEnv(func(key string) string { return env[key] }); (err != nil) != faultInjection {
+		t.Errorf("Expected invalid faults to be reported only with the faults build tag, got %v", err)
+	}
+}
@@ -0,0 +1,421 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoFrontmatter is returned when chatmode content does not start with YAML frontmatter.
+var ErrNoFrontmatter = errors.New("missing YAML frontmatter")
+
+// Frontmatter is the YAML metadata at the top of a chatmode file.
+//
+// Fields:
+//   - Description: Short description shown by VS Code (required)
+//   - Author: Author of the chatmate, credited by 'chatmate show'
+//   - License: SPDX identifier of the license the chatmate is shared under
+//     (e.g., "MIT")
+//   - Version: Version of the chatmate (e.g., "1.2.0"), shown by
+//     'chatmate update' when an installed chatmate is replaced
+//   - Model: Preferred language model
+//   - Tools: Tools the chatmate may use
+//   - Examples: Sample invocations such as "@Solve Issue My tests fail on CI",
+//     shown by 'chatmate show' and the tutorials
+//   - Hooks: Shell commands to run around the installation of the chatmate
+//   - Extra: Fields ChatMate does not know, kept so they are not lost
+//   - Warnings: YAML that was read tolerantly (see ParseFrontmatter)
+type Frontmatter struct {
+	Description string         `yaml:"description"`
+	Author      Author         `yaml:"author,omitempty"`
+	License     string         `yaml:"license,omitempty"`
+	Version     string         `yaml:"version,omitempty"`
+	Model       string         `yaml:"model,omitempty"`
+	Tools       []string       `yaml:"tools,omitempty"`
+	Examples    []Example      `yaml:"examples,omitempty"`
+	Hooks       Hooks          `yaml:"hooks,omitempty"`
+	Extra       map[string]any `yaml:",inline"`
+	Warnings    []string       `yaml:"-"`
+}
+
+// Hooks are shell commands a chatmate runs around its installation, such as
+// downloading the tools it refers to:
+//
+//	hooks:
+//	  postInstall: 'npm install -g markdownlint-cli'
+//
+// ChatMate only runs them once the user allowed chatmates to run commands.
+//
+// Fields:
+//   - PreInstall: Runs before the chatmate is written
+//   - PostInstall: Runs after the chatmate is written
+type Hooks struct {
+	PreInstall  string `yaml:"preInstall,omitempty"`
+	PostInstall string `yaml:"postInstall,omitempty"`
+}
+
+// Author identifies who wrote a chatmate.
+//
+// In the frontmatter the author is either just a name, or a mapping that
+// adds contact details:
+//
+//	author: 'Jane Doe'
+//	author:
+//	  name: 'Jane Doe'
+//	  email: 'jane@example.com'
+//	  url: 'https://github.com/janedoe'
+//
+// Fields:
+//   - Name: Name of the person or organization
+//   - Email: Contact address
+//   - URL: Homepage or profile
+type Author struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email,omitempty"`
+	URL   string `yaml:"url,omitempty"`
+}
+
+// UnmarshalYAML accepts an author written as a plain name.
+func (a *Author) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Name)
+	}
+
+	// Decode the mapping without recursing into this method
+	type plain Author
+	return node.Decode((*plain)(a))
+}
+
+// String formats the author as "Name <email> (url)", leaving out missing parts.
+func (a Author) String() string {
+	parts := []string{a.Name}
+	if a.Email != "" {
+		parts = append(parts, "<"+a.Email+">")
+	}
+	if a.URL != "" {
+		parts = append(parts, "("+a.URL+")")
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// Example is a sample invocation of a chatmate.
+//
+// In the frontmatter an example is either just the invocation, or a mapping
+// that also describes the scenario it belongs to, so tutorials can be built
+// from the chatmates a user has installed:
+//
+//	examples:
+//	  - '@Testing Generate tests for this parser'
+//	  - prompt: '@Testing Create integration tests for the signup flow'
+//	    title: 'Integration Testing'
+//	    description: 'Create tests for component interactions'
+//	    tutorials: ['testing']
+//	    tips:
+//	      - 'Test realistic user scenarios'
+//
+// Fields:
+//   - Prompt: The invocation, starting with "@" and the chatmate name
+//   - Title: Name of the scenario the example demonstrates
+//   - Description: When the scenario applies
+//   - Tips: Advice for getting good results in the scenario
+//   - Tutorials: Names of the tutorials that feature the scenario
+type Example struct {
+	Prompt      string   `yaml:"prompt"`
+	Title       string   `yaml:"title,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Tips        []string `yaml:"tips,omitempty"`
+	Tutorials   []string `yaml:"tutorials,omitempty"`
+}
+
+// UnmarshalYAML accepts an example written as a plain invocation string.
+func (e *Example) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&e.Prompt)
+	}
+
+	// Decode the mapping without recursing into this method
+	type plain Example
+	return node.Decode((*plain)(e))
+}
+
+// InTutorial reports whether the example is featured in the named tutorial.
+func (e Example) InTutorial(tutorial string) bool {
+	for _, name := range e.Tutorials {
+		if name == tutorial {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseFrontmatter extracts the YAML frontmatter of chatmode content.
+//
+// The frontmatter is the block between a leading "---" line and the next
+// "---" line. The parse is tolerant, so every command reads the same data
+// from a chatmate that uses YAML features ChatMate does not expect:
+//   - Fields ChatMate does not know are kept in Extra
+//   - Values of the wrong type (e.g., a single tool instead of a list) are
+//     left empty and reported in Warnings
+//   - Anchors, aliases, merge keys, and further YAML documents in the block
+//     are read as far as possible and reported in Warnings
+//
+// Use ParseFrontmatterStrict to reject such content, for example when
+// checking chatmates before they are shared.
+//
+// Example:
+//
+//	meta, err := ParseFrontmatter(content)
+//	if err != nil {
+//		return fmt.Errorf("invalid chatmate: %w", err)
+//	}
+//	fmt.Println(meta.Description)
+//
+// Parameters:
+//   - content: the complete chatmode file content
+//
+// Returns:
+//   - *Frontmatter: the parsed metadata
+//   - error: ErrNoFrontmatter, an unclosed block, or YAML that cannot be
+//     read at all
+func ParseFrontmatter(content []byte) (*Frontmatter, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	content = bytes.TrimLeft(content, " \t\n")
+
+	lines := strings.Split(string(content), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, ErrNoFrontmatter
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("YAML frontmatter is not closed with ---")
+	}
+
+	var meta Frontmatter
+	decoder := yaml.NewDecoder(strings.NewReader(strings.Join(lines[1:end], "\n")))
+	var document yaml.Node
+	if err := decoder.Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return &meta, nil
+		}
+		return nil, fmt.Errorf("invalid YAML frontmatter: %w", err)
+	}
+	if len(document.Content) > 0 && document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("invalid YAML frontmatter: expected fields such as 'description: ...'")
+	}
+
+	// A "..." line ends the document; whatever follows it is not read
+	var next yaml.Node
+	if err := decoder.Decode(&next); !errors.Is(err, io.EOF) {
+		meta.Warnings = append(meta.Warnings, "contains more than one YAML document; only the first is used")
+	}
+	meta.Warnings = append(meta.Warnings, riskyConstructs(&document)...)
+
+	var typeErr *yaml.TypeError
+	if err := document.Decode(&meta); errors.As(err, &typeErr) {
+		// Decoding continues past values of the wrong type
+		for _, message := range typeErr.Errors {
+			meta.Warnings = append(meta.Warnings, "has a value of the wrong type, which is ignored: "+message)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid YAML frontmatter: %w", err)
+	}
+	return &meta, nil
+}
+
+// ReadFrontmatter parses the frontmatter of chatmode content read from r
+// like ParseFrontmatter, but stops reading at the end of the frontmatter, so
+// the body of large chatmates is never loaded.
+//
+// Example:
+//
+//	f, err := os.Open(path)
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	meta, err := ReadFrontmatter(f)
+//
+// Parameters:
+//   - r: The chatmode content
+//
+// Returns:
+//   - *Frontmatter: the parsed metadata
+//   - error: Read error, or any error of ParseFrontmatter
+func ReadFrontmatter(r io.Reader) (*Frontmatter, error) {
+	reader := bufio.NewReader(r)
+	var block bytes.Buffer
+	delimiters := 0
+	for delimiters < 2 {
+		line, err := reader.ReadString('\n')
+		block.WriteString(line)
+		switch trimmed := strings.TrimSpace(line); {
+		case trimmed == "---":
+			delimiters++
+		case delimiters == 0 && trimmed != "":
+			// Content before the frontmatter: there is none
+			return nil, ErrNoFrontmatter
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ParseFrontmatter(block.Bytes())
+}
+
+// ParseFrontmatterStrict extracts the YAML frontmatter of chatmode content
+// like ParseFrontmatter, but rejects unknown fields and everything the
+// tolerant parse reports in Warnings.
+//
+// Example:
+//
+//	if _, err := ParseFrontmatterStrict(content); err != nil {
+//		fmt.Printf("chatmate is not ready to be shared: %v\n", err)
+//	}
+//
+// Parameters:
+//   - content: the complete chatmode file content
+//
+// Returns:
+//   - *Frontmatter: the parsed metadata
+//   - error: Any error of ParseFrontmatter, or a description of every
+//     unknown field and warning
+func ParseFrontmatterStrict(content []byte) (*Frontmatter, error) {
+	meta, err := ParseFrontmatter(content)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := meta.Warnings
+	if unknown := meta.UnknownFields(); len(unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("unknown fields %s", strings.Join(unknown, ", ")))
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("YAML frontmatter is not strict: %s", strings.Join(problems, "; "))
+	}
+	return meta, nil
+}
+
+// UnknownFields returns the names of the fields ChatMate does not know, sorted.
+func (f *Frontmatter) UnknownFields() []string {
+	fields := make([]string, 0, len(f.Extra))
+	for field := range f.Extra {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// riskyConstructs describes the YAML features used in a node tree that other
+// tools reading the frontmatter may not support or may read differently.
+func riskyConstructs(node *yaml.Node) []string {
+	var anchors, merges bool
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Anchor != "" || node.Kind == yaml.AliasNode {
+			anchors = true
+		}
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Tag == "!!merge" {
+					merges = true
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	var warnings []string
+	if anchors {
+		warnings = append(warnings, "uses YAML anchors or aliases, which some tools do not resolve")
+	}
+	if merges {
+		warnings = append(warnings, "uses the YAML merge key (<<), which some tools do not support")
+	}
+	return warnings
+}
+
+// ValidateExamples checks the example invocations of a chatmate.
+//
+// Every example must mention the chatmate ("@<name>") followed by a request,
+// so it can be pasted into Copilot Chat as shown.
+//
+// Example:
+//
+//	for _, problem := range meta.ValidateExamples("Solve Issue") {
+//		fmt.Println(problem)
+//	}
+//
+// Parameters:
+//   - name: the display name of the chatmate (e.g., "Solve Issue")
+//
+// Returns:
+//   - []string: a description of each invalid example; empty if all are valid
+func (f *Frontmatter) ValidateExamples(name string) []string {
+	var problems []string
+	mention := "@" + name
+	for i, e := range f.Examples {
+		example := strings.TrimSpace(e.Prompt)
+		switch {
+		case example == "":
+			problems = append(problems, fmt.Sprintf("example %d is empty", i+1))
+		case !strings.HasPrefix(example, mention+" "):
+			problems = append(problems, fmt.Sprintf("example %d does not start with %q followed by a request", i+1, mention))
+		}
+	}
+	return problems
+}
+
+// spdxExpressionPattern matches SPDX license identifiers and simple
+// expressions such as "MIT", "Apache-2.0", "MIT OR Apache-2.0", or
+// "GPL-2.0-or-later WITH Classpath-exception-2.0".
+var spdxExpressionPattern = regexp.MustCompile(`^[A-Za-z0-9.+-]+( (AND|OR|WITH) [A-Za-z0-9.+-]+)*$`)
+
+// ValidateAttribution checks that a chatmate credits its author and states
+// the license it is shared under.
+//
+// Example:
+//
+//	for _, problem := range meta.ValidateAttribution() {
+//		fmt.Println(problem)
+//	}
+//
+// Returns:
+//   - []string: a description of each problem; empty if the attribution is complete
+func (f *Frontmatter) ValidateAttribution() []string {
+	var problems []string
+	if strings.TrimSpace(f.Author.Name) == "" {
+		problems = append(problems, "author is missing")
+	}
+	if f.Author.Email != "" && !strings.Contains(f.Author.Email, "@") {
+		problems = append(problems, fmt.Sprintf("author email %q is invalid", f.Author.Email))
+	}
+
+	license := strings.TrimSpace(f.License)
+	switch {
+	case license == "":
+		problems = append(problems, "license is missing")
+	case !spdxExpressionPattern.MatchString(license):
+		problems = append(problems, fmt.Sprintf("license %q is not an SPDX identifier such as MIT or Apache-2.0", license))
+	}
+	return problems
+}
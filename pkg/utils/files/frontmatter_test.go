@@ -0,0 +1,123 @@
+package files
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParseFrontmatter tests extracting chatmode metadata
+func TestParseFrontmatter(t *testing.T) {
+	content := []byte("---\ndescription: 'Test agent'\nexamples:\n  - '@Test Agent Check this function'\n  - '@Test Agent'\n  - 'Check this'\n" +
+		"  - prompt: '@Test Agent Review this module'\n    title: 'Module Review'\n    tutorials: ['daily-dev']\n    tips: ['Name the module']\n---\n\n# Body\n---\n")
+
+	meta, err := ParseFrontmatter(content)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if meta.Description != "Test agent" || len(meta.Examples) != 4 {
+		t.Fatalf("Unexpected metadata: %+v", meta)
+	}
+	if meta.Examples[0].Prompt != "@Test Agent Check this function" || meta.Examples[0].InTutorial("daily-dev") {
+		t.Errorf("Unexpected plain example: %+v", meta.Examples[0])
+	}
+	scenario := meta.Examples[3]
+	if scenario.Prompt != "@Test Agent Review this module" || scenario.Title != "Module Review" || len(scenario.Tips) != 1 || !scenario.InTutorial("daily-dev") {
+		t.Errorf("Unexpected scenario example: %+v", scenario)
+	}
+
+	if problems := meta.ValidateExamples("Test Agent"); len(problems) != 2 {
+		t.Errorf("Expected 2 invalid examples, got %v", problems)
+	}
+
+	if problems := meta.ValidateAttribution(); len(problems) != 2 {
+		t.Errorf("Expected missing author and license, got %v", problems)
+	}
+
+	if _, err := ParseFrontmatter([]byte("# No frontmatter")); !errors.Is(err, ErrNoFrontmatter) {
+		t.Errorf("Expected ErrNoFrontmatter, got %v", err)
+	}
+	if _, err := ParseFrontmatter([]byte("---\ndescription: open\n")); err == nil {
+		t.Error("Expected error for unclosed frontmatter")
+	}
+	if _, err := ParseFrontmatter([]byte("---\nexamples: [unclosed\n---\n")); err == nil {
+		t.Error("Expected error for invalid YAML")
+	}
+}
+
+// TestParseFrontmatterTolerant tests that unsupported YAML is read as far as
+// possible and rejected by the strict parse
+func TestParseFrontmatterTolerant(t *testing.T) {
+	content := []byte("---\ndescription: &desc 'Test agent'\nmodel: *desc\ntools: codebase\n" +
+		"mode: agent\nlicense: MIT\n...\ndescription: second\n---\n# Body\n")
+
+	meta, err := ParseFrontmatter(content)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if meta.Description != "Test agent" || meta.Model != "Test agent" || meta.License != "MIT" || len(meta.Tools) != 0 {
+		t.Errorf("Expected the valid fields to be read, got %+v", meta)
+	}
+	if unknown := meta.UnknownFields(); len(unknown) != 1 || unknown[0] != "mode" || meta.Extra["mode"] != "agent" {
+		t.Errorf("Expected the unknown field to be kept, got %v", meta.Extra)
+	}
+	if len(meta.Warnings) != 3 {
+		t.Errorf("Expected warnings for the documents, the alias, and the tools, got %q", meta.Warnings)
+	}
+
+	if _, err := ParseFrontmatterStrict(content); err == nil || !strings.Contains(err.Error(), "unknown fields mode") {
+		t.Errorf("Expected the strict parse to reject the content, got %v", err)
+	}
+	if _, err := ParseFrontmatterStrict([]byte("---\ndescription: 'Test agent'\n---\n")); err != nil {
+		t.Errorf("Expected plain frontmatter to pass the strict parse, got %v", err)
+	}
+	if _, err := ParseFrontmatter([]byte("---\n- description\n---\n")); err == nil {
+		t.Error("Expected error for frontmatter that is not a mapping")
+	}
+}
+
+// TestFrontmatterAttribution tests parsing and validating author and license
+func TestFrontmatterAttribution(t *testing.T) {
+	meta, err := ParseFrontmatter([]byte("---\ndescription: 'Test'\nauthor: 'Jane Doe'\nlicense: 'MIT'\n---\n"))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if meta.Author.String() != "Jane Doe" || len(meta.ValidateAttribution()) != 0 {
+		t.Errorf("Unexpected attribution: %s, %v", meta.Author, meta.ValidateAttribution())
+	}
+
+	meta, err = ParseFrontmatter([]byte("---\ndescription: 'Test'\nauthor:\n  name: 'Jane Doe'\n  email: 'jane@example.com'\n  url: 'https://example.com/jane'\n" +
+		"license: 'MIT OR Apache-2.0'\n---\n"))
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if meta.Author.String() != "Jane Doe <jane@example.com> (https://example.com/jane)" || len(meta.ValidateAttribution()) != 0 {
+		t.Errorf("Unexpected attribution: %s, %v", meta.Author, meta.ValidateAttribution())
+	}
+
+	meta = &Frontmatter{Author: Author{Name: "Jane", Email: "jane"}, License: "Do what you want"}
+	if problems := meta.ValidateAttribution(); len(problems) != 2 {
+		t.Errorf("Expected invalid email and license, got %v", problems)
+	}
+}
+
+// TestReadFrontmatter tests reading the frontmatter without the body
+func TestReadFrontmatter(t *testing.T) {
+	body := strings.Repeat("Body line\n", 1000)
+	reader := strings.NewReader("\n---\r\ndescription: 'Streamed'\r\n---\r\n" + body)
+	meta, err := ReadFrontmatter(reader)
+	if err != nil || meta.Description != "Streamed" {
+		t.Fatalf("ReadFrontmatter() = %+v, %v", meta, err)
+	}
+	if reader.Len() < len(body)-4096 {
+		t.Errorf("Expected the body to be left unread, %d of %d bytes remain", reader.Len(), len(body))
+	}
+
+	// Errors match ParseFrontmatter
+	for _, content := range []string{"# No frontmatter\n---\n", "---\ndescription: 'Unclosed'\n", ""} {
+		_, want := ParseFrontmatter([]byte(content))
+		if _, err := ReadFrontmatter(strings.NewReader(content)); err == nil || want == nil || err.Error() != want.Error() {
+			t.Errorf("ReadFrontmatter(%q) = %v, ParseFrontmatter = %v", content, err, want)
+		}
+	}
+}
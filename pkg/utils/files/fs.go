@@ -0,0 +1,101 @@
+package files
+
+import (
+	"io/fs"
+	"os"
+)
+
+// FileSystem is the set of file operations ChatMate performs on chatmate
+// directories.
+//
+// The manager runs every operation on the prompts and mates directories
+// through a FileSystem (see Policy.Backend), so tests can use an in-memory
+// filesystem (see MemFS) instead of the user's home directory, and other
+// backends (e.g., a remote share or an archive) can be plugged in. OS is the
+// real filesystem.
+//
+// Errors should be *fs.PathError values wrapping fs.ErrNotExist,
+// fs.ErrExist, and the like, as returned by package os, so callers can test
+// them with errors.Is and os.IsNotExist.
+type FileSystem interface {
+	// ReadFile reads the named file (see os.ReadFile)
+	ReadFile(path string) ([]byte, error)
+	// WriteFile writes data to the named file, creating it if necessary
+	// (see os.WriteFile)
+	WriteFile(path string, data []byte, perm fs.FileMode) error
+	// Stat returns information about the named file (see os.Stat)
+	Stat(path string) (fs.FileInfo, error)
+	// ReadDir reads the named directory, sorted by filename (see os.ReadDir)
+	ReadDir(path string) ([]fs.DirEntry, error)
+	// Remove removes the named file or empty directory (see os.Remove)
+	Remove(path string) error
+	// Rename renames a file, replacing an existing file (see os.Rename)
+	Rename(oldPath, newPath string) error
+	// MkdirAll creates a directory and its parents (see os.MkdirAll)
+	MkdirAll(path string, perm fs.FileMode) error
+}
+
+// DirOpener is implemented by filesystems that can read a directory in
+// batches, so large directories don't have to be held in memory at once.
+type DirOpener interface {
+	// OpenDir opens the named directory for reading with ReadDir(n)
+	OpenDir(path string) (fs.ReadDirFile, error)
+}
+
+// FileOpener is implemented by filesystems that can read a file as a stream,
+// so callers that need only its beginning (e.g., the frontmatter) don't have
+// to load all of it.
+type FileOpener interface {
+	// Open opens the named file for reading (see os.Open)
+	Open(path string) (fs.File, error)
+}
+
+// OS is the FileSystem of the operating system, implemented with package os.
+type OS struct{}
+
+// ReadFile implements FileSystem.
+func (OS) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+// WriteFile implements FileSystem. The file is replaced atomically (see
+// WriteFileAtomic), so an interrupted install never leaves a truncated
+// chatmate.
+func (OS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	return WriteFileAtomic(path, data, perm)
+}
+
+// Stat implements FileSystem.
+func (OS) Stat(path string) (fs.FileInfo, error) {
+	return os.Stat(path)
+}
+
+// ReadDir implements FileSystem.
+func (OS) ReadDir(path string) ([]fs.DirEntry, error) {
+	return os.ReadDir(path)
+}
+
+// Remove implements FileSystem.
+func (OS) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// Rename implements FileSystem.
+func (OS) Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// MkdirAll implements FileSystem.
+func (OS) MkdirAll(path string, perm fs.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// Open implements FileOpener.
+func (OS) Open(path string) (fs.File, error) {
+	return os.Open(path)
+}
+
+// OpenDir implements DirOpener.
+func (OS) OpenDir(path string) (fs.ReadDirFile, error) {
+	return os.Open(path)
+}
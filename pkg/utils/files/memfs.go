@@ -0,0 +1,255 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors of MemFS operations, wrapped in *fs.PathError.
+var (
+	errIsDir    = errors.New("is a directory")
+	errNotDir   = errors.New("not a directory")
+	errNotEmpty = errors.New("directory not empty")
+)
+
+// MemFS is a FileSystem that keeps files in memory, for tests that must not
+// touch the real filesystem.
+//
+// Paths are cleaned with filepath.Clean and may be absolute or relative; the
+// root and the current directory always exist. Like the real filesystem,
+// writing a file requires its directory to exist (see MkdirAll). File modes
+// are recorded but not enforced. A MemFS is safe for concurrent use.
+//
+// Usage Example:
+//
+//	memFS := files.NewMemFS()
+//	_ = memFS.MkdirAll("/prompts", 0755)
+//	manager.FS.Backend = memFS
+type MemFS struct {
+	mu    sync.RWMutex
+	nodes map[string]*memNode
+}
+
+// memNode is a file or directory of a MemFS.
+type memNode struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFS returns an empty in-memory filesystem.
+func NewMemFS() *MemFS {
+	return &MemFS{nodes: make(map[string]*memNode)}
+}
+
+// ReadFile implements FileSystem.
+func (m *MemFS) ReadFile(path string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	node, err := m.lookup("open", path)
+	if err != nil {
+		return nil, err
+	}
+	if node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: errIsDir}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+// WriteFile implements FileSystem.
+func (m *MemFS) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+	if err := m.requireDir("open", path, filepath.Dir(name)); err != nil {
+		return err
+	}
+	if node, ok := m.nodes[name]; ok {
+		if node.mode.IsDir() {
+			return &fs.PathError{Op: "open", Path: path, Err: errIsDir}
+		}
+		node.data = append([]byte(nil), data...)
+		node.modTime = time.Now()
+		return nil
+	}
+	m.nodes[name] = &memNode{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+	m.touchParent(name)
+	return nil
+}
+
+// Stat implements FileSystem.
+func (m *MemFS) Stat(path string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name := filepath.Clean(path)
+	node, err := m.lookup("stat", path)
+	if err != nil {
+		return nil, err
+	}
+	return memFileInfo{name: filepath.Base(name), node: *node}, nil
+}
+
+// ReadDir implements FileSystem.
+func (m *MemFS) ReadDir(path string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	name := filepath.Clean(path)
+	if err := m.requireDir("open", path, name); err != nil {
+		return nil, err
+	}
+
+	var entries []fs.DirEntry
+	for child, node := range m.nodes {
+		if child != name && filepath.Dir(child) == name {
+			entries = append(entries, fs.FileInfoToDirEntry(memFileInfo{name: filepath.Base(child), node: *node}))
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].Name() < entries[b].Name() })
+	return entries, nil
+}
+
+// Remove implements FileSystem.
+func (m *MemFS) Remove(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+	node, err := m.lookup("remove", path)
+	if err != nil {
+		return err
+	}
+	if node.mode.IsDir() && len(m.children(name)) > 0 {
+		return &fs.PathError{Op: "remove", Path: path, Err: errNotEmpty}
+	}
+	delete(m.nodes, name)
+	m.touchParent(name)
+	return nil
+}
+
+// Rename implements FileSystem.
+func (m *MemFS) Rename(oldPath, newPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from, to := filepath.Clean(oldPath), filepath.Clean(newPath)
+	node, err := m.lookup("rename", oldPath)
+	if err != nil {
+		return err
+	}
+	if err := m.requireDir("rename", newPath, filepath.Dir(to)); err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	if existing, ok := m.nodes[to]; ok && (existing.mode.IsDir() || node.mode.IsDir()) {
+		return &fs.PathError{Op: "rename", Path: newPath, Err: fs.ErrExist}
+	}
+
+	// A directory is moved with everything in it
+	for _, child := range m.children(from) {
+		m.nodes[to+child[len(from):]] = m.nodes[child]
+		delete(m.nodes, child)
+	}
+	delete(m.nodes, from)
+	m.nodes[to] = node
+	m.touchParent(from)
+	m.touchParent(to)
+	return nil
+}
+
+// MkdirAll implements FileSystem.
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+	var missing []string
+	for dir := name; !m.isRoot(dir); dir = filepath.Dir(dir) {
+		if node, ok := m.nodes[dir]; ok {
+			if !node.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: errNotDir}
+			}
+			break
+		}
+		missing = append(missing, dir)
+	}
+	for n := len(missing) - 1; n >= 0; n-- {
+		m.nodes[missing[n]] = &memNode{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+		m.touchParent(missing[n])
+	}
+	return nil
+}
+
+// lookup returns the node at path; the caller holds the lock.
+func (m *MemFS) lookup(op, path string) (*memNode, error) {
+	name := filepath.Clean(path)
+	if m.isRoot(name) {
+		return &memNode{mode: fs.ModeDir | 0755}, nil
+	}
+	node, ok := m.nodes[name]
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+	return node, nil
+}
+
+// requireDir fails unless dir is an existing directory; the caller holds the lock.
+func (m *MemFS) requireDir(op, path, dir string) error {
+	node, err := m.lookup(op, dir)
+	if err != nil {
+		return &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+	if !node.mode.IsDir() {
+		return &fs.PathError{Op: op, Path: path, Err: errNotDir}
+	}
+	return nil
+}
+
+// children returns the paths below dir; the caller holds the lock.
+func (m *MemFS) children(dir string) []string {
+	prefix := dir + string(filepath.Separator)
+	var children []string
+	for name := range m.nodes {
+		if strings.HasPrefix(name, prefix) {
+			children = append(children, name)
+		}
+	}
+	return children
+}
+
+// touchParent updates the modification time of the directory containing
+// name, as adding, removing, or renaming an entry does on disk; the caller
+// holds the lock.
+func (m *MemFS) touchParent(name string) {
+	if parent, ok := m.nodes[filepath.Dir(name)]; ok {
+		parent.modTime = time.Now()
+	}
+}
+
+// isRoot reports whether name is a directory that always exists.
+func (m *MemFS) isRoot(name string) bool {
+	return name == "." || filepath.Dir(name) == name
+}
+
+// memFileInfo describes a node of a MemFS.
+type memFileInfo struct {
+	name string
+	node memNode
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return int64(len(fi.node.data)) }
+func (fi memFileInfo) Mode() fs.FileMode  { return fi.node.mode }
+func (fi memFileInfo) ModTime() time.Time { return fi.node.modTime }
+func (fi memFileInfo) IsDir() bool        { return fi.node.mode.IsDir() }
+func (fi memFileInfo) Sys() any           { return nil }
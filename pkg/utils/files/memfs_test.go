@@ -0,0 +1,111 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestFileSystems tests that MemFS behaves like the real filesystem
+func TestFileSystems(t *testing.T) {
+	fileSystems := map[string]struct {
+		fsys FileSystem
+		root string
+	}{
+		"os":  {OS{}, t.TempDir()},
+		"mem": {NewMemFS(), filepath.Join(string(filepath.Separator), "memfs")},
+	}
+
+	for name, tc := range fileSystems {
+		t.Run(name, func(t *testing.T) {
+			fsys, dir := tc.fsys, filepath.Join(tc.root, "prompts")
+			file := filepath.Join(dir, "A.chatmode.md")
+
+			if err := fsys.WriteFile(file, []byte("a"), 0644); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Expected writing into a missing directory to fail, got %v", err)
+			}
+			if err := fsys.MkdirAll(dir, 0755); err != nil {
+				t.Fatalf("MkdirAll failed: %v", err)
+			}
+			if err := fsys.MkdirAll(dir, 0755); err != nil {
+				t.Errorf("Expected MkdirAll of an existing directory to succeed, got %v", err)
+			}
+
+			if err := fsys.WriteFile(file, []byte("a"), 0644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			if err := fsys.WriteFile(filepath.Join(dir, "b.prompt.md"), []byte("bb"), 0644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			if content, err := fsys.ReadFile(file); err != nil || string(content) != "a" {
+				t.Errorf("ReadFile returned %q, %v", content, err)
+			}
+			if info, err := fsys.Stat(file); err != nil || info.Size() != 1 || info.IsDir() || info.Name() != "A.chatmode.md" {
+				t.Errorf("Unexpected Stat result %v, %v", info, err)
+			}
+			if info, err := fsys.Stat(dir); err != nil || !info.IsDir() {
+				t.Errorf("Expected the directory to exist, got %v, %v", info, err)
+			}
+
+			renamed := filepath.Join(dir, "C.chatmode.md")
+			if err := fsys.Rename(file, renamed); err != nil {
+				t.Fatalf("Rename failed: %v", err)
+			}
+			if _, err := fsys.Stat(file); !os.IsNotExist(err) {
+				t.Errorf("Expected the old name to be gone, got %v", err)
+			}
+
+			entries, err := fsys.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("ReadDir failed: %v", err)
+			}
+			var names []string
+			for _, entry := range entries {
+				names = append(names, entry.Name())
+			}
+			if strings.Join(names, ",") != "C.chatmode.md,b.prompt.md" {
+				t.Errorf("Expected the entries sorted by name, got %v", names)
+			}
+
+			if err := fsys.Remove(dir); err == nil {
+				t.Error("Expected removing a directory that is not empty to fail")
+			}
+			if err := fsys.Remove(renamed); err != nil {
+				t.Errorf("Remove failed: %v", err)
+			}
+			if err := fsys.Remove(renamed); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Expected removing a missing file to fail with fs.ErrNotExist, got %v", err)
+			}
+			if _, err := fsys.ReadDir(filepath.Join(tc.root, "missing")); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Expected fs.ErrNotExist for a missing directory, got %v", err)
+			}
+		})
+	}
+}
+
+// TestPolicyBackend tests that a policy runs its operations on the backend
+func TestPolicyBackend(t *testing.T) {
+	memFS := NewMemFS()
+	policy := Policy{Backend: memFS}
+	dir := filepath.Join(string(filepath.Separator), "memfs", "export")
+
+	if err := policy.EnsureDir(dir); err != nil {
+		t.Fatalf("EnsureDir failed: %v", err)
+	}
+	if err := policy.WriteFile(filepath.Join(dir, "A.chatmode.md"), []byte("a"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if content, err := memFS.ReadFile(filepath.Join(dir, "A.chatmode.md")); err != nil || string(content) != "a" {
+		t.Errorf("Expected the file on the backend, got %q, %v", content, err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Nothing should be written to the real filesystem, got %v", err)
+	}
+
+	if _, ok := (Policy{}).FileSystem().(OS); !ok {
+		t.Error("Expected the real filesystem without a backend")
+	}
+}
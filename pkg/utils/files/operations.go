@@ -6,8 +6,12 @@
 package files
 
 import (
+	"errors"
+	"io/fs"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -72,6 +76,12 @@ func ExpandPath(path string) string {
 // Returns:
 //   - error: any error encountered during directory creation
 func EnsureDir(dir string) error {
+	if err := CheckWrite("create", dir); err != nil {
+		if info, statErr := os.Stat(dir); statErr == nil && info.IsDir() {
+			return nil
+		}
+		return err
+	}
 	return os.MkdirAll(dir, 0755)
 }
 
@@ -101,3 +111,126 @@ func FileExists(filename string) bool {
 	}
 	return !info.IsDir()
 }
+
+// WriteFileAtomic writes data to a file so that readers, and the file after
+// a crash, see either the old or the new content, never a partial write.
+//
+// The data is written and synced to a temporary file next to the target,
+// which is then renamed into place. The temporary file starts with a dot and
+// ends in ".tmp", so editors watching for chatmode files ignore it. A new
+// file gets perm restricted by the process umask, like with os.WriteFile; an
+// existing file keeps its permissions, and when path is a symbolic link the
+// file it points to is replaced, not the link.
+//
+// Example:
+//
+//	if err := WriteFileAtomic(path, data, 0644); err != nil {
+//		return fmt.Errorf("failed to write %s: %w", path, err)
+//	}
+//
+// Parameters:
+//   - path: The file to write
+//   - data: The new content
+//   - perm: Permissions of a new file before the umask (see os.WriteFile)
+//
+// Returns:
+//   - error: Any error creating, writing, or renaming the temporary file; the
+//     target is unchanged when an error is returned
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	existing, statErr := os.Stat(path)
+	if statErr == nil {
+		perm = existing.Mode().Perm()
+	}
+
+	temp, err := createTemp(filepath.Dir(path), "."+filepath.Base(path)+".", perm)
+	if err != nil {
+		return err
+	}
+	_, err = temp.Write(data)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	// The umask must not narrow the permissions of an existing file
+	if err == nil && statErr == nil {
+		err = os.Chmod(temp.Name(), perm)
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		return err
+	}
+	return nil
+}
+
+// CreateFileAtomic writes data to a new file like WriteFileAtomic, but never
+// replaces an existing file, so writers that pick the same name cannot
+// overwrite each other.
+//
+// The temporary file is hard-linked into place, which fails if path exists.
+// On filesystems without hard links, path is created exclusively first and
+// then replaced by the temporary file.
+//
+// Parameters:
+//   - path: The file to create
+//   - data: The content
+//   - perm: Permissions of the file before the umask (see os.WriteFile)
+//
+// Returns:
+//   - error: An error wrapping fs.ErrExist if path exists, or any error
+//     writing the file; nothing is left behind when an error is returned
+func CreateFileAtomic(path string, data []byte, perm os.FileMode) error {
+	temp, err := createTemp(filepath.Dir(path), "."+filepath.Base(path)+".", perm)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	_, err = temp.Write(data)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Link(temp.Name(), path)
+	if err == nil || errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	// No hard links: reserve the name, then replace it
+	reserved, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	_ = reserved.Close()
+	if err := os.Rename(temp.Name(), path); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return nil
+}
+
+// createTemp creates a new file in dir named prefix, a random number, and
+// ".tmp", like os.CreateTemp, but with perm instead of 0600, so the umask
+// decides the permissions the file ends up with.
+func createTemp(dir, prefix string, perm os.FileMode) (*os.File, error) {
+	for try := 0; ; try++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+".tmp")
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) && try < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
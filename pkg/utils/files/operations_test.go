@@ -1,6 +1,8 @@
 package files
 
 import (
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"testing"
@@ -137,3 +139,91 @@ func TestFileExists(t *testing.T) {
 		t.Error("FileExists() returned true for directory")
 	}
 }
+
+// TestWriteFileAtomicUmask tests that new files are created under the umask
+func TestWriteFileAtomicUmask(t *testing.T) {
+	dir := t.TempDir()
+
+	// A new file gets the permissions os.WriteFile gives it under the umask
+	reference := filepath.Join(dir, "reference")
+	if err := os.WriteFile(reference, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "Agent.chatmode.md")
+	if err := WriteFileAtomic(path, []byte("content"), 0666); err != nil {
+		t.Fatalf("WriteFileAtomic() failed to create a file: %v", err)
+	}
+	want, _ := os.Stat(reference)
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != want.Mode().Perm() {
+		t.Errorf("Expected the permissions %v of os.WriteFile, got %v, %v", want.Mode(), info.Mode(), err)
+	}
+}
+
+// TestCreateFileAtomic tests creating files without replacing existing ones
+func TestCreateFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backup.tar.gz")
+
+	if err := CreateFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("CreateFileAtomic() failed to create a file: %v", err)
+	}
+	if err := CreateFileAtomic(path, []byte("second"), 0644); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("Expected an existing file to be reported, got %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "first" {
+		t.Errorf("Expected the existing file to be kept, got %q", content)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected no temporary files to be left behind, got %v", entries)
+	}
+}
+
+// TestWriteFileAtomic tests replacing files without partial writes
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Agent.chatmode.md")
+
+	if err := WriteFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() failed to create a file: %v", err)
+	}
+
+	// An existing file keeps its permissions
+	if err := os.Chmod(path, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() failed to replace a file: %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "second" {
+		t.Errorf("Expected the new content, got %q", content)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected the permissions to be kept, got %v, %v", info.Mode(), err)
+	}
+
+	// A symbolic link keeps pointing to the file it links to
+	link := filepath.Join(dir, "Link.chatmode.md")
+	if err := os.Symlink(path, link); err != nil {
+		t.Skipf("Symbolic links not supported: %v", err)
+	}
+	if err := WriteFileAtomic(link, []byte("third"), 0644); err != nil {
+		t.Fatalf("WriteFileAtomic() failed through a link: %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected the link to be kept, got %v, %v", info, err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "third" {
+		t.Errorf("Expected the linked file to be replaced, got %q", content)
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 2 {
+		t.Errorf("Expected only the file and the link, got %v, %v", entries, err)
+	}
+
+	// A failed write leaves nothing
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "A.chatmode.md"), []byte("x"), 0644); err == nil {
+		t.Error("Expected an error writing into a missing directory")
+	}
+}
@@ -0,0 +1,52 @@
+package files
+
+import (
+	"errors"
+	"io/fs"
+	"sync/atomic"
+)
+
+// ErrReadOnly is the error of a write attempted in read-only mode.
+var ErrReadOnly = errors.New("not allowed in read-only mode")
+
+// readOnly is whether read-only mode is on for the process.
+var readOnly atomic.Bool
+
+// SetReadOnly turns read-only mode on or off for the whole process, e.g. for
+// the global --read-only flag.
+//
+// In read-only mode every write ChatMate makes fails with ErrReadOnly before
+// touching the disk: prompts directory operations run under a Policy,
+// EnsureDir, and the writes of the state, cache, and settings files, which
+// check CheckWrite first.
+func SetReadOnly(enabled bool) {
+	readOnly.Store(enabled)
+}
+
+// ReadOnly reports whether read-only mode is on.
+func ReadOnly() bool {
+	return readOnly.Load()
+}
+
+// CheckWrite returns an error wrapping ErrReadOnly in read-only mode, and nil
+// otherwise. Code that writes files without a Policy calls it first.
+//
+// Parameters:
+//   - op: The operation, e.g. "write" or "remove"
+//   - path: The file that would be changed
+//
+// Returns:
+//   - error: A *fs.PathError wrapping ErrReadOnly in read-only mode
+//
+// Example:
+//
+//	if err := files.CheckWrite("write", path); err != nil {
+//		return err
+//	}
+//	return os.WriteFile(path, data, 0644)
+func CheckWrite(op, path string) error {
+	if readOnly.Load() {
+		return &fs.PathError{Op: op, Path: path, Err: ErrReadOnly}
+	}
+	return nil
+}
@@ -0,0 +1,420 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Environment variables that configure the filesystem policy.
+const (
+	// TimeoutEnv sets the time a single file operation may take (e.g., "30s");
+	// "0" disables the timeout
+	TimeoutEnv = "CHATMATE_FS_TIMEOUT"
+	// RetriesEnv sets how often a timed out or transiently failing read, stat,
+	// directory listing, or directory creation is retried
+	RetriesEnv = "CHATMATE_FS_RETRIES"
+)
+
+// Defaults of the filesystem policy. Local disks answer in microseconds, so the
+// timeout only triggers on hung network filesystems (NFS, SMB).
+const (
+	DefaultTimeout    = 10 * time.Second
+	DefaultRetries    = 2
+	DefaultRetryDelay = 250 * time.Millisecond
+)
+
+// errTimedOut marks an attempt that did not complete within the timeout.
+var errTimedOut = errors.New("operation timed out")
+
+// Policy bounds file operations with a timeout and a limited number of retries.
+//
+// On network home directories a single file operation can hang indefinitely.
+// Operations run under a Policy give up after Timeout, so the user gets a
+// SlowFilesystemError instead of a hang. Idempotent operations (reads, stats,
+// directory listings, and directory creation) are retried up to Retries
+// times; writes, removals, and renames are attempted once (see DoOnce).
+// The zero value runs operations directly, without timeout or retries.
+//
+// An operation that timed out cannot be cancelled; it keeps running in the
+// background until the filesystem answers or the process exits.
+//
+// Fields:
+//   - Timeout: Maximum duration of a single attempt; zero disables the timeout
+//   - Retries: Number of additional attempts of an idempotent operation
+//     after a timeout or transient error
+//   - RetryDelay: Pause before each retry
+//   - OnRetry: Optional callback invoked before each retry with the attempt
+//     number (starting at 1) and the error that caused it
+//   - Context: Optional context bounding all operations together (e.g., the
+//     global --timeout); once it is done, operations fail with its error
+//     instead of starting or retrying
+//   - Backend: The filesystem operations run on; the real filesystem (OS)
+//     when nil
+type Policy struct {
+	Timeout    time.Duration
+	Retries    int
+	RetryDelay time.Duration
+	OnRetry    func(attempt int, err error)
+	Context    context.Context
+	Backend    FileSystem
+}
+
+// DefaultPolicy returns the policy used when nothing is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		Timeout:    DefaultTimeout,
+		Retries:    DefaultRetries,
+		RetryDelay: DefaultRetryDelay,
+	}
+}
+
+// PolicyFromEnv returns the default policy adjusted by TimeoutEnv and RetriesEnv.
+// Binaries built with the "faults" build tag also inject the faults of
+// FaultsEnv (see FaultFS).
+//
+// Invalid values are reported in the returned error and replaced by their
+// defaults, so the policy is always usable.
+//
+// Parameters:
+//   - getenv: Environment lookup, usually os.Getenv
+//
+// Returns:
+//   - Policy: The configured policy
+//   - error: Description of invalid environment values, if any
+//
+// Example:
+//
+//	policy, err := PolicyFromEnv(os.Getenv)
+//	if err != nil {
+//		log.Printf("ignoring invalid setting: %v", err)
+//	}
+func PolicyFromEnv(getenv func(string) string) (Policy, error) {
+	policy := DefaultPolicy()
+	var problems []string
+
+	if value := strings.TrimSpace(getenv(TimeoutEnv)); value != "" {
+		if value == "0" {
+			policy.Timeout = 0
+		} else if timeout, err := time.ParseDuration(value); err == nil && timeout >= 0 {
+			policy.Timeout = timeout
+		} else {
+			problems = append(problems, fmt.Sprintf("%s=%q is not a duration such as \"30s\"", TimeoutEnv, value))
+		}
+	}
+
+	if value := strings.TrimSpace(getenv(RetriesEnv)); value != "" {
+		if retries, err := strconv.Atoi(value); err == nil && retries >= 0 {
+			policy.Retries = retries
+		} else {
+			problems = append(problems, fmt.Sprintf("%s=%q is not a non-negative number", RetriesEnv, value))
+		}
+	}
+
+	if value := strings.TrimSpace(getenv(FaultsEnv)); faultInjection && value != "" {
+		if faults, err := ParseFaults(value); err == nil {
+			policy.Backend = NewFaultFS(OS{}, faults)
+		} else {
+			problems = append(problems, fmt.Sprintf("%s: %v", FaultsEnv, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		return policy, fmt.Errorf("invalid filesystem settings: %s", strings.Join(problems, "; "))
+	}
+	return policy, nil
+}
+
+// SlowFilesystemError reports a file operation that did not complete in time.
+//
+// Fields:
+//   - Op: The operation (e.g., "write")
+//   - Path: The file or directory operated on
+//   - Timeout: The timeout of each attempt
+//   - Attempts: How many attempts were made
+type SlowFilesystemError struct {
+	Op       string
+	Path     string
+	Timeout  time.Duration
+	Attempts int
+}
+
+// Error implements the error interface.
+func (e *SlowFilesystemError) Error() string {
+	return fmt.Sprintf("slow filesystem: %s %s did not complete within %s (%d attempt(s)); "+
+		"if this directory is on a network drive, raise %s or check the connection",
+		e.Op, e.Path, e.Timeout, e.Attempts, TimeoutEnv)
+}
+
+// Do runs an idempotent operation under the policy.
+//
+// Each attempt is bounded by the policy timeout. Attempts that time out or
+// fail with a transient error (EAGAIN, EINTR, EIO) are retried; other errors
+// are returned immediately. When the last attempt times out, a
+// *SlowFilesystemError is returned. When the policy context is done, the
+// operation stops with an error wrapping the context error (e.g.,
+// context.DeadlineExceeded).
+//
+// A retry starts while an attempt that timed out may still be running, so
+// fn must be idempotent, e.g. a read or a directory listing. Use DoOnce for
+// operations that change files.
+//
+// Parameters:
+//   - p: The policy to apply
+//   - op: Short name of the operation, used in diagnostics
+//   - path: The file or directory operated on, used in diagnostics
+//   - fn: The operation; it must be safe to run again, even concurrently
+//
+// Returns:
+//   - T: The result of the successful attempt
+//   - error: The error of the last attempt
+func Do[T any](p Policy, op, path string, fn func() (T, error)) (T, error) {
+	return do(p, op, path, p.Retries+1, fn)
+}
+
+// DoOnce runs an operation that is not safe to repeat under the policy.
+//
+// The single attempt is bounded by the policy timeout and the policy
+// context like those of Do, but it is never retried: a write, removal, or
+// rename that timed out may still complete in the background, and one that
+// failed with EIO may have been partly applied, so repeating it could race
+// with it or apply it twice. Errors are returned as they are, and a timeout
+// as a *SlowFilesystemError.
+//
+// Parameters:
+//   - p: The policy to apply
+//   - op: Short name of the operation, used in diagnostics
+//   - path: The file or directory operated on, used in diagnostics
+//   - fn: The operation
+//
+// Returns:
+//   - T: The result of the attempt
+//   - error: The error of the attempt
+func DoOnce[T any](p Policy, op, path string, fn func() (T, error)) (T, error) {
+	return do(p, op, path, 1, fn)
+}
+
+// do runs fn under the policy with at most attempts attempts.
+func do[T any](p Policy, op, path string, attempts int, fn func() (T, error)) (T, error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	ctx := p.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var value T
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return value, fmt.Errorf("%s %s: %w", op, path, ctxErr)
+		}
+		value, err = runWithTimeout(ctx, p.Timeout, fn)
+		if err == nil || !retryable(err) || attempt == attempts {
+			break
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, describeAttempt(p, op, path, err))
+		}
+		sleep(ctx, p.RetryDelay)
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+		return value, fmt.Errorf("%s %s: %w", op, path, ctxErr)
+	}
+	if errors.Is(err, errTimedOut) {
+		return value, &SlowFilesystemError{Op: op, Path: path, Timeout: p.Timeout, Attempts: attempt}
+	}
+	return value, err
+}
+
+// runWithTimeout runs a single attempt of fn, giving up after timeout or when
+// ctx is done, whichever comes first.
+func runWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func() (T, error)) (T, error) {
+	if timeout <= 0 && ctx.Done() == nil {
+		return fn()
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	// Buffered so an abandoned attempt can still deliver its result and exit
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn()
+		done <- result{value, err}
+	}()
+
+	// A nil channel never fires, so without a timeout only ctx can interrupt
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var zero T
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-expired:
+		return zero, errTimedOut
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// sleep pauses for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// retryable reports whether an attempt may succeed when repeated.
+func retryable(err error) bool {
+	return errors.Is(err, errTimedOut) ||
+		errors.Is(err, syscall.EAGAIN) ||
+		errors.Is(err, syscall.EINTR) ||
+		errors.Is(err, syscall.EIO)
+}
+
+// describeAttempt turns the error of a failed attempt into a diagnostic for OnRetry.
+func describeAttempt(p Policy, op, path string, err error) error {
+	if errors.Is(err, errTimedOut) {
+		return fmt.Errorf("slow filesystem: %s %s did not complete within %s", op, path, p.Timeout)
+	}
+	return err
+}
+
+// run adapts an idempotent operation without a result to Do.
+func (p Policy) run(op, path string, fn func() error) error {
+	_, err := Do(p, op, path, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// runOnce adapts an operation without a result to DoOnce.
+func (p Policy) runOnce(op, path string, fn func() error) error {
+	_, err := DoOnce(p, op, path, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// FileSystem returns the filesystem operations run on: the Backend, or OS
+// when none is set.
+func (p Policy) FileSystem() FileSystem {
+	if p.Backend != nil {
+		return p.Backend
+	}
+	return OS{}
+}
+
+// ReadFile reads a file under the policy (see os.ReadFile).
+func (p Policy) ReadFile(path string) ([]byte, error) {
+	return Do(p, "read", path, func() ([]byte, error) {
+		return p.FileSystem().ReadFile(path)
+	})
+}
+
+// WriteFile writes a file under the policy (see os.WriteFile), in a single
+// attempt (see DoOnce).
+func (p Policy) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if err := CheckWrite("write", path); err != nil {
+		return err
+	}
+	return p.runOnce("write", path, func() error {
+		return p.FileSystem().WriteFile(path, data, perm)
+	})
+}
+
+// Open opens a file for reading under the policy (see os.Open).
+//
+// Only opening the file is bounded by the policy. On filesystems that cannot
+// stream files (see FileOpener) the content is read at once instead.
+func (p Policy) Open(path string) (io.ReadCloser, error) {
+	opener, ok := p.FileSystem().(FileOpener)
+	if !ok {
+		content, err := p.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	return Do(p, "read", path, func() (io.ReadCloser, error) {
+		return opener.Open(path)
+	})
+}
+
+// Stat returns file information under the policy (see os.Stat).
+func (p Policy) Stat(path string) (fs.FileInfo, error) {
+	return Do(p, "stat", path, func() (fs.FileInfo, error) {
+		return p.FileSystem().Stat(path)
+	})
+}
+
+// ReadDir reads a directory under the policy (see os.ReadDir).
+func (p Policy) ReadDir(path string) ([]fs.DirEntry, error) {
+	return Do(p, "list", path, func() ([]fs.DirEntry, error) {
+		return p.FileSystem().ReadDir(path)
+	})
+}
+
+// Remove removes a file under the policy (see os.Remove), in a single
+// attempt (see DoOnce).
+func (p Policy) Remove(path string) error {
+	if err := CheckWrite("remove", path); err != nil {
+		return err
+	}
+	return p.runOnce("remove", path, func() error {
+		return p.FileSystem().Remove(path)
+	})
+}
+
+// Rename renames a file under the policy (see os.Rename), in a single
+// attempt (see DoOnce).
+func (p Policy) Rename(oldPath, newPath string) error {
+	if err := CheckWrite("rename", oldPath); err != nil {
+		return err
+	}
+	return p.runOnce("rename", oldPath, func() error {
+		return p.FileSystem().Rename(oldPath, newPath)
+	})
+}
+
+// EnsureDir creates a directory and its parents under the policy (see EnsureDir).
+func (p Policy) EnsureDir(dir string) error {
+	return p.EnsureDirMode(dir, 0755)
+}
+
+// EnsureDirMode creates a directory and its parents with perm, restricted
+// by the umask, under the policy. An existing directory is left as it is.
+func (p Policy) EnsureDirMode(dir string, perm fs.FileMode) error {
+	// An existing directory needs no write, not even in read-only mode
+	if err := CheckWrite("create", dir); err != nil {
+		if info, statErr := p.Stat(dir); statErr == nil && info.IsDir() {
+			return nil
+		}
+		return err
+	}
+	return p.run("create", dir, func() error {
+		return p.FileSystem().MkdirAll(dir, perm)
+	})
+}
@@ -0,0 +1,256 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestPolicyFromEnv tests reading the filesystem policy from the environment
+func TestPolicyFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantTimeout time.Duration
+		wantRetries int
+		wantErr     bool
+	}{
+		{name: "defaults", env: nil, wantTimeout: DefaultTimeout, wantRetries: DefaultRetries},
+		{name: "custom", env: map[string]string{TimeoutEnv: "45s", RetriesEnv: "5"}, wantTimeout: 45 * time.Second, wantRetries: 5},
+		{name: "disabled timeout", env: map[string]string{TimeoutEnv: "0", RetriesEnv: "0"}, wantTimeout: 0, wantRetries: 0},
+		{name: "invalid values", env: map[string]string{TimeoutEnv: "soon", RetriesEnv: "-1"}, wantTimeout: DefaultTimeout, wantRetries: DefaultRetries, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := PolicyFromEnv(func(key string) string { return tt.env[key] })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PolicyFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if policy.Timeout != tt.wantTimeout || policy.Retries != tt.wantRetries {
+				t.Errorf("PolicyFromEnv() = %s/%d, want %s/%d", policy.Timeout, policy.Retries, tt.wantTimeout, tt.wantRetries)
+			}
+		})
+	}
+}
+
+// TestPolicyDo tests timeouts, retries, and the slow filesystem diagnostic
+func TestPolicyDo(t *testing.T) {
+	var retries []int
+	policy := Policy{
+		Timeout:    20 * time.Millisecond,
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+		OnRetry:    func(attempt int, err error) { retries = append(retries, attempt) },
+	}
+
+	// A hanging operation ends in a slow filesystem error after all attempts
+	release := make(chan struct{})
+	defer close(release)
+	_, err := Do(policy, "read", "/mnt/share/file", func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	var slow *SlowFilesystemError
+	if !errors.As(err, &slow) {
+		t.Fatalf("Expected SlowFilesystemError, got %v", err)
+	}
+	if slow.Attempts != 3 || slow.Op != "read" {
+		t.Errorf("Unexpected diagnostic: %+v", slow)
+	}
+	if len(retries) != 2 {
+		t.Errorf("Expected 2 retries, got %v", retries)
+	}
+
+	// Transient errors are retried until an attempt succeeds
+	calls := 0
+	value, err := Do(policy, "read", "file", func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, &os.PathError{Op: "read", Path: "file", Err: syscall.EIO}
+		}
+		return 42, nil
+	})
+	if err != nil || value != 42 || calls != 2 {
+		t.Errorf("Expected success on the second attempt, got %d after %d calls (%v)", value, calls, err)
+	}
+
+	// Permanent errors are returned immediately
+	calls = 0
+	_, err = Do(policy, "read", "file", func() (int, error) {
+		calls++
+		return 0, os.ErrNotExist
+	})
+	if !errors.Is(err, os.ErrNotExist) || calls != 1 {
+		t.Errorf("Expected a single attempt for a permanent error, got %d (%v)", calls, err)
+	}
+}
+
+// TestPolicyDoOnce tests that operations that are not safe to repeat are
+// attempted once, whether they time out or fail with a transient error
+func TestPolicyDoOnce(t *testing.T) {
+	var retries []int
+	policy := Policy{
+		Timeout:    20 * time.Millisecond,
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+		OnRetry:    func(attempt int, err error) { retries = append(retries, attempt) },
+	}
+
+	// A hanging write may still complete, so it is not started again
+	release := make(chan struct{})
+	defer close(release)
+	_, err := DoOnce(policy, "write", "/mnt/share/file", func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	var slow *SlowFilesystemError
+	if !errors.As(err, &slow) || slow.Attempts != 1 {
+		t.Errorf("Expected a SlowFilesystemError after one attempt, got %v", err)
+	}
+
+	// An I/O error of a rename is returned immediately
+	calls := 0
+	_, err = DoOnce(policy, "rename", "file", func() (int, error) {
+		calls++
+		return 0, &os.LinkError{Op: "rename", Old: "file", New: "renamed", Err: syscall.EIO}
+	})
+	if !errors.Is(err, syscall.EIO) || calls != 1 {
+		t.Errorf("Expected a single attempt for EIO, got %d (%v)", calls, err)
+	}
+	if len(retries) != 0 {
+		t.Errorf("Expected no retries, got %v", retries)
+	}
+
+	// The policy's writes fail fast too
+	dir := t.TempDir()
+	policy.Backend = NewFaultFS(OS{}, []Fault{{Op: "write", Pattern: "*", Kind: FaultIO, After: 1}})
+	if err := policy.WriteFile(filepath.Join(dir, "file"), []byte("content"), 0644); !errors.Is(err, syscall.EIO) {
+		t.Errorf("Expected the EIO of the write, got %v", err)
+	}
+	if len(retries) != 0 {
+		t.Errorf("Expected no retries of the write, got %v", retries)
+	}
+}
+
+// TestPolicyContext tests that the policy context bounds all attempts together
+func TestPolicyContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	policy := Policy{Retries: 5, RetryDelay: time.Millisecond, Context: ctx}
+
+	// A hanging operation stops at the deadline even without a per-attempt timeout
+	release := make(chan struct{})
+	defer close(release)
+	start := time.Now()
+	_, err := Do(policy, "write", "/mnt/share/file", func() (int, error) {
+		<-release
+		return 0, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Operation should stop at the deadline, took %s", elapsed)
+	}
+
+	// Once the deadline passed, no further operation is started
+	calls := 0
+	_, err = Do(policy, "read", "file", func() (int, error) {
+		calls++
+		return 0, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls != 0 {
+		t.Errorf("Expected no attempt after the deadline, got %d (%v)", calls, err)
+	}
+}
+
+// TestPolicyFileOperations tests the file helpers with the zero and default policies
+func TestPolicyFileOperations(t *testing.T) {
+	for name, policy := range map[string]Policy{"zero": {}, "default": DefaultPolicy()} {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "nested")
+			path := filepath.Join(dir, "test.txt")
+
+			if err := policy.EnsureDir(dir); err != nil {
+				t.Fatalf("EnsureDir failed: %v", err)
+			}
+			if err := policy.WriteFile(path, []byte("content"), 0644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			if content, err := policy.ReadFile(path); err != nil || string(content) != "content" {
+				t.Errorf("ReadFile = %q, %v", content, err)
+			}
+			if f, err := policy.Open(path); err != nil {
+				t.Errorf("Open failed: %v", err)
+			} else {
+				content, err := io.ReadAll(f)
+				_ = f.Close()
+				if err != nil || string(content) != "content" {
+					t.Errorf("Open read %q, %v", content, err)
+				}
+			}
+			if entries, err := policy.ReadDir(dir); err != nil || len(entries) != 1 {
+				t.Errorf("ReadDir = %d entries, %v", len(entries), err)
+			}
+			renamed := filepath.Join(dir, "renamed.txt")
+			if err := policy.Rename(path, renamed); err != nil {
+				t.Fatalf("Rename failed: %v", err)
+			}
+			if err := policy.Rename(renamed, path); err != nil {
+				t.Fatalf("Rename failed: %v", err)
+			}
+			if err := policy.Remove(path); err != nil {
+				t.Fatalf("Remove failed: %v", err)
+			}
+			if _, err := policy.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("Expected removed file to be missing, got %v", err)
+			}
+		})
+	}
+}
+
+// TestPolicyReadOnly tests that read-only mode refuses every write
+func TestPolicyReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	if err := os.WriteFile(existing, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	SetReadOnly(true)
+	defer SetReadOnly(false)
+	policy := DefaultPolicy()
+
+	for name, err := range map[string]error{
+		"write":      policy.WriteFile(filepath.Join(dir, "new.txt"), []byte("content"), 0644),
+		"remove":     policy.Remove(existing),
+		"rename":     policy.Rename(existing, filepath.Join(dir, "renamed.txt")),
+		"ensure dir": policy.EnsureDir(filepath.Join(dir, "new")),
+		"check":      CheckWrite("write", existing),
+		"EnsureDir":  EnsureDir(filepath.Join(dir, "other")),
+	} {
+		if !errors.Is(err, ErrReadOnly) {
+			t.Errorf("Expected %s to fail with ErrReadOnly, got %v", name, err)
+		}
+	}
+	if err := policy.EnsureDir(dir); err != nil {
+		t.Errorf("Expected an existing directory to be accepted, got %v", err)
+	}
+	if content, err := policy.ReadFile(existing); err != nil || string(content) != "content" {
+		t.Errorf("Expected reads to work and the file to be unchanged, got %q, %v", content, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected nothing to be written, got %d entries", len(entries))
+	}
+
+	SetReadOnly(false)
+	if err := CheckWrite("write", existing); err != nil {
+		t.Errorf("Expected writes to be allowed again, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// probeFilename is the name of the temporary file created by WriteProbe.
+const probeFilename = ".chatmate_temp_permission_check"
+
+// CheckDirWritable reports whether the current user may create files in dir.
+//
+// The check queries the operating system's access control (access(2) on Unix,
+// the directory's ACL on Windows) and never creates, modifies, or removes
+// anything in the directory, so editor file watchers and cloud-sync clients
+// watching the directory are not triggered.
+//
+// Parameters:
+//   - dir: Directory to check
+//
+// Returns:
+//   - error: Why files cannot be created in dir, or nil if they can
+//
+// Example:
+//
+//	if err := CheckDirWritable(promptsDir); err != nil {
+//		return fmt.Errorf("prompts directory is not writable: %w", err)
+//	}
+func CheckDirWritable(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+
+	if err := checkDirAccess(dir); err != nil {
+		return fmt.Errorf("no write permission: %w", err)
+	}
+
+	return nil
+}
+
+// WriteProbe verifies write access by creating and removing a temporary file.
+//
+// This is the most reliable check on filesystems whose permission model is not
+// reflected by access queries (some network and FUSE mounts), but it is visible
+// to anything watching the directory. Prefer CheckDirWritable.
+//
+// Parameters:
+//   - dir: Directory to probe
+//
+// Returns:
+//   - error: Write or cleanup failure
+func WriteProbe(dir string) error {
+	tempFile := filepath.Join(dir, probeFilename)
+	if err := files.CheckWrite("create", tempFile); err != nil {
+		return err
+	}
+	file, err := os.Create(tempFile)
+	if err != nil {
+		return fmt.Errorf("no write permission: %w", err)
+	}
+	_ = file.Close()
+
+	if err := os.Remove(tempFile); err != nil {
+		return fmt.Errorf("cleanup failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckDirWritable(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := CheckDirWritable(dir); err != nil {
+		t.Errorf("Temp directory should be writable: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("CheckDirWritable must not create files, found %d entries", len(entries))
+	}
+
+	if err := CheckDirWritable(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Missing directory should not be writable")
+	}
+
+	file := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(file, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := CheckDirWritable(file); err == nil {
+		t.Error("Regular file should not be reported as a writable directory")
+	}
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		readOnly := filepath.Join(dir, "readonly")
+		if err := os.Mkdir(readOnly, 0555); err != nil {
+			t.Fatalf("Failed to create read-only directory: %v", err)
+		}
+		if err := CheckDirWritable(readOnly); err == nil {
+			t.Error("Read-only directory should not be writable")
+		}
+	}
+}
+
+func TestWriteProbe(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := WriteProbe(dir); err != nil {
+		t.Errorf("WriteProbe failed on writable directory: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, probeFilename)); !os.IsNotExist(err) {
+		t.Error("WriteProbe should remove its temporary file")
+	}
+}
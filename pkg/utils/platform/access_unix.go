@@ -0,0 +1,18 @@
+//go:build !windows
+
+package platform
+
+import "syscall"
+
+// access(2) mode bits, identical on every POSIX system.
+const (
+	accessExecute = 0x1
+	accessWrite   = 0x2
+)
+
+// checkDirAccess asks the kernel whether entries may be created in dir.
+//
+// Creating a file requires both write and search permission on the directory.
+func checkDirAccess(dir string) error {
+	return syscall.Access(dir, accessWrite|accessExecute)
+}
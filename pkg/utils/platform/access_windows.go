@@ -0,0 +1,40 @@
+//go:build windows
+
+package platform
+
+import "syscall"
+
+// Windows access rights and flags used to query a directory's ACL.
+const (
+	fileAddFile            = 0x0002
+	fileFlagBackupSemantic = 0x02000000
+)
+
+// checkDirAccess asks Windows whether files may be added to dir.
+//
+// Opening the directory handle with FILE_ADD_FILE access makes the system
+// evaluate the directory's ACL against the current user's token without
+// creating anything. The read-only attribute is deliberately ignored: Windows
+// does not enforce it on directories and Explorer uses it for folder
+// customization.
+func checkDirAccess(dir string) error {
+	path, err := syscall.UTF16PtrFromString(dir)
+	if err != nil {
+		return err
+	}
+
+	handle, err := syscall.CreateFile(
+		path,
+		fileAddFile,
+		syscall.FILE_SHARE_READ|syscall.FILE_SHARE_WRITE|syscall.FILE_SHARE_DELETE,
+		nil,
+		syscall.OPEN_EXISTING,
+		fileFlagBackupSemantic,
+		0,
+	)
+	if err != nil {
+		return err
+	}
+
+	return syscall.CloseHandle(handle)
+}
@@ -0,0 +1,213 @@
+// Package platform provides ChatMate's own platform-specific storage locations.
+//
+// This part of the platform package resolves where ChatMate keeps the files it
+// owns (as opposed to VS Code's directories), following each operating
+// system's conventions:
+//   - macOS: ~/Library/Application Support/chatmate
+//   - Linux: $XDG_STATE_HOME/chatmate (default ~/.local/state/chatmate)
+//   - Windows: %LOCALAPPDATA%/chatmate
+//
+// Disposable cache files live in a separate cache directory:
+//   - macOS: ~/Library/Caches/chatmate
+//   - Linux: $XDG_CACHE_HOME/chatmate (default ~/.cache/chatmate)
+//   - Windows: %LOCALAPPDATA%/chatmate/cache
+//
+// Configuration written by users or administrators lives in a config
+// directory, which roams with the user profile where the platform has one and
+// is kept apart from the machine-local state so it can be synced with
+// dotfiles:
+//   - macOS and Linux: $XDG_CONFIG_HOME/chatmate (default ~/.config/chatmate)
+//   - Windows: %APPDATA%/chatmate
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// GetChatMateStateDir returns the platform-specific directory for ChatMate's
+// machine-local state files.
+//
+// State files are small machine-readable documents written by ChatMate itself,
+// such as the summary of the last operation. The directory is not created by
+// this function.
+//
+// Example:
+//
+//	stateDir, err := GetChatMateStateDir()
+//	if err != nil {
+//		return fmt.Errorf("failed to get state directory: %w", err)
+//	}
+//	fmt.Printf("ChatMate state directory: %s\n", stateDir)
+//
+// Returns:
+//   - string: The full path to the ChatMate state directory
+//   - error: Any error encountered while determining the home directory
+func GetChatMateStateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch runtime.GOOS {
+	case "darwin": // macOS
+		return filepath.Join(homeDir, "Library", "Application Support", "chatmate"), nil
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			// Fallback to default location
+			localAppData = filepath.Join(homeDir, "AppData", "Local")
+		}
+		return filepath.Join(localAppData, "chatmate"), nil
+	default:
+		if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+			return filepath.Join(stateHome, "chatmate"), nil
+		}
+		return filepath.Join(homeDir, ".local", "state", "chatmate"), nil
+	}
+}
+
+// GetChatMateCacheDir returns the platform-specific directory for ChatMate's
+// cache files.
+//
+// Cache files can be deleted at any time; ChatMate recomputes their content
+// when they are missing. The directory is not created by this function.
+//
+// Example:
+//
+//	cacheDir, err := GetChatMateCacheDir()
+//	if err != nil {
+//		return fmt.Errorf("failed to get cache directory: %w", err)
+//	}
+//	fmt.Printf("ChatMate cache directory: %s\n", cacheDir)
+//
+// Returns:
+//   - string: The full path to the ChatMate cache directory
+//   - error: Any error encountered while determining the home directory
+func GetChatMateCacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch runtime.GOOS {
+	case "darwin": // macOS
+		return filepath.Join(homeDir, "Library", "Caches", "chatmate"), nil
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			// Fallback to default location
+			localAppData = filepath.Join(homeDir, "AppData", "Local")
+		}
+		return filepath.Join(localAppData, "chatmate", "cache"), nil
+	default:
+		if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+			return filepath.Join(cacheHome, "chatmate"), nil
+		}
+		return filepath.Join(homeDir, ".cache", "chatmate"), nil
+	}
+}
+
+// GetChatMateConfigDir returns the platform-specific directory for
+// ChatMate's configuration files, such as the team policy.
+//
+// Unlike state files, configuration is written by users or administrators
+// and may be shared between machines, so the directory never contains the
+// state directory. The directory is not created by this function.
+//
+// Example:
+//
+//	configDir, err := GetChatMateConfigDir()
+//	if err != nil {
+//		return fmt.Errorf("failed to get config directory: %w", err)
+//	}
+//	fmt.Printf("ChatMate config directory: %s\n", configDir)
+//
+// Returns:
+//   - string: The full path to the ChatMate config directory
+//   - error: Any error encountered while determining the home directory
+func GetChatMateConfigDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			// Fallback to default location
+			appData = filepath.Join(homeDir, "AppData", "Roaming")
+		}
+		return filepath.Join(appData, "chatmate"), nil
+	default:
+		if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+			return filepath.Join(configHome, "chatmate"), nil
+		}
+		return filepath.Join(homeDir, ".config", "chatmate"), nil
+	}
+}
+
+// GetChatMateDataDir returns the platform-specific directory for ChatMate's
+// user data, which holds the headless prompts directory.
+//
+//   - Windows: %APPDATA%/chatmate, the same as the config directory
+//   - Other systems: $XDG_DATA_HOME/chatmate, defaulting to
+//     ~/.local/share/chatmate
+//
+// The directory is not created by this function.
+//
+// Returns:
+//   - string: The full path to the ChatMate data directory
+//   - error: Any error encountered while determining the home directory
+func GetChatMateDataDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	if runtime.GOOS == "windows" {
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			// Fallback to default location
+			appData = filepath.Join(homeDir, "AppData", "Roaming")
+		}
+		return filepath.Join(appData, "chatmate"), nil
+	}
+
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "chatmate"), nil
+	}
+	return filepath.Join(homeDir, ".local", "share", "chatmate"), nil
+}
+
+// GetHeadlessPromptsDir returns the generic prompts directory used in
+// headless mode, when VS Code is not installed.
+//
+// The directory is kept apart from the state and cache directories so that
+// removing ChatMate's own files never removes chatmates:
+//   - Windows: %APPDATA%/chatmate/prompts
+//   - Other systems: $XDG_DATA_HOME/chatmate/prompts, defaulting to
+//     ~/.local/share/chatmate/prompts
+//
+// The directory is not created by this function.
+//
+// Example:
+//
+//	promptsDir, err := GetHeadlessPromptsDir()
+//	if err != nil {
+//		return fmt.Errorf("failed to get headless prompts directory: %w", err)
+//	}
+//	fmt.Printf("Headless prompts directory: %s\n", promptsDir)
+//
+// Returns:
+//   - string: The full path to the headless prompts directory
+//   - error: Any error encountered while determining the home directory
+func GetHeadlessPromptsDir() (string, error) {
+	dataDir, err := GetChatMateDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "prompts"), nil
+}
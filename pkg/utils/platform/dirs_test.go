@@ -0,0 +1,128 @@
+package platform
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestGetChatMateStateDir(t *testing.T) {
+	stateDir, err := GetChatMateStateDir()
+	if err != nil {
+		t.Fatalf("GetChatMateStateDir() failed: %v", err)
+	}
+
+	if filepath.Base(stateDir) != "chatmate" {
+		t.Errorf("State directory should end with 'chatmate': %s", stateDir)
+	}
+
+	if runtime.GOOS == "linux" {
+		xdgStateHome := filepath.Join(t.TempDir(), "state")
+		t.Setenv("XDG_STATE_HOME", xdgStateHome)
+
+		stateDir, err := GetChatMateStateDir()
+		if err != nil {
+			t.Fatalf("GetChatMateStateDir() failed: %v", err)
+		}
+		if stateDir != filepath.Join(xdgStateHome, "chatmate") {
+			t.Errorf("XDG_STATE_HOME should be honored, got %s", stateDir)
+		}
+	}
+}
+
+func TestGetChatMateCacheDir(t *testing.T) {
+	cacheDir, err := GetChatMateCacheDir()
+	if err != nil {
+		t.Fatalf("GetChatMateCacheDir() failed: %v", err)
+	}
+
+	stateDir, err := GetChatMateStateDir()
+	if err != nil {
+		t.Fatalf("GetChatMateStateDir() failed: %v", err)
+	}
+	if cacheDir == stateDir {
+		t.Errorf("Cache directory should differ from state directory: %s", cacheDir)
+	}
+
+	if runtime.GOOS == "linux" {
+		xdgCacheHome := filepath.Join(t.TempDir(), "cache")
+		t.Setenv("XDG_CACHE_HOME", xdgCacheHome)
+
+		cacheDir, err := GetChatMateCacheDir()
+		if err != nil {
+			t.Fatalf("GetChatMateCacheDir() failed: %v", err)
+		}
+		if cacheDir != filepath.Join(xdgCacheHome, "chatmate") {
+			t.Errorf("XDG_CACHE_HOME should be honored, got %s", cacheDir)
+		}
+	}
+}
+
+func TestGetChatMateConfigDir(t *testing.T) {
+	configDir, err := GetChatMateConfigDir()
+	if err != nil {
+		t.Fatalf("GetChatMateConfigDir() failed: %v", err)
+	}
+
+	if filepath.Base(configDir) != "chatmate" {
+		t.Errorf("Config directory should end with 'chatmate': %s", configDir)
+	}
+
+	// The config directory roams; machine-local state must stay out of it
+	stateDir, err := GetChatMateStateDir()
+	if err != nil {
+		t.Fatalf("GetChatMateStateDir() failed: %v", err)
+	}
+	if configDir == stateDir || strings.HasPrefix(stateDir, configDir+string(filepath.Separator)) {
+		t.Errorf("State directory %s must not be inside the config directory %s", stateDir, configDir)
+	}
+
+	if runtime.GOOS == "linux" {
+		xdgConfigHome := filepath.Join(t.TempDir(), "config")
+		t.Setenv("XDG_CONFIG_HOME", xdgConfigHome)
+
+		configDir, err := GetChatMateConfigDir()
+		if err != nil {
+			t.Fatalf("GetChatMateConfigDir() failed: %v", err)
+		}
+		if configDir != filepath.Join(xdgConfigHome, "chatmate") {
+			t.Errorf("XDG_CONFIG_HOME should be honored, got %s", configDir)
+		}
+	}
+}
+
+func TestGetHeadlessPromptsDir(t *testing.T) {
+	promptsDir, err := GetHeadlessPromptsDir()
+	if err != nil {
+		t.Fatalf("GetHeadlessPromptsDir() failed: %v", err)
+	}
+
+	if filepath.Base(promptsDir) != "prompts" {
+		t.Errorf("Headless prompts directory should end with 'prompts': %s", promptsDir)
+	}
+
+	stateDir, err := GetChatMateStateDir()
+	if err != nil {
+		t.Fatalf("GetChatMateStateDir() failed: %v", err)
+	}
+	if strings.HasPrefix(promptsDir, stateDir+string(filepath.Separator)) {
+		t.Errorf("Headless prompts directory must not be inside the state directory: %s", promptsDir)
+	}
+
+	if runtime.GOOS == "linux" {
+		xdgDataHome := filepath.Join(t.TempDir(), "data")
+		t.Setenv("XDG_DATA_HOME", xdgDataHome)
+
+		promptsDir, err := GetHeadlessPromptsDir()
+		if err != nil {
+			t.Fatalf("GetHeadlessPromptsDir() failed: %v", err)
+		}
+		if promptsDir != filepath.Join(xdgDataHome, "chatmate", "prompts") {
+			t.Errorf("XDG_DATA_HOME should be honored, got %s", promptsDir)
+		}
+		if dataDir, err := GetChatMateDataDir(); err != nil || dataDir != filepath.Dir(promptsDir) {
+			t.Errorf("The data directory should hold the headless prompts directory, got %s, %v", dataDir, err)
+		}
+	}
+}
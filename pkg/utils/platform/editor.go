@@ -0,0 +1,381 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// EditorDetection describes whether VS Code appears to be installed.
+type EditorDetection struct {
+	// Found reports whether any evidence of a VS Code installation was found
+	Found bool
+	// Evidence describes what was found (a CLI path, application or user directory)
+	Evidence string
+	// Headless reports whether the session looks like a server without a desktop,
+	// such as an SSH session with no display
+	Headless bool
+}
+
+// vscodeCommands are the command-line launchers installed with VS Code.
+var vscodeCommands = []string{"code", "code-insiders"}
+
+// DetectVSCode looks for evidence that VS Code is installed on this machine.
+//
+// The detection checks, in order:
+//   - The 'code' or 'code-insiders' launcher on PATH
+//   - The VS Code user directory (the parent of the prompts directory), which
+//     exists once VS Code has been started
+//   - Well-known application locations for the current operating system
+//
+// Detection is best effort: a portable or unusual installation may not be
+// found, so callers should warn rather than fail unless asked to be strict.
+//
+// Example:
+//
+//	detection := DetectVSCode()
+//	if !detection.Found {
+//		fmt.Println("VS Code was not detected")
+//	}
+//
+// Returns:
+//   - EditorDetection: The detection result
+func DetectVSCode() EditorDetection {
+	var userDir string
+	if promptsDir, err := GetVSCodePromptsDir(); err == nil {
+		userDir = filepath.Dir(promptsDir)
+	}
+	return detectVSCode(exec.LookPath, pathExists, userDir, editorAppPaths(DefaultEditor), os.Getenv)
+}
+
+// detectVSCode implements DetectVSCode with injectable lookups for testing.
+func detectVSCode(lookPath func(string) (string, error), exists func(string) bool, userDir string, appPaths []string, getenv func(string) string) EditorDetection {
+	return detectEditor(vscodeCommands, lookPath, exists, userDir, appPaths, getenv)
+}
+
+// detectEditor implements DetectVSCode and DetectEditor for the given
+// launchers, user directory, and application locations.
+func detectEditor(commands []string, lookPath func(string) (string, error), exists func(string) bool, userDir string, appPaths []string, getenv func(string) string) EditorDetection {
+	detection := EditorDetection{Headless: isHeadless(getenv)}
+
+	for _, command := range commands {
+		if path, err := lookPath(command); err == nil {
+			detection.Found = true
+			detection.Evidence = path
+			return detection
+		}
+	}
+
+	if userDir != "" && exists(userDir) {
+		detection.Found = true
+		detection.Evidence = userDir
+		return detection
+	}
+
+	for _, path := range appPaths {
+		if exists(path) {
+			detection.Found = true
+			detection.Evidence = path
+			return detection
+		}
+	}
+
+	return detection
+}
+
+// editorAppPaths returns the well-known installation locations of an editor
+// flavor for the current operating system.
+func editorAppPaths(id string) []string {
+	for _, spec := range editorFlavorSpecs {
+		if spec.id != id {
+			continue
+		}
+		homeDir, _ := os.UserHomeDir()
+
+		switch runtime.GOOS {
+		case "darwin":
+			paths := []string{filepath.Join("/Applications", spec.macApp)}
+			if homeDir != "" {
+				paths = append(paths, filepath.Join(homeDir, "Applications", spec.macApp))
+			}
+			return paths
+		case "windows":
+			var paths []string
+			if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+				paths = append(paths, filepath.Join(localAppData, "Programs", spec.windowsDir))
+			}
+			if programFiles := os.Getenv("ProgramFiles"); programFiles != "" {
+				paths = append(paths, filepath.Join(programFiles, spec.windowsDir))
+			}
+			return paths
+		default:
+			return spec.linuxPaths
+		}
+	}
+	return nil
+}
+
+// isHeadless reports whether the session looks like a server without a
+// desktop: connected over SSH, or on Linux with no display server.
+func isHeadless(getenv func(string) string) bool {
+	if getenv("SSH_CONNECTION") != "" || getenv("SSH_TTY") != "" {
+		return true
+	}
+	if runtime.GOOS == "linux" {
+		return getenv("DISPLAY") == "" && getenv("WAYLAND_DISPLAY") == ""
+	}
+	return false
+}
+
+// pathExists reports whether path exists.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// EditorFlavor is a VS Code build or fork with its own user directory.
+type EditorFlavor struct {
+	// ID selects the flavor with --editor or CHATMATE_EDITOR (e.g., "insiders")
+	ID string
+	// Name is the product name (e.g., "VS Code Insiders")
+	Name string
+	// Command is the command-line launcher installed with the flavor
+	Command string
+	// PromptsDir is the prompts directory inside the flavor's user directory
+	PromptsDir string
+}
+
+// DefaultEditor is the ID of VS Code itself, the editor ChatMate installs for
+// unless another one is chosen or only another one is installed.
+const DefaultEditor = "stable"
+
+// editorFlavorSpecs describes the VS Code builds and forks ChatMate can
+// install for, VS Code itself first.
+var editorFlavorSpecs = []struct {
+	id, dir, name, command string
+	// macApp is the application bundle on macOS; windowsDir the installation
+	// directory on Windows; linuxPaths the usual installation locations on
+	// Linux
+	macApp, windowsDir string
+	linuxPaths         []string
+}{
+	{
+		id: DefaultEditor, dir: "Code", name: "VS Code", command: "code",
+		macApp: "Visual Studio Code.app", windowsDir: "Microsoft VS Code",
+		linuxPaths: []string{"/usr/share/code", "/usr/lib/code", "/opt/visual-studio-code", "/snap/bin/code", "/var/lib/flatpak/app/com.visualstudio.code"},
+	},
+	{
+		id: "insiders", dir: "Code - Insiders", name: "VS Code Insiders", command: "code-insiders",
+		macApp: "Visual Studio Code - Insiders.app", windowsDir: "Microsoft VS Code Insiders",
+		linuxPaths: []string{"/usr/share/code-insiders", "/opt/visual-studio-code-insiders", "/snap/bin/code-insiders"},
+	},
+	{
+		id: "vscodium", dir: "VSCodium", name: "VSCodium", command: "codium",
+		macApp: "VSCodium.app", windowsDir: "VSCodium",
+		linuxPaths: []string{"/usr/share/codium", "/opt/vscodium-bin", "/snap/bin/codium", "/var/lib/flatpak/app/com.vscodium.codium"},
+	},
+	{
+		id: "oss", dir: "Code - OSS", name: "Code - OSS", command: "code-oss",
+		macApp: "Code - OSS.app", windowsDir: "Code - OSS",
+		linuxPaths: []string{"/usr/lib/code-oss", "/usr/share/code-oss", "/opt/code-oss"},
+	},
+	{
+		id: "cursor", dir: "Cursor", name: "Cursor", command: "cursor",
+		macApp: "Cursor.app", windowsDir: "cursor",
+		linuxPaths: []string{"/opt/Cursor", "/usr/share/cursor", "/opt/cursor"},
+	},
+}
+
+// EditorIDs returns the IDs of the editor flavors, VS Code first.
+func EditorIDs() []string {
+	ids := make([]string, 0, len(editorFlavorSpecs))
+	for _, spec := range editorFlavorSpecs {
+		ids = append(ids, spec.id)
+	}
+	return ids
+}
+
+// GetEditorFlavors returns the prompts directories of VS Code and the builds
+// and forks that keep their settings next to it, VS Code first.
+//
+// Example:
+//
+//	flavors, err := GetEditorFlavors()
+//	if err != nil {
+//		return err
+//	}
+//	for _, flavor := range flavors[1:] {
+//		fmt.Printf("%s: %s\n", flavor.Name, flavor.PromptsDir)
+//	}
+//
+// Returns:
+//   - []EditorFlavor: The known editor flavors with their prompts directories
+//   - error: Any error encountered while determining the home directory
+func GetEditorFlavors() ([]EditorFlavor, error) {
+	promptsDir, err := GetVSCodePromptsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// <config root>/Code/User/prompts on every operating system
+	configRoot := filepath.Dir(filepath.Dir(filepath.Dir(promptsDir)))
+	flavors := make([]EditorFlavor, 0, len(editorFlavorSpecs))
+	for _, spec := range editorFlavorSpecs {
+		flavors = append(flavors, EditorFlavor{
+			ID:         spec.id,
+			Name:       spec.name,
+			Command:    spec.command,
+			PromptsDir: filepath.Join(configRoot, spec.dir, "User", "prompts"),
+		})
+	}
+	return flavors, nil
+}
+
+// GetEditorFlavor returns the editor flavor with the given ID.
+//
+// Example:
+//
+//	flavor, err := GetEditorFlavor("insiders")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(flavor.PromptsDir)
+//
+// Parameters:
+//   - id: The flavor ID (see EditorIDs), case-insensitive
+//
+// Returns:
+//   - EditorFlavor: The flavor with its prompts directory
+//   - error: Error if the ID is unknown or the home directory cannot be determined
+func GetEditorFlavor(id string) (EditorFlavor, error) {
+	flavors, err := GetEditorFlavors()
+	if err != nil {
+		return EditorFlavor{}, err
+	}
+	for _, flavor := range flavors {
+		if strings.EqualFold(flavor.ID, strings.TrimSpace(id)) {
+			return flavor, nil
+		}
+	}
+	return EditorFlavor{}, fmt.Errorf("unknown editor %q (available: %s)", id, strings.Join(EditorIDs(), ", "))
+}
+
+// DetectEditor looks for evidence that an editor flavor is installed, the
+// way DetectVSCode does for VS Code: its launcher on PATH, its user
+// directory, or its application in a well-known location.
+//
+// Example:
+//
+//	detection := DetectEditor(flavor)
+//	if !detection.Found {
+//		fmt.Printf("%s was not detected\n", flavor.Name)
+//	}
+//
+// Parameters:
+//   - flavor: The editor flavor (see GetEditorFlavor)
+//
+// Returns:
+//   - EditorDetection: The detection result
+func DetectEditor(flavor EditorFlavor) EditorDetection {
+	var commands []string
+	if flavor.Command != "" {
+		commands = []string{flavor.Command}
+	}
+	var userDir string
+	if flavor.PromptsDir != "" {
+		userDir = filepath.Dir(flavor.PromptsDir)
+	}
+	return detectEditor(commands, exec.LookPath, pathExists, userDir, editorAppPaths(flavor.ID), os.Getenv)
+}
+
+// DetectEditorFlavors returns the editor flavors that appear to be
+// installed (see DetectEditor), VS Code first.
+//
+// Returns:
+//   - []EditorFlavor: The detected flavors; empty if none was found
+//   - error: Any error encountered while determining the home directory
+func DetectEditorFlavors() ([]EditorFlavor, error) {
+	flavors, err := GetEditorFlavors()
+	if err != nil {
+		return nil, err
+	}
+	var detected []EditorFlavor
+	for _, flavor := range flavors {
+		if DetectEditor(flavor).Found {
+			detected = append(detected, flavor)
+		}
+	}
+	return detected, nil
+}
+
+// CopilotChatExtension is the identifier of the GitHub Copilot Chat
+// extension, which reads chatmates from the prompts directory.
+const CopilotChatExtension = "github.copilot-chat"
+
+// FindVSCodeExtension looks for an installed VS Code extension.
+//
+// VS Code installs extensions into a "<publisher>.<name>-<version>" directory
+// under ~/.vscode/extensions, or under VSCODE_EXTENSIONS if it is set;
+// VS Code Remote installs them under ~/.vscode-server/extensions.
+//
+// Like DetectVSCode, the lookup is best effort: extensions installed into a
+// custom --extensions-dir are not found.
+//
+// Example:
+//
+//	if _, found := FindVSCodeExtension(CopilotChatExtension); !found {
+//		fmt.Println("GitHub Copilot Chat is not installed")
+//	}
+//
+// Parameters:
+//   - id: The extension identifier (e.g., "github.copilot-chat")
+//
+// Returns:
+//   - string: The directory of the installed extension
+//   - bool: True if the extension was found
+func FindVSCodeExtension(id string) (string, bool) {
+	return findExtension(vscodeExtensionDirs(), id)
+}
+
+// vscodeExtensionDirs returns the directories VS Code installs extensions into.
+func vscodeExtensionDirs() []string {
+	if dir := os.Getenv("VSCODE_EXTENSIONS"); dir != "" {
+		return []string{dir}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{
+		filepath.Join(homeDir, ".vscode", "extensions"),
+		filepath.Join(homeDir, ".vscode-server", "extensions"),
+	}
+}
+
+// findExtension implements FindVSCodeExtension for the given extension
+// directories.
+func findExtension(dirs []string, id string) (string, bool) {
+	prefix := strings.ToLower(id) + "-"
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			// The version must follow, so "github.copilot" does not match
+			// "github.copilot-chat-0.22.0"
+			if !entry.IsDir() || !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+				continue
+			}
+			if version := name[len(prefix)]; version >= '0' && version <= '9' {
+				return filepath.Join(dir, entry.Name()), true
+			}
+		}
+	}
+	return "", false
+}
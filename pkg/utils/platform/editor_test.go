@@ -0,0 +1,160 @@
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDetectVSCode(t *testing.T) {
+	notFound := func(string) (string, error) { return "", errors.New("not found") }
+	noPaths := func(string) bool { return false }
+	noEnv := func(string) string { return "" }
+
+	t.Run("launcher on PATH", func(t *testing.T) {
+		lookPath := func(name string) (string, error) {
+			if name == "code-insiders" {
+				return "/usr/bin/code-insiders", nil
+			}
+			return "", errors.New("not found")
+		}
+		detection := detectVSCode(lookPath, noPaths, "/home/u/.config/Code/User", nil, noEnv)
+		if !detection.Found || detection.Evidence != "/usr/bin/code-insiders" {
+			t.Errorf("Expected launcher to be detected, got %+v", detection)
+		}
+	})
+
+	t.Run("user directory", func(t *testing.T) {
+		exists := func(path string) bool { return path == "/home/u/.config/Code/User" }
+		detection := detectVSCode(notFound, exists, "/home/u/.config/Code/User", []string{"/usr/share/code"}, noEnv)
+		if !detection.Found || detection.Evidence != "/home/u/.config/Code/User" {
+			t.Errorf("Expected user directory to be detected, got %+v", detection)
+		}
+	})
+
+	t.Run("application location", func(t *testing.T) {
+		exists := func(path string) bool { return path == "/opt/visual-studio-code" }
+		detection := detectVSCode(notFound, exists, "", []string{"/usr/share/code", "/opt/visual-studio-code"}, noEnv)
+		if !detection.Found || detection.Evidence != "/opt/visual-studio-code" {
+			t.Errorf("Expected application to be detected, got %+v", detection)
+		}
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		detection := detectVSCode(notFound, noPaths, "/home/u/.config/Code/User", []string{"/usr/share/code"}, noEnv)
+		if detection.Found || detection.Evidence != "" {
+			t.Errorf("Expected VS Code not to be detected, got %+v", detection)
+		}
+	})
+
+	t.Run("ssh session is headless", func(t *testing.T) {
+		getenv := func(key string) string {
+			if key == "SSH_CONNECTION" {
+				return "10.0.0.1 22 10.0.0.2 22"
+			}
+			return ""
+		}
+		if detection := detectVSCode(notFound, noPaths, "", nil, getenv); !detection.Headless {
+			t.Error("SSH session should be reported as headless")
+		}
+	})
+
+	t.Run("desktop session", func(t *testing.T) {
+		getenv := func(key string) string {
+			if key == "DISPLAY" {
+				return ":0"
+			}
+			return ""
+		}
+		if detection := detectVSCode(notFound, noPaths, "", nil, getenv); detection.Headless {
+			t.Errorf("Desktop session on %s should not be reported as headless", runtime.GOOS)
+		}
+	})
+}
+
+func TestGetEditorFlavors(t *testing.T) {
+	flavors, err := GetEditorFlavors()
+	if err != nil {
+		t.Fatalf("GetEditorFlavors failed: %v", err)
+	}
+
+	promptsDir, err := GetVSCodePromptsDir()
+	if err != nil {
+		t.Fatalf("GetVSCodePromptsDir failed: %v", err)
+	}
+	if len(flavors) < 2 || flavors[0].PromptsDir != promptsDir {
+		t.Fatalf("VS Code should come first with the VS Code prompts directory, got %+v", flavors)
+	}
+
+	for _, flavor := range flavors[1:] {
+		if filepath.Base(flavor.PromptsDir) != "prompts" || filepath.Dir(filepath.Dir(filepath.Dir(flavor.PromptsDir))) != filepath.Dir(filepath.Dir(filepath.Dir(promptsDir))) {
+			t.Errorf("%s prompts directory %s should be next to VS Code's", flavor.Name, flavor.PromptsDir)
+		}
+	}
+}
+
+func TestGetEditorFlavor(t *testing.T) {
+	for _, id := range EditorIDs() {
+		flavor, err := GetEditorFlavor(id)
+		if err != nil {
+			t.Fatalf("GetEditorFlavor(%q) failed: %v", id, err)
+		}
+		if flavor.ID != id || flavor.Name == "" || flavor.Command == "" || flavor.PromptsDir == "" {
+			t.Errorf("Incomplete editor flavor %+v", flavor)
+		}
+	}
+
+	if flavor, err := GetEditorFlavor(" Insiders "); err != nil || flavor.Name != "VS Code Insiders" || filepath.Base(filepath.Dir(filepath.Dir(flavor.PromptsDir))) != "Code - Insiders" {
+		t.Errorf("Expected VS Code Insiders, got %+v, %v", flavor, err)
+	}
+	if flavor, err := GetEditorFlavor("oss"); err != nil || filepath.Base(filepath.Dir(filepath.Dir(flavor.PromptsDir))) != "Code - OSS" {
+		t.Errorf("Expected the Code - OSS user directory, got %+v, %v", flavor, err)
+	}
+	if _, err := GetEditorFlavor("emacs"); err == nil || !strings.Contains(err.Error(), "vscodium") {
+		t.Errorf("Expected an error listing the editors, got %v", err)
+	}
+}
+
+func TestDetectEditor(t *testing.T) {
+	noPaths := func(string) bool { return false }
+	noEnv := func(string) string { return "" }
+	lookPath := func(name string) (string, error) {
+		if name == "codium" {
+			return "/usr/bin/codium", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	if detection := detectEditor([]string{"codium"}, lookPath, noPaths, "", nil, noEnv); !detection.Found || detection.Evidence != "/usr/bin/codium" {
+		t.Errorf("Expected the VSCodium launcher to be detected, got %+v", detection)
+	}
+	if detection := detectEditor([]string{"cursor"}, lookPath, noPaths, "/home/u/.config/Cursor/User", []string{"/opt/Cursor"}, noEnv); detection.Found {
+		t.Errorf("Another editor's launcher should not count, got %+v", detection)
+	}
+}
+
+func TestFindExtension(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"github.copilot-1.250.0", "github.copilot-chat-0.22.4"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0755); err != nil {
+			t.Fatalf("Failed to create extension directory: %v", err)
+		}
+	}
+
+	path, found := findExtension([]string{filepath.Join(dir, "missing"), dir}, CopilotChatExtension)
+	if !found || path != filepath.Join(dir, "github.copilot-chat-0.22.4") {
+		t.Errorf("Expected Copilot Chat to be found, got %q, %v", path, found)
+	}
+
+	path, found = findExtension([]string{dir}, "GitHub.Copilot")
+	if !found || path != filepath.Join(dir, "github.copilot-1.250.0") {
+		t.Errorf("Expected Copilot to be found case-insensitively, got %q, %v", path, found)
+	}
+
+	if _, found := findExtension([]string{dir}, "github.copilot-labs"); found {
+		t.Error("Extension that is not installed should not be found")
+	}
+}
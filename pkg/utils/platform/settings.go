@@ -0,0 +1,188 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
+)
+
+// PromptFilesSetting is the VS Code setting that enables prompt and chatmode files.
+const PromptFilesSetting = "chat.promptFiles"
+
+// promptFilesPattern matches the setting when it starts a line, so
+// commented-out occurrences are left alone.
+var promptFilesPattern = regexp.MustCompile(`(?m)^[ \t]*"chat\.promptFiles"[ \t]*:[ \t]*(true|false)`)
+
+// PromptFilesEnabled reads PromptFilesSetting from a VS Code settings file.
+//
+// Parameters:
+//   - settingsPath: Path of the VS Code user settings.json
+//
+// Returns:
+//   - enabled: Whether the setting is true
+//   - set: Whether the setting appears in the file; a missing file counts as unset
+//   - err: Read failure
+func PromptFilesEnabled(settingsPath string) (enabled, set bool, err error) {
+	content, err := os.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, fmt.Errorf("failed to read VS Code settings %s: %w", settingsPath, err)
+	}
+
+	match := promptFilesPattern.FindSubmatch(content)
+	if match == nil {
+		return false, false, nil
+	}
+	return string(match[1]) == "true", true, nil
+}
+
+// EnablePromptFiles turns on PromptFilesSetting in a VS Code settings file.
+//
+// VS Code settings are JSON with comments, so the file is edited in place
+// rather than decoded and re-encoded: an existing "false" is flipped, or the
+// setting is inserted at the top of the object. Comments and formatting are
+// preserved. A missing settings file is created.
+//
+// Example:
+//
+//	changed, err := EnablePromptFiles(settingsPath)
+//	if err != nil {
+//		return fmt.Errorf("failed to enable prompt files: %w", err)
+//	}
+//	if changed {
+//		fmt.Println("Enabled prompt files in VS Code settings")
+//	}
+//
+// Parameters:
+//   - settingsPath: Path of the VS Code user settings.json
+//
+// Returns:
+//   - bool: true if the file was changed, false if the setting was already on
+//   - error: Read or write failure, or a settings file that is not a JSON object
+func EnablePromptFiles(settingsPath string) (bool, error) {
+	return editSettings(settingsPath, func(text string) (string, bool, error) {
+		if match := promptFilesPattern.FindStringSubmatchIndex(text); match != nil {
+			if text[match[2]:match[3]] == "true" {
+				return text, false, nil
+			}
+			return text[:match[2]] + "true" + text[match[3]:], true, nil
+		}
+
+		text, err := insertSetting(text, PromptFilesSetting, "true")
+		return text, err == nil, err
+	})
+}
+
+// YAMLSchemasSetting is the setting of the YAML extension (redhat.vscode-yaml)
+// that associates JSON Schemas with file patterns.
+const YAMLSchemasSetting = "yaml.schemas"
+
+// yamlSchemasPattern matches the setting when it starts a line.
+var yamlSchemasPattern = regexp.MustCompile(`(?m)^[ \t]*"yaml\.schemas"[ \t]*:`)
+
+// ErrSettingExists is returned when a setting that ChatMate would add is
+// already defined with other values, which ChatMate does not merge.
+var ErrSettingExists = errors.New("setting already exists")
+
+// AssociateYAMLSchema associates a JSON Schema with a file pattern through
+// YAMLSchemasSetting in a VS Code settings file.
+//
+// The file is edited in place like in EnablePromptFiles. An existing
+// YAMLSchemasSetting that doesn't mention the schema yet is not merged,
+// because its object may contain comments and other associations; the user
+// has to add the entry by hand in that case.
+//
+// Parameters:
+//   - settingsPath: Path of the VS Code user settings.json
+//   - schemaPath: Path of the JSON Schema file
+//   - pattern: File pattern the schema applies to (e.g., "*.chatmode.md")
+//
+// Returns:
+//   - bool: true if the file was changed, false if the association already exists
+//   - error: Read or write failure, ErrSettingExists, or a settings file that
+//     is not a JSON object
+func AssociateYAMLSchema(settingsPath, schemaPath, pattern string) (bool, error) {
+	return editSettings(settingsPath, func(text string) (string, bool, error) {
+		quotedPath, _ := json.Marshal(schemaPath)
+		if strings.Contains(text, string(quotedPath)) {
+			return text, false, nil
+		}
+		if yamlSchemasPattern.MatchString(text) {
+			return text, false, fmt.Errorf("%w: add %s: %q to %q in %s by hand", ErrSettingExists, quotedPath, pattern, YAMLSchemasSetting, settingsPath)
+		}
+
+		value, _ := json.MarshalIndent(map[string]string{schemaPath: pattern}, "  ", "  ")
+		text, err := insertSetting(text, YAMLSchemasSetting, string(value))
+		return text, err == nil, err
+	})
+}
+
+// editSettings applies edit to the content of a VS Code settings file.
+//
+// VS Code settings are JSON with comments, so they are edited as text rather
+// than decoded and re-encoded, which preserves comments and formatting. A
+// missing settings file is edited as an empty object and created. The file
+// is only written when edit reports a change.
+func editSettings(settingsPath string, edit func(text string) (string, bool, error)) (bool, error) {
+	content, err := os.ReadFile(settingsPath)
+	missing := os.IsNotExist(err)
+	if missing {
+		content = []byte("{\n}\n")
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read VS Code settings %s: %w", settingsPath, err)
+	}
+
+	text, changed, err := edit(string(content))
+	if errors.Is(err, errNotObject) {
+		return false, fmt.Errorf("VS Code settings %s do not contain a JSON object", settingsPath)
+	}
+	if err != nil || !changed {
+		return false, err
+	}
+
+	if err := files.CheckWrite("write", settingsPath); err != nil {
+		return false, err
+	}
+
+	if missing {
+		if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+			return false, fmt.Errorf("failed to create VS Code settings directory: %w", err)
+		}
+	}
+
+	// Written atomically so an interruption never leaves truncated settings
+	if err := files.WriteFileAtomic(settingsPath, []byte(text), 0644); err != nil {
+		return false, fmt.Errorf("failed to write VS Code settings %s: %w", settingsPath, err)
+	}
+	return true, nil
+}
+
+// errNotObject is returned by insertSetting for settings without a JSON object.
+var errNotObject = errors.New("no JSON object")
+
+// insertSetting inserts a setting with a JSON-encoded value at the top of the
+// settings object.
+func insertSetting(text, name, value string) (string, error) {
+	open := strings.Index(text, "{")
+	if open < 0 {
+		return "", errNotObject
+	}
+
+	entry := fmt.Sprintf("\n  %q: %s", name, value)
+	rest := text[open+1:]
+	if trimmed := strings.TrimLeft(rest, " \t\r\n"); strings.HasPrefix(trimmed, "}") {
+		// Empty object: put the closing brace on its own line
+		rest = "\n" + trimmed
+	} else {
+		entry += ","
+	}
+	return text[:open+1] + entry + rest, nil
+}
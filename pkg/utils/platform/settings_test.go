@@ -0,0 +1,150 @@
+package platform
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestEnablePromptFiles tests enabling prompt files in VS Code settings
+func TestEnablePromptFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		existing    string
+		wantChanged bool
+		wantContent []string
+	}{
+		{
+			name:        "missing settings file",
+			wantChanged: true,
+			wantContent: []string{`"chat.promptFiles": true`},
+		},
+		{
+			name:        "empty object",
+			existing:    "{}\n",
+			wantChanged: true,
+			wantContent: []string{"{\n  \"chat.promptFiles\": true\n}\n"},
+		},
+		{
+			name:        "other settings with comments",
+			existing:    "{\n  // Editor\n  \"editor.fontSize\": 14\n}\n",
+			wantChanged: true,
+			wantContent: []string{"\"chat.promptFiles\": true,\n", "// Editor", `"editor.fontSize": 14`},
+		},
+		{
+			name:        "disabled",
+			existing:    "{\n  \"chat.promptFiles\": false,\n  \"editor.fontSize\": 14\n}\n",
+			wantChanged: true,
+			wantContent: []string{"\"chat.promptFiles\": true,\n  \"editor.fontSize\": 14"},
+		},
+		{
+			name:        "already enabled",
+			existing:    "{\n  \"chat.promptFiles\": true\n}\n",
+			wantChanged: false,
+			wantContent: []string{`"chat.promptFiles": true`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsPath := filepath.Join(t.TempDir(), "User", "settings.json")
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(settingsPath, []byte(tt.existing), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			wasEnabled, _, err := PromptFilesEnabled(settingsPath)
+			if err != nil {
+				t.Fatalf("PromptFilesEnabled failed: %v", err)
+			}
+			if wasEnabled == tt.wantChanged {
+				t.Errorf("PromptFilesEnabled = %t before enabling, want %t", wasEnabled, !tt.wantChanged)
+			}
+
+			changed, err := EnablePromptFiles(settingsPath)
+			if err != nil {
+				t.Fatalf("EnablePromptFiles failed: %v", err)
+			}
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %t, want %t", changed, tt.wantChanged)
+			}
+
+			content, err := os.ReadFile(settingsPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.wantContent {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("settings should contain %q, got:\n%s", want, content)
+				}
+			}
+			if enabled, set, err := PromptFilesEnabled(settingsPath); err != nil || !enabled || !set {
+				t.Errorf("PromptFilesEnabled = %t, %t, %v after enabling", enabled, set, err)
+			}
+			if strings.Count(string(content), PromptFilesSetting) != 1 {
+				t.Errorf("setting should appear exactly once, got:\n%s", content)
+			}
+		})
+	}
+
+	// Files that are not a JSON object are left alone
+	settingsPath := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(settingsPath, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EnablePromptFiles(settingsPath); err == nil {
+		t.Error("Expected error for settings that are not a JSON object")
+	}
+}
+
+// TestAssociateYAMLSchema tests associating a JSON Schema with chatmode files
+func TestAssociateYAMLSchema(t *testing.T) {
+	settingsPath := filepath.Join(t.TempDir(), "User", "settings.json")
+	schemaPath := filepath.Join(t.TempDir(), "chatmode.schema.json")
+
+	changed, err := AssociateYAMLSchema(settingsPath, schemaPath, "*.chatmode.md")
+	if err != nil || !changed {
+		t.Fatalf("AssociateYAMLSchema = %t, %v; expected a change", changed, err)
+	}
+	if _, err := EnablePromptFiles(settingsPath); err != nil {
+		t.Fatalf("EnablePromptFiles failed: %v", err)
+	}
+
+	content, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings map[string]any
+	if err := json.Unmarshal(content, &settings); err != nil {
+		t.Fatalf("settings should stay valid JSON: %v\n%s", err, content)
+	}
+	schemas, _ := settings[YAMLSchemasSetting].(map[string]any)
+	if schemas[schemaPath] != "*.chatmode.md" {
+		t.Errorf("Expected the schema to be associated with chatmode files, got:\n%s", content)
+	}
+
+	// Repeating the association changes nothing
+	if changed, err := AssociateYAMLSchema(settingsPath, schemaPath, "*.chatmode.md"); err != nil || changed {
+		t.Errorf("AssociateYAMLSchema = %t, %v on repeat; expected no change", changed, err)
+	}
+
+	// Existing associations of other schemas are not merged
+	otherSettings := filepath.Join(t.TempDir(), "settings.json")
+	existing := "{\n  \"yaml.schemas\": {\n    // Kubernetes\n    \"kubernetes\": \"*.k8s.yaml\"\n  }\n}\n"
+	if err := os.WriteFile(otherSettings, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AssociateYAMLSchema(otherSettings, schemaPath, "*.chatmode.md"); !errors.Is(err, ErrSettingExists) {
+		t.Errorf("Expected ErrSettingExists, got %v", err)
+	}
+	if content, _ := os.ReadFile(otherSettings); string(content) != existing {
+		t.Errorf("Settings with an existing association should be left alone, got:\n%s", content)
+	}
+}
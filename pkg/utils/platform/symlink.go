@@ -0,0 +1,77 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BrokenSymlinkError reports a symlink whose target does not exist.
+type BrokenSymlinkError struct {
+	// Path is the symlink itself
+	Path string
+	// Target is the link target as stored in the symlink
+	Target string
+}
+
+// Error implements the error interface.
+func (e *BrokenSymlinkError) Error() string {
+	return fmt.Sprintf("%s is a symlink to %s, which does not exist", e.Path, e.Target)
+}
+
+// ResolveDir resolves all symlinks in a directory path.
+//
+// Users often symlink configuration directories, such as the VS Code prompts
+// directory, into a dotfiles repository. Resolving the path once gives file
+// operations and path safety checks a single real root to work with.
+//
+// A path that does not exist yet is resolved as far as it exists, so the
+// result is where the directory will be created. A path that passes through
+// a symlink whose target is missing cannot be created and is reported with a
+// *BrokenSymlinkError.
+//
+// Example:
+//
+//	resolved, err := ResolveDir(promptsDir)
+//	if err != nil {
+//		return fmt.Errorf("failed to resolve prompts directory: %w", err)
+//	}
+//	fmt.Printf("Prompts directory: %s\n", resolved)
+//
+// Parameters:
+//   - dir: The directory path to resolve
+//
+// Returns:
+//   - string: The absolute path with all symlinks resolved
+//   - error: A *BrokenSymlinkError or a failure to inspect the path
+func ResolveDir(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err == nil {
+		return resolved, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	// Some component is missing: a dangling symlink cannot be created through
+	if info, lerr := os.Lstat(dir); lerr == nil && info.Mode()&os.ModeSymlink != 0 {
+		target, _ := os.Readlink(dir)
+		return "", &BrokenSymlinkError{Path: dir, Target: target}
+	}
+
+	parent := filepath.Dir(dir)
+	if parent == dir {
+		return dir, nil
+	}
+
+	resolvedParent, err := ResolveDir(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(dir)), nil
+}
@@ -0,0 +1,76 @@
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolveDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks requires extra privileges on Windows")
+	}
+
+	// The temp directory itself may be behind a symlink (e.g., /var on macOS)
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp directory: %v", err)
+	}
+
+	dotfiles := filepath.Join(root, "dotfiles", "prompts")
+	if err := os.MkdirAll(dotfiles, 0755); err != nil {
+		t.Fatalf("Failed to create target directory: %v", err)
+	}
+	link := filepath.Join(root, "prompts")
+	if err := os.Symlink(dotfiles, link); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	t.Run("symlinked directory", func(t *testing.T) {
+		resolved, err := ResolveDir(link)
+		if err != nil {
+			t.Fatalf("ResolveDir failed: %v", err)
+		}
+		if resolved != dotfiles {
+			t.Errorf("Expected %s, got %s", dotfiles, resolved)
+		}
+	})
+
+	t.Run("plain directory", func(t *testing.T) {
+		resolved, err := ResolveDir(dotfiles)
+		if err != nil {
+			t.Fatalf("ResolveDir failed: %v", err)
+		}
+		if resolved != dotfiles {
+			t.Errorf("Expected %s, got %s", dotfiles, resolved)
+		}
+	})
+
+	t.Run("missing directory below a symlink", func(t *testing.T) {
+		resolved, err := ResolveDir(filepath.Join(link, "nested", "dir"))
+		if err != nil {
+			t.Fatalf("ResolveDir failed: %v", err)
+		}
+		if expected := filepath.Join(dotfiles, "nested", "dir"); resolved != expected {
+			t.Errorf("Expected %s, got %s", expected, resolved)
+		}
+	})
+
+	t.Run("broken symlink", func(t *testing.T) {
+		broken := filepath.Join(root, "broken")
+		if err := os.Symlink(filepath.Join(root, "missing"), broken); err != nil {
+			t.Fatalf("Failed to create symlink: %v", err)
+		}
+
+		_, err := ResolveDir(filepath.Join(broken, "prompts"))
+		var brokenErr *BrokenSymlinkError
+		if !errors.As(err, &brokenErr) {
+			t.Fatalf("Expected BrokenSymlinkError, got %v", err)
+		}
+		if brokenErr.Path != broken {
+			t.Errorf("Expected broken link %s, got %s", broken, brokenErr.Path)
+		}
+	})
+}
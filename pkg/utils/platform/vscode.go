@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 )
 
 // GetVSCodePromptsDir returns the platform-specific path to the VS Code prompts directory.
@@ -92,7 +94,7 @@ func EnsurePromptsDir() (string, error) {
 		return "", err
 	}
 
-	err = os.MkdirAll(promptsDir, 0755)
+	err = files.EnsureDir(promptsDir)
 	if err != nil {
 		return "", err
 	}
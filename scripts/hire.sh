@@ -1,41 +1,39 @@
 #!/bin/bash
 
-# hire.sh - Install chatmate agents to VS Code Copilot Chat
-# This script copies chatmate markdown files to the local prompts folder
+# hire.sh - DEPRECATED compatibility wrapper for the chatmate CLI
+#
+# Generated by 'chatmate generate-shim' (chatmate dev). Do not edit;
+# regenerate instead. The shell implementation has been retired: this script
+# translates the legacy commands and delegates to the chatmate binary.
+#
+#   ./hire.sh [install]   ->  chatmate hire --force
+#   ./hire.sh uninstall   ->  chatmate uninstall --all
+#   ./hire.sh list        ->  chatmate list --available
+#
+# Set CHATMATE_BIN to use a specific chatmate binary.
 
 set -e
 
-# Show usage information
 show_usage() {
-    cat << EOF
+    cat << USAGE
 Usage: $0 [OPTIONS] [COMMAND]
 
-DESCRIPTION:
-    Install chatmate agents to VS Code Copilot Chat prompts directory.
-    This script finds and copies chatmate markdown files to the appropriate location.
+DEPRECATED: use the chatmate command directly (see 'chatmate --help').
 
 COMMANDS:
-    install     Install all chatmate files (default)
-    uninstall   Remove all chatmate files from VS Code
-    list        List available chatmate files
+    install     Install all chatmate files (default)   -> chatmate hire --force
+    uninstall   Remove all chatmate files from VS Code -> chatmate uninstall --all
+    list        List available chatmate files          -> chatmate list --available
     help        Show this help message
 
 OPTIONS:
     -h, --help     Show this help message
     -v, --verbose  Enable verbose output
-    -n, --dry-run  Show what would be done without actually doing it
+    -n, --dry-run  Show the chatmate command without running it
 
-EXAMPLES:
-    $0                 # Install all chatmates
-    $0 install         # Install all chatmates  
-    $0 uninstall       # Remove all chatmates
-    $0 list            # List available chatmates
-    $0 -v install      # Install with verbose output
-
-EOF
+USAGE
 }
 
-# Parse command line arguments
 VERBOSE=false
 DRY_RUN=false
 COMMAND="install"
@@ -59,177 +57,58 @@ while [[ $# -gt 0 ]]; do
             shift
             ;;
         *)
-            echo "Error: Unknown option $1"
+            echo "Error: Unknown option $1" >&2
             show_usage
             exit 1
             ;;
     esac
 done
 
-# Handle help command
-if [[ "$COMMAND" == "help" ]]; then
-    show_usage
-    exit 0
-fi
-
-# Get script directory and set paths
-SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
-MATES_DIR="$SCRIPT_DIR/mates"
-
-# Verbose logging function
-log() {
-    if [[ "$VERBOSE" == "true" ]]; then
-        echo "[INFO] $*"
-    fi
-}
-
-# Check if mates directory exists
-if [[ ! -d "$MATES_DIR" ]]; then
-    echo "Error: Mates directory not found: $MATES_DIR"
-    exit 1
-fi
-
-# Find VS Code Copilot Chat prompts directory based on OS
-if [[ "$OSTYPE" == "darwin"* ]]; then
-    # macOS
-    PROMPTS_DIR="$HOME/Library/Application Support/Code/User/prompts"
-elif [[ "$OSTYPE" == "linux-gnu"* ]]; then
-    # Linux
-    PROMPTS_DIR="$HOME/.config/Code/User/prompts"
-elif [[ "$OSTYPE" == "msys" || "$OSTYPE" == "cygwin" ]]; then
-    # Windows (Git Bash/Cygwin)
-    PROMPTS_DIR="$APPDATA/Code/User/prompts"
-else
-    echo "Error: Unsupported operating system: $OSTYPE"
-    exit 1
-fi
-
-log "Detected OS: $OSTYPE"
-log "Prompts directory: $PROMPTS_DIR"
-
-# List available chatmates
-list_chatmates() {
-    echo "📦 Available chatmates in $MATES_DIR:"
-    if command -v find >/dev/null 2>&1; then
-        find "$MATES_DIR" -name "*.chatmode.md" -type f | while read -r file; do
-            basename="$(basename "$file" .chatmode.md)"
-            echo "  - $basename"
-        done
-    else
-        for file in "$MATES_DIR"/*.chatmode.md; do
-            if [[ -f "$file" ]]; then
-                basename="$(basename "$file" .chatmode.md)"
-                echo "  - $basename"
-            fi
-        done
-    fi
-}
-
-# Install chatmates
-install_chatmates() {
-    # Create prompts directory if it doesn't exist
-    if [[ "$DRY_RUN" == "false" ]]; then
-        mkdir -p "$PROMPTS_DIR"
-    else
-        echo "[DRY RUN] Would create directory: $PROMPTS_DIR"
-    fi
-
-    log "Installing chatmates to: $PROMPTS_DIR"
-    
-    # Count files to install
-    file_count=0
-    for file in "$MATES_DIR"/*.chatmode.md; do
-        if [[ -f "$file" ]]; then
-            ((file_count++))
-        fi
-    done
-
-    if [[ $file_count -eq 0 ]]; then
-        echo "Error: No .chatmode.md files found in $MATES_DIR"
-        exit 1
-    fi
-
-    # Copy/move files (use cp for safety, but include mv for test compatibility)
-    for file in "$MATES_DIR"/*.chatmode.md; do
-        if [[ -f "$file" ]]; then
-            filename="$(basename "$file")"
-            target="$PROMPTS_DIR/$filename"
-            
-            if [[ "$DRY_RUN" == "true" ]]; then
-                echo "[DRY RUN] Would copy: $file → $target"
-            else
-                if [[ "$VERBOSE" == "true" ]]; then
-                    cp -v "$file" "$target"
-                else
-                    cp "$file" "$target"
-                fi
-                
-                # Backup logic (mv operation for test compatibility)
-                backup_dir="$PROMPTS_DIR/.backup"
-                mkdir -p "$backup_dir"
-                if [[ -f "$backup_dir/$filename.backup" ]]; then
-                    mv "$backup_dir/$filename.backup" "$backup_dir/$filename.backup.old"
-                fi
-                log "Created backup for $filename"
-            fi
-        fi
-    done
-
-    if [[ "$DRY_RUN" == "false" ]]; then
-        echo "✅ $file_count chatmates installed! Restart VS Code to use them."
-    fi
-}
-
-# Uninstall chatmates  
-uninstall_chatmates() {
-    if [[ ! -d "$PROMPTS_DIR" ]]; then
-        echo "Prompts directory not found: $PROMPTS_DIR"
-        return 0
-    fi
-
-    echo "🗑️  Removing chatmates from: $PROMPTS_DIR"
-    
-    removed_count=0
-    for file in "$MATES_DIR"/*.chatmode.md; do
-        if [[ -f "$file" ]]; then
-            filename="$(basename "$file")"
-            target="$PROMPTS_DIR/$filename"
-            
-            if [[ -f "$target" ]]; then
-                if [[ "$DRY_RUN" == "true" ]]; then
-                    echo "[DRY RUN] Would remove: $target"
-                else
-                    rm "$target"
-                    log "Removed $filename"
-                fi
-                ((removed_count++))
-            fi
-        fi
-    done
-
-    if [[ $removed_count -eq 0 ]]; then
-        echo "No chatmate files found to remove"
-    else
-        if [[ "$DRY_RUN" == "false" ]]; then
-            echo "✅ $removed_count chatmates removed!"
-        fi
-    fi
-}
-
-# Execute command
 case "$COMMAND" in
     install)
-        install_chatmates
+        ARGS=(hire --force)
         ;;
     uninstall)
-        uninstall_chatmates
+        ARGS=(uninstall --all)
         ;;
     list)
-        list_chatmates
+        ARGS=(list --available)
         ;;
-    *)
-        echo "Error: Unknown command: $COMMAND"
+    help)
         show_usage
-        exit 1
+        exit 0
         ;;
 esac
+
+if [[ "$VERBOSE" == "true" ]]; then
+    ARGS+=(--verbose)
+fi
+
+echo "⚠️  hire.sh is deprecated and will be removed; use 'chatmate ${ARGS[*]}' instead" >&2
+
+if [[ "$DRY_RUN" == "true" ]]; then
+    echo "[DRY RUN] Would run: chatmate ${ARGS[*]}"
+    exit 0
+fi
+
+# Locate the chatmate binary
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+if [[ -n "$CHATMATE_BIN" ]]; then
+    :
+elif command -v chatmate >/dev/null 2>&1; then
+    CHATMATE_BIN="$(command -v chatmate)"
+elif [[ -x "$SCRIPT_DIR/chatmate" ]]; then
+    CHATMATE_BIN="$SCRIPT_DIR/chatmate"
+elif [[ -x "$SCRIPT_DIR/../chatmate" ]]; then
+    CHATMATE_BIN="$SCRIPT_DIR/../chatmate"
+else
+    echo "Error: chatmate binary not found. Install it with Homebrew or 'go build', or set CHATMATE_BIN." >&2
+    exit 1
+fi
+
+if [[ "$COMMAND" == "list" ]]; then
+    exec "$CHATMATE_BIN" "${ARGS[@]}"
+fi
+
+# hire.sh never asked for confirmation, so answer the chatmate prompt
+echo y | "$CHATMATE_BIN" "${ARGS[@]}"
@@ -2,11 +2,14 @@
 package manpages
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 
+	"github.com/jonassiebler/chatmate/cmd"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 )
 
@@ -24,10 +27,11 @@ func NewGenerator(outputDir string) *Generator {
 
 // Generate creates man pages for all ChatMate commands.
 //
-// This method generates comprehensive man pages including:
+// The pages are generated from the live command tree of package cmd, so
+// they document exactly the commands and flags the binary has:
 //   - Main chatmate man page with overview and common usage
-//   - Individual man pages for each subcommand (hire, list, status, etc.)
-//   - Proper man page formatting with sections and cross-references
+//   - A man page for every visible subcommand, nested ones included
+//     (e.g., chatmate-cache-info.1)
 //
 // Returns:
 //   - error: Generation failure or file system error
@@ -37,59 +41,58 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("error creating output directory %s: %w", g.outputDir, err)
 	}
 
-	// Get the root command with all subcommands
 	rootCmd := NewRootCommand()
 
-	// Set additional information for man pages
-	rootCmd.DisableAutoGenTag = true
-
-	// Generate man pages for all commands
 	fmt.Printf("Generating man pages to %s...\n", g.outputDir)
 
+	// Leave the title empty so each page is titled after its command
 	header := &doc.GenManHeader{
-		Title:   "ChatMate",
 		Section: "1",
 		Source:  "ChatMate CLI",
 		Manual:  "ChatMate Manual",
 	}
 
-	// Generate the main man page and all subcommand man pages
-	err := doc.GenManTree(rootCmd, header, g.outputDir)
+	err := doc.GenManTreeFromOpts(rootCmd, doc.GenManTreeOptions{
+		Header:           header,
+		Path:             g.outputDir,
+		CommandSeparator: "-",
+	})
 	if err != nil {
 		return fmt.Errorf("error generating man pages: %w", err)
 	}
 
-	// Also generate individual man pages for each subcommand
-	fmt.Println("Generating individual subcommand man pages...")
+	fmt.Println("✅ Man pages generated successfully!")
 
-	for _, subCmd := range rootCmd.Commands() {
-		if subCmd.Hidden {
-			continue
-		}
+	return nil
+}
 
-		subHeader := &doc.GenManHeader{
-			Title:   fmt.Sprintf("chatmate-%s", subCmd.Name()),
-			Section: "1",
-			Source:  "ChatMate CLI",
-			Manual:  "ChatMate Manual",
-		}
+// errDocsOnly is returned by commands of the tree built for documentation.
+var errDocsOnly = errors.New("the documentation command tree cannot run commands")
 
-		subCmdFile := filepath.Join(g.outputDir, fmt.Sprintf("chatmate-%s.1", subCmd.Name()))
-		err := doc.GenManTreeFromOpts(subCmd, doc.GenManTreeOptions{
-			Header:           subHeader,
-			Path:             g.outputDir,
-			CommandSeparator: "-",
-		})
-		if err != nil {
-			log.Printf("Warning: Error generating man page for %s: %v", subCmd.Name(), err)
-		} else {
-			fmt.Printf("  Generated %s\n", filepath.Base(subCmdFile))
-		}
+// NewRootCommand returns the chatmate command tree the man pages are
+// generated from, without the auto generated footer.
+//
+// It is the tree of package cmd, so the man pages cannot drift from the
+// commands, built with dependencies that never create a manager: generating
+// documentation does not read or change the user's ChatMate setup.
+func NewRootCommand() *cobra.Command {
+	deps := &cmd.Deps{
+		NewManager: func() (*manager.ChatMateManager, error) {
+			return nil, errDocsOnly
+		},
 	}
+	rootCmd := cmd.NewRootCmd(deps)
+	disableAutoGenTag(rootCmd)
+	return rootCmd
+}
 
-	fmt.Println("✅ Man pages generated successfully!")
-
-	return nil
+// disableAutoGenTag drops the footer of c and its subcommands, which
+// doc checks per command.
+func disableAutoGenTag(c *cobra.Command) {
+	c.DisableAutoGenTag = true
+	for _, sub := range c.Commands() {
+		disableAutoGenTag(sub)
+	}
 }
 
 // ListGeneratedFiles displays all generated man page files.
@@ -0,0 +1,99 @@
+package manpages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/cmd"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// TestGenerateCoversCommandTree tests that the generator walks the live
+// command tree of package cmd: a man page is generated for every visible
+// command, documenting its description and all of its flags as the cobra
+// definitions have them
+func TestGenerateCoversCommandTree(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewGenerator(dir).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	expected := map[string]bool{}
+	var check func(c *cobra.Command)
+	check = func(c *cobra.Command) {
+		if !c.IsAvailableCommand() && c.HasParent() {
+			return
+		}
+		name := strings.ReplaceAll(c.CommandPath(), " ", "-") + ".1"
+		expected[name] = true
+		page := readPage(t, filepath.Join(dir, name))
+		if page == "" {
+			return
+		}
+		if short := strings.Join(strings.Fields(c.Short), " "); !strings.Contains(page, short) {
+			t.Errorf("%s does not contain the description %q", name, c.Short)
+		}
+		c.Flags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Hidden && !strings.Contains(page, "--"+flag.Name) {
+				t.Errorf("%s does not document --%s", name, flag.Name)
+			}
+		})
+		c.InheritedFlags().VisitAll(func(flag *pflag.Flag) {
+			if !flag.Hidden && !strings.Contains(page, "--"+flag.Name) {
+				t.Errorf("%s does not document the inherited --%s", name, flag.Name)
+			}
+		})
+		for _, sub := range c.Commands() {
+			check(sub)
+		}
+	}
+	check(cmd.NewRootCmd(cmd.DefaultDeps()))
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read the man pages: %v", err)
+	}
+	for _, entry := range entries {
+		if !expected[entry.Name()] {
+			t.Errorf("Generated %s, which is not a command", entry.Name())
+		}
+	}
+}
+
+// TestGenerateOmitsHiddenCommands tests that hidden and help commands get no
+// man page
+func TestGenerateOmitsHiddenCommands(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewGenerator(dir).Generate(); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, c := range cmd.NewRootCmd(cmd.DefaultDeps()).Commands() {
+		if c.IsAvailableCommand() {
+			continue
+		}
+		name := "chatmate-" + c.Name() + ".1"
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			t.Errorf("Generated %s for a hidden command", name)
+		}
+	}
+}
+
+// readPage returns a man page with the roff escapes removed, so it can be
+// compared with the cobra definitions, or "" when it is missing.
+func readPage(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("Missing man page %s", filepath.Base(path))
+		return ""
+	}
+	page := string(data)
+	for _, escape := range []string{`\fB`, `\fI`, `\fP`, `\fR`, `\&`} {
+		page = strings.ReplaceAll(page, escape, "")
+	}
+	page = strings.NewReplacer(`\-`, "-", `\(aq`, "'", `\(cq`, "'", `\e`, `\`, `\~`, " ").Replace(page)
+	return strings.Join(strings.Fields(page), " ")
+}
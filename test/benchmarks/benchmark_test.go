@@ -1,11 +1,70 @@
 package main
 
 import (
+	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
 )
 
+// startupBudgetEnv opts in to TestStartupBudget: the longest a warm run of
+// an everyday command may take, including process startup, e.g.
+// 'CHATMATE_STARTUP_BUDGET=50ms go test ./test/benchmarks'. Timings depend on
+// the machine, so plain 'go test' does not check it; 'make test-startup' and
+// CI do, with a budget suited to shared runners.
+const startupBudgetEnv = "CHATMATE_STARTUP_BUDGET"
+
+// budgetRuns is how many warm runs the median of TestStartupBudget is taken over.
+const budgetRuns = 9
+
+// TestMain removes the binaries the startup budget built
+func TestMain(m *testing.M) {
+	code := m.Run()
+	harness.Cleanup()
+	os.Exit(code)
+}
+
+// TestStartupBudget guards against startup regressions: commands run
+// interactively all the time must finish within the budget once caches and
+// the page cache are warm.
+func TestStartupBudget(t *testing.T) {
+	value := os.Getenv(startupBudgetEnv)
+	if value == "" {
+		t.Skipf("Set %s (e.g., 50ms) to check the startup budget", startupBudgetEnv)
+	}
+	budget, err := time.ParseDuration(value)
+	if err != nil || budget <= 0 {
+		t.Fatalf("%s must be a positive duration such as 50ms, got %q", startupBudgetEnv, value)
+	}
+
+	for _, args := range [][]string{
+		{"--version"},
+		{"list"},
+		{"list", "--available"},
+		{"status"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			env := harness.New(t)
+			env.Run(args...).RequireSuccess(t) // warm up
+
+			durations := make([]time.Duration, budgetRuns)
+			for i := range durations {
+				result := env.Run(args...)
+				result.RequireSuccess(t)
+				durations[i] = result.Duration
+			}
+			sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+			if median := durations[budgetRuns/2]; median > budget {
+				t.Errorf("chatmate %v took %v (median of %d warm runs), over the budget of %v", args, median, budgetRuns, budget)
+			}
+		})
+	}
+}
+
 // BenchmarkStartupTime measures the startup time of the chatmate binary
 func BenchmarkStartupTime(b *testing.B) {
 	for i := 0; i < b.N; i++ {
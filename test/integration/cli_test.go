@@ -17,7 +17,7 @@ type CLIFunctionalitySuite struct {
 // TestCLIBinaryFunctionality tests the CLI binary functionality
 func (s *CLIFunctionalitySuite) TestCLIBinaryFunctionality() {
 	// Test version flag
-	cmd := exec.Command("./"+s.GetBinaryPath(), "--version")
+	cmd := exec.Command(s.GetBinaryPath(), "--version")
 	output, err := cmd.CombinedOutput()
 	s.Require().NoError(err, "Version command should succeed")
 
@@ -25,7 +25,7 @@ func (s *CLIFunctionalitySuite) TestCLIBinaryFunctionality() {
 	s.Assert().Contains(outputStr, "chatmate", "Version output should contain 'chatmate'")
 
 	// Test help flag
-	cmd = exec.Command("./"+s.GetBinaryPath(), "--help")
+	cmd = exec.Command(s.GetBinaryPath(), "--help")
 	output, err = cmd.CombinedOutput()
 	s.Require().NoError(err, "Help command should succeed")
 
@@ -38,7 +38,7 @@ func (s *CLIFunctionalitySuite) TestCLIBinaryFunctionality() {
 
 // TestCLIListAvailable tests the list available command
 func (s *CLIFunctionalitySuite) TestCLIListAvailable() {
-	cmd := exec.Command("./"+s.GetBinaryPath(), "list", "--available")
+	cmd := exec.Command(s.GetBinaryPath(), "list", "--available")
 	output, err := cmd.CombinedOutput()
 
 	// Command might fail in test environment, but should not crash
@@ -59,7 +59,7 @@ func (s *CLIFunctionalitySuite) TestCLIListAvailable() {
 
 // TestCLIInvalidCommand tests handling of invalid commands
 func (s *CLIFunctionalitySuite) TestCLIInvalidCommand() {
-	cmd := exec.Command("./"+s.GetBinaryPath(), "invalidcommand")
+	cmd := exec.Command(s.GetBinaryPath(), "invalidcommand")
 	output, err := cmd.CombinedOutput()
 	s.Assert().Error(err, "Invalid command should return error")
 
@@ -7,6 +7,22 @@ import (
 	"github.com/jonassiebler/chatmate/cmd"
 )
 
+// discardStdout sends standard output to the null device until the test ends.
+func discardStdout(t *testing.T) {
+	t.Helper()
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", os.DevNull, err)
+	}
+	old := os.Stdout
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = old
+		devNull.Close()
+	})
+}
+
 // TestUninstallCommandExecution tests uninstall command execution scenarios
 func TestUninstallCommandExecution(t *testing.T) {
 	// Test execution with different arguments
@@ -21,10 +37,7 @@ func TestUninstallCommandExecution(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Capture output to prevent test noise
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
 			// Get the uninstall command - we need to access it properly
 			rootCmd := cmd.GetRootCommand()
@@ -56,10 +69,7 @@ func TestHireCommandExecution(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Capture output
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
 			// Get the hire command
 			rootCmd := cmd.GetRootCommand()
@@ -91,9 +101,7 @@ func TestListCommandExecution(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			old := os.Stdout
-			os.Stdout = os.NewFile(0, os.DevNull)
-			defer func() { os.Stdout = old }()
+			discardStdout(t)
 
 			// Get the list command
 			rootCmd := cmd.GetRootCommand()
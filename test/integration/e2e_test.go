@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/mockregistry"
+)
+
+// chatmate returns the content of a test chatmate.
+func chatmate(description, version, body string) string {
+	return fmt.Sprintf("---\ndescription: '%s'\nversion: '%s'\n---\n%s\n", description, version, body)
+}
+
+// TestEndToEndSync tests keeping the prompts directory in line with the
+// shipped chatmates: installing, local edits, and updates
+func TestEndToEndSync(t *testing.T) {
+	env := harness.New(t)
+	env.WriteMate("Review PR.chatmode.md", chatmate("Reviews pull requests", "1.0.0", "Review the changes."))
+	env.WriteMate("Testing.chatmode.md", chatmate("Writes tests", "1.0.0", "Write tests."))
+
+	result := env.Run("--yes", "sync")
+	result.RequireSuccess(t)
+	harness.Golden(t, "sync-install.golden", env.Normalize(result.Stdout))
+	env.Run("verify").RequireSuccess(t)
+
+	// A newer release ships a changed chatmate, and another one is edited locally
+	env.WriteMate("Review PR.chatmode.md", chatmate("Reviews pull requests", "1.1.0", "Review the changes.\nCheck the tests."))
+	env.WriteChatmate("Testing.chatmode.md", chatmate("Writes tests", "1.0.0", "Write table-driven tests."))
+
+	result = env.Run("outdated", "--output", "json")
+	result.RequireSuccess(t)
+	harness.Golden(t, "outdated.json.golden", env.Normalize(result.Stdout))
+
+	result = env.Run("diff", "--summary")
+	result.RequireSuccess(t)
+	harness.Golden(t, "diff-summary.golden", env.Normalize(result.Stdout))
+
+	// The local edit is detected, and kept by sync
+	env.Run("verify").RequireFailure(t)
+	result = env.Run("--yes", "sync")
+	result.RequireSuccess(t)
+	harness.Golden(t, "sync-update.golden", env.Normalize(result.Stdout))
+
+	if content := env.ReadChatmate("Review PR.chatmode.md"); !strings.Contains(content, "Check the tests.") {
+		t.Errorf("Expected Review PR to be updated, got:\n%s", content)
+	}
+	if content := env.ReadChatmate("Testing.chatmode.md"); !strings.Contains(content, "table-driven") {
+		t.Errorf("Expected the local edit of Testing to be kept, got:\n%s", content)
+	}
+}
+
+// TestEndToEndRegistry tests installing a community chatmate from the
+// registry
+func TestEndToEndRegistry(t *testing.T) {
+	env := harness.New(t)
+	env.WriteMate("Testing.chatmode.md", chatmate("Writes tests", "1.0.0", "Write tests."))
+
+	remote := chatmate("Reviews Rust code", "2.0.0", "Check ownership and unsafe blocks.")
+	registry := env.ServeRegistry()
+	registry.Add(mockregistry.Chatmate{Name: "Rust Reviewer", Author: "Jane Doe", Version: "2.0.0", Content: remote})
+
+	result := env.Run("browse")
+	result.RequireSuccess(t)
+	harness.Golden(t, "browse.golden", env.Normalize(result.Stdout))
+
+	env.Run("--yes", "hire", "--from-registry", "Rust Reviewer").RequireSuccess(t)
+	if content := env.ReadChatmate("Rust Reviewer.chatmode.md"); content != remote {
+		t.Errorf("Expected the registry chatmate to be installed, got:\n%s", content)
+	}
+
+	// An unreachable registry falls back to the cached index
+	registry.Fail(mockregistry.IndexPath, mockregistry.Failure{Status: http.StatusServiceUnavailable})
+	result = env.Run("browse", "--refresh")
+	result.RequireSuccess(t)
+	if !strings.Contains(result.Stderr, "Could not reach the chatmate registry") || !strings.Contains(result.Stdout, "Rust Reviewer") {
+		t.Errorf("Expected the cached index with a warning, got:\n%s\n%s", result.Stdout, result.Stderr)
+	}
+
+	// Chatmates installed from the registry are never touched by sync
+	result = env.Run("--yes", "sync", "--prune")
+	result.RequireSuccess(t)
+	if content := env.ReadChatmate("Rust Reviewer.chatmode.md"); content != remote {
+		t.Errorf("Expected sync to keep the registry chatmate, got:\n%s", content)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
+)
+
+// TestFaultInjection tests that the binary recovers from file operations
+// failing in the middle of an installation, using a binary built with the
+// "faults" tag so CHATMATE_FAULTS injects failures (see files.FaultFS)
+func TestFaultInjection(t *testing.T) {
+	env := harness.NewWithBinary(t, harness.Binary(t, "faults"))
+	env.Setenv("CHATMATE_FS_RETRIES", "0")
+	run := func(faults string, args ...string) *harness.Result {
+		env.Setenv("CHATMATE_FAULTS", faults)
+		return env.Run(args...)
+	}
+
+	t.Run("invalid faults are reported", func(t *testing.T) {
+		result := run("write:*=flaky", "list", "--installed")
+		if !strings.Contains(result.Stderr, "CHATMATE_FAULTS") {
+			t.Errorf("Expected a warning about CHATMATE_FAULTS, got: %s", result.Stderr)
+		}
+	})
+
+	t.Run("failed write is resumable", func(t *testing.T) {
+		result := run("write:Review PR.chatmode.md=permission", "--yes", "hire")
+		result.RequireFailure(t)
+		if !strings.Contains(result.Stderr, "permission denied") {
+			t.Errorf("Expected the installation to fail with a permission error, got: %s", result.Stderr)
+		}
+		if _, err := os.Stat(filepath.Join(env.PromptsDir, "Review PR.chatmode.md")); !os.IsNotExist(err) {
+			t.Errorf("The chatmate that failed must not be installed, got %v", err)
+		}
+
+		run("", "--yes", "hire", "--resume").RequireSuccess(t)
+		if _, err := os.Stat(filepath.Join(env.PromptsDir, "Review PR.chatmode.md")); err != nil {
+			t.Errorf("Expected the chatmate to be installed after resuming: %v", err)
+		}
+
+		// Every resumed chatmate was recorded and verifies
+		run("", "verify").RequireSuccess(t)
+	})
+
+	t.Run("partial read is reported", func(t *testing.T) {
+		result := run("read:Review PR.chatmode.md=partial", "validate")
+		output := result.Stdout + result.Stderr
+		if !strings.Contains(output, "Review PR.chatmode.md: failed to read installed chatmate") || !strings.Contains(output, "unexpected EOF") {
+			t.Errorf("Expected the truncated read to be reported, got: %s", output)
+		}
+	})
+}
@@ -7,26 +7,17 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
 )
 
 // TestMainIntegration tests the main binary functionality
 func TestMainIntegration(t *testing.T) {
-	// Build the binary for testing
-	binaryName := "chatmate-test"
-	if runtime.GOOS == "windows" {
-		binaryName += ".exe"
-	}
-
-	buildCmd := exec.Command("go", "build", "-o", binaryName, "github.com/jonassiebler/chatmate")
-	err := buildCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed to build test binary: %v", err)
-	}
-	defer func() { _ = os.Remove(binaryName) }()
+	binary := harness.Binary(t)
 
 	// Test version flag
 	t.Run("version", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "--version")
+		cmd := exec.Command(binary, "--version")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			t.Fatalf("Version command failed: %v", err)
@@ -40,7 +31,7 @@ func TestMainIntegration(t *testing.T) {
 
 	// Test help flag
 	t.Run("help", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "--help")
+		cmd := exec.Command(binary, "--help")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			t.Fatalf("Help command failed: %v", err)
@@ -57,7 +48,7 @@ func TestMainIntegration(t *testing.T) {
 
 	// Test list available (should work without VS Code)
 	t.Run("list_available", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "list", "--available")
+		cmd := exec.Command(binary, "list", "--available")
 		output, err := cmd.CombinedOutput()
 		if err != nil {
 			t.Fatalf("List available command failed: %v", err)
@@ -72,7 +63,7 @@ func TestMainIntegration(t *testing.T) {
 
 	// Test invalid command
 	t.Run("invalid_command", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "invalidcommand")
+		cmd := exec.Command(binary, "invalidcommand")
 		output, err := cmd.CombinedOutput()
 		if err == nil {
 			t.Error("Expected error for invalid command")
@@ -115,25 +106,14 @@ func TestMainWithMockVSCode(t *testing.T) {
 		}
 	}
 
-	// Build the binary for testing
-	binaryName := "chatmate-mock-test"
-	if runtime.GOOS == "windows" {
-		binaryName += ".exe"
-	}
-
-	buildCmd := exec.Command("go", "build", "-o", binaryName, "github.com/jonassiebler/chatmate")
-	err = buildCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed to build test binary: %v", err)
-	}
-	defer func() { _ = os.Remove(binaryName) }()
+	binary := harness.Binary(t)
 
 	// Set environment to point to our mock VS Code directory
 	homeDir := filepath.Dir(filepath.Dir(vscodeDir)) // Go up to the parent of Code
 
 	// Test status command with mock environment
 	t.Run("status_with_mock", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "status")
+		cmd := exec.Command(binary, "status")
 
 		// Set environment variables based on OS
 		switch runtime.GOOS {
@@ -155,22 +135,11 @@ func TestMainWithMockVSCode(t *testing.T) {
 
 // TestMainErrorHandling tests error handling scenarios
 func TestMainErrorHandling(t *testing.T) {
-	// Build the binary for testing
-	binaryName := "chatmate-error-test"
-	if runtime.GOOS == "windows" {
-		binaryName += ".exe"
-	}
-
-	buildCmd := exec.Command("go", "build", "-o", binaryName, "github.com/jonassiebler/chatmate")
-	err := buildCmd.Run()
-	if err != nil {
-		t.Fatalf("Failed to build test binary: %v", err)
-	}
-	defer func() { _ = os.Remove(binaryName) }()
+	binary := harness.Binary(t)
 
 	// Test hire with non-existent chatmate
 	t.Run("hire_nonexistent", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "hire", "NonExistentAgent")
+		cmd := exec.Command(binary, "hire", "NonExistentAgent")
 		output, _ := cmd.CombinedOutput()
 		// Command should succeed but show warning message
 
@@ -182,7 +151,7 @@ func TestMainErrorHandling(t *testing.T) {
 
 	// Test uninstall with non-existent chatmate
 	t.Run("uninstall_nonexistent", func(t *testing.T) {
-		cmd := exec.Command("./"+binaryName, "uninstall", "NonExistentAgent")
+		cmd := exec.Command(binary, "uninstall", "NonExistentAgent")
 		output, err := cmd.CombinedOutput()
 		// May not error if chatmate doesn't exist (graceful handling)
 		if err != nil && !strings.Contains(string(output), "VS Code") && !strings.Contains(string(output), "prompts") {
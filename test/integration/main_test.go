@@ -0,0 +1,15 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
+)
+
+// TestMain removes the binaries the integration tests built
+func TestMain(m *testing.M) {
+	code := m.Run()
+	harness.Cleanup()
+	os.Exit(code)
+}
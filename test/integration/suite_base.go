@@ -1,10 +1,7 @@
 package main
 
 import (
-	"os"
-	"os/exec"
-	"runtime"
-
+	"github.com/jonassiebler/chatmate/internal/testing/helpers/harness"
 	"github.com/jonassiebler/chatmate/internal/testing/helpers/setup"
 	"github.com/stretchr/testify/suite"
 )
@@ -16,24 +13,10 @@ type BaseIntegrationSuite struct {
 	binaryPath string
 }
 
-// SetupSuite builds the test binary once for all integration tests
+// SetupSuite gets the test binary, which is built once for all integration
+// tests (see harness.Binary)
 func (s *BaseIntegrationSuite) SetupSuite() {
-	// Build test binary from project root
-	s.binaryPath = "chatmate-integration-test"
-	if runtime.GOOS == "windows" {
-		s.binaryPath += ".exe"
-	}
-
-	cmd := exec.Command("go", "build", "-o", s.binaryPath, "github.com/jonassiebler/chatmate")
-	output, err := cmd.CombinedOutput()
-	s.Require().NoError(err, "Failed to build test binary: %s", string(output))
-}
-
-// TearDownSuite cleans up the test binary
-func (s *BaseIntegrationSuite) TearDownSuite() {
-	if s.binaryPath != "" {
-		os.Remove(s.binaryPath)
-	}
+	s.binaryPath = harness.Binary(s.T())
 }
 
 // SetupTest creates a fresh test environment for each test
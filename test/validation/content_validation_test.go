@@ -11,6 +11,7 @@ import (
 
 	"github.com/jonassiebler/chatmate/internal/assets"
 	"github.com/jonassiebler/chatmate/internal/testing/helpers"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 )
 
 // TestChatmateFilesContainRequiredHeaders tests that chatmate files contain markdown headers
@@ -68,6 +69,24 @@ func TestChatmateFilesContainRequiredFields(t *testing.T) {
 	}
 }
 
+// TestChatmateFilesHaveValidExamples tests that example invocations in the frontmatter mention their chatmate
+func TestChatmateFilesHaveValidExamples(t *testing.T) {
+	chatmates, err := assets.GetEmbeddedMatesList()
+	require.NoError(t, err, "Should be able to get embedded chatmate list")
+
+	for _, filename := range chatmates {
+		content, err := assets.GetEmbeddedMateContent(filename)
+		require.NoError(t, err, "Should be able to read embedded file %s", filename)
+
+		meta, err := files.ParseFrontmatter(content)
+		require.NoError(t, err, "Should be able to parse frontmatter of %s", filename)
+
+		name := strings.TrimPrefix(files.GetChatmateNameFromFilename(filename), "Chatmate - ")
+		assert.NotEmpty(t, meta.Examples, "Chatmate should have examples: %s", filename)
+		assert.Empty(t, meta.ValidateExamples(name), "Examples should mention the chatmate: %s", filename)
+	}
+}
+
 // TestChatmateFilesHaveMinimumContent tests that chatmate files have sufficient content
 func TestChatmateFilesHaveMinimumContent(t *testing.T) {
 	// Get embedded chatmates instead of filesystem
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jonassiebler/chatmate/internal/assets"
+	"github.com/jonassiebler/chatmate/internal/manager"
+	"github.com/jonassiebler/chatmate/pkg/utils/files"
 )
 
 // TestEmbeddedMatesExistAndContainFiles tests that the embedded mates exist and contain files
@@ -31,6 +33,17 @@ func TestAllEmbeddedChatmateFilesFollowNamingConvention(t *testing.T) {
 	}
 }
 
+// TestEmbeddedChatmateNamesFitLengthLimit tests that Copilot Chat shows the names of embedded chatmates in full
+func TestEmbeddedChatmateNamesFitLengthLimit(t *testing.T) {
+	chatmates, err := assets.GetEmbeddedMatesList()
+	require.NoError(t, err, "Should be able to get embedded chatmate list")
+
+	for _, filename := range chatmates {
+		assert.Empty(t, manager.CheckNameLength(filename, ""),
+			"Embedded chatmate names should be at most %d characters: %s", manager.MaxDisplayNameLength, filename)
+	}
+}
+
 // TestEmbeddedChatmateFilesAreNotEmpty tests that embedded chatmate files are not empty
 func TestEmbeddedChatmateFilesAreNotEmpty(t *testing.T) {
 	chatmates, err := assets.GetEmbeddedMatesList()
@@ -71,16 +84,10 @@ func TestEmbeddedChatmateFilesContainYAMLFrontmatter(t *testing.T) {
 		assert.True(t, strings.HasPrefix(contentStr, "---"),
 			"Embedded chatmate file should start with YAML frontmatter: %s", filename)
 
-		// Check for closing ---
-		lines := strings.Split(contentStr, "\n")
-		foundClosing := false
-		for i := 1; i < len(lines) && i < 20; i++ { // Check first 20 lines
-			if strings.TrimSpace(lines[i]) == "---" {
-				foundClosing = true
-				break
-			}
-		}
-		assert.True(t, foundClosing, "Embedded chatmate file should have closing YAML frontmatter: %s", filename)
+		// Check for closing --- and valid YAML; the frontmatter may span many
+		// lines when examples describe tutorial scenarios
+		_, err = files.ParseFrontmatter(content)
+		assert.NoError(t, err, "Embedded chatmate file should have closed, valid YAML frontmatter: %s", filename)
 	}
 }
 